@@ -43,6 +43,7 @@ import (
 	"time"
 
 	"github.com/ajbeattie/octobud/backend/internal/api/shared"
+	"github.com/ajbeattie/octobud/backend/internal/github"
 	"github.com/ajbeattie/octobud/backend/web"
 )
 
@@ -86,8 +87,13 @@ func main() {
 	fileServer := http.FileServer(http.FS(frontendFS))
 	mux.HandleFunc("/", spaHandler(frontendFS, fileServer))
 
-	// Wrap the entire handler with security headers middleware
+	// Wrap the entire handler with security headers middleware, allowing avatars from a
+	// GitHub Enterprise Server (GH_API_URL/GH_WEB_URL) if there is one
 	handler := shared.SecurityHeadersMiddleware(mux)
+	githubWebURL := github.NewEndpoints(os.Getenv("GH_API_URL"), os.Getenv("GH_WEB_URL")).WebURL
+	if githubWebURL != github.DefaultWebURL {
+		handler = shared.SecurityHeaders(githubWebURL)(mux)
+	}
 
 	server := &http.Server{
 		Addr:         *addr,
@@ -43,10 +43,12 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/api/shared"
 	apiuser "github.com/ajbeattie/octobud/backend/internal/api/user"
 	config "github.com/ajbeattie/octobud/backend/internal/config"
+	"github.com/ajbeattie/octobud/backend/internal/core/account"
 	authsvc "github.com/ajbeattie/octobud/backend/internal/core/auth"
 	"github.com/ajbeattie/octobud/backend/internal/core/syncstate"
 	store "github.com/ajbeattie/octobud/backend/internal/db"
 	"github.com/ajbeattie/octobud/backend/internal/github"
+	githubinterfaces "github.com/ajbeattie/octobud/backend/internal/github/interfaces"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
@@ -104,58 +106,69 @@ func main() {
 	}
 	log.Println("server: River client initialized for job queueing")
 
-	// Initialize GitHub client
-	githubClient := github.NewClient()
-	var apiHandler *api.Handler
-	tokenConfigured := false
+	// Initialize zap logger
+	logger, err := zap.NewProduction()
+	if err != nil {
+		logger = zap.NewNop()
+	}
 
-	// Try prompt first if requested
-	if *promptToken {
-		log.Println("server: prompting for GitHub token")
-		token, promptErr := promptGitHubToken()
-		if promptErr != nil {
-			log.Printf("server: prompt failed: %v", promptErr)
-			// Fall through to try GH_TOKEN if available
-		} else {
-			if setErr := githubClient.SetToken(ctx, token); setErr != nil {
-				log.Printf("server: failed to set prompted token: %v", setErr)
-				// Fall through to try GH_TOKEN if available
-			} else {
-				tokenConfigured = true
-				log.Println("server: GitHub token configured via prompt")
-			}
-		}
+	if *promptToken && len(cfg.GitHubAccounts) > 1 {
+		log.Fatalf("server: --prompt-token only supports a single GitHub account")
 	}
 
-	// If no token configured yet, try GH_TOKEN environment variable
-	if !tokenConfigured && cfg.GHToken != "" {
-		log.Println("server: using GitHub token from GH_TOKEN environment variable")
-		if setErr := githubClient.SetToken(ctx, cfg.GHToken); setErr != nil {
-			log.Fatalf("server: failed to set GitHub token: %v", setErr)
-		}
-		tokenConfigured = true
-		log.Println("server: GitHub token validated successfully")
+	// Each GitHub account with a token gets its own client and sync service, so subject
+	// refreshes and timelines use the account a notification was synced from. Security
+	// headers and the user API use the first account's endpoints.
+	accountSvc := account.NewService(queries)
+	githubEndpoints := github.NewEndpoints(
+		cfg.GitHubAccounts[0].APIURL,
+		cfg.GitHubAccounts[0].WebURL,
+	)
+	handlerOpts := []api.HandlerOption{
+		api.WithRiverClient(riverClient),
+		api.WithWebhookSecret(cfg.GitHubWebhookSecret),
 	}
+	tokenConfigured := false
+	for _, accountCfg := range cfg.GitHubAccounts {
+		// github.com unless the account's API URL points at an Enterprise Server
+		endpoints := github.NewEndpoints(accountCfg.APIURL, accountCfg.WebURL)
+		githubClient := github.NewClient(github.WithEndpoints(endpoints))
+		if !configureToken(ctx, githubClient, accountCfg, *promptToken) {
+			continue
+		}
 
-	// Initialize zap logger
-	logger, err := zap.NewProduction()
-	if err != nil {
-		logger = zap.NewNop()
+		registered, registerErr := accountSvc.RegisterAccount(
+			ctx,
+			accountCfg.Name,
+			endpoints.APIURL,
+			endpoints.WebURL,
+		)
+		if registerErr != nil {
+			log.Fatalf(
+				"server: failed to register GitHub account %q: %v",
+				accountCfg.Name,
+				registerErr,
+			)
+		}
+
+		handlerOpts = append(
+			handlerOpts,
+			api.WithSyncService(dbConn, registered.ID, githubClient, logger),
+		)
+		tokenConfigured = true
+		log.Printf("server: GitHub account %q (id %d) configured", registered.Name, registered.ID)
 	}
 
-	// Configure handler based on whether token is available
+	// Configure handler based on whether any token is available
 	if tokenConfigured {
-		apiHandler = api.NewHandler(queries,
-			api.WithSyncService(dbConn, githubClient, logger),
-			api.WithRiverClient(riverClient))
 		log.Println("server: GitHub client configured, refresh endpoint available")
 	} else {
 		fmt.Fprintln(os.Stderr, "Warning: No GitHub token configured")
 		fmt.Fprintln(os.Stderr, "The refresh endpoint will be unavailable.")
 		fmt.Fprintln(os.Stderr, "Set GH_TOKEN environment variable or use --prompt-token flag to enable it.")
 		log.Println("server: no GitHub token configured, refresh endpoint will be unavailable")
-		apiHandler = api.NewHandler(queries, api.WithRiverClient(riverClient))
 	}
+	apiHandler := api.NewHandler(queries, handlerOpts...)
 
 	router := chi.NewRouter()
 	router.Use(middleware.RequestID)
@@ -163,7 +176,11 @@ func main() {
 	router.Use(middleware.Recoverer)
 
 	// Add security headers to all responses
-	router.Use(shared.SecurityHeadersMiddleware)
+	if githubEndpoints.WebURL != github.DefaultWebURL {
+		router.Use(shared.SecurityHeaders(githubEndpoints.WebURL))
+	} else {
+		router.Use(shared.SecurityHeadersMiddleware)
+	}
 
 	// Limit request body size to prevent DoS attacks (1MB default)
 	router.Use(shared.BodyLimitMiddleware(shared.DefaultMaxBodySize))
@@ -201,36 +218,22 @@ func main() {
 		rateLimiter,
 		tokenRevocation,
 		secureCookies,
-	).WithRiverClient(riverClient).WithSyncStateService(syncStateSvc)
+	).WithRiverClient(riverClient).
+		WithSyncStateService(syncStateSvc).
+		WithAccountService(accountSvc).
+		WithGitHubEndpoints(githubEndpoints)
 
 	// Register API routes with auth middleware
-	router.Route("/api", func(r chi.Router) {
-		// Public user routes (login) - with rate limiting
-		r.Group(func(r chi.Router) {
-			r.Use(auth.RateLimitMiddleware(rateLimiter, logger))
-			r.Post("/user/login", userHandler.HandleLogin)
-		})
-
-		// Protected user routes
-		r.Group(func(r chi.Router) {
-			r.Use(auth.JWTMiddleware(cfg.JWTSecret, logger, tokenRevocation))
-			r.Use(auth.CSRFMiddleware(logger))
-			r.Get("/user/me", userHandler.HandleGetCurrentUser)
-			r.Post("/user/refresh", userHandler.HandleRefreshToken)
-			r.Post("/user/logout", userHandler.HandleLogout)
-			r.Put("/user/credentials", userHandler.HandleUpdateCredentials)
-			r.Get("/user/sync-settings", userHandler.HandleGetSyncSettings)
-			r.Put("/user/sync-settings", userHandler.HandleUpdateSyncSettings)
-			r.Get("/user/sync-state", userHandler.HandleGetSyncState)
-			r.Post("/user/sync-older", userHandler.HandleSyncOlder)
-		})
-
-		// All other API routes require auth and CSRF
-		r.Group(func(r chi.Router) {
-			r.Use(auth.JWTMiddleware(cfg.JWTSecret, logger, tokenRevocation))
-			r.Use(auth.CSRFMiddleware(logger))
-			apiHandler.Register(r)
-		})
+	if cfg.GitHubWebhookSecret != "" {
+		log.Println("server: GitHub webhook endpoint enabled at /api/webhooks/github")
+	}
+	registerAPIRoutes(router, apiRoutes{
+		logger:          logger,
+		jwtSecret:       cfg.JWTSecret,
+		rateLimiter:     rateLimiter,
+		tokenRevocation: tokenRevocation,
+		userHandler:     userHandler,
+		apiHandler:      apiHandler,
 	})
 
 	server := &http.Server{
@@ -268,6 +271,40 @@ func waitForShutdown(server *http.Server) {
 	log.Println("server: stopped")
 }
 
+// configureToken sets an account's GitHub token, prompting for it if asked to, and
+// reports whether the account has one. Exits if a token from the environment is invalid.
+func configureToken(
+	ctx context.Context,
+	githubClient githubinterfaces.Client,
+	accountCfg config.GitHubAccount,
+	prompt bool,
+) bool {
+	if prompt {
+		log.Println("server: prompting for GitHub token")
+		token, promptErr := promptGitHubToken()
+		if promptErr != nil {
+			log.Printf("server: prompt failed: %v", promptErr)
+			// Fall through to try the environment variable if available
+		} else if setErr := githubClient.SetToken(ctx, token); setErr != nil {
+			log.Printf("server: failed to set prompted token: %v", setErr)
+			// Fall through to try the environment variable if available
+		} else {
+			log.Println("server: GitHub token configured via prompt")
+			return true
+		}
+	}
+
+	if accountCfg.Token == "" {
+		return false
+	}
+	log.Printf("server: using GitHub token from %s environment variable", accountCfg.TokenEnv)
+	if setErr := githubClient.SetToken(ctx, accountCfg.Token); setErr != nil {
+		log.Fatalf("server: failed to set %s: %v", accountCfg.TokenEnv, setErr)
+	}
+	log.Println("server: GitHub token validated successfully")
+	return true
+}
+
 // promptGitHubToken prompts the user to enter their GitHub Personal Access Token.
 // Returns the token string or an error if prompting fails.
 func promptGitHubToken() (string, error) {
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/api"
+	"github.com/ajbeattie/octobud/backend/internal/api/auth"
+	apiuser "github.com/ajbeattie/octobud/backend/internal/api/user"
+)
+
+// apiRoutes are the handlers served under /api, and what their auth middleware needs
+type apiRoutes struct {
+	logger          *zap.Logger
+	jwtSecret       string
+	rateLimiter     *auth.RateLimiter
+	tokenRevocation *auth.TokenRevocation
+	userHandler     *apiuser.Handler
+	apiHandler      *api.Handler
+}
+
+// registerAPIRoutes attaches the /api routes to router: login with rate limiting, webhooks
+// authenticated by their signature, and everything else behind JWT auth and CSRF checks
+func registerAPIRoutes(router chi.Router, routes apiRoutes) {
+	userHandler := routes.userHandler
+	router.Route("/api", func(r chi.Router) {
+		// Public user routes (login) - with rate limiting
+		r.Group(func(r chi.Router) {
+			r.Use(auth.RateLimitMiddleware(routes.rateLimiter, routes.logger))
+			r.Post("/user/login", userHandler.HandleLogin)
+		})
+
+		// Protected user routes
+		r.Group(func(r chi.Router) {
+			r.Use(auth.JWTMiddleware(routes.jwtSecret, routes.logger, routes.tokenRevocation))
+			r.Use(auth.CSRFMiddleware(routes.logger))
+			r.Get("/user/me", userHandler.HandleGetCurrentUser)
+			r.Post("/user/refresh", userHandler.HandleRefreshToken)
+			r.Post("/user/logout", userHandler.HandleLogout)
+			r.Put("/user/credentials", userHandler.HandleUpdateCredentials)
+			r.Get("/user/sync-settings", userHandler.HandleGetSyncSettings)
+			r.Put("/user/sync-settings", userHandler.HandleUpdateSyncSettings)
+			r.Get("/user/sync-state", userHandler.HandleGetSyncState)
+			r.Get("/user/sync-runs", userHandler.HandleListSyncRuns)
+			r.Post("/user/sync-older", userHandler.HandleSyncOlder)
+			r.Get("/user/accounts", userHandler.HandleListAccounts)
+			r.Put("/user/accounts/{id}/sync-settings", userHandler.HandleUpdateAccountSyncSettings)
+			r.Delete(
+				"/user/accounts/{id}/sync-settings",
+				userHandler.HandleDeleteAccountSyncSettings,
+			)
+		})
+
+		// GitHub webhooks, authenticated by their signature rather than a session
+		routes.apiHandler.RegisterWebhooks(r)
+
+		// All other API routes require auth and CSRF
+		r.Group(func(r chi.Router) {
+			r.Use(auth.JWTMiddleware(routes.jwtSecret, routes.logger, routes.tokenRevocation))
+			r.Use(auth.CSRFMiddleware(routes.logger))
+			routes.apiHandler.Register(r)
+		})
+	})
+}
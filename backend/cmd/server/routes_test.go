@@ -0,0 +1,98 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/api"
+	"github.com/ajbeattie/octobud/backend/internal/api/auth"
+	apiuser "github.com/ajbeattie/octobud/backend/internal/api/user"
+	"github.com/ajbeattie/octobud/backend/internal/db"
+)
+
+func setupAPIRouter(t *testing.T) (*chi.Mux, *apiuser.Handler) {
+	t.Helper()
+
+	logger := zap.NewNop()
+	rateLimiter := auth.NewRateLimiter(5, time.Minute, logger)
+	tokenRevocation := auth.NewTokenRevocation(logger)
+	userHandler := apiuser.New(
+		logger,
+		nil,
+		"test-secret",
+		time.Hour,
+		rateLimiter,
+		tokenRevocation,
+		false,
+	)
+
+	router := chi.NewRouter()
+	registerAPIRoutes(router, apiRoutes{
+		logger:          logger,
+		jwtSecret:       "test-secret",
+		rateLimiter:     rateLimiter,
+		tokenRevocation: tokenRevocation,
+		userHandler:     userHandler,
+		apiHandler:      api.NewHandler(db.New(nil)),
+	})
+	return router, userHandler
+}
+
+// TestRegisterAPIRoutes_UserRoutes tests that the server serves every route of the user
+// handler, which it lists by hand to put login outside the session middleware
+func TestRegisterAPIRoutes_UserRoutes(t *testing.T) {
+	router, userHandler := setupAPIRouter(t)
+
+	served := map[string]bool{}
+	require.NoError(t, chi.Walk(router, func(
+		method, route string,
+		_ http.Handler,
+		_ ...func(http.Handler) http.Handler,
+	) error {
+		served[method+" "+route] = true
+		return nil
+	}))
+
+	userRouter := chi.NewRouter()
+	userRouter.Route("/api", userHandler.Register)
+	require.NoError(t, chi.Walk(userRouter, func(
+		method, route string,
+		_ http.Handler,
+		_ ...func(http.Handler) http.Handler,
+	) error {
+		require.True(t, served[method+" "+route], "%s %s is not served", method, route)
+		return nil
+	}))
+}
+
+// TestRegisterAPIRoutes_SyncRuns tests that the sync run history is served behind auth
+func TestRegisterAPIRoutes_SyncRuns(t *testing.T) {
+	router, _ := setupAPIRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/sync-runs", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
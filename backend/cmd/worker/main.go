@@ -31,18 +31,20 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/riverdriver/riverpgxv5"
-	"github.com/riverqueue/river/rivertype"
 	"go.uber.org/zap"
 	"golang.org/x/term"
 
 	config "github.com/ajbeattie/octobud/backend/internal/config"
+	"github.com/ajbeattie/octobud/backend/internal/core/account"
 	"github.com/ajbeattie/octobud/backend/internal/core/notification"
 	"github.com/ajbeattie/octobud/backend/internal/core/pullrequest"
 	"github.com/ajbeattie/octobud/backend/internal/core/repository"
 	"github.com/ajbeattie/octobud/backend/internal/core/syncstate"
 	"github.com/ajbeattie/octobud/backend/internal/db"
 	"github.com/ajbeattie/octobud/backend/internal/github"
+	githubinterfaces "github.com/ajbeattie/octobud/backend/internal/github/interfaces"
 	"github.com/ajbeattie/octobud/backend/internal/jobs"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 	"github.com/ajbeattie/octobud/backend/internal/sync"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -93,82 +95,151 @@ func main() {
 		}
 	}()
 
-	// Initialize GitHub client
-	githubClient := github.NewClient()
-
-	if *promptToken {
-		log.Println("worker: prompting for GitHub token")
-		token, tokenErr := promptGitHubToken()
-		if tokenErr != nil {
-			log.Fatalf("worker: failed to prompt for token: %v", tokenErr)
-		}
-		if setErr := githubClient.SetToken(ctx, token); setErr != nil {
-			log.Fatalf("worker: failed to set prompted token: %v", setErr)
-		}
-		log.Println("worker: GitHub token configured via prompt")
-	} else {
-		if cfg.GHToken == "" {
-			fmt.Fprintln(os.Stderr, "Error: GH_TOKEN environment variable is not set")
-			fmt.Fprintln(os.Stderr, "Please set GH_TOKEN or use --prompt-token flag to enter it interactively")
-			os.Exit(1)
-		}
-		log.Println("worker: using GitHub token from GH_TOKEN environment variable")
-		if setErr := githubClient.SetToken(ctx, cfg.GHToken); setErr != nil {
-			log.Fatalf("worker: failed to set GitHub token: %v", setErr)
-		}
-		log.Println("worker: GitHub token validated successfully")
-	}
-
 	// Initialize logger
 	logger, err := zap.NewProduction()
 	if err != nil {
 		log.Fatalf("worker: failed to create logger: %v", err)
 	}
 
+	if *promptToken && len(cfg.GitHubAccounts) > 1 {
+		log.Fatalf("worker: --prompt-token only supports a single GitHub account")
+	}
+
 	// Initialize business logic services
 	queries := db.New(dbConn)
-	syncStateSvc := syncstate.NewSyncStateService(queries)
+	accountSvc := account.NewService(queries)
 	repositorySvc := repository.NewService(queries)
 	pullRequestSvc := pullrequest.NewService(queries)
 	notificationSvc := notification.NewService(queries)
 
-	// Initialize sync service
-	syncService := sync.NewService(
-		logger,
-		time.Now,
-		githubClient,
-		syncStateSvc,
-		repositorySvc,
-		pullRequestSvc,
-		notificationSvc,
-		queries, // userStore for sync settings
-	)
-
-	// Configure periodic jobs
-	var periodicJobs []*river.PeriodicJob
 	syncInterval := cfg.SyncInterval
 	if syncInterval == 0 {
 		syncInterval = 20 * time.Second // Default to 20 seconds if not configured
 	}
 
-	// Periodic sync of notifications
+	reconcileInterval := cfg.ReconcileInterval
+	if reconcileInterval == 0 {
+		reconcileInterval = time.Hour // Listing every thread costs a request per 50 threads
+	}
+	reconcilePolicy, err := models.ParseMissingThreadPolicy(cfg.ReconcilePolicy)
+	if err != nil {
+		log.Fatalf("worker: invalid RECONCILE_POLICY: %v", err)
+	}
+
+	// Each run refreshes at most 100 subjects, issues and pull requests 50 per GraphQL query
+	subjectRefreshInterval := cfg.SubjectRefreshInterval
+	if subjectRefreshInterval == 0 {
+		subjectRefreshInterval = 15 * time.Minute
+	}
+	subjectRefreshAge := cfg.SubjectRefreshAge
+	if subjectRefreshAge == 0 {
+		subjectRefreshAge = time.Hour
+	}
+
+	syncRunRetention := cfg.SyncRunRetention
+	if syncRunRetention == 0 {
+		syncRunRetention = 7 * 24 * time.Hour
+	}
+
+	// Delivered write-backs are kept as long as the sync run history
+	outboxRetention := syncRunRetention
+
+	// Each GitHub account gets its own client, sync state and poll schedule
+	var accounts []syncedAccount
+	for _, accountCfg := range cfg.GitHubAccounts {
+		// github.com unless the account's API URL points at an Enterprise Server
+		endpoints := github.NewEndpoints(accountCfg.APIURL, accountCfg.WebURL)
+		githubClient := github.NewClient(github.WithEndpoints(endpoints))
+		configureToken(ctx, githubClient, accountCfg, *promptToken)
+
+		registered, registerErr := accountSvc.RegisterAccount(
+			ctx,
+			accountCfg.Name,
+			endpoints.APIURL,
+			endpoints.WebURL,
+		)
+		if registerErr != nil {
+			log.Fatalf(
+				"worker: failed to register GitHub account %q: %v",
+				accountCfg.Name,
+				registerErr,
+			)
+		}
+
+		syncService := sync.NewService(
+			logger,
+			time.Now,
+			githubClient,
+			syncstate.NewSyncStateService(queries),
+			repositorySvc,
+			pullRequestSvc,
+			notificationSvc,
+			queries, // userStore for sync settings
+		).WithAccount(registered.ID)
+
+		accounts = append(accounts, syncedAccount{
+			id:          registered.ID,
+			name:        registered.Name,
+			client:      githubClient,
+			syncService: syncService,
+			schedule:    jobs.NewPollSchedule(syncInterval),
+		})
+	}
+
+	// Configure periodic jobs
+	var periodicJobs []*river.PeriodicJob
+
+	// Periodic sync of each account's notifications, backing off while GitHub's
+	// X-Poll-Interval is longer
+	for _, acct := range accounts {
+		args := jobs.SyncNotificationsArgs{AccountID: acct.id}
+		periodicJobs = append(periodicJobs, river.NewPeriodicJob(
+			acct.schedule,
+			func() (river.JobArgs, *river.InsertOpts) {
+				return args, nil
+			},
+			&river.PeriodicJobOpts{RunOnStart: true},
+		))
+	}
+
+	// Periodic reconciliation of each account's notifications with GitHub's full list
+	for _, acct := range accounts {
+		args := jobs.ReconcileNotificationsArgs{AccountID: acct.id}
+		periodicJobs = append(periodicJobs, river.NewPeriodicJob(
+			river.PeriodicInterval(reconcileInterval),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return args, nil
+			},
+			nil,
+		))
+	}
+
+	// Periodic refresh of each account's stale subjects
+	for _, acct := range accounts {
+		args := jobs.RefreshSubjectsArgs{AccountID: acct.id}
+		periodicJobs = append(periodicJobs, river.NewPeriodicJob(
+			river.PeriodicInterval(subjectRefreshInterval),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return args, nil
+			},
+			nil,
+		))
+	}
+
+	// Hourly pruning of the sync run history, for every account at once
+	periodicJobs = append(periodicJobs, river.NewPeriodicJob(
+		river.PeriodicInterval(time.Hour),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return jobs.PruneSyncRunsArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	))
+
+	// Re-queue undelivered GitHub write-backs and prune the delivered ones
 	periodicJobs = append(periodicJobs, river.NewPeriodicJob(
-		river.PeriodicInterval(syncInterval),
+		river.PeriodicInterval(10*time.Minute),
 		func() (river.JobArgs, *river.InsertOpts) {
-			return jobs.SyncNotificationsArgs{},
-
-				&river.InsertOpts{
-					Queue: "sync_notifications",
-					UniqueOpts: river.UniqueOpts{
-						ByState: []rivertype.JobState{
-							rivertype.JobStateAvailable,
-							rivertype.JobStatePending,
-							rivertype.JobStateRunning,
-							rivertype.JobStateRetryable,
-							rivertype.JobStateScheduled,
-						},
-					},
-				}
+			return jobs.SweepGitHubOutboxArgs{}, nil
 		},
 		&river.PeriodicJobOpts{RunOnStart: true},
 	))
@@ -184,6 +255,8 @@ func main() {
 			"sync_notifications":   {MaxWorkers: 1},
 			"process_notification": {MaxWorkers: 10}, // Allow parallel processing of notifications
 			"apply_rule":           {MaxWorkers: 10},
+			"github_write_back":    {MaxWorkers: 5},
+			"refresh_subjects":     {MaxWorkers: 1},
 		},
 		Workers:      workers,
 		PeriodicJobs: periodicJobs,
@@ -192,20 +265,83 @@ func main() {
 		log.Fatalf("worker: failed to create River client: %v", err)
 	}
 
-	// Register workers after River client is created
-	river.AddWorker(workers, jobs.NewSyncNotificationsWorker(logger, syncService, riverClient))
-	river.AddWorker(
-		workers,
-		jobs.NewSyncOlderNotificationsWorker(logger, syncService, riverClient),
+	// Register workers after River client is created. Jobs queued without an account
+	// (e.g. from the API) sync the first configured account.
+	syncWorker := jobs.NewSyncNotificationsWorker(logger, accounts[0].syncService, riverClient).
+		WithPollSchedule(accounts[0].schedule)
+	syncOlderWorker := jobs.NewSyncOlderNotificationsWorker(
+		logger,
+		accounts[0].syncService,
+		riverClient,
+	)
+	processWorker := jobs.NewProcessNotificationWorker(dbConn, accounts[0].syncService)
+	writeBackWorker := jobs.NewWriteBackWorker(logger, queries, accounts[0].client)
+	reconcileWorker := jobs.NewReconcileNotificationsWorker(
+		logger,
+		accounts[0].syncService,
+		reconcilePolicy,
+	)
+	refreshSubjectsWorker := jobs.NewRefreshSubjectsWorker(
+		logger,
+		accounts[0].syncService,
+		subjectRefreshAge,
+	)
+	pruneSyncRunsWorker := jobs.NewPruneSyncRunsWorker(
+		logger,
+		accounts[0].syncService,
+		syncRunRetention,
+	)
+	refreshNotificationSubjectsWorker := jobs.NewRefreshNotificationSubjectsWorker(
+		logger,
+		accounts[0].syncService,
+	)
+	saveOutboxWorker := jobs.NewSaveGitHubOutboxWorker(logger, queries, riverClient)
+	sweepOutboxWorker := jobs.NewSweepGitHubOutboxWorker(
+		logger,
+		queries,
+		riverClient,
+		outboxRetention,
 	)
-	river.AddWorker(workers, jobs.NewProcessNotificationWorker(dbConn, syncService))
+	for _, acct := range accounts {
+		syncWorker.WithAccount(acct.id, acct.syncService, acct.schedule)
+		syncOlderWorker.WithAccount(acct.id, acct.syncService)
+		processWorker.WithAccount(acct.id, acct.syncService)
+		writeBackWorker.WithAccountClient(acct.id, acct.client)
+		reconcileWorker.WithAccount(acct.id, acct.syncService)
+		refreshSubjectsWorker.WithAccount(acct.id, acct.syncService)
+		refreshNotificationSubjectsWorker.WithAccount(acct.id, acct.syncService)
+		log.Printf("worker: syncing GitHub account %q (id %d)", acct.name, acct.id)
+	}
+	river.AddWorker(workers, syncWorker)
+	river.AddWorker(workers, syncOlderWorker)
+	river.AddWorker(workers, processWorker)
 	river.AddWorker(workers, jobs.NewApplyRuleWorker(queries))
+	river.AddWorker(workers, writeBackWorker)
+	river.AddWorker(workers, saveOutboxWorker)
+	river.AddWorker(workers, reconcileWorker)
+	river.AddWorker(workers, refreshSubjectsWorker)
+	river.AddWorker(workers, refreshNotificationSubjectsWorker)
+	river.AddWorker(workers, pruneSyncRunsWorker)
+	river.AddWorker(workers, sweepOutboxWorker)
 	log.Println(
-		"worker: registered 4 workers (SyncNotifications, SyncOlderNotifications, ProcessNotification, ApplyRule)",
+		"worker: registered 11 workers (SyncNotifications, SyncOlderNotifications, " +
+			"ProcessNotification, ApplyRule, WriteBack, SaveGitHubOutbox, ReconcileNotifications, " +
+			"RefreshSubjects, RefreshNotificationSubjects, PruneSyncRuns, SweepGitHubOutbox)",
 	)
 
 	// Start River client
 	log.Printf("worker: starting River client with sync interval: %s", syncInterval)
+	log.Printf(
+		"worker: reconciling with GitHub every %s, policy for missing threads: %s",
+		reconcileInterval,
+		reconcilePolicy,
+	)
+	log.Printf(
+		"worker: refreshing subjects older than %s every %s",
+		subjectRefreshAge,
+		subjectRefreshInterval,
+	)
+	log.Printf("worker: keeping sync run history for %s", syncRunRetention)
 	if err := riverClient.Start(ctx); err != nil {
 		log.Fatalf("worker: failed to start River client: %v", err)
 	}
@@ -224,6 +360,52 @@ func main() {
 	log.Println("worker: stopped")
 }
 
+// syncedAccount is a configured GitHub account, with the client and services syncing it
+type syncedAccount struct {
+	id          int64
+	name        string
+	client      githubinterfaces.Client
+	syncService *sync.Service
+	schedule    *jobs.PollSchedule
+}
+
+// configureToken sets an account's GitHub token, prompting for it if asked to.
+// Exits if the token is missing or invalid.
+func configureToken(
+	ctx context.Context,
+	githubClient githubinterfaces.Client,
+	accountCfg config.GitHubAccount,
+	prompt bool,
+) {
+	if prompt {
+		log.Println("worker: prompting for GitHub token")
+		token, tokenErr := promptGitHubToken()
+		if tokenErr != nil {
+			log.Fatalf("worker: failed to prompt for token: %v", tokenErr)
+		}
+		if setErr := githubClient.SetToken(ctx, token); setErr != nil {
+			log.Fatalf("worker: failed to set prompted token: %v", setErr)
+		}
+		log.Println("worker: GitHub token configured via prompt")
+		return
+	}
+
+	if accountCfg.Token == "" {
+		fmt.Fprintf(os.Stderr, "Error: %s environment variable is not set\n", accountCfg.TokenEnv)
+		fmt.Fprintf(
+			os.Stderr,
+			"Please set %s or use --prompt-token flag to enter it interactively\n",
+			accountCfg.TokenEnv,
+		)
+		os.Exit(1)
+	}
+	log.Printf("worker: using GitHub token from %s environment variable", accountCfg.TokenEnv)
+	if setErr := githubClient.SetToken(ctx, accountCfg.Token); setErr != nil {
+		log.Fatalf("worker: failed to set %s: %v", accountCfg.TokenEnv, setErr)
+	}
+	log.Println("worker: GitHub token validated successfully")
+}
+
 // promptGitHubToken prompts the user to enter their GitHub Personal Access Token.
 // Returns the token string or an error if prompting fails.
 func promptGitHubToken() (string, error) {
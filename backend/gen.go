@@ -27,5 +27,7 @@ package backend
 //go:generate mockgen -source=internal/core/auth/service.go -destination=internal/core/auth/mocks/mock_service.go -package=mocks
 //go:generate mockgen -source=internal/core/repository/service.go -destination=internal/core/repository/mocks/mock_service.go -package=mocks
 //go:generate mockgen -source=internal/core/pullrequest/service.go -destination=internal/core/pullrequest/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/completion/service.go -destination=internal/core/completion/mocks/mock_service.go -package=mocks
+//go:generate mockgen -source=internal/core/account/service.go -destination=internal/core/account/mocks/mock_service.go -package=mocks
 //go:generate mockgen -source=internal/db/river.go -destination=internal/db/mocks/mock_river.go -package=mocks
 //go:generate mockgen -source=internal/jobs/rule_matcher.go -destination=internal/jobs/mocks/mock_rule_matcher.go -package=mocks
@@ -24,10 +24,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ajbeattie/octobud/backend/internal/api/notifications"
+	"github.com/ajbeattie/octobud/backend/internal/api/query"
 	"github.com/ajbeattie/octobud/backend/internal/api/repositories"
 	"github.com/ajbeattie/octobud/backend/internal/api/rules"
 	"github.com/ajbeattie/octobud/backend/internal/api/tags"
 	"github.com/ajbeattie/octobud/backend/internal/api/views"
+	"github.com/ajbeattie/octobud/backend/internal/api/webhooks"
+	"github.com/ajbeattie/octobud/backend/internal/core/completion"
 	"github.com/ajbeattie/octobud/backend/internal/core/notification"
 	"github.com/ajbeattie/octobud/backend/internal/core/pullrequest"
 	"github.com/ajbeattie/octobud/backend/internal/core/repository"
@@ -38,6 +41,7 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/core/view"
 	"github.com/ajbeattie/octobud/backend/internal/db"
 	githubinterfaces "github.com/ajbeattie/octobud/backend/internal/github/interfaces"
+	"github.com/ajbeattie/octobud/backend/internal/jobs"
 	"github.com/ajbeattie/octobud/backend/internal/sync"
 )
 
@@ -46,8 +50,7 @@ type Handler struct {
 	logger         *zap.Logger
 	queries        *db.Queries
 	notifications  *notification.Service
-	syncService    *sync.Service
-	githubClient   githubinterfaces.Client
+	syncServices   map[int64]*sync.Service // by GitHub account ID
 	timelineSvc    *timelinesvc.Service
 	riverClient    db.RiverClient
 	notificationsH *notifications.Handler
@@ -55,15 +58,20 @@ type Handler struct {
 	viewsH         *views.Handler
 	rulesH         *rules.Handler
 	repositoriesH  *repositories.Handler
+	queryH         *query.Handler
+	webhookSecret  string
+	webhooksH      *webhooks.Handler // nil unless a webhook secret is configured
 }
 
 // HandlerOption configures a Handler
 type HandlerOption func(*Handler)
 
-// WithSyncService configures the handler with a sync service for refreshing subject data.
-// This enables the refresh-subject endpoint for notifications.
+// WithSyncService configures the handler with a sync service for refreshing the subject
+// data of a GitHub account's notifications. This enables the refresh-subject and timeline
+// endpoints for them; give it once per account with a token.
 func WithSyncService(
 	dbConn *sql.DB,
+	accountID int64,
 	githubClient githubinterfaces.Client,
 	logger *zap.Logger,
 ) HandlerOption {
@@ -73,7 +81,7 @@ func WithSyncService(
 		repositorySvc := repository.NewService(queries)
 		pullRequestSvc := pullrequest.NewService(queries)
 		notificationSvc := notification.NewService(queries)
-		h.syncService = sync.NewService(
+		syncService := sync.NewService(
 			logger,
 			time.Now,
 			githubClient,
@@ -82,8 +90,11 @@ func WithSyncService(
 			pullRequestSvc,
 			notificationSvc,
 			queries, // userStore for sync settings
-		)
-		h.githubClient = githubClient
+		).WithAccount(accountID)
+		if h.syncServices == nil {
+			h.syncServices = make(map[int64]*sync.Service)
+		}
+		h.syncServices[accountID] = syncService
 		h.timelineSvc = timelinesvc.NewService()
 	}
 }
@@ -95,6 +106,14 @@ func WithRiverClient(client db.RiverClient) HandlerOption {
 	}
 }
 
+// WithWebhookSecret enables the GitHub webhook endpoint, verifying deliveries with secret.
+// An empty secret leaves it disabled.
+func WithWebhookSecret(secret string) HandlerOption {
+	return func(h *Handler) {
+		h.webhookSecret = secret
+	}
+}
+
 // NewHandler returns an API handler backed by the provided db queries.
 func NewHandler(queries *db.Queries, opts ...HandlerOption) *Handler {
 	// Initialize zap logger
@@ -110,6 +129,7 @@ func NewHandler(queries *db.Queries, opts ...HandlerOption) *Handler {
 	tagSvc := tag.NewService(queries)
 	viewSvc := view.NewService(queries)
 	ruleSvc := rulescore.NewService(queries)
+	completionSvc := completion.NewService(queries)
 
 	h := &Handler{
 		logger:        logger,
@@ -122,15 +142,24 @@ func NewHandler(queries *db.Queries, opts ...HandlerOption) *Handler {
 		opt(h)
 	}
 
+	// Write-back to GitHub runs as jobs, so it needs River (and the user to opt in)
+	if h.riverClient != nil {
+		notificationsSvc.WithWriteBack(jobs.NewWriteBackQueue(logger, queries, h.riverClient))
+	}
+
 	// Create all resource handlers
 	h.notificationsH = notifications.New(
 		logger, queries, notificationsSvc, repositorySvc, tagSvc,
-		h.timelineSvc, h.githubClient, h.syncService, h.riverClient,
+		h.timelineSvc, h.syncServices, h.riverClient,
 	)
 	h.tagsH = tags.New(logger, tagSvc)
 	h.viewsH = views.New(logger, viewSvc)
 	h.rulesH = rules.New(logger, ruleSvc, viewSvc, h.riverClient)
 	h.repositoriesH = repositories.New(logger, repositorySvc)
+	h.queryH = query.New(logger, completionSvc, notificationsSvc)
+	if h.webhookSecret != "" {
+		h.webhooksH = webhooks.New(logger, queries, h.webhookSecret, h.riverClient)
+	}
 
 	return h
 }
@@ -143,4 +172,13 @@ func (h *Handler) Register(r chi.Router) {
 	h.viewsH.Register(r)
 	h.rulesH.Register(r)
 	h.repositoriesH.Register(r)
+	h.queryH.Register(r)
+}
+
+// RegisterWebhooks attaches the webhook routes, if a webhook secret is configured. They
+// verify deliveries by signature, so the router passed in must not require a session.
+func (h *Handler) RegisterWebhooks(r chi.Router) {
+	if h.webhooksH != nil {
+		h.webhooksH.Register(r)
+	}
 }
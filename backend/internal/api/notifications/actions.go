@@ -117,8 +117,14 @@ func (h *Handler) handleNotificationAction(
 		return
 	}
 
+	accountID, err := accountIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid accountId")
+		return
+	}
+
 	// Execute the action
-	_, err = h.executeNotificationAction(ctx, action, githubID)
+	_, err = h.executeNotificationAction(ctx, action, accountID, githubID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			h.logger.Debug(
@@ -145,7 +151,12 @@ func (h *Handler) handleNotificationAction(
 
 	// Get updated notification with details
 	queryStr := r.URL.Query().Get("query")
-	notification, err := h.notifications.GetNotificationWithDetails(ctx, githubID, queryStr)
+	notification, err := h.notifications.GetNotificationWithDetails(
+		ctx,
+		accountID,
+		githubID,
+		queryStr,
+	)
 	if err != nil {
 		h.logger.Error(
 			"failed to get notification with details",
@@ -163,29 +174,30 @@ func (h *Handler) handleNotificationAction(
 func (h *Handler) executeNotificationAction(
 	ctx context.Context,
 	action NotificationAction,
+	accountID int64,
 	githubID string,
 ) (interface{}, error) {
 	switch action {
 	case ActionMarkRead:
-		return h.notifications.MarkNotificationRead(ctx, githubID)
+		return h.notifications.MarkNotificationRead(ctx, accountID, githubID)
 	case ActionMarkUnread:
-		return h.notifications.MarkNotificationUnread(ctx, githubID)
+		return h.notifications.MarkNotificationUnread(ctx, accountID, githubID)
 	case ActionArchive:
-		return h.notifications.ArchiveNotification(ctx, githubID)
+		return h.notifications.ArchiveNotification(ctx, accountID, githubID)
 	case ActionUnarchive:
-		return h.notifications.UnarchiveNotification(ctx, githubID)
+		return h.notifications.UnarchiveNotification(ctx, accountID, githubID)
 	case ActionMute:
-		return h.notifications.MuteNotification(ctx, githubID)
+		return h.notifications.MuteNotification(ctx, accountID, githubID)
 	case ActionUnmute:
-		return h.notifications.UnmuteNotification(ctx, githubID)
+		return h.notifications.UnmuteNotification(ctx, accountID, githubID)
 	case ActionUnsnooze:
-		return h.notifications.UnsnoozeNotification(ctx, githubID)
+		return h.notifications.UnsnoozeNotification(ctx, accountID, githubID)
 	case ActionStar:
-		return h.notifications.StarNotification(ctx, githubID)
+		return h.notifications.StarNotification(ctx, accountID, githubID)
 	case ActionUnstar:
-		return h.notifications.UnstarNotification(ctx, githubID)
+		return h.notifications.UnstarNotification(ctx, accountID, githubID)
 	case ActionUnfilter:
-		return h.notifications.UnfilterNotification(ctx, githubID)
+		return h.notifications.UnfilterNotification(ctx, accountID, githubID)
 	default:
 		return nil, fmt.Errorf("unknown notification action: %s", action)
 	}
@@ -254,6 +266,12 @@ func (h *Handler) handleSnoozeNotification(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	accountID, err := accountIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid accountId")
+		return
+	}
+
 	var req snoozeNotificationRequest
 	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
 		h.logger.Error(
@@ -269,7 +287,7 @@ func (h *Handler) handleSnoozeNotification(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	_, err = h.notifications.SnoozeNotification(ctx, githubID, req.SnoozedUntil)
+	_, err = h.notifications.SnoozeNotification(ctx, accountID, githubID, req.SnoozedUntil)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			h.logger.Debug("notification not found", zap.String("github_id", githubID))
@@ -286,7 +304,12 @@ func (h *Handler) handleSnoozeNotification(w http.ResponseWriter, r *http.Reques
 	}
 
 	queryStr := r.URL.Query().Get("query")
-	notification, err := h.notifications.GetNotificationWithDetails(ctx, githubID, queryStr)
+	notification, err := h.notifications.GetNotificationWithDetails(
+		ctx,
+		accountID,
+		githubID,
+		queryStr,
+	)
 	if err != nil {
 		h.logger.Error(
 			"failed to get notification with details",
@@ -317,6 +340,12 @@ func (h *Handler) handleAssignTagToNotification(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	accountID, err := accountIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid accountId")
+		return
+	}
+
 	var req assignTagRequest
 	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
 		shared.WriteError(w, http.StatusBadRequest, "invalid request body")
@@ -329,7 +358,7 @@ func (h *Handler) handleAssignTagToNotification(w http.ResponseWriter, r *http.R
 	}
 
 	// Assign the tag using service
-	_, err = h.notifications.AssignTag(ctx, githubID, req.TagID)
+	_, err = h.notifications.AssignTag(ctx, accountID, githubID, req.TagID)
 	if err != nil {
 		if errors.Is(err, notificationcore.ErrNotificationNotFound) {
 			shared.WriteError(w, http.StatusNotFound, "notification not found")
@@ -354,7 +383,12 @@ func (h *Handler) handleAssignTagToNotification(w http.ResponseWriter, r *http.R
 	}
 
 	queryStr := r.URL.Query().Get("query")
-	notification, err := h.notifications.GetNotificationWithDetails(ctx, githubID, queryStr)
+	notification, err := h.notifications.GetNotificationWithDetails(
+		ctx,
+		accountID,
+		githubID,
+		queryStr,
+	)
 	if err != nil {
 		h.logger.Error(
 			"failed to get updated notification",
@@ -385,6 +419,12 @@ func (h *Handler) handleAssignTagByName(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	accountID, err := accountIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid accountId")
+		return
+	}
+
 	var req assignTagByNameRequest
 	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
 		shared.WriteError(w, http.StatusBadRequest, "invalid request body")
@@ -397,7 +437,7 @@ func (h *Handler) handleAssignTagByName(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Assign tag by name using service (creates tag if needed)
-	_, err = h.notifications.AssignTagByName(ctx, githubID, req.TagName)
+	_, err = h.notifications.AssignTagByName(ctx, accountID, githubID, req.TagName)
 	if err != nil {
 		if errors.Is(err, notificationcore.ErrNotificationNotFound) {
 			shared.WriteError(w, http.StatusNotFound, "notification not found")
@@ -418,7 +458,12 @@ func (h *Handler) handleAssignTagByName(w http.ResponseWriter, r *http.Request)
 	}
 
 	queryStr := r.URL.Query().Get("query")
-	notification, err := h.notifications.GetNotificationWithDetails(ctx, githubID, queryStr)
+	notification, err := h.notifications.GetNotificationWithDetails(
+		ctx,
+		accountID,
+		githubID,
+		queryStr,
+	)
 	if err != nil {
 		h.logger.Error(
 			"failed to get updated notification",
@@ -449,6 +494,12 @@ func (h *Handler) handleRemoveTagFromNotification(w http.ResponseWriter, r *http
 		return
 	}
 
+	accountID, err := accountIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid accountId")
+		return
+	}
+
 	tagIDStr := chi.URLParam(r, "tagId")
 	if tagIDStr == "" {
 		shared.WriteError(w, http.StatusBadRequest, "tagId is required")
@@ -462,7 +513,7 @@ func (h *Handler) handleRemoveTagFromNotification(w http.ResponseWriter, r *http
 	}
 
 	// Remove tag using service
-	_, err = h.notifications.RemoveTag(ctx, githubID, tagID)
+	_, err = h.notifications.RemoveTag(ctx, accountID, githubID, tagID)
 	if err != nil {
 		if errors.Is(err, notificationcore.ErrNotificationNotFound) {
 			shared.WriteError(w, http.StatusNotFound, "notification not found")
@@ -479,7 +530,12 @@ func (h *Handler) handleRemoveTagFromNotification(w http.ResponseWriter, r *http
 	}
 
 	queryStr := r.URL.Query().Get("query")
-	notification, err := h.notifications.GetNotificationWithDetails(ctx, githubID, queryStr)
+	notification, err := h.notifications.GetNotificationWithDetails(
+		ctx,
+		accountID,
+		githubID,
+		queryStr,
+	)
 	if err != nil {
 		h.logger.Error(
 			"failed to get updated notification",
@@ -39,6 +39,7 @@ func TestHandler_handleNotificationAction(t *testing.T) {
 	tests := []struct {
 		name           string
 		githubID       string
+		accountID      string
 		action         NotificationAction
 		setupMock      func(*notificationmocks.MockNotificationService)
 		expectedStatus int
@@ -50,10 +51,10 @@ func TestHandler_handleNotificationAction(t *testing.T) {
 			action:   ActionMarkRead,
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					MarkNotificationRead(gomock.Any(), "test-id").
+					MarkNotificationRead(gomock.Any(), models.DefaultAccountID, "test-id").
 					Return(db.Notification{}, nil)
 				mockSvc.EXPECT().
-					GetNotificationWithDetails(gomock.Any(), "test-id", "").
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "test-id", "").
 					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -64,6 +65,29 @@ func TestHandler_handleNotificationAction(t *testing.T) {
 				require.Equal(t, "test-id", response.Notification.GithubID)
 			},
 		},
+		{
+			name:      "accountId selects the notification's account",
+			githubID:  "test-id",
+			accountID: "2",
+			action:    ActionArchive,
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					ArchiveNotification(gomock.Any(), int64(2), "test-id").
+					Return(db.Notification{}, nil)
+				mockSvc.EXPECT().
+					GetNotificationWithDetails(gomock.Any(), int64(2), "test-id", "").
+					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid accountId returns 400",
+			githubID:       "test-id",
+			accountID:      "abc",
+			action:         ActionMarkRead,
+			setupMock:      func(*notificationmocks.MockNotificationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "missing githubID returns 400",
 			githubID:       "",
@@ -77,7 +101,7 @@ func TestHandler_handleNotificationAction(t *testing.T) {
 			action:   ActionMarkRead,
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					MarkNotificationRead(gomock.Any(), "not-found").
+					MarkNotificationRead(gomock.Any(), models.DefaultAccountID, "not-found").
 					Return(db.Notification{}, sql.ErrNoRows)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -88,7 +112,7 @@ func TestHandler_handleNotificationAction(t *testing.T) {
 			action:   ActionMarkRead,
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					MarkNotificationRead(gomock.Any(), "test-id").
+					MarkNotificationRead(gomock.Any(), models.DefaultAccountID, "test-id").
 					Return(db.Notification{}, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -105,11 +129,11 @@ func TestHandler_handleNotificationAction(t *testing.T) {
 				tt.setupMock(mockSvc)
 			}
 
-			req := createRequest(
-				http.MethodPost,
-				"/notifications/"+url.PathEscape(tt.githubID),
-				nil,
-			)
+			target := "/notifications/" + url.PathEscape(tt.githubID)
+			if tt.accountID != "" {
+				target += "?accountId=" + tt.accountID
+			}
+			req := createRequest(http.MethodPost, target, nil)
 			rctx := chi.NewRouteContext()
 			if tt.githubID != "" {
 				rctx.URLParams.Add("githubID", tt.githubID)
@@ -144,10 +168,10 @@ func TestHandler_handleSnoozeNotification(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					SnoozeNotification(gomock.Any(), "test-id", "2024-12-31T23:59:59Z").
+					SnoozeNotification(gomock.Any(), models.DefaultAccountID, "test-id", "2024-12-31T23:59:59Z").
 					Return(db.Notification{}, nil)
 				mockSvc.EXPECT().
-					GetNotificationWithDetails(gomock.Any(), "test-id", "").
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "test-id", "").
 					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -180,7 +204,7 @@ func TestHandler_handleSnoozeNotification(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					SnoozeNotification(gomock.Any(), "not-found", "2024-12-31T23:59:59Z").
+					SnoozeNotification(gomock.Any(), models.DefaultAccountID, "not-found", "2024-12-31T23:59:59Z").
 					Return(db.Notification{}, sql.ErrNoRows)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -236,10 +260,10 @@ func TestHandler_handleAssignTagToNotification(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					AssignTag(gomock.Any(), "test-id", int64(1)).
+					AssignTag(gomock.Any(), models.DefaultAccountID, "test-id", int64(1)).
 					Return(db.Notification{}, nil)
 				mockSvc.EXPECT().
-					GetNotificationWithDetails(gomock.Any(), "test-id", "").
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "test-id", "").
 					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -263,7 +287,7 @@ func TestHandler_handleAssignTagToNotification(t *testing.T) {
 			requestBody: assignTagRequest{TagID: 999},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					AssignTag(gomock.Any(), "test-id", int64(999)).
+					AssignTag(gomock.Any(), models.DefaultAccountID, "test-id", int64(999)).
 					Return(db.Notification{}, notificationcore.ErrTagNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -274,7 +298,7 @@ func TestHandler_handleAssignTagToNotification(t *testing.T) {
 			requestBody: assignTagRequest{TagID: 1},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					AssignTag(gomock.Any(), "not-found", int64(1)).
+					AssignTag(gomock.Any(), models.DefaultAccountID, "not-found", int64(1)).
 					Return(db.Notification{}, notificationcore.ErrNotificationNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -330,10 +354,10 @@ func TestHandler_handleAssignTagByName(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					AssignTagByName(gomock.Any(), "test-id", "test-tag").
+					AssignTagByName(gomock.Any(), models.DefaultAccountID, "test-id", "test-tag").
 					Return(db.Notification{}, nil)
 				mockSvc.EXPECT().
-					GetNotificationWithDetails(gomock.Any(), "test-id", "").
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "test-id", "").
 					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -366,7 +390,7 @@ func TestHandler_handleAssignTagByName(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					AssignTagByName(gomock.Any(), "not-found", "test-tag").
+					AssignTagByName(gomock.Any(), models.DefaultAccountID, "not-found", "test-tag").
 					Return(db.Notification{}, notificationcore.ErrNotificationNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -379,7 +403,7 @@ func TestHandler_handleAssignTagByName(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					AssignTagByName(gomock.Any(), "test-id", "!!!").
+					AssignTagByName(gomock.Any(), models.DefaultAccountID, "test-id", "!!!").
 					Return(db.Notification{}, notificationcore.ErrInvalidTagName)
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -392,7 +416,7 @@ func TestHandler_handleAssignTagByName(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					AssignTagByName(gomock.Any(), "test-id", "test-tag").
+					AssignTagByName(gomock.Any(), models.DefaultAccountID, "test-id", "test-tag").
 					Return(db.Notification{}, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -446,10 +470,10 @@ func TestHandler_handleRemoveTagFromNotification(t *testing.T) {
 			tagID:    "1",
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					RemoveTag(gomock.Any(), "test-id", int64(1)).
+					RemoveTag(gomock.Any(), models.DefaultAccountID, "test-id", int64(1)).
 					Return(db.Notification{}, nil)
 				mockSvc.EXPECT().
-					GetNotificationWithDetails(gomock.Any(), "test-id", "").
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "test-id", "").
 					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -487,7 +511,7 @@ func TestHandler_handleRemoveTagFromNotification(t *testing.T) {
 			tagID:    "1",
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					RemoveTag(gomock.Any(), "not-found", int64(1)).
+					RemoveTag(gomock.Any(), models.DefaultAccountID, "not-found", int64(1)).
 					Return(db.Notification{}, notificationcore.ErrNotificationNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -498,7 +522,7 @@ func TestHandler_handleRemoveTagFromNotification(t *testing.T) {
 			tagID:    "1",
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					RemoveTag(gomock.Any(), "test-id", int64(1)).
+					RemoveTag(gomock.Any(), models.DefaultAccountID, "test-id", int64(1)).
 					Return(db.Notification{}, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -57,15 +57,25 @@ const (
 
 type bulkMarkNotificationsRequest struct {
 	GithubIDs []string `json:"githubIds,omitempty"`
+	AccountID int64    `json:"accountId,omitempty"` // Account of githubIds, default if unset
 	Query     string   `json:"query,omitempty"`
 }
 
 type bulkTagNotificationsRequest struct {
 	GithubIDs []string `json:"githubIds,omitempty"`
+	AccountID int64    `json:"accountId,omitempty"` // Account of githubIds, default if unset
 	TagID     int64    `json:"tagId"`
 	Query     string   `json:"query,omitempty"`
 }
 
+// accountOrDefault returns the account a bulk request's githubIds belong to
+func accountOrDefault(accountID int64) int64 {
+	if accountID == 0 {
+		return models.DefaultAccountID
+	}
+	return accountID
+}
+
 // handleBulkOperation handles bulk operations that follow the standard pattern
 // (read, unread, archive, unarchive, mute, unmute, star, unstar, unfilter, unsnooze)
 func (h *Handler) handleBulkOperation(w http.ResponseWriter, r *http.Request, op BulkOperation) {
@@ -121,7 +131,12 @@ func (h *Handler) handleBulkOperation(w http.ResponseWriter, r *http.Request, op
 	if hasQuery {
 		count, err = h.executeBulkOperationByQuery(ctx, op, req.Query)
 	} else {
-		count, err = h.executeBulkOperationByIDs(ctx, op, req.GithubIDs)
+		count, err = h.executeBulkOperationByIDs(
+			ctx,
+			op,
+			accountOrDefault(req.AccountID),
+			req.GithubIDs,
+		)
 	}
 
 	if err != nil {
@@ -163,12 +178,13 @@ func (h *Handler) executeBulkOperationByQuery(
 func (h *Handler) executeBulkOperationByIDs(
 	ctx context.Context,
 	op BulkOperation,
+	accountID int64,
 	githubIDs []string,
 ) (int64, error) {
 	return h.notifications.BulkUpdate(
 		ctx,
 		models.BulkOperationType(op),
-		models.BulkOperationTarget{IDs: githubIDs},
+		models.BulkOperationTarget{IDs: githubIDs, AccountID: accountID},
 		models.BulkUpdateParams{},
 	)
 }
@@ -298,7 +314,11 @@ func (h *Handler) handleBulkAssignTag(w http.ResponseWriter, r *http.Request) {
 		// Get notifications by IDs
 		notifications = make([]db.Notification, 0, len(req.GithubIDs))
 		for _, githubID := range req.GithubIDs {
-			notification, getErr := h.notifications.GetByGithubID(ctx, githubID)
+			notification, getErr := h.notifications.GetByGithubID(
+				ctx,
+				accountOrDefault(req.AccountID),
+				githubID,
+			)
 			if getErr != nil {
 				if errors.Is(getErr, sql.ErrNoRows) {
 					continue // Skip missing notifications
@@ -398,7 +418,11 @@ func (h *Handler) handleBulkRemoveTag(w http.ResponseWriter, r *http.Request) {
 		// Get notifications by IDs
 		notifications = make([]db.Notification, 0, len(req.GithubIDs))
 		for _, githubID := range req.GithubIDs {
-			notification, getErr := h.notifications.GetByGithubID(ctx, githubID)
+			notification, getErr := h.notifications.GetByGithubID(
+				ctx,
+				accountOrDefault(req.AccountID),
+				githubID,
+			)
 			if getErr != nil {
 				if errors.Is(getErr, sql.ErrNoRows) {
 					continue // Skip missing notifications
@@ -35,6 +35,7 @@ var (
 
 type bulkSnoozeNotificationsRequest struct {
 	GithubIDs    []string `json:"githubIds,omitempty"`
+	AccountID    int64    `json:"accountId,omitempty"` // Account of githubIds, default if unset
 	SnoozedUntil string   `json:"snoozedUntil"`
 	Query        string   `json:"query,omitempty"`
 }
@@ -106,7 +107,10 @@ func (h *Handler) handleBulkSnoozeNotifications(w http.ResponseWriter, r *http.R
 		count, err = h.notifications.BulkUpdate(
 			ctx,
 			models.BulkOpSnooze,
-			models.BulkOperationTarget{IDs: req.GithubIDs},
+			models.BulkOperationTarget{
+				IDs:       req.GithubIDs,
+				AccountID: accountOrDefault(req.AccountID),
+			},
 			models.BulkUpdateParams{SnoozedUntil: req.SnoozedUntil},
 		)
 	}
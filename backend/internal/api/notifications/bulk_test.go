@@ -61,6 +61,25 @@ func TestHandler_handleBulkOperation(t *testing.T) {
 				require.Equal(t, 2, response.Count)
 			},
 		},
+		{
+			name:      "githubIds of another account",
+			operation: BulkOpMarkRead,
+			requestBody: bulkMarkNotificationsRequest{
+				GithubIDs: []string{"id1"},
+				AccountID: 2,
+			},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					BulkUpdate(
+						gomock.Any(),
+						models.BulkOpMarkRead,
+						models.BulkOperationTarget{IDs: []string{"id1"}, AccountID: 2},
+						gomock.Any(),
+					).
+					Return(int64(1), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:      "success with query",
 			operation: BulkOpMarkRead,
@@ -187,10 +206,10 @@ func TestHandler_handleBulkAssignTag(t *testing.T) {
 					GetTag(gomock.Any(), int64(1)).
 					Return(db.Tag{ID: 1, Name: "test"}, nil)
 				mockSvc.EXPECT().
-					GetByGithubID(gomock.Any(), "id1").
+					GetByGithubID(gomock.Any(), models.DefaultAccountID, "id1").
 					Return(db.Notification{GithubID: "id1"}, nil)
 				mockSvc.EXPECT().
-					GetByGithubID(gomock.Any(), "id2").
+					GetByGithubID(gomock.Any(), models.DefaultAccountID, "id2").
 					Return(db.Notification{GithubID: "id2"}, nil)
 				mockSvc.EXPECT().
 					BulkAssignTag(gomock.Any(), gomock.Any(), int64(1)).
@@ -259,7 +278,7 @@ func TestHandler_handleBulkAssignTag(t *testing.T) {
 					GetTag(gomock.Any(), int64(1)).
 					Return(db.Tag{ID: 1, Name: "test"}, nil)
 				mockSvc.EXPECT().
-					GetByGithubID(gomock.Any(), "not-found").
+					GetByGithubID(gomock.Any(), models.DefaultAccountID, "not-found").
 					Return(db.Notification{}, sql.ErrNoRows)
 			},
 			expectedStatus: http.StatusOK,
@@ -310,10 +329,10 @@ func TestHandler_handleBulkRemoveTag(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					GetByGithubID(gomock.Any(), "id1").
+					GetByGithubID(gomock.Any(), models.DefaultAccountID, "id1").
 					Return(db.Notification{GithubID: "id1"}, nil)
 				mockSvc.EXPECT().
-					GetByGithubID(gomock.Any(), "id2").
+					GetByGithubID(gomock.Any(), models.DefaultAccountID, "id2").
 					Return(db.Notification{GithubID: "id2"}, nil)
 				mockSvc.EXPECT().
 					BulkRemoveTag(gomock.Any(), gomock.Any(), int64(1)).
@@ -366,7 +385,7 @@ func TestHandler_handleBulkRemoveTag(t *testing.T) {
 			},
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					GetByGithubID(gomock.Any(), "not-found").
+					GetByGithubID(gomock.Any(), models.DefaultAccountID, "not-found").
 					Return(db.Notification{}, sql.ErrNoRows)
 			},
 			expectedStatus: http.StatusOK,
@@ -24,7 +24,6 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/core/tag"
 	timelinesvc "github.com/ajbeattie/octobud/backend/internal/core/timeline"
 	"github.com/ajbeattie/octobud/backend/internal/db"
-	githubinterfaces "github.com/ajbeattie/octobud/backend/internal/github/interfaces"
 	"github.com/ajbeattie/octobud/backend/internal/sync"
 )
 
@@ -36,8 +35,7 @@ type Handler struct {
 	repositorySvc repository.RepositoryService
 	tagSvc        tag.TagService
 	timelineSvc   *timelinesvc.Service
-	githubClient  githubinterfaces.Client
-	syncService   *sync.Service
+	syncServices  map[int64]*sync.Service // by account ID; accounts without a token are missing
 	riverClient   db.RiverClient
 }
 
@@ -49,8 +47,7 @@ func New(
 	repositorySvc repository.RepositoryService,
 	tagSvc tag.TagService,
 	timelineSvc *timelinesvc.Service,
-	githubClient githubinterfaces.Client,
-	syncService *sync.Service,
+	syncServices map[int64]*sync.Service,
 	riverClient db.RiverClient,
 ) *Handler {
 	return &Handler{
@@ -60,8 +57,7 @@ func New(
 		repositorySvc: repositorySvc,
 		tagSvc:        tagSvc,
 		timelineSvc:   timelineSvc,
-		githubClient:  githubClient,
-		syncService:   syncService,
+		syncServices:  syncServices,
 		riverClient:   riverClient,
 	}
 }
@@ -30,6 +30,8 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/api/shared"
 	"github.com/ajbeattie/octobud/backend/internal/core/notification"
 	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/query"
+	"github.com/ajbeattie/octobud/backend/internal/sync"
 )
 
 // Error definitions
@@ -49,6 +51,7 @@ var (
 	ErrGitHubClientTypeMismatch          = errors.New("GitHub client type mismatch")
 	ErrFailedToFetchTimeline             = errors.New("failed to fetch timeline")
 	ErrFailedToFetchTags                 = errors.New("failed to fetch tags")
+	ErrFailedToMarkNotificationViewed    = errors.New("failed to mark notification viewed")
 )
 
 func (h *Handler) handleListNotifications(w http.ResponseWriter, r *http.Request) {
@@ -65,9 +68,11 @@ func (h *Handler) handleListNotifications(w http.ResponseWriter, r *http.Request
 				zap.String("query", options.Query),
 				zap.Error(err),
 			)
-			// Extract the error message for the client
-			errorMsg := getQueryErrorMessage(err)
-			shared.WriteError(w, http.StatusBadRequest, errorMsg)
+			// Return the message plus positioned errors so the client can mark the bad spans
+			shared.WriteJSON(w, http.StatusBadRequest, queryErrorResponse{
+				Error:       getQueryErrorMessage(err),
+				QueryErrors: query.QueryErrors(err),
+			})
 			return
 		}
 
@@ -152,8 +157,19 @@ func (h *Handler) handleGetNotification(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	accountID, err := accountIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid accountId")
+		return
+	}
+
 	queryStr := r.URL.Query().Get("query")
-	notification, err := h.notifications.GetNotificationWithDetails(ctx, githubID, queryStr)
+	notification, err := h.notifications.GetNotificationWithDetails(
+		ctx,
+		accountID,
+		githubID,
+		queryStr,
+	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			h.logger.Debug("notification not found", zap.String("github_id", githubID))
@@ -169,22 +185,21 @@ func (h *Handler) handleGetNotification(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Opening a notification makes background subject refreshes get to it first
+	if err := h.notifications.MarkNotificationViewed(ctx, accountID, githubID); err != nil {
+		h.logger.Warn(
+			"failed to mark notification viewed",
+			zap.String("github_id", githubID),
+			zap.Error(errors.Join(ErrFailedToMarkNotificationViewed, err)),
+		)
+	}
+
 	shared.WriteJSON(w, http.StatusOK, notificationDetailResponse{Notification: notification})
 }
 
 func (h *Handler) handleRefreshNotificationSubject(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Check if sync service is available
-	if h.syncService == nil {
-		h.logger.Warn(
-			"sync service not available for refresh",
-			zap.Error(ErrSyncServiceNotAvailable),
-		)
-		shared.WriteError(w, http.StatusServiceUnavailable, "subject refresh not available")
-		return
-	}
-
 	rawGithubID := chi.URLParam(r, "githubID")
 	if rawGithubID == "" {
 		shared.WriteError(w, http.StatusBadRequest, "githubID is required")
@@ -202,8 +217,26 @@ func (h *Handler) handleRefreshNotificationSubject(w http.ResponseWriter, r *htt
 		return
 	}
 
+	accountID, err := accountIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid accountId")
+		return
+	}
+
+	// Check if the notification's account has a sync service (i.e. a GitHub token)
+	syncService := h.syncServices[accountID]
+	if syncService == nil {
+		h.logger.Warn(
+			"sync service not available for refresh",
+			zap.Int64("account_id", accountID),
+			zap.Error(ErrSyncServiceNotAvailable),
+		)
+		shared.WriteError(w, http.StatusServiceUnavailable, "subject refresh not available")
+		return
+	}
+
 	// Verify the notification exists
-	_, err = h.notifications.GetByGithubID(ctx, githubID)
+	_, err = h.notifications.GetByGithubID(ctx, accountID, githubID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			h.logger.Debug("notification not found for refresh", zap.String("github_id", githubID))
@@ -220,7 +253,7 @@ func (h *Handler) handleRefreshNotificationSubject(w http.ResponseWriter, r *htt
 	}
 
 	// Refresh the subject by fetching fresh data from GitHub
-	err = h.refreshSubjectData(ctx, githubID)
+	err = h.refreshSubjectData(ctx, syncService, githubID)
 	if err != nil {
 		h.logger.Error(
 			"failed to refresh subject data",
@@ -233,7 +266,12 @@ func (h *Handler) handleRefreshNotificationSubject(w http.ResponseWriter, r *htt
 
 	// Get the updated notification with details
 	queryStr := r.URL.Query().Get("query")
-	updatedNotification, err := h.notifications.GetNotificationWithDetails(ctx, githubID, queryStr)
+	updatedNotification, err := h.notifications.GetNotificationWithDetails(
+		ctx,
+		accountID,
+		githubID,
+		queryStr,
+	)
 	if err != nil {
 		h.logger.Error(
 			"failed to load updated notification",
@@ -291,6 +329,15 @@ func getQueryErrorMessage(err error) string {
 		return "Invalid query"
 	}
 
+	// Prefer the positioned errors reported by the query parser and validator
+	if queryErrs := query.QueryErrors(err); len(queryErrs) > 0 {
+		messages := make([]string, 0, len(queryErrs))
+		for _, queryErr := range queryErrs {
+			messages = append(messages, queryErr.Message)
+		}
+		return strings.Join(messages, "; ")
+	}
+
 	// Try to extract the underlying error message
 	errStr := err.Error()
 
@@ -313,13 +360,28 @@ func getQueryErrorMessage(err error) string {
 	return errStr
 }
 
-// refreshSubjectData fetches fresh subject data from GitHub and updates the notification
-func (h *Handler) refreshSubjectData(ctx context.Context, githubID string) error {
-	if h.syncService == nil {
-		return ErrSyncServiceNotAvailable
+// accountIDParam reads the accountId query parameter naming the GitHub account a
+// notification belongs to. Thread IDs are only unique within an account; requests that
+// leave it out mean the default account.
+func accountIDParam(r *http.Request) (int64, error) {
+	raw := r.URL.Query().Get("accountId")
+	if raw == "" {
+		return models.DefaultAccountID, nil
+	}
+	accountID, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || accountID <= 0 {
+		return 0, errors.New("invalid accountId")
 	}
+	return accountID, nil
+}
 
-	err := h.syncService.RefreshSubjectData(ctx, githubID)
+// refreshSubjectData fetches fresh subject data from GitHub and updates the notification
+func (h *Handler) refreshSubjectData(
+	ctx context.Context,
+	syncService *sync.Service,
+	githubID string,
+) error {
+	err := syncService.RefreshSubjectData(ctx, githubID)
 	if err != nil {
 		return errors.Join(ErrFailedToRefreshSubjectData, err)
 	}
@@ -31,11 +31,15 @@ import (
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
 
+	"github.com/ajbeattie/octobud/backend/internal/core/notification"
 	notificationmocks "github.com/ajbeattie/octobud/backend/internal/core/notification/mocks"
 	repositorymocks "github.com/ajbeattie/octobud/backend/internal/core/repository/mocks"
 	tagmocks "github.com/ajbeattie/octobud/backend/internal/core/tag/mocks"
 	dbmocks "github.com/ajbeattie/octobud/backend/internal/db/mocks"
 	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/query"
+	"github.com/ajbeattie/octobud/backend/internal/query/parse"
+	"github.com/ajbeattie/octobud/backend/internal/sync"
 )
 
 func setupTestHandler(
@@ -54,8 +58,7 @@ func setupTestHandler(
 		repositorySvc: mockRepositorySvc,
 		tagSvc:        mockTagSvc,
 		timelineSvc:   nil, // Set in individual tests if needed
-		githubClient:  nil, // Set in individual tests if needed
-		syncService:   nil, // Set in individual tests if needed
+		syncServices:  nil, // Set in individual tests if needed
 		riverClient:   mockRiverClient,
 	}
 
@@ -141,6 +144,34 @@ func TestHandler_handleListNotifications(t *testing.T) {
 				require.Equal(t, "database error", response["error"])
 			},
 		},
+		{
+			name:        "invalid query returns positioned errors",
+			queryParams: map[string]string{"query": "is:unread reasn:mention"},
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				_, queryErr := query.ParseAndValidate(
+					"is:unread reasn:mention",
+					query.Definitions{},
+				)
+				mockSvc.EXPECT().
+					ListNotifications(gomock.Any(), gomock.Any()).
+					Return(
+						models.ListDetailsResult{},
+						errors.Join(notification.ErrInvalidQuery, queryErr),
+					)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response queryErrorResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, "unknown field: reasn (did you mean reason:?)", response.Error)
+				require.Len(t, response.QueryErrors, 1)
+				require.Equal(t, parse.CodeUnknownField, response.QueryErrors[0].Code)
+				require.Equal(t, 10, response.QueryErrors[0].Start)
+				require.Equal(t, 15, response.QueryErrors[0].End)
+				require.Equal(t, []string{"reason"}, response.QueryErrors[0].Suggestions)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -188,8 +219,9 @@ func TestHandler_handleGetNotification(t *testing.T) {
 			queryStr: "",
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					GetNotificationWithDetails(gomock.Any(), "test-id", "").
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "test-id", "").
 					Return(models.Notification{ID: 1, GithubID: "test-id", SubjectTitle: "Test"}, nil)
+				mockSvc.EXPECT().MarkNotificationViewed(gomock.Any(), models.DefaultAccountID, "test-id").Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -199,6 +231,19 @@ func TestHandler_handleGetNotification(t *testing.T) {
 				require.Equal(t, "test-id", response.Notification.GithubID)
 			},
 		},
+		{
+			name:     "failure to mark viewed still returns notification",
+			githubID: "test-id",
+			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
+				mockSvc.EXPECT().
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "test-id", "").
+					Return(models.Notification{ID: 1, GithubID: "test-id"}, nil)
+				mockSvc.EXPECT().
+					MarkNotificationViewed(gomock.Any(), models.DefaultAccountID, "test-id").
+					Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:           "missing githubID returns 400",
 			githubID:       "",
@@ -216,7 +261,7 @@ func TestHandler_handleGetNotification(t *testing.T) {
 			githubID: "not-found",
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					GetNotificationWithDetails(gomock.Any(), "not-found", "").
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "not-found", "").
 					Return(models.Notification{}, sql.ErrNoRows)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -226,7 +271,7 @@ func TestHandler_handleGetNotification(t *testing.T) {
 			githubID: "test-id",
 			setupMock: func(mockSvc *notificationmocks.MockNotificationService) {
 				mockSvc.EXPECT().
-					GetNotificationWithDetails(gomock.Any(), "test-id", "").
+					GetNotificationWithDetails(gomock.Any(), models.DefaultAccountID, "test-id", "").
 					Return(models.Notification{}, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -264,3 +309,53 @@ func TestHandler_handleGetNotification(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_handleRefreshNotificationSubject_Unavailable(t *testing.T) {
+	tests := []struct {
+		name           string
+		syncServices   map[int64]*sync.Service
+		accountID      string
+		expectedStatus int
+	}{
+		{
+			name:           "no GitHub token returns 503",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "account without a sync service returns 503",
+			syncServices:   map[int64]*sync.Service{2: {}},
+			accountID:      "1",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "invalid accountId returns 400",
+			syncServices:   map[int64]*sync.Service{1: {}},
+			accountID:      "work",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, _, _ := setupTestHandler(ctrl)
+			handler.syncServices = tt.syncServices
+
+			req := createRequest(http.MethodPost, "/notifications/test-id/refresh-subject", nil)
+			if tt.accountID != "" {
+				req.URL.RawQuery = "accountId=" + tt.accountID
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("githubID", "test-id")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			handler.handleRefreshNotificationSubject(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
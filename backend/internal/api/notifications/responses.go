@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/query"
 )
 
 // NotificationResponse is the response type for a single notification
@@ -46,7 +47,10 @@ type ThreadItem struct {
 	Message     *string      `json:"message,omitempty"`     // For commits
 	SHA         *string      `json:"sha,omitempty"`         // For commits
 	HTMLURL     string       `json:"htmlUrl"`
-	Timestamp   time.Time    `json:"-"` // Internal field for sorting
+	IsAnswer    bool         `json:"isAnswer,omitempty"`    // For discussion comments
+	UpvoteCount int          `json:"upvoteCount,omitempty"` // For discussion comments
+	Replies     []ThreadItem `json:"replies,omitempty"`     // For discussion comments
+	Timestamp   time.Time    `json:"-"`                     // Internal field for sorting
 }
 
 // ThreadAuthor represents the author of a comment, review, or event.
@@ -72,6 +76,13 @@ type listNotificationsResponse struct {
 	PageSize      int                    `json:"pageSize"`
 }
 
+// queryErrorResponse is the response type for a list request with an invalid query.
+// QueryErrors carries the offsets of each problem so the client can underline it.
+type queryErrorResponse struct {
+	Error       string              `json:"error"`
+	QueryErrors []*query.QueryError `json:"queryErrors,omitempty"`
+}
+
 type notificationDetailResponse struct {
 	Notification NotificationResponse `json:"notification"`
 }
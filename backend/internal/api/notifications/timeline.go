@@ -36,6 +36,11 @@ import (
 func (h *Handler) handleGetNotificationTimeline(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	githubID := chi.URLParam(r, "githubID")
+	accountID, err := accountIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid accountId")
+		return
+	}
 
 	// Parse query parameters
 	perPageStr := r.URL.Query().Get("per_page")
@@ -57,7 +62,7 @@ func (h *Handler) handleGetNotificationTimeline(w http.ResponseWriter, r *http.R
 	}
 
 	// Fetch notification from database
-	notification, err := h.notifications.GetByGithubID(ctx, githubID)
+	notification, err := h.notifications.GetByGithubID(ctx, accountID, githubID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			h.logger.Debug("notification not found for timeline", zap.String("github_id", githubID))
@@ -75,7 +80,6 @@ func (h *Handler) handleGetNotificationTimeline(w http.ResponseWriter, r *http.R
 	}
 
 	// Check if this notification type supports timeline before attempting to fetch
-	// This is important for Discussions which don't have a REST API timeline endpoint
 	if !timeline.SupportsTimeline(notification.SubjectType) {
 		h.logger.Debug(
 			"timeline not supported for notification type",
@@ -92,6 +96,18 @@ func (h *Handler) handleGetNotificationTimeline(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// Ensure we have a GitHub client for the notification's account and a timeline service
+	syncService := h.syncServices[accountID]
+	if syncService == nil || h.timelineSvc == nil {
+		h.logger.Error(
+			"GitHub client or timeline service not configured",
+			zap.String("github_id", githubID),
+			zap.Error(ErrGitHubClientNotConfigured),
+		)
+		shared.WriteError(w, http.StatusInternalServerError, "GitHub client not configured")
+		return
+	}
+
 	// Extract subject info
 	subjectURL := ""
 	if notification.SubjectUrl.Valid {
@@ -103,7 +119,11 @@ func (h *Handler) handleGetNotificationTimeline(w http.ResponseWriter, r *http.R
 		subjectRaw = notification.SubjectRaw.RawMessage
 	}
 
-	subjectInfo, err := github.ExtractSubjectInfo(subjectURL, subjectRaw)
+	subjectInfo, err := github.ExtractSubjectInfo(
+		syncService.GitHubClient().Endpoints(),
+		subjectURL,
+		subjectRaw,
+	)
 	if err != nil {
 		h.logger.Error(
 			"failed to parse subject info",
@@ -118,25 +138,13 @@ func (h *Handler) handleGetNotificationTimeline(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Ensure we have a GitHub client and timeline service
-	if h.githubClient == nil || h.timelineSvc == nil {
-		h.logger.Error(
-			"GitHub client or timeline service not configured",
-			zap.String("github_id", githubID),
-			zap.Error(ErrGitHubClientNotConfigured),
-		)
-		shared.WriteError(w, http.StatusInternalServerError, "GitHub client not configured")
-		return
+	// Fetch filtered timeline using the core timeline service. Discussions have no REST
+	// timeline, so theirs is their comments.
+	fetchTimeline := h.timelineSvc.FetchFilteredTimeline
+	if timeline.IsDiscussion(notification.SubjectType) {
+		fetchTimeline = h.timelineSvc.FetchDiscussionTimeline
 	}
-
-	// Fetch filtered timeline using the core timeline service
-	result, err := h.timelineSvc.FetchFilteredTimeline(
-		ctx,
-		h.githubClient,
-		subjectInfo,
-		perPage,
-		page,
-	)
+	result, err := fetchTimeline(ctx, syncService.GitHubClient(), subjectInfo, perPage, page)
 	if err != nil {
 		h.logger.Error(
 			"failed to fetch timeline",
@@ -199,6 +207,11 @@ func convertTimelineItemToThreadItem(item models.TimelineItem) ThreadItem {
 	if item.SHA != "" {
 		threadItem.SHA = &item.SHA
 	}
+	threadItem.IsAnswer = item.IsAnswer
+	threadItem.UpvoteCount = item.UpvoteCount
+	for _, reply := range item.Replies {
+		threadItem.Replies = append(threadItem.Replies, convertTimelineItemToThreadItem(reply))
+	}
 
 	return threadItem
 }
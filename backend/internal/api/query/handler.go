@@ -0,0 +1,157 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package query provides the query language handler.
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/api/shared"
+	"github.com/ajbeattie/octobud/backend/internal/core/completion"
+	"github.com/ajbeattie/octobud/backend/internal/core/notification"
+	"github.com/ajbeattie/octobud/backend/internal/query"
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadCompletions = errors.New("failed to load query completions")
+	ErrFailedToExplainQuery    = errors.New("failed to explain query")
+)
+
+// Handler handles query language HTTP routes
+type Handler struct {
+	logger          *zap.Logger
+	completionSvc   completion.CompletionService
+	notificationSvc notification.NotificationService
+}
+
+// New creates a new query handler
+func New(
+	logger *zap.Logger,
+	completionSvc completion.CompletionService,
+	notificationSvc notification.NotificationService,
+) *Handler {
+	return &Handler{
+		logger:          logger,
+		completionSvc:   completionSvc,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Register registers query routes on the provided router
+func (h *Handler) Register(r chi.Router) {
+	r.Get("/query/complete", h.handleComplete)
+	r.Post("/query/explain", h.handleExplain)
+}
+
+// handleComplete returns completions for the cursor position in a query.
+// The cursor is a byte offset into q and defaults to the end of the query.
+func (h *Handler) handleComplete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	queryStr := r.URL.Query().Get("q")
+
+	cursor := len(queryStr)
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || parsed > len(queryStr) {
+			shared.WriteError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		cursor = parsed
+	}
+
+	completions, err := h.completionSvc.Complete(ctx, queryStr, cursor)
+	if err != nil {
+		h.logger.Error(
+			"failed to load query completions",
+			zap.String("query", queryStr),
+			zap.Error(errors.Join(ErrFailedToLoadCompletions, err)),
+		)
+		shared.WriteError(w, http.StatusInternalServerError, "failed to load completions")
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, completions)
+}
+
+// ExplainRequest is the request body for POST /api/query/explain
+type ExplainRequest struct {
+	Query       string `json:"query"`
+	IncludePlan bool   `json:"includePlan"`
+}
+
+// queryErrorResponse is the response type for an explain request with an invalid query.
+// QueryErrors carries the offsets of each problem so the client can underline it.
+type queryErrorResponse struct {
+	Error       string              `json:"error"`
+	QueryErrors []*query.QueryError `json:"queryErrors,omitempty"`
+}
+
+// handleExplain shows how a query is parsed, which defaults apply, the SQL it runs
+// and how many notifications each top-level clause matches.
+func (h *Handler) handleExplain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req ExplainRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode explain request", zap.Error(err))
+		shared.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	explanation, err := h.notificationSvc.ExplainQuery(ctx, req.Query, req.IncludePlan)
+	if err != nil {
+		if errors.Is(err, notification.ErrInvalidQuery) {
+			shared.WriteJSON(w, http.StatusBadRequest, queryErrorResponse{
+				Error:       queryErrorMessage(err),
+				QueryErrors: query.QueryErrors(err),
+			})
+			return
+		}
+
+		h.logger.Error(
+			"failed to explain query",
+			zap.String("query", req.Query),
+			zap.Error(errors.Join(ErrFailedToExplainQuery, err)),
+		)
+		shared.WriteError(w, http.StatusInternalServerError, "failed to explain query")
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, explanation)
+}
+
+// queryErrorMessage joins the positioned query error messages into one line
+func queryErrorMessage(err error) string {
+	queryErrs := query.QueryErrors(err)
+	if len(queryErrs) == 0 {
+		return "Invalid query"
+	}
+
+	messages := make([]string, 0, len(queryErrs))
+	for _, queryErr := range queryErrs {
+		messages = append(messages, queryErr.Message)
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,224 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	completionmocks "github.com/ajbeattie/octobud/backend/internal/core/completion/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/core/notification"
+	notificationmocks "github.com/ajbeattie/octobud/backend/internal/core/notification/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/query"
+)
+
+func TestHandler_handleComplete(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		setupMock      func(*completionmocks.MockCompletionService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "returns completions for the cursor",
+			url:  "/query/complete?q=repo%3Acl+is%3Aunread&cursor=7",
+			setupMock: func(m *completionmocks.MockCompletionService) {
+				m.EXPECT().
+					Complete(gomock.Any(), "repo:cl is:unread", 7).
+					Return(models.QueryCompletions{
+						Start: 5,
+						End:   7,
+						Field: "repo",
+						Items: []models.QueryCompletion{
+							{Text: "cli/cli", Kind: models.QueryCompletionValue},
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.QueryCompletions
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, 5, response.Start)
+				require.Equal(t, 7, response.End)
+				require.Equal(t, "repo", response.Field)
+				require.Equal(t, "cli/cli", response.Items[0].Text)
+			},
+		},
+		{
+			name: "cursor defaults to the end of the query",
+			url:  "/query/complete?q=is%3A",
+			setupMock: func(m *completionmocks.MockCompletionService) {
+				m.EXPECT().
+					Complete(gomock.Any(), "is:", 3).
+					Return(models.QueryCompletions{Items: []models.QueryCompletion{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "non-numeric cursor returns 400",
+			url:            "/query/complete?q=is%3A&cursor=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "cursor past the end returns 400",
+			url:            "/query/complete?q=is%3A&cursor=4",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error returns 500",
+			url:  "/query/complete?q=repo%3A",
+			setupMock: func(m *completionmocks.MockCompletionService) {
+				m.EXPECT().
+					Complete(gomock.Any(), "repo:", 5).
+					Return(models.QueryCompletions{}, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := completionmocks.NewMockCompletionService(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+			handler := New(zap.NewNop(), mockSvc, nil)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			handler.handleComplete(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
+
+func TestHandler_handleExplain(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*notificationmocks.MockNotificationService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "returns the explanation",
+			body: `{"query":"is:unread","includePlan":true}`,
+			setupMock: func(m *notificationmocks.MockNotificationService) {
+				m.EXPECT().
+					ExplainQuery(gomock.Any(), "is:unread", true).
+					Return(models.QueryExplanation{
+						Query:    "is:unread",
+						Defaults: query.DefaultsMutedOnly,
+						Where:    []string{"n.is_read = FALSE", "n.muted = FALSE"},
+						Total:    4,
+						Clauses: []models.QueryExplanationClause{
+							{Source: query.ClauseSourceQuery, Expr: "is:unread", Count: 6},
+						},
+						Plan: []string{"Seq Scan on notifications n"},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.QueryExplanation
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, query.DefaultsMutedOnly, response.Defaults)
+				require.Equal(t, int64(4), response.Total)
+				require.Equal(t, int64(6), response.Clauses[0].Count)
+				require.Equal(t, []string{"Seq Scan on notifications n"}, response.Plan)
+			},
+		},
+		{
+			name:           "malformed body returns 400",
+			body:           `{"query":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid query returns 400 with query errors",
+			body: `{"query":"bogus:value"}`,
+			setupMock: func(m *notificationmocks.MockNotificationService) {
+				_, err := query.Explain("bogus:value", query.Definitions{})
+				m.EXPECT().
+					ExplainQuery(gomock.Any(), "bogus:value", false).
+					Return(
+						models.QueryExplanation{},
+						errors.Join(notification.ErrInvalidQuery, err),
+					)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response queryErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Contains(t, response.Error, "unknown field: bogus")
+				require.Len(t, response.QueryErrors, 1)
+				require.Equal(t, 0, response.QueryErrors[0].Start)
+				require.Equal(t, 5, response.QueryErrors[0].End)
+			},
+		},
+		{
+			name: "service error returns 500",
+			body: `{"query":"is:unread"}`,
+			setupMock: func(m *notificationmocks.MockNotificationService) {
+				m.EXPECT().
+					ExplainQuery(gomock.Any(), "is:unread", false).
+					Return(models.QueryExplanation{}, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSvc := notificationmocks.NewMockNotificationService(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+			handler := New(zap.NewNop(), nil, mockSvc)
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/query/explain",
+				strings.NewReader(tt.body),
+			)
+			w := httptest.NewRecorder()
+			handler.handleExplain(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
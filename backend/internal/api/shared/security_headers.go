@@ -20,8 +20,26 @@ import (
 	"strings"
 )
 
+// imgSources are the image sources allowed by the Content Security Policy
+const imgSources = "'self' data: https://github.com https://avatars.githubusercontent.com https://*.githubusercontent.com https://dependabot-badges.githubapp.com" //nolint:lll // URLs are long.
+
 // SecurityHeadersMiddleware adds security headers to all HTTP responses
 func SecurityHeadersMiddleware(next http.Handler) http.Handler {
+	return SecurityHeaders()(next)
+}
+
+// SecurityHeaders returns middleware that adds security headers to all HTTP responses,
+// also allowing images from extraImgSources (e.g. a GitHub Enterprise Server, which
+// serves avatars itself).
+func SecurityHeaders(extraImgSources ...string) func(http.Handler) http.Handler {
+	imgSrc := strings.Join(append([]string{imgSources}, extraImgSources...), " ")
+	return func(next http.Handler) http.Handler {
+		return securityHeaders(next, imgSrc)
+	}
+}
+
+// securityHeaders wraps next with the security headers, allowing images from imgSrc
+func securityHeaders(next http.Handler, imgSrc string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Prevent MIME type sniffing
 		w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -43,7 +61,7 @@ func SecurityHeadersMiddleware(next http.Handler) http.Handler {
 		csp := "default-src 'self'; " +
 			"script-src 'self' 'unsafe-inline' 'unsafe-eval'; " +
 			"style-src 'self' 'unsafe-inline'; " +
-			"img-src 'self' data: https://github.com https://avatars.githubusercontent.com https://*.githubusercontent.com https://dependabot-badges.githubapp.com; " + //nolint:lll // URLs are long.
+			"img-src " + imgSrc + "; " +
 			"font-src 'self' data:; " +
 			"connect-src 'self'; " +
 			"frame-ancestors 'self'; " +
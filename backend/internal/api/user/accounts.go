@@ -0,0 +1,144 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/api/auth"
+	"github.com/ajbeattie/octobud/backend/internal/api/shared"
+	"github.com/ajbeattie/octobud/backend/internal/core/account"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// HandleListAccounts handles GET /api/user/accounts
+// Returns the GitHub accounts whose notifications feed the inbox
+func (h *Handler) HandleListAccounts(w http.ResponseWriter, r *http.Request) {
+	username := auth.GetUsernameFromContext(r.Context())
+	if username == "" {
+		shared.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if h.accountSvc == nil {
+		shared.WriteError(w, http.StatusServiceUnavailable, "Account service not available")
+		return
+	}
+
+	accounts, err := h.accountSvc.ListAccounts(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list GitHub accounts", zap.Error(err))
+		shared.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := AccountsResponse{Accounts: make([]AccountResponse, 0, len(accounts))}
+	for _, acct := range accounts {
+		response.Accounts = append(response.Accounts, accountResponse(acct))
+	}
+	shared.WriteJSON(w, http.StatusOK, response)
+}
+
+// HandleUpdateAccountSyncSettings handles PUT /api/user/accounts/{id}/sync-settings
+// Sets the sync settings overriding the user's for one account
+func (h *Handler) HandleUpdateAccountSyncSettings(w http.ResponseWriter, r *http.Request) {
+	var req AccountSyncSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Debug("failed to decode account sync settings request", zap.Error(err))
+		shared.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := validateInitialSync(req.InitialSyncDays, req.InitialSyncMaxCount); msg != "" {
+		shared.WriteError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	h.updateAccountSyncSettings(w, r, &models.SyncSettings{
+		InitialSyncDays:       req.InitialSyncDays,
+		InitialSyncMaxCount:   req.InitialSyncMaxCount,
+		InitialSyncUnreadOnly: req.InitialSyncUnreadOnly,
+	})
+}
+
+// HandleDeleteAccountSyncSettings handles DELETE /api/user/accounts/{id}/sync-settings
+// Makes an account use the user's sync settings again
+func (h *Handler) HandleDeleteAccountSyncSettings(w http.ResponseWriter, r *http.Request) {
+	h.updateAccountSyncSettings(w, r, nil)
+}
+
+// updateAccountSyncSettings saves an account's sync settings (nil to use the user's) and
+// writes the updated account
+func (h *Handler) updateAccountSyncSettings(
+	w http.ResponseWriter,
+	r *http.Request,
+	settings *models.SyncSettings,
+) {
+	username := auth.GetUsernameFromContext(r.Context())
+	if username == "" {
+		shared.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if h.accountSvc == nil {
+		shared.WriteError(w, http.StatusServiceUnavailable, "Account service not available")
+		return
+	}
+
+	accountID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || accountID <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, "Invalid account id")
+		return
+	}
+
+	updated, err := h.accountSvc.UpdateSyncSettings(r.Context(), accountID, settings)
+	if err != nil {
+		if errors.Is(err, account.ErrAccountNotFound) {
+			shared.WriteError(w, http.StatusNotFound, "Account not found")
+			return
+		}
+		h.logger.Error("failed to update account sync settings",
+			zap.Int64("accountID", accountID),
+			zap.Error(err))
+		shared.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, accountResponse(updated))
+}
+
+// accountResponse converts an account for the frontend
+func accountResponse(acct models.GitHubAccount) AccountResponse {
+	response := AccountResponse{
+		ID:     acct.ID,
+		Name:   acct.Name,
+		APIURL: acct.APIURL,
+		WebURL: acct.WebURL,
+	}
+	if acct.SyncSettings != nil {
+		response.SyncSettings = &AccountSyncSettingsResponse{
+			InitialSyncDays:       acct.SyncSettings.InitialSyncDays,
+			InitialSyncMaxCount:   acct.SyncSettings.InitialSyncMaxCount,
+			InitialSyncUnreadOnly: acct.SyncSettings.InitialSyncUnreadOnly,
+		}
+	}
+	return response
+}
@@ -0,0 +1,257 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/api/auth"
+	"github.com/ajbeattie/octobud/backend/internal/core/account"
+	accountmocks "github.com/ajbeattie/octobud/backend/internal/core/account/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+func TestHandler_HandleListAccounts(t *testing.T) {
+	days := 7
+	tests := []struct {
+		name           string
+		authenticated  bool
+		setupMock      func(*accountmocks.MockAccountService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "success returns accounts",
+			authenticated: true,
+			setupMock: func(m *accountmocks.MockAccountService) {
+				m.EXPECT().ListAccounts(gomock.Any()).Return([]models.GitHubAccount{
+					{
+						ID:     1,
+						Name:   "default",
+						APIURL: "https://api.github.com",
+						WebURL: "https://github.com",
+					},
+					{
+						ID:           2,
+						Name:         "work",
+						APIURL:       "https://ghe.example.com/api/v3",
+						WebURL:       "https://ghe.example.com",
+						SyncSettings: &models.SyncSettings{InitialSyncDays: &days},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response AccountsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, []AccountResponse{
+					{
+						ID:     1,
+						Name:   "default",
+						APIURL: "https://api.github.com",
+						WebURL: "https://github.com",
+					},
+					{
+						ID:           2,
+						Name:         "work",
+						APIURL:       "https://ghe.example.com/api/v3",
+						WebURL:       "https://ghe.example.com",
+						SyncSettings: &AccountSyncSettingsResponse{InitialSyncDays: &days},
+					},
+				}, response.Accounts)
+			},
+		},
+		{
+			name:           "missing username returns 401",
+			authenticated:  false,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "service error returns 500",
+			authenticated: true,
+			setupMock: func(m *accountmocks.MockAccountService) {
+				m.EXPECT().ListAccounts(gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccounts := accountmocks.NewMockAccountService(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockAccounts)
+			}
+			handler, _ := setupTestHandler(ctrl)
+			handler.WithAccountService(mockAccounts)
+
+			req := createRequest(http.MethodGet, "/api/user/accounts", nil)
+			if tt.authenticated {
+				req = req.WithContext(auth.SetUsernameInContext(req.Context(), "admin"))
+			}
+			w := httptest.NewRecorder()
+
+			handler.HandleListAccounts(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
+
+func TestHandler_HandleListAccounts_ServiceUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, _ := setupTestHandler(ctrl)
+	req := createRequest(http.MethodGet, "/api/user/accounts", nil)
+	req = req.WithContext(auth.SetUsernameInContext(req.Context(), "admin"))
+	w := httptest.NewRecorder()
+
+	handler.HandleListAccounts(w, req)
+
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestHandler_HandleUpdateAccountSyncSettings(t *testing.T) {
+	days := 14
+	tests := []struct {
+		name           string
+		accountID      string
+		requestBody    interface{}
+		setupMock      func(*accountmocks.MockAccountService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "success saves settings",
+			accountID:   "2",
+			requestBody: AccountSyncSettingsRequest{InitialSyncDays: &days},
+			setupMock: func(m *accountmocks.MockAccountService) {
+				settings := &models.SyncSettings{InitialSyncDays: &days}
+				m.EXPECT().
+					UpdateSyncSettings(gomock.Any(), int64(2), settings).
+					Return(models.GitHubAccount{ID: 2, Name: "work", SyncSettings: settings}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response AccountResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, "work", response.Name)
+				require.Equal(
+					t,
+					&AccountSyncSettingsResponse{InitialSyncDays: &days},
+					response.SyncSettings,
+				)
+			},
+		},
+		{
+			name:           "invalid days returns 400",
+			accountID:      "2",
+			requestBody:    map[string]int{"initialSyncDays": 0},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid id returns 400",
+			accountID:      "work",
+			requestBody:    AccountSyncSettingsRequest{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "unknown account returns 404",
+			accountID:   "9",
+			requestBody: AccountSyncSettingsRequest{},
+			setupMock: func(m *accountmocks.MockAccountService) {
+				m.EXPECT().
+					UpdateSyncSettings(gomock.Any(), int64(9), gomock.Any()).
+					Return(models.GitHubAccount{}, account.ErrAccountNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccounts := accountmocks.NewMockAccountService(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockAccounts)
+			}
+			handler, _ := setupTestHandler(ctrl)
+			handler.WithAccountService(mockAccounts)
+
+			req := createRequest(
+				http.MethodPut,
+				"/api/user/accounts/"+tt.accountID+"/sync-settings",
+				tt.requestBody,
+			)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.accountID)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(auth.SetUsernameInContext(ctx, "admin"))
+			w := httptest.NewRecorder()
+
+			handler.HandleUpdateAccountSyncSettings(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
+
+func TestHandler_HandleDeleteAccountSyncSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccounts := accountmocks.NewMockAccountService(ctrl)
+	mockAccounts.EXPECT().
+		UpdateSyncSettings(gomock.Any(), int64(2), (*models.SyncSettings)(nil)).
+		Return(models.GitHubAccount{ID: 2, Name: "work"}, nil)
+	handler, _ := setupTestHandler(ctrl)
+	handler.WithAccountService(mockAccounts)
+
+	req := createRequest(http.MethodDelete, "/api/user/accounts/2/sync-settings", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "2")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	req = req.WithContext(auth.SetUsernameInContext(ctx, "admin"))
+	w := httptest.NewRecorder()
+
+	handler.HandleDeleteAccountSyncSettings(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response AccountResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Nil(t, response.SyncSettings)
+}
@@ -30,9 +30,12 @@ import (
 
 	"github.com/ajbeattie/octobud/backend/internal/api/auth"
 	"github.com/ajbeattie/octobud/backend/internal/api/shared"
+	"github.com/ajbeattie/octobud/backend/internal/core/account"
 	authsvc "github.com/ajbeattie/octobud/backend/internal/core/auth"
 	"github.com/ajbeattie/octobud/backend/internal/core/syncstate"
 	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
 )
 
 // Handler handles user-related HTTP routes
@@ -46,6 +49,8 @@ type Handler struct {
 	secureCookies   bool                       // Force secure cookies (overrides auto-detection if true)
 	riverClient     db.RiverClient             // Optional: for queueing sync jobs
 	syncStateSvc    syncstate.SyncStateService // Optional: for sync state operations
+	accountSvc      account.AccountService     // Optional: for GitHub account operations
+	githubEndpoints types.Endpoints            // Optional: defaults to github.com
 }
 
 // New creates a new user handler
@@ -81,6 +86,18 @@ func (h *Handler) WithSyncStateService(svc syncstate.SyncStateService) *Handler
 	return h
 }
 
+// WithAccountService sets the GitHub account service for account operations
+func (h *Handler) WithAccountService(svc account.AccountService) *Handler {
+	h.accountSvc = svc
+	return h
+}
+
+// WithGitHubEndpoints sets the GitHub instance reported to the frontend, for building links
+func (h *Handler) WithGitHubEndpoints(endpoints types.Endpoints) *Handler {
+	h.githubEndpoints = endpoints
+	return h
+}
+
 // Register registers user routes on the provided router
 func (h *Handler) Register(r chi.Router) {
 	r.Route("/user", func(r chi.Router) {
@@ -92,7 +109,11 @@ func (h *Handler) Register(r chi.Router) {
 		r.Get("/sync-settings", h.HandleGetSyncSettings)
 		r.Put("/sync-settings", h.HandleUpdateSyncSettings)
 		r.Get("/sync-state", h.HandleGetSyncState)
+		r.Get("/sync-runs", h.HandleListSyncRuns)
 		r.Post("/sync-older", h.HandleSyncOlder)
+		r.Get("/accounts", h.HandleListAccounts)
+		r.Put("/accounts/{id}/sync-settings", h.HandleUpdateAccountSyncSettings)
+		r.Delete("/accounts/{id}/sync-settings", h.HandleDeleteAccountSyncSettings)
 	})
 }
 
@@ -319,8 +340,15 @@ func (h *Handler) HandleGetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	endpoints := h.githubEndpoints
+	if endpoints == (types.Endpoints{}) {
+		endpoints = github.DefaultEndpoints()
+	}
+
 	response := UserResponse{
-		Username: username,
+		Username:     username,
+		GitHubAPIURL: endpoints.APIURL,
+		GitHubWebURL: endpoints.WebURL,
 	}
 
 	// Include sync settings if they exist
@@ -330,6 +358,7 @@ func (h *Handler) HandleGetCurrentUser(w http.ResponseWriter, r *http.Request) {
 			InitialSyncMaxCount:   user.SyncSettings.InitialSyncMaxCount,
 			InitialSyncUnreadOnly: user.SyncSettings.InitialSyncUnreadOnly,
 			SetupCompleted:        user.SyncSettings.SetupCompleted,
+			WriteBackToGitHub:     user.SyncSettings.WriteBackToGitHub,
 		}
 	}
 
@@ -35,6 +35,7 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/api/auth"
 	authsvc "github.com/ajbeattie/octobud/backend/internal/core/auth"
 	"github.com/ajbeattie/octobud/backend/internal/core/auth/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/github"
 	"github.com/ajbeattie/octobud/backend/internal/models"
 )
 
@@ -231,6 +232,8 @@ func TestHandler_HandleGetCurrentUser(t *testing.T) {
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				require.NoError(t, err)
 				require.Equal(t, "admin", response.Username)
+				require.Equal(t, "https://api.github.com", response.GitHubAPIURL)
+				require.Equal(t, "https://github.com", response.GitHubWebURL)
 			},
 		},
 		{
@@ -268,6 +271,27 @@ func TestHandler_HandleGetCurrentUser(t *testing.T) {
 	}
 }
 
+func TestHandler_HandleGetCurrentUser_GitHubEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, mockService := setupTestHandler(ctrl)
+	handler.WithGitHubEndpoints(github.NewEndpoints("https://github.example.com/api/v3", ""))
+	mockService.EXPECT().GetUser(gomock.Any()).Return(&models.User{Username: "admin"}, nil)
+
+	req := createRequest(http.MethodGet, "/api/user/me", nil)
+	req = req.WithContext(auth.SetUsernameInContext(req.Context(), "admin"))
+	w := httptest.NewRecorder()
+
+	handler.HandleGetCurrentUser(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response UserResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, "https://github.example.com/api/v3", response.GitHubAPIURL)
+	require.Equal(t, "https://github.example.com", response.GitHubWebURL)
+}
+
 func TestHandler_HandleUpdateCredentials(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -491,6 +515,10 @@ func intPtr(i int) *int {
 	return &i
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 type errorResponse struct {
 	Error string `json:"error"`
 }
@@ -32,6 +32,9 @@ type LoginResponse struct {
 type UserResponse struct { //nolint:revive // exported type name stutters with package name
 	Username     string                `json:"username"`
 	SyncSettings *SyncSettingsResponse `json:"syncSettings,omitempty"`
+	// GitHubAPIURL and GitHubWebURL are the base URLs of the GitHub instance synced from
+	GitHubAPIURL string `json:"githubApiUrl"`
+	GitHubWebURL string `json:"githubWebUrl"`
 }
 
 // SyncSettingsResponse represents the user's sync settings
@@ -40,6 +43,7 @@ type SyncSettingsResponse struct {
 	InitialSyncMaxCount   *int `json:"initialSyncMaxCount,omitempty"`
 	InitialSyncUnreadOnly bool `json:"initialSyncUnreadOnly"`
 	SetupCompleted        bool `json:"setupCompleted"`
+	WriteBackToGitHub     bool `json:"writeBackToGitHub"`
 }
 
 // UpdateCredentialsRequest represents the request to update credentials
@@ -55,6 +59,8 @@ type SyncSettingsRequest struct {
 	InitialSyncMaxCount   *int `json:"initialSyncMaxCount,omitempty"`
 	InitialSyncUnreadOnly bool `json:"initialSyncUnreadOnly"`
 	SetupCompleted        bool `json:"setupCompleted"`
+	// WriteBackToGitHub keeps its current value when omitted
+	WriteBackToGitHub *bool `json:"writeBackToGitHub,omitempty"`
 }
 
 // SyncOlderRequest represents the request to sync older notifications
@@ -65,8 +71,87 @@ type SyncOlderRequest struct {
 	BeforeDate *string `json:"beforeDate,omitempty"` // Optional: override the "before" date (RFC3339 format)
 }
 
+// AccountSyncSettingsRequest represents the request to override the user's sync settings
+// for one GitHub account
+type AccountSyncSettingsRequest struct {
+	InitialSyncDays       *int `json:"initialSyncDays,omitempty"`
+	InitialSyncMaxCount   *int `json:"initialSyncMaxCount,omitempty"`
+	InitialSyncUnreadOnly bool `json:"initialSyncUnreadOnly"`
+}
+
+// AccountSyncSettingsResponse represents the sync settings overriding the user's for an account
+type AccountSyncSettingsResponse struct {
+	InitialSyncDays       *int `json:"initialSyncDays,omitempty"`
+	InitialSyncMaxCount   *int `json:"initialSyncMaxCount,omitempty"`
+	InitialSyncUnreadOnly bool `json:"initialSyncUnreadOnly"`
+}
+
+// AccountResponse represents a GitHub account whose notifications feed the inbox
+type AccountResponse struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	APIURL string `json:"apiUrl"`
+	WebURL string `json:"webUrl"`
+	// SyncSettings are omitted when the account uses the user's sync settings
+	SyncSettings *AccountSyncSettingsResponse `json:"syncSettings,omitempty"`
+}
+
+// AccountsResponse represents the list of GitHub accounts
+type AccountsResponse struct {
+	Accounts []AccountResponse `json:"accounts"`
+}
+
 // SyncStateResponse represents the sync state information for the frontend
 type SyncStateResponse struct {
-	OldestNotificationSyncedAt *string `json:"oldestNotificationSyncedAt,omitempty"`
-	InitialSyncCompletedAt     *string `json:"initialSyncCompletedAt,omitempty"`
+	OldestNotificationSyncedAt *string            `json:"oldestNotificationSyncedAt,omitempty"`
+	InitialSyncCompletedAt     *string            `json:"initialSyncCompletedAt,omitempty"`
+	RateLimit                  *RateLimitResponse `json:"rateLimit,omitempty"`
+	// Reconciliation is omitted until notifications have been reconciled with GitHub
+	Reconciliation *ReconciliationResponse `json:"reconciliation,omitempty"`
+}
+
+// RateLimitResponse is the GitHub rate limit budget as last seen by the sync worker
+type RateLimitResponse struct {
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	ResetAt   string `json:"resetAt"`   // RFC3339
+	UpdatedAt string `json:"updatedAt"` // RFC3339, when the worker last saw it
+}
+
+// ReconciliationResponse is what the latest reconciliation with GitHub's list changed
+type ReconciliationResponse struct {
+	ReconciledAt string `json:"reconciledAt"` // RFC3339
+	Policy       string `json:"policy"`       // archive, flag or delete
+	Checked      int    `json:"checked"`      // Unarchived notifications compared
+	Archived     int    `json:"archived"`
+	Flagged      int    `json:"flagged"`
+	Deleted      int    `json:"deleted"`
+	Unflagged    int    `json:"unflagged"` // Stale notifications GitHub returned again
+}
+
+// SyncRunResponse is one sync or backfill run from the sync run history
+type SyncRunResponse struct {
+	ID                   int64   `json:"id"`
+	AccountID            int64   `json:"accountId"`
+	Kind                 string  `json:"kind"`                   // sync or backfill
+	StartedAt            string  `json:"startedAt"`              // RFC3339
+	FinishedAt           *string `json:"finishedAt,omitempty"`   // RFC3339, omitted while running
+	WindowSince          *string `json:"windowSince,omitempty"`  // RFC3339
+	WindowBefore         *string `json:"windowBefore,omitempty"` // RFC3339
+	NotModified          bool    `json:"notModified"`
+	ThreadsFetched       int     `json:"threadsFetched"`
+	JobsQueued           int     `json:"jobsQueued"`
+	SubjectFetchFailures int     `json:"subjectFetchFailures"`
+	Error                *string `json:"error,omitempty"`
+	RateLimited          bool    `json:"rateLimited"`
+	RateLimitRemaining   *int    `json:"rateLimitRemaining,omitempty"`
+	RateLimitResetAt     *string `json:"rateLimitResetAt,omitempty"` // RFC3339
+}
+
+// SyncRunsResponse is a page of the sync run history, newest first
+type SyncRunsResponse struct {
+	Runs     []SyncRunResponse `json:"runs"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"pageSize"`
 }
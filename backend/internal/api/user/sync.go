@@ -16,8 +16,10 @@
 package user
 
 import (
+	"database/sql"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
@@ -51,6 +53,7 @@ func (h *Handler) HandleGetSyncSettings(w http.ResponseWriter, r *http.Request)
 			InitialSyncMaxCount:   settings.InitialSyncMaxCount,
 			InitialSyncUnreadOnly: settings.InitialSyncUnreadOnly,
 			SetupCompleted:        settings.SetupCompleted,
+			WriteBackToGitHub:     settings.WriteBackToGitHub,
 		}
 	}
 
@@ -72,45 +75,23 @@ func (h *Handler) HandleUpdateSyncSettings(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Validate sync settings
-	// Upper bounds prevent abuse and unreasonable values
-	const maxSyncDays = 3650 // 10 years
-	const maxNotificationCount = 100000
-
-	if req.InitialSyncDays != nil {
-		if *req.InitialSyncDays < 1 {
-			shared.WriteError(w, http.StatusBadRequest, "initialSyncDays must be at least 1")
-			return
-		}
-		if *req.InitialSyncDays > maxSyncDays {
-			shared.WriteError(
-				w,
-				http.StatusBadRequest,
-				"initialSyncDays cannot exceed 3650 (10 years)",
-			)
-			return
-		}
-	}
-
-	if req.InitialSyncMaxCount != nil {
-		if *req.InitialSyncMaxCount < 1 {
-			shared.WriteError(w, http.StatusBadRequest, "initialSyncMaxCount must be at least 1")
-			return
-		}
-		if *req.InitialSyncMaxCount > maxNotificationCount {
-			shared.WriteError(w, http.StatusBadRequest, "initialSyncMaxCount cannot exceed 100000")
-			return
-		}
+	if msg := validateInitialSync(req.InitialSyncDays, req.InitialSyncMaxCount); msg != "" {
+		shared.WriteError(w, http.StatusBadRequest, msg)
+		return
 	}
 
 	ctx := r.Context()
 
 	// Check current sync settings to see if setup was already completed
 	// We only want to trigger sync on initial setup completion, not on subsequent updates
-	var wasAlreadyCompleted bool
+	var wasAlreadyCompleted, writeBackToGitHub bool
 	currentSettings, err := h.authSvc.GetUserSyncSettings(ctx)
 	if err == nil && currentSettings != nil {
 		wasAlreadyCompleted = currentSettings.SetupCompleted
+		writeBackToGitHub = currentSettings.WriteBackToGitHub
+	}
+	if req.WriteBackToGitHub != nil {
+		writeBackToGitHub = *req.WriteBackToGitHub
 	}
 
 	settings := &models.SyncSettings{
@@ -118,6 +99,7 @@ func (h *Handler) HandleUpdateSyncSettings(w http.ResponseWriter, r *http.Reques
 		InitialSyncMaxCount:   req.InitialSyncMaxCount,
 		InitialSyncUnreadOnly: req.InitialSyncUnreadOnly,
 		SetupCompleted:        req.SetupCompleted,
+		WriteBackToGitHub:     writeBackToGitHub,
 	}
 
 	if err := h.authSvc.UpdateUserSyncSettings(ctx, settings); err != nil {
@@ -143,13 +125,42 @@ func (h *Handler) HandleUpdateSyncSettings(w http.ResponseWriter, r *http.Reques
 		InitialSyncMaxCount:   settings.InitialSyncMaxCount,
 		InitialSyncUnreadOnly: settings.InitialSyncUnreadOnly,
 		SetupCompleted:        settings.SetupCompleted,
+		WriteBackToGitHub:     settings.WriteBackToGitHub,
 	}
 
 	shared.WriteJSON(w, http.StatusOK, response)
 }
 
+// validateInitialSync checks the initial sync settings, returning the error message for an
+// invalid one or "" if they're valid. Upper bounds prevent abuse and unreasonable values.
+func validateInitialSync(initialSyncDays, initialSyncMaxCount *int) string {
+	const maxSyncDays = 3650 // 10 years
+	const maxNotificationCount = 100000
+
+	if initialSyncDays != nil {
+		if *initialSyncDays < 1 {
+			return "initialSyncDays must be at least 1"
+		}
+		if *initialSyncDays > maxSyncDays {
+			return "initialSyncDays cannot exceed 3650 (10 years)"
+		}
+	}
+
+	if initialSyncMaxCount != nil {
+		if *initialSyncMaxCount < 1 {
+			return "initialSyncMaxCount must be at least 1"
+		}
+		if *initialSyncMaxCount > maxNotificationCount {
+			return "initialSyncMaxCount cannot exceed 100000"
+		}
+	}
+
+	return ""
+}
+
 // HandleGetSyncState handles GET /api/user/sync-state
-// Returns the current sync state including oldest_notification_synced_at
+// Returns the current sync state including oldest_notification_synced_at, the GitHub rate
+// limit and what the latest reconciliation with GitHub changed
 func (h *Handler) HandleGetSyncState(w http.ResponseWriter, r *http.Request) {
 	username := auth.GetUsernameFromContext(r.Context())
 	if username == "" {
@@ -179,10 +190,118 @@ func (h *Handler) HandleGetSyncState(w http.ResponseWriter, r *http.Request) {
 		formatted := state.InitialSyncCompletedAt.Time.Format(time.RFC3339)
 		response.InitialSyncCompletedAt = &formatted
 	}
+	if state.RateLimitLimit.Valid {
+		response.RateLimit = &RateLimitResponse{
+			Limit:     int(state.RateLimitLimit.Int32),
+			Remaining: int(state.RateLimitRemaining.Int32),
+			ResetAt:   state.RateLimitResetAt.Time.Format(time.RFC3339),
+			UpdatedAt: state.RateLimitUpdatedAt.Time.Format(time.RFC3339),
+		}
+	}
+	if state.ReconciledAt.Valid {
+		response.Reconciliation = &ReconciliationResponse{
+			ReconciledAt: state.ReconciledAt.Time.Format(time.RFC3339),
+			Policy:       state.ReconcilePolicy.String,
+			Checked:      int(state.ReconcileChecked.Int32),
+			Archived:     int(state.ReconcileArchived.Int32),
+			Flagged:      int(state.ReconcileFlagged.Int32),
+			Deleted:      int(state.ReconcileDeleted.Int32),
+			Unflagged:    int(state.ReconcileUnflagged.Int32),
+		}
+	}
 
 	shared.WriteJSON(w, http.StatusOK, response)
 }
 
+// HandleListSyncRuns handles GET /api/user/sync-runs
+// Returns a page of the sync run history, newest first, optionally of one account
+// (accountId). page and pageSize default to 1 and 50.
+func (h *Handler) HandleListSyncRuns(w http.ResponseWriter, r *http.Request) {
+	username := auth.GetUsernameFromContext(r.Context())
+	if username == "" {
+		shared.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if h.syncStateSvc == nil {
+		shared.WriteError(w, http.StatusServiceUnavailable, "Sync state service not available")
+		return
+	}
+
+	query := r.URL.Query()
+	var opts models.SyncRunListOptions
+	if raw := query.Get("accountId"); raw != "" {
+		accountID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || accountID < 1 {
+			shared.WriteError(w, http.StatusBadRequest, "Invalid account id")
+			return
+		}
+		opts.AccountID = accountID
+	}
+	if page, err := strconv.Atoi(query.Get("page")); err == nil {
+		opts.Page = page
+	}
+	if pageSize, err := strconv.Atoi(query.Get("pageSize")); err == nil {
+		opts.PageSize = pageSize
+	}
+
+	ctx := r.Context()
+	page, err := h.syncStateSvc.ListSyncRuns(ctx, opts)
+	if err != nil {
+		h.logger.Error("failed to list sync runs", zap.Error(err))
+		shared.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	response := SyncRunsResponse{
+		Runs:     make([]SyncRunResponse, 0, len(page.Runs)),
+		Total:    page.Total,
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}
+	for _, run := range page.Runs {
+		response.Runs = append(response.Runs, syncRunResponse(run))
+	}
+
+	shared.WriteJSON(w, http.StatusOK, response)
+}
+
+// syncRunResponse converts a sync run for the frontend
+func syncRunResponse(run models.SyncRun) SyncRunResponse {
+	response := SyncRunResponse{
+		ID:                   run.ID,
+		AccountID:            run.AccountID,
+		Kind:                 string(run.Kind),
+		StartedAt:            run.StartedAt.Format(time.RFC3339),
+		FinishedAt:           formatNullTime(run.FinishedAt),
+		WindowSince:          formatNullTime(run.WindowSince),
+		WindowBefore:         formatNullTime(run.WindowBefore),
+		NotModified:          run.NotModified,
+		ThreadsFetched:       run.ThreadsFetched,
+		JobsQueued:           run.JobsQueued,
+		SubjectFetchFailures: run.SubjectFetchFailures,
+		RateLimited:          run.RateLimited,
+		RateLimitResetAt:     formatNullTime(run.RateLimitResetAt),
+	}
+	if run.Error.Valid {
+		response.Error = &run.Error.String
+	}
+	if run.RateLimitRemaining.Valid {
+		remaining := int(run.RateLimitRemaining.Int32)
+		response.RateLimitRemaining = &remaining
+	}
+	return response
+}
+
+// formatNullTime formats a time as RFC3339, or returns nil if it's not set
+func formatNullTime(value sql.NullTime) *string {
+	if !value.Valid {
+		return nil
+	}
+	formatted := value.Time.Format(time.RFC3339)
+	return &formatted
+}
+
 // HandleSyncOlder handles POST /api/user/sync-older
 // Queues a job to sync notifications older than the current oldest synced notification
 func (h *Handler) HandleSyncOlder(w http.ResponseWriter, r *http.Request) {
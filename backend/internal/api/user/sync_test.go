@@ -210,6 +210,54 @@ func TestHandler_HandleUpdateSyncSettings(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name: "success enables write-back to GitHub",
+			requestBody: SyncSettingsRequest{
+				SetupCompleted:    true,
+				WriteBackToGitHub: boolPtr(true),
+			},
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupMock: func(m *authmocks.MockAuthService) {
+				m.EXPECT().GetUserSyncSettings(gomock.Any()).Return(nil, nil)
+				m.EXPECT().UpdateUserSyncSettings(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, settings *models.SyncSettings) error {
+						require.True(t, settings.WriteBackToGitHub)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncSettingsResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.True(t, response.WriteBackToGitHub)
+			},
+		},
+		{
+			name: "omitted write-back keeps the current setting",
+			requestBody: SyncSettingsRequest{
+				SetupCompleted: true,
+			},
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupMock: func(m *authmocks.MockAuthService) {
+				m.EXPECT().GetUserSyncSettings(gomock.Any()).Return(&models.SyncSettings{
+					SetupCompleted:    true,
+					WriteBackToGitHub: true,
+				}, nil)
+				m.EXPECT().UpdateUserSyncSettings(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, settings *models.SyncSettings) error {
+						require.True(t, settings.WriteBackToGitHub)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name: "missing username in context returns 401",
 			requestBody: SyncSettingsRequest{
@@ -375,6 +423,69 @@ func TestHandler_HandleGetSyncState(t *testing.T) {
 				require.Contains(t, *response.InitialSyncCompletedAt, "2024-01-20")
 			},
 		},
+		{
+			name: "success returns rate limit",
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupHandler: func(h *Handler, ctrl *gomock.Controller) {
+				mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+				resetAt := time.Date(2024, 1, 20, 13, 0, 0, 0, time.UTC)
+				updatedAt := time.Date(2024, 1, 20, 12, 30, 0, 0, time.UTC)
+				mockSyncState.EXPECT().GetSyncState(gomock.Any()).Return(models.SyncState{
+					RateLimitLimit:     sql.NullInt32{Int32: 5000, Valid: true},
+					RateLimitRemaining: sql.NullInt32{Int32: 12, Valid: true},
+					RateLimitResetAt:   sql.NullTime{Time: resetAt, Valid: true},
+					RateLimitUpdatedAt: sql.NullTime{Time: updatedAt, Valid: true},
+				}, nil)
+				h.syncStateSvc = mockSyncState
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncStateResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, &RateLimitResponse{
+					Limit:     5000,
+					Remaining: 12,
+					ResetAt:   "2024-01-20T13:00:00Z",
+					UpdatedAt: "2024-01-20T12:30:00Z",
+				}, response.RateLimit)
+			},
+		},
+		{
+			name: "success returns last reconciliation",
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupHandler: func(h *Handler, ctrl *gomock.Controller) {
+				mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+				reconciledAt := time.Date(2024, 1, 20, 14, 0, 0, 0, time.UTC)
+				mockSyncState.EXPECT().GetSyncState(gomock.Any()).Return(models.SyncState{
+					ReconciledAt:       sql.NullTime{Time: reconciledAt, Valid: true},
+					ReconcilePolicy:    sql.NullString{String: "flag", Valid: true},
+					ReconcileChecked:   sql.NullInt32{Int32: 120, Valid: true},
+					ReconcileFlagged:   sql.NullInt32{Int32: 3, Valid: true},
+					ReconcileUnflagged: sql.NullInt32{Int32: 1, Valid: true},
+				}, nil)
+				h.syncStateSvc = mockSyncState
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncStateResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, &ReconciliationResponse{
+					ReconciledAt: "2024-01-20T14:00:00Z",
+					Policy:       "flag",
+					Checked:      120,
+					Flagged:      3,
+					Unflagged:    1,
+				}, response.Reconciliation)
+			},
+		},
 		{
 			name: "success returns empty response when no timestamps",
 			setupContext: func(req *http.Request) *http.Request {
@@ -393,6 +504,8 @@ func TestHandler_HandleGetSyncState(t *testing.T) {
 				require.NoError(t, err)
 				require.Nil(t, response.OldestNotificationSyncedAt)
 				require.Nil(t, response.InitialSyncCompletedAt)
+				require.Nil(t, response.RateLimit)
+				require.Nil(t, response.Reconciliation)
 			},
 		},
 		{
@@ -455,6 +568,197 @@ func TestHandler_HandleGetSyncState(t *testing.T) {
 	}
 }
 
+func TestHandler_HandleListSyncRuns(t *testing.T) {
+	startedAt := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		url            string
+		setupContext   func(*http.Request) *http.Request
+		setupHandler   func(*Handler, *gomock.Controller)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name: "success returns a page of sync runs",
+			url:  "/api/user/sync-runs?accountId=2&page=2&pageSize=10",
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupHandler: func(h *Handler, ctrl *gomock.Controller) {
+				mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+				mockSyncState.EXPECT().
+					ListSyncRuns(gomock.Any(), models.SyncRunListOptions{
+						AccountID: 2,
+						Page:      2,
+						PageSize:  10,
+					}).
+					Return(models.SyncRunPage{
+						Runs: []models.SyncRun{
+							{
+								ID:        7,
+								AccountID: 2,
+								Kind:      models.SyncRunKindSync,
+								StartedAt: startedAt,
+								FinishedAt: sql.NullTime{
+									Time:  startedAt.Add(time.Second),
+									Valid: true,
+								},
+								WindowSince: sql.NullTime{
+									Time:  startedAt.Add(-time.Hour),
+									Valid: true,
+								},
+								ThreadsFetched:       12,
+								JobsQueued:           12,
+								SubjectFetchFailures: 1,
+								Error: sql.NullString{
+									String: "limited",
+									Valid:  true,
+								},
+								RateLimited:        true,
+								RateLimitRemaining: sql.NullInt32{Int32: 0, Valid: true},
+								RateLimitResetAt: sql.NullTime{
+									Time:  startedAt.Add(time.Hour),
+									Valid: true,
+								},
+							},
+						},
+						Total:    11,
+						Page:     2,
+						PageSize: 10,
+					}, nil)
+				h.syncStateSvc = mockSyncState
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response SyncRunsResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, int64(11), response.Total)
+				require.Equal(t, 2, response.Page)
+				require.Equal(t, 10, response.PageSize)
+				require.Len(t, response.Runs, 1)
+
+				finishedAt := "2024-01-20T12:00:01Z"
+				windowSince := "2024-01-20T11:00:00Z"
+				runErr := "limited"
+				remaining := 0
+				resetAt := "2024-01-20T13:00:00Z"
+				require.Equal(t, SyncRunResponse{
+					ID:                   7,
+					AccountID:            2,
+					Kind:                 "sync",
+					StartedAt:            "2024-01-20T12:00:00Z",
+					FinishedAt:           &finishedAt,
+					WindowSince:          &windowSince,
+					ThreadsFetched:       12,
+					JobsQueued:           12,
+					SubjectFetchFailures: 1,
+					Error:                &runErr,
+					RateLimited:          true,
+					RateLimitRemaining:   &remaining,
+					RateLimitResetAt:     &resetAt,
+				}, response.Runs[0])
+			},
+		},
+		{
+			name: "success returns an empty list without runs",
+			url:  "/api/user/sync-runs",
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupHandler: func(h *Handler, ctrl *gomock.Controller) {
+				mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+				mockSyncState.EXPECT().
+					ListSyncRuns(gomock.Any(), models.SyncRunListOptions{}).
+					Return(models.SyncRunPage{Page: 1, PageSize: 50}, nil)
+				h.syncStateSvc = mockSyncState
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.JSONEq(
+					t,
+					`{"runs": [], "total": 0, "page": 1, "pageSize": 50}`,
+					w.Body.String(),
+				)
+			},
+		},
+		{
+			name: "invalid account id returns 400",
+			url:  "/api/user/sync-runs?accountId=abc",
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupHandler: func(h *Handler, ctrl *gomock.Controller) {
+				h.syncStateSvc = syncstatemocks.NewMockSyncStateService(ctrl)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing username returns 401",
+			url:  "/api/user/sync-runs",
+			setupContext: func(req *http.Request) *http.Request {
+				return req
+			},
+			setupHandler:   nil,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "sync state service unavailable returns 503",
+			url:  "/api/user/sync-runs",
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupHandler: func(h *Handler, _ *gomock.Controller) {
+				h.syncStateSvc = nil
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "service error returns 500",
+			url:  "/api/user/sync-runs",
+			setupContext: func(req *http.Request) *http.Request {
+				ctx := auth.SetUsernameInContext(req.Context(), "admin")
+				return req.WithContext(ctx)
+			},
+			setupHandler: func(h *Handler, ctrl *gomock.Controller) {
+				mockSyncState := syncstatemocks.NewMockSyncStateService(ctrl)
+				mockSyncState.EXPECT().
+					ListSyncRuns(gomock.Any(), gomock.Any()).
+					Return(models.SyncRunPage{}, errors.New("database error"))
+				h.syncStateSvc = mockSyncState
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, _ := setupTestHandler(ctrl)
+			if tt.setupHandler != nil {
+				tt.setupHandler(handler, ctrl)
+			}
+
+			req := createRequest(http.MethodGet, tt.url, nil)
+			req = tt.setupContext(req)
+			w := httptest.NewRecorder()
+
+			handler.HandleListSyncRuns(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
+
 func TestHandler_HandleSyncOlder(t *testing.T) {
 	tests := []struct {
 		name           string
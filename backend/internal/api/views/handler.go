@@ -46,4 +46,10 @@ func (h *Handler) Register(r chi.Router) {
 		r.Put("/{id}", h.handleUpdateView)
 		r.Delete("/{id}", h.handleDeleteView)
 	})
+	r.Route("/macros", func(r chi.Router) {
+		r.Get("/", h.handleListMacros)
+		r.Post("/", h.handleCreateMacro)
+		r.Put("/{id}", h.handleUpdateMacro)
+		r.Delete("/{id}", h.handleDeleteMacro)
+	})
 }
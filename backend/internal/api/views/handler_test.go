@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -541,6 +542,20 @@ func TestHandler_handleDeleteView(t *testing.T) {
 				require.Equal(t, float64(2), response["linkedRuleCount"])
 			},
 		},
+		{
+			name:   "view used by other queries returns 409",
+			viewID: "1",
+			force:  true,
+			setupMock: func(mockSvc *viewmocks.MockViewService, _ int64) {
+				mockSvc.EXPECT().
+					DeleteView(gomock.Any(), int64(1), true).
+					Return(0, fmt.Errorf("view:work is used by @focus: %w", viewcore.ErrViewInUse))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Contains(t, w.Body.String(), "view:work is used by @focus")
+			},
+		},
 		{
 			name:   "service error returns 500",
 			viewID: "1",
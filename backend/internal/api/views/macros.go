@@ -0,0 +1,155 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/api/shared"
+	viewcore "github.com/ajbeattie/octobud/backend/internal/core/view"
+)
+
+type createMacroRequest struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+type updateMacroRequest struct {
+	Name  *string `json:"name"`
+	Query *string `json:"query"`
+}
+
+func (h *Handler) handleListMacros(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	macros, err := h.viewSvc.ListMacros(ctx)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, "failed to load macros")
+		return
+	}
+
+	response := make([]MacroResponse, 0, len(macros))
+	response = append(response, macros...)
+
+	shared.WriteJSON(w, http.StatusOK, listMacrosResponse{Macros: response})
+}
+
+func (h *Handler) handleCreateMacro(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req createMacroRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	macro, err := h.viewSvc.CreateMacro(ctx, req.Name, req.Query)
+	if err != nil {
+		if status, ok := macroErrorStatus(err); ok {
+			shared.WriteError(w, status, err.Error())
+			return
+		}
+		h.logger.Error("failed to create macro", zap.String("name", req.Name), zap.Error(err))
+		shared.WriteError(w, http.StatusInternalServerError, "failed to create macro")
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusCreated, macroEnvelope{Macro: macro})
+}
+
+func (h *Handler) handleUpdateMacro(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	macroID, err := parseMacroIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req updateMacroRequest
+	if decodeErr := json.NewDecoder(r.Body).Decode(&req); decodeErr != nil {
+		shared.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	macro, err := h.viewSvc.UpdateMacro(ctx, macroID, req.Name, req.Query)
+	if err != nil {
+		if status, ok := macroErrorStatus(err); ok {
+			shared.WriteError(w, status, err.Error())
+			return
+		}
+		h.logger.Error("failed to update macro", zap.Int64("macro_id", macroID), zap.Error(err))
+		shared.WriteError(w, http.StatusInternalServerError, "failed to update macro")
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, macroEnvelope{Macro: macro})
+}
+
+func (h *Handler) handleDeleteMacro(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	macroID, err := parseMacroIDParam(r)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.viewSvc.DeleteMacro(ctx, macroID); err != nil {
+		if status, ok := macroErrorStatus(err); ok {
+			shared.WriteError(w, status, err.Error())
+			return
+		}
+		shared.WriteError(w, http.StatusInternalServerError, "failed to delete macro")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// macroErrorStatus maps the macro errors a client can fix to a status code
+func macroErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, viewcore.ErrMacroNotFound):
+		return http.StatusNotFound, true
+	case errors.Is(err, viewcore.ErrMacroNameAlreadyExists),
+		errors.Is(err, viewcore.ErrMacroInUse):
+		return http.StatusConflict, true
+	case errors.Is(err, viewcore.ErrInvalidQuery),
+		errors.Is(err, viewcore.ErrMacroNameRequired),
+		errors.Is(err, viewcore.ErrInvalidMacroName),
+		errors.Is(err, viewcore.ErrMacroNameReserved),
+		errors.Is(err, viewcore.ErrMacroQueryRequired),
+		errors.Is(err, viewcore.ErrMacroQueryCannotBeEmpty):
+		return http.StatusBadRequest, true
+	default:
+		return 0, false
+	}
+}
+
+func parseMacroIDParam(r *http.Request) (int64, error) {
+	macroID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || macroID <= 0 {
+		return 0, errors.New("invalid macro id")
+	}
+	return macroID, nil
+}
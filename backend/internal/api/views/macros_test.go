@@ -0,0 +1,174 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package views
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	viewcore "github.com/ajbeattie/octobud/backend/internal/core/view"
+	viewmocks "github.com/ajbeattie/octobud/backend/internal/core/view/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+func TestHandler_macroRoutes(t *testing.T) {
+	bots := models.QueryMacro{ID: "1", Name: "bots", Query: "author:*[bot]"}
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           interface{}
+		setupMock      func(*viewmocks.MockViewService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "list returns macros",
+			method: http.MethodGet,
+			url:    "/macros",
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().ListMacros(gomock.Any()).Return([]models.QueryMacro{bots}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response listMacrosResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, []MacroResponse{bots}, response.Macros)
+			},
+		},
+		{
+			name:   "create returns 201",
+			method: http.MethodPost,
+			url:    "/macros",
+			body:   createMacroRequest{Name: "bots", Query: "author:*[bot]"},
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().
+					CreateMacro(gomock.Any(), "bots", "author:*[bot]").
+					Return(bots, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response macroEnvelope
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, bots, response.Macro)
+			},
+		},
+		{
+			name:   "create with an invalid query returns 400",
+			method: http.MethodPost,
+			url:    "/macros",
+			body:   createMacroRequest{Name: "bots", Query: "@bots"},
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().
+					CreateMacro(gomock.Any(), "bots", "@bots").
+					Return(models.QueryMacro{}, viewcore.ErrInvalidQuery)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "update renames a macro",
+			method: http.MethodPut,
+			url:    "/macros/1",
+			body:   map[string]string{"name": "robots"},
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				name := "robots"
+				mockSvc.EXPECT().
+					UpdateMacro(gomock.Any(), int64(1), &name, nil).
+					Return(models.QueryMacro{ID: "1", Name: "robots", Query: bots.Query}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "update with an invalid id returns 400",
+			method:         http.MethodPut,
+			url:            "/macros/abc",
+			body:           map[string]string{"name": "robots"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "delete returns 204",
+			method: http.MethodDelete,
+			url:    "/macros/1",
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().DeleteMacro(gomock.Any(), int64(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "delete of a used macro returns 409",
+			method: http.MethodDelete,
+			url:    "/macros/1",
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().
+					DeleteMacro(gomock.Any(), int64(1)).
+					Return(fmt.Errorf("@bots is used by view:triage: %w", viewcore.ErrMacroInUse))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				require.Contains(t, w.Body.String(), "@bots is used by view:triage")
+			},
+		},
+		{
+			name:   "delete of a missing macro returns 404",
+			method: http.MethodDelete,
+			url:    "/macros/9",
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().DeleteMacro(gomock.Any(), int64(9)).Return(viewcore.ErrMacroNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "service error returns 500",
+			method: http.MethodGet,
+			url:    "/macros",
+			setupMock: func(mockSvc *viewmocks.MockViewService) {
+				mockSvc.EXPECT().ListMacros(gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, mockSvc := setupTestHandler(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockSvc)
+			}
+			router := chi.NewRouter()
+			handler.Register(router)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, createRequest(tt.method, tt.url, tt.body))
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				tt.expectedBody(t, w)
+			}
+		})
+	}
+}
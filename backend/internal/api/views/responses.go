@@ -31,3 +31,16 @@ type listViewsResponse struct {
 type viewEnvelope struct {
 	View ViewResponse `json:"view"`
 }
+
+// MacroResponse is the response type for a query macro.
+type MacroResponse = models.QueryMacro
+
+// listMacrosResponse is the response type for a list of query macros.
+type listMacrosResponse struct {
+	Macros []MacroResponse `json:"macros"`
+}
+
+// macroEnvelope is the envelope type for a query macro.
+type macroEnvelope struct {
+	Macro MacroResponse `json:"macro"`
+}
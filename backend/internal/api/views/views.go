@@ -177,6 +177,10 @@ func (h *Handler) handleUpdateView(w http.ResponseWriter, r *http.Request) {
 			shared.WriteError(w, http.StatusConflict, err.Error())
 			return
 		}
+		if errors.Is(err, viewcore.ErrViewInUse) {
+			shared.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
 		if errors.Is(err, viewcore.ErrInvalidQuery) {
 			shared.WriteError(w, http.StatusBadRequest, err.Error())
 			return
@@ -217,6 +221,10 @@ func (h *Handler) handleDeleteView(w http.ResponseWriter, r *http.Request) {
 			shared.WriteError(w, http.StatusNotFound, err.Error())
 			return
 		}
+		if errors.Is(err, viewcore.ErrViewInUse) {
+			shared.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
 		shared.WriteError(w, http.StatusInternalServerError, "failed to delete view")
 		return
 	}
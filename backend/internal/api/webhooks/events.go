@@ -0,0 +1,85 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package webhooks
+
+import (
+	"strconv"
+)
+
+// subjectEvents are the webhook events about an issue, pull request or discussion,
+// the subjects of notifications
+var subjectEvents = map[string]bool{
+	"issues":                      true,
+	"issue_comment":               true,
+	"pull_request":                true,
+	"pull_request_review":         true,
+	"pull_request_review_comment": true,
+	"pull_request_review_thread":  true,
+	"discussion":                  true,
+	"discussion_comment":          true,
+}
+
+// webhookPayload holds the parts of a delivery that identify its subject
+type webhookPayload struct {
+	Repository  *payloadRepository  `json:"repository"`
+	Issue       *payloadIssue       `json:"issue"`
+	PullRequest *payloadPullRequest `json:"pull_request"`
+	Discussion  *payloadDiscussion  `json:"discussion"`
+}
+
+type payloadRepository struct {
+	URL string `json:"url"`
+}
+
+type payloadIssue struct {
+	URL string `json:"url"`
+	// Set when the issue is a pull request, as for comments on a pull request
+	PullRequest *payloadPullRequest `json:"pull_request"`
+}
+
+type payloadPullRequest struct {
+	URL string `json:"url"`
+}
+
+type payloadDiscussion struct {
+	Number int `json:"number"`
+}
+
+// subjectURLs returns the API URLs a notification about the delivery's subject may have as
+// its subject_url. A pull request's notifications use its pulls URL, even for events such
+// as issue_comment that describe it as an issue.
+func (p webhookPayload) subjectURLs() []string {
+	var urls []string
+	add := func(url string) {
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+
+	if p.Issue != nil {
+		add(p.Issue.URL)
+		if p.Issue.PullRequest != nil {
+			add(p.Issue.PullRequest.URL)
+		}
+	}
+	if p.PullRequest != nil {
+		add(p.PullRequest.URL)
+	}
+	if p.Discussion != nil && p.Repository != nil && p.Repository.URL != "" {
+		add(p.Repository.URL + "/discussions/" + strconv.Itoa(p.Discussion.Number))
+	}
+	return urls
+}
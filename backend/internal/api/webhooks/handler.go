@@ -0,0 +1,254 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package webhooks provides the GitHub webhook handler.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/api/shared"
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/jobs"
+)
+
+// Error definitions
+var (
+	ErrInvalidSignature           = errors.New("invalid webhook signature")
+	ErrInvalidPayload             = errors.New("invalid webhook payload")
+	ErrFailedToMatchNotifications = errors.New("failed to match notifications")
+	ErrFailedToQueueRefresh       = errors.New("failed to queue subject refresh")
+	ErrFailedToQueueSync          = errors.New("failed to queue notification sync")
+)
+
+// Headers set by GitHub on each delivery
+const (
+	signatureHeader = "X-Hub-Signature-256"
+	eventHeader     = "X-GitHub-Event"
+	deliveryHeader  = "X-GitHub-Delivery"
+)
+
+// Handler receives GitHub webhook deliveries. Each delivery about an issue, pull request
+// or discussion queues a refresh of its notifications' subjects and a notification sync,
+// so the inbox catches up without waiting for the next poll. The worker does both, so a
+// delivery is answered without calling GitHub.
+type Handler struct {
+	logger      *zap.Logger
+	store       db.Store
+	secret      []byte
+	riverClient db.RiverClient
+}
+
+// New creates a new webhooks handler verifying deliveries with secret
+func New(
+	logger *zap.Logger,
+	store db.Store,
+	secret string,
+	riverClient db.RiverClient,
+) *Handler {
+	return &Handler{
+		logger:      logger,
+		store:       store,
+		secret:      []byte(secret),
+		riverClient: riverClient,
+	}
+}
+
+// Register registers webhook routes on the provided router. The routes authenticate
+// deliveries by their signature, so they must not sit behind the session middleware.
+func (h *Handler) Register(r chi.Router) {
+	r.Post("/webhooks/github", h.handleGitHubWebhook)
+}
+
+func (h *Handler) handleGitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	delivery := zap.String("delivery", r.Header.Get(deliveryHeader))
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	if !validSignature(h.secret, body, r.Header.Get(signatureHeader)) {
+		h.logger.Warn("rejected webhook delivery", delivery, zap.Error(ErrInvalidSignature))
+		shared.WriteError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	event := r.Header.Get(eventHeader)
+	if !subjectEvents[event] {
+		// Includes the ping sent when the webhook is created
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.logger.Warn(
+			"invalid webhook payload",
+			delivery,
+			zap.String("event", event),
+			zap.Error(errors.Join(ErrInvalidPayload, err)),
+		)
+		shared.WriteError(w, http.StatusBadRequest, "invalid payload")
+		return
+	}
+
+	var matches []db.Notification
+	if urls := payload.subjectURLs(); len(urls) > 0 {
+		matches, err = h.store.ListNotificationsBySubjectURLs(ctx, urls)
+	}
+	if err != nil {
+		h.logger.Error(
+			"failed to match webhook to notifications",
+			delivery,
+			zap.Error(errors.Join(ErrFailedToMatchNotifications, err)),
+		)
+		shared.WriteError(w, http.StatusInternalServerError, "failed to match notifications")
+		return
+	}
+
+	if err := h.queueRefreshes(ctx, matches); err != nil {
+		h.logger.Error(
+			"failed to queue subject refresh",
+			delivery,
+			zap.Error(errors.Join(ErrFailedToQueueRefresh, err)),
+		)
+		shared.WriteError(w, http.StatusInternalServerError, "failed to queue subject refresh")
+		return
+	}
+
+	accountIDs, err := h.accountsToSync(ctx, matches)
+	if err != nil {
+		h.logger.Error(
+			"failed to list accounts to sync",
+			delivery,
+			zap.Error(errors.Join(ErrFailedToQueueSync, err)),
+		)
+		shared.WriteError(w, http.StatusInternalServerError, "failed to queue sync")
+		return
+	}
+	for _, accountID := range accountIDs {
+		_, err := h.riverClient.Insert(ctx, jobs.SyncNotificationsArgs{AccountID: accountID}, nil)
+		if err != nil {
+			h.logger.Error(
+				"failed to queue notification sync",
+				delivery,
+				zap.Int64("accountID", accountID),
+				zap.Error(errors.Join(ErrFailedToQueueSync, err)),
+			)
+			shared.WriteError(w, http.StatusInternalServerError, "failed to queue sync")
+			return
+		}
+	}
+
+	h.logger.Info(
+		"processed webhook delivery",
+		delivery,
+		zap.String("event", event),
+		zap.Int("matched", len(matches)),
+	)
+
+	shared.WriteJSON(w, http.StatusAccepted, webhookResponse{
+		Matched:          len(matches),
+		SyncedAccountIDs: accountIDs,
+	})
+}
+
+// queueRefreshes queues a job refreshing the subjects of the matched notifications of
+// each account
+func (h *Handler) queueRefreshes(ctx context.Context, matches []db.Notification) error {
+	var accountIDs []int64
+	githubIDs := make(map[int64][]string)
+	for _, notification := range matches {
+		if _, ok := githubIDs[notification.AccountID]; !ok {
+			accountIDs = append(accountIDs, notification.AccountID)
+		}
+		githubIDs[notification.AccountID] = append(
+			githubIDs[notification.AccountID],
+			notification.GithubID,
+		)
+	}
+
+	for _, accountID := range accountIDs {
+		args := jobs.RefreshNotificationSubjectsArgs{
+			AccountID: accountID,
+			GithubIDs: githubIDs[accountID],
+		}
+		if _, err := h.riverClient.Insert(ctx, args, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accountsToSync returns the accounts whose notifications the delivery matched. A delivery
+// matching nothing may be about a thread none of them has seen yet, so every account syncs.
+func (h *Handler) accountsToSync(ctx context.Context, matches []db.Notification) ([]int64, error) {
+	seen := make(map[int64]bool)
+	var accountIDs []int64
+	for _, notification := range matches {
+		if !seen[notification.AccountID] {
+			seen[notification.AccountID] = true
+			accountIDs = append(accountIDs, notification.AccountID)
+		}
+	}
+	if len(accountIDs) > 0 {
+		sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+		return accountIDs, nil
+	}
+
+	accounts, err := h.store.ListGitHubAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		accountIDs = append(accountIDs, account.ID)
+	}
+	return accountIDs, nil
+}
+
+// validSignature reports whether header is the X-Hub-Signature-256 of body: the hex
+// HMAC-SHA256 of the body keyed with the webhook secret, prefixed with "sha256="
+func validSignature(secret, body []byte, header string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,326 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/jobs"
+)
+
+const testSecret = "webhook-secret"
+
+func sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func createDelivery(event string, body []byte, signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(eventHeader, event)
+	req.Header.Set(deliveryHeader, "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	req.Header.Set(signatureHeader, signature)
+	return req
+}
+
+func serve(h *Handler, req *http.Request) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	h.Register(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// expectJobs expects the jobs to be queued, in order
+func expectJobs(t *testing.T, mockRiver *mocks.MockRiverClient, expected ...river.JobArgs) {
+	var queued []river.JobArgs
+	mockRiver.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Nil()).DoAndReturn(
+		func(_ context.Context, args river.JobArgs, _ *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+			queued = append(queued, args)
+			return &rivertype.JobInsertResult{}, nil
+		},
+	).Times(len(expected))
+	t.Cleanup(func() {
+		require.Equal(t, expected, queued)
+	})
+}
+
+// refreshJob is the job refreshing the subjects of an account's notifications
+func refreshJob(accountID int64, githubIDs ...string) jobs.RefreshNotificationSubjectsArgs {
+	return jobs.RefreshNotificationSubjectsArgs{AccountID: accountID, GithubIDs: githubIDs}
+}
+
+// syncJob is the job syncing an account's notifications
+func syncJob(accountID int64) jobs.SyncNotificationsArgs {
+	return jobs.SyncNotificationsArgs{AccountID: accountID}
+}
+
+func TestHandler_handleGitHubWebhook(t *testing.T) {
+	pullRequestURL := "https://api.github.com/repos/octo/repo/pulls/7"
+	issueURL := "https://api.github.com/repos/octo/repo/issues/7"
+
+	prPayload := []byte(`{
+		"action": "closed",
+		"pull_request": {"url": "` + pullRequestURL + `", "number": 7, "merged": true},
+		"repository": {"url": "https://api.github.com/repos/octo/repo"}
+	}`)
+	commentPayload := []byte(`{
+		"action": "created",
+		"issue": {"url": "` + issueURL + `", "pull_request": {"url": "` + pullRequestURL + `"}},
+		"repository": {"url": "https://api.github.com/repos/octo/repo"}
+	}`)
+
+	tests := []struct {
+		name             string
+		event            string
+		body             []byte
+		signature        func([]byte) string
+		setupMocks       func(*testing.T, *mocks.MockStore, *mocks.MockRiverClient)
+		expectedStatus   int
+		expectedResponse *webhookResponse
+	}{
+		{
+			name:      "missing signature is rejected",
+			event:     "pull_request",
+			body:      prPayload,
+			signature: func([]byte) string { return "" },
+			setupMocks: func(*testing.T, *mocks.MockStore, *mocks.MockRiverClient) {
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "signature of another body is rejected",
+			event:     "pull_request",
+			body:      prPayload,
+			signature: func([]byte) string { return sign(commentPayload) },
+			setupMocks: func(*testing.T, *mocks.MockStore, *mocks.MockRiverClient) {
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:      "ping is acknowledged",
+			event:     "ping",
+			body:      []byte(`{"zen": "Design for failure."}`),
+			signature: sign,
+			setupMocks: func(*testing.T, *mocks.MockStore, *mocks.MockRiverClient) {
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:      "events without a notification subject are ignored",
+			event:     "push",
+			body:      []byte(`{"ref": "refs/heads/main"}`),
+			signature: sign,
+			setupMocks: func(*testing.T, *mocks.MockStore, *mocks.MockRiverClient) {
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:      "invalid payload is rejected",
+			event:     "pull_request",
+			body:      []byte(`{"pull_request": `),
+			signature: sign,
+			setupMocks: func(*testing.T, *mocks.MockStore, *mocks.MockRiverClient) {
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "pull request queues refreshes of matching notifications and syncs",
+			event:     "pull_request",
+			body:      prPayload,
+			signature: sign,
+			setupMocks: func(t *testing.T, m *mocks.MockStore, mockRiver *mocks.MockRiverClient) {
+				m.EXPECT().
+					ListNotificationsBySubjectURLs(gomock.Any(), []string{pullRequestURL}).
+					Return([]db.Notification{
+						{GithubID: "thread-work", AccountID: 2},
+						{GithubID: "thread-default", AccountID: 1},
+						{GithubID: "thread-work-2", AccountID: 2},
+					}, nil)
+				expectJobs(t, mockRiver,
+					refreshJob(2, "thread-work", "thread-work-2"),
+					refreshJob(1, "thread-default"),
+					syncJob(1),
+					syncJob(2),
+				)
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedResponse: &webhookResponse{
+				Matched:          3,
+				SyncedAccountIDs: []int64{1, 2},
+			},
+		},
+		{
+			name:      "comment on a pull request matches its issue and pulls URLs",
+			event:     "issue_comment",
+			body:      commentPayload,
+			signature: sign,
+			setupMocks: func(t *testing.T, m *mocks.MockStore, mockRiver *mocks.MockRiverClient) {
+				m.EXPECT().
+					ListNotificationsBySubjectURLs(
+						gomock.Any(),
+						[]string{issueURL, pullRequestURL},
+					).
+					Return([]db.Notification{{GithubID: "thread", AccountID: 1}}, nil)
+				expectJobs(t, mockRiver, refreshJob(1, "thread"), syncJob(1))
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedResponse: &webhookResponse{
+				Matched:          1,
+				SyncedAccountIDs: []int64{1},
+			},
+		},
+		{
+			name:  "discussion matches by repository and number",
+			event: "discussion",
+			body: []byte(`{
+				"discussion": {"number": 12},
+				"repository": {"url": "https://api.github.com/repos/octo/repo"}
+			}`),
+			signature: sign,
+			setupMocks: func(t *testing.T, m *mocks.MockStore, mockRiver *mocks.MockRiverClient) {
+				m.EXPECT().
+					ListNotificationsBySubjectURLs(
+						gomock.Any(),
+						[]string{"https://api.github.com/repos/octo/repo/discussions/12"},
+					).
+					Return([]db.Notification{{GithubID: "thread", AccountID: 1}}, nil)
+				expectJobs(t, mockRiver, refreshJob(1, "thread"), syncJob(1))
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedResponse: &webhookResponse{
+				Matched:          1,
+				SyncedAccountIDs: []int64{1},
+			},
+		},
+		{
+			name:      "unmatched delivery syncs every account",
+			event:     "pull_request",
+			body:      prPayload,
+			signature: sign,
+			setupMocks: func(t *testing.T, m *mocks.MockStore, mockRiver *mocks.MockRiverClient) {
+				m.EXPECT().
+					ListNotificationsBySubjectURLs(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				m.EXPECT().ListGitHubAccounts(gomock.Any()).Return([]db.GithubAccount{
+					{ID: 1, Name: "default"},
+					{ID: 2, Name: "work"},
+				}, nil)
+				expectJobs(t, mockRiver, syncJob(1), syncJob(2))
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedResponse: &webhookResponse{
+				SyncedAccountIDs: []int64{1, 2},
+			},
+		},
+		{
+			name:      "store error returns 500",
+			event:     "pull_request",
+			body:      prPayload,
+			signature: sign,
+			setupMocks: func(_ *testing.T, m *mocks.MockStore, _ *mocks.MockRiverClient) {
+				m.EXPECT().
+					ListNotificationsBySubjectURLs(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:      "refresh queue error returns 500",
+			event:     "pull_request",
+			body:      prPayload,
+			signature: sign,
+			setupMocks: func(_ *testing.T, m *mocks.MockStore, mockRiver *mocks.MockRiverClient) {
+				m.EXPECT().
+					ListNotificationsBySubjectURLs(gomock.Any(), gomock.Any()).
+					Return([]db.Notification{{GithubID: "thread", AccountID: 1}}, nil)
+				mockRiver.EXPECT().
+					Insert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("queue unavailable"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:      "sync queue error returns 500",
+			event:     "pull_request",
+			body:      prPayload,
+			signature: sign,
+			setupMocks: func(_ *testing.T, m *mocks.MockStore, mockRiver *mocks.MockRiverClient) {
+				m.EXPECT().
+					ListNotificationsBySubjectURLs(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				m.EXPECT().ListGitHubAccounts(gomock.Any()).Return([]db.GithubAccount{{ID: 1}}, nil)
+				mockRiver.EXPECT().
+					Insert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("queue unavailable"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStore(ctrl)
+			mockRiver := mocks.NewMockRiverClient(ctrl)
+			tt.setupMocks(t, mockStore, mockRiver)
+
+			handler := New(zap.NewNop(), mockStore, testSecret, mockRiver)
+
+			w := serve(handler, createDelivery(tt.event, tt.body, tt.signature(tt.body)))
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedResponse != nil {
+				var response webhookResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, *tt.expectedResponse, response)
+			}
+		})
+	}
+}
+
+func TestValidSignature(t *testing.T) {
+	body := []byte(`{"action": "opened"}`)
+
+	require.True(t, validSignature([]byte(testSecret), body, sign(body)))
+	require.False(t, validSignature([]byte("other-secret"), body, sign(body)))
+	require.False(t, validSignature([]byte(testSecret), []byte(`{}`), sign(body)))
+	require.False(t, validSignature([]byte(testSecret), body, sign(body)[len("sha256="):]))
+	require.False(t, validSignature([]byte(testSecret), body, "sha256=not-hex"))
+	require.False(t, validSignature(nil, body, "sha256="))
+}
@@ -0,0 +1,22 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package webhooks
+
+// webhookResponse is the response type for a processed webhook delivery
+type webhookResponse struct {
+	Matched          int     `json:"matched"`          // Notifications queued for a subject refresh
+	SyncedAccountIDs []int64 `json:"syncedAccountIds"` // Accounts queued to sync
+}
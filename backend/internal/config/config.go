@@ -35,8 +35,41 @@ type Config struct {
 	GHToken      string
 	JWTSecret    string
 	JWTExpiry    time.Duration
+	// GitHubAPIURL and GitHubWebURL point Octobud at a GitHub Enterprise Server. Empty
+	// means github.com, and an empty web URL is derived from the API URL.
+	GitHubAPIURL string
+	GitHubWebURL string
+	// GitHubAccounts are the GitHub identities whose notifications feed the inbox
+	GitHubAccounts []GitHubAccount
+	// GitHubWebhookSecret verifies deliveries to the GitHub webhook endpoint. Empty
+	// leaves the endpoint disabled.
+	GitHubWebhookSecret string
+	// ReconcileInterval is how often notifications are reconciled with GitHub's list, and
+	// ReconcilePolicy what happens to those GitHub no longer returns: archive (the default),
+	// flag or delete.
+	ReconcileInterval time.Duration
+	ReconcilePolicy   string
+	// SubjectRefreshInterval is how often subjects are refreshed in the background, and
+	// SubjectRefreshAge how old a subject has to be to be refreshed.
+	SubjectRefreshInterval time.Duration
+	SubjectRefreshAge      time.Duration
+	// SyncRunRetention is how long the sync run history is kept.
+	SyncRunRetention time.Duration
 }
 
+// GitHubAccount configures a GitHub identity synced by the worker. Empty URLs mean
+// github.com, as with GitHubAPIURL and GitHubWebURL.
+type GitHubAccount struct {
+	Name     string
+	Token    string
+	TokenEnv string // The environment variable the token is read from
+	APIURL   string
+	WebURL   string
+}
+
+// defaultAccountName names the account configured by GH_TOKEN, GH_API_URL and GH_WEB_URL
+const defaultAccountName = "default"
+
 // Load loads the configuration from the environment variables.
 func Load() Config {
 	// Load .env files with priority: root .env (../.env) overrides local .env
@@ -74,14 +107,85 @@ func Load() Config {
 		GHToken:      getEnv("GH_TOKEN", ""),
 		JWTSecret:    jwtSecret,
 		JWTExpiry:    jwtExpiry,
+		GitHubAPIURL: strings.TrimSpace(os.Getenv("GH_API_URL")),
+		GitHubWebURL: strings.TrimSpace(os.Getenv("GH_WEB_URL")),
+
+		GitHubWebhookSecret: os.Getenv("GH_WEBHOOK_SECRET"),
+		ReconcileInterval:   getDurationEnv("RECONCILE_INTERVAL"),
+		ReconcilePolicy:     strings.TrimSpace(os.Getenv("RECONCILE_POLICY")),
+
+		SubjectRefreshInterval: getDurationEnv("SUBJECT_REFRESH_INTERVAL"),
+		SubjectRefreshAge:      getDurationEnv("SUBJECT_REFRESH_AGE"),
+
+		SyncRunRetention: getDurationEnv("SYNC_RUN_RETENTION"),
 	}
 
+	cfg.GitHubAccounts = loadGitHubAccounts(os.Getenv("GH_ACCOUNTS"), cfg, os.Getenv)
+
 	// Warn about default credentials
 	warnAboutDefaultCredentials(cfg.DatabaseURL, cfg.Addr)
 
 	return cfg
 }
 
+// loadGitHubAccounts reads the accounts named by GH_ACCOUNTS (comma-separated), each
+// configured by GH_TOKEN_<NAME>, GH_API_URL_<NAME> and GH_WEB_URL_<NAME>. An account
+// named default falls back to GH_TOKEN, GH_API_URL and GH_WEB_URL, which configure the
+// only account when GH_ACCOUNTS is unset.
+func loadGitHubAccounts(names string, cfg Config, getenv func(string) string) []GitHubAccount {
+	defaultAccount := GitHubAccount{
+		Name:     defaultAccountName,
+		Token:    cfg.GHToken,
+		TokenEnv: "GH_TOKEN",
+		APIURL:   cfg.GitHubAPIURL,
+		WebURL:   cfg.GitHubWebURL,
+	}
+
+	var accounts []GitHubAccount
+	seen := map[string]bool{}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		suffix := "_" + envSuffix(name)
+		account := GitHubAccount{
+			Name:     name,
+			Token:    strings.TrimSpace(getenv("GH_TOKEN" + suffix)),
+			TokenEnv: "GH_TOKEN" + suffix,
+			APIURL:   strings.TrimSpace(getenv("GH_API_URL" + suffix)),
+			WebURL:   strings.TrimSpace(getenv("GH_WEB_URL" + suffix)),
+		}
+		unset := account.Token == "" && account.APIURL == "" && account.WebURL == ""
+		if name == defaultAccountName && unset {
+			account = defaultAccount
+		}
+		accounts = append(accounts, account)
+	}
+
+	if len(accounts) == 0 {
+		return []GitHubAccount{defaultAccount}
+	}
+	return accounts
+}
+
+// envSuffix turns an account name into the suffix of its environment variables:
+// upper case, with anything but letters and digits replaced by underscores
+func envSuffix(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok && value != "" {
 		return value
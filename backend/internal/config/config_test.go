@@ -0,0 +1,86 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadGitHubAccounts(t *testing.T) {
+	cfg := Config{
+		GHToken:      "default-token",
+		GitHubAPIURL: "https://ghe.example.com/api/v3",
+	}
+	env := map[string]string{
+		"GH_TOKEN_WORK":       "work-token",
+		"GH_TOKEN_ACME_GHE":   "ghe-token",
+		"GH_API_URL_ACME_GHE": " https://ghe.acme.com/api/v3 ",
+		"GH_WEB_URL_ACME_GHE": "https://ghe.acme.com",
+	}
+	getenv := func(key string) string { return env[key] }
+
+	tests := []struct {
+		name  string
+		names string
+		want  []GitHubAccount
+	}{
+		{
+			name:  "unset uses GH_TOKEN",
+			names: "",
+			want: []GitHubAccount{{
+				Name:     "default",
+				Token:    "default-token",
+				TokenEnv: "GH_TOKEN",
+				APIURL:   "https://ghe.example.com/api/v3",
+			}},
+		},
+		{
+			name:  "named accounts",
+			names: "Work, acme-ghe,work,",
+			want: []GitHubAccount{
+				{Name: "work", Token: "work-token", TokenEnv: "GH_TOKEN_WORK"},
+				{
+					Name:     "acme-ghe",
+					Token:    "ghe-token",
+					TokenEnv: "GH_TOKEN_ACME_GHE",
+					APIURL:   "https://ghe.acme.com/api/v3",
+					WebURL:   "https://ghe.acme.com",
+				},
+			},
+		},
+		{
+			name:  "default falls back to GH_TOKEN",
+			names: "default,work",
+			want: []GitHubAccount{
+				{
+					Name:     "default",
+					Token:    "default-token",
+					TokenEnv: "GH_TOKEN",
+					APIURL:   "https://ghe.example.com/api/v3",
+				},
+				{Name: "work", Token: "work-token", TokenEnv: "GH_TOKEN_WORK"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, loadGitHubAccounts(tt.names, cfg, getenv))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/sqlc-dev/pqtype"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrFailedToListAccounts           = errors.New("failed to list GitHub accounts")
+	ErrFailedToGetAccount             = errors.New("failed to get GitHub account")
+	ErrAccountNotFound                = errors.New("GitHub account not found")
+	ErrInvalidAccountName             = errors.New("invalid GitHub account name")
+	ErrFailedToRegisterAccount        = errors.New("failed to register GitHub account")
+	ErrFailedToUpdateSyncSettings     = errors.New("failed to update account sync settings")
+	ErrFailedToParseAccountSettings   = errors.New("failed to parse account sync settings")
+	ErrFailedToMarshalAccountSettings = errors.New("failed to marshal account sync settings")
+)
+
+// ListAccounts returns every GitHub account, oldest first
+func (s *Service) ListAccounts(ctx context.Context) ([]models.GitHubAccount, error) {
+	rows, err := s.queries.ListGitHubAccounts(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToListAccounts, err)
+	}
+
+	accounts := make([]models.GitHubAccount, 0, len(rows))
+	for _, row := range rows {
+		account, err := models.GitHubAccountFromDB(row)
+		if err != nil {
+			return nil, errors.Join(ErrFailedToParseAccountSettings, err)
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// GetAccount returns a GitHub account by ID
+func (s *Service) GetAccount(ctx context.Context, accountID int64) (models.GitHubAccount, error) {
+	row, err := s.queries.GetGitHubAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.GitHubAccount{}, ErrAccountNotFound
+		}
+		return models.GitHubAccount{}, errors.Join(ErrFailedToGetAccount, err)
+	}
+
+	account, err := models.GitHubAccountFromDB(row)
+	if err != nil {
+		return models.GitHubAccount{}, errors.Join(ErrFailedToParseAccountSettings, err)
+	}
+	return account, nil
+}
+
+// RegisterAccount creates the account with the given name, or updates its endpoints if
+// it exists. The server and worker register every configured account when they start.
+func (s *Service) RegisterAccount(
+	ctx context.Context,
+	name, apiURL, webURL string,
+) (models.GitHubAccount, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return models.GitHubAccount{}, ErrInvalidAccountName
+	}
+
+	row, err := s.queries.UpsertGitHubAccount(ctx, db.UpsertGitHubAccountParams{
+		Name:   name,
+		ApiUrl: apiURL,
+		WebUrl: webURL,
+	})
+	if err != nil {
+		return models.GitHubAccount{}, errors.Join(ErrFailedToRegisterAccount, err)
+	}
+
+	account, err := models.GitHubAccountFromDB(row)
+	if err != nil {
+		return models.GitHubAccount{}, errors.Join(ErrFailedToParseAccountSettings, err)
+	}
+	return account, nil
+}
+
+// UpdateSyncSettings sets the sync settings overriding the user's for an account; nil
+// settings go back to the user's
+func (s *Service) UpdateSyncSettings(
+	ctx context.Context,
+	accountID int64,
+	settings *models.SyncSettings,
+) (models.GitHubAccount, error) {
+	settingsJSON, err := settings.ToJSON()
+	if err != nil {
+		return models.GitHubAccount{}, errors.Join(ErrFailedToMarshalAccountSettings, err)
+	}
+
+	row, err := s.queries.UpdateGitHubAccountSyncSettings(
+		ctx,
+		db.UpdateGitHubAccountSyncSettingsParams{
+			SyncSettings: pqtype.NullRawMessage{
+				RawMessage: settingsJSON,
+				Valid:      settingsJSON != nil,
+			},
+			ID: accountID,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.GitHubAccount{}, ErrAccountNotFound
+		}
+		return models.GitHubAccount{}, errors.Join(ErrFailedToUpdateSyncSettings, err)
+	}
+
+	account, err := models.GitHubAccountFromDB(row)
+	if err != nil {
+		return models.GitHubAccount{}, errors.Join(ErrFailedToParseAccountSettings, err)
+	}
+	return account, nil
+}
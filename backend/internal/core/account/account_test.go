@@ -0,0 +1,224 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sqlc-dev/pqtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+func TestService_ListAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		ListGitHubAccounts(gomock.Any()).
+		Return([]db.GithubAccount{
+			{ID: 1, Name: "default", ApiUrl: "https://api.github.com"},
+			{
+				ID:     2,
+				Name:   "work",
+				ApiUrl: "https://ghe.example.com/api/v3",
+				SyncSettings: pqtype.NullRawMessage{
+					RawMessage: json.RawMessage(`{"initialSyncUnreadOnly":true}`),
+					Valid:      true,
+				},
+			},
+		}, nil)
+
+	accounts, err := NewService(mockStore).ListAccounts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	require.Equal(t, "default", accounts[0].Name)
+	require.Nil(t, accounts[0].SyncSettings)
+	require.Equal(t, "https://ghe.example.com/api/v3", accounts[1].APIURL)
+	require.NotNil(t, accounts[1].SyncSettings)
+	require.True(t, accounts[1].SyncSettings.InitialSyncUnreadOnly)
+}
+
+func TestService_ListAccounts_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		ListGitHubAccounts(gomock.Any()).
+		Return(nil, errors.New("database error"))
+
+	_, err := NewService(mockStore).ListAccounts(context.Background())
+	require.ErrorIs(t, err, ErrFailedToListAccounts)
+}
+
+func TestService_GetAccount(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+		wantErr   error
+	}{
+		{
+			name: "found",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetGitHubAccount(gomock.Any(), int64(2)).
+					Return(db.GithubAccount{ID: 2, Name: "work"}, nil)
+			},
+		},
+		{
+			name: "not found",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetGitHubAccount(gomock.Any(), int64(2)).
+					Return(db.GithubAccount{}, sql.ErrNoRows)
+			},
+			wantErr: ErrAccountNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetGitHubAccount(gomock.Any(), int64(2)).
+					Return(db.GithubAccount{}, errors.New("database error"))
+			},
+			wantErr: ErrFailedToGetAccount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockStore)
+
+			account, err := NewService(mockStore).GetAccount(context.Background(), 2)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "work", account.Name)
+		})
+	}
+}
+
+func TestService_RegisterAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		UpsertGitHubAccount(gomock.Any(), db.UpsertGitHubAccountParams{
+			Name:   "work",
+			ApiUrl: "https://ghe.example.com/api/v3",
+			WebUrl: "https://ghe.example.com",
+		}).
+		Return(db.GithubAccount{ID: 2, Name: "work"}, nil)
+
+	account, err := NewService(mockStore).RegisterAccount(
+		context.Background(),
+		" Work ",
+		"https://ghe.example.com/api/v3",
+		"https://ghe.example.com",
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), account.ID)
+
+	_, err = NewService(mockStore).RegisterAccount(context.Background(), " ", "", "")
+	require.ErrorIs(t, err, ErrInvalidAccountName)
+}
+
+func TestService_UpdateSyncSettings(t *testing.T) {
+	days := 7
+	tests := []struct {
+		name      string
+		settings  *models.SyncSettings
+		setupMock func(*mocks.MockStore)
+		wantErr   error
+	}{
+		{
+			name:     "saves the settings",
+			settings: &models.SyncSettings{InitialSyncDays: &days},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateGitHubAccountSyncSettings(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(
+						_ context.Context,
+						params db.UpdateGitHubAccountSyncSettingsParams,
+					) (db.GithubAccount, error) {
+						require.Equal(t, int64(2), params.ID)
+						require.True(t, params.SyncSettings.Valid)
+						return db.GithubAccount{ID: 2, SyncSettings: params.SyncSettings}, nil
+					})
+			},
+		},
+		{
+			name:     "nil clears the settings",
+			settings: nil,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateGitHubAccountSyncSettings(gomock.Any(), db.UpdateGitHubAccountSyncSettingsParams{
+						ID: 2,
+					}).
+					Return(db.GithubAccount{ID: 2}, nil)
+			},
+		},
+		{
+			name:     "account not found",
+			settings: nil,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateGitHubAccountSyncSettings(gomock.Any(), gomock.Any()).
+					Return(db.GithubAccount{}, sql.ErrNoRows)
+			},
+			wantErr: ErrAccountNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockStore)
+
+			account, err := NewService(mockStore).UpdateSyncSettings(
+				context.Background(),
+				2,
+				tt.settings,
+			)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.settings, account.SyncSettings)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/account/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/account/service.go -destination=internal/core/account/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ajbeattie/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccountService is a mock of AccountService interface.
+type MockAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountServiceMockRecorder is the mock recorder for MockAccountService.
+type MockAccountServiceMockRecorder struct {
+	mock *MockAccountService
+}
+
+// NewMockAccountService creates a new mock instance.
+func NewMockAccountService(ctrl *gomock.Controller) *MockAccountService {
+	mock := &MockAccountService{ctrl: ctrl}
+	mock.recorder = &MockAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAccount mocks base method.
+func (m *MockAccountService) GetAccount(ctx context.Context, accountID int64) (models.GitHubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, accountID)
+	ret0, _ := ret[0].(models.GitHubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountServiceMockRecorder) GetAccount(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountService)(nil).GetAccount), ctx, accountID)
+}
+
+// ListAccounts mocks base method.
+func (m *MockAccountService) ListAccounts(ctx context.Context) ([]models.GitHubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx)
+	ret0, _ := ret[0].([]models.GitHubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockAccountServiceMockRecorder) ListAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockAccountService)(nil).ListAccounts), ctx)
+}
+
+// RegisterAccount mocks base method.
+func (m *MockAccountService) RegisterAccount(ctx context.Context, name, apiURL, webURL string) (models.GitHubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterAccount", ctx, name, apiURL, webURL)
+	ret0, _ := ret[0].(models.GitHubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterAccount indicates an expected call of RegisterAccount.
+func (mr *MockAccountServiceMockRecorder) RegisterAccount(ctx, name, apiURL, webURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAccount", reflect.TypeOf((*MockAccountService)(nil).RegisterAccount), ctx, name, apiURL, webURL)
+}
+
+// UpdateSyncSettings mocks base method.
+func (m *MockAccountService) UpdateSyncSettings(ctx context.Context, accountID int64, settings *models.SyncSettings) (models.GitHubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncSettings", ctx, accountID, settings)
+	ret0, _ := ret[0].(models.GitHubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSyncSettings indicates an expected call of UpdateSyncSettings.
+func (mr *MockAccountServiceMockRecorder) UpdateSyncSettings(ctx, accountID, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncSettings", reflect.TypeOf((*MockAccountService)(nil).UpdateSyncSettings), ctx, accountID, settings)
+}
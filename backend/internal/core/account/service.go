@@ -0,0 +1,50 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package account provides the GitHub account service.
+package account
+
+import (
+	"context"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// AccountService is the interface for the GitHub account service.
+//
+//nolint:revive // exported type name stutters with package name
+type AccountService interface {
+	ListAccounts(ctx context.Context) ([]models.GitHubAccount, error)
+	GetAccount(ctx context.Context, accountID int64) (models.GitHubAccount, error)
+	RegisterAccount(ctx context.Context, name, apiURL, webURL string) (models.GitHubAccount, error)
+	UpdateSyncSettings(
+		ctx context.Context,
+		accountID int64,
+		settings *models.SyncSettings,
+	) (models.GitHubAccount, error)
+}
+
+// Service provides business logic for GitHub account operations
+type Service struct {
+	queries db.Store
+}
+
+// NewService constructs a Service backed by the provided queries
+func NewService(queries db.Store) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
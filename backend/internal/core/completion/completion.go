@@ -0,0 +1,225 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package completion
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/query/parse"
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadCompletions = errors.New("failed to load completions")
+)
+
+// maxCompletions caps how many suggestions are returned for one cursor position
+const maxCompletions = 20
+
+// Complete returns suggestions for the text at the cursor (a byte offset into queryStr).
+// Field names come from the query field registry; values for fields like repo:, author:,
+// label:, tags: and view: come from stored data, as do @macro names, and fixed value sets
+// (in:, is:, ...) from the registry.
+func (s *Service) Complete(
+	ctx context.Context,
+	queryStr string,
+	cursor int,
+) (models.QueryCompletions, error) {
+	completionCtx := parse.CompletionContextAt(queryStr, cursor)
+
+	result := models.QueryCompletions{
+		Start: completionCtx.Start,
+		End:   completionCtx.End,
+		Items: []models.QueryCompletion{},
+	}
+
+	switch completionCtx.Kind {
+	case parse.CompleteField:
+		if strings.HasPrefix(completionCtx.Prefix, parse.MacroPrefix) {
+			names, err := s.macroNames(ctx, completionCtx.Prefix[len(parse.MacroPrefix):])
+			if err != nil {
+				return models.QueryCompletions{}, errors.Join(ErrFailedToLoadCompletions, err)
+			}
+			for _, name := range names {
+				result.Items = append(result.Items, models.QueryCompletion{
+					Text: parse.MacroPrefix + name,
+					Kind: models.QueryCompletionMacro,
+				})
+			}
+			break
+		}
+		for _, name := range parse.FieldCompletions(completionCtx.Prefix) {
+			result.Items = append(result.Items, models.QueryCompletion{
+				Text: name + ":",
+				Kind: models.QueryCompletionField,
+			})
+		}
+	case parse.CompleteValue:
+		values, err := s.valueCompletions(ctx, completionCtx.Field, completionCtx.Prefix)
+		if err != nil {
+			return models.QueryCompletions{}, errors.Join(ErrFailedToLoadCompletions, err)
+		}
+		result.Field = strings.ToLower(completionCtx.Field)
+		for _, value := range values {
+			result.Items = append(result.Items, models.QueryCompletion{
+				Text: parse.QuoteValue(value),
+				Kind: models.QueryCompletionValue,
+			})
+		}
+	case parse.CompleteNone:
+	}
+
+	if len(result.Items) > maxCompletions {
+		result.Items = result.Items[:maxCompletions]
+	}
+	return result, nil
+}
+
+// valueCompletions returns values for a field that contain the typed prefix
+func (s *Service) valueCompletions(ctx context.Context, field, prefix string) ([]string, error) {
+	spec, ok := parse.LookupField(field)
+	if !ok {
+		return nil, nil
+	}
+
+	switch {
+	case spec.Kind == parse.FieldTags:
+		return s.tagSlugs(ctx, prefix)
+	case spec.Kind == parse.FieldView:
+		return s.viewSlugs(ctx, prefix)
+	case spec.Kind == parse.FieldAccount:
+		return s.accountNames(ctx, prefix)
+	case spec.Kind == parse.FieldPrefix:
+		return s.queries.ListRepositoryOwners(ctx, db.ListRepositoryOwnersParams{
+			Search:   prefix,
+			RowLimit: maxCompletions,
+		})
+	case spec.Column == parse.ColumnRepoFullName:
+		return s.queries.ListRepositoryFullNames(ctx, db.ListRepositoryFullNamesParams{
+			Search:   prefix,
+			RowLimit: maxCompletions,
+		})
+	case spec.Column == parse.ColumnAuthorLogin:
+		return s.queries.ListNotificationAuthorLogins(ctx, db.ListNotificationAuthorLoginsParams{
+			Search:   prefix,
+			RowLimit: maxCompletions,
+		})
+	case spec.Column == parse.ColumnReason:
+		return s.queries.ListNotificationReasons(ctx, db.ListNotificationReasonsParams{
+			Search:   prefix,
+			RowLimit: maxCompletions,
+		})
+	case spec.Column == parse.ColumnSubjectLabels:
+		return s.queries.ListNotificationLabels(ctx, db.ListNotificationLabelsParams{
+			Search:   prefix,
+			RowLimit: maxCompletions,
+		})
+	case spec.Column == parse.ColumnSubjectMilestone:
+		return s.queries.ListNotificationMilestones(ctx, db.ListNotificationMilestonesParams{
+			Search:   prefix,
+			RowLimit: maxCompletions,
+		})
+	case spec.Column == parse.ColumnSubjectCategory:
+		return s.queries.ListNotificationCategories(ctx, db.ListNotificationCategoriesParams{
+			Search:   prefix,
+			RowLimit: maxCompletions,
+		})
+	case spec.Column == parse.ColumnSubjectType:
+		return s.queries.ListNotificationSubjectTypes(ctx, db.ListNotificationSubjectTypesParams{
+			Search:   prefix,
+			RowLimit: maxCompletions,
+		})
+	default:
+		return parse.StaticValueCompletions(field, prefix), nil
+	}
+}
+
+// tagSlugs returns the slugs of tags whose slug contains prefix
+func (s *Service) tagSlugs(ctx context.Context, prefix string) ([]string, error) {
+	tags, err := s.queries.ListAllTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	var slugs []string
+	for _, tag := range tags {
+		if strings.Contains(strings.ToLower(tag.Slug), prefix) {
+			slugs = append(slugs, tag.Slug)
+		}
+	}
+	return slugs, nil
+}
+
+// accountNames returns the names of GitHub accounts whose name contains prefix
+func (s *Service) accountNames(ctx context.Context, prefix string) ([]string, error) {
+	accounts, err := s.queries.ListGitHubAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	var names []string
+	for _, account := range accounts {
+		if strings.Contains(strings.ToLower(account.Name), prefix) {
+			names = append(names, account.Name)
+		}
+	}
+	return names, nil
+}
+
+// viewSlugs returns the slugs of views (including system views) whose slug contains prefix
+func (s *Service) viewSlugs(ctx context.Context, prefix string) ([]string, error) {
+	views, err := s.queries.ListViews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	var slugs []string
+	for _, view := range views {
+		if strings.Contains(strings.ToLower(view.Slug), prefix) {
+			slugs = append(slugs, view.Slug)
+		}
+	}
+	for _, slug := range db.SystemViewSlugs() {
+		if strings.Contains(slug, prefix) {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs, nil
+}
+
+// macroNames returns the names of query macros that contain prefix
+func (s *Service) macroNames(ctx context.Context, prefix string) ([]string, error) {
+	macros, err := s.queries.ListQueryMacros(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	var names []string
+	for _, macro := range macros {
+		if strings.Contains(macro.Name, prefix) {
+			names = append(names, macro.Name)
+		}
+	}
+	return names, nil
+}
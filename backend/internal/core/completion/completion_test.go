@@ -0,0 +1,316 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package completion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+func TestService_Complete(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		cursor      int
+		setupMock   func(*mocks.MockStore)
+		expectErr   bool
+		checkResult func(*testing.T, models.QueryCompletions)
+	}{
+		{
+			name:   "field names from the registry",
+			query:  "is:unread rea",
+			cursor: 13,
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, 10, result.Start)
+				require.Equal(t, 13, result.End)
+				require.Equal(t, []models.QueryCompletion{
+					{Text: "read:", Kind: models.QueryCompletionField},
+					{Text: "reason:", Kind: models.QueryCompletionField},
+				}, result.Items)
+			},
+		},
+		{
+			name:   "repo values from repositories",
+			query:  "repo:cl",
+			cursor: 7,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListRepositoryFullNames(gomock.Any(), db.ListRepositoryFullNamesParams{
+						Search:   "cl",
+						RowLimit: maxCompletions,
+					}).
+					Return([]string{"cli/cli", "octo/clippy"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, "repo", result.Field)
+				require.Equal(t, 5, result.Start)
+				require.Equal(t, []models.QueryCompletion{
+					{Text: "cli/cli", Kind: models.QueryCompletionValue},
+					{Text: "octo/clippy", Kind: models.QueryCompletionValue},
+				}, result.Items)
+			},
+		},
+		{
+			name:   "org values from repository owners",
+			query:  "org:",
+			cursor: 4,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListRepositoryOwners(gomock.Any(), gomock.Any()).
+					Return([]string{"cli"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Len(t, result.Items, 1)
+				require.Equal(t, "cli", result.Items[0].Text)
+			},
+		},
+		{
+			name:   "author values from notifications",
+			query:  "author:oct",
+			cursor: 10,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationAuthorLogins(
+						gomock.Any(),
+						db.ListNotificationAuthorLoginsParams{Search: "oct", RowLimit: maxCompletions},
+					).
+					Return([]string{"octocat", "dependabot[bot]"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, "octocat", result.Items[0].Text)
+				require.Equal(t, "dependabot[bot]", result.Items[1].Text)
+			},
+		},
+		{
+			name:   "reason and type values from notifications",
+			query:  "reason:m type:",
+			cursor: 8,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationReasons(gomock.Any(), gomock.Any()).
+					Return([]string{"mention", "team_mention"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Len(t, result.Items, 2)
+			},
+		},
+		{
+			name:   "subject type values",
+			query:  "type:",
+			cursor: 5,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationSubjectTypes(gomock.Any(), gomock.Any()).
+					Return([]string{"Issue", "PullRequest"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Len(t, result.Items, 2)
+			},
+		},
+		{
+			name:   "label and milestone values from notifications",
+			query:  "label:good milestone:v2",
+			cursor: 23,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationMilestones(
+						gomock.Any(),
+						db.ListNotificationMilestonesParams{Search: "v2", RowLimit: 20},
+					).
+					Return([]string{"v2.0", "v2.1"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, "milestone", result.Field)
+				require.Len(t, result.Items, 2)
+			},
+		},
+		{
+			name:   "labels needing quotes are quoted",
+			query:  "label:good",
+			cursor: 10,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationLabels(
+						gomock.Any(),
+						db.ListNotificationLabelsParams{Search: "good", RowLimit: 20},
+					).
+					Return([]string{"good first issue"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, `"good first issue"`, result.Items[0].Text)
+			},
+		},
+		{
+			name:   "category values from notifications",
+			query:  "category:q",
+			cursor: 10,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationCategories(
+						gomock.Any(),
+						db.ListNotificationCategoriesParams{Search: "q", RowLimit: 20},
+					).
+					Return([]string{"Q&A"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, "category", result.Field)
+				require.Len(t, result.Items, 1)
+			},
+		},
+		{
+			name:   "tag slugs filtered by prefix",
+			query:  "tags:BU",
+			cursor: 7,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListAllTags(gomock.Any()).
+					Return([]db.Tag{{Slug: "bug"}, {Slug: "feature"}, {Slug: "debug-me"}}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, []models.QueryCompletion{
+					{Text: "bug", Kind: models.QueryCompletionValue},
+					{Text: "debug-me", Kind: models.QueryCompletionValue},
+				}, result.Items)
+			},
+		},
+		{
+			name:   "account names filtered by prefix",
+			query:  "account:WO",
+			cursor: 10,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListGitHubAccounts(gomock.Any()).
+					Return([]db.GithubAccount{{Name: "default"}, {Name: "work"}}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, []models.QueryCompletion{
+					{Text: "work", Kind: models.QueryCompletionValue},
+				}, result.Items)
+			},
+		},
+		{
+			name:   "static values without a query",
+			query:  "in:ar",
+			cursor: 5,
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, []models.QueryCompletion{
+					{Text: "archive", Kind: models.QueryCompletionValue},
+				}, result.Items)
+			},
+		},
+		{
+			name:   "values needing quotes are quoted",
+			query:  "reason:",
+			cursor: 7,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListNotificationReasons(gomock.Any(), gomock.Any()).
+					Return([]string{"security alert"}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, `"security alert"`, result.Items[0].Text)
+			},
+		},
+		{
+			name:   "view values from custom and system views",
+			query:  "view:ar",
+			cursor: 7,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListViews(gomock.Any()).
+					Return([]db.View{{Slug: "team-prs"}, {Slug: "Arch-review"}}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, "view", result.Field)
+				require.Equal(t, []models.QueryCompletion{
+					{Text: "Arch-review", Kind: models.QueryCompletionValue},
+					{Text: "archive", Kind: models.QueryCompletionValue},
+					{Text: "starred", Kind: models.QueryCompletionValue},
+				}, result.Items)
+			},
+		},
+		{
+			name:   "macro names after @",
+			query:  "is:unread @bo",
+			cursor: 13,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListQueryMacros(gomock.Any()).
+					Return([]db.QueryMacro{{Name: "bots"}, {Name: "team"}, {Name: "robots"}}, nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.Equal(t, 10, result.Start)
+				require.Equal(t, []models.QueryCompletion{
+					{Text: "@bots", Kind: models.QueryCompletionMacro},
+					{Text: "@robots", Kind: models.QueryCompletionMacro},
+				}, result.Items)
+			},
+		},
+		{
+			name:   "nothing to complete",
+			query:  `repo:"cli"`,
+			cursor: 10,
+			checkResult: func(t *testing.T, result models.QueryCompletions) {
+				require.NotNil(t, result.Items)
+				require.Empty(t, result.Items)
+			},
+		},
+		{
+			name:   "database error is wrapped",
+			query:  "repo:",
+			cursor: 5,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListRepositoryFullNames(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStore(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(mockStore)
+			}
+			service := NewService(mockStore)
+
+			result, err := service.Complete(context.Background(), tt.query, tt.cursor)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrFailedToLoadCompletions))
+				return
+			}
+			require.NoError(t, err)
+			if tt.checkResult != nil {
+				tt.checkResult(t, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/completion/service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/completion/service.go -destination=internal/core/completion/mocks/mock_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ajbeattie/octobud/backend/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCompletionService is a mock of CompletionService interface.
+type MockCompletionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompletionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCompletionServiceMockRecorder is the mock recorder for MockCompletionService.
+type MockCompletionServiceMockRecorder struct {
+	mock *MockCompletionService
+}
+
+// NewMockCompletionService creates a new mock instance.
+func NewMockCompletionService(ctrl *gomock.Controller) *MockCompletionService {
+	mock := &MockCompletionService{ctrl: ctrl}
+	mock.recorder = &MockCompletionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompletionService) EXPECT() *MockCompletionServiceMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockCompletionService) Complete(ctx context.Context, queryStr string, cursor int) (models.QueryCompletions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, queryStr, cursor)
+	ret0, _ := ret[0].(models.QueryCompletions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockCompletionServiceMockRecorder) Complete(ctx, queryStr, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockCompletionService)(nil).Complete), ctx, queryStr, cursor)
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package completion provides query autocompletion backed by stored data.
+package completion
+
+import (
+	"context"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// CompletionService is the interface for the query completion service.
+//
+//nolint:revive // exported type name stutters with package name
+type CompletionService interface {
+	Complete(ctx context.Context, queryStr string, cursor int) (models.QueryCompletions, error)
+}
+
+// Service provides query completions from the field registry and stored data
+type Service struct {
+	queries db.Store
+}
+
+// NewService constructs a Service backed by the provided queries
+func NewService(queries db.Store) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
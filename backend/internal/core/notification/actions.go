@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 )
 
 // Error definitions
@@ -29,54 +30,109 @@ var (
 	ErrInvalidSnoozedUntilFormat = errors.New("invalid snoozedUntil format")
 )
 
-// MarkNotificationRead marks a notification as read.
+// MarkNotificationRead marks a notification as read, and on GitHub if write-back is enabled.
 func (s *Service) MarkNotificationRead(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 ) (db.Notification, error) {
-	return s.queries.MarkNotificationRead(ctx, githubID)
+	notification, err := s.queries.MarkNotificationRead(ctx, db.MarkNotificationReadParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
+	if err == nil {
+		s.queueWriteBack(ctx, models.GitHubActionMarkRead, accountID, []string{githubID})
+	}
+	return notification, err
 }
 
 // MarkNotificationUnread marks a notification as unread.
 func (s *Service) MarkNotificationUnread(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 ) (db.Notification, error) {
-	return s.queries.MarkNotificationUnread(ctx, githubID)
+	return s.queries.MarkNotificationUnread(ctx, db.MarkNotificationUnreadParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
+}
+
+// MarkNotificationViewed records that a notification was opened, so background subject
+// refreshes get to it first.
+func (s *Service) MarkNotificationViewed(
+	ctx context.Context,
+	accountID int64,
+	githubID string,
+) error {
+	return s.queries.MarkNotificationViewed(ctx, db.MarkNotificationViewedParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 }
 
-// ArchiveNotification archives a notification.
+// ArchiveNotification archives a notification, marking it done on GitHub if write-back
+// is enabled.
 func (s *Service) ArchiveNotification(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 ) (db.Notification, error) {
-	return s.queries.ArchiveNotification(ctx, githubID)
+	notification, err := s.queries.ArchiveNotification(ctx, db.ArchiveNotificationParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
+	if err == nil {
+		s.queueWriteBack(ctx, models.GitHubActionMarkDone, accountID, []string{githubID})
+	}
+	return notification, err
 }
 
 // UnarchiveNotification unarchives a notification.
 func (s *Service) UnarchiveNotification(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 ) (db.Notification, error) {
-	return s.queries.UnarchiveNotification(ctx, githubID)
+	return s.queries.UnarchiveNotification(ctx, db.UnarchiveNotificationParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 }
 
-// MuteNotification mutes a notification.
-func (s *Service) MuteNotification(ctx context.Context, githubID string) (db.Notification, error) {
-	return s.queries.MuteNotification(ctx, githubID)
+// MuteNotification mutes a notification, unsubscribing from it on GitHub if write-back
+// is enabled.
+func (s *Service) MuteNotification(
+	ctx context.Context,
+	accountID int64,
+	githubID string,
+) (db.Notification, error) {
+	notification, err := s.queries.MuteNotification(ctx, db.MuteNotificationParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
+	if err == nil {
+		s.queueWriteBack(ctx, models.GitHubActionUnsubscribe, accountID, []string{githubID})
+	}
+	return notification, err
 }
 
 // UnmuteNotification unmutes a notification.
 func (s *Service) UnmuteNotification(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 ) (db.Notification, error) {
-	return s.queries.UnmuteNotification(ctx, githubID)
+	return s.queries.UnmuteNotification(ctx, db.UnmuteNotificationParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 }
 
 // SnoozeNotification snoozes a notification until a specified time.
 func (s *Service) SnoozeNotification(
 	ctx context.Context,
+	accountID int64,
 	githubID, snoozedUntil string,
 ) (db.Notification, error) {
 	// Parse the time string
@@ -86,6 +142,7 @@ func (s *Service) SnoozeNotification(
 	}
 
 	return s.queries.SnoozeNotification(ctx, db.SnoozeNotificationParams{
+		AccountID:    accountID,
 		GithubID:     githubID,
 		SnoozedUntil: sql.NullTime{Time: t, Valid: true},
 	})
@@ -94,28 +151,47 @@ func (s *Service) SnoozeNotification(
 // UnsnoozeNotification clears the snooze on a notification.
 func (s *Service) UnsnoozeNotification(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 ) (db.Notification, error) {
-	return s.queries.UnsnoozeNotification(ctx, githubID)
+	return s.queries.UnsnoozeNotification(ctx, db.UnsnoozeNotificationParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 }
 
 // StarNotification stars a notification.
-func (s *Service) StarNotification(ctx context.Context, githubID string) (db.Notification, error) {
-	return s.queries.StarNotification(ctx, githubID)
+func (s *Service) StarNotification(
+	ctx context.Context,
+	accountID int64,
+	githubID string,
+) (db.Notification, error) {
+	return s.queries.StarNotification(ctx, db.StarNotificationParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 }
 
 // UnstarNotification unstars a notification.
 func (s *Service) UnstarNotification(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 ) (db.Notification, error) {
-	return s.queries.UnstarNotification(ctx, githubID)
+	return s.queries.UnstarNotification(ctx, db.UnstarNotificationParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 }
 
 // UnfilterNotification unfilters a notification (moves it to inbox).
 func (s *Service) UnfilterNotification(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 ) (db.Notification, error) {
-	return s.queries.MarkNotificationUnfiltered(ctx, githubID)
+	return s.queries.MarkNotificationUnfiltered(ctx, db.MarkNotificationUnfilteredParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 }
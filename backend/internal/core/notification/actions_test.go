@@ -27,6 +27,7 @@ import (
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
 	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 )
 
 func TestService_MarkNotificationRead(t *testing.T) {
@@ -53,7 +54,10 @@ func TestService_MarkNotificationRead(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					MarkNotificationRead(gomock.Any(), id).
+					MarkNotificationRead(gomock.Any(), db.MarkNotificationReadParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -68,7 +72,10 @@ func TestService_MarkNotificationRead(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					MarkNotificationRead(gomock.Any(), id).
+					MarkNotificationRead(gomock.Any(), db.MarkNotificationReadParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -89,7 +96,7 @@ func TestService_MarkNotificationRead(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.MarkNotificationRead(ctx, tt.githubID)
+			result, err := service.MarkNotificationRead(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -128,7 +135,10 @@ func TestService_MarkNotificationUnread(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					MarkNotificationUnread(gomock.Any(), id).
+					MarkNotificationUnread(gomock.Any(), db.MarkNotificationUnreadParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -143,7 +153,10 @@ func TestService_MarkNotificationUnread(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					MarkNotificationUnread(gomock.Any(), id).
+					MarkNotificationUnread(gomock.Any(), db.MarkNotificationUnreadParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -163,7 +176,7 @@ func TestService_MarkNotificationUnread(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.MarkNotificationUnread(ctx, tt.githubID)
+			result, err := service.MarkNotificationUnread(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -202,7 +215,10 @@ func TestService_ArchiveNotification(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					ArchiveNotification(gomock.Any(), id).
+					ArchiveNotification(gomock.Any(), db.ArchiveNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -217,7 +233,10 @@ func TestService_ArchiveNotification(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					ArchiveNotification(gomock.Any(), id).
+					ArchiveNotification(gomock.Any(), db.ArchiveNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -237,7 +256,7 @@ func TestService_ArchiveNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.ArchiveNotification(ctx, tt.githubID)
+			result, err := service.ArchiveNotification(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -276,7 +295,10 @@ func TestService_UnarchiveNotification(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					UnarchiveNotification(gomock.Any(), id).
+					UnarchiveNotification(gomock.Any(), db.UnarchiveNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -291,7 +313,10 @@ func TestService_UnarchiveNotification(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					UnarchiveNotification(gomock.Any(), id).
+					UnarchiveNotification(gomock.Any(), db.UnarchiveNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -311,7 +336,7 @@ func TestService_UnarchiveNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.UnarchiveNotification(ctx, tt.githubID)
+			result, err := service.UnarchiveNotification(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -350,7 +375,10 @@ func TestService_MuteNotification(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					MuteNotification(gomock.Any(), id).
+					MuteNotification(gomock.Any(), db.MuteNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -365,7 +393,10 @@ func TestService_MuteNotification(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					MuteNotification(gomock.Any(), id).
+					MuteNotification(gomock.Any(), db.MuteNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -385,7 +416,7 @@ func TestService_MuteNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.MuteNotification(ctx, tt.githubID)
+			result, err := service.MuteNotification(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -424,7 +455,10 @@ func TestService_UnmuteNotification(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					UnmuteNotification(gomock.Any(), id).
+					UnmuteNotification(gomock.Any(), db.UnmuteNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -439,7 +473,10 @@ func TestService_UnmuteNotification(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					UnmuteNotification(gomock.Any(), id).
+					UnmuteNotification(gomock.Any(), db.UnmuteNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -459,7 +496,7 @@ func TestService_UnmuteNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.UnmuteNotification(ctx, tt.githubID)
+			result, err := service.UnmuteNotification(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -498,7 +535,10 @@ func TestService_UnsnoozeNotification(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					UnsnoozeNotification(gomock.Any(), id).
+					UnsnoozeNotification(gomock.Any(), db.UnsnoozeNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -513,7 +553,10 @@ func TestService_UnsnoozeNotification(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					UnsnoozeNotification(gomock.Any(), id).
+					UnsnoozeNotification(gomock.Any(), db.UnsnoozeNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -533,7 +576,7 @@ func TestService_UnsnoozeNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.UnsnoozeNotification(ctx, tt.githubID)
+			result, err := service.UnsnoozeNotification(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -572,7 +615,10 @@ func TestService_StarNotification(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					StarNotification(gomock.Any(), id).
+					StarNotification(gomock.Any(), db.StarNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -587,7 +633,10 @@ func TestService_StarNotification(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					StarNotification(gomock.Any(), id).
+					StarNotification(gomock.Any(), db.StarNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -607,7 +656,7 @@ func TestService_StarNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.StarNotification(ctx, tt.githubID)
+			result, err := service.StarNotification(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -646,7 +695,10 @@ func TestService_UnstarNotification(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					UnstarNotification(gomock.Any(), id).
+					UnstarNotification(gomock.Any(), db.UnstarNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -661,7 +713,10 @@ func TestService_UnstarNotification(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					UnstarNotification(gomock.Any(), id).
+					UnstarNotification(gomock.Any(), db.UnstarNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -681,7 +736,7 @@ func TestService_UnstarNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.UnstarNotification(ctx, tt.githubID)
+			result, err := service.UnstarNotification(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -720,7 +775,10 @@ func TestService_UnfilterNotification(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					MarkNotificationUnfiltered(gomock.Any(), id).
+					MarkNotificationUnfiltered(gomock.Any(), db.MarkNotificationUnfilteredParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -735,7 +793,10 @@ func TestService_UnfilterNotification(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, id string) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					MarkNotificationUnfiltered(gomock.Any(), id).
+					MarkNotificationUnfiltered(gomock.Any(), db.MarkNotificationUnfilteredParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -755,7 +816,7 @@ func TestService_UnfilterNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.UnfilterNotification(ctx, tt.githubID)
+			result, err := service.UnfilterNotification(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -834,7 +895,7 @@ func TestService_SnoozeNotification(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.SnoozeNotification(ctx, tt.githubID, tt.snoozedUntil)
+			result, err := service.SnoozeNotification(ctx, models.DefaultAccountID, tt.githubID, tt.snoozedUntil)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -850,3 +911,153 @@ func TestService_SnoozeNotification(t *testing.T) {
 		})
 	}
 }
+
+// fakeWriteBack records the write-backs a Service queues
+type fakeWriteBack struct {
+	enabled bool
+	queued  map[models.GitHubAction][]string
+}
+
+func (f *fakeWriteBack) Enabled(_ context.Context) bool {
+	return f.enabled
+}
+
+func (f *fakeWriteBack) Enqueue(
+	_ context.Context,
+	action models.GitHubAction,
+	_ int64,
+	githubIDs []string,
+) {
+	if f.queued == nil {
+		f.queued = make(map[models.GitHubAction][]string)
+	}
+	f.queued[action] = append(f.queued[action], githubIDs...)
+}
+
+func TestService_ActionsWriteBack(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		setupMock func(*mocks.MockStore)
+		act       func(*Service) error
+		expected  map[models.GitHubAction][]string
+	}{
+		{
+			name:    "mark read queues mark-read",
+			enabled: true,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					MarkNotificationRead(gomock.Any(), db.MarkNotificationReadParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  "abc",
+					}).
+					Return(db.Notification{GithubID: "abc"}, nil)
+			},
+			act: func(s *Service) error {
+				_, err := s.MarkNotificationRead(context.Background(), models.DefaultAccountID, "abc")
+				return err
+			},
+			expected: map[models.GitHubAction][]string{models.GitHubActionMarkRead: {"abc"}},
+		},
+		{
+			name:    "archive queues mark-done",
+			enabled: true,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ArchiveNotification(gomock.Any(), db.ArchiveNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  "abc",
+					}).
+					Return(db.Notification{GithubID: "abc"}, nil)
+			},
+			act: func(s *Service) error {
+				_, err := s.ArchiveNotification(context.Background(), models.DefaultAccountID, "abc")
+				return err
+			},
+			expected: map[models.GitHubAction][]string{models.GitHubActionMarkDone: {"abc"}},
+		},
+		{
+			name:    "mute queues unsubscribe",
+			enabled: true,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					MuteNotification(gomock.Any(), db.MuteNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  "abc",
+					}).
+					Return(db.Notification{GithubID: "abc"}, nil)
+			},
+			act: func(s *Service) error {
+				_, err := s.MuteNotification(context.Background(), models.DefaultAccountID, "abc")
+				return err
+			},
+			expected: map[models.GitHubAction][]string{models.GitHubActionUnsubscribe: {"abc"}},
+		},
+		{
+			name:    "star is not written back",
+			enabled: true,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					StarNotification(gomock.Any(), db.StarNotificationParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  "abc",
+					}).
+					Return(db.Notification{GithubID: "abc"}, nil)
+			},
+			act: func(s *Service) error {
+				_, err := s.StarNotification(context.Background(), models.DefaultAccountID, "abc")
+				return err
+			},
+		},
+		{
+			name:    "nothing queued when disabled",
+			enabled: false,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					MarkNotificationRead(gomock.Any(), db.MarkNotificationReadParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  "abc",
+					}).
+					Return(db.Notification{GithubID: "abc"}, nil)
+			},
+			act: func(s *Service) error {
+				_, err := s.MarkNotificationRead(context.Background(), models.DefaultAccountID, "abc")
+				return err
+			},
+		},
+		{
+			name:    "nothing queued when the update fails",
+			enabled: true,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					MarkNotificationRead(gomock.Any(), db.MarkNotificationReadParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  "abc",
+					}).
+					Return(db.Notification{}, errors.New("database error"))
+			},
+			act: func(s *Service) error {
+				_, err := s.MarkNotificationRead(context.Background(), models.DefaultAccountID, "abc")
+				if err == nil {
+					return errors.New("expected an error")
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			writeBack := &fakeWriteBack{enabled: tt.enabled}
+			service := NewService(mockQuerier).WithWriteBack(writeBack)
+
+			require.NoError(t, tt.act(service))
+			require.Equal(t, tt.expected, writeBack.queued)
+		})
+	}
+}
@@ -67,15 +67,93 @@ func (s *Service) BulkUpdate(
 
 	// Execute based on target type
 	if len(target.IDs) > 0 {
-		return s.executeBulkUpdateByIDs(ctx, op, target.IDs, params)
+		count, err := s.executeBulkUpdateByIDs(ctx, op, target.AccountID, target.IDs, params)
+		if err == nil {
+			if action, ok := models.GitHubActionForBulkOp(op); ok {
+				s.queueWriteBack(ctx, action, target.AccountID, dedupeAndSort(target.IDs))
+			}
+		}
+		return count, err
 	}
-	return s.executeBulkUpdateByQuery(ctx, op, target.Query, params)
+
+	// The update doesn't say which notifications it changed, so list them beforehand
+	var writeBackIDs map[int64][]string
+	action, writeBack := models.GitHubActionForBulkOp(op)
+	if writeBack && s.writeBackEnabled(ctx) {
+		ids, err := s.listGithubIDsForQuery(ctx, target.Query)
+		if err != nil {
+			return 0, err
+		}
+		writeBackIDs = ids
+	}
+
+	count, err := s.executeBulkUpdateByQuery(ctx, op, target.Query, params)
+	if err == nil {
+		for accountID, githubIDs := range writeBackIDs {
+			s.queueWriteBack(ctx, action, accountID, githubIDs)
+		}
+	}
+	return count, err
+}
+
+// writeBackPageSize is how many notifications a bulk operation by query lists at a time
+// to write them back to GitHub
+const writeBackPageSize = 1000
+
+// listGithubIDsForQuery lists the GitHub IDs of every notification a query matches,
+// grouped by account, a page at a time
+func (s *Service) listGithubIDsForQuery(
+	ctx context.Context,
+	queryStr string,
+) (map[int64][]string, error) {
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToBuildQuery, err)
+	}
+
+	dbQuery, err := query.BuildQuery(queryStr, defs, 0, 0)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToBuildQuery, err)
+	}
+
+	byAccount := make(map[int64][]string)
+	var afterID int64
+	for {
+		threads, err := s.queries.ListNotificationThreadsFromQuery(
+			ctx,
+			db.ListNotificationThreadsFromQueryParams{
+				Query:   dbQuery,
+				AfterID: afterID,
+				Limit:   writeBackPageSize,
+			},
+		)
+		if err != nil {
+			return nil, errors.Join(ErrFailedToListNotifications, err)
+		}
+		for _, thread := range threads {
+			byAccount[thread.AccountID] = append(byAccount[thread.AccountID], thread.GithubID)
+		}
+		if len(threads) < writeBackPageSize {
+			return byAccount, nil
+		}
+		afterID = threads[len(threads)-1].ID
+	}
+}
+
+// groupGithubIDsByAccount groups the GitHub IDs of notifications by their account
+func groupGithubIDsByAccount(notifications []db.Notification) map[int64][]string {
+	byAccount := make(map[int64][]string)
+	for _, n := range notifications {
+		byAccount[n.AccountID] = append(byAccount[n.AccountID], n.GithubID)
+	}
+	return byAccount
 }
 
 // executeBulkUpdateByIDs executes a bulk operation using notification IDs
 func (s *Service) executeBulkUpdateByIDs(
 	ctx context.Context,
 	op models.BulkOperationType,
+	accountID int64,
 	githubIDs []string,
 	params models.BulkUpdateParams,
 ) (int64, error) {
@@ -86,34 +164,65 @@ func (s *Service) executeBulkUpdateByIDs(
 
 	switch op {
 	case models.BulkOpMarkRead:
-		return s.queries.BulkMarkNotificationsRead(ctx, canonicalIDs)
+		return s.queries.BulkMarkNotificationsRead(ctx, db.BulkMarkNotificationsReadParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpMarkUnread:
-		return s.queries.BulkMarkNotificationsUnread(ctx, canonicalIDs)
+		return s.queries.BulkMarkNotificationsUnread(ctx, db.BulkMarkNotificationsUnreadParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpArchive:
-		return s.queries.BulkArchiveNotifications(ctx, canonicalIDs)
+		return s.queries.BulkArchiveNotifications(ctx, db.BulkArchiveNotificationsParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpUnarchive:
-		return s.queries.BulkUnarchiveNotifications(ctx, canonicalIDs)
+		return s.queries.BulkUnarchiveNotifications(ctx, db.BulkUnarchiveNotificationsParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpMute:
-		return s.queries.BulkMuteNotifications(ctx, canonicalIDs)
+		return s.queries.BulkMuteNotifications(ctx, db.BulkMuteNotificationsParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpUnmute:
-		return s.queries.BulkUnmuteNotifications(ctx, canonicalIDs)
+		return s.queries.BulkUnmuteNotifications(ctx, db.BulkUnmuteNotificationsParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpStar:
-		return s.queries.BulkStarNotifications(ctx, canonicalIDs)
+		return s.queries.BulkStarNotifications(ctx, db.BulkStarNotificationsParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpUnstar:
-		return s.queries.BulkUnstarNotifications(ctx, canonicalIDs)
+		return s.queries.BulkUnstarNotifications(ctx, db.BulkUnstarNotificationsParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpUnfilter:
-		return s.queries.BulkMarkNotificationsUnfiltered(ctx, canonicalIDs)
+		return s.queries.BulkMarkNotificationsUnfiltered(ctx, db.BulkMarkNotificationsUnfilteredParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	case models.BulkOpSnooze:
 		t, err := time.Parse(time.RFC3339, params.SnoozedUntil)
 		if err != nil {
 			return 0, errors.Join(ErrInvalidSnoozedUntilFormat, err)
 		}
 		return s.queries.BulkSnoozeNotifications(ctx, db.BulkSnoozeNotificationsParams{
+			AccountID:    accountID,
 			GithubIds:    canonicalIDs,
 			SnoozedUntil: sql.NullTime{Time: t, Valid: true},
 		})
 	case models.BulkOpUnsnooze:
-		return s.queries.BulkUnsnoozeNotifications(ctx, canonicalIDs)
+		return s.queries.BulkUnsnoozeNotifications(ctx, db.BulkUnsnoozeNotificationsParams{
+			AccountID: accountID,
+			GithubIds: canonicalIDs,
+		})
 	default:
 		return 0, errors.New("unknown bulk operation type: " + string(op))
 	}
@@ -126,7 +235,12 @@ func (s *Service) executeBulkUpdateByQuery(
 	queryStr string,
 	params models.BulkUpdateParams,
 ) (int64, error) {
-	dbQuery, err := query.BuildQuery(queryStr, 0, 0)
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToBuildQuery, err)
+	}
+
+	dbQuery, err := query.BuildQuery(queryStr, defs, 0, 0)
 	if err != nil {
 		return 0, errors.Join(ErrFailedToBuildQuery, err)
 	}
@@ -154,19 +268,21 @@ func (s *Service) executeBulkUpdateByQuery(
 		if err != nil {
 			return 0, errors.Join(ErrFailedToListNotifications, err)
 		}
-		if len(result.Notifications) == 0 {
-			return 0, nil
-		}
-		githubIDs := make([]string, 0, len(result.Notifications))
-		for _, n := range result.Notifications {
-			githubIDs = append(githubIDs, n.GithubID)
+		var total int64
+		for accountID, githubIDs := range groupGithubIDsByAccount(result.Notifications) {
+			count, err := s.executeBulkUpdateByIDs(
+				ctx,
+				models.BulkOpUnfilter,
+				accountID,
+				githubIDs,
+				models.BulkUpdateParams{},
+			)
+			if err != nil {
+				return total, err
+			}
+			total += count
 		}
-		return s.executeBulkUpdateByIDs(
-			ctx,
-			models.BulkOpUnfilter,
-			githubIDs,
-			models.BulkUpdateParams{},
-		)
+		return total, nil
 	case models.BulkOpSnooze:
 		t, err := time.Parse(time.RFC3339, params.SnoozedUntil)
 		if err != nil {
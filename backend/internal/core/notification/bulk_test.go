@@ -18,6 +18,7 @@ package notification
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -44,7 +45,10 @@ func TestService_BulkUpdate_MarkRead(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				// Verify deduplication and sorting happens - should receive sorted, deduplicated list
 				m.EXPECT().
-					BulkMarkNotificationsRead(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkMarkNotificationsRead(gomock.Any(), db.BulkMarkNotificationsReadParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -78,7 +82,7 @@ func TestService_BulkUpdate_MarkRead(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpMarkRead,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -112,7 +116,10 @@ func TestService_BulkUpdate_MarkNotificationsUnread(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkMarkNotificationsUnread(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkMarkNotificationsUnread(gomock.Any(), db.BulkMarkNotificationsUnreadParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -146,7 +153,7 @@ func TestService_BulkUpdate_MarkNotificationsUnread(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpMarkUnread,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -180,7 +187,10 @@ func TestService_BulkUpdate_ArchiveNotifications(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkArchiveNotifications(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkArchiveNotifications(gomock.Any(), db.BulkArchiveNotificationsParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -214,7 +224,7 @@ func TestService_BulkUpdate_ArchiveNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpArchive,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -298,7 +308,7 @@ func TestService_BulkUpdate_SnoozeNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpSnooze,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{SnoozedUntil: tt.snoozedUntil},
 			)
 
@@ -332,7 +342,10 @@ func TestService_BulkUpdate_UnarchiveNotifications(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkUnarchiveNotifications(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkUnarchiveNotifications(gomock.Any(), db.BulkUnarchiveNotificationsParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -366,7 +379,7 @@ func TestService_BulkUpdate_UnarchiveNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpUnarchive,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -400,7 +413,10 @@ func TestService_BulkUpdate_UnsnoozeNotifications(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkUnsnoozeNotifications(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkUnsnoozeNotifications(gomock.Any(), db.BulkUnsnoozeNotificationsParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -434,7 +450,7 @@ func TestService_BulkUpdate_UnsnoozeNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpUnsnooze,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -468,7 +484,10 @@ func TestService_BulkUpdate_MuteNotifications(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkMuteNotifications(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkMuteNotifications(gomock.Any(), db.BulkMuteNotificationsParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -502,7 +521,7 @@ func TestService_BulkUpdate_MuteNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpMute,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -536,7 +555,10 @@ func TestService_BulkUpdate_UnmuteNotifications(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkUnmuteNotifications(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkUnmuteNotifications(gomock.Any(), db.BulkUnmuteNotificationsParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -570,7 +592,7 @@ func TestService_BulkUpdate_UnmuteNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpUnmute,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -604,7 +626,10 @@ func TestService_BulkUpdate_StarNotifications(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkStarNotifications(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkStarNotifications(gomock.Any(), db.BulkStarNotificationsParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -638,7 +663,7 @@ func TestService_BulkUpdate_StarNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpStar,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -672,7 +697,10 @@ func TestService_BulkUpdate_UnstarNotifications(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkUnstarNotifications(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkUnstarNotifications(gomock.Any(), db.BulkUnstarNotificationsParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -706,7 +734,7 @@ func TestService_BulkUpdate_UnstarNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpUnstar,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -740,7 +768,10 @@ func TestService_BulkUpdate_UnfilterNotifications(t *testing.T) {
 			githubIDs: []string{"notif-2", "notif-1"},
 			setupMock: func(m *mocks.MockStore, _ []string) {
 				m.EXPECT().
-					BulkMarkNotificationsUnfiltered(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkMarkNotificationsUnfiltered(gomock.Any(), db.BulkMarkNotificationsUnfilteredParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -774,7 +805,7 @@ func TestService_BulkUpdate_UnfilterNotifications(t *testing.T) {
 			result, err := service.BulkUpdate(
 				ctx,
 				models.BulkOpUnfilter,
-				models.BulkOperationTarget{IDs: tt.githubIDs},
+				models.BulkOperationTarget{IDs: tt.githubIDs, AccountID: models.DefaultAccountID},
 				models.BulkUpdateParams{},
 			)
 
@@ -1496,8 +1527,20 @@ func TestService_BulkUpdate_UnfilterNotificationsByQuery(t *testing.T) {
 				// First call to ListNotificationsFromQuery to get matching notifications
 				now := time.Now().UTC()
 				notifications := []db.Notification{
-					{ID: 1, GithubID: "notif-1", Filtered: true, ImportedAt: now},
-					{ID: 2, GithubID: "notif-2", Filtered: true, ImportedAt: now},
+					{
+						ID:         1,
+						AccountID:  models.DefaultAccountID,
+						GithubID:   "notif-1",
+						Filtered:   true,
+						ImportedAt: now,
+					},
+					{
+						ID:         2,
+						AccountID:  models.DefaultAccountID,
+						GithubID:   "notif-2",
+						Filtered:   true,
+						ImportedAt: now,
+					},
 				}
 				m.EXPECT().
 					ListNotificationsFromQuery(gomock.Any(), gomock.Any()).
@@ -1507,7 +1550,10 @@ func TestService_BulkUpdate_UnfilterNotificationsByQuery(t *testing.T) {
 					}, nil)
 				// Then call to models.BulkUnfilterNotifications
 				m.EXPECT().
-					BulkMarkNotificationsUnfiltered(gomock.Any(), []string{"notif-1", "notif-2"}).
+					BulkMarkNotificationsUnfiltered(gomock.Any(), db.BulkMarkNotificationsUnfilteredParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
 					Return(int64(2), nil)
 			},
 			expectErr: false,
@@ -1590,6 +1636,178 @@ func TestService_BulkUpdate_UnfilterNotificationsByQuery(t *testing.T) {
 	}
 }
 
+func TestService_BulkUpdate_WriteBack(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   bool
+		op        models.BulkOperationType
+		target    models.BulkOperationTarget
+		setupMock func(*mocks.MockStore)
+		expected  map[models.GitHubAction][]string
+		check     func(t *testing.T, queued map[models.GitHubAction][]string)
+	}{
+		{
+			name:    "by IDs queues the deduplicated IDs",
+			enabled: true,
+			op:      models.BulkOpMarkRead,
+			target: models.BulkOperationTarget{
+				IDs:       []string{"notif-2", "notif-1", "notif-1"},
+				AccountID: models.DefaultAccountID,
+			},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					BulkMarkNotificationsRead(gomock.Any(), db.BulkMarkNotificationsReadParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1", "notif-2"},
+					}).
+					Return(int64(2), nil)
+			},
+			expected: map[models.GitHubAction][]string{
+				models.GitHubActionMarkRead: {"notif-1", "notif-2"},
+			},
+		},
+		{
+			name:    "by query lists the matching notifications first",
+			enabled: true,
+			op:      models.BulkOpArchive,
+			target:  models.BulkOperationTarget{Query: "is:unread"},
+			setupMock: func(m *mocks.MockStore) {
+				gomock.InOrder(
+					m.EXPECT().
+						ListNotificationThreadsFromQuery(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(
+							_ context.Context,
+							arg db.ListNotificationThreadsFromQueryParams,
+						) ([]db.NotificationThread, error) {
+							require.Equal(t, int64(0), arg.AfterID)
+							return []db.NotificationThread{
+								{ID: 1, AccountID: 1, GithubID: "notif-1"},
+								{ID: 2, AccountID: 1, GithubID: "notif-2"},
+							}, nil
+						}),
+					m.EXPECT().
+						BulkArchiveNotificationsByQuery(gomock.Any(), gomock.Any()).
+						Return(int64(2), nil),
+				)
+			},
+			expected: map[models.GitHubAction][]string{
+				models.GitHubActionMarkDone: {"notif-1", "notif-2"},
+			},
+		},
+		{
+			name:    "by query pages through every match",
+			enabled: true,
+			op:      models.BulkOpMarkRead,
+			target:  models.BulkOperationTarget{Query: "is:unread"},
+			setupMock: func(m *mocks.MockStore) {
+				firstPage := make([]db.NotificationThread, writeBackPageSize)
+				for i := range firstPage {
+					firstPage[i] = db.NotificationThread{
+						ID:        int64(i + 1),
+						AccountID: 1,
+						GithubID:  fmt.Sprintf("notif-%d", i+1),
+					}
+				}
+				gomock.InOrder(
+					m.EXPECT().
+						ListNotificationThreadsFromQuery(
+							gomock.Any(),
+							gomock.Cond(func(arg db.ListNotificationThreadsFromQueryParams) bool {
+								return arg.AfterID == 0
+							}),
+						).
+						Return(firstPage, nil),
+					m.EXPECT().
+						ListNotificationThreadsFromQuery(
+							gomock.Any(),
+							gomock.Cond(func(arg db.ListNotificationThreadsFromQueryParams) bool {
+								return arg.AfterID == writeBackPageSize
+							}),
+						).
+						Return([]db.NotificationThread{
+							{ID: writeBackPageSize + 1, AccountID: 1, GithubID: "last"},
+						}, nil),
+					m.EXPECT().
+						BulkMarkNotificationsReadByQuery(gomock.Any(), gomock.Any()).
+						Return(int64(writeBackPageSize+1), nil),
+				)
+			},
+			check: func(t *testing.T, queued map[models.GitHubAction][]string) {
+				ids := queued[models.GitHubActionMarkRead]
+				require.Len(t, ids, writeBackPageSize+1)
+				require.Equal(t, "notif-1", ids[0])
+				require.Equal(t, "last", ids[writeBackPageSize])
+			},
+		},
+		{
+			name:    "by query skips listing when disabled",
+			enabled: false,
+			op:      models.BulkOpMute,
+			target:  models.BulkOperationTarget{Query: "is:unread"},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					BulkMuteNotificationsByQuery(gomock.Any(), gomock.Any()).
+					Return(int64(2), nil)
+			},
+		},
+		{
+			name:    "operations without a GitHub action are not written back",
+			enabled: true,
+			op:      models.BulkOpStar,
+			target: models.BulkOperationTarget{
+				IDs:       []string{"notif-1"},
+				AccountID: models.DefaultAccountID,
+			},
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					BulkStarNotifications(gomock.Any(), db.BulkStarNotificationsParams{
+						AccountID: models.DefaultAccountID,
+						GithubIds: []string{"notif-1"},
+					}).
+					Return(int64(1), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			writeBack := &fakeWriteBack{enabled: tt.enabled}
+			service := NewService(mockQuerier).WithWriteBack(writeBack)
+
+			_, err := service.BulkUpdate(
+				context.Background(),
+				tt.op,
+				tt.target,
+				models.BulkUpdateParams{},
+			)
+			require.NoError(t, err)
+			if tt.check != nil {
+				tt.check(t, writeBack.queued)
+				return
+			}
+			require.Equal(t, tt.expected, writeBack.queued)
+		})
+	}
+}
+
+func TestGroupGithubIDsByAccount(t *testing.T) {
+	notifications := []db.Notification{
+		{AccountID: 1, GithubID: "notif-1"},
+		{AccountID: 2, GithubID: "notif-1"},
+		{AccountID: 1, GithubID: "notif-2"},
+	}
+
+	require.Equal(t, map[int64][]string{
+		1: {"notif-1", "notif-2"},
+		2: {"notif-1"},
+	}, groupGithubIDsByAccount(notifications))
+}
+
 func TestDedupeAndSort_Comprehensive(t *testing.T) {
 	tests := []struct {
 		name     string
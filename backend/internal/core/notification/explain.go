@@ -0,0 +1,101 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/query"
+)
+
+// Error definitions
+var (
+	ErrFailedToExplainQuery = errors.New("failed to explain query")
+)
+
+// explainPlanLimit is the page size used for the EXPLAIN plan, matching the default list page
+const explainPlanLimit = 50
+
+// ExplainQuery shows how a query string is parsed and turned into SQL, with the number of
+// notifications the whole query and each top-level clause match on their own.
+// includePlan adds the Postgres EXPLAIN output for the list query.
+func (s *Service) ExplainQuery(
+	ctx context.Context,
+	queryStr string,
+	includePlan bool,
+) (models.QueryExplanation, error) {
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return models.QueryExplanation{}, errors.Join(ErrFailedToExplainQuery, err)
+	}
+
+	explanation, err := query.Explain(queryStr, defs)
+	if err != nil {
+		return models.QueryExplanation{}, errors.Join(ErrInvalidQuery, err)
+	}
+
+	result := models.QueryExplanation{
+		Query:    queryStr,
+		AST:      explanation.AST,
+		Defaults: explanation.Defaults,
+		Joins:    nonNil(explanation.Query.Joins),
+		Where:    nonNil(explanation.Query.Where),
+		Args:     nonNil(explanation.Query.Args),
+		OrderBy:  nonNil(explanation.Query.OrderBy),
+		Clauses:  make([]models.QueryExplanationClause, 0, len(explanation.Clauses)),
+	}
+
+	result.Total, err = s.queries.CountNotificationsFromQuery(ctx, explanation.Query)
+	if err != nil {
+		return models.QueryExplanation{}, errors.Join(ErrFailedToExplainQuery, err)
+	}
+
+	for _, clause := range explanation.Clauses {
+		count, err := s.queries.CountNotificationsFromQuery(ctx, clause.Query)
+		if err != nil {
+			return models.QueryExplanation{}, errors.Join(ErrFailedToExplainQuery, err)
+		}
+		result.Clauses = append(result.Clauses, models.QueryExplanationClause{
+			Source: clause.Source,
+			Expr:   clause.Expr,
+			Where:  nonNil(clause.Query.Where),
+			Args:   nonNil(clause.Query.Args),
+			Count:  count,
+		})
+	}
+
+	if includePlan {
+		planQuery := explanation.Query
+		planQuery.Limit = explainPlanLimit
+		planQuery.IncludeSubject = true
+		result.Plan, err = s.queries.ExplainNotificationsFromQuery(ctx, planQuery)
+		if err != nil {
+			return models.QueryExplanation{}, errors.Join(ErrFailedToExplainQuery, err)
+		}
+	}
+
+	return result, nil
+}
+
+// nonNil returns an empty slice instead of nil so it encodes as [] rather than null
+func nonNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
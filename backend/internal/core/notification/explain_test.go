@@ -0,0 +1,147 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/query"
+)
+
+func TestService_ExplainQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		includePlan bool
+		setupMock   func(*mocks.MockStore)
+		expectErr   bool
+		checkErr    func(*testing.T, error)
+		checkResult func(*testing.T, models.QueryExplanation)
+	}{
+		{
+			name:  "counts the whole query and each clause",
+			query: "repo:cli is:unread",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					CountNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					Return(int64(3), nil)
+				// repo:cli, is:unread and the muted default
+				m.EXPECT().
+					CountNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					Return(int64(10), nil)
+				m.EXPECT().
+					CountNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					Return(int64(5), nil)
+				m.EXPECT().
+					CountNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					Return(int64(40), nil)
+			},
+			checkResult: func(t *testing.T, result models.QueryExplanation) {
+				require.Equal(t, "repo:cli is:unread", result.Query)
+				require.Equal(t, query.DefaultsMutedOnly, result.Defaults)
+				require.NotNil(t, result.AST)
+				require.Equal(t, int64(3), result.Total)
+				require.Len(t, result.Clauses, 3)
+				require.Equal(t, query.ClauseSourceQuery, result.Clauses[0].Source)
+				require.Equal(t, "repo:cli", result.Clauses[0].Expr)
+				require.Equal(t, int64(10), result.Clauses[0].Count)
+				require.Equal(t, "is:unread", result.Clauses[1].Expr)
+				require.Equal(t, int64(5), result.Clauses[1].Count)
+				require.Equal(t, query.ClauseSourceDefault, result.Clauses[2].Source)
+				require.Equal(t, int64(40), result.Clauses[2].Count)
+				require.Nil(t, result.Plan)
+			},
+		},
+		{
+			name:        "includes the plan when requested",
+			query:       "in:anywhere",
+			includePlan: true,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					CountNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					Return(int64(7), nil).
+					Times(2)
+				m.EXPECT().
+					ExplainNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, q db.NotificationQuery) ([]string, error) {
+						require.Equal(t, int32(explainPlanLimit), q.Limit)
+						return []string{"Limit  (cost=0.00..1.00 rows=1 width=8)"}, nil
+					})
+			},
+			checkResult: func(t *testing.T, result models.QueryExplanation) {
+				require.Equal(t, query.DefaultsNone, result.Defaults)
+				require.Len(t, result.Clauses, 1)
+				require.Equal(t, []string{"Limit  (cost=0.00..1.00 rows=1 width=8)"}, result.Plan)
+			},
+		},
+		{
+			name:      "invalid query returns ErrInvalidQuery",
+			query:     "bogus:value",
+			setupMock: func(*mocks.MockStore) {},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidQuery)
+				require.NotEmpty(t, query.QueryErrors(err))
+			},
+		},
+		{
+			name:  "count error returns ErrFailedToExplainQuery",
+			query: "is:unread",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					CountNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrFailedToExplainQuery)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockStore)
+
+			service := NewService(mockStore)
+			result, err := service.ExplainQuery(context.Background(), tt.query, tt.includePlan)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				if tt.checkErr != nil {
+					tt.checkErr(t, err)
+				}
+			} else {
+				require.NoError(t, err)
+				if tt.checkResult != nil {
+					tt.checkResult(t, result)
+				}
+			}
+		})
+	}
+}
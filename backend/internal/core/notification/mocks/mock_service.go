@@ -58,34 +58,49 @@ func (mr *MockNotificationReaderMockRecorder) BuildResponse(ctx, notification, r
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildResponse", reflect.TypeOf((*MockNotificationReader)(nil).BuildResponse), ctx, notification, repoMap, evaluator)
 }
 
+// ExplainQuery mocks base method.
+func (m *MockNotificationReader) ExplainQuery(ctx context.Context, queryStr string, includePlan bool) (models.QueryExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainQuery", ctx, queryStr, includePlan)
+	ret0, _ := ret[0].(models.QueryExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainQuery indicates an expected call of ExplainQuery.
+func (mr *MockNotificationReaderMockRecorder) ExplainQuery(ctx, queryStr, includePlan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainQuery", reflect.TypeOf((*MockNotificationReader)(nil).ExplainQuery), ctx, queryStr, includePlan)
+}
+
 // GetByGithubID mocks base method.
-func (m *MockNotificationReader) GetByGithubID(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationReader) GetByGithubID(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByGithubID", ctx, githubID)
+	ret := m.ctrl.Call(m, "GetByGithubID", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByGithubID indicates an expected call of GetByGithubID.
-func (mr *MockNotificationReaderMockRecorder) GetByGithubID(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationReaderMockRecorder) GetByGithubID(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGithubID", reflect.TypeOf((*MockNotificationReader)(nil).GetByGithubID), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGithubID", reflect.TypeOf((*MockNotificationReader)(nil).GetByGithubID), ctx, accountID, githubID)
 }
 
 // GetNotificationWithDetails mocks base method.
-func (m *MockNotificationReader) GetNotificationWithDetails(ctx context.Context, githubID, queryStr string) (models.Notification, error) {
+func (m *MockNotificationReader) GetNotificationWithDetails(ctx context.Context, accountID int64, githubID, queryStr string) (models.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNotificationWithDetails", ctx, githubID, queryStr)
+	ret := m.ctrl.Call(m, "GetNotificationWithDetails", ctx, accountID, githubID, queryStr)
 	ret0, _ := ret[0].(models.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNotificationWithDetails indicates an expected call of GetNotificationWithDetails.
-func (mr *MockNotificationReaderMockRecorder) GetNotificationWithDetails(ctx, githubID, queryStr any) *gomock.Call {
+func (mr *MockNotificationReaderMockRecorder) GetNotificationWithDetails(ctx, accountID, githubID, queryStr any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationWithDetails", reflect.TypeOf((*MockNotificationReader)(nil).GetNotificationWithDetails), ctx, githubID, queryStr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationWithDetails", reflect.TypeOf((*MockNotificationReader)(nil).GetNotificationWithDetails), ctx, accountID, githubID, queryStr)
 }
 
 // GetTagsForNotification mocks base method.
@@ -164,18 +179,18 @@ func (mr *MockNotificationReaderMockRecorder) ListPollNotifications(ctx, opts an
 }
 
 // NewEvaluator mocks base method.
-func (m *MockNotificationReader) NewEvaluator(queryStr string) (*eval.Evaluator, error) {
+func (m *MockNotificationReader) NewEvaluator(ctx context.Context, queryStr string) (*eval.Evaluator, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewEvaluator", queryStr)
+	ret := m.ctrl.Call(m, "NewEvaluator", ctx, queryStr)
 	ret0, _ := ret[0].(*eval.Evaluator)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // NewEvaluator indicates an expected call of NewEvaluator.
-func (mr *MockNotificationReaderMockRecorder) NewEvaluator(queryStr any) *gomock.Call {
+func (mr *MockNotificationReaderMockRecorder) NewEvaluator(ctx, queryStr any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewEvaluator", reflect.TypeOf((*MockNotificationReader)(nil).NewEvaluator), queryStr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewEvaluator", reflect.TypeOf((*MockNotificationReader)(nil).NewEvaluator), ctx, queryStr)
 }
 
 // MockNotificationWriter is a mock of NotificationWriter interface.
@@ -203,168 +218,182 @@ func (m *MockNotificationWriter) EXPECT() *MockNotificationWriterMockRecorder {
 }
 
 // ArchiveNotification mocks base method.
-func (m *MockNotificationWriter) ArchiveNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) ArchiveNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ArchiveNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "ArchiveNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ArchiveNotification indicates an expected call of ArchiveNotification.
-func (mr *MockNotificationWriterMockRecorder) ArchiveNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) ArchiveNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveNotification", reflect.TypeOf((*MockNotificationWriter)(nil).ArchiveNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveNotification", reflect.TypeOf((*MockNotificationWriter)(nil).ArchiveNotification), ctx, accountID, githubID)
 }
 
 // MarkNotificationRead mocks base method.
-func (m *MockNotificationWriter) MarkNotificationRead(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) MarkNotificationRead(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, githubID)
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkNotificationRead indicates an expected call of MarkNotificationRead.
-func (mr *MockNotificationWriterMockRecorder) MarkNotificationRead(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) MarkNotificationRead(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockNotificationWriter)(nil).MarkNotificationRead), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockNotificationWriter)(nil).MarkNotificationRead), ctx, accountID, githubID)
 }
 
 // MarkNotificationUnread mocks base method.
-func (m *MockNotificationWriter) MarkNotificationUnread(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) MarkNotificationUnread(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationUnread", ctx, githubID)
+	ret := m.ctrl.Call(m, "MarkNotificationUnread", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkNotificationUnread indicates an expected call of MarkNotificationUnread.
-func (mr *MockNotificationWriterMockRecorder) MarkNotificationUnread(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) MarkNotificationUnread(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnread", reflect.TypeOf((*MockNotificationWriter)(nil).MarkNotificationUnread), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnread", reflect.TypeOf((*MockNotificationWriter)(nil).MarkNotificationUnread), ctx, accountID, githubID)
+}
+
+// MarkNotificationViewed mocks base method.
+func (m *MockNotificationWriter) MarkNotificationViewed(ctx context.Context, accountID int64, githubID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationViewed", ctx, accountID, githubID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationViewed indicates an expected call of MarkNotificationViewed.
+func (mr *MockNotificationWriterMockRecorder) MarkNotificationViewed(ctx, accountID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationViewed", reflect.TypeOf((*MockNotificationWriter)(nil).MarkNotificationViewed), ctx, accountID, githubID)
 }
 
 // MuteNotification mocks base method.
-func (m *MockNotificationWriter) MuteNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) MuteNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MuteNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "MuteNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MuteNotification indicates an expected call of MuteNotification.
-func (mr *MockNotificationWriterMockRecorder) MuteNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) MuteNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteNotification", reflect.TypeOf((*MockNotificationWriter)(nil).MuteNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteNotification", reflect.TypeOf((*MockNotificationWriter)(nil).MuteNotification), ctx, accountID, githubID)
 }
 
 // SnoozeNotification mocks base method.
-func (m *MockNotificationWriter) SnoozeNotification(ctx context.Context, githubID, snoozedUntil string) (db.Notification, error) {
+func (m *MockNotificationWriter) SnoozeNotification(ctx context.Context, accountID int64, githubID, snoozedUntil string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SnoozeNotification", ctx, githubID, snoozedUntil)
+	ret := m.ctrl.Call(m, "SnoozeNotification", ctx, accountID, githubID, snoozedUntil)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SnoozeNotification indicates an expected call of SnoozeNotification.
-func (mr *MockNotificationWriterMockRecorder) SnoozeNotification(ctx, githubID, snoozedUntil any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) SnoozeNotification(ctx, accountID, githubID, snoozedUntil any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockNotificationWriter)(nil).SnoozeNotification), ctx, githubID, snoozedUntil)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockNotificationWriter)(nil).SnoozeNotification), ctx, accountID, githubID, snoozedUntil)
 }
 
 // StarNotification mocks base method.
-func (m *MockNotificationWriter) StarNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) StarNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StarNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "StarNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StarNotification indicates an expected call of StarNotification.
-func (mr *MockNotificationWriterMockRecorder) StarNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) StarNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StarNotification", reflect.TypeOf((*MockNotificationWriter)(nil).StarNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StarNotification", reflect.TypeOf((*MockNotificationWriter)(nil).StarNotification), ctx, accountID, githubID)
 }
 
 // UnarchiveNotification mocks base method.
-func (m *MockNotificationWriter) UnarchiveNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) UnarchiveNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnarchiveNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnarchiveNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnarchiveNotification indicates an expected call of UnarchiveNotification.
-func (mr *MockNotificationWriterMockRecorder) UnarchiveNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) UnarchiveNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnarchiveNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnarchiveNotification), ctx, accountID, githubID)
 }
 
 // UnfilterNotification mocks base method.
-func (m *MockNotificationWriter) UnfilterNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) UnfilterNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnfilterNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnfilterNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnfilterNotification indicates an expected call of UnfilterNotification.
-func (mr *MockNotificationWriterMockRecorder) UnfilterNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) UnfilterNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfilterNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnfilterNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfilterNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnfilterNotification), ctx, accountID, githubID)
 }
 
 // UnmuteNotification mocks base method.
-func (m *MockNotificationWriter) UnmuteNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) UnmuteNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnmuteNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnmuteNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnmuteNotification indicates an expected call of UnmuteNotification.
-func (mr *MockNotificationWriterMockRecorder) UnmuteNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) UnmuteNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnmuteNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnmuteNotification), ctx, accountID, githubID)
 }
 
 // UnsnoozeNotification mocks base method.
-func (m *MockNotificationWriter) UnsnoozeNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) UnsnoozeNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnsnoozeNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnsnoozeNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnsnoozeNotification indicates an expected call of UnsnoozeNotification.
-func (mr *MockNotificationWriterMockRecorder) UnsnoozeNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) UnsnoozeNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsnoozeNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnsnoozeNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsnoozeNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnsnoozeNotification), ctx, accountID, githubID)
 }
 
 // UnstarNotification mocks base method.
-func (m *MockNotificationWriter) UnstarNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationWriter) UnstarNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnstarNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnstarNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnstarNotification indicates an expected call of UnstarNotification.
-func (mr *MockNotificationWriterMockRecorder) UnstarNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationWriterMockRecorder) UnstarNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnstarNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarNotification", reflect.TypeOf((*MockNotificationWriter)(nil).UnstarNotification), ctx, accountID, githubID)
 }
 
 // UpdateNotificationSubject mocks base method.
@@ -421,48 +450,48 @@ func (m *MockNotificationTagger) EXPECT() *MockNotificationTaggerMockRecorder {
 }
 
 // AssignTag mocks base method.
-func (m *MockNotificationTagger) AssignTag(ctx context.Context, githubID string, tagID int64) (db.Notification, error) {
+func (m *MockNotificationTagger) AssignTag(ctx context.Context, accountID int64, githubID string, tagID int64) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignTag", ctx, githubID, tagID)
+	ret := m.ctrl.Call(m, "AssignTag", ctx, accountID, githubID, tagID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssignTag indicates an expected call of AssignTag.
-func (mr *MockNotificationTaggerMockRecorder) AssignTag(ctx, githubID, tagID any) *gomock.Call {
+func (mr *MockNotificationTaggerMockRecorder) AssignTag(ctx, accountID, githubID, tagID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTag", reflect.TypeOf((*MockNotificationTagger)(nil).AssignTag), ctx, githubID, tagID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTag", reflect.TypeOf((*MockNotificationTagger)(nil).AssignTag), ctx, accountID, githubID, tagID)
 }
 
 // AssignTagByName mocks base method.
-func (m *MockNotificationTagger) AssignTagByName(ctx context.Context, githubID, tagName string) (db.Notification, error) {
+func (m *MockNotificationTagger) AssignTagByName(ctx context.Context, accountID int64, githubID, tagName string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignTagByName", ctx, githubID, tagName)
+	ret := m.ctrl.Call(m, "AssignTagByName", ctx, accountID, githubID, tagName)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssignTagByName indicates an expected call of AssignTagByName.
-func (mr *MockNotificationTaggerMockRecorder) AssignTagByName(ctx, githubID, tagName any) *gomock.Call {
+func (mr *MockNotificationTaggerMockRecorder) AssignTagByName(ctx, accountID, githubID, tagName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTagByName", reflect.TypeOf((*MockNotificationTagger)(nil).AssignTagByName), ctx, githubID, tagName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTagByName", reflect.TypeOf((*MockNotificationTagger)(nil).AssignTagByName), ctx, accountID, githubID, tagName)
 }
 
 // RemoveTag mocks base method.
-func (m *MockNotificationTagger) RemoveTag(ctx context.Context, githubID string, tagID int64) (db.Notification, error) {
+func (m *MockNotificationTagger) RemoveTag(ctx context.Context, accountID int64, githubID string, tagID int64) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveTag", ctx, githubID, tagID)
+	ret := m.ctrl.Call(m, "RemoveTag", ctx, accountID, githubID, tagID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RemoveTag indicates an expected call of RemoveTag.
-func (mr *MockNotificationTaggerMockRecorder) RemoveTag(ctx, githubID, tagID any) *gomock.Call {
+func (mr *MockNotificationTaggerMockRecorder) RemoveTag(ctx, accountID, githubID, tagID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockNotificationTagger)(nil).RemoveTag), ctx, githubID, tagID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockNotificationTagger)(nil).RemoveTag), ctx, accountID, githubID, tagID)
 }
 
 // MockBulkOperations is a mock of BulkOperations interface.
@@ -559,48 +588,48 @@ func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder
 }
 
 // ArchiveNotification mocks base method.
-func (m *MockNotificationService) ArchiveNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) ArchiveNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ArchiveNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "ArchiveNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ArchiveNotification indicates an expected call of ArchiveNotification.
-func (mr *MockNotificationServiceMockRecorder) ArchiveNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) ArchiveNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveNotification", reflect.TypeOf((*MockNotificationService)(nil).ArchiveNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveNotification", reflect.TypeOf((*MockNotificationService)(nil).ArchiveNotification), ctx, accountID, githubID)
 }
 
 // AssignTag mocks base method.
-func (m *MockNotificationService) AssignTag(ctx context.Context, githubID string, tagID int64) (db.Notification, error) {
+func (m *MockNotificationService) AssignTag(ctx context.Context, accountID int64, githubID string, tagID int64) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignTag", ctx, githubID, tagID)
+	ret := m.ctrl.Call(m, "AssignTag", ctx, accountID, githubID, tagID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssignTag indicates an expected call of AssignTag.
-func (mr *MockNotificationServiceMockRecorder) AssignTag(ctx, githubID, tagID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) AssignTag(ctx, accountID, githubID, tagID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTag", reflect.TypeOf((*MockNotificationService)(nil).AssignTag), ctx, githubID, tagID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTag", reflect.TypeOf((*MockNotificationService)(nil).AssignTag), ctx, accountID, githubID, tagID)
 }
 
 // AssignTagByName mocks base method.
-func (m *MockNotificationService) AssignTagByName(ctx context.Context, githubID, tagName string) (db.Notification, error) {
+func (m *MockNotificationService) AssignTagByName(ctx context.Context, accountID int64, githubID, tagName string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignTagByName", ctx, githubID, tagName)
+	ret := m.ctrl.Call(m, "AssignTagByName", ctx, accountID, githubID, tagName)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssignTagByName indicates an expected call of AssignTagByName.
-func (mr *MockNotificationServiceMockRecorder) AssignTagByName(ctx, githubID, tagName any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) AssignTagByName(ctx, accountID, githubID, tagName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTagByName", reflect.TypeOf((*MockNotificationService)(nil).AssignTagByName), ctx, githubID, tagName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTagByName", reflect.TypeOf((*MockNotificationService)(nil).AssignTagByName), ctx, accountID, githubID, tagName)
 }
 
 // BuildResponse mocks base method.
@@ -663,34 +692,49 @@ func (mr *MockNotificationServiceMockRecorder) BulkUpdate(ctx, op, target, param
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdate", reflect.TypeOf((*MockNotificationService)(nil).BulkUpdate), ctx, op, target, params)
 }
 
+// ExplainQuery mocks base method.
+func (m *MockNotificationService) ExplainQuery(ctx context.Context, queryStr string, includePlan bool) (models.QueryExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainQuery", ctx, queryStr, includePlan)
+	ret0, _ := ret[0].(models.QueryExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainQuery indicates an expected call of ExplainQuery.
+func (mr *MockNotificationServiceMockRecorder) ExplainQuery(ctx, queryStr, includePlan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainQuery", reflect.TypeOf((*MockNotificationService)(nil).ExplainQuery), ctx, queryStr, includePlan)
+}
+
 // GetByGithubID mocks base method.
-func (m *MockNotificationService) GetByGithubID(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) GetByGithubID(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByGithubID", ctx, githubID)
+	ret := m.ctrl.Call(m, "GetByGithubID", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByGithubID indicates an expected call of GetByGithubID.
-func (mr *MockNotificationServiceMockRecorder) GetByGithubID(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) GetByGithubID(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGithubID", reflect.TypeOf((*MockNotificationService)(nil).GetByGithubID), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGithubID", reflect.TypeOf((*MockNotificationService)(nil).GetByGithubID), ctx, accountID, githubID)
 }
 
 // GetNotificationWithDetails mocks base method.
-func (m *MockNotificationService) GetNotificationWithDetails(ctx context.Context, githubID, queryStr string) (models.Notification, error) {
+func (m *MockNotificationService) GetNotificationWithDetails(ctx context.Context, accountID int64, githubID, queryStr string) (models.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNotificationWithDetails", ctx, githubID, queryStr)
+	ret := m.ctrl.Call(m, "GetNotificationWithDetails", ctx, accountID, githubID, queryStr)
 	ret0, _ := ret[0].(models.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNotificationWithDetails indicates an expected call of GetNotificationWithDetails.
-func (mr *MockNotificationServiceMockRecorder) GetNotificationWithDetails(ctx, githubID, queryStr any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) GetNotificationWithDetails(ctx, accountID, githubID, queryStr any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationWithDetails", reflect.TypeOf((*MockNotificationService)(nil).GetNotificationWithDetails), ctx, githubID, queryStr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationWithDetails", reflect.TypeOf((*MockNotificationService)(nil).GetNotificationWithDetails), ctx, accountID, githubID, queryStr)
 }
 
 // GetTagsForNotification mocks base method.
@@ -769,183 +813,197 @@ func (mr *MockNotificationServiceMockRecorder) ListPollNotifications(ctx, opts a
 }
 
 // MarkNotificationRead mocks base method.
-func (m *MockNotificationService) MarkNotificationRead(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) MarkNotificationRead(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, githubID)
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkNotificationRead indicates an expected call of MarkNotificationRead.
-func (mr *MockNotificationServiceMockRecorder) MarkNotificationRead(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) MarkNotificationRead(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockNotificationService)(nil).MarkNotificationRead), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockNotificationService)(nil).MarkNotificationRead), ctx, accountID, githubID)
 }
 
 // MarkNotificationUnread mocks base method.
-func (m *MockNotificationService) MarkNotificationUnread(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) MarkNotificationUnread(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationUnread", ctx, githubID)
+	ret := m.ctrl.Call(m, "MarkNotificationUnread", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkNotificationUnread indicates an expected call of MarkNotificationUnread.
-func (mr *MockNotificationServiceMockRecorder) MarkNotificationUnread(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) MarkNotificationUnread(ctx, accountID, githubID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnread", reflect.TypeOf((*MockNotificationService)(nil).MarkNotificationUnread), ctx, accountID, githubID)
+}
+
+// MarkNotificationViewed mocks base method.
+func (m *MockNotificationService) MarkNotificationViewed(ctx context.Context, accountID int64, githubID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationViewed", ctx, accountID, githubID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationViewed indicates an expected call of MarkNotificationViewed.
+func (mr *MockNotificationServiceMockRecorder) MarkNotificationViewed(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnread", reflect.TypeOf((*MockNotificationService)(nil).MarkNotificationUnread), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationViewed", reflect.TypeOf((*MockNotificationService)(nil).MarkNotificationViewed), ctx, accountID, githubID)
 }
 
 // MuteNotification mocks base method.
-func (m *MockNotificationService) MuteNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) MuteNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MuteNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "MuteNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MuteNotification indicates an expected call of MuteNotification.
-func (mr *MockNotificationServiceMockRecorder) MuteNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) MuteNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteNotification", reflect.TypeOf((*MockNotificationService)(nil).MuteNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteNotification", reflect.TypeOf((*MockNotificationService)(nil).MuteNotification), ctx, accountID, githubID)
 }
 
 // NewEvaluator mocks base method.
-func (m *MockNotificationService) NewEvaluator(queryStr string) (*eval.Evaluator, error) {
+func (m *MockNotificationService) NewEvaluator(ctx context.Context, queryStr string) (*eval.Evaluator, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewEvaluator", queryStr)
+	ret := m.ctrl.Call(m, "NewEvaluator", ctx, queryStr)
 	ret0, _ := ret[0].(*eval.Evaluator)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // NewEvaluator indicates an expected call of NewEvaluator.
-func (mr *MockNotificationServiceMockRecorder) NewEvaluator(queryStr any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) NewEvaluator(ctx, queryStr any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewEvaluator", reflect.TypeOf((*MockNotificationService)(nil).NewEvaluator), queryStr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewEvaluator", reflect.TypeOf((*MockNotificationService)(nil).NewEvaluator), ctx, queryStr)
 }
 
 // RemoveTag mocks base method.
-func (m *MockNotificationService) RemoveTag(ctx context.Context, githubID string, tagID int64) (db.Notification, error) {
+func (m *MockNotificationService) RemoveTag(ctx context.Context, accountID int64, githubID string, tagID int64) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveTag", ctx, githubID, tagID)
+	ret := m.ctrl.Call(m, "RemoveTag", ctx, accountID, githubID, tagID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RemoveTag indicates an expected call of RemoveTag.
-func (mr *MockNotificationServiceMockRecorder) RemoveTag(ctx, githubID, tagID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) RemoveTag(ctx, accountID, githubID, tagID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockNotificationService)(nil).RemoveTag), ctx, githubID, tagID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockNotificationService)(nil).RemoveTag), ctx, accountID, githubID, tagID)
 }
 
 // SnoozeNotification mocks base method.
-func (m *MockNotificationService) SnoozeNotification(ctx context.Context, githubID, snoozedUntil string) (db.Notification, error) {
+func (m *MockNotificationService) SnoozeNotification(ctx context.Context, accountID int64, githubID, snoozedUntil string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SnoozeNotification", ctx, githubID, snoozedUntil)
+	ret := m.ctrl.Call(m, "SnoozeNotification", ctx, accountID, githubID, snoozedUntil)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SnoozeNotification indicates an expected call of SnoozeNotification.
-func (mr *MockNotificationServiceMockRecorder) SnoozeNotification(ctx, githubID, snoozedUntil any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) SnoozeNotification(ctx, accountID, githubID, snoozedUntil any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockNotificationService)(nil).SnoozeNotification), ctx, githubID, snoozedUntil)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeNotification", reflect.TypeOf((*MockNotificationService)(nil).SnoozeNotification), ctx, accountID, githubID, snoozedUntil)
 }
 
 // StarNotification mocks base method.
-func (m *MockNotificationService) StarNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) StarNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StarNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "StarNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StarNotification indicates an expected call of StarNotification.
-func (mr *MockNotificationServiceMockRecorder) StarNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) StarNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StarNotification", reflect.TypeOf((*MockNotificationService)(nil).StarNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StarNotification", reflect.TypeOf((*MockNotificationService)(nil).StarNotification), ctx, accountID, githubID)
 }
 
 // UnarchiveNotification mocks base method.
-func (m *MockNotificationService) UnarchiveNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) UnarchiveNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnarchiveNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnarchiveNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnarchiveNotification indicates an expected call of UnarchiveNotification.
-func (mr *MockNotificationServiceMockRecorder) UnarchiveNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) UnarchiveNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNotification", reflect.TypeOf((*MockNotificationService)(nil).UnarchiveNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNotification", reflect.TypeOf((*MockNotificationService)(nil).UnarchiveNotification), ctx, accountID, githubID)
 }
 
 // UnfilterNotification mocks base method.
-func (m *MockNotificationService) UnfilterNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) UnfilterNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnfilterNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnfilterNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnfilterNotification indicates an expected call of UnfilterNotification.
-func (mr *MockNotificationServiceMockRecorder) UnfilterNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) UnfilterNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfilterNotification", reflect.TypeOf((*MockNotificationService)(nil).UnfilterNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfilterNotification", reflect.TypeOf((*MockNotificationService)(nil).UnfilterNotification), ctx, accountID, githubID)
 }
 
 // UnmuteNotification mocks base method.
-func (m *MockNotificationService) UnmuteNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) UnmuteNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnmuteNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnmuteNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnmuteNotification indicates an expected call of UnmuteNotification.
-func (mr *MockNotificationServiceMockRecorder) UnmuteNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) UnmuteNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteNotification", reflect.TypeOf((*MockNotificationService)(nil).UnmuteNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteNotification", reflect.TypeOf((*MockNotificationService)(nil).UnmuteNotification), ctx, accountID, githubID)
 }
 
 // UnsnoozeNotification mocks base method.
-func (m *MockNotificationService) UnsnoozeNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) UnsnoozeNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnsnoozeNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnsnoozeNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnsnoozeNotification indicates an expected call of UnsnoozeNotification.
-func (mr *MockNotificationServiceMockRecorder) UnsnoozeNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) UnsnoozeNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsnoozeNotification", reflect.TypeOf((*MockNotificationService)(nil).UnsnoozeNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsnoozeNotification", reflect.TypeOf((*MockNotificationService)(nil).UnsnoozeNotification), ctx, accountID, githubID)
 }
 
 // UnstarNotification mocks base method.
-func (m *MockNotificationService) UnstarNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockNotificationService) UnstarNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnstarNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnstarNotification", ctx, accountID, githubID)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnstarNotification indicates an expected call of UnstarNotification.
-func (mr *MockNotificationServiceMockRecorder) UnstarNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) UnstarNotification(ctx, accountID, githubID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarNotification", reflect.TypeOf((*MockNotificationService)(nil).UnstarNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarNotification", reflect.TypeOf((*MockNotificationService)(nil).UnstarNotification), ctx, accountID, githubID)
 }
 
 // UpdateNotificationSubject mocks base method.
@@ -976,3 +1034,53 @@ func (mr *MockNotificationServiceMockRecorder) UpsertNotification(ctx, params an
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotification", reflect.TypeOf((*MockNotificationService)(nil).UpsertNotification), ctx, params)
 }
+
+// MockWriteBack is a mock of WriteBack interface.
+type MockWriteBack struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriteBackMockRecorder
+	isgomock struct{}
+}
+
+// MockWriteBackMockRecorder is the mock recorder for MockWriteBack.
+type MockWriteBackMockRecorder struct {
+	mock *MockWriteBack
+}
+
+// NewMockWriteBack creates a new mock instance.
+func NewMockWriteBack(ctrl *gomock.Controller) *MockWriteBack {
+	mock := &MockWriteBack{ctrl: ctrl}
+	mock.recorder = &MockWriteBackMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriteBack) EXPECT() *MockWriteBackMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockWriteBack) Enabled(ctx context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled", ctx)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockWriteBackMockRecorder) Enabled(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockWriteBack)(nil).Enabled), ctx)
+}
+
+// Enqueue mocks base method.
+func (m *MockWriteBack) Enqueue(ctx context.Context, action models.GitHubAction, accountID int64, githubIDs []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Enqueue", ctx, action, accountID, githubIDs)
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockWriteBackMockRecorder) Enqueue(ctx, action, accountID, githubIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockWriteBack)(nil).Enqueue), ctx, action, accountID, githubIDs)
+}
@@ -40,9 +40,16 @@ var (
 	ErrFailedToGetNotification           = errors.New("failed to get notification")
 )
 
-// GetByGithubID fetches a notification by its GitHub identifier.
-func (s *Service) GetByGithubID(ctx context.Context, githubID string) (db.Notification, error) {
-	notification, err := s.queries.GetNotificationByGithubID(ctx, githubID)
+// GetByGithubID fetches an account's notification by its GitHub identifier.
+func (s *Service) GetByGithubID(
+	ctx context.Context,
+	accountID int64,
+	githubID string,
+) (db.Notification, error) {
+	notification, err := s.queries.GetNotificationByGithubID(ctx, db.GetNotificationByGithubIDParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 	if err != nil {
 		return db.Notification{}, errors.Join(ErrFailedToGetNotification, err)
 	}
@@ -56,8 +63,21 @@ func (s *Service) ListNotifications(
 ) (models.ListDetailsResult, error) {
 	limit, offset, page, pageSize := normalizedPagination(opts)
 
+	defs, err := query.LoadDefinitions(ctx, s.queries, opts.Query)
+	if err != nil {
+		return models.ListDetailsResult{}, errors.Join(ErrFailedToBuildQuery, err)
+	}
+
+	// Create evaluator once for all notifications (optimization)
+	// Always create evaluator, even for empty queries, so action hints work correctly
+	evaluator := s.hintEvaluator(ctx, opts.Query, defs)
+
+	// Free-text search matches the subject body, so the evaluator needs subject_raw for
+	// hints. It is still dropped from the response below unless requested.
+	includeSubject := opts.IncludeSubject || (evaluator != nil && evaluator.NeedsSubject())
+
 	// Use unified BuildQuery which applies business rules based on query content
-	dbQuery, err := query.BuildQueryWithOptions(opts.Query, limit, offset, opts.IncludeSubject)
+	dbQuery, err := query.BuildQueryWithOptions(opts.Query, defs, limit, offset, includeSubject)
 
 	if err != nil {
 		// Wrap query errors in a high-level error type
@@ -76,14 +96,6 @@ func (s *Service) ListNotifications(
 		return models.ListDetailsResult{}, errors.Join(ErrFailedToIndexRepositories, err)
 	}
 
-	// Create evaluator once for all notifications (optimization)
-	// Always create evaluator, even for empty queries, so action hints work correctly
-	evaluator, err := query.NewEvaluator(opts.Query)
-	if err != nil {
-		// Continue with nil evaluator - hints will be conservative (empty)
-		evaluator = nil
-	}
-
 	// Build responses for each notification
 	responses := make([]models.Notification, 0, len(result.Notifications))
 	for _, notification := range result.Notifications {
@@ -118,9 +130,15 @@ func (s *Service) ListPollNotifications(
 ) (models.ListPollResult, error) {
 	limit, offset, page, pageSize := normalizedPagination(opts)
 
+	defs, err := query.LoadDefinitions(ctx, s.queries, opts.Query)
+	if err != nil {
+		return models.ListPollResult{}, err
+	}
+
 	// Use unified BuildQuery which applies business rules based on query content
 	dbQuery, err := query.BuildQueryWithOptions(
 		opts.Query,
+		defs,
 		limit,
 		offset,
 		false,
@@ -179,7 +197,12 @@ func (s *Service) ListNotificationsFromQueryString(
 	queryStr string,
 	limit int32,
 ) ([]db.Notification, error) {
-	dbQuery, err := query.BuildQuery(queryStr, limit, 0)
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToBuildQuery, err)
+	}
+
+	dbQuery, err := query.BuildQuery(queryStr, defs, limit, 0)
 	if err != nil {
 		return nil, errors.Join(ErrFailedToBuildQuery, err)
 	}
@@ -208,8 +231,44 @@ func (s *Service) GetTagsForNotification(
 }
 
 // NewEvaluator creates a query evaluator for the given query string
-func (s *Service) NewEvaluator(queryStr string) (*eval.Evaluator, error) {
-	return query.NewEvaluator(queryStr)
+func (s *Service) NewEvaluator(ctx context.Context, queryStr string) (*eval.Evaluator, error) {
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewEvaluator(queryStr, defs)
+}
+
+// hintEvaluator creates the evaluator used for action hints, loading tags and accounts when
+// the query filters on them. Returns nil if the query, tags or accounts can't be loaded,
+// which makes hints conservative (empty).
+func (s *Service) hintEvaluator(
+	ctx context.Context,
+	queryStr string,
+	defs query.Definitions,
+) *eval.Evaluator {
+	evaluator, err := query.NewEvaluator(queryStr, defs)
+	if err != nil {
+		return nil
+	}
+
+	if evaluator.NeedsTags() {
+		tags, err := s.queries.ListAllTags(ctx)
+		if err != nil {
+			return nil
+		}
+		evaluator.SetTags(tags)
+	}
+
+	if evaluator.NeedsAccounts() {
+		accounts, err := s.queries.ListGitHubAccounts(ctx)
+		if err != nil {
+			return nil
+		}
+		evaluator.SetAccounts(accounts)
+	}
+
+	return evaluator
 }
 
 // UpsertNotification creates or updates a notification
@@ -237,18 +296,18 @@ func (s *Service) UpdateNotificationSubject(
 
 // GetNotificationWithDetails retrieves a single notification with all enriched data
 func (s *Service) GetNotificationWithDetails(
-	ctx context.Context, githubID, queryStr string,
+	ctx context.Context, accountID int64, githubID, queryStr string,
 ) (models.Notification, error) {
-	notification, err := s.GetByGithubID(ctx, githubID)
+	notification, err := s.GetByGithubID(ctx, accountID, githubID)
 	if err != nil {
 		return models.Notification{}, err
 	}
 
-	// Always create evaluator, even for empty queries, so action hints work correctly
-	evaluator, err := query.NewEvaluator(queryStr)
-	if err != nil {
-		// Continue with nil evaluator - hints will be conservative (empty)
-		evaluator = nil
+	// Always create evaluator, even for empty queries, so action hints work correctly.
+	// Without the query's definitions hints are conservative (empty), like for a bad query.
+	var evaluator *eval.Evaluator
+	if defs, err := query.LoadDefinitions(ctx, s.queries, queryStr); err == nil {
+		evaluator = s.hintEvaluator(ctx, queryStr, defs)
 	}
 
 	// Build response (no repoMap needed for single notification)
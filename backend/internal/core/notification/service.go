@@ -35,16 +35,17 @@ type NotificationReader interface {
 		ctx context.Context,
 		opts models.ListOptions,
 	) (models.ListPollResult, error)
-	GetByGithubID(ctx context.Context, githubID string) (db.Notification, error)
+	GetByGithubID(ctx context.Context, accountID int64, githubID string) (db.Notification, error)
 	ListNotificationsFromQueryString(
 		ctx context.Context,
 		queryStr string,
 		limit int32,
 	) ([]db.Notification, error)
 	GetTagsForNotification(ctx context.Context, notificationID int64) ([]db.Tag, error)
-	NewEvaluator(queryStr string) (*eval.Evaluator, error)
+	NewEvaluator(ctx context.Context, queryStr string) (*eval.Evaluator, error)
 	GetNotificationWithDetails(
 		ctx context.Context,
+		accountID int64,
 		githubID string,
 		queryStr string,
 	) (models.Notification, error)
@@ -55,6 +56,11 @@ type NotificationReader interface {
 		evaluator *eval.Evaluator,
 	) (models.Notification, error)
 	IndexRepositories(ctx context.Context) (map[int64]db.Repository, error)
+	ExplainQuery(
+		ctx context.Context,
+		queryStr string,
+		includePlan bool,
+	) (models.QueryExplanation, error)
 }
 
 // NotificationWriter defines individual write operations for notifications
@@ -66,30 +72,79 @@ type NotificationWriter interface {
 		params db.UpsertNotificationParams,
 	) (db.Notification, error)
 	UpdateNotificationSubject(ctx context.Context, params db.UpdateNotificationSubjectParams) error
-	MarkNotificationRead(ctx context.Context, githubID string) (db.Notification, error)
-	MarkNotificationUnread(ctx context.Context, githubID string) (db.Notification, error)
-	ArchiveNotification(ctx context.Context, githubID string) (db.Notification, error)
-	UnarchiveNotification(ctx context.Context, githubID string) (db.Notification, error)
+	MarkNotificationRead(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+	) (db.Notification, error)
+	MarkNotificationUnread(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+	) (db.Notification, error)
+	MarkNotificationViewed(ctx context.Context, accountID int64, githubID string) error
+	ArchiveNotification(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+	) (db.Notification, error)
+	UnarchiveNotification(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+	) (db.Notification, error)
 	SnoozeNotification(
 		ctx context.Context,
+		accountID int64,
 		githubID string,
 		snoozedUntil string,
 	) (db.Notification, error)
-	UnsnoozeNotification(ctx context.Context, githubID string) (db.Notification, error)
-	MuteNotification(ctx context.Context, githubID string) (db.Notification, error)
-	UnmuteNotification(ctx context.Context, githubID string) (db.Notification, error)
-	StarNotification(ctx context.Context, githubID string) (db.Notification, error)
-	UnstarNotification(ctx context.Context, githubID string) (db.Notification, error)
-	UnfilterNotification(ctx context.Context, githubID string) (db.Notification, error)
+	UnsnoozeNotification(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+	) (db.Notification, error)
+	MuteNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error)
+	UnmuteNotification(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+	) (db.Notification, error)
+	StarNotification(ctx context.Context, accountID int64, githubID string) (db.Notification, error)
+	UnstarNotification(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+	) (db.Notification, error)
+	UnfilterNotification(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+	) (db.Notification, error)
 }
 
 // NotificationTagger defines tag operations for notifications
 //
 //nolint:revive // exported type name stutters with package name
 type NotificationTagger interface {
-	AssignTag(ctx context.Context, githubID string, tagID int64) (db.Notification, error)
-	AssignTagByName(ctx context.Context, githubID string, tagName string) (db.Notification, error)
-	RemoveTag(ctx context.Context, githubID string, tagID int64) (db.Notification, error)
+	AssignTag(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+		tagID int64,
+	) (db.Notification, error)
+	AssignTagByName(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+		tagName string,
+	) (db.Notification, error)
+	RemoveTag(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+		tagID int64,
+	) (db.Notification, error)
 }
 
 // BulkOperations defines bulk operations for notifications
@@ -115,9 +170,24 @@ type NotificationService interface {
 	BulkOperations
 }
 
+// WriteBack writes local changes back to GitHub (see jobs.WriteBackQueue)
+type WriteBack interface {
+	// Enabled reports whether the user opted in to writing changes back
+	Enabled(ctx context.Context) bool
+	// Enqueue queues an action for each of an account's threads. The local change has
+	// already been made, so failures are logged rather than returned.
+	Enqueue(
+		ctx context.Context,
+		action models.GitHubAction,
+		accountID int64,
+		githubIDs []string,
+	)
+}
+
 // Service provides higher-level operations over notification records.
 type Service struct {
-	queries db.Store
+	queries   db.Store
+	writeBack WriteBack // Optional, for marking read, archiving and muting on GitHub too
 }
 
 // NewService constructs a Service backed by the provided queries.
@@ -126,3 +196,29 @@ func NewService(queries db.Store) *Service {
 		queries: queries,
 	}
 }
+
+// WithWriteBack makes marking read, archiving and muting also queue the matching change on
+// GitHub, when the user has opted in.
+func (s *Service) WithWriteBack(writeBack WriteBack) *Service {
+	s.writeBack = writeBack
+	return s
+}
+
+// writeBackEnabled reports whether changes should be written back to GitHub
+func (s *Service) writeBackEnabled(ctx context.Context) bool {
+	return s.writeBack != nil && s.writeBack.Enabled(ctx)
+}
+
+// queueWriteBack queues an action on GitHub for each of an account's threads, if
+// write-back is enabled
+func (s *Service) queueWriteBack(
+	ctx context.Context,
+	action models.GitHubAction,
+	accountID int64,
+	githubIDs []string,
+) {
+	if len(githubIDs) == 0 || !s.writeBackEnabled(ctx) {
+		return
+	}
+	s.writeBack.Enqueue(ctx, action, accountID, githubIDs)
+}
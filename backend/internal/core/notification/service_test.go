@@ -27,6 +27,7 @@ import (
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
 	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 )
 
 func TestService_GetByGithubID(t *testing.T) {
@@ -50,7 +51,10 @@ func TestService_GetByGithubID(t *testing.T) {
 					ImportedAt:   now,
 				}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), id).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(expectedNotification, nil)
 			},
 			expectErr: false,
@@ -63,7 +67,10 @@ func TestService_GetByGithubID(t *testing.T) {
 			githubID: "not-found",
 			setupMock: func(m *mocks.MockStore, id string) {
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), id).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  id,
+					}).
 					Return(db.Notification{}, sql.ErrNoRows)
 			},
 			expectErr: true,
@@ -83,7 +90,7 @@ func TestService_GetByGithubID(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.GetByGithubID(ctx, tt.githubID)
+			result, err := service.GetByGithubID(ctx, models.DefaultAccountID, tt.githubID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -407,7 +414,7 @@ func TestService_NewEvaluator(t *testing.T) {
 			mockQuerier := mocks.NewMockStore(ctrl)
 			service := NewService(mockQuerier)
 
-			evaluator, err := service.NewEvaluator(tt.queryStr)
+			evaluator, err := service.NewEvaluator(context.Background(), tt.queryStr)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -40,11 +40,15 @@ var (
 // AssignTag assigns a tag to a notification by tag ID
 func (s *Service) AssignTag(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 	tagID int64,
 ) (db.Notification, error) {
 	// Get the notification
-	notification, err := s.queries.GetNotificationByGithubID(ctx, githubID)
+	notification, err := s.queries.GetNotificationByGithubID(ctx, db.GetNotificationByGithubIDParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return db.Notification{}, errors.Join(ErrNotificationNotFound, err)
@@ -78,7 +82,10 @@ func (s *Service) AssignTag(
 	}
 
 	// Return the updated notification
-	updatedNotification, err := s.queries.GetNotificationByGithubID(ctx, githubID)
+	updatedNotification, err := s.queries.GetNotificationByGithubID(ctx, db.GetNotificationByGithubIDParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 	if err != nil {
 		return db.Notification{}, errors.Join(ErrFailedToGetUpdatedNotification, err)
 	}
@@ -89,10 +96,14 @@ func (s *Service) AssignTag(
 // AssignTagByName assigns a tag to a notification by name, creating it if it doesn't exist
 func (s *Service) AssignTagByName(
 	ctx context.Context,
+	accountID int64,
 	githubID, tagName string,
 ) (db.Notification, error) {
 	// Get the notification
-	notification, err := s.queries.GetNotificationByGithubID(ctx, githubID)
+	notification, err := s.queries.GetNotificationByGithubID(ctx, db.GetNotificationByGithubIDParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return db.Notification{}, errors.Join(ErrNotificationNotFound, err)
@@ -144,7 +155,10 @@ func (s *Service) AssignTagByName(
 	}
 
 	// Return the updated notification
-	updatedNotification, err := s.queries.GetNotificationByGithubID(ctx, githubID)
+	updatedNotification, err := s.queries.GetNotificationByGithubID(ctx, db.GetNotificationByGithubIDParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 	if err != nil {
 		return db.Notification{}, errors.Join(ErrFailedToGetUpdatedNotification, err)
 	}
@@ -155,11 +169,15 @@ func (s *Service) AssignTagByName(
 // RemoveTag removes a tag from a notification
 func (s *Service) RemoveTag(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 	tagID int64,
 ) (db.Notification, error) {
 	// Get the notification
-	notification, err := s.queries.GetNotificationByGithubID(ctx, githubID)
+	notification, err := s.queries.GetNotificationByGithubID(ctx, db.GetNotificationByGithubIDParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return db.Notification{}, errors.Join(ErrNotificationNotFound, err)
@@ -184,7 +202,10 @@ func (s *Service) RemoveTag(
 	}
 
 	// Return the updated notification
-	updatedNotification, err := s.queries.GetNotificationByGithubID(ctx, githubID)
+	updatedNotification, err := s.queries.GetNotificationByGithubID(ctx, db.GetNotificationByGithubIDParams{
+		AccountID: accountID,
+		GithubID:  githubID,
+	})
 	if err != nil {
 		return db.Notification{}, errors.Join(ErrFailedToGetUpdatedNotification, err)
 	}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
 	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 )
 
 func TestService_AssignTag(t *testing.T) {
@@ -53,7 +54,10 @@ func TestService_AssignTag(t *testing.T) {
 				}
 
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTag(gomock.Any(), tagID).
@@ -65,7 +69,10 @@ func TestService_AssignTag(t *testing.T) {
 					UpdateNotificationTagIds(gomock.Any(), int64(1)).
 					Return(nil)
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(updatedNotification, nil)
 			},
 			expectErr: false,
@@ -80,7 +87,10 @@ func TestService_AssignTag(t *testing.T) {
 			tagID:    10,
 			setupMock: func(m *mocks.MockStore, githubID string, _ int64) {
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(db.Notification{}, sql.ErrNoRows)
 			},
 			expectErr: true,
@@ -95,7 +105,10 @@ func TestService_AssignTag(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, githubID string, tagID int64) {
 				notification := db.Notification{ID: 1, GithubID: githubID}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTag(gomock.Any(), tagID).
@@ -114,7 +127,10 @@ func TestService_AssignTag(t *testing.T) {
 				notification := db.Notification{ID: 1, GithubID: githubID}
 				tag := db.Tag{ID: tagID, Name: "bug"}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTag(gomock.Any(), tagID).
@@ -137,7 +153,10 @@ func TestService_AssignTag(t *testing.T) {
 				notification := db.Notification{ID: 1, GithubID: githubID}
 				tag := db.Tag{ID: tagID, Name: "bug"}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTag(gomock.Any(), tagID).
@@ -164,7 +183,10 @@ func TestService_AssignTag(t *testing.T) {
 				tag := db.Tag{ID: tagID, Name: "bug"}
 				// First call to get notification
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTag(gomock.Any(), tagID).
@@ -178,7 +200,10 @@ func TestService_AssignTag(t *testing.T) {
 				// Second call to get updated notification fails
 				dbError := errors.New("database error")
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -198,7 +223,7 @@ func TestService_AssignTag(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.AssignTag(ctx, tt.githubID, tt.tagID)
+			result, err := service.AssignTag(ctx, models.DefaultAccountID, tt.githubID, tt.tagID)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -239,7 +264,10 @@ func TestService_AssignTagByName(t *testing.T) {
 				}
 
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTagByName(gomock.Any(), tagName).
@@ -251,7 +279,10 @@ func TestService_AssignTagByName(t *testing.T) {
 					UpdateNotificationTagIds(gomock.Any(), int64(1)).
 					Return(nil)
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(updatedNotification, nil)
 			},
 			expectErr: false,
@@ -274,7 +305,10 @@ func TestService_AssignTagByName(t *testing.T) {
 				}
 
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTagByName(gomock.Any(), tagName).
@@ -289,7 +323,10 @@ func TestService_AssignTagByName(t *testing.T) {
 					UpdateNotificationTagIds(gomock.Any(), int64(1)).
 					Return(nil)
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(updatedNotification, nil)
 			},
 			expectErr: false,
@@ -305,7 +342,10 @@ func TestService_AssignTagByName(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, githubID string, tagName string) {
 				notification := db.Notification{ID: 1, GithubID: githubID}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTagByName(gomock.Any(), tagName).
@@ -323,7 +363,10 @@ func TestService_AssignTagByName(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, githubID string, tagName string) {
 				notification := db.Notification{ID: 1, GithubID: githubID}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				dbError := errors.New("database error")
 				m.EXPECT().
@@ -342,7 +385,10 @@ func TestService_AssignTagByName(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, githubID string, tagName string) {
 				notification := db.Notification{ID: 1, GithubID: githubID}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTagByName(gomock.Any(), tagName).
@@ -365,7 +411,10 @@ func TestService_AssignTagByName(t *testing.T) {
 				notification := db.Notification{ID: 1, GithubID: githubID}
 				tag := db.Tag{ID: 10, Name: tagName, Slug: "bug"}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTagByName(gomock.Any(), tagName).
@@ -388,7 +437,10 @@ func TestService_AssignTagByName(t *testing.T) {
 				notification := db.Notification{ID: 1, GithubID: githubID}
 				tag := db.Tag{ID: 10, Name: tagName, Slug: "bug"}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTagByName(gomock.Any(), tagName).
@@ -415,7 +467,10 @@ func TestService_AssignTagByName(t *testing.T) {
 				tag := db.Tag{ID: 10, Name: tagName, Slug: "bug"}
 				// First call to get notification
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					GetTagByName(gomock.Any(), tagName).
@@ -429,7 +484,10 @@ func TestService_AssignTagByName(t *testing.T) {
 				// Second call to get updated notification fails
 				dbError := errors.New("database error")
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -449,7 +507,7 @@ func TestService_AssignTagByName(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.AssignTagByName(ctx, tt.githubID, tt.tagName)
+			result, err := service.AssignTagByName(ctx, models.DefaultAccountID, tt.githubID, tt.tagName)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -489,7 +547,10 @@ func TestService_RemoveTag(t *testing.T) {
 				}
 
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					RemoveTagAssignment(gomock.Any(), gomock.Any()).
@@ -498,7 +559,10 @@ func TestService_RemoveTag(t *testing.T) {
 					UpdateNotificationTagIds(gomock.Any(), int64(1)).
 					Return(nil)
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(updatedNotification, nil)
 			},
 			expectErr: false,
@@ -513,7 +577,10 @@ func TestService_RemoveTag(t *testing.T) {
 			tagID:    10,
 			setupMock: func(m *mocks.MockStore, githubID string, _ int64) {
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(db.Notification{}, sql.ErrNoRows)
 			},
 			expectErr: true,
@@ -528,7 +595,10 @@ func TestService_RemoveTag(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, githubID string, _ int64) {
 				notification := db.Notification{ID: 1, GithubID: githubID, TagIds: []int64{10, 20}}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				dbError := errors.New("database error")
 				m.EXPECT().
@@ -547,7 +617,10 @@ func TestService_RemoveTag(t *testing.T) {
 			setupMock: func(m *mocks.MockStore, githubID string, _ int64) {
 				notification := db.Notification{ID: 1, GithubID: githubID, TagIds: []int64{10, 20}}
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					RemoveTagAssignment(gomock.Any(), gomock.Any()).
@@ -570,7 +643,10 @@ func TestService_RemoveTag(t *testing.T) {
 				notification := db.Notification{ID: 1, GithubID: githubID, TagIds: []int64{10, 20}}
 				// First call to get notification
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(notification, nil)
 				m.EXPECT().
 					RemoveTagAssignment(gomock.Any(), gomock.Any()).
@@ -581,7 +657,10 @@ func TestService_RemoveTag(t *testing.T) {
 				// Second call to get updated notification fails
 				dbError := errors.New("database error")
 				m.EXPECT().
-					GetNotificationByGithubID(gomock.Any(), githubID).
+					GetNotificationByGithubID(gomock.Any(), db.GetNotificationByGithubIDParams{
+						AccountID: models.DefaultAccountID,
+						GithubID:  githubID,
+					}).
 					Return(db.Notification{}, dbError)
 			},
 			expectErr: true,
@@ -601,7 +680,7 @@ func TestService_RemoveTag(t *testing.T) {
 			service := NewService(mockQuerier)
 
 			ctx := context.Background()
-			result, err := service.RemoveTag(ctx, tt.githubID, tt.tagID)
+			result, err := service.RemoveTag(ctx, models.DefaultAccountID, tt.githubID, tt.tagID)
 
 			if tt.expectErr {
 				require.Error(t, err)
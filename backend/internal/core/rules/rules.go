@@ -94,6 +94,23 @@ func (s *Service) GetRule(ctx context.Context, ruleID int64) (models.Rule, error
 	return models.RuleFromDB(rule), nil
 }
 
+// validateQuery parses and validates a rule's query, inlining the views and macros it uses.
+// Returns the query in canonical form, which is what gets saved.
+func (s *Service) validateQuery(ctx context.Context, queryStr string) (string, error) {
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return "", err
+	}
+	if _, err := query.ParseAndValidate(queryStr, defs); err != nil {
+		return "", errors.Join(ErrInvalidQuery, err)
+	}
+	normalized, err := query.Normalize(queryStr)
+	if err != nil {
+		return "", errors.Join(ErrInvalidQuery, err)
+	}
+	return normalized, nil
+}
+
 // CreateRule creates a new rule
 func (s *Service) CreateRule(
 	ctx context.Context,
@@ -147,9 +164,11 @@ func (s *Service) CreateRule(
 			return models.Rule{}, ErrQueryCannotBeEmpty
 		}
 		// Validate the query by attempting to parse and validate it
-		if _, err := query.ParseAndValidate(queryStr); err != nil {
-			return models.Rule{}, errors.Join(ErrInvalidQuery, err)
+		normalized, err := s.validateQuery(ctx, queryStr)
+		if err != nil {
+			return models.Rule{}, err
 		}
+		queryStr = normalized
 	}
 
 	// Marshal actions to JSON
@@ -227,8 +246,9 @@ func (s *Service) UpdateRule(
 			return models.Rule{}, ErrQueryCannotBeEmpty
 		}
 		// Validate the query by attempting to parse and validate it
-		if _, err := query.ParseAndValidate(queryStr); err != nil {
-			return models.Rule{}, errors.Join(ErrInvalidQuery, err)
+		queryStr, err := s.validateQuery(ctx, queryStr)
+		if err != nil {
+			return models.Rule{}, err
 		}
 		dbParams.Query = sql.NullString{String: queryStr, Valid: true}
 		// Clear viewId when setting query (mutual exclusivity)
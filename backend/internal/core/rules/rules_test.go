@@ -332,6 +332,35 @@ func TestService_CreateRule(t *testing.T) {
 				require.True(t, rule.Actions.SkipInbox)
 			},
 		},
+		{
+			name: "query is saved in canonical form",
+			params: models.CreateRuleParams{
+				Name:    "My Rule",
+				Query:   stringPtr("repository:cli/cli AND NOT (is:read)"),
+				Actions: models.RuleActions{SkipInbox: true},
+			},
+			setupMock: func(m *mocks.MockStore, params models.CreateRuleParams) {
+				m.EXPECT().
+					ListRules(gomock.Any()).
+					Return([]db.Rule{}, nil)
+				m.EXPECT().
+					CreateRule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, arg db.CreateRuleParams) (db.Rule, error) {
+						require.Equal(t, "repo:cli/cli -is:read", arg.Query.String)
+						return db.Rule{
+							ID:      1,
+							Name:    arg.Name,
+							Query:   arg.Query,
+							Actions: arg.Actions,
+							Enabled: true,
+						}, nil
+					})
+			},
+			expectErr: false,
+			checkResult: func(t *testing.T, rule models.Rule) {
+				require.Equal(t, "repo:cli/cli -is:read", rule.Query)
+			},
+		},
 		{
 			name: "success creates rule with viewID",
 			params: models.CreateRuleParams{
@@ -57,6 +57,21 @@ func (mr *MockSyncStateServiceMockRecorder) GetSyncState(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncState", reflect.TypeOf((*MockSyncStateService)(nil).GetSyncState), ctx)
 }
 
+// ListSyncRuns mocks base method.
+func (m *MockSyncStateService) ListSyncRuns(ctx context.Context, opts models.SyncRunListOptions) (models.SyncRunPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSyncRuns", ctx, opts)
+	ret0, _ := ret[0].(models.SyncRunPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSyncRuns indicates an expected call of ListSyncRuns.
+func (mr *MockSyncStateServiceMockRecorder) ListSyncRuns(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncRuns", reflect.TypeOf((*MockSyncStateService)(nil).ListSyncRuns), ctx, opts)
+}
+
 // UpsertSyncState mocks base method.
 func (m *MockSyncStateService) UpsertSyncState(ctx context.Context, lastSuccessfulPoll, latestNotificationAt *time.Time) (models.SyncState, error) {
 	m.ctrl.T.Helper()
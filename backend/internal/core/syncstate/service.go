@@ -32,16 +32,26 @@ type SyncStateService interface { //nolint:revive // exported type name stutters
 		lastSuccessfulPoll *time.Time,
 		latestNotificationAt *time.Time,
 	) (models.SyncState, error)
+	ListSyncRuns(ctx context.Context, opts models.SyncRunListOptions) (models.SyncRunPage, error)
 }
 
 // Service provides business logic for sync state operations
 type Service struct {
-	queries db.Store
+	queries   db.Store
+	accountID int64 // The GitHub account whose sync state this is
 }
 
-// NewSyncStateService constructs a Service backed by the provided queries
+// NewSyncStateService constructs a Service backed by the provided queries, for the
+// default GitHub account
 func NewSyncStateService(queries db.Store) *Service {
 	return &Service{
-		queries: queries,
+		queries:   queries,
+		accountID: models.DefaultAccountID,
 	}
 }
+
+// WithAccount makes the service read and write the sync state of another GitHub account
+func (s *Service) WithAccount(accountID int64) *Service {
+	s.accountID = accountID
+	return s
+}
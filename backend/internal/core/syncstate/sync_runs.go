@@ -0,0 +1,159 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package syncstate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// Error definitions
+var (
+	ErrFailedToRecordSyncRun = errors.New("failed to record sync run")
+	ErrFailedToListSyncRuns  = errors.New("failed to list sync runs")
+	ErrFailedToPruneSyncRuns = errors.New("failed to prune sync runs")
+)
+
+const (
+	defaultSyncRunPageSize = 50
+	maxSyncRunPageSize     = 200
+)
+
+// StartSyncRun records the start of a sync or backfill run of the account, fetching the
+// notifications updated since since and before before (either may be nil), and returns
+// the run's ID
+func (s *Service) StartSyncRun(
+	ctx context.Context,
+	kind models.SyncRunKind,
+	since, before *time.Time,
+) (int64, error) {
+	runID, err := s.queries.CreateSyncRun(ctx, db.CreateSyncRunParams{
+		AccountID:    s.accountID,
+		Kind:         string(kind),
+		WindowSince:  models.SQLNullTime(since),
+		WindowBefore: models.SQLNullTime(before),
+	})
+	if err != nil {
+		return 0, errors.Join(ErrFailedToRecordSyncRun, err)
+	}
+	return runID, nil
+}
+
+// FinishSyncRun records what a sync run did when it finishes
+func (s *Service) FinishSyncRun(
+	ctx context.Context,
+	runID int64,
+	result models.SyncRunResult,
+) error {
+	params := db.FinishSyncRunParams{
+		ID:                 runID,
+		NotModified:        result.NotModified,
+		ThreadsFetched:     int32(result.ThreadsFetched),
+		JobsQueued:         int32(result.JobsQueued),
+		Error:              result.Error,
+		RateLimited:        result.RateLimited,
+		RateLimitRemaining: result.RateLimitRemaining,
+		RateLimitResetAt:   result.RateLimitResetAt,
+	}
+
+	if err := s.queries.FinishSyncRun(ctx, params); err != nil {
+		return errors.Join(ErrFailedToRecordSyncRun, err)
+	}
+	return nil
+}
+
+// AddSyncRunSubjectFetchFailure counts a subject that a job queued by the run failed to fetch
+func (s *Service) AddSyncRunSubjectFetchFailure(ctx context.Context, runID int64) error {
+	if err := s.queries.IncrementSyncRunSubjectFetchFailures(ctx, runID); err != nil {
+		return errors.Join(ErrFailedToRecordSyncRun, err)
+	}
+	return nil
+}
+
+// ListSyncRuns returns a page of the sync runs of one account, or of all of them, newest
+// first
+func (s *Service) ListSyncRuns(
+	ctx context.Context,
+	opts models.SyncRunListOptions,
+) (models.SyncRunPage, error) {
+	page := max(opts.Page, 1)
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultSyncRunPageSize
+	}
+	pageSize = min(pageSize, maxSyncRunPageSize)
+
+	accountID := sql.NullInt64{Int64: opts.AccountID, Valid: opts.AccountID != 0}
+	rows, err := s.queries.ListSyncRuns(ctx, db.ListSyncRunsParams{
+		AccountID: accountID,
+		RowLimit:  int32(pageSize),
+		RowOffset: int32((page - 1) * pageSize),
+	})
+	if err != nil {
+		return models.SyncRunPage{}, errors.Join(ErrFailedToListSyncRuns, err)
+	}
+	total, err := s.queries.CountSyncRuns(ctx, accountID)
+	if err != nil {
+		return models.SyncRunPage{}, errors.Join(ErrFailedToListSyncRuns, err)
+	}
+
+	runs := make([]models.SyncRun, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, syncRunFromRow(row))
+	}
+	return models.SyncRunPage{
+		Runs:     runs,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// PruneSyncRuns deletes the sync runs of every account started before startedBefore, and
+// returns how many were deleted
+func (s *Service) PruneSyncRuns(ctx context.Context, startedBefore time.Time) (int64, error) {
+	deleted, err := s.queries.DeleteSyncRunsStartedBefore(ctx, startedBefore)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToPruneSyncRuns, err)
+	}
+	return deleted, nil
+}
+
+// syncRunFromRow converts a sync_runs row
+func syncRunFromRow(row db.SyncRun) models.SyncRun {
+	return models.SyncRun{
+		ID:                   row.ID,
+		AccountID:            row.AccountID,
+		Kind:                 models.SyncRunKind(row.Kind),
+		StartedAt:            row.StartedAt,
+		FinishedAt:           row.FinishedAt,
+		WindowSince:          row.WindowSince,
+		WindowBefore:         row.WindowBefore,
+		NotModified:          row.NotModified,
+		ThreadsFetched:       int(row.ThreadsFetched),
+		JobsQueued:           int(row.JobsQueued),
+		SubjectFetchFailures: int(row.SubjectFetchFailures),
+		Error:                row.Error,
+		RateLimited:          row.RateLimited,
+		RateLimitRemaining:   row.RateLimitRemaining,
+		RateLimitResetAt:     row.RateLimitResetAt,
+	}
+}
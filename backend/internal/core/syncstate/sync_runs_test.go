@@ -0,0 +1,321 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package syncstate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+func TestService_StartSyncRun(t *testing.T) {
+	since := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		since     *time.Time
+		before    *time.Time
+		setupMock func(*mocks.MockStore)
+		expectErr bool
+	}{
+		{
+			name:  "records a sync run",
+			since: &since,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					CreateSyncRun(gomock.Any(), db.CreateSyncRunParams{
+						AccountID:   3,
+						Kind:        "sync",
+						WindowSince: sql.NullTime{Time: since, Valid: true},
+					}).
+					Return(int64(7), nil)
+			},
+		},
+		{
+			name:   "records a window",
+			since:  &since,
+			before: &before,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					CreateSyncRun(gomock.Any(), db.CreateSyncRunParams{
+						AccountID:    3,
+						Kind:         "sync",
+						WindowSince:  sql.NullTime{Time: since, Valid: true},
+						WindowBefore: sql.NullTime{Time: before, Valid: true},
+					}).
+					Return(int64(7), nil)
+			},
+		},
+		{
+			name: "database error",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					CreateSyncRun(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewSyncStateService(mockQuerier).WithAccount(3)
+
+			runID, err := service.StartSyncRun(
+				context.Background(),
+				models.SyncRunKindSync,
+				tt.since,
+				tt.before,
+			)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrFailedToRecordSyncRun))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, int64(7), runID)
+			}
+		})
+	}
+}
+
+func TestService_FinishSyncRun(t *testing.T) {
+	resetAt := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	result := models.SyncRunResult{
+		ThreadsFetched:     12,
+		JobsQueued:         10,
+		Error:              sql.NullString{String: "boom", Valid: true},
+		RateLimitRemaining: sql.NullInt32{Int32: 4000, Valid: true},
+		RateLimitResetAt:   sql.NullTime{Time: resetAt, Valid: true},
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+		expectErr bool
+	}{
+		{
+			name: "saves the result",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					FinishSyncRun(gomock.Any(), db.FinishSyncRunParams{
+						ID:                 7,
+						ThreadsFetched:     12,
+						JobsQueued:         10,
+						Error:              sql.NullString{String: "boom", Valid: true},
+						RateLimitRemaining: sql.NullInt32{Int32: 4000, Valid: true},
+						RateLimitResetAt:   sql.NullTime{Time: resetAt, Valid: true},
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name: "database error",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					FinishSyncRun(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewSyncStateService(mockQuerier)
+
+			err := service.FinishSyncRun(context.Background(), 7, result)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrFailedToRecordSyncRun))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_AddSyncRunSubjectFetchFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	gomock.InOrder(
+		mockQuerier.EXPECT().
+			IncrementSyncRunSubjectFetchFailures(gomock.Any(), int64(7)).
+			Return(nil),
+		mockQuerier.EXPECT().
+			IncrementSyncRunSubjectFetchFailures(gomock.Any(), int64(7)).
+			Return(errors.New("database error")),
+	)
+	service := NewSyncStateService(mockQuerier)
+
+	require.NoError(t, service.AddSyncRunSubjectFetchFailure(context.Background(), 7))
+	err := service.AddSyncRunSubjectFetchFailure(context.Background(), 7)
+	require.True(t, errors.Is(err, ErrFailedToRecordSyncRun))
+}
+
+func TestService_ListSyncRuns(t *testing.T) {
+	startedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	row := db.SyncRun{
+		ID:                   7,
+		AccountID:            1,
+		Kind:                 "backfill",
+		StartedAt:            startedAt,
+		FinishedAt:           sql.NullTime{Time: startedAt.Add(time.Second), Valid: true},
+		ThreadsFetched:       12,
+		JobsQueued:           10,
+		SubjectFetchFailures: 2,
+	}
+
+	tests := []struct {
+		name        string
+		opts        models.SyncRunListOptions
+		setupMock   func(*mocks.MockStore)
+		expectErr   bool
+		checkResult func(*testing.T, models.SyncRunPage)
+	}{
+		{
+			name: "defaults to the first page of every account",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListSyncRuns(gomock.Any(), db.ListSyncRunsParams{RowLimit: 50}).
+					Return([]db.SyncRun{row}, nil)
+				m.EXPECT().CountSyncRuns(gomock.Any(), sql.NullInt64{}).Return(int64(1), nil)
+			},
+			checkResult: func(t *testing.T, page models.SyncRunPage) {
+				require.Equal(t, int64(1), page.Total)
+				require.Equal(t, 1, page.Page)
+				require.Equal(t, 50, page.PageSize)
+				require.Len(t, page.Runs, 1)
+				run := page.Runs[0]
+				require.Equal(t, int64(7), run.ID)
+				require.Equal(t, models.SyncRunKindBackfill, run.Kind)
+				require.Equal(t, startedAt, run.StartedAt)
+				require.True(t, run.FinishedAt.Valid)
+				require.Equal(t, 12, run.ThreadsFetched)
+				require.Equal(t, 10, run.JobsQueued)
+				require.Equal(t, 2, run.SubjectFetchFailures)
+			},
+		},
+		{
+			name: "filters by account and caps the page size",
+			opts: models.SyncRunListOptions{AccountID: 2, Page: 3, PageSize: 500},
+			setupMock: func(m *mocks.MockStore) {
+				accountID := sql.NullInt64{Int64: 2, Valid: true}
+				m.EXPECT().
+					ListSyncRuns(gomock.Any(), db.ListSyncRunsParams{
+						AccountID: accountID,
+						RowLimit:  200,
+						RowOffset: 400,
+					}).
+					Return(nil, nil)
+				m.EXPECT().CountSyncRuns(gomock.Any(), accountID).Return(int64(0), nil)
+			},
+			checkResult: func(t *testing.T, page models.SyncRunPage) {
+				require.Empty(t, page.Runs)
+				require.Equal(t, 3, page.Page)
+				require.Equal(t, 200, page.PageSize)
+			},
+		},
+		{
+			name: "list error",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					ListSyncRuns(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "count error",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().ListSyncRuns(gomock.Any(), gomock.Any()).Return(nil, nil)
+				m.EXPECT().
+					CountSyncRuns(gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("database error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewSyncStateService(mockQuerier)
+
+			page, err := service.ListSyncRuns(context.Background(), tt.opts)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrFailedToListSyncRuns))
+			} else {
+				require.NoError(t, err)
+				tt.checkResult(t, page)
+			}
+		})
+	}
+}
+
+func TestService_PruneSyncRuns(t *testing.T) {
+	cutoff := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	gomock.InOrder(
+		mockQuerier.EXPECT().
+			DeleteSyncRunsStartedBefore(gomock.Any(), cutoff).
+			Return(int64(5), nil),
+		mockQuerier.EXPECT().
+			DeleteSyncRunsStartedBefore(gomock.Any(), cutoff).
+			Return(int64(0), errors.New("database error")),
+	)
+	service := NewSyncStateService(mockQuerier)
+
+	deleted, err := service.PruneSyncRuns(context.Background(), cutoff)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), deleted)
+
+	_, err = service.PruneSyncRuns(context.Background(), cutoff)
+	require.True(t, errors.Is(err, ErrFailedToPruneSyncRuns))
+}
@@ -33,7 +33,7 @@ var (
 
 // GetSyncState returns the current sync state
 func (s *Service) GetSyncState(ctx context.Context) (models.SyncState, error) {
-	state, err := s.queries.GetSyncState(ctx)
+	state, err := s.queries.GetSyncState(ctx, s.accountID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.SyncState{}, nil
@@ -47,6 +47,19 @@ func (s *Service) GetSyncState(ctx context.Context) (models.SyncState, error) {
 		UpdatedAt:                  state.UpdatedAt,
 		InitialSyncCompletedAt:     state.InitialSyncCompletedAt,
 		OldestNotificationSyncedAt: state.OldestNotificationSyncedAt,
+		LastNotificationEtag:       state.LastNotificationEtag,
+		LastNotificationModified:   state.LastNotificationModified,
+		RateLimitLimit:             state.RateLimitLimit,
+		RateLimitRemaining:         state.RateLimitRemaining,
+		RateLimitResetAt:           state.RateLimitResetAt,
+		RateLimitUpdatedAt:         state.RateLimitUpdatedAt,
+		ReconciledAt:               state.ReconciledAt,
+		ReconcilePolicy:            state.ReconcilePolicy,
+		ReconcileChecked:           state.ReconcileChecked,
+		ReconcileArchived:          state.ReconcileArchived,
+		ReconcileFlagged:           state.ReconcileFlagged,
+		ReconcileDeleted:           state.ReconcileDeleted,
+		ReconcileUnflagged:         state.ReconcileUnflagged,
 	}, nil
 }
 
@@ -65,9 +78,11 @@ func (s *Service) UpsertSyncStateWithInitialSync(
 	initialSyncCompletedAt, oldestNotificationSyncedAt *time.Time,
 ) (models.SyncState, error) {
 	params := db.UpsertSyncStateParams{
+		AccountID:                  s.accountID,
 		LastSuccessfulPoll:         models.SQLNullTime(lastSuccessfulPoll),
 		LatestNotificationAt:       models.SQLNullTime(latestNotificationAt),
 		LastNotificationEtag:       sql.NullString{},
+		LastNotificationModified:   sql.NullString{},
 		InitialSyncCompletedAt:     models.SQLNullTime(initialSyncCompletedAt),
 		OldestNotificationSyncedAt: models.SQLNullTime(oldestNotificationSyncedAt),
 	}
@@ -77,11 +92,84 @@ func (s *Service) UpsertSyncStateWithInitialSync(
 		return models.SyncState{}, errors.Join(ErrFailedToUpdateSyncState, err)
 	}
 
+	return syncStateFromUpsert(result), nil
+}
+
+// UpdatePollValidators saves the ETag and Last-Modified of the latest notifications poll,
+// leaving the rest of the sync state as it is. Empty validators are not saved.
+func (s *Service) UpdatePollValidators(
+	ctx context.Context,
+	etag, lastModified string,
+) (models.SyncState, error) {
+	params := db.UpsertSyncStateParams{
+		AccountID:                s.accountID,
+		LastNotificationEtag:     sql.NullString{String: etag, Valid: etag != ""},
+		LastNotificationModified: sql.NullString{String: lastModified, Valid: lastModified != ""},
+	}
+
+	result, err := s.queries.UpsertSyncState(ctx, params)
+	if err != nil {
+		return models.SyncState{}, errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+
+	return syncStateFromUpsert(result), nil
+}
+
+// UpdateRateLimit saves the latest GitHub rate limit budget, leaving the rest of the sync
+// state as it is
+func (s *Service) UpdateRateLimit(
+	ctx context.Context,
+	limit, remaining int,
+	resetAt time.Time,
+) error {
+	params := db.UpdateSyncStateRateLimitParams{
+		AccountID:          s.accountID,
+		RateLimitLimit:     sql.NullInt32{Int32: int32(limit), Valid: true},
+		RateLimitRemaining: sql.NullInt32{Int32: int32(remaining), Valid: true},
+		RateLimitResetAt:   models.SQLNullTime(&resetAt),
+	}
+
+	if err := s.queries.UpdateSyncStateRateLimit(ctx, params); err != nil {
+		return errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+
+	return nil
+}
+
+// UpdateReconciliation saves what the latest reconciliation with GitHub changed, leaving the
+// rest of the sync state as it is
+func (s *Service) UpdateReconciliation(
+	ctx context.Context,
+	reconciliation models.Reconciliation,
+	reconciledAt time.Time,
+) error {
+	params := db.UpdateSyncStateReconciliationParams{
+		AccountID:          s.accountID,
+		ReconciledAt:       models.SQLNullTime(&reconciledAt),
+		ReconcilePolicy:    sql.NullString{String: string(reconciliation.Policy), Valid: true},
+		ReconcileChecked:   sql.NullInt32{Int32: int32(reconciliation.Checked), Valid: true},
+		ReconcileArchived:  sql.NullInt32{Int32: int32(reconciliation.Archived), Valid: true},
+		ReconcileFlagged:   sql.NullInt32{Int32: int32(reconciliation.Flagged), Valid: true},
+		ReconcileDeleted:   sql.NullInt32{Int32: int32(reconciliation.Deleted), Valid: true},
+		ReconcileUnflagged: sql.NullInt32{Int32: int32(reconciliation.Unflagged), Valid: true},
+	}
+
+	if err := s.queries.UpdateSyncStateReconciliation(ctx, params); err != nil {
+		return errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+
+	return nil
+}
+
+// syncStateFromUpsert converts the row returned by UpsertSyncState
+func syncStateFromUpsert(row db.UpsertSyncStateRow) models.SyncState {
 	return models.SyncState{
-		LastSuccessfulPoll:         result.LastSuccessfulPoll,
-		LatestNotificationAt:       result.LatestNotificationAt,
-		UpdatedAt:                  result.UpdatedAt,
-		InitialSyncCompletedAt:     result.InitialSyncCompletedAt,
-		OldestNotificationSyncedAt: result.OldestNotificationSyncedAt,
-	}, nil
+		LastSuccessfulPoll:         row.LastSuccessfulPoll,
+		LatestNotificationAt:       row.LatestNotificationAt,
+		UpdatedAt:                  row.UpdatedAt,
+		InitialSyncCompletedAt:     row.InitialSyncCompletedAt,
+		OldestNotificationSyncedAt: row.OldestNotificationSyncedAt,
+		LastNotificationEtag:       row.LastNotificationEtag,
+		LastNotificationModified:   row.LastNotificationModified,
+	}
 }
@@ -49,7 +49,7 @@ func TestService_GetSyncState(t *testing.T) {
 					UpdatedAt:            now,
 				}
 				m.EXPECT().
-					GetSyncState(gomock.Any()).
+					GetSyncState(gomock.Any(), models.DefaultAccountID).
 					Return(expectedState, nil)
 			},
 			expectErr: false,
@@ -62,7 +62,7 @@ func TestService_GetSyncState(t *testing.T) {
 			name: "no rows returns empty state without error",
 			setupMock: func(m *mocks.MockStore) {
 				m.EXPECT().
-					GetSyncState(gomock.Any()).
+					GetSyncState(gomock.Any(), models.DefaultAccountID).
 					Return(db.GetSyncStateRow{}, sql.ErrNoRows)
 			},
 			expectErr: false,
@@ -76,7 +76,7 @@ func TestService_GetSyncState(t *testing.T) {
 			setupMock: func(m *mocks.MockStore) {
 				dbError := errors.New("database connection failed")
 				m.EXPECT().
-					GetSyncState(gomock.Any()).
+					GetSyncState(gomock.Any(), models.DefaultAccountID).
 					Return(db.GetSyncStateRow{}, dbError)
 			},
 			expectErr: true,
@@ -331,3 +331,245 @@ func TestService_UpsertSyncStateWithInitialSync(t *testing.T) {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestService_UpdatePollValidators(t *testing.T) {
+	tests := []struct {
+		name         string
+		etag         string
+		lastModified string
+		setupMock    func(*mocks.MockStore)
+		expectErr    bool
+		checkResult  func(*testing.T, models.SyncState)
+	}{
+		{
+			name:         "saves only the validators",
+			etag:         `W/"abc"`,
+			lastModified: "Mon, 15 Jan 2024 10:00:00 GMT",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpsertSyncState(gomock.Any(), db.UpsertSyncStateParams{
+						AccountID:            models.DefaultAccountID,
+						LastNotificationEtag: sql.NullString{String: `W/"abc"`, Valid: true},
+						LastNotificationModified: sql.NullString{
+							String: "Mon, 15 Jan 2024 10:00:00 GMT",
+							Valid:  true,
+						},
+					}).
+					DoAndReturn(func(_ context.Context, params db.UpsertSyncStateParams) (db.UpsertSyncStateRow, error) {
+						return db.UpsertSyncStateRow{
+							ID:                       1,
+							LastNotificationEtag:     params.LastNotificationEtag,
+							LastNotificationModified: params.LastNotificationModified,
+						}, nil
+					})
+			},
+			checkResult: func(t *testing.T, state models.SyncState) {
+				require.Equal(t, `W/"abc"`, state.LastNotificationEtag.String)
+				require.Equal(
+					t,
+					"Mon, 15 Jan 2024 10:00:00 GMT",
+					state.LastNotificationModified.String,
+				)
+			},
+		},
+		{
+			name: "empty validators are not saved",
+			etag: `"abc"`,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpsertSyncState(gomock.Any(), db.UpsertSyncStateParams{
+						AccountID:            models.DefaultAccountID,
+						LastNotificationEtag: sql.NullString{String: `"abc"`, Valid: true},
+					}).
+					Return(db.UpsertSyncStateRow{ID: 1}, nil)
+			},
+		},
+		{
+			name: "database error",
+			etag: `"abc"`,
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpsertSyncState(gomock.Any(), gomock.Any()).
+					Return(db.UpsertSyncStateRow{}, errors.New("database error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewSyncStateService(mockQuerier)
+
+			result, err := service.UpdatePollValidators(
+				context.Background(),
+				tt.etag,
+				tt.lastModified,
+			)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrFailedToUpdateSyncState))
+			} else {
+				require.NoError(t, err)
+				if tt.checkResult != nil {
+					tt.checkResult(t, result)
+				}
+			}
+		})
+	}
+}
+
+func TestService_UpdateRateLimit(t *testing.T) {
+	resetAt := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+		expectErr bool
+	}{
+		{
+			name: "saves the rate limit",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateSyncStateRateLimit(gomock.Any(), db.UpdateSyncStateRateLimitParams{
+						AccountID:          models.DefaultAccountID,
+						RateLimitLimit:     sql.NullInt32{Int32: 5000, Valid: true},
+						RateLimitRemaining: sql.NullInt32{Int32: 120, Valid: true},
+						RateLimitResetAt:   sql.NullTime{Time: resetAt, Valid: true},
+					}).
+					Return(nil)
+			},
+		},
+		{
+			name: "database error",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateSyncStateRateLimit(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewSyncStateService(mockQuerier)
+
+			err := service.UpdateRateLimit(context.Background(), 5000, 120, resetAt)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrFailedToUpdateSyncState))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_UpdateReconciliation(t *testing.T) {
+	reconciledAt := time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)
+	reconciliation := models.Reconciliation{
+		Policy:    models.MissingThreadFlag,
+		Checked:   40,
+		Flagged:   3,
+		Unflagged: 1,
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+		expectErr bool
+	}{
+		{
+			name: "saves the reconciliation",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateSyncStateReconciliation(
+						gomock.Any(),
+						db.UpdateSyncStateReconciliationParams{
+							AccountID:          models.DefaultAccountID,
+							ReconciledAt:       sql.NullTime{Time: reconciledAt, Valid: true},
+							ReconcilePolicy:    sql.NullString{String: "flag", Valid: true},
+							ReconcileChecked:   sql.NullInt32{Int32: 40, Valid: true},
+							ReconcileArchived:  sql.NullInt32{Int32: 0, Valid: true},
+							ReconcileFlagged:   sql.NullInt32{Int32: 3, Valid: true},
+							ReconcileDeleted:   sql.NullInt32{Int32: 0, Valid: true},
+							ReconcileUnflagged: sql.NullInt32{Int32: 1, Valid: true},
+						},
+					).
+					Return(nil)
+			},
+		},
+		{
+			name: "database error",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					UpdateSyncStateReconciliation(gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewSyncStateService(mockQuerier)
+
+			err := service.UpdateReconciliation(context.Background(), reconciliation, reconciledAt)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrFailedToUpdateSyncState))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_WithAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		GetSyncState(gomock.Any(), int64(7)).
+		Return(db.GetSyncStateRow{ID: 3}, nil)
+	mockQuerier.EXPECT().
+		UpsertSyncState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, params db.UpsertSyncStateParams) (db.UpsertSyncStateRow, error) {
+			require.Equal(t, int64(7), params.AccountID)
+			return db.UpsertSyncStateRow{ID: 3}, nil
+		})
+	mockQuerier.EXPECT().
+		UpdateSyncStateRateLimit(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, params db.UpdateSyncStateRateLimitParams) error {
+			require.Equal(t, int64(7), params.AccountID)
+			return nil
+		})
+
+	service := NewSyncStateService(mockQuerier).WithAccount(7)
+	ctx := context.Background()
+
+	_, err := service.GetSyncState(ctx)
+	require.NoError(t, err)
+	_, err = service.UpdatePollValidators(ctx, `"abc"`, "")
+	require.NoError(t, err)
+	require.NoError(t, service.UpdateRateLimit(ctx, 5000, 120, time.Now()))
+}
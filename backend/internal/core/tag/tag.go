@@ -207,7 +207,7 @@ func (s *Service) calculateTagUnreadCount(ctx context.Context, tagSlug string) (
 	queryStr := fmt.Sprintf("tags:%s in:anywhere -is:muted is:unread", tagSlug)
 
 	// Use unified BuildQuery which applies business rules based on query content
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	dbQuery, err := query.BuildQuery(queryStr, query.Definitions{}, 1, 0)
 	if err != nil {
 		return 0, errors.Join(ErrFailedToBuildQuery, err)
 	}
@@ -43,6 +43,21 @@ func (m *MockTimelineService) EXPECT() *MockTimelineServiceMockRecorder {
 	return m.recorder
 }
 
+// FetchDiscussionTimeline mocks base method.
+func (m *MockTimelineService) FetchDiscussionTimeline(ctx context.Context, client githubinterfaces.Client, subjectInfo *types.SubjectInfo, perPage, page int) (*models.TimelineResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDiscussionTimeline", ctx, client, subjectInfo, perPage, page)
+	ret0, _ := ret[0].(*models.TimelineResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDiscussionTimeline indicates an expected call of FetchDiscussionTimeline.
+func (mr *MockTimelineServiceMockRecorder) FetchDiscussionTimeline(ctx, client, subjectInfo, perPage, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDiscussionTimeline", reflect.TypeOf((*MockTimelineService)(nil).FetchDiscussionTimeline), ctx, client, subjectInfo, perPage, page)
+}
+
 // FetchFilteredTimeline mocks base method.
 func (m *MockTimelineService) FetchFilteredTimeline(ctx context.Context, client githubinterfaces.Client, subjectInfo *types.SubjectInfo, perPage, page int) (*models.TimelineResult, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +80,12 @@ type TimelineService interface {
 		subjectInfo *types.SubjectInfo,
 		perPage, page int,
 	) (*models.TimelineResult, error)
+	FetchDiscussionTimeline(
+		ctx context.Context,
+		client githubinterfaces.Client,
+		subjectInfo *types.SubjectInfo,
+		perPage, page int,
+	) (*models.TimelineResult, error)
 }
 
 // Service provides timeline business logic operations.
@@ -99,6 +105,8 @@ func SupportsTimeline(subjectType string) bool {
 		return true
 	case "issue":
 		return true
+	case "discussion":
+		return true
 	default:
 		return false
 	}
@@ -148,6 +156,70 @@ func (s *Service) FetchFilteredTimeline(
 	return result, nil
 }
 
+// IsDiscussion reports whether a notification type is a discussion, whose timeline is its
+// comments (see FetchDiscussionTimeline).
+func IsDiscussion(subjectType string) bool {
+	return strings.EqualFold(subjectType, "discussion")
+}
+
+// FetchDiscussionTimeline fetches a discussion's comments, newest first, as "commented"
+// items with their replies threaded under them. Discussions have no REST timeline, so
+// there are no other events.
+func (s *Service) FetchDiscussionTimeline(
+	ctx context.Context,
+	client githubinterfaces.Client,
+	subjectInfo *types.SubjectInfo,
+	perPage, page int,
+) (*models.TimelineResult, error) {
+	// Comments are fetched newest first, so only those up to the requested page are needed
+	discussion, err := client.FetchDiscussionComments(
+		ctx,
+		subjectInfo.Owner,
+		subjectInfo.Repo,
+		subjectInfo.Number,
+		perPage*page,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discussion comments: %w", err)
+	}
+
+	start := (page - 1) * perPage
+	end := min(start+perPage, len(discussion.Comments))
+	result := &models.TimelineResult{
+		Items:   []models.TimelineItem{},
+		Total:   discussion.TotalCount,
+		Page:    page,
+		PerPage: perPage,
+		HasMore: start+perPage < discussion.TotalCount,
+	}
+	for _, comment := range discussion.Comments[min(start, end):end] {
+		result.Items = append(result.Items, convertDiscussionComment(comment))
+	}
+	return result, nil
+}
+
+// convertDiscussionComment converts a discussion comment and its replies to a TimelineItem
+func convertDiscussionComment(comment types.DiscussionComment) models.TimelineItem {
+	createdAt, updatedAt := comment.CreatedAt, comment.UpdatedAt
+	item := models.TimelineItem{
+		Event:           "commented",
+		ID:              comment.ID,
+		Body:            comment.Body,
+		AuthorLogin:     comment.User.Login,
+		AuthorAvatarURL: comment.User.AvatarURL,
+		CreatedAt:       &createdAt,
+		UpdatedAt:       &updatedAt,
+		HTMLURL:         comment.HTMLURL,
+		IsAnswer:        comment.IsAnswer,
+		UpvoteCount:     comment.UpvoteCount,
+		Timestamp:       createdAt,
+	}
+	for _, reply := range comment.Replies {
+		item.Replies = append(item.Replies, convertDiscussionComment(reply))
+	}
+	return item
+}
+
 // fetchAndFilterTimelineEvents intelligently fetches timeline events from GitHub,
 // filtering out unwanted event types and requesting more events if needed.
 func fetchAndFilterTimelineEvents(
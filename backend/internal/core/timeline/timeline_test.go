@@ -232,6 +232,101 @@ func TestFetchFilteredTimeline(t *testing.T) {
 	}
 }
 
+func TestFetchDiscussionTimeline(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	service := NewService()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := githubmocks.NewMockClient(ctrl)
+	subjectInfo := &types.SubjectInfo{
+		Owner:  "test-owner",
+		Repo:   "test-repo",
+		Number: 42,
+	}
+
+	// The client returns comments newest first; page 2 of 2 per page needs 4 of them
+	client.EXPECT().
+		FetchDiscussionComments(ctx, "test-owner", "test-repo", 42, 4).
+		Return(types.DiscussionComments{
+			TotalCount: 5,
+			Comments: []types.DiscussionComment{
+				{ID: "c5", CreatedAt: now},
+				{ID: "c4", CreatedAt: now},
+				{
+					ID:          "c3",
+					Body:        "Use --verbose",
+					User:        types.SimpleUser{Login: "alice"},
+					CreatedAt:   now,
+					IsAnswer:    true,
+					UpvoteCount: 3,
+					Replies: []types.DiscussionComment{
+						{ID: "r1", Body: "Thanks!", CreatedAt: now},
+					},
+				},
+				{ID: "c2", CreatedAt: now},
+			},
+		}, nil)
+
+	result, err := service.FetchDiscussionTimeline(ctx, client, subjectInfo, 2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(result.Items))
+	}
+	if result.Items[0].ID != "c3" || result.Items[1].ID != "c2" {
+		t.Errorf(
+			"expected comments c3 and c2, got %s and %s",
+			result.Items[0].ID,
+			result.Items[1].ID,
+		)
+	}
+	if result.Total != 5 || !result.HasMore {
+		t.Errorf("expected total 5 with more pages, got %d (%v)", result.Total, result.HasMore)
+	}
+
+	answer := result.Items[0]
+	if answer.Event != "commented" || answer.AuthorLogin != "alice" ||
+		answer.Body != "Use --verbose" {
+		t.Errorf("unexpected comment item: %+v", answer)
+	}
+	if !answer.IsAnswer || answer.UpvoteCount != 3 {
+		t.Errorf("expected an answer with 3 upvotes, got %v, %d", answer.IsAnswer, answer.UpvoteCount)
+	}
+	if len(answer.Replies) != 1 || answer.Replies[0].Body != "Thanks!" {
+		t.Errorf("expected one reply, got %+v", answer.Replies)
+	}
+}
+
+func TestFetchDiscussionTimeline_PastTheEnd(t *testing.T) {
+	ctx := context.Background()
+	service := NewService()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := githubmocks.NewMockClient(ctrl)
+	client.EXPECT().
+		FetchDiscussionComments(ctx, "test-owner", "test-repo", 42, 30).
+		Return(types.DiscussionComments{
+			TotalCount: 1,
+			Comments:   []types.DiscussionComment{{ID: "c1"}},
+		}, nil)
+
+	subjectInfo := &types.SubjectInfo{Owner: "test-owner", Repo: "test-repo", Number: 42}
+	result, err := service.FetchDiscussionTimeline(ctx, client, subjectInfo, 10, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Items == nil || len(result.Items) != 0 || result.HasMore {
+		t.Errorf("expected an empty last page, got %+v", result)
+	}
+}
+
 func TestSupportsTimeline(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -243,8 +338,8 @@ func TestSupportsTimeline(t *testing.T) {
 		{"pull request no underscore", "pull_request", true},
 		{"issue", "Issue", true},
 		{"issue lowercase", "issue", true},
-		{"discussion", "Discussion", false},
-		{"discussion lowercase", "discussion", false},
+		{"discussion", "Discussion", true},
+		{"discussion lowercase", "discussion", true},
 		{"unsupported type", "Commit", false},
 		{"unsupported type", "Release", false},
 	}
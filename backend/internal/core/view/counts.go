@@ -50,8 +50,13 @@ func (s *Service) calculateViewUnreadCount(
 		queryStr = fmt.Sprintf("(%s) AND is:unread", queryStr)
 	}
 
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToBuildQuery, err)
+	}
+
 	// Use unified BuildQuery which applies business rules based on query content
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	dbQuery, err := query.BuildQuery(queryStr, defs, 1, 0)
 
 	if err != nil {
 		return 0, errors.Join(ErrFailedToBuildQuery, err)
@@ -70,7 +75,7 @@ func (s *Service) calculateInboxUnreadCount(ctx context.Context) (int64, error)
 	// Inbox uses explicit in:inbox query, badge count shows only unread items
 	queryStr := "in:inbox is:unread"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	dbQuery, err := query.BuildQuery(queryStr, query.Definitions{}, 1, 0)
 	if err != nil {
 		return 0, errors.Join(ErrFailedToBuildQuery, err)
 	}
@@ -88,7 +93,7 @@ func (s *Service) calculateEverythingUnreadCount(ctx context.Context) (int64, er
 	// Everything view shows all notifications, badge shows count of unread items including archived/muted/snoozed
 	queryStr := "is:unread in:anywhere"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	dbQuery, err := query.BuildQuery(queryStr, query.Definitions{}, 1, 0)
 	if err != nil {
 		return 0, errors.Join(ErrFailedToBuildQuery, err)
 	}
@@ -106,7 +111,7 @@ func (s *Service) calculateArchiveUnreadCount(ctx context.Context) (int64, error
 	// Archive view shows all archived notifications
 	queryStr := "in:archive is:unread"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	dbQuery, err := query.BuildQuery(queryStr, query.Definitions{}, 1, 0)
 	if err != nil {
 		return 0, errors.Join(ErrFailedToBuildQuery, err)
 	}
@@ -124,7 +129,7 @@ func (s *Service) calculateSnoozedUnreadCount(ctx context.Context) (int64, error
 	// Snoozed view shows all snoozed notifications
 	queryStr := "in:snoozed is:unread"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	dbQuery, err := query.BuildQuery(queryStr, query.Definitions{}, 1, 0)
 	if err != nil {
 		return 0, errors.Join(ErrFailedToBuildQuery, err)
 	}
@@ -142,7 +147,7 @@ func (s *Service) calculateStarredUnreadCount(ctx context.Context) (int64, error
 	// Starred view shows all starred notifications including archived/snoozed
 	queryStr := "is:starred is:unread in:anywhere"
 
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	dbQuery, err := query.BuildQuery(queryStr, query.Definitions{}, 1, 0)
 	if err != nil {
 		return 0, errors.Join(ErrFailedToBuildQuery, err)
 	}
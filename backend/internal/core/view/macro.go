@@ -0,0 +1,208 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/query"
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadMacros      = errors.New("failed to load macros")
+	ErrFailedToCreateMacro     = errors.New("failed to create macro")
+	ErrFailedToUpdateMacro     = errors.New("failed to update macro")
+	ErrFailedToDeleteMacro     = errors.New("failed to delete macro")
+	ErrMacroNotFound           = errors.New("macro not found")
+	ErrMacroNameAlreadyExists  = errors.New("a macro with that name already exists")
+	ErrMacroInUse              = errors.New("macro is used by other queries")
+	ErrMacroNameRequired       = errors.New("macro name is required")
+	ErrInvalidMacroName        = errors.New("invalid macro name")
+	ErrMacroNameReserved       = errors.New("macro name is reserved and cannot be used")
+	ErrMacroQueryRequired      = errors.New("macro query is required")
+	ErrMacroQueryCannotBeEmpty = errors.New("macro query cannot be empty")
+)
+
+// macroNamePattern matches valid macro names (the text after the @)
+var macroNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// reservedMacroNames can't be used as macro names: @me already means the token's user
+var reservedMacroNames = map[string]struct{}{
+	"me": {},
+}
+
+// ListMacros returns all query macros, ordered by name
+func (s *Service) ListMacros(ctx context.Context) ([]models.QueryMacro, error) {
+	macros, err := s.queries.ListQueryMacros(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToLoadMacros, err)
+	}
+
+	response := make([]models.QueryMacro, len(macros))
+	for i, macro := range macros {
+		response[i] = models.QueryMacroFromDB(macro)
+	}
+	return response, nil
+}
+
+// CreateMacro creates a query macro that other queries can use as @name
+func (s *Service) CreateMacro(
+	ctx context.Context,
+	name, queryStr string,
+) (models.QueryMacro, error) {
+	name, err := normalizeMacroName(name)
+	if err != nil {
+		return models.QueryMacro{}, err
+	}
+
+	queryStr = strings.TrimSpace(queryStr)
+	if queryStr == "" {
+		return models.QueryMacro{}, ErrMacroQueryRequired
+	}
+	if err := s.validateMacroQuery(ctx, name, queryStr); err != nil {
+		return models.QueryMacro{}, err
+	}
+	queryStr, err = normalizeQuery(queryStr)
+	if err != nil {
+		return models.QueryMacro{}, err
+	}
+
+	macro, err := s.queries.CreateQueryMacro(ctx, db.CreateQueryMacroParams{
+		Name:  name,
+		Query: queryStr,
+	})
+	if err != nil {
+		if models.IsUniqueViolation(err) {
+			return models.QueryMacro{}, errors.Join(ErrMacroNameAlreadyExists, err)
+		}
+		return models.QueryMacro{}, errors.Join(ErrFailedToCreateMacro, err)
+	}
+
+	return models.QueryMacroFromDB(macro), nil
+}
+
+// UpdateMacro updates a query macro's name and/or query.
+// A macro that other queries use can't be renamed, since they refer to it by name.
+func (s *Service) UpdateMacro(
+	ctx context.Context,
+	macroID int64,
+	name, queryStr *string,
+) (models.QueryMacro, error) {
+	current, err := s.queries.GetQueryMacro(ctx, macroID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.QueryMacro{}, errors.Join(ErrMacroNotFound, err)
+		}
+		return models.QueryMacro{}, errors.Join(ErrFailedToUpdateMacro, err)
+	}
+
+	params := db.UpdateQueryMacroParams{ID: macroID}
+	macroName := current.Name
+	if name != nil {
+		macroName, err = normalizeMacroName(*name)
+		if err != nil {
+			return models.QueryMacro{}, err
+		}
+		if macroName != current.Name {
+			if err := s.checkMacroUnused(ctx, current.Name); err != nil {
+				return models.QueryMacro{}, err
+			}
+		}
+		params.Name = sql.NullString{String: macroName, Valid: true}
+	}
+	if queryStr != nil {
+		queryTrimmed := strings.TrimSpace(*queryStr)
+		if queryTrimmed == "" {
+			return models.QueryMacro{}, ErrMacroQueryCannotBeEmpty
+		}
+		if err := s.validateMacroQuery(ctx, macroName, queryTrimmed); err != nil {
+			return models.QueryMacro{}, err
+		}
+		queryTrimmed, err := normalizeQuery(queryTrimmed)
+		if err != nil {
+			return models.QueryMacro{}, err
+		}
+		params.Query = sql.NullString{String: queryTrimmed, Valid: true}
+	}
+
+	macro, err := s.queries.UpdateQueryMacro(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.QueryMacro{}, errors.Join(ErrMacroNotFound, err)
+		}
+		if models.IsUniqueViolation(err) {
+			return models.QueryMacro{}, errors.Join(ErrMacroNameAlreadyExists, err)
+		}
+		return models.QueryMacro{}, errors.Join(ErrFailedToUpdateMacro, err)
+	}
+
+	return models.QueryMacroFromDB(macro), nil
+}
+
+// DeleteMacro deletes a query macro that no other query uses
+func (s *Service) DeleteMacro(ctx context.Context, macroID int64) error {
+	macro, err := s.queries.GetQueryMacro(ctx, macroID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Join(ErrMacroNotFound, err)
+		}
+		return errors.Join(ErrFailedToDeleteMacro, err)
+	}
+	if err := s.checkMacroUnused(ctx, macro.Name); err != nil {
+		return err
+	}
+
+	if _, err := s.queries.DeleteQueryMacro(ctx, macroID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Join(ErrMacroNotFound, err)
+		}
+		return errors.Join(ErrFailedToDeleteMacro, err)
+	}
+	return nil
+}
+
+// normalizeMacroName trims, lowercases and checks a macro name, accepting a leading @
+func normalizeMacroName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimPrefix(name, query.MacroPrefix)
+	if name == "" {
+		return "", ErrMacroNameRequired
+	}
+	if !macroNamePattern.MatchString(name) {
+		return "", fmt.Errorf(
+			"macro name '%s' must start with a letter or digit and contain only "+
+				"letters, digits, - and _: %w",
+			name,
+			ErrInvalidMacroName,
+		)
+	}
+	if _, isReserved := reservedMacroNames[name]; isReserved {
+		return "", fmt.Errorf(
+			"macro name '%s' is reserved and cannot be used: %w",
+			name,
+			ErrMacroNameReserved,
+		)
+	}
+	return name, nil
+}
@@ -0,0 +1,381 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+func TestService_ListMacros(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockQuerier := mocks.NewMockStore(ctrl)
+	mockQuerier.EXPECT().
+		ListQueryMacros(gomock.Any()).
+		Return([]db.QueryMacro{{ID: 7, Name: "bots", Query: "author:*[bot]"}}, nil)
+	service := NewService(mockQuerier)
+
+	macros, err := service.ListMacros(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, []models.QueryMacro{{ID: "7", Name: "bots", Query: "author:*[bot]"}}, macros)
+}
+
+func TestService_CreateMacro(t *testing.T) {
+	tests := []struct {
+		name        string
+		macroName   string
+		queryStr    string
+		setupMock   func(*mocks.MockStore)
+		expectErr   bool
+		checkErr    func(*testing.T, error)
+		checkResult func(*testing.T, models.QueryMacro)
+	}{
+		{
+			name:      "success normalizes the name",
+			macroName: " @Bots ",
+			queryStr:  " author:*[bot] ",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					CreateQueryMacro(gomock.Any(), db.CreateQueryMacroParams{
+						Name:  "bots",
+						Query: "author:*[bot]",
+					}).
+					Return(db.QueryMacro{ID: 1, Name: "bots", Query: "author:*[bot]"}, nil)
+			},
+			expectErr: false,
+			checkResult: func(t *testing.T, macro models.QueryMacro) {
+				require.Equal(t, "1", macro.ID)
+				require.Equal(t, "bots", macro.Name)
+			},
+		},
+		{
+			name:      "empty name returns error before DB call",
+			macroName: "@",
+			queryStr:  "is:unread",
+			setupMock: func(_ *mocks.MockStore) {},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroNameRequired)
+			},
+		},
+		{
+			name:      "name with spaces returns ErrInvalidMacroName",
+			macroName: "my bots",
+			queryStr:  "is:unread",
+			setupMock: func(_ *mocks.MockStore) {},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidMacroName)
+			},
+		},
+		{
+			name:      "@me is reserved",
+			macroName: "me",
+			queryStr:  "is:unread",
+			setupMock: func(_ *mocks.MockStore) {},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroNameReserved)
+			},
+		},
+		{
+			name:      "empty query returns error before DB call",
+			macroName: "bots",
+			queryStr:  "  ",
+			setupMock: func(_ *mocks.MockStore) {},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroQueryRequired)
+			},
+		},
+		{
+			name:      "invalid query returns ErrInvalidQuery",
+			macroName: "bots",
+			queryStr:  "is:bogus",
+			setupMock: func(_ *mocks.MockStore) {},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidQuery)
+			},
+		},
+		{
+			name:      "macro that uses itself returns ErrInvalidQuery",
+			macroName: "bots",
+			queryStr:  "author:*[bot] OR @bots",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().ListViews(gomock.Any()).Return(nil, nil)
+				m.EXPECT().ListQueryMacros(gomock.Any()).Return(nil, nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidQuery)
+				require.Contains(t, err.Error(), "@bots -> @bots")
+			},
+		},
+		{
+			name:      "duplicate name returns ErrMacroNameAlreadyExists",
+			macroName: "bots",
+			queryStr:  "author:*[bot]",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					CreateQueryMacro(gomock.Any(), gomock.Any()).
+					Return(db.QueryMacro{}, &pq.Error{Code: "23505"})
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroNameAlreadyExists)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewService(mockQuerier)
+
+			result, err := service.CreateMacro(context.Background(), tt.macroName, tt.queryStr)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				if tt.checkErr != nil {
+					tt.checkErr(t, err)
+				}
+			} else {
+				require.NoError(t, err)
+				if tt.checkResult != nil {
+					tt.checkResult(t, result)
+				}
+			}
+		})
+	}
+}
+
+func TestService_UpdateMacro(t *testing.T) {
+	current := db.QueryMacro{ID: 1, Name: "bots", Query: "author:*[bot]"}
+
+	tests := []struct {
+		name      string
+		macroName *string
+		queryStr  *string
+		setupMock func(*mocks.MockStore)
+		expectErr bool
+		checkErr  func(*testing.T, error)
+	}{
+		{
+			name:     "success updates the query",
+			queryStr: stringPtr("author:/bot/"),
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetQueryMacro(gomock.Any(), int64(1)).Return(current, nil)
+				m.EXPECT().
+					UpdateQueryMacro(gomock.Any(), db.UpdateQueryMacroParams{
+						ID:    1,
+						Query: sql.NullString{String: "author:/bot/", Valid: true},
+					}).
+					Return(db.QueryMacro{ID: 1, Name: "bots", Query: "author:/bot/"}, nil)
+			},
+			expectErr: false,
+		},
+		{
+			name:     "query is saved in canonical form",
+			queryStr: stringPtr("author:/bot/ AND NOT (is:read)"),
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetQueryMacro(gomock.Any(), int64(1)).Return(current, nil)
+				m.EXPECT().
+					UpdateQueryMacro(gomock.Any(), db.UpdateQueryMacroParams{
+						ID:    1,
+						Query: sql.NullString{String: "author:/bot/ -is:read", Valid: true},
+					}).
+					Return(db.QueryMacro{ID: 1, Name: "bots", Query: "author:/bot/ -is:read"}, nil)
+			},
+			expectErr: false,
+		},
+		{
+			name:      "renaming an unused macro succeeds",
+			macroName: stringPtr("robots"),
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetQueryMacro(gomock.Any(), int64(1)).Return(current, nil)
+				expectNoReferences(m)
+				m.EXPECT().
+					UpdateQueryMacro(gomock.Any(), db.UpdateQueryMacroParams{
+						ID:   1,
+						Name: sql.NullString{String: "robots", Valid: true},
+					}).
+					Return(db.QueryMacro{ID: 1, Name: "robots", Query: current.Query}, nil)
+			},
+			expectErr: false,
+		},
+		{
+			name:      "renaming a used macro returns ErrMacroInUse",
+			macroName: stringPtr("robots"),
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetQueryMacro(gomock.Any(), int64(1)).Return(current, nil)
+				m.EXPECT().ListViews(gomock.Any()).Return(nil, nil)
+				m.EXPECT().
+					ListQueryMacros(gomock.Any()).
+					Return([]db.QueryMacro{current, {ID: 2, Name: "noise", Query: "@bots OR is:muted"}}, nil)
+				m.EXPECT().ListRules(gomock.Any()).Return(nil, nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroInUse)
+				require.Contains(t, err.Error(), "@bots is used by @noise")
+			},
+		},
+		{
+			name:     "empty query returns ErrMacroQueryCannotBeEmpty",
+			queryStr: stringPtr(""),
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().GetQueryMacro(gomock.Any(), int64(1)).Return(current, nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroQueryCannotBeEmpty)
+			},
+		},
+		{
+			name:     "not found returns ErrMacroNotFound",
+			queryStr: stringPtr("is:unread"),
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetQueryMacro(gomock.Any(), int64(1)).
+					Return(db.QueryMacro{}, sql.ErrNoRows)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewService(mockQuerier)
+
+			_, err := service.UpdateMacro(context.Background(), 1, tt.macroName, tt.queryStr)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				if tt.checkErr != nil {
+					tt.checkErr(t, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_DeleteMacro(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.MockStore)
+		expectErr bool
+		checkErr  func(*testing.T, error)
+	}{
+		{
+			name: "success deletes an unused macro",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetQueryMacro(gomock.Any(), int64(1)).
+					Return(db.QueryMacro{ID: 1, Name: "bots"}, nil)
+				expectNoReferences(m)
+				m.EXPECT().DeleteQueryMacro(gomock.Any(), int64(1)).Return(int64(1), nil)
+			},
+			expectErr: false,
+		},
+		{
+			name: "macro used by a rule returns ErrMacroInUse without deleting",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetQueryMacro(gomock.Any(), int64(1)).
+					Return(db.QueryMacro{ID: 1, Name: "bots"}, nil)
+				m.EXPECT().ListViews(gomock.Any()).Return(nil, nil)
+				m.EXPECT().ListQueryMacros(gomock.Any()).Return(nil, nil)
+				m.EXPECT().
+					ListRules(gomock.Any()).
+					Return([]db.Rule{
+						{Name: "Mute bots", Query: sql.NullString{String: "@Bots", Valid: true}},
+					}, nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroInUse)
+				require.Contains(t, err.Error(), `rule "Mute bots"`)
+			},
+		},
+		{
+			name: "not found returns ErrMacroNotFound",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetQueryMacro(gomock.Any(), int64(1)).
+					Return(db.QueryMacro{}, sql.ErrNoRows)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrMacroNotFound)
+			},
+		},
+		{
+			name: "error wrapping reference check failure",
+			setupMock: func(m *mocks.MockStore) {
+				m.EXPECT().
+					GetQueryMacro(gomock.Any(), int64(1)).
+					Return(db.QueryMacro{ID: 1, Name: "bots"}, nil)
+				m.EXPECT().ListViews(gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrFailedToCheckReferences)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockQuerier := mocks.NewMockStore(ctrl)
+			tt.setupMock(mockQuerier)
+			service := NewService(mockQuerier)
+
+			err := service.DeleteMacro(context.Background(), 1)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				if tt.checkErr != nil {
+					tt.checkErr(t, err)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
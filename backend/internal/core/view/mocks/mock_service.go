@@ -42,6 +42,21 @@ func (m *MockViewService) EXPECT() *MockViewServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateMacro mocks base method.
+func (m *MockViewService) CreateMacro(ctx context.Context, name, queryStr string) (models.QueryMacro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMacro", ctx, name, queryStr)
+	ret0, _ := ret[0].(models.QueryMacro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMacro indicates an expected call of CreateMacro.
+func (mr *MockViewServiceMockRecorder) CreateMacro(ctx, name, queryStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMacro", reflect.TypeOf((*MockViewService)(nil).CreateMacro), ctx, name, queryStr)
+}
+
 // CreateView mocks base method.
 func (m *MockViewService) CreateView(ctx context.Context, name string, description, icon *string, isDefault *bool, queryStr string) (models.View, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +72,20 @@ func (mr *MockViewServiceMockRecorder) CreateView(ctx, name, description, icon,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockViewService)(nil).CreateView), ctx, name, description, icon, isDefault, queryStr)
 }
 
+// DeleteMacro mocks base method.
+func (m *MockViewService) DeleteMacro(ctx context.Context, macroID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMacro", ctx, macroID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMacro indicates an expected call of DeleteMacro.
+func (mr *MockViewServiceMockRecorder) DeleteMacro(ctx, macroID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMacro", reflect.TypeOf((*MockViewService)(nil).DeleteMacro), ctx, macroID)
+}
+
 // DeleteView mocks base method.
 func (m *MockViewService) DeleteView(ctx context.Context, viewID int64, force bool) (int, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +116,21 @@ func (mr *MockViewServiceMockRecorder) GetView(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockViewService)(nil).GetView), ctx, id)
 }
 
+// ListMacros mocks base method.
+func (m *MockViewService) ListMacros(ctx context.Context) ([]models.QueryMacro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMacros", ctx)
+	ret0, _ := ret[0].([]models.QueryMacro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMacros indicates an expected call of ListMacros.
+func (mr *MockViewServiceMockRecorder) ListMacros(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMacros", reflect.TypeOf((*MockViewService)(nil).ListMacros), ctx)
+}
+
 // ListViewsWithCounts mocks base method.
 func (m *MockViewService) ListViewsWithCounts(ctx context.Context) ([]models.View, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +161,21 @@ func (mr *MockViewServiceMockRecorder) ReorderViews(ctx, viewIDs any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderViews", reflect.TypeOf((*MockViewService)(nil).ReorderViews), ctx, viewIDs)
 }
 
+// UpdateMacro mocks base method.
+func (m *MockViewService) UpdateMacro(ctx context.Context, macroID int64, name, queryStr *string) (models.QueryMacro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMacro", ctx, macroID, name, queryStr)
+	ret0, _ := ret[0].(models.QueryMacro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMacro indicates an expected call of UpdateMacro.
+func (mr *MockViewServiceMockRecorder) UpdateMacro(ctx, macroID, name, queryStr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMacro", reflect.TypeOf((*MockViewService)(nil).UpdateMacro), ctx, macroID, name, queryStr)
+}
+
 // UpdateView mocks base method.
 func (m *MockViewService) UpdateView(ctx context.Context, viewID int64, name, description, icon *string, isDefault *bool, queryStr *string) (models.View, error) {
 	m.ctrl.T.Helper()
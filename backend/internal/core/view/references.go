@@ -0,0 +1,194 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package view
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/query"
+)
+
+// Error definitions
+var (
+	ErrViewInUse               = errors.New("view is used by other queries")
+	ErrFailedToCheckReferences = errors.New("failed to check for references")
+)
+
+// validateViewQuery validates the query of the view with the given slug ("" for a new view
+// without one yet), inlining the views and macros it references. The view's own entry is
+// replaced by queryStr so that a query which leads back to the view is reported as a cycle.
+func (s *Service) validateViewQuery(ctx context.Context, slug, queryStr string) error {
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return errors.Join(ErrFailedToCheckReferences, err)
+	}
+	if slug != "" && defs.Views != nil {
+		defs.Views[slug] = queryStr
+	}
+
+	if _, err := query.ParseAndValidate(queryStr, defs); err != nil {
+		return errors.Join(ErrInvalidQuery, err)
+	}
+	return nil
+}
+
+// validateMacroQuery validates the query of the macro with the given name, like validateViewQuery
+func (s *Service) validateMacroQuery(ctx context.Context, name, queryStr string) error {
+	defs, err := query.LoadDefinitions(ctx, s.queries, queryStr)
+	if err != nil {
+		return errors.Join(ErrFailedToCheckReferences, err)
+	}
+	if defs.Macros != nil {
+		defs.Macros[name] = queryStr
+	}
+
+	if _, err := query.ParseAndValidate(queryStr, defs); err != nil {
+		return errors.Join(ErrInvalidQuery, err)
+	}
+	return nil
+}
+
+// normalizeQuery returns a validated query in canonical form, which is what gets saved
+func normalizeQuery(queryStr string) (string, error) {
+	normalized, err := query.Normalize(queryStr)
+	if err != nil {
+		return "", errors.Join(ErrInvalidQuery, err)
+	}
+	return normalized, nil
+}
+
+// checkViewUpdate validates an update against the queries that reference the view.
+// References use the slug, so a view that other queries use can't be renamed.
+func (s *Service) checkViewUpdate(
+	ctx context.Context,
+	viewID int64,
+	params db.UpdateViewParams,
+) error {
+	hasReferences := params.Query.Valid && query.HasReferences(params.Query.String)
+	if !params.Slug.Valid && !hasReferences {
+		if params.Query.Valid {
+			return s.validateViewQuery(ctx, "", params.Query.String)
+		}
+		return nil
+	}
+
+	current, err := s.queries.GetView(ctx, viewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.Join(ErrViewNotFound, err)
+		}
+		return errors.Join(ErrFailedToGetView, err)
+	}
+
+	slug := current.Slug
+	if params.Slug.Valid && params.Slug.String != current.Slug {
+		if err := s.checkViewUnused(ctx, current.Slug); err != nil {
+			return err
+		}
+		slug = params.Slug.String
+	}
+
+	if params.Query.Valid {
+		return s.validateViewQuery(ctx, slug, params.Query.String)
+	}
+	return nil
+}
+
+// checkViewUnused returns ErrViewInUse if any saved query has a view:<slug> term
+func (s *Service) checkViewUnused(ctx context.Context, slug string) error {
+	users, err := s.referencedBy(ctx, func(views, _ []string) bool {
+		return slices.Contains(views, slug)
+	})
+	if err != nil {
+		return err
+	}
+	if len(users) > 0 {
+		return fmt.Errorf(
+			"view:%s is used by %s: %w",
+			slug,
+			strings.Join(users, ", "),
+			ErrViewInUse,
+		)
+	}
+	return nil
+}
+
+// checkMacroUnused returns ErrMacroInUse if any saved query refers to @name
+func (s *Service) checkMacroUnused(ctx context.Context, name string) error {
+	users, err := s.referencedBy(ctx, func(_, macros []string) bool {
+		return slices.Contains(macros, name)
+	})
+	if err != nil {
+		return err
+	}
+	if len(users) > 0 {
+		return fmt.Errorf(
+			"%s%s is used by %s: %w",
+			query.MacroPrefix,
+			name,
+			strings.Join(users, ", "),
+			ErrMacroInUse,
+		)
+	}
+	return nil
+}
+
+// referencedBy lists the views, macros and rules whose queries match uses
+// (view:slug, @name and rule "Name")
+func (s *Service) referencedBy(
+	ctx context.Context,
+	uses func(views, macros []string) bool,
+) ([]string, error) {
+	var users []string
+
+	views, err := s.queries.ListViews(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToCheckReferences, err)
+	}
+	for _, view := range views {
+		if uses(query.References(view.Query.String)) {
+			users = append(users, query.ViewField+":"+view.Slug)
+		}
+	}
+
+	macros, err := s.queries.ListQueryMacros(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToCheckReferences, err)
+	}
+	for _, macro := range macros {
+		if uses(query.References(macro.Query)) {
+			users = append(users, query.MacroPrefix+macro.Name)
+		}
+	}
+
+	rules, err := s.queries.ListRules(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToCheckReferences, err)
+	}
+	for _, rule := range rules {
+		if uses(query.References(rule.Query.String)) {
+			users = append(users, fmt.Sprintf("rule %q", rule.Name))
+		}
+	}
+
+	return users, nil
+}
@@ -54,6 +54,14 @@ type ViewService interface {
 	) (models.View, error)
 	DeleteView(ctx context.Context, viewID int64, force bool) (linkedRuleCount int, err error)
 	ReorderViews(ctx context.Context, viewIDs []int64) ([]models.View, error)
+	ListMacros(ctx context.Context) ([]models.QueryMacro, error)
+	CreateMacro(ctx context.Context, name, queryStr string) (models.QueryMacro, error)
+	UpdateMacro(
+		ctx context.Context,
+		macroID int64,
+		name, queryStr *string,
+	) (models.QueryMacro, error)
+	DeleteMacro(ctx context.Context, macroID int64) error
 }
 
 // Service implements the ViewService interface, providing
@@ -24,7 +24,6 @@ import (
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
 	"github.com/ajbeattie/octobud/backend/internal/models"
-	"github.com/ajbeattie/octobud/backend/internal/query"
 )
 
 // Error definitions
@@ -199,8 +198,12 @@ func (s *Service) CreateView(
 	}
 
 	// Validate the query by attempting to parse and validate it
-	if _, err := query.ParseAndValidate(queryStr); err != nil {
-		return models.View{}, errors.Join(ErrInvalidQuery, err)
+	if err := s.validateViewQuery(ctx, slug, queryStr); err != nil {
+		return models.View{}, err
+	}
+	queryStr, err := normalizeQuery(queryStr)
+	if err != nil {
+		return models.View{}, err
 	}
 
 	params := db.CreateViewParams{
@@ -285,13 +288,21 @@ func (s *Service) UpdateView(
 		if queryTrimmed == "" {
 			return models.View{}, ErrQueryCannotBeEmpty
 		}
-		// Validate the query by attempting to parse and validate it
-		if _, err := query.ParseAndValidate(queryTrimmed); err != nil {
-			return models.View{}, errors.Join(ErrInvalidQuery, err)
-		}
 		params.Query = sql.NullString{String: queryTrimmed, Valid: true}
 	}
 
+	// Validate the query, and a rename, against the views and macros it references
+	if err := s.checkViewUpdate(ctx, viewID, params); err != nil {
+		return models.View{}, err
+	}
+	if params.Query.Valid {
+		normalized, err := normalizeQuery(params.Query.String)
+		if err != nil {
+			return models.View{}, err
+		}
+		params.Query.String = normalized
+	}
+
 	view, err := s.queries.UpdateView(ctx, params)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -335,6 +346,18 @@ func (s *Service) DeleteView(
 		return linkedRuleCount, nil
 	}
 
+	// Queries that reference the view by slug would stop parsing without it
+	view, err := s.queries.GetView(ctx, viewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errors.Join(ErrViewNotFound, err)
+		}
+		return 0, errors.Join(ErrFailedToGetView, err)
+	}
+	if err := s.checkViewUnused(ctx, view.Slug); err != nil {
+		return 0, err
+	}
+
 	if _, err := s.queries.DeleteView(ctx, viewID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, errors.Join(ErrViewNotFound, err)
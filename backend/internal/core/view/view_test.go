@@ -227,6 +227,54 @@ func TestService_CreateView(t *testing.T) {
 						errors.Is(err, ErrFailedToCreateView),
 				)
 			},
+		}, {
+			name:        "query referencing views and macros is validated against them",
+			viewName:    "Triage",
+			description: nil,
+			icon:        nil,
+			isDefault:   nil,
+			queryStr:    "view:inbox @bots",
+			setupMock: func(m *mocks.MockStore, name string, query string) {
+				m.EXPECT().ListViews(gomock.Any()).Return(nil, nil)
+				m.EXPECT().
+					ListQueryMacros(gomock.Any()).
+					Return([]db.QueryMacro{{ID: 1, Name: "bots", Query: "author:*[bot]"}}, nil)
+				m.EXPECT().
+					CreateView(gomock.Any(), gomock.Any()).
+					Return(db.View{
+						ID:    1,
+						Name:  name,
+						Slug:  "triage",
+						Query: sql.NullString{String: query, Valid: true},
+					}, nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{Total: 1}, nil).
+					AnyTimes()
+				m.EXPECT().ListViews(gomock.Any()).Return(nil, nil).AnyTimes()
+				m.EXPECT().ListQueryMacros(gomock.Any()).Return(nil, nil).AnyTimes()
+			},
+			expectErr: false,
+			checkResult: func(t *testing.T, view models.View) {
+				require.Equal(t, "view:inbox @bots", view.Query)
+			},
+		},
+		{
+			name:        "query referencing an unknown view returns ErrInvalidQuery",
+			viewName:    "Triage",
+			description: nil,
+			icon:        nil,
+			isDefault:   nil,
+			queryStr:    "view:inbx",
+			setupMock: func(m *mocks.MockStore, _ string, _ string) {
+				m.EXPECT().ListViews(gomock.Any()).Return(nil, nil)
+				m.EXPECT().ListQueryMacros(gomock.Any()).Return(nil, nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidQuery)
+				require.Contains(t, err.Error(), "did you mean view:inbox?")
+			},
 		},
 	}
 
@@ -287,6 +335,10 @@ func TestService_UpdateView(t *testing.T) {
 			isDefault:   boolPtr(true),
 			queryStr:    stringPtr("is:read"),
 			setupMock: func(m *mocks.MockStore, id int64) {
+				m.EXPECT().
+					GetView(gomock.Any(), id).
+					Return(db.View{ID: id, Name: "Old View", Slug: "old-view"}, nil)
+				expectNoReferences(m)
 				expectedView := db.View{
 					ID:   id,
 					Name: "Updated View",
@@ -306,6 +358,37 @@ func TestService_UpdateView(t *testing.T) {
 				require.Equal(t, "Updated View", view.Name)
 			},
 		},
+		{
+			name:        "query is saved in canonical form",
+			viewID:      1,
+			namePtr:     nil,
+			description: nil,
+			icon:        nil,
+			isDefault:   nil,
+			queryStr:    stringPtr("repository:cli/cli AND (is:unread OR is:starred)"),
+			setupMock: func(m *mocks.MockStore, id int64) {
+				const want = "repo:cli/cli (is:unread OR is:starred)"
+				m.EXPECT().
+					UpdateView(gomock.Any(), db.UpdateViewParams{
+						ID:    id,
+						Query: sql.NullString{String: want, Valid: true},
+					}).
+					Return(db.View{
+						ID:    id,
+						Name:  "CLI",
+						Slug:  "cli",
+						Query: sql.NullString{String: want, Valid: true},
+					}, nil)
+				m.EXPECT().
+					ListNotificationsFromQuery(gomock.Any(), gomock.Any()).
+					Return(db.ListNotificationsFromQueryResult{Total: 0}, nil).
+					AnyTimes()
+			},
+			expectErr: false,
+			checkResult: func(t *testing.T, view models.View) {
+				require.Equal(t, "repo:cli/cli (is:unread OR is:starred)", view.Query)
+			},
+		},
 		{
 			name:        "empty name returns error before DB call",
 			viewID:      1,
@@ -346,9 +429,9 @@ func TestService_UpdateView(t *testing.T) {
 			icon:        nil,
 			isDefault:   nil,
 			queryStr:    nil,
-			setupMock: func(m *mocks.MockStore, _ int64) {
+			setupMock: func(m *mocks.MockStore, id int64) {
 				m.EXPECT().
-					UpdateView(gomock.Any(), gomock.Any()).
+					GetView(gomock.Any(), id).
 					Return(db.View{}, sql.ErrNoRows)
 			},
 			expectErr: true,
@@ -364,7 +447,10 @@ func TestService_UpdateView(t *testing.T) {
 			icon:        nil,
 			isDefault:   nil,
 			queryStr:    nil,
-			setupMock: func(m *mocks.MockStore, _ int64) {
+			setupMock: func(m *mocks.MockStore, id int64) {
+				m.EXPECT().
+					GetView(gomock.Any(), id).
+					Return(db.View{ID: id, Name: "Updated View", Slug: "updated-view"}, nil)
 				dbError := errors.New("database error")
 				m.EXPECT().
 					UpdateView(gomock.Any(), gomock.Any()).
@@ -375,6 +461,66 @@ func TestService_UpdateView(t *testing.T) {
 				require.True(t, errors.Is(err, ErrFailedToUpdateView))
 			},
 		},
+		{
+			name:        "renaming a referenced view returns ErrViewInUse",
+			viewID:      1,
+			namePtr:     stringPtr("Renamed"),
+			description: nil,
+			icon:        nil,
+			isDefault:   nil,
+			queryStr:    nil,
+			setupMock: func(m *mocks.MockStore, id int64) {
+				m.EXPECT().
+					GetView(gomock.Any(), id).
+					Return(db.View{ID: id, Name: "Work", Slug: "work"}, nil)
+				m.EXPECT().
+					ListViews(gomock.Any()).
+					Return([]db.View{
+						{ID: 2, Slug: "triage", Query: sql.NullString{
+							String: "view:work is:unread", Valid: true,
+						}},
+					}, nil)
+				m.EXPECT().ListQueryMacros(gomock.Any()).Return(nil, nil)
+				m.EXPECT().
+					ListRules(gomock.Any()).
+					Return([]db.Rule{
+						{Name: "Archive work", Query: sql.NullString{
+							String: "-view:work", Valid: true,
+						}},
+					}, nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrViewInUse)
+				require.Contains(t, err.Error(), `view:triage, rule "Archive work"`)
+			},
+		},
+		{
+			name:        "query that leads back to the view returns ErrInvalidQuery",
+			viewID:      1,
+			namePtr:     nil,
+			description: nil,
+			icon:        nil,
+			isDefault:   nil,
+			queryStr:    stringPtr("view:triage"),
+			setupMock: func(m *mocks.MockStore, id int64) {
+				m.EXPECT().
+					GetView(gomock.Any(), id).
+					Return(db.View{ID: id, Name: "Work", Slug: "work"}, nil)
+				m.EXPECT().
+					ListViews(gomock.Any()).
+					Return([]db.View{
+						{ID: 1, Slug: "work", Query: sql.NullString{String: "is:unread", Valid: true}},
+						{ID: 2, Slug: "triage", Query: sql.NullString{String: "view:work", Valid: true}},
+					}, nil)
+				m.EXPECT().ListQueryMacros(gomock.Any()).Return(nil, nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrInvalidQuery)
+				require.Contains(t, err.Error(), "view:triage -> view:work -> view:triage")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -430,6 +576,10 @@ func TestService_DeleteView(t *testing.T) {
 				m.EXPECT().
 					GetRulesByViewID(gomock.Any(), gomock.Any()).
 					Return([]db.Rule{}, nil)
+				m.EXPECT().
+					GetView(gomock.Any(), id).
+					Return(db.View{ID: id, Slug: "work"}, nil)
+				expectNoReferences(m)
 				m.EXPECT().
 					DeleteView(gomock.Any(), id).
 					Return(id, nil)
@@ -465,6 +615,10 @@ func TestService_DeleteView(t *testing.T) {
 				m.EXPECT().
 					GetRulesByViewID(gomock.Any(), gomock.Any()).
 					Return(linkedRules, nil)
+				m.EXPECT().
+					GetView(gomock.Any(), id).
+					Return(db.View{ID: id, Slug: "work"}, nil)
+				expectNoReferences(m)
 				m.EXPECT().
 					DeleteView(gomock.Any(), id).
 					Return(id, nil)
@@ -481,8 +635,8 @@ func TestService_DeleteView(t *testing.T) {
 					GetRulesByViewID(gomock.Any(), gomock.Any()).
 					Return([]db.Rule{}, nil)
 				m.EXPECT().
-					DeleteView(gomock.Any(), id).
-					Return(int64(0), sql.ErrNoRows)
+					GetView(gomock.Any(), id).
+					Return(db.View{}, sql.ErrNoRows)
 			},
 			expectErr: true,
 			checkErr: func(t *testing.T, err error) {
@@ -512,6 +666,10 @@ func TestService_DeleteView(t *testing.T) {
 				m.EXPECT().
 					GetRulesByViewID(gomock.Any(), gomock.Any()).
 					Return([]db.Rule{}, nil)
+				m.EXPECT().
+					GetView(gomock.Any(), id).
+					Return(db.View{ID: id, Slug: "work"}, nil)
+				expectNoReferences(m)
 				dbError := errors.New("database error")
 				m.EXPECT().
 					DeleteView(gomock.Any(), id).
@@ -522,6 +680,29 @@ func TestService_DeleteView(t *testing.T) {
 				require.True(t, errors.Is(err, ErrFailedToDeleteView))
 			},
 		},
+		{
+			name:   "view used by a macro returns ErrViewInUse without deleting",
+			viewID: 1,
+			force:  true,
+			setupMock: func(m *mocks.MockStore, id int64) {
+				m.EXPECT().
+					GetRulesByViewID(gomock.Any(), gomock.Any()).
+					Return([]db.Rule{}, nil)
+				m.EXPECT().
+					GetView(gomock.Any(), id).
+					Return(db.View{ID: id, Slug: "work"}, nil)
+				m.EXPECT().ListViews(gomock.Any()).Return(nil, nil)
+				m.EXPECT().
+					ListQueryMacros(gomock.Any()).
+					Return([]db.QueryMacro{{ID: 1, Name: "focus", Query: "VIEW:Work is:unread"}}, nil)
+				m.EXPECT().ListRules(gomock.Any()).Return(nil, nil)
+			},
+			expectErr: true,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrViewInUse)
+				require.Contains(t, err.Error(), "view:work is used by @focus")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -657,3 +838,10 @@ func stringPtr(s string) *string {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+// expectNoReferences expects the reference check to find no queries at all
+func expectNoReferences(m *mocks.MockStore) {
+	m.EXPECT().ListViews(gomock.Any()).Return(nil, nil)
+	m.EXPECT().ListQueryMacros(gomock.Any()).Return(nil, nil)
+	m.EXPECT().ListRules(gomock.Any()).Return(nil, nil)
+}
@@ -56,3 +56,18 @@ func (mr *MockRiverClientMockRecorder) Insert(ctx, args, opts any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRiverClient)(nil).Insert), ctx, args, opts)
 }
+
+// InsertMany mocks base method.
+func (m *MockRiverClient) InsertMany(ctx context.Context, params []river.InsertManyParams) ([]*rivertype.JobInsertResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMany", ctx, params)
+	ret0, _ := ret[0].([]*rivertype.JobInsertResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertMany indicates an expected call of InsertMany.
+func (mr *MockRiverClientMockRecorder) InsertMany(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMany", reflect.TypeOf((*MockRiverClient)(nil).InsertMany), ctx, params)
+}
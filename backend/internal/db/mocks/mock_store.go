@@ -13,6 +13,7 @@ import (
 	context "context"
 	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	db "github.com/ajbeattie/octobud/backend/internal/db"
 	pqtype "github.com/sqlc-dev/pqtype"
@@ -44,18 +45,18 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 }
 
 // ArchiveNotification mocks base method.
-func (m *MockStore) ArchiveNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) ArchiveNotification(ctx context.Context, arg db.ArchiveNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ArchiveNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "ArchiveNotification", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ArchiveNotification indicates an expected call of ArchiveNotification.
-func (mr *MockStoreMockRecorder) ArchiveNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) ArchiveNotification(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveNotification", reflect.TypeOf((*MockStore)(nil).ArchiveNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveNotification", reflect.TypeOf((*MockStore)(nil).ArchiveNotification), ctx, arg)
 }
 
 // AssignTagToEntity mocks base method.
@@ -74,18 +75,18 @@ func (mr *MockStoreMockRecorder) AssignTagToEntity(ctx, arg any) *gomock.Call {
 }
 
 // BulkArchiveNotifications mocks base method.
-func (m *MockStore) BulkArchiveNotifications(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkArchiveNotifications(ctx context.Context, arg db.BulkArchiveNotificationsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkArchiveNotifications", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkArchiveNotifications", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkArchiveNotifications indicates an expected call of BulkArchiveNotifications.
-func (mr *MockStoreMockRecorder) BulkArchiveNotifications(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkArchiveNotifications(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkArchiveNotifications", reflect.TypeOf((*MockStore)(nil).BulkArchiveNotifications), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkArchiveNotifications", reflect.TypeOf((*MockStore)(nil).BulkArchiveNotifications), ctx, arg)
 }
 
 // BulkArchiveNotificationsByQuery mocks base method.
@@ -103,19 +104,49 @@ func (mr *MockStoreMockRecorder) BulkArchiveNotificationsByQuery(ctx, query any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkArchiveNotificationsByQuery", reflect.TypeOf((*MockStore)(nil).BulkArchiveNotificationsByQuery), ctx, query)
 }
 
+// BulkClearNotificationsStale mocks base method.
+func (m *MockStore) BulkClearNotificationsStale(ctx context.Context, arg db.BulkClearNotificationsStaleParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkClearNotificationsStale", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkClearNotificationsStale indicates an expected call of BulkClearNotificationsStale.
+func (mr *MockStoreMockRecorder) BulkClearNotificationsStale(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkClearNotificationsStale", reflect.TypeOf((*MockStore)(nil).BulkClearNotificationsStale), ctx, arg)
+}
+
+// BulkDeleteNotifications mocks base method.
+func (m *MockStore) BulkDeleteNotifications(ctx context.Context, arg db.BulkDeleteNotificationsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDeleteNotifications", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDeleteNotifications indicates an expected call of BulkDeleteNotifications.
+func (mr *MockStoreMockRecorder) BulkDeleteNotifications(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDeleteNotifications", reflect.TypeOf((*MockStore)(nil).BulkDeleteNotifications), ctx, arg)
+}
+
 // BulkMarkNotificationsRead mocks base method.
-func (m *MockStore) BulkMarkNotificationsRead(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkMarkNotificationsRead(ctx context.Context, arg db.BulkMarkNotificationsReadParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkMarkNotificationsRead", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkMarkNotificationsRead", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkMarkNotificationsRead indicates an expected call of BulkMarkNotificationsRead.
-func (mr *MockStoreMockRecorder) BulkMarkNotificationsRead(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkMarkNotificationsRead(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsRead", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsRead), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsRead", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsRead), ctx, arg)
 }
 
 // BulkMarkNotificationsReadByQuery mocks base method.
@@ -133,34 +164,49 @@ func (mr *MockStoreMockRecorder) BulkMarkNotificationsReadByQuery(ctx, query any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsReadByQuery", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsReadByQuery), ctx, query)
 }
 
+// BulkMarkNotificationsStale mocks base method.
+func (m *MockStore) BulkMarkNotificationsStale(ctx context.Context, arg db.BulkMarkNotificationsStaleParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkMarkNotificationsStale", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkMarkNotificationsStale indicates an expected call of BulkMarkNotificationsStale.
+func (mr *MockStoreMockRecorder) BulkMarkNotificationsStale(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsStale", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsStale), ctx, arg)
+}
+
 // BulkMarkNotificationsUnfiltered mocks base method.
-func (m *MockStore) BulkMarkNotificationsUnfiltered(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkMarkNotificationsUnfiltered(ctx context.Context, arg db.BulkMarkNotificationsUnfilteredParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkMarkNotificationsUnfiltered", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkMarkNotificationsUnfiltered", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkMarkNotificationsUnfiltered indicates an expected call of BulkMarkNotificationsUnfiltered.
-func (mr *MockStoreMockRecorder) BulkMarkNotificationsUnfiltered(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkMarkNotificationsUnfiltered(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsUnfiltered", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsUnfiltered), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsUnfiltered", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsUnfiltered), ctx, arg)
 }
 
 // BulkMarkNotificationsUnread mocks base method.
-func (m *MockStore) BulkMarkNotificationsUnread(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkMarkNotificationsUnread(ctx context.Context, arg db.BulkMarkNotificationsUnreadParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkMarkNotificationsUnread", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkMarkNotificationsUnread", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkMarkNotificationsUnread indicates an expected call of BulkMarkNotificationsUnread.
-func (mr *MockStoreMockRecorder) BulkMarkNotificationsUnread(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkMarkNotificationsUnread(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsUnread", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsUnread), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationsUnread", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationsUnread), ctx, arg)
 }
 
 // BulkMarkNotificationsUnreadByQuery mocks base method.
@@ -179,18 +225,18 @@ func (mr *MockStoreMockRecorder) BulkMarkNotificationsUnreadByQuery(ctx, query a
 }
 
 // BulkMuteNotifications mocks base method.
-func (m *MockStore) BulkMuteNotifications(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkMuteNotifications(ctx context.Context, arg db.BulkMuteNotificationsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkMuteNotifications", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkMuteNotifications", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkMuteNotifications indicates an expected call of BulkMuteNotifications.
-func (mr *MockStoreMockRecorder) BulkMuteNotifications(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkMuteNotifications(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMuteNotifications", reflect.TypeOf((*MockStore)(nil).BulkMuteNotifications), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMuteNotifications", reflect.TypeOf((*MockStore)(nil).BulkMuteNotifications), ctx, arg)
 }
 
 // BulkMuteNotificationsByQuery mocks base method.
@@ -239,18 +285,18 @@ func (mr *MockStoreMockRecorder) BulkSnoozeNotificationsByQuery(ctx, arg any) *g
 }
 
 // BulkStarNotifications mocks base method.
-func (m *MockStore) BulkStarNotifications(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkStarNotifications(ctx context.Context, arg db.BulkStarNotificationsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkStarNotifications", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkStarNotifications", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkStarNotifications indicates an expected call of BulkStarNotifications.
-func (mr *MockStoreMockRecorder) BulkStarNotifications(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkStarNotifications(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkStarNotifications", reflect.TypeOf((*MockStore)(nil).BulkStarNotifications), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkStarNotifications", reflect.TypeOf((*MockStore)(nil).BulkStarNotifications), ctx, arg)
 }
 
 // BulkStarNotificationsByQuery mocks base method.
@@ -269,18 +315,18 @@ func (mr *MockStoreMockRecorder) BulkStarNotificationsByQuery(ctx, query any) *g
 }
 
 // BulkUnarchiveNotifications mocks base method.
-func (m *MockStore) BulkUnarchiveNotifications(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkUnarchiveNotifications(ctx context.Context, arg db.BulkUnarchiveNotificationsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkUnarchiveNotifications", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkUnarchiveNotifications", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkUnarchiveNotifications indicates an expected call of BulkUnarchiveNotifications.
-func (mr *MockStoreMockRecorder) BulkUnarchiveNotifications(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkUnarchiveNotifications(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnarchiveNotifications", reflect.TypeOf((*MockStore)(nil).BulkUnarchiveNotifications), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnarchiveNotifications", reflect.TypeOf((*MockStore)(nil).BulkUnarchiveNotifications), ctx, arg)
 }
 
 // BulkUnarchiveNotificationsByQuery mocks base method.
@@ -299,18 +345,18 @@ func (mr *MockStoreMockRecorder) BulkUnarchiveNotificationsByQuery(ctx, query an
 }
 
 // BulkUnmuteNotifications mocks base method.
-func (m *MockStore) BulkUnmuteNotifications(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkUnmuteNotifications(ctx context.Context, arg db.BulkUnmuteNotificationsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkUnmuteNotifications", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkUnmuteNotifications", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkUnmuteNotifications indicates an expected call of BulkUnmuteNotifications.
-func (mr *MockStoreMockRecorder) BulkUnmuteNotifications(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkUnmuteNotifications(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnmuteNotifications", reflect.TypeOf((*MockStore)(nil).BulkUnmuteNotifications), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnmuteNotifications", reflect.TypeOf((*MockStore)(nil).BulkUnmuteNotifications), ctx, arg)
 }
 
 // BulkUnmuteNotificationsByQuery mocks base method.
@@ -329,18 +375,18 @@ func (mr *MockStoreMockRecorder) BulkUnmuteNotificationsByQuery(ctx, query any)
 }
 
 // BulkUnsnoozeNotifications mocks base method.
-func (m *MockStore) BulkUnsnoozeNotifications(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkUnsnoozeNotifications(ctx context.Context, arg db.BulkUnsnoozeNotificationsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkUnsnoozeNotifications", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkUnsnoozeNotifications", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkUnsnoozeNotifications indicates an expected call of BulkUnsnoozeNotifications.
-func (mr *MockStoreMockRecorder) BulkUnsnoozeNotifications(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkUnsnoozeNotifications(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnsnoozeNotifications", reflect.TypeOf((*MockStore)(nil).BulkUnsnoozeNotifications), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnsnoozeNotifications", reflect.TypeOf((*MockStore)(nil).BulkUnsnoozeNotifications), ctx, arg)
 }
 
 // BulkUnsnoozeNotificationsByQuery mocks base method.
@@ -359,18 +405,18 @@ func (mr *MockStoreMockRecorder) BulkUnsnoozeNotificationsByQuery(ctx, query any
 }
 
 // BulkUnstarNotifications mocks base method.
-func (m *MockStore) BulkUnstarNotifications(ctx context.Context, githubIds []string) (int64, error) {
+func (m *MockStore) BulkUnstarNotifications(ctx context.Context, arg db.BulkUnstarNotificationsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkUnstarNotifications", ctx, githubIds)
+	ret := m.ctrl.Call(m, "BulkUnstarNotifications", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BulkUnstarNotifications indicates an expected call of BulkUnstarNotifications.
-func (mr *MockStoreMockRecorder) BulkUnstarNotifications(ctx, githubIds any) *gomock.Call {
+func (mr *MockStoreMockRecorder) BulkUnstarNotifications(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnstarNotifications", reflect.TypeOf((*MockStore)(nil).BulkUnstarNotifications), ctx, githubIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnstarNotifications", reflect.TypeOf((*MockStore)(nil).BulkUnstarNotifications), ctx, arg)
 }
 
 // BulkUnstarNotificationsByQuery mocks base method.
@@ -388,6 +434,80 @@ func (mr *MockStoreMockRecorder) BulkUnstarNotificationsByQuery(ctx, query any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUnstarNotificationsByQuery", reflect.TypeOf((*MockStore)(nil).BulkUnstarNotificationsByQuery), ctx, query)
 }
 
+// CompleteGitHubOutboxEntry mocks base method.
+func (m *MockStore) CompleteGitHubOutboxEntry(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteGitHubOutboxEntry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteGitHubOutboxEntry indicates an expected call of CompleteGitHubOutboxEntry.
+func (mr *MockStoreMockRecorder) CompleteGitHubOutboxEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteGitHubOutboxEntry", reflect.TypeOf((*MockStore)(nil).CompleteGitHubOutboxEntry), ctx, id)
+}
+
+// CountNotificationsFromQuery mocks base method.
+func (m *MockStore) CountNotificationsFromQuery(ctx context.Context, query db.NotificationQuery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountNotificationsFromQuery", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountNotificationsFromQuery indicates an expected call of CountNotificationsFromQuery.
+func (mr *MockStoreMockRecorder) CountNotificationsFromQuery(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNotificationsFromQuery", reflect.TypeOf((*MockStore)(nil).CountNotificationsFromQuery), ctx, query)
+}
+
+// CountSyncRuns mocks base method.
+func (m *MockStore) CountSyncRuns(ctx context.Context, accountID sql.NullInt64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSyncRuns", ctx, accountID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSyncRuns indicates an expected call of CountSyncRuns.
+func (mr *MockStoreMockRecorder) CountSyncRuns(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSyncRuns", reflect.TypeOf((*MockStore)(nil).CountSyncRuns), ctx, accountID)
+}
+
+// CreateGitHubOutboxEntries mocks base method.
+func (m *MockStore) CreateGitHubOutboxEntries(ctx context.Context, arg db.CreateGitHubOutboxEntriesParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGitHubOutboxEntries", ctx, arg)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGitHubOutboxEntries indicates an expected call of CreateGitHubOutboxEntries.
+func (mr *MockStoreMockRecorder) CreateGitHubOutboxEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGitHubOutboxEntries", reflect.TypeOf((*MockStore)(nil).CreateGitHubOutboxEntries), ctx, arg)
+}
+
+// CreateQueryMacro mocks base method.
+func (m *MockStore) CreateQueryMacro(ctx context.Context, arg db.CreateQueryMacroParams) (db.QueryMacro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQueryMacro", ctx, arg)
+	ret0, _ := ret[0].(db.QueryMacro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQueryMacro indicates an expected call of CreateQueryMacro.
+func (mr *MockStoreMockRecorder) CreateQueryMacro(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueryMacro", reflect.TypeOf((*MockStore)(nil).CreateQueryMacro), ctx, arg)
+}
+
 // CreateRule mocks base method.
 func (m *MockStore) CreateRule(ctx context.Context, arg db.CreateRuleParams) (db.Rule, error) {
 	m.ctrl.T.Helper()
@@ -403,6 +523,21 @@ func (mr *MockStoreMockRecorder) CreateRule(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockStore)(nil).CreateRule), ctx, arg)
 }
 
+// CreateSyncRun mocks base method.
+func (m *MockStore) CreateSyncRun(ctx context.Context, arg db.CreateSyncRunParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSyncRun", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSyncRun indicates an expected call of CreateSyncRun.
+func (mr *MockStoreMockRecorder) CreateSyncRun(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSyncRun", reflect.TypeOf((*MockStore)(nil).CreateSyncRun), ctx, arg)
+}
+
 // CreateUser mocks base method.
 func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -433,6 +568,36 @@ func (mr *MockStoreMockRecorder) CreateView(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockStore)(nil).CreateView), ctx, arg)
 }
 
+// DeleteGitHubOutboxEntriesCompletedBefore mocks base method.
+func (m *MockStore) DeleteGitHubOutboxEntriesCompletedBefore(ctx context.Context, completedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGitHubOutboxEntriesCompletedBefore", ctx, completedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGitHubOutboxEntriesCompletedBefore indicates an expected call of DeleteGitHubOutboxEntriesCompletedBefore.
+func (mr *MockStoreMockRecorder) DeleteGitHubOutboxEntriesCompletedBefore(ctx, completedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitHubOutboxEntriesCompletedBefore", reflect.TypeOf((*MockStore)(nil).DeleteGitHubOutboxEntriesCompletedBefore), ctx, completedBefore)
+}
+
+// DeleteQueryMacro mocks base method.
+func (m *MockStore) DeleteQueryMacro(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueryMacro", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteQueryMacro indicates an expected call of DeleteQueryMacro.
+func (mr *MockStoreMockRecorder) DeleteQueryMacro(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueryMacro", reflect.TypeOf((*MockStore)(nil).DeleteQueryMacro), ctx, id)
+}
+
 // DeleteRule mocks base method.
 func (m *MockStore) DeleteRule(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -447,6 +612,21 @@ func (mr *MockStoreMockRecorder) DeleteRule(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockStore)(nil).DeleteRule), ctx, id)
 }
 
+// DeleteSyncRunsStartedBefore mocks base method.
+func (m *MockStore) DeleteSyncRunsStartedBefore(ctx context.Context, startedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSyncRunsStartedBefore", ctx, startedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSyncRunsStartedBefore indicates an expected call of DeleteSyncRunsStartedBefore.
+func (mr *MockStoreMockRecorder) DeleteSyncRunsStartedBefore(ctx, startedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSyncRunsStartedBefore", reflect.TypeOf((*MockStore)(nil).DeleteSyncRunsStartedBefore), ctx, startedBefore)
+}
+
 // DeleteTag mocks base method.
 func (m *MockStore) DeleteTag(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -476,19 +656,78 @@ func (mr *MockStoreMockRecorder) DeleteView(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockStore)(nil).DeleteView), ctx, id)
 }
 
+// ExplainNotificationsFromQuery mocks base method.
+func (m *MockStore) ExplainNotificationsFromQuery(ctx context.Context, query db.NotificationQuery) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainNotificationsFromQuery", ctx, query)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainNotificationsFromQuery indicates an expected call of ExplainNotificationsFromQuery.
+func (mr *MockStoreMockRecorder) ExplainNotificationsFromQuery(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainNotificationsFromQuery", reflect.TypeOf((*MockStore)(nil).ExplainNotificationsFromQuery), ctx, query)
+}
+
+// FinishSyncRun mocks base method.
+func (m *MockStore) FinishSyncRun(ctx context.Context, arg db.FinishSyncRunParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSyncRun", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishSyncRun indicates an expected call of FinishSyncRun.
+func (mr *MockStoreMockRecorder) FinishSyncRun(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSyncRun", reflect.TypeOf((*MockStore)(nil).FinishSyncRun), ctx, arg)
+}
+
+// GetGitHubAccount mocks base method.
+func (m *MockStore) GetGitHubAccount(ctx context.Context, id int64) (db.GithubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitHubAccount", ctx, id)
+	ret0, _ := ret[0].(db.GithubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGitHubAccount indicates an expected call of GetGitHubAccount.
+func (mr *MockStoreMockRecorder) GetGitHubAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubAccount", reflect.TypeOf((*MockStore)(nil).GetGitHubAccount), ctx, id)
+}
+
+// GetGitHubOutboxEntry mocks base method.
+func (m *MockStore) GetGitHubOutboxEntry(ctx context.Context, id int64) (db.GithubOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGitHubOutboxEntry", ctx, id)
+	ret0, _ := ret[0].(db.GithubOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGitHubOutboxEntry indicates an expected call of GetGitHubOutboxEntry.
+func (mr *MockStoreMockRecorder) GetGitHubOutboxEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubOutboxEntry", reflect.TypeOf((*MockStore)(nil).GetGitHubOutboxEntry), ctx, id)
+}
+
 // GetNotificationByGithubID mocks base method.
-func (m *MockStore) GetNotificationByGithubID(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) GetNotificationByGithubID(ctx context.Context, arg db.GetNotificationByGithubIDParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNotificationByGithubID", ctx, githubID)
+	ret := m.ctrl.Call(m, "GetNotificationByGithubID", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNotificationByGithubID indicates an expected call of GetNotificationByGithubID.
-func (mr *MockStoreMockRecorder) GetNotificationByGithubID(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) GetNotificationByGithubID(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByGithubID", reflect.TypeOf((*MockStore)(nil).GetNotificationByGithubID), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByGithubID", reflect.TypeOf((*MockStore)(nil).GetNotificationByGithubID), ctx, arg)
 }
 
 // GetNotificationByID mocks base method.
@@ -506,6 +745,21 @@ func (mr *MockStoreMockRecorder) GetNotificationByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByID", reflect.TypeOf((*MockStore)(nil).GetNotificationByID), ctx, id)
 }
 
+// GetQueryMacro mocks base method.
+func (m *MockStore) GetQueryMacro(ctx context.Context, id int64) (db.QueryMacro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryMacro", ctx, id)
+	ret0, _ := ret[0].(db.QueryMacro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryMacro indicates an expected call of GetQueryMacro.
+func (mr *MockStoreMockRecorder) GetQueryMacro(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryMacro", reflect.TypeOf((*MockStore)(nil).GetQueryMacro), ctx, id)
+}
+
 // GetRepositoryByID mocks base method.
 func (m *MockStore) GetRepositoryByID(ctx context.Context, id int64) (db.Repository, error) {
 	m.ctrl.T.Helper()
@@ -552,18 +806,18 @@ func (mr *MockStoreMockRecorder) GetRulesByViewID(ctx, viewID any) *gomock.Call
 }
 
 // GetSyncState mocks base method.
-func (m *MockStore) GetSyncState(ctx context.Context) (db.GetSyncStateRow, error) {
+func (m *MockStore) GetSyncState(ctx context.Context, accountID int64) (db.GetSyncStateRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSyncState", ctx)
+	ret := m.ctrl.Call(m, "GetSyncState", ctx, accountID)
 	ret0, _ := ret[0].(db.GetSyncStateRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSyncState indicates an expected call of GetSyncState.
-func (mr *MockStoreMockRecorder) GetSyncState(ctx any) *gomock.Call {
+func (mr *MockStoreMockRecorder) GetSyncState(ctx, accountID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncState", reflect.TypeOf((*MockStore)(nil).GetSyncState), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncState", reflect.TypeOf((*MockStore)(nil).GetSyncState), ctx, accountID)
 }
 
 // GetTag mocks base method.
@@ -626,6 +880,20 @@ func (mr *MockStoreMockRecorder) GetView(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockStore)(nil).GetView), ctx, id)
 }
 
+// IncrementSyncRunSubjectFetchFailures mocks base method.
+func (m *MockStore) IncrementSyncRunSubjectFetchFailures(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementSyncRunSubjectFetchFailures", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementSyncRunSubjectFetchFailures indicates an expected call of IncrementSyncRunSubjectFetchFailures.
+func (mr *MockStoreMockRecorder) IncrementSyncRunSubjectFetchFailures(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementSyncRunSubjectFetchFailures", reflect.TypeOf((*MockStore)(nil).IncrementSyncRunSubjectFetchFailures), ctx, id)
+}
+
 // ListAllTags mocks base method.
 func (m *MockStore) ListAllTags(ctx context.Context) ([]db.Tag, error) {
 	m.ctrl.T.Helper()
@@ -656,6 +924,141 @@ func (mr *MockStoreMockRecorder) ListEnabledRulesOrdered(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabledRulesOrdered", reflect.TypeOf((*MockStore)(nil).ListEnabledRulesOrdered), ctx)
 }
 
+// ListGitHubAccounts mocks base method.
+func (m *MockStore) ListGitHubAccounts(ctx context.Context) ([]db.GithubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGitHubAccounts", ctx)
+	ret0, _ := ret[0].([]db.GithubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGitHubAccounts indicates an expected call of ListGitHubAccounts.
+func (mr *MockStoreMockRecorder) ListGitHubAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGitHubAccounts", reflect.TypeOf((*MockStore)(nil).ListGitHubAccounts), ctx)
+}
+
+// ListNotificationAuthorLogins mocks base method.
+func (m *MockStore) ListNotificationAuthorLogins(ctx context.Context, arg db.ListNotificationAuthorLoginsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationAuthorLogins", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationAuthorLogins indicates an expected call of ListNotificationAuthorLogins.
+func (mr *MockStoreMockRecorder) ListNotificationAuthorLogins(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationAuthorLogins", reflect.TypeOf((*MockStore)(nil).ListNotificationAuthorLogins), ctx, arg)
+}
+
+// ListNotificationCategories mocks base method.
+func (m *MockStore) ListNotificationCategories(ctx context.Context, arg db.ListNotificationCategoriesParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationCategories", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationCategories indicates an expected call of ListNotificationCategories.
+func (mr *MockStoreMockRecorder) ListNotificationCategories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationCategories", reflect.TypeOf((*MockStore)(nil).ListNotificationCategories), ctx, arg)
+}
+
+// ListNotificationLabels mocks base method.
+func (m *MockStore) ListNotificationLabels(ctx context.Context, arg db.ListNotificationLabelsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationLabels", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationLabels indicates an expected call of ListNotificationLabels.
+func (mr *MockStoreMockRecorder) ListNotificationLabels(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationLabels", reflect.TypeOf((*MockStore)(nil).ListNotificationLabels), ctx, arg)
+}
+
+// ListNotificationMilestones mocks base method.
+func (m *MockStore) ListNotificationMilestones(ctx context.Context, arg db.ListNotificationMilestonesParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationMilestones", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationMilestones indicates an expected call of ListNotificationMilestones.
+func (mr *MockStoreMockRecorder) ListNotificationMilestones(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationMilestones", reflect.TypeOf((*MockStore)(nil).ListNotificationMilestones), ctx, arg)
+}
+
+// ListNotificationReasons mocks base method.
+func (m *MockStore) ListNotificationReasons(ctx context.Context, arg db.ListNotificationReasonsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationReasons", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationReasons indicates an expected call of ListNotificationReasons.
+func (mr *MockStoreMockRecorder) ListNotificationReasons(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationReasons", reflect.TypeOf((*MockStore)(nil).ListNotificationReasons), ctx, arg)
+}
+
+// ListNotificationSubjectTypes mocks base method.
+func (m *MockStore) ListNotificationSubjectTypes(ctx context.Context, arg db.ListNotificationSubjectTypesParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationSubjectTypes", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationSubjectTypes indicates an expected call of ListNotificationSubjectTypes.
+func (mr *MockStoreMockRecorder) ListNotificationSubjectTypes(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationSubjectTypes", reflect.TypeOf((*MockStore)(nil).ListNotificationSubjectTypes), ctx, arg)
+}
+
+// ListNotificationThreadsFromQuery mocks base method.
+func (m *MockStore) ListNotificationThreadsFromQuery(ctx context.Context, arg db.ListNotificationThreadsFromQueryParams) ([]db.NotificationThread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationThreadsFromQuery", ctx, arg)
+	ret0, _ := ret[0].([]db.NotificationThread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationThreadsFromQuery indicates an expected call of ListNotificationThreadsFromQuery.
+func (mr *MockStoreMockRecorder) ListNotificationThreadsFromQuery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationThreadsFromQuery", reflect.TypeOf((*MockStore)(nil).ListNotificationThreadsFromQuery), ctx, arg)
+}
+
+// ListNotificationsBySubjectURLs mocks base method.
+func (m *MockStore) ListNotificationsBySubjectURLs(ctx context.Context, subjectUrls []string) ([]db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationsBySubjectURLs", ctx, subjectUrls)
+	ret0, _ := ret[0].([]db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationsBySubjectURLs indicates an expected call of ListNotificationsBySubjectURLs.
+func (mr *MockStoreMockRecorder) ListNotificationsBySubjectURLs(ctx, subjectUrls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationsBySubjectURLs", reflect.TypeOf((*MockStore)(nil).ListNotificationsBySubjectURLs), ctx, subjectUrls)
+}
+
 // ListNotificationsFromQuery mocks base method.
 func (m *MockStore) ListNotificationsFromQuery(ctx context.Context, query db.NotificationQuery) (db.ListNotificationsFromQueryResult, error) {
 	m.ctrl.T.Helper()
@@ -671,6 +1074,66 @@ func (mr *MockStoreMockRecorder) ListNotificationsFromQuery(ctx, query any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationsFromQuery", reflect.TypeOf((*MockStore)(nil).ListNotificationsFromQuery), ctx, query)
 }
 
+// ListNotificationsToReconcile mocks base method.
+func (m *MockStore) ListNotificationsToReconcile(ctx context.Context, arg db.ListNotificationsToReconcileParams) ([]db.ListNotificationsToReconcileRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationsToReconcile", ctx, arg)
+	ret0, _ := ret[0].([]db.ListNotificationsToReconcileRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationsToReconcile indicates an expected call of ListNotificationsToReconcile.
+func (mr *MockStoreMockRecorder) ListNotificationsToReconcile(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationsToReconcile", reflect.TypeOf((*MockStore)(nil).ListNotificationsToReconcile), ctx, arg)
+}
+
+// ListNotificationsWithStaleSubjects mocks base method.
+func (m *MockStore) ListNotificationsWithStaleSubjects(ctx context.Context, arg db.ListNotificationsWithStaleSubjectsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationsWithStaleSubjects", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationsWithStaleSubjects indicates an expected call of ListNotificationsWithStaleSubjects.
+func (mr *MockStoreMockRecorder) ListNotificationsWithStaleSubjects(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationsWithStaleSubjects", reflect.TypeOf((*MockStore)(nil).ListNotificationsWithStaleSubjects), ctx, arg)
+}
+
+// ListPendingGitHubOutboxEntries mocks base method.
+func (m *MockStore) ListPendingGitHubOutboxEntries(ctx context.Context, arg db.ListPendingGitHubOutboxEntriesParams) ([]db.GithubOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingGitHubOutboxEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.GithubOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingGitHubOutboxEntries indicates an expected call of ListPendingGitHubOutboxEntries.
+func (mr *MockStoreMockRecorder) ListPendingGitHubOutboxEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingGitHubOutboxEntries", reflect.TypeOf((*MockStore)(nil).ListPendingGitHubOutboxEntries), ctx, arg)
+}
+
+// ListQueryMacros mocks base method.
+func (m *MockStore) ListQueryMacros(ctx context.Context) ([]db.QueryMacro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueryMacros", ctx)
+	ret0, _ := ret[0].([]db.QueryMacro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueryMacros indicates an expected call of ListQueryMacros.
+func (mr *MockStoreMockRecorder) ListQueryMacros(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueryMacros", reflect.TypeOf((*MockStore)(nil).ListQueryMacros), ctx)
+}
+
 // ListRepositories mocks base method.
 func (m *MockStore) ListRepositories(ctx context.Context) ([]db.Repository, error) {
 	m.ctrl.T.Helper()
@@ -686,6 +1149,36 @@ func (mr *MockStoreMockRecorder) ListRepositories(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositories", reflect.TypeOf((*MockStore)(nil).ListRepositories), ctx)
 }
 
+// ListRepositoryFullNames mocks base method.
+func (m *MockStore) ListRepositoryFullNames(ctx context.Context, arg db.ListRepositoryFullNamesParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRepositoryFullNames", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRepositoryFullNames indicates an expected call of ListRepositoryFullNames.
+func (mr *MockStoreMockRecorder) ListRepositoryFullNames(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositoryFullNames", reflect.TypeOf((*MockStore)(nil).ListRepositoryFullNames), ctx, arg)
+}
+
+// ListRepositoryOwners mocks base method.
+func (m *MockStore) ListRepositoryOwners(ctx context.Context, arg db.ListRepositoryOwnersParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRepositoryOwners", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRepositoryOwners indicates an expected call of ListRepositoryOwners.
+func (mr *MockStoreMockRecorder) ListRepositoryOwners(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositoryOwners", reflect.TypeOf((*MockStore)(nil).ListRepositoryOwners), ctx, arg)
+}
+
 // ListRules mocks base method.
 func (m *MockStore) ListRules(ctx context.Context) ([]db.Rule, error) {
 	m.ctrl.T.Helper()
@@ -701,6 +1194,21 @@ func (mr *MockStoreMockRecorder) ListRules(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockStore)(nil).ListRules), ctx)
 }
 
+// ListSyncRuns mocks base method.
+func (m *MockStore) ListSyncRuns(ctx context.Context, arg db.ListSyncRunsParams) ([]db.SyncRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSyncRuns", ctx, arg)
+	ret0, _ := ret[0].([]db.SyncRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSyncRuns indicates an expected call of ListSyncRuns.
+func (mr *MockStoreMockRecorder) ListSyncRuns(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSyncRuns", reflect.TypeOf((*MockStore)(nil).ListSyncRuns), ctx, arg)
+}
+
 // ListTagsForEntity mocks base method.
 func (m *MockStore) ListTagsForEntity(ctx context.Context, arg db.ListTagsForEntityParams) ([]db.Tag, error) {
 	m.ctrl.T.Helper()
@@ -732,78 +1240,120 @@ func (mr *MockStoreMockRecorder) ListViews(ctx any) *gomock.Call {
 }
 
 // MarkNotificationFiltered mocks base method.
-func (m *MockStore) MarkNotificationFiltered(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) MarkNotificationFiltered(ctx context.Context, arg db.MarkNotificationFilteredParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationFiltered", ctx, githubID)
+	ret := m.ctrl.Call(m, "MarkNotificationFiltered", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkNotificationFiltered indicates an expected call of MarkNotificationFiltered.
-func (mr *MockStoreMockRecorder) MarkNotificationFiltered(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) MarkNotificationFiltered(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationFiltered", reflect.TypeOf((*MockStore)(nil).MarkNotificationFiltered), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationFiltered", reflect.TypeOf((*MockStore)(nil).MarkNotificationFiltered), ctx, arg)
 }
 
 // MarkNotificationRead mocks base method.
-func (m *MockStore) MarkNotificationRead(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) MarkNotificationRead(ctx context.Context, arg db.MarkNotificationReadParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, githubID)
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkNotificationRead indicates an expected call of MarkNotificationRead.
-func (mr *MockStoreMockRecorder) MarkNotificationRead(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) MarkNotificationRead(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockStore)(nil).MarkNotificationRead), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockStore)(nil).MarkNotificationRead), ctx, arg)
+}
+
+// MarkNotificationSubjectFetched mocks base method.
+func (m *MockStore) MarkNotificationSubjectFetched(ctx context.Context, arg db.MarkNotificationSubjectFetchedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationSubjectFetched", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationSubjectFetched indicates an expected call of MarkNotificationSubjectFetched.
+func (mr *MockStoreMockRecorder) MarkNotificationSubjectFetched(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationSubjectFetched", reflect.TypeOf((*MockStore)(nil).MarkNotificationSubjectFetched), ctx, arg)
 }
 
 // MarkNotificationUnfiltered mocks base method.
-func (m *MockStore) MarkNotificationUnfiltered(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) MarkNotificationUnfiltered(ctx context.Context, arg db.MarkNotificationUnfilteredParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationUnfiltered", ctx, githubID)
+	ret := m.ctrl.Call(m, "MarkNotificationUnfiltered", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkNotificationUnfiltered indicates an expected call of MarkNotificationUnfiltered.
-func (mr *MockStoreMockRecorder) MarkNotificationUnfiltered(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) MarkNotificationUnfiltered(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnfiltered", reflect.TypeOf((*MockStore)(nil).MarkNotificationUnfiltered), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnfiltered", reflect.TypeOf((*MockStore)(nil).MarkNotificationUnfiltered), ctx, arg)
 }
 
 // MarkNotificationUnread mocks base method.
-func (m *MockStore) MarkNotificationUnread(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) MarkNotificationUnread(ctx context.Context, arg db.MarkNotificationUnreadParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkNotificationUnread", ctx, githubID)
+	ret := m.ctrl.Call(m, "MarkNotificationUnread", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkNotificationUnread indicates an expected call of MarkNotificationUnread.
-func (mr *MockStoreMockRecorder) MarkNotificationUnread(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) MarkNotificationUnread(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnread", reflect.TypeOf((*MockStore)(nil).MarkNotificationUnread), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationUnread", reflect.TypeOf((*MockStore)(nil).MarkNotificationUnread), ctx, arg)
+}
+
+// MarkNotificationViewed mocks base method.
+func (m *MockStore) MarkNotificationViewed(ctx context.Context, arg db.MarkNotificationViewedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationViewed", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationViewed indicates an expected call of MarkNotificationViewed.
+func (mr *MockStoreMockRecorder) MarkNotificationViewed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationViewed", reflect.TypeOf((*MockStore)(nil).MarkNotificationViewed), ctx, arg)
 }
 
 // MuteNotification mocks base method.
-func (m *MockStore) MuteNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) MuteNotification(ctx context.Context, arg db.MuteNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MuteNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "MuteNotification", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MuteNotification indicates an expected call of MuteNotification.
-func (mr *MockStoreMockRecorder) MuteNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) MuteNotification(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteNotification", reflect.TypeOf((*MockStore)(nil).MuteNotification), ctx, arg)
+}
+
+// RecordGitHubOutboxFailure mocks base method.
+func (m *MockStore) RecordGitHubOutboxFailure(ctx context.Context, arg db.RecordGitHubOutboxFailureParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordGitHubOutboxFailure", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordGitHubOutboxFailure indicates an expected call of RecordGitHubOutboxFailure.
+func (mr *MockStoreMockRecorder) RecordGitHubOutboxFailure(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteNotification", reflect.TypeOf((*MockStore)(nil).MuteNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordGitHubOutboxFailure", reflect.TypeOf((*MockStore)(nil).RecordGitHubOutboxFailure), ctx, arg)
 }
 
 // RemoveTagAssignment mocks base method.
@@ -836,78 +1386,93 @@ func (mr *MockStoreMockRecorder) SnoozeNotification(ctx, arg any) *gomock.Call {
 }
 
 // StarNotification mocks base method.
-func (m *MockStore) StarNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) StarNotification(ctx context.Context, arg db.StarNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StarNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "StarNotification", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // StarNotification indicates an expected call of StarNotification.
-func (mr *MockStoreMockRecorder) StarNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) StarNotification(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StarNotification", reflect.TypeOf((*MockStore)(nil).StarNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StarNotification", reflect.TypeOf((*MockStore)(nil).StarNotification), ctx, arg)
 }
 
 // UnarchiveNotification mocks base method.
-func (m *MockStore) UnarchiveNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) UnarchiveNotification(ctx context.Context, arg db.UnarchiveNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnarchiveNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnarchiveNotification", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnarchiveNotification indicates an expected call of UnarchiveNotification.
-func (mr *MockStoreMockRecorder) UnarchiveNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) UnarchiveNotification(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNotification", reflect.TypeOf((*MockStore)(nil).UnarchiveNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNotification", reflect.TypeOf((*MockStore)(nil).UnarchiveNotification), ctx, arg)
 }
 
 // UnmuteNotification mocks base method.
-func (m *MockStore) UnmuteNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) UnmuteNotification(ctx context.Context, arg db.UnmuteNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnmuteNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnmuteNotification", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnmuteNotification indicates an expected call of UnmuteNotification.
-func (mr *MockStoreMockRecorder) UnmuteNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) UnmuteNotification(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteNotification", reflect.TypeOf((*MockStore)(nil).UnmuteNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteNotification", reflect.TypeOf((*MockStore)(nil).UnmuteNotification), ctx, arg)
 }
 
 // UnsnoozeNotification mocks base method.
-func (m *MockStore) UnsnoozeNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) UnsnoozeNotification(ctx context.Context, arg db.UnsnoozeNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnsnoozeNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnsnoozeNotification", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnsnoozeNotification indicates an expected call of UnsnoozeNotification.
-func (mr *MockStoreMockRecorder) UnsnoozeNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) UnsnoozeNotification(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsnoozeNotification", reflect.TypeOf((*MockStore)(nil).UnsnoozeNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsnoozeNotification", reflect.TypeOf((*MockStore)(nil).UnsnoozeNotification), ctx, arg)
 }
 
 // UnstarNotification mocks base method.
-func (m *MockStore) UnstarNotification(ctx context.Context, githubID string) (db.Notification, error) {
+func (m *MockStore) UnstarNotification(ctx context.Context, arg db.UnstarNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnstarNotification", ctx, githubID)
+	ret := m.ctrl.Call(m, "UnstarNotification", ctx, arg)
 	ret0, _ := ret[0].(db.Notification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnstarNotification indicates an expected call of UnstarNotification.
-func (mr *MockStoreMockRecorder) UnstarNotification(ctx, githubID any) *gomock.Call {
+func (mr *MockStoreMockRecorder) UnstarNotification(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarNotification", reflect.TypeOf((*MockStore)(nil).UnstarNotification), ctx, arg)
+}
+
+// UpdateGitHubAccountSyncSettings mocks base method.
+func (m *MockStore) UpdateGitHubAccountSyncSettings(ctx context.Context, arg db.UpdateGitHubAccountSyncSettingsParams) (db.GithubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGitHubAccountSyncSettings", ctx, arg)
+	ret0, _ := ret[0].(db.GithubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGitHubAccountSyncSettings indicates an expected call of UpdateGitHubAccountSyncSettings.
+func (mr *MockStoreMockRecorder) UpdateGitHubAccountSyncSettings(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstarNotification", reflect.TypeOf((*MockStore)(nil).UnstarNotification), ctx, githubID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubAccountSyncSettings", reflect.TypeOf((*MockStore)(nil).UpdateGitHubAccountSyncSettings), ctx, arg)
 }
 
 // UpdateNotificationSubject mocks base method.
@@ -938,6 +1503,21 @@ func (mr *MockStoreMockRecorder) UpdateNotificationTagIds(ctx, notificationID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationTagIds", reflect.TypeOf((*MockStore)(nil).UpdateNotificationTagIds), ctx, notificationID)
 }
 
+// UpdateQueryMacro mocks base method.
+func (m *MockStore) UpdateQueryMacro(ctx context.Context, arg db.UpdateQueryMacroParams) (db.QueryMacro, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQueryMacro", ctx, arg)
+	ret0, _ := ret[0].(db.QueryMacro)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateQueryMacro indicates an expected call of UpdateQueryMacro.
+func (mr *MockStoreMockRecorder) UpdateQueryMacro(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQueryMacro", reflect.TypeOf((*MockStore)(nil).UpdateQueryMacro), ctx, arg)
+}
+
 // UpdateRule mocks base method.
 func (m *MockStore) UpdateRule(ctx context.Context, arg db.UpdateRuleParams) (db.Rule, error) {
 	m.ctrl.T.Helper()
@@ -967,6 +1547,34 @@ func (mr *MockStoreMockRecorder) UpdateRuleOrder(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRuleOrder", reflect.TypeOf((*MockStore)(nil).UpdateRuleOrder), ctx, arg)
 }
 
+// UpdateSyncStateRateLimit mocks base method.
+func (m *MockStore) UpdateSyncStateRateLimit(ctx context.Context, arg db.UpdateSyncStateRateLimitParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncStateRateLimit", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncStateRateLimit indicates an expected call of UpdateSyncStateRateLimit.
+func (mr *MockStoreMockRecorder) UpdateSyncStateRateLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncStateRateLimit", reflect.TypeOf((*MockStore)(nil).UpdateSyncStateRateLimit), ctx, arg)
+}
+
+// UpdateSyncStateReconciliation mocks base method.
+func (m *MockStore) UpdateSyncStateReconciliation(ctx context.Context, arg db.UpdateSyncStateReconciliationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSyncStateReconciliation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSyncStateReconciliation indicates an expected call of UpdateSyncStateReconciliation.
+func (mr *MockStoreMockRecorder) UpdateSyncStateReconciliation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSyncStateReconciliation", reflect.TypeOf((*MockStore)(nil).UpdateSyncStateReconciliation), ctx, arg)
+}
+
 // UpdateTag mocks base method.
 func (m *MockStore) UpdateTag(ctx context.Context, arg db.UpdateTagParams) (db.Tag, error) {
 	m.ctrl.T.Helper()
@@ -1085,6 +1693,21 @@ func (mr *MockStoreMockRecorder) UpdateViewOrder(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateViewOrder", reflect.TypeOf((*MockStore)(nil).UpdateViewOrder), ctx, arg)
 }
 
+// UpsertGitHubAccount mocks base method.
+func (m *MockStore) UpsertGitHubAccount(ctx context.Context, arg db.UpsertGitHubAccountParams) (db.GithubAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertGitHubAccount", ctx, arg)
+	ret0, _ := ret[0].(db.GithubAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertGitHubAccount indicates an expected call of UpsertGitHubAccount.
+func (mr *MockStoreMockRecorder) UpsertGitHubAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertGitHubAccount", reflect.TypeOf((*MockStore)(nil).UpsertGitHubAccount), ctx, arg)
+}
+
 // UpsertNotification mocks base method.
 func (m *MockStore) UpsertNotification(ctx context.Context, arg db.UpsertNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -12,6 +12,27 @@ import (
 	"github.com/sqlc-dev/pqtype"
 )
 
+type GithubAccount struct {
+	ID           int64
+	Name         string
+	ApiUrl       string
+	WebUrl       string
+	SyncSettings pqtype.NullRawMessage
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type GithubOutbox struct {
+	ID          int64
+	GithubID    string
+	Action      string
+	Attempts    int32
+	LastError   sql.NullString
+	CreatedAt   time.Time
+	CompletedAt sql.NullTime
+	AccountID   int64
+}
+
 type Notification struct {
 	ID                      int64
 	GithubID                string
@@ -46,6 +67,25 @@ type Notification struct {
 	SubjectState            sql.NullString
 	SubjectMerged           sql.NullBool
 	SubjectStateReason      sql.NullString
+	SubjectCreatedAt        sql.NullTime
+	SearchVector            interface{}
+	SubjectDraft            sql.NullBool
+	SubjectReviewDecision   sql.NullString
+	SubjectReviewRequested  []string
+	SubjectChecksStatus     sql.NullString
+	SubjectLabels           []string
+	SubjectMilestone        sql.NullString
+	SubjectAssignees        []string
+	SubjectComments         sql.NullInt32
+	AccountID               int64
+	SubjectCategory         sql.NullString
+	SubjectAnswered         sql.NullBool
+	SubjectTag              sql.NullString
+	SubjectConclusion       sql.NullString
+	SubjectSha              sql.NullString
+	SubjectSeverity         sql.NullString
+	StaleAt                 sql.NullTime
+	ViewedAt                sql.NullTime
 }
 
 type PullRequest struct {
@@ -67,6 +107,14 @@ type PullRequest struct {
 	Raw          pqtype.NullRawMessage
 }
 
+type QueryMacro struct {
+	ID        int64
+	Name      string
+	Query     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type Repository struct {
 	ID             int64
 	GithubID       sql.NullInt64
@@ -89,6 +137,7 @@ type Repository struct {
 	Raw            pqtype.NullRawMessage
 	OwnerAvatarUrl sql.NullString
 	OwnerHtmlUrl   sql.NullString
+	AccountID      int64
 }
 
 type Rule struct {
@@ -104,6 +153,24 @@ type Rule struct {
 	ViewID       sql.NullInt64
 }
 
+type SyncRun struct {
+	ID                   int64
+	AccountID            int64
+	Kind                 string
+	StartedAt            time.Time
+	FinishedAt           sql.NullTime
+	WindowSince          sql.NullTime
+	WindowBefore         sql.NullTime
+	NotModified          bool
+	ThreadsFetched       int32
+	JobsQueued           int32
+	SubjectFetchFailures int32
+	Error                sql.NullString
+	RateLimited          bool
+	RateLimitRemaining   sql.NullInt32
+	RateLimitResetAt     sql.NullTime
+}
+
 type SyncState struct {
 	ID                         int32
 	LastSuccessfulPoll         sql.NullTime
@@ -113,6 +180,18 @@ type SyncState struct {
 	LatestNotificationAt       sql.NullTime
 	InitialSyncCompletedAt     sql.NullTime
 	OldestNotificationSyncedAt sql.NullTime
+	LastNotificationModified   sql.NullString
+	RateLimitLimit             sql.NullInt32
+	RateLimitRemaining         sql.NullInt32
+	RateLimitResetAt           sql.NullTime
+	RateLimitUpdatedAt         sql.NullTime
+	ReconciledAt               sql.NullTime
+	ReconcilePolicy            sql.NullString
+	ReconcileChecked           sql.NullInt32
+	ReconcileArchived          sql.NullInt32
+	ReconcileFlagged           sql.NullInt32
+	ReconcileDeleted           sql.NullInt32
+	ReconcileUnflagged         sql.NullInt32
 }
 
 type Tag struct {
@@ -19,12 +19,18 @@ SET archived = TRUE,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) ArchiveNotification(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, archiveNotification, githubID)
+type ArchiveNotificationParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) ArchiveNotification(ctx context.Context, arg ArchiveNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, archiveNotification, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -60,6 +66,25 @@ func (q *Queries) ArchiveNotification(ctx context.Context, githubID string) (Not
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -70,11 +95,67 @@ SET archived = true,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
+`
+
+type BulkArchiveNotificationsParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkArchiveNotifications(ctx context.Context, arg BulkArchiveNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkArchiveNotifications, arg.AccountID, pq.Array(arg.GithubIds))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const bulkClearNotificationsStale = `-- name: BulkClearNotificationsStale :execrows
+UPDATE notifications
+SET stale_at = NULL
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
+  AND stale_at IS NOT NULL
 `
 
-func (q *Queries) BulkArchiveNotifications(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkArchiveNotifications, pq.Array(githubIds))
+type BulkClearNotificationsStaleParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkClearNotificationsStale(ctx context.Context, arg BulkClearNotificationsStaleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkClearNotificationsStale, arg.AccountID, pq.Array(arg.GithubIds))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const bulkDeleteNotifications = `-- name: BulkDeleteNotifications :execrows
+WITH deleted_tag_assignments AS (
+    DELETE FROM tag_assignments
+    WHERE entity_type = 'notification'
+      AND entity_id IN (
+          SELECT id
+          FROM notifications
+          WHERE account_id = $1
+            AND github_id = ANY($2::text[])
+      )
+)
+DELETE FROM notifications
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
+`
+
+type BulkDeleteNotificationsParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkDeleteNotifications(ctx context.Context, arg BulkDeleteNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkDeleteNotifications, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -84,11 +165,17 @@ func (q *Queries) BulkArchiveNotifications(ctx context.Context, githubIds []stri
 const bulkMarkNotificationsFiltered = `-- name: BulkMarkNotificationsFiltered :execrows
 UPDATE notifications
 SET filtered = TRUE
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkMarkNotificationsFiltered(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsFiltered, pq.Array(githubIds))
+type BulkMarkNotificationsFilteredParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkMarkNotificationsFiltered(ctx context.Context, arg BulkMarkNotificationsFilteredParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsFiltered, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -98,11 +185,38 @@ func (q *Queries) BulkMarkNotificationsFiltered(ctx context.Context, githubIds [
 const bulkMarkNotificationsRead = `-- name: BulkMarkNotificationsRead :execrows
 UPDATE notifications
 SET is_read = true
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkMarkNotificationsRead(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsRead, pq.Array(githubIds))
+type BulkMarkNotificationsReadParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkMarkNotificationsRead(ctx context.Context, arg BulkMarkNotificationsReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsRead, arg.AccountID, pq.Array(arg.GithubIds))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const bulkMarkNotificationsStale = `-- name: BulkMarkNotificationsStale :execrows
+UPDATE notifications
+SET stale_at = now()
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
+  AND stale_at IS NULL
+`
+
+type BulkMarkNotificationsStaleParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkMarkNotificationsStale(ctx context.Context, arg BulkMarkNotificationsStaleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsStale, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -112,11 +226,17 @@ func (q *Queries) BulkMarkNotificationsRead(ctx context.Context, githubIds []str
 const bulkMarkNotificationsUnfiltered = `-- name: BulkMarkNotificationsUnfiltered :execrows
 UPDATE notifications
 SET filtered = FALSE
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkMarkNotificationsUnfiltered(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsUnfiltered, pq.Array(githubIds))
+type BulkMarkNotificationsUnfilteredParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkMarkNotificationsUnfiltered(ctx context.Context, arg BulkMarkNotificationsUnfilteredParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsUnfiltered, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -126,11 +246,17 @@ func (q *Queries) BulkMarkNotificationsUnfiltered(ctx context.Context, githubIds
 const bulkMarkNotificationsUnread = `-- name: BulkMarkNotificationsUnread :execrows
 UPDATE notifications
 SET is_read = false
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkMarkNotificationsUnread(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsUnread, pq.Array(githubIds))
+type BulkMarkNotificationsUnreadParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkMarkNotificationsUnread(ctx context.Context, arg BulkMarkNotificationsUnreadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkMarkNotificationsUnread, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -143,11 +269,17 @@ SET muted = true,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkMuteNotifications(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkMuteNotifications, pq.Array(githubIds))
+type BulkMuteNotificationsParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkMuteNotifications(ctx context.Context, arg BulkMuteNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkMuteNotifications, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -159,16 +291,18 @@ UPDATE notifications
 SET snoozed_until = $1,
     snoozed_at = NOW(),
     effective_sort_date = $1
-WHERE github_id = ANY($2::text[])
+WHERE account_id = $2
+  AND github_id = ANY($3::text[])
 `
 
 type BulkSnoozeNotificationsParams struct {
 	SnoozedUntil sql.NullTime
+	AccountID    int64
 	GithubIds    []string
 }
 
 func (q *Queries) BulkSnoozeNotifications(ctx context.Context, arg BulkSnoozeNotificationsParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkSnoozeNotifications, arg.SnoozedUntil, pq.Array(arg.GithubIds))
+	result, err := q.db.ExecContext(ctx, bulkSnoozeNotifications, arg.SnoozedUntil, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -178,11 +312,17 @@ func (q *Queries) BulkSnoozeNotifications(ctx context.Context, arg BulkSnoozeNot
 const bulkStarNotifications = `-- name: BulkStarNotifications :execrows
 UPDATE notifications
 SET starred = TRUE
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkStarNotifications(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkStarNotifications, pq.Array(githubIds))
+type BulkStarNotificationsParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkStarNotifications(ctx context.Context, arg BulkStarNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkStarNotifications, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -192,11 +332,17 @@ func (q *Queries) BulkStarNotifications(ctx context.Context, githubIds []string)
 const bulkUnarchiveNotifications = `-- name: BulkUnarchiveNotifications :execrows
 UPDATE notifications
 SET archived = false
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkUnarchiveNotifications(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkUnarchiveNotifications, pq.Array(githubIds))
+type BulkUnarchiveNotificationsParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkUnarchiveNotifications(ctx context.Context, arg BulkUnarchiveNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkUnarchiveNotifications, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -206,11 +352,17 @@ func (q *Queries) BulkUnarchiveNotifications(ctx context.Context, githubIds []st
 const bulkUnmuteNotifications = `-- name: BulkUnmuteNotifications :execrows
 UPDATE notifications
 SET muted = false
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkUnmuteNotifications(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkUnmuteNotifications, pq.Array(githubIds))
+type BulkUnmuteNotificationsParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkUnmuteNotifications(ctx context.Context, arg BulkUnmuteNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkUnmuteNotifications, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -222,11 +374,17 @@ UPDATE notifications
 SET snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkUnsnoozeNotifications(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkUnsnoozeNotifications, pq.Array(githubIds))
+type BulkUnsnoozeNotificationsParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkUnsnoozeNotifications(ctx context.Context, arg BulkUnsnoozeNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkUnsnoozeNotifications, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -236,11 +394,17 @@ func (q *Queries) BulkUnsnoozeNotifications(ctx context.Context, githubIds []str
 const bulkUnstarNotifications = `-- name: BulkUnstarNotifications :execrows
 UPDATE notifications
 SET starred = FALSE
-WHERE github_id = ANY($1::text[])
+WHERE account_id = $1
+  AND github_id = ANY($2::text[])
 `
 
-func (q *Queries) BulkUnstarNotifications(ctx context.Context, githubIds []string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, bulkUnstarNotifications, pq.Array(githubIds))
+type BulkUnstarNotificationsParams struct {
+	AccountID int64
+	GithubIds []string
+}
+
+func (q *Queries) BulkUnstarNotifications(ctx context.Context, arg BulkUnstarNotificationsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkUnstarNotifications, arg.AccountID, pq.Array(arg.GithubIds))
 	if err != nil {
 		return 0, err
 	}
@@ -248,13 +412,19 @@ func (q *Queries) BulkUnstarNotifications(ctx context.Context, githubIds []strin
 }
 
 const getNotificationByGithubID = `-- name: GetNotificationByGithubID :one
-SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 FROM notifications
-WHERE github_id = $1
+WHERE account_id = $1
+  AND github_id = $2
 `
 
-func (q *Queries) GetNotificationByGithubID(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, getNotificationByGithubID, githubID)
+type GetNotificationByGithubIDParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) GetNotificationByGithubID(ctx context.Context, arg GetNotificationByGithubIDParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationByGithubID, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -290,12 +460,31 @@ func (q *Queries) GetNotificationByGithubID(ctx context.Context, githubID string
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
 
 const getNotificationByID = `-- name: GetNotificationByID :one
-SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 FROM notifications
 WHERE id = $1
 `
@@ -337,12 +526,257 @@ func (q *Queries) GetNotificationByID(ctx context.Context, id int64) (Notificati
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
 
+const listNotificationAuthorLogins = `-- name: ListNotificationAuthorLogins :many
+SELECT author_login::text AS author_login
+FROM notifications
+WHERE author_login IS NOT NULL
+  AND strpos(lower(author_login), lower($1::text)) > 0
+GROUP BY author_login
+ORDER BY strpos(lower(author_login), lower($1::text)), author_login
+LIMIT $2
+`
+
+type ListNotificationAuthorLoginsParams struct {
+	Search   string
+	RowLimit int32
+}
+
+func (q *Queries) ListNotificationAuthorLogins(ctx context.Context, arg ListNotificationAuthorLoginsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationAuthorLogins, arg.Search, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var authorLogin string
+		if err := rows.Scan(&authorLogin); err != nil {
+			return nil, err
+		}
+		items = append(items, authorLogin)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationCategories = `-- name: ListNotificationCategories :many
+SELECT subject_category::text AS category
+FROM notifications
+WHERE subject_category IS NOT NULL
+  AND strpos(lower(subject_category), lower($1::text)) > 0
+GROUP BY subject_category
+ORDER BY strpos(lower(subject_category), lower($1::text)), subject_category
+LIMIT $2
+`
+
+type ListNotificationCategoriesParams struct {
+	Search   string
+	RowLimit int32
+}
+
+func (q *Queries) ListNotificationCategories(ctx context.Context, arg ListNotificationCategoriesParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationCategories, arg.Search, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var category string
+		if err := rows.Scan(&category); err != nil {
+			return nil, err
+		}
+		items = append(items, category)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationLabels = `-- name: ListNotificationLabels :many
+SELECT label::text AS label
+FROM notifications, unnest(subject_labels) AS label
+WHERE strpos(label, lower($1::text)) > 0
+GROUP BY label
+ORDER BY strpos(label, lower($1::text)), label
+LIMIT $2
+`
+
+type ListNotificationLabelsParams struct {
+	Search   string
+	RowLimit int32
+}
+
+func (q *Queries) ListNotificationLabels(ctx context.Context, arg ListNotificationLabelsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationLabels, arg.Search, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, err
+		}
+		items = append(items, label)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationMilestones = `-- name: ListNotificationMilestones :many
+SELECT subject_milestone::text AS milestone
+FROM notifications
+WHERE subject_milestone IS NOT NULL
+  AND strpos(lower(subject_milestone), lower($1::text)) > 0
+GROUP BY subject_milestone
+ORDER BY strpos(lower(subject_milestone), lower($1::text)), subject_milestone
+LIMIT $2
+`
+
+type ListNotificationMilestonesParams struct {
+	Search   string
+	RowLimit int32
+}
+
+func (q *Queries) ListNotificationMilestones(ctx context.Context, arg ListNotificationMilestonesParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationMilestones, arg.Search, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var milestone string
+		if err := rows.Scan(&milestone); err != nil {
+			return nil, err
+		}
+		items = append(items, milestone)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationReasons = `-- name: ListNotificationReasons :many
+SELECT reason::text AS reason
+FROM notifications
+WHERE reason IS NOT NULL
+  AND strpos(lower(reason), lower($1::text)) > 0
+GROUP BY reason
+ORDER BY strpos(lower(reason), lower($1::text)), reason
+LIMIT $2
+`
+
+type ListNotificationReasonsParams struct {
+	Search   string
+	RowLimit int32
+}
+
+func (q *Queries) ListNotificationReasons(ctx context.Context, arg ListNotificationReasonsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationReasons, arg.Search, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var reason string
+		if err := rows.Scan(&reason); err != nil {
+			return nil, err
+		}
+		items = append(items, reason)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationSubjectTypes = `-- name: ListNotificationSubjectTypes :many
+SELECT subject_type
+FROM notifications
+WHERE strpos(lower(subject_type), lower($1::text)) > 0
+GROUP BY subject_type
+ORDER BY strpos(lower(subject_type), lower($1::text)), subject_type
+LIMIT $2
+`
+
+type ListNotificationSubjectTypesParams struct {
+	Search   string
+	RowLimit int32
+}
+
+func (q *Queries) ListNotificationSubjectTypes(ctx context.Context, arg ListNotificationSubjectTypesParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationSubjectTypes, arg.Search, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var subjectType string
+		if err := rows.Scan(&subjectType); err != nil {
+			return nil, err
+		}
+		items = append(items, subjectType)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listNotifications = `-- name: ListNotifications :many
-SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 FROM notifications
 ORDER BY github_updated_at DESC NULLS LAST, imported_at DESC
 `
@@ -390,6 +824,108 @@ func (q *Queries) ListNotifications(ctx context.Context) ([]Notification, error)
 			&i.SubjectState,
 			&i.SubjectMerged,
 			&i.SubjectStateReason,
+			&i.SubjectCreatedAt,
+			&i.SearchVector,
+			&i.SubjectDraft,
+			&i.SubjectReviewDecision,
+			pq.Array(&i.SubjectReviewRequested),
+			&i.SubjectChecksStatus,
+			pq.Array(&i.SubjectLabels),
+			&i.SubjectMilestone,
+			pq.Array(&i.SubjectAssignees),
+			&i.SubjectComments,
+			&i.AccountID,
+			&i.SubjectCategory,
+			&i.SubjectAnswered,
+			&i.SubjectTag,
+			&i.SubjectConclusion,
+			&i.SubjectSha,
+			&i.SubjectSeverity,
+			&i.StaleAt,
+			&i.ViewedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationsBySubjectURLs = `-- name: ListNotificationsBySubjectURLs :many
+SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
+FROM notifications
+WHERE subject_url = ANY($1::text[])
+ORDER BY github_updated_at DESC NULLS LAST, imported_at DESC
+`
+
+func (q *Queries) ListNotificationsBySubjectURLs(ctx context.Context, subjectUrls []string) ([]Notification, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationsBySubjectURLs, pq.Array(subjectUrls))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Notification
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.GithubID,
+			&i.RepositoryID,
+			&i.PullRequestID,
+			&i.SubjectType,
+			&i.SubjectTitle,
+			&i.SubjectUrl,
+			&i.SubjectLatestCommentUrl,
+			&i.Reason,
+			&i.Archived,
+			&i.GithubUnread,
+			&i.GithubUpdatedAt,
+			&i.GithubLastReadAt,
+			&i.GithubUrl,
+			&i.GithubSubscriptionUrl,
+			&i.ImportedAt,
+			&i.Payload,
+			&i.SubjectRaw,
+			&i.SubjectFetchedAt,
+			&i.AuthorLogin,
+			&i.AuthorID,
+			&i.IsRead,
+			&i.Muted,
+			&i.SnoozedUntil,
+			&i.EffectiveSortDate,
+			&i.SnoozedAt,
+			&i.Starred,
+			&i.Filtered,
+			pq.Array(&i.TagIds),
+			&i.SubjectNumber,
+			&i.SubjectState,
+			&i.SubjectMerged,
+			&i.SubjectStateReason,
+			&i.SubjectCreatedAt,
+			&i.SearchVector,
+			&i.SubjectDraft,
+			&i.SubjectReviewDecision,
+			pq.Array(&i.SubjectReviewRequested),
+			&i.SubjectChecksStatus,
+			pq.Array(&i.SubjectLabels),
+			&i.SubjectMilestone,
+			pq.Array(&i.SubjectAssignees),
+			&i.SubjectComments,
+			&i.AccountID,
+			&i.SubjectCategory,
+			&i.SubjectAnswered,
+			&i.SubjectTag,
+			&i.SubjectConclusion,
+			&i.SubjectSha,
+			&i.SubjectSeverity,
+			&i.StaleAt,
+			&i.ViewedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -405,7 +941,7 @@ func (q *Queries) ListNotifications(ctx context.Context) ([]Notification, error)
 }
 
 const listNotificationsForRepository = `-- name: ListNotificationsForRepository :many
-SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+SELECT id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 FROM notifications
 WHERE repository_id = $1
 ORDER BY github_updated_at DESC NULLS LAST, imported_at DESC
@@ -454,6 +990,76 @@ func (q *Queries) ListNotificationsForRepository(ctx context.Context, repository
 			&i.SubjectState,
 			&i.SubjectMerged,
 			&i.SubjectStateReason,
+			&i.SubjectCreatedAt,
+			&i.SearchVector,
+			&i.SubjectDraft,
+			&i.SubjectReviewDecision,
+			pq.Array(&i.SubjectReviewRequested),
+			&i.SubjectChecksStatus,
+			pq.Array(&i.SubjectLabels),
+			&i.SubjectMilestone,
+			pq.Array(&i.SubjectAssignees),
+			&i.SubjectComments,
+			&i.AccountID,
+			&i.SubjectCategory,
+			&i.SubjectAnswered,
+			&i.SubjectTag,
+			&i.SubjectConclusion,
+			&i.SubjectSha,
+			&i.SubjectSeverity,
+			&i.StaleAt,
+			&i.ViewedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationsToReconcile = `-- name: ListNotificationsToReconcile :many
+SELECT id, github_id, github_updated_at, stale_at
+FROM notifications
+WHERE account_id = $1
+  AND archived = FALSE
+  AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListNotificationsToReconcileParams struct {
+	AccountID int64
+	AfterID   int64
+	RowLimit  int32
+}
+
+type ListNotificationsToReconcileRow struct {
+	ID              int64
+	GithubID        string
+	GithubUpdatedAt sql.NullTime
+	StaleAt         sql.NullTime
+}
+
+func (q *Queries) ListNotificationsToReconcile(ctx context.Context, arg ListNotificationsToReconcileParams) ([]ListNotificationsToReconcileRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationsToReconcile, arg.AccountID, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNotificationsToReconcileRow
+	for rows.Next() {
+		var i ListNotificationsToReconcileRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GithubID,
+			&i.GithubUpdatedAt,
+			&i.StaleAt,
 		); err != nil {
 			return nil, err
 		}
@@ -468,15 +1074,72 @@ func (q *Queries) ListNotificationsForRepository(ctx context.Context, repository
 	return items, nil
 }
 
+const listNotificationsWithStaleSubjects = `-- name: ListNotificationsWithStaleSubjects :many
+SELECT github_id
+FROM notifications
+WHERE account_id = $1
+  AND (subject_fetched_at IS NULL OR subject_fetched_at < $2)
+  AND lower(replace(subject_type, '_', '')) <> 'checkrun'
+  AND (
+      starred = TRUE
+      OR (
+          archived = FALSE
+          AND muted = FALSE
+          AND filtered = FALSE
+          AND (snoozed_until IS NULL OR snoozed_until <= now())
+      )
+  )
+ORDER BY viewed_at DESC NULLS LAST, subject_fetched_at NULLS FIRST
+LIMIT $3
+`
+
+type ListNotificationsWithStaleSubjectsParams struct {
+	AccountID     int64
+	FetchedBefore sql.NullTime
+	RowLimit      int32
+}
+
+// Inbox and starred notifications whose subject was fetched before fetched_before, most
+// recently viewed first. Subjects never fetched count as stale. Check runs can't be
+// refreshed on their own.
+func (q *Queries) ListNotificationsWithStaleSubjects(ctx context.Context, arg ListNotificationsWithStaleSubjectsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationsWithStaleSubjects, arg.AccountID, arg.FetchedBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var githubID string
+		if err := rows.Scan(&githubID); err != nil {
+			return nil, err
+		}
+		items = append(items, githubID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markNotificationFiltered = `-- name: MarkNotificationFiltered :one
 UPDATE notifications
 SET filtered = TRUE
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) MarkNotificationFiltered(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, markNotificationFiltered, githubID)
+type MarkNotificationFilteredParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) MarkNotificationFiltered(ctx context.Context, arg MarkNotificationFilteredParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, markNotificationFiltered, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -512,6 +1175,25 @@ func (q *Queries) MarkNotificationFiltered(ctx context.Context, githubID string)
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -519,12 +1201,18 @@ func (q *Queries) MarkNotificationFiltered(ctx context.Context, githubID string)
 const markNotificationRead = `-- name: MarkNotificationRead :one
 UPDATE notifications
 SET is_read = true
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) MarkNotificationRead(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, markNotificationRead, githubID)
+type MarkNotificationReadParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, markNotificationRead, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -560,19 +1248,64 @@ func (q *Queries) MarkNotificationRead(ctx context.Context, githubID string) (No
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
 
+const markNotificationSubjectFetched = `-- name: MarkNotificationSubjectFetched :exec
+UPDATE notifications
+SET subject_fetched_at = $1
+WHERE account_id = $2
+  AND github_id = $3
+`
+
+type MarkNotificationSubjectFetchedParams struct {
+	FetchedAt sql.NullTime
+	AccountID int64
+	GithubID  string
+}
+
+// Sets subject_fetched_at without changing the subject, so a subject that failed to
+// refresh waits for the next refresh instead of being retried first.
+func (q *Queries) MarkNotificationSubjectFetched(ctx context.Context, arg MarkNotificationSubjectFetchedParams) error {
+	_, err := q.db.ExecContext(ctx, markNotificationSubjectFetched, arg.FetchedAt, arg.AccountID, arg.GithubID)
+	return err
+}
+
 const markNotificationUnfiltered = `-- name: MarkNotificationUnfiltered :one
 UPDATE notifications
 SET filtered = FALSE
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) MarkNotificationUnfiltered(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, markNotificationUnfiltered, githubID)
+type MarkNotificationUnfilteredParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) MarkNotificationUnfiltered(ctx context.Context, arg MarkNotificationUnfilteredParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, markNotificationUnfiltered, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -608,6 +1341,25 @@ func (q *Queries) MarkNotificationUnfiltered(ctx context.Context, githubID strin
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -615,12 +1367,18 @@ func (q *Queries) MarkNotificationUnfiltered(ctx context.Context, githubID strin
 const markNotificationUnread = `-- name: MarkNotificationUnread :one
 UPDATE notifications
 SET is_read = false
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) MarkNotificationUnread(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, markNotificationUnread, githubID)
+type MarkNotificationUnreadParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) MarkNotificationUnread(ctx context.Context, arg MarkNotificationUnreadParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, markNotificationUnread, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -656,22 +1414,64 @@ func (q *Queries) MarkNotificationUnread(ctx context.Context, githubID string) (
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
 
+const markNotificationViewed = `-- name: MarkNotificationViewed :exec
+UPDATE notifications
+SET viewed_at = now()
+WHERE account_id = $1
+  AND github_id = $2
+`
+
+type MarkNotificationViewedParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) MarkNotificationViewed(ctx context.Context, arg MarkNotificationViewedParams) error {
+	_, err := q.db.ExecContext(ctx, markNotificationViewed, arg.AccountID, arg.GithubID)
+	return err
+}
+
 const muteNotification = `-- name: MuteNotification :one
 UPDATE notifications
 SET muted = true,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) MuteNotification(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, muteNotification, githubID)
+type MuteNotificationParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) MuteNotification(ctx context.Context, arg MuteNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, muteNotification, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -707,6 +1507,25 @@ func (q *Queries) MuteNotification(ctx context.Context, githubID string) (Notifi
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -716,17 +1535,19 @@ UPDATE notifications
 SET snoozed_until = $1,
     snoozed_at = NOW(),
     effective_sort_date = $1
-WHERE github_id = $2
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $2
+  AND github_id = $3
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
 type SnoozeNotificationParams struct {
 	SnoozedUntil sql.NullTime
+	AccountID    int64
 	GithubID     string
 }
 
 func (q *Queries) SnoozeNotification(ctx context.Context, arg SnoozeNotificationParams) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, snoozeNotification, arg.SnoozedUntil, arg.GithubID)
+	row := q.db.QueryRowContext(ctx, snoozeNotification, arg.SnoozedUntil, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -762,6 +1583,25 @@ func (q *Queries) SnoozeNotification(ctx context.Context, arg SnoozeNotification
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -769,12 +1609,18 @@ func (q *Queries) SnoozeNotification(ctx context.Context, arg SnoozeNotification
 const starNotification = `-- name: StarNotification :one
 UPDATE notifications
 SET starred = TRUE
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) StarNotification(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, starNotification, githubID)
+type StarNotificationParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) StarNotification(ctx context.Context, arg StarNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, starNotification, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -810,6 +1656,25 @@ func (q *Queries) StarNotification(ctx context.Context, githubID string) (Notifi
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -817,12 +1682,18 @@ func (q *Queries) StarNotification(ctx context.Context, githubID string) (Notifi
 const unarchiveNotification = `-- name: UnarchiveNotification :one
 UPDATE notifications
 SET archived = FALSE
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) UnarchiveNotification(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, unarchiveNotification, githubID)
+type UnarchiveNotificationParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) UnarchiveNotification(ctx context.Context, arg UnarchiveNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, unarchiveNotification, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -858,6 +1729,25 @@ func (q *Queries) UnarchiveNotification(ctx context.Context, githubID string) (N
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -865,12 +1755,18 @@ func (q *Queries) UnarchiveNotification(ctx context.Context, githubID string) (N
 const unmuteNotification = `-- name: UnmuteNotification :one
 UPDATE notifications
 SET muted = false
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) UnmuteNotification(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, unmuteNotification, githubID)
+type UnmuteNotificationParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) UnmuteNotification(ctx context.Context, arg UnmuteNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, unmuteNotification, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -906,6 +1802,25 @@ func (q *Queries) UnmuteNotification(ctx context.Context, githubID string) (Noti
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -915,12 +1830,18 @@ UPDATE notifications
 SET snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) UnsnoozeNotification(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, unsnoozeNotification, githubID)
+type UnsnoozeNotificationParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) UnsnoozeNotification(ctx context.Context, arg UnsnoozeNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, unsnoozeNotification, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -956,6 +1877,25 @@ func (q *Queries) UnsnoozeNotification(ctx context.Context, githubID string) (No
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -963,12 +1903,18 @@ func (q *Queries) UnsnoozeNotification(ctx context.Context, githubID string) (No
 const unstarNotification = `-- name: UnstarNotification :one
 UPDATE notifications
 SET starred = FALSE
-WHERE github_id = $1
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+WHERE account_id = $1
+  AND github_id = $2
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
-func (q *Queries) UnstarNotification(ctx context.Context, githubID string) (Notification, error) {
-	row := q.db.QueryRowContext(ctx, unstarNotification, githubID)
+type UnstarNotificationParams struct {
+	AccountID int64
+	GithubID  string
+}
+
+func (q *Queries) UnstarNotification(ctx context.Context, arg UnstarNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, unstarNotification, arg.AccountID, arg.GithubID)
 	var i Notification
 	err := row.Scan(
 		&i.ID,
@@ -1004,6 +1950,25 @@ func (q *Queries) UnstarNotification(ctx context.Context, githubID string) (Noti
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -1016,19 +1981,51 @@ SET subject_raw = $1,
     subject_number = $4,
     subject_state = $5,
     subject_merged = $6,
-    subject_state_reason = $7
-WHERE github_id = $8
+    subject_state_reason = $7,
+    subject_created_at = $8,
+    subject_draft = $9,
+    subject_review_decision = $10,
+    subject_review_requested = $11,
+    subject_checks_status = $12,
+    subject_labels = $13,
+    subject_milestone = $14,
+    subject_assignees = $15,
+    subject_comments = $16,
+    subject_category = $17,
+    subject_answered = $18,
+    subject_tag = $19,
+    subject_conclusion = $20,
+    subject_sha = $21,
+    subject_severity = $22
+WHERE account_id = $23
+  AND github_id = $24
 `
 
 type UpdateNotificationSubjectParams struct {
-	SubjectRaw         pqtype.NullRawMessage
-	SubjectFetchedAt   sql.NullTime
-	PullRequestID      sql.NullInt64
-	SubjectNumber      sql.NullInt32
-	SubjectState       sql.NullString
-	SubjectMerged      sql.NullBool
-	SubjectStateReason sql.NullString
-	GithubID           string
+	SubjectRaw             pqtype.NullRawMessage
+	SubjectFetchedAt       sql.NullTime
+	PullRequestID          sql.NullInt64
+	SubjectNumber          sql.NullInt32
+	SubjectState           sql.NullString
+	SubjectMerged          sql.NullBool
+	SubjectStateReason     sql.NullString
+	SubjectCreatedAt       sql.NullTime
+	SubjectDraft           sql.NullBool
+	SubjectReviewDecision  sql.NullString
+	SubjectReviewRequested []string
+	SubjectChecksStatus    sql.NullString
+	SubjectLabels          []string
+	SubjectMilestone       sql.NullString
+	SubjectAssignees       []string
+	SubjectComments        sql.NullInt32
+	SubjectCategory        sql.NullString
+	SubjectAnswered        sql.NullBool
+	SubjectTag             sql.NullString
+	SubjectConclusion      sql.NullString
+	SubjectSha             sql.NullString
+	SubjectSeverity        sql.NullString
+	AccountID              int64
+	GithubID               string
 }
 
 func (q *Queries) UpdateNotificationSubject(ctx context.Context, arg UpdateNotificationSubjectParams) error {
@@ -1040,6 +2037,22 @@ func (q *Queries) UpdateNotificationSubject(ctx context.Context, arg UpdateNotif
 		arg.SubjectState,
 		arg.SubjectMerged,
 		arg.SubjectStateReason,
+		arg.SubjectCreatedAt,
+		arg.SubjectDraft,
+		arg.SubjectReviewDecision,
+		pq.Array(arg.SubjectReviewRequested),
+		arg.SubjectChecksStatus,
+		pq.Array(arg.SubjectLabels),
+		arg.SubjectMilestone,
+		pq.Array(arg.SubjectAssignees),
+		arg.SubjectComments,
+		arg.SubjectCategory,
+		arg.SubjectAnswered,
+		arg.SubjectTag,
+		arg.SubjectConclusion,
+		arg.SubjectSha,
+		arg.SubjectSeverity,
+		arg.AccountID,
 		arg.GithubID,
 	)
 	return err
@@ -1069,6 +2082,22 @@ INSERT INTO notifications (
     subject_state,
     subject_merged,
     subject_state_reason,
+    subject_created_at,
+    subject_draft,
+    subject_review_decision,
+    subject_review_requested,
+    subject_checks_status,
+    subject_labels,
+    subject_milestone,
+    subject_assignees,
+    subject_comments,
+    account_id,
+    subject_category,
+    subject_answered,
+    subject_tag,
+    subject_conclusion,
+    subject_sha,
+    subject_severity,
     effective_sort_date
 )
 VALUES (
@@ -1094,9 +2123,25 @@ VALUES (
     $20,
     $21,
     $22,
+    $23,
+    $24,
+    $25,
+    $26,
+    $27,
+    $28,
+    $29,
+    $30,
+    $31,
+    $32,
+    $33,
+    $34,
+    $35,
+    $36,
+    $37,
+    $38,
     $10
 )
-ON CONFLICT (github_id) DO UPDATE
+ON CONFLICT (account_id, github_id) DO UPDATE
 SET repository_id = EXCLUDED.repository_id,
     pull_request_id = EXCLUDED.pull_request_id,
     subject_type = EXCLUDED.subject_type,
@@ -1118,6 +2163,24 @@ SET repository_id = EXCLUDED.repository_id,
     subject_state = EXCLUDED.subject_state,
     subject_merged = EXCLUDED.subject_merged,
     subject_state_reason = EXCLUDED.subject_state_reason,
+    subject_created_at = EXCLUDED.subject_created_at,
+    subject_draft = EXCLUDED.subject_draft,
+    subject_review_decision = EXCLUDED.subject_review_decision,
+    subject_review_requested = EXCLUDED.subject_review_requested,
+    subject_checks_status = EXCLUDED.subject_checks_status,
+    subject_labels = EXCLUDED.subject_labels,
+    subject_milestone = EXCLUDED.subject_milestone,
+    subject_assignees = EXCLUDED.subject_assignees,
+    subject_comments = EXCLUDED.subject_comments,
+    account_id = EXCLUDED.account_id,
+    subject_category = EXCLUDED.subject_category,
+    subject_answered = EXCLUDED.subject_answered,
+    subject_tag = EXCLUDED.subject_tag,
+    subject_conclusion = EXCLUDED.subject_conclusion,
+    subject_sha = EXCLUDED.subject_sha,
+    subject_severity = EXCLUDED.subject_severity,
+    -- GitHub returned the thread again, so it is no longer stale
+    stale_at = NULL,
     imported_at = now(),
     -- Smart status updates on sync
     is_read = CASE
@@ -1140,7 +2203,7 @@ SET repository_id = EXCLUDED.repository_id,
     filtered = notifications.filtered,
     -- Update effective_sort_date: use existing snoozed_until if set, otherwise use new github_updated_at
     effective_sort_date = COALESCE(notifications.snoozed_until, EXCLUDED.github_updated_at)
-RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason
+RETURNING id, github_id, repository_id, pull_request_id, subject_type, subject_title, subject_url, subject_latest_comment_url, reason, archived, github_unread, github_updated_at, github_last_read_at, github_url, github_subscription_url, imported_at, payload, subject_raw, subject_fetched_at, author_login, author_id, is_read, muted, snoozed_until, effective_sort_date, snoozed_at, starred, filtered, tag_ids, subject_number, subject_state, subject_merged, subject_state_reason, subject_created_at, search_vector, subject_draft, subject_review_decision, subject_review_requested, subject_checks_status, subject_labels, subject_milestone, subject_assignees, subject_comments, account_id, subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha, subject_severity, stale_at, viewed_at
 `
 
 type UpsertNotificationParams struct {
@@ -1166,6 +2229,22 @@ type UpsertNotificationParams struct {
 	SubjectState            sql.NullString
 	SubjectMerged           sql.NullBool
 	SubjectStateReason      sql.NullString
+	SubjectCreatedAt        sql.NullTime
+	SubjectDraft            sql.NullBool
+	SubjectReviewDecision   sql.NullString
+	SubjectReviewRequested  []string
+	SubjectChecksStatus     sql.NullString
+	SubjectLabels           []string
+	SubjectMilestone        sql.NullString
+	SubjectAssignees        []string
+	SubjectComments         sql.NullInt32
+	AccountID               int64
+	SubjectCategory         sql.NullString
+	SubjectAnswered         sql.NullBool
+	SubjectTag              sql.NullString
+	SubjectConclusion       sql.NullString
+	SubjectSha              sql.NullString
+	SubjectSeverity         sql.NullString
 }
 
 func (q *Queries) UpsertNotification(ctx context.Context, arg UpsertNotificationParams) (Notification, error) {
@@ -1192,6 +2271,22 @@ func (q *Queries) UpsertNotification(ctx context.Context, arg UpsertNotification
 		arg.SubjectState,
 		arg.SubjectMerged,
 		arg.SubjectStateReason,
+		arg.SubjectCreatedAt,
+		arg.SubjectDraft,
+		arg.SubjectReviewDecision,
+		pq.Array(arg.SubjectReviewRequested),
+		arg.SubjectChecksStatus,
+		pq.Array(arg.SubjectLabels),
+		arg.SubjectMilestone,
+		pq.Array(arg.SubjectAssignees),
+		arg.SubjectComments,
+		arg.AccountID,
+		arg.SubjectCategory,
+		arg.SubjectAnswered,
+		arg.SubjectTag,
+		arg.SubjectConclusion,
+		arg.SubjectSha,
+		arg.SubjectSeverity,
 	)
 	var i Notification
 	err := row.Scan(
@@ -1228,6 +2323,25 @@ func (q *Queries) UpsertNotification(ctx context.Context, arg UpsertNotification
 		&i.SubjectState,
 		&i.SubjectMerged,
 		&i.SubjectStateReason,
+		&i.SubjectCreatedAt,
+		&i.SearchVector,
+		&i.SubjectDraft,
+		&i.SubjectReviewDecision,
+		pq.Array(&i.SubjectReviewRequested),
+		&i.SubjectChecksStatus,
+		pq.Array(&i.SubjectLabels),
+		&i.SubjectMilestone,
+		pq.Array(&i.SubjectAssignees),
+		&i.SubjectComments,
+		&i.AccountID,
+		&i.SubjectCategory,
+		&i.SubjectAnswered,
+		&i.SubjectTag,
+		&i.SubjectConclusion,
+		&i.SubjectSha,
+		&i.SubjectSeverity,
+		&i.StaleAt,
+		&i.ViewedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,34 @@
+-- name: ListGitHubAccounts :many
+SELECT *
+FROM github_accounts
+ORDER BY id;
+
+-- name: GetGitHubAccount :one
+SELECT *
+FROM github_accounts
+WHERE id = sqlc.arg('id')
+LIMIT 1;
+
+-- name: UpsertGitHubAccount :one
+INSERT INTO github_accounts (
+    name,
+    api_url,
+    web_url
+)
+VALUES (
+    sqlc.arg('name'),
+    sqlc.arg('api_url'),
+    sqlc.arg('web_url')
+)
+ON CONFLICT (name) DO UPDATE
+SET api_url = EXCLUDED.api_url,
+    web_url = EXCLUDED.web_url,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: UpdateGitHubAccountSyncSettings :one
+UPDATE github_accounts
+SET sync_settings = sqlc.narg('sync_settings'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
@@ -0,0 +1,43 @@
+-- name: CreateGitHubOutboxEntries :many
+INSERT INTO github_outbox (
+    github_id,
+    action,
+    account_id
+)
+SELECT
+    unnest(sqlc.arg('github_ids')::text[]),
+    sqlc.arg('action')::text,
+    sqlc.arg('account_id')::bigint
+RETURNING id;
+
+-- name: GetGitHubOutboxEntry :one
+SELECT *
+FROM github_outbox
+WHERE id = sqlc.arg('id')
+LIMIT 1;
+
+-- name: CompleteGitHubOutboxEntry :exec
+UPDATE github_outbox
+SET attempts = attempts + 1,
+    last_error = NULL,
+    completed_at = NOW()
+WHERE id = sqlc.arg('id');
+
+-- name: RecordGitHubOutboxFailure :exec
+UPDATE github_outbox
+SET attempts = attempts + 1,
+    last_error = sqlc.arg('last_error')
+WHERE id = sqlc.arg('id');
+
+-- name: ListPendingGitHubOutboxEntries :many
+SELECT *
+FROM github_outbox
+WHERE completed_at IS NULL
+  AND created_at < sqlc.arg('created_before')
+  AND attempts < sqlc.arg('max_attempts')
+ORDER BY id
+LIMIT sqlc.arg('row_limit');
+
+-- name: DeleteGitHubOutboxEntriesCompletedBefore :execrows
+DELETE FROM github_outbox
+WHERE completed_at < sqlc.arg('completed_before');
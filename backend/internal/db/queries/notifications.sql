@@ -22,6 +22,22 @@ INSERT INTO notifications (
     subject_state,
     subject_merged,
     subject_state_reason,
+    subject_created_at,
+    subject_draft,
+    subject_review_decision,
+    subject_review_requested,
+    subject_checks_status,
+    subject_labels,
+    subject_milestone,
+    subject_assignees,
+    subject_comments,
+    account_id,
+    subject_category,
+    subject_answered,
+    subject_tag,
+    subject_conclusion,
+    subject_sha,
+    subject_severity,
     effective_sort_date
 )
 VALUES (
@@ -47,9 +63,25 @@ VALUES (
     sqlc.narg('subject_state'),
     sqlc.narg('subject_merged'),
     sqlc.narg('subject_state_reason'),
+    sqlc.narg('subject_created_at'),
+    sqlc.narg('subject_draft'),
+    sqlc.narg('subject_review_decision'),
+    sqlc.narg('subject_review_requested'),
+    sqlc.narg('subject_checks_status'),
+    sqlc.narg('subject_labels'),
+    sqlc.narg('subject_milestone'),
+    sqlc.narg('subject_assignees'),
+    sqlc.narg('subject_comments'),
+    sqlc.arg('account_id'),
+    sqlc.narg('subject_category'),
+    sqlc.narg('subject_answered'),
+    sqlc.narg('subject_tag'),
+    sqlc.narg('subject_conclusion'),
+    sqlc.narg('subject_sha'),
+    sqlc.narg('subject_severity'),
     sqlc.narg('github_updated_at')
 )
-ON CONFLICT (github_id) DO UPDATE
+ON CONFLICT (account_id, github_id) DO UPDATE
 SET repository_id = EXCLUDED.repository_id,
     pull_request_id = EXCLUDED.pull_request_id,
     subject_type = EXCLUDED.subject_type,
@@ -71,6 +103,24 @@ SET repository_id = EXCLUDED.repository_id,
     subject_state = EXCLUDED.subject_state,
     subject_merged = EXCLUDED.subject_merged,
     subject_state_reason = EXCLUDED.subject_state_reason,
+    subject_created_at = EXCLUDED.subject_created_at,
+    subject_draft = EXCLUDED.subject_draft,
+    subject_review_decision = EXCLUDED.subject_review_decision,
+    subject_review_requested = EXCLUDED.subject_review_requested,
+    subject_checks_status = EXCLUDED.subject_checks_status,
+    subject_labels = EXCLUDED.subject_labels,
+    subject_milestone = EXCLUDED.subject_milestone,
+    subject_assignees = EXCLUDED.subject_assignees,
+    subject_comments = EXCLUDED.subject_comments,
+    account_id = EXCLUDED.account_id,
+    subject_category = EXCLUDED.subject_category,
+    subject_answered = EXCLUDED.subject_answered,
+    subject_tag = EXCLUDED.subject_tag,
+    subject_conclusion = EXCLUDED.subject_conclusion,
+    subject_sha = EXCLUDED.subject_sha,
+    subject_severity = EXCLUDED.subject_severity,
+    -- GitHub returned the thread again, so it is no longer stale
+    stale_at = NULL,
     imported_at = now(),
     -- Smart status updates on sync
     is_read = CASE
@@ -98,7 +148,8 @@ RETURNING *;
 -- name: GetNotificationByGithubID :one
 SELECT *
 FROM notifications
-WHERE github_id = sqlc.arg('github_id');
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id');
 
 -- name: GetNotificationByID :one
 SELECT *
@@ -108,24 +159,42 @@ WHERE id = sqlc.arg('id');
 -- name: MarkNotificationRead :one
 UPDATE notifications
 SET is_read = true
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: MarkNotificationUnread :one
 UPDATE notifications
 SET is_read = false
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
+-- name: MarkNotificationViewed :exec
+UPDATE notifications
+SET viewed_at = now()
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id');
+
+-- name: MarkNotificationSubjectFetched :exec
+-- Sets subject_fetched_at without changing the subject, so a subject that failed to
+-- refresh waits for the next refresh instead of being retried first.
+UPDATE notifications
+SET subject_fetched_at = sqlc.arg('fetched_at')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id');
+
 -- name: BulkMarkNotificationsRead :execrows
 UPDATE notifications
 SET is_read = true
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: BulkMarkNotificationsUnread :execrows
 UPDATE notifications
 SET is_read = false
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: BulkArchiveNotifications :execrows
 UPDATE notifications
@@ -133,19 +202,22 @@ SET archived = true,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: BulkUnarchiveNotifications :execrows
 UPDATE notifications
 SET archived = false
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: BulkSnoozeNotifications :execrows
 UPDATE notifications
 SET snoozed_until = sqlc.arg('snoozed_until'),
     snoozed_at = NOW(),
     effective_sort_date = sqlc.arg('snoozed_until')
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: MuteNotification :one
 UPDATE notifications
@@ -153,13 +225,15 @@ SET muted = true,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: UnmuteNotification :one
 UPDATE notifications
 SET muted = false
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: BulkMuteNotifications :execrows
@@ -168,19 +242,22 @@ SET muted = true,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: BulkUnmuteNotifications :execrows
 UPDATE notifications
 SET muted = false
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: SnoozeNotification :one
 UPDATE notifications
 SET snoozed_until = sqlc.arg('snoozed_until'),
     snoozed_at = NOW(),
     effective_sort_date = sqlc.arg('snoozed_until')
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: UnsnoozeNotification :one
@@ -188,7 +265,8 @@ UPDATE notifications
 SET snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: BulkUnsnoozeNotifications :execrows
@@ -196,7 +274,8 @@ UPDATE notifications
 SET snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: ListNotifications :many
 SELECT *
@@ -209,19 +288,27 @@ FROM notifications
 WHERE repository_id = sqlc.arg('repository_id')
 ORDER BY github_updated_at DESC NULLS LAST, imported_at DESC;
 
+-- name: ListNotificationsBySubjectURLs :many
+SELECT *
+FROM notifications
+WHERE subject_url = ANY(sqlc.arg('subject_urls')::text[])
+ORDER BY github_updated_at DESC NULLS LAST, imported_at DESC;
+
 -- name: ArchiveNotification :one
 UPDATE notifications
 SET archived = TRUE,
     snoozed_until = NULL,
     snoozed_at = NULL,
     effective_sort_date = COALESCE(github_updated_at, imported_at)
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: UnarchiveNotification :one
 UPDATE notifications
 SET archived = FALSE
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: UpdateNotificationSubject :exec
@@ -232,50 +319,184 @@ SET subject_raw = sqlc.narg('subject_raw'),
     subject_number = sqlc.narg('subject_number'),
     subject_state = sqlc.narg('subject_state'),
     subject_merged = sqlc.narg('subject_merged'),
-    subject_state_reason = sqlc.narg('subject_state_reason')
-WHERE github_id = sqlc.arg('github_id');
+    subject_state_reason = sqlc.narg('subject_state_reason'),
+    subject_created_at = sqlc.narg('subject_created_at'),
+    subject_draft = sqlc.narg('subject_draft'),
+    subject_review_decision = sqlc.narg('subject_review_decision'),
+    subject_review_requested = sqlc.narg('subject_review_requested'),
+    subject_checks_status = sqlc.narg('subject_checks_status'),
+    subject_labels = sqlc.narg('subject_labels'),
+    subject_milestone = sqlc.narg('subject_milestone'),
+    subject_assignees = sqlc.narg('subject_assignees'),
+    subject_comments = sqlc.narg('subject_comments'),
+    subject_category = sqlc.narg('subject_category'),
+    subject_answered = sqlc.narg('subject_answered'),
+    subject_tag = sqlc.narg('subject_tag'),
+    subject_conclusion = sqlc.narg('subject_conclusion'),
+    subject_sha = sqlc.narg('subject_sha'),
+    subject_severity = sqlc.narg('subject_severity')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id');
 
 -- name: StarNotification :one
 UPDATE notifications
 SET starred = TRUE
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: UnstarNotification :one
 UPDATE notifications
 SET starred = FALSE
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: BulkStarNotifications :execrows
 UPDATE notifications
 SET starred = TRUE
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: BulkUnstarNotifications :execrows
 UPDATE notifications
 SET starred = FALSE
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: MarkNotificationFiltered :one
 UPDATE notifications
 SET filtered = TRUE
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: MarkNotificationUnfiltered :one
 UPDATE notifications
 SET filtered = FALSE
-WHERE github_id = sqlc.arg('github_id')
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = sqlc.arg('github_id')
 RETURNING *;
 
 -- name: BulkMarkNotificationsFiltered :execrows
 UPDATE notifications
 SET filtered = TRUE
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
 
 -- name: BulkMarkNotificationsUnfiltered :execrows
 UPDATE notifications
 SET filtered = FALSE
-WHERE github_id = ANY(sqlc.arg('github_ids')::text[]);
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
+
+-- name: ListNotificationAuthorLogins :many
+SELECT author_login::text AS author_login
+FROM notifications
+WHERE author_login IS NOT NULL
+  AND strpos(lower(author_login), lower(sqlc.arg('search')::text)) > 0
+GROUP BY author_login
+ORDER BY strpos(lower(author_login), lower(sqlc.arg('search')::text)), author_login
+LIMIT sqlc.arg('row_limit');
+
+-- name: ListNotificationCategories :many
+SELECT subject_category::text AS category
+FROM notifications
+WHERE subject_category IS NOT NULL
+  AND strpos(lower(subject_category), lower(sqlc.arg('search')::text)) > 0
+GROUP BY subject_category
+ORDER BY strpos(lower(subject_category), lower(sqlc.arg('search')::text)), subject_category
+LIMIT sqlc.arg('row_limit');
+
+-- name: ListNotificationLabels :many
+SELECT label::text AS label
+FROM notifications, unnest(subject_labels) AS label
+WHERE strpos(label, lower(sqlc.arg('search')::text)) > 0
+GROUP BY label
+ORDER BY strpos(label, lower(sqlc.arg('search')::text)), label
+LIMIT sqlc.arg('row_limit');
+
+-- name: ListNotificationMilestones :many
+SELECT subject_milestone::text AS milestone
+FROM notifications
+WHERE subject_milestone IS NOT NULL
+  AND strpos(lower(subject_milestone), lower(sqlc.arg('search')::text)) > 0
+GROUP BY subject_milestone
+ORDER BY strpos(lower(subject_milestone), lower(sqlc.arg('search')::text)), subject_milestone
+LIMIT sqlc.arg('row_limit');
+
+-- name: ListNotificationReasons :many
+SELECT reason::text AS reason
+FROM notifications
+WHERE reason IS NOT NULL
+  AND strpos(lower(reason), lower(sqlc.arg('search')::text)) > 0
+GROUP BY reason
+ORDER BY strpos(lower(reason), lower(sqlc.arg('search')::text)), reason
+LIMIT sqlc.arg('row_limit');
+
+-- name: ListNotificationSubjectTypes :many
+SELECT subject_type
+FROM notifications
+WHERE strpos(lower(subject_type), lower(sqlc.arg('search')::text)) > 0
+GROUP BY subject_type
+ORDER BY strpos(lower(subject_type), lower(sqlc.arg('search')::text)), subject_type
+LIMIT sqlc.arg('row_limit');
+
+-- name: ListNotificationsToReconcile :many
+SELECT id, github_id, github_updated_at, stale_at
+FROM notifications
+WHERE account_id = sqlc.arg('account_id')
+  AND archived = FALSE
+  AND id > sqlc.arg('after_id')
+ORDER BY id
+LIMIT sqlc.arg('row_limit');
 
+-- name: ListNotificationsWithStaleSubjects :many
+-- Inbox and starred notifications whose subject was fetched before fetched_before, most
+-- recently viewed first. Subjects never fetched count as stale. Check runs can't be
+-- refreshed on their own.
+SELECT github_id
+FROM notifications
+WHERE account_id = sqlc.arg('account_id')
+  AND (subject_fetched_at IS NULL OR subject_fetched_at < sqlc.arg('fetched_before'))
+  AND lower(replace(subject_type, '_', '')) <> 'checkrun'
+  AND (
+      starred = TRUE
+      OR (
+          archived = FALSE
+          AND muted = FALSE
+          AND filtered = FALSE
+          AND (snoozed_until IS NULL OR snoozed_until <= now())
+      )
+  )
+ORDER BY viewed_at DESC NULLS LAST, subject_fetched_at NULLS FIRST
+LIMIT sqlc.arg('row_limit');
+
+-- name: BulkMarkNotificationsStale :execrows
+UPDATE notifications
+SET stale_at = now()
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[])
+  AND stale_at IS NULL;
+
+-- name: BulkClearNotificationsStale :execrows
+UPDATE notifications
+SET stale_at = NULL
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[])
+  AND stale_at IS NOT NULL;
+
+-- name: BulkDeleteNotifications :execrows
+WITH deleted_tag_assignments AS (
+    DELETE FROM tag_assignments
+    WHERE entity_type = 'notification'
+      AND entity_id IN (
+          SELECT id
+          FROM notifications
+          WHERE account_id = sqlc.arg('account_id')
+            AND github_id = ANY(sqlc.arg('github_ids')::text[])
+      )
+)
+DELETE FROM notifications
+WHERE account_id = sqlc.arg('account_id')
+  AND github_id = ANY(sqlc.arg('github_ids')::text[]);
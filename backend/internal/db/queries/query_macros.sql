@@ -0,0 +1,34 @@
+-- name: ListQueryMacros :many
+SELECT *
+FROM query_macros
+ORDER BY name;
+
+-- name: GetQueryMacro :one
+SELECT *
+FROM query_macros
+WHERE id = sqlc.arg('id')
+LIMIT 1;
+
+-- name: CreateQueryMacro :one
+INSERT INTO query_macros (
+    name,
+    query
+)
+VALUES (
+    sqlc.arg('name'),
+    sqlc.arg('query')
+)
+RETURNING *;
+
+-- name: UpdateQueryMacro :one
+UPDATE query_macros
+SET name = COALESCE(sqlc.narg('name'), query_macros.name),
+    query = COALESCE(sqlc.narg('query'), query_macros.query),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteQueryMacro :one
+DELETE FROM query_macros
+WHERE id = sqlc.arg('id')
+RETURNING id;
@@ -1,5 +1,6 @@
 -- name: UpsertRepository :one
 INSERT INTO repositories (
+    account_id,
     github_id,
     node_id,
     name,
@@ -22,6 +23,7 @@ INSERT INTO repositories (
     raw
 )
 VALUES (
+    sqlc.arg('account_id'),
     sqlc.narg('github_id'),
     sqlc.narg('node_id'),
     sqlc.arg('name'),
@@ -43,7 +45,7 @@ VALUES (
     sqlc.narg('updated_at'),
     sqlc.narg('raw')
 )
-ON CONFLICT (full_name) DO UPDATE
+ON CONFLICT (account_id, full_name) DO UPDATE
 SET github_id = EXCLUDED.github_id,
     node_id = EXCLUDED.node_id,
     name = EXCLUDED.name,
@@ -78,5 +80,22 @@ WHERE id = sqlc.arg('id');
 -- name: FindRepositoryByFullName :one
 SELECT *
 FROM repositories
-WHERE full_name = sqlc.arg('full_name');
+WHERE account_id = sqlc.arg('account_id')
+  AND full_name = sqlc.arg('full_name');
 
+-- name: ListRepositoryFullNames :many
+SELECT full_name
+FROM repositories
+WHERE strpos(lower(full_name), lower(sqlc.arg('search')::text)) > 0
+ORDER BY strpos(lower(full_name), lower(sqlc.arg('search')::text)), full_name
+LIMIT sqlc.arg('row_limit');
+
+-- name: ListRepositoryOwners :many
+SELECT owner::text
+FROM (
+    SELECT DISTINCT split_part(full_name, '/', 1) AS owner
+    FROM repositories
+) owners
+WHERE strpos(lower(owner), lower(sqlc.arg('search')::text)) > 0
+ORDER BY strpos(lower(owner), lower(sqlc.arg('search')::text)), owner
+LIMIT sqlc.arg('row_limit');
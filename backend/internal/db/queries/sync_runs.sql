@@ -0,0 +1,49 @@
+-- name: CreateSyncRun :one
+INSERT INTO sync_runs (
+    account_id,
+    kind,
+    window_since,
+    window_before
+)
+VALUES (
+    sqlc.arg('account_id'),
+    sqlc.arg('kind'),
+    sqlc.narg('window_since'),
+    sqlc.narg('window_before')
+)
+RETURNING id;
+
+-- name: FinishSyncRun :exec
+UPDATE sync_runs
+SET finished_at = now(),
+    not_modified = sqlc.arg('not_modified'),
+    threads_fetched = sqlc.arg('threads_fetched'),
+    jobs_queued = sqlc.arg('jobs_queued'),
+    error = sqlc.narg('error'),
+    rate_limited = sqlc.arg('rate_limited'),
+    rate_limit_remaining = sqlc.narg('rate_limit_remaining'),
+    rate_limit_reset_at = sqlc.narg('rate_limit_reset_at')
+WHERE id = sqlc.arg('id');
+
+-- name: IncrementSyncRunSubjectFetchFailures :exec
+UPDATE sync_runs
+SET subject_fetch_failures = subject_fetch_failures + 1
+WHERE id = sqlc.arg('id');
+
+-- name: ListSyncRuns :many
+-- Newest first, of one account or (with a NULL account_id) of all of them
+SELECT *
+FROM sync_runs
+WHERE (sqlc.narg('account_id')::bigint IS NULL OR account_id = sqlc.narg('account_id'))
+ORDER BY started_at DESC, id DESC
+LIMIT sqlc.arg('row_limit')
+OFFSET sqlc.arg('row_offset');
+
+-- name: CountSyncRuns :one
+SELECT COUNT(*)
+FROM sync_runs
+WHERE (sqlc.narg('account_id')::bigint IS NULL OR account_id = sqlc.narg('account_id'));
+
+-- name: DeleteSyncRunsStartedBefore :execrows
+DELETE FROM sync_runs
+WHERE started_at < sqlc.arg('started_before');
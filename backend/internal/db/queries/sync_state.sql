@@ -3,12 +3,24 @@ SELECT id,
        last_successful_poll,
        latest_notification_at,
        last_notification_etag,
+       last_notification_modified,
        created_at,
        updated_at,
        initial_sync_completed_at,
-       oldest_notification_synced_at
+       oldest_notification_synced_at,
+       rate_limit_limit,
+       rate_limit_remaining,
+       rate_limit_reset_at,
+       rate_limit_updated_at,
+       reconciled_at,
+       reconcile_policy,
+       reconcile_checked,
+       reconcile_archived,
+       reconcile_flagged,
+       reconcile_deleted,
+       reconcile_unflagged
 FROM sync_state
-WHERE id = 1;
+WHERE account_id = sqlc.arg('account_id');
 
 -- name: UpdateSyncState :one
 UPDATE sync_state
@@ -16,7 +28,7 @@ SET last_successful_poll  = sqlc.narg('last_successful_poll'),
     latest_notification_at = sqlc.narg('latest_notification_at'),
     last_notification_etag = sqlc.narg('last_notification_etag'),
     updated_at = now()
-WHERE id = 1
+WHERE account_id = sqlc.arg('account_id')
 RETURNING id,
           last_successful_poll,
           latest_notification_at,
@@ -25,12 +37,13 @@ RETURNING id,
           updated_at;
 
 -- name: UpsertSyncState :one
-INSERT INTO sync_state (id, last_successful_poll, latest_notification_at, last_notification_etag, initial_sync_completed_at, oldest_notification_synced_at)
-VALUES (1, sqlc.narg('last_successful_poll'), sqlc.narg('latest_notification_at'), sqlc.narg('last_notification_etag'), sqlc.narg('initial_sync_completed_at'), sqlc.narg('oldest_notification_synced_at'))
-ON CONFLICT (id) DO UPDATE
+INSERT INTO sync_state (account_id, last_successful_poll, latest_notification_at, last_notification_etag, last_notification_modified, initial_sync_completed_at, oldest_notification_synced_at)
+VALUES (sqlc.arg('account_id'), sqlc.narg('last_successful_poll'), sqlc.narg('latest_notification_at'), sqlc.narg('last_notification_etag'), sqlc.narg('last_notification_modified'), sqlc.narg('initial_sync_completed_at'), sqlc.narg('oldest_notification_synced_at'))
+ON CONFLICT (account_id) DO UPDATE
 SET last_successful_poll  = COALESCE(EXCLUDED.last_successful_poll, sync_state.last_successful_poll),
     latest_notification_at = COALESCE(EXCLUDED.latest_notification_at, sync_state.latest_notification_at),
     last_notification_etag = COALESCE(EXCLUDED.last_notification_etag, sync_state.last_notification_etag),
+    last_notification_modified = COALESCE(EXCLUDED.last_notification_modified, sync_state.last_notification_modified),
     initial_sync_completed_at = COALESCE(EXCLUDED.initial_sync_completed_at, sync_state.initial_sync_completed_at),
     oldest_notification_synced_at = COALESCE(EXCLUDED.oldest_notification_synced_at, sync_state.oldest_notification_synced_at),
     updated_at = now()
@@ -38,8 +51,30 @@ RETURNING id,
           last_successful_poll,
           latest_notification_at,
           last_notification_etag,
+          last_notification_modified,
           created_at,
           updated_at,
           initial_sync_completed_at,
           oldest_notification_synced_at;
 
+
+-- name: UpdateSyncStateRateLimit :exec
+INSERT INTO sync_state (account_id, rate_limit_limit, rate_limit_remaining, rate_limit_reset_at, rate_limit_updated_at)
+VALUES (sqlc.arg('account_id'), sqlc.arg('rate_limit_limit'), sqlc.arg('rate_limit_remaining'), sqlc.arg('rate_limit_reset_at'), now())
+ON CONFLICT (account_id) DO UPDATE
+SET rate_limit_limit = EXCLUDED.rate_limit_limit,
+    rate_limit_remaining = EXCLUDED.rate_limit_remaining,
+    rate_limit_reset_at = EXCLUDED.rate_limit_reset_at,
+    rate_limit_updated_at = EXCLUDED.rate_limit_updated_at;
+
+-- name: UpdateSyncStateReconciliation :exec
+INSERT INTO sync_state (account_id, reconciled_at, reconcile_policy, reconcile_checked, reconcile_archived, reconcile_flagged, reconcile_deleted, reconcile_unflagged)
+VALUES (sqlc.arg('account_id'), sqlc.arg('reconciled_at'), sqlc.arg('reconcile_policy'), sqlc.arg('reconcile_checked'), sqlc.arg('reconcile_archived'), sqlc.arg('reconcile_flagged'), sqlc.arg('reconcile_deleted'), sqlc.arg('reconcile_unflagged'))
+ON CONFLICT (account_id) DO UPDATE
+SET reconciled_at = EXCLUDED.reconciled_at,
+    reconcile_policy = EXCLUDED.reconcile_policy,
+    reconcile_checked = EXCLUDED.reconcile_checked,
+    reconcile_archived = EXCLUDED.reconcile_archived,
+    reconcile_flagged = EXCLUDED.reconcile_flagged,
+    reconcile_deleted = EXCLUDED.reconcile_deleted,
+    reconcile_unflagged = EXCLUDED.reconcile_unflagged;
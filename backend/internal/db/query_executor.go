@@ -30,6 +30,7 @@ type NotificationQuery struct {
 	Joins          []string      // SQL JOIN clauses needed
 	Where          []string      // SQL WHERE conditions
 	Args           []interface{} // Query parameters for prepared statements
+	OrderBy        []string      // SQL ORDER BY expressions from sort: terms
 	Limit          int32
 	Offset         int32
 	IncludeSubject bool // Whether to include subject_raw in SELECT (default: true for backward compatibility)
@@ -45,7 +46,12 @@ type NotificationQuery struct {
 // 15: imported_at, 16: payload, 17: subject_raw, 18: subject_fetched_at, 19: author_login,
 // 20: author_id, 21: is_read, 22: muted, 23: snoozed_until, 24: effective_sort_date,
 // 25: snoozed_at, 26: starred, 27: filtered, 28: tag_ids, 29: subject_number, 30: subject_state,
-// 31: subject_merged, 32: subject_state_reason
+// 31: subject_merged, 32: subject_state_reason, 33: subject_created_at, 34: search_vector,
+// 35: subject_draft, 36: subject_review_decision, 37: subject_review_requested,
+// 38: subject_checks_status, 39: subject_labels, 40: subject_milestone, 41: subject_assignees,
+// 42: subject_comments, 43: account_id, 44: subject_category, 45: subject_answered,
+// 46: subject_tag, 47: subject_conclusion, 48: subject_sha, 49: subject_severity, 50: stale_at,
+// 51: viewed_at
 func notificationColumns(includeSubject bool) string {
 	columns := []string{
 		"n.id",                         // 0
@@ -80,6 +86,24 @@ func notificationColumns(includeSubject bool) string {
 		"n.subject_state",              // 29
 		"n.subject_merged",             // 30
 		"n.subject_state_reason",       // 31
+		"n.subject_created_at",         // 32
+		"n.subject_draft",              // 33
+		"n.subject_review_decision",    // 34
+		"n.subject_review_requested",   // 35
+		"n.subject_checks_status",      // 36
+		"n.subject_labels",             // 37
+		"n.subject_milestone",          // 38
+		"n.subject_assignees",          // 39
+		"n.subject_comments",           // 40
+		"n.account_id",                 // 41
+		"n.subject_category",           // 42
+		"n.subject_answered",           // 43
+		"n.subject_tag",                // 44
+		"n.subject_conclusion",         // 45
+		"n.subject_sha",                // 46
+		"n.subject_severity",           // 47
+		"n.stale_at",                   // 48
+		"n.viewed_at",                  // 49
 	}
 
 	// If includeSubject is true, add subject_raw to the columns.
@@ -104,27 +128,11 @@ func (q *Queries) ListNotificationsFromQuery(
 	// Build the SELECT query - conditionally exclude subject_raw to reduce data transfer
 	baseSelect := notificationColumns(query.IncludeSubject)
 
-	// Add JOINs
-	joins := ""
-	if len(query.Joins) > 0 {
-		joins = " " + strings.Join(query.Joins, " ")
-	}
-
-	// Add WHERE clause
-	where := ""
-	if len(query.Where) > 0 {
-		where = " WHERE " + strings.Join(query.Where, " AND ")
-	}
-
-	// Add ORDER BY
-	// Sort by effective_sort_date which is managed by the application layer
-	orderBy := " ORDER BY n.effective_sort_date DESC NULLS LAST, n.imported_at DESC"
-
-	// Add LIMIT and OFFSET
-	limitOffset := fmt.Sprintf(" LIMIT %d OFFSET %d", query.Limit, query.Offset)
+	// Add JOINs and WHERE clause
+	filter := notificationQueryFilter(query)
 
 	// Combine everything
-	selectQuery := baseSelect + joins + where + orderBy + limitOffset
+	selectQuery := baseSelect + filter + notificationQueryOrderAndLimit(query)
 
 	// Execute query
 	rows, err := q.db.QueryContext(ctx, selectQuery, query.Args...)
@@ -141,38 +149,56 @@ func (q *Queries) ListNotificationsFromQuery(
 		// Column order must match notificationColumns() function above
 		// When adding new columns, update both notificationColumns() and these Scan() calls
 		scanColumns := []any{
-			&n.ID,                      // 0
-			&n.GithubID,                // 1
-			&n.RepositoryID,            // 2
-			&n.PullRequestID,           // 3
-			&n.SubjectType,             // 4
-			&n.SubjectTitle,            // 5
-			&n.SubjectUrl,              // 6
-			&n.SubjectLatestCommentUrl, // 7
-			&n.Reason,                  // 8
-			&n.Archived,                // 9
-			&n.GithubUnread,            // 10
-			&n.GithubUpdatedAt,         // 11
-			&n.GithubLastReadAt,        // 12
-			&n.GithubUrl,               // 13
-			&n.GithubSubscriptionUrl,   // 14
-			&n.ImportedAt,              // 15
-			&n.Payload,                 // 16
-			&n.SubjectFetchedAt,        // 17
-			&n.AuthorLogin,             // 18
-			&n.AuthorID,                // 19
-			&n.IsRead,                  // 20
-			&n.Muted,                   // 21
-			&n.SnoozedUntil,            // 22
-			&n.EffectiveSortDate,       // 23
-			&n.SnoozedAt,               // 24
-			&n.Starred,                 // 25
-			&n.Filtered,                // 26
-			pq.Array(&n.TagIds),        // 27
-			&n.SubjectNumber,           // 28
-			&n.SubjectState,            // 29
-			&n.SubjectMerged,           // 30
-			&n.SubjectStateReason,      // 31
+			&n.ID,                               // 0
+			&n.GithubID,                         // 1
+			&n.RepositoryID,                     // 2
+			&n.PullRequestID,                    // 3
+			&n.SubjectType,                      // 4
+			&n.SubjectTitle,                     // 5
+			&n.SubjectUrl,                       // 6
+			&n.SubjectLatestCommentUrl,          // 7
+			&n.Reason,                           // 8
+			&n.Archived,                         // 9
+			&n.GithubUnread,                     // 10
+			&n.GithubUpdatedAt,                  // 11
+			&n.GithubLastReadAt,                 // 12
+			&n.GithubUrl,                        // 13
+			&n.GithubSubscriptionUrl,            // 14
+			&n.ImportedAt,                       // 15
+			&n.Payload,                          // 16
+			&n.SubjectFetchedAt,                 // 17
+			&n.AuthorLogin,                      // 18
+			&n.AuthorID,                         // 19
+			&n.IsRead,                           // 20
+			&n.Muted,                            // 21
+			&n.SnoozedUntil,                     // 22
+			&n.EffectiveSortDate,                // 23
+			&n.SnoozedAt,                        // 24
+			&n.Starred,                          // 25
+			&n.Filtered,                         // 26
+			pq.Array(&n.TagIds),                 // 27
+			&n.SubjectNumber,                    // 28
+			&n.SubjectState,                     // 29
+			&n.SubjectMerged,                    // 30
+			&n.SubjectStateReason,               // 31
+			&n.SubjectCreatedAt,                 // 32
+			&n.SubjectDraft,                     // 33
+			&n.SubjectReviewDecision,            // 34
+			pq.Array(&n.SubjectReviewRequested), // 35
+			&n.SubjectChecksStatus,              // 36
+			pq.Array(&n.SubjectLabels),          // 37
+			&n.SubjectMilestone,                 // 38
+			pq.Array(&n.SubjectAssignees),       // 39
+			&n.SubjectComments,                  // 40
+			&n.AccountID,                        // 41
+			&n.SubjectCategory,                  // 42
+			&n.SubjectAnswered,                  // 43
+			&n.SubjectTag,                       // 44
+			&n.SubjectConclusion,                // 45
+			&n.SubjectSha,                       // 46
+			&n.SubjectSeverity,                  // 47
+			&n.StaleAt,                          // 48
+			&n.ViewedAt,                         // 49
 		}
 
 		// For convience, add subject_raw and any other future optional columns last so that
@@ -197,9 +223,7 @@ func (q *Queries) ListNotificationsFromQuery(
 	}
 
 	// Get total count
-	countQuery := "SELECT COUNT(*) FROM notifications n" + joins + where
-	var total int64
-	err = q.db.QueryRowContext(ctx, countQuery, query.Args...).Scan(&total)
+	total, err := q.CountNotificationsFromQuery(ctx, query)
 	if err != nil {
 		return ListNotificationsFromQueryResult{}, err
 	}
@@ -210,6 +234,132 @@ func (q *Queries) ListNotificationsFromQuery(
 	}, nil
 }
 
+// CountNotificationsFromQuery counts the notifications matching a query, ignoring limit and offset
+func (q *Queries) CountNotificationsFromQuery(
+	ctx context.Context,
+	query NotificationQuery,
+) (int64, error) {
+	countQuery := "SELECT COUNT(*) FROM notifications n" + notificationQueryFilter(query)
+
+	var total int64
+	if err := q.db.QueryRowContext(ctx, countQuery, query.Args...).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// NotificationThread is the GitHub thread of a notification
+type NotificationThread struct {
+	ID        int64
+	AccountID int64
+	GithubID  string
+}
+
+// ListNotificationThreadsFromQueryParams contains the parameters for listing threads by query
+type ListNotificationThreadsFromQueryParams struct {
+	Query   NotificationQuery
+	AfterID int64
+	Limit   int32
+}
+
+// ListNotificationThreadsFromQuery lists the threads of the notifications matching a query
+// whose ID is above AfterID, in ID order, so that callers can page through every match.
+// The query's sort, limit and offset are ignored.
+func (q *Queries) ListNotificationThreadsFromQuery(
+	ctx context.Context,
+	arg ListNotificationThreadsFromQueryParams,
+) ([]NotificationThread, error) {
+	args := append(append([]interface{}{}, arg.Query.Args...), arg.AfterID)
+	filter := notificationQueryFilter(NotificationQuery{
+		Joins: arg.Query.Joins,
+		Where: append(append([]string{}, arg.Query.Where...), fmt.Sprintf("n.id > $%d", len(args))),
+	})
+	selectQuery := "SELECT n.id, n.account_id, n.github_id FROM notifications n" + filter +
+		fmt.Sprintf(" ORDER BY n.id LIMIT %d", arg.Limit)
+
+	rows, err := q.db.QueryContext(ctx, selectQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var threads []NotificationThread
+	for rows.Next() {
+		var thread NotificationThread
+		if err := rows.Scan(&thread.ID, &thread.AccountID, &thread.GithubID); err != nil {
+			return nil, err
+		}
+		threads = append(threads, thread)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return threads, nil
+}
+
+// ExplainNotificationsFromQuery returns the Postgres plan for listing a query's notifications,
+// one line per row of EXPLAIN output
+func (q *Queries) ExplainNotificationsFromQuery(
+	ctx context.Context,
+	query NotificationQuery,
+) ([]string, error) {
+	explainQuery := "EXPLAIN " + notificationColumns(query.IncludeSubject) +
+		notificationQueryFilter(query) + notificationQueryOrderAndLimit(query)
+
+	rows, err := q.db.QueryContext(ctx, explainQuery, query.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var plan []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		plan = append(plan, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// notificationQueryFilter builds the JOIN and WHERE parts of a notification query
+func notificationQueryFilter(query NotificationQuery) string {
+	filter := ""
+
+	// Add JOINs
+	if len(query.Joins) > 0 {
+		filter += " " + strings.Join(query.Joins, " ")
+	}
+
+	// Add WHERE clause
+	if len(query.Where) > 0 {
+		filter += " WHERE " + strings.Join(query.Where, " AND ")
+	}
+
+	return filter
+}
+
+// notificationQueryOrderAndLimit builds the ORDER BY, LIMIT and OFFSET of a notification query
+func notificationQueryOrderAndLimit(query NotificationQuery) string {
+	// Sort by effective_sort_date which is managed by the application layer.
+	// Explicit sort: keys come first, with the default order breaking ties.
+	orderBy := " ORDER BY n.effective_sort_date DESC NULLS LAST, n.imported_at DESC"
+	if len(query.OrderBy) > 0 {
+		orderBy = " ORDER BY " + strings.Join(query.OrderBy, ", ") +
+			", n.effective_sort_date DESC NULLS LAST, n.imported_at DESC"
+	}
+
+	return orderBy + fmt.Sprintf(" LIMIT %d OFFSET %d", query.Limit, query.Offset)
+}
+
 // BulkMarkNotificationsReadByQuery marks all notifications matching a query as read
 func (q *Queries) BulkMarkNotificationsReadByQuery(
 	ctx context.Context,
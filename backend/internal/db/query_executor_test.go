@@ -16,7 +16,12 @@
 package db
 
 import (
+	"context"
+	"regexp"
 	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIncrementPlaceholders(t *testing.T) {
@@ -85,3 +90,40 @@ func TestIncrementPlaceholders(t *testing.T) {
 		})
 	}
 }
+
+// TestListNotificationThreadsFromQuery tests that the page starts after AfterID, whose
+// placeholder follows the query's own
+func TestListNotificationThreadsFromQuery(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT n.id, n.account_id, n.github_id FROM notifications n "+
+			"WHERE n.archived = $1 AND n.id > $2 ORDER BY n.id LIMIT 2",
+	)).
+		WithArgs(false, int64(40)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "github_id"}).
+			AddRow(int64(41), int64(1), "thread-1").
+			AddRow(int64(42), int64(2), "thread-1"))
+
+	threads, err := New(dbConn).ListNotificationThreadsFromQuery(
+		context.Background(),
+		ListNotificationThreadsFromQueryParams{
+			Query: NotificationQuery{
+				Where:   []string{"n.archived = $1"},
+				Args:    []interface{}{false},
+				OrderBy: []string{"n.subject_title ASC"},
+				Limit:   50,
+			},
+			AfterID: 40,
+			Limit:   2,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []NotificationThread{
+		{ID: 41, AccountID: 1, GithubID: "thread-1"},
+		{ID: 42, AccountID: 2, GithubID: "thread-1"},
+	}, threads)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
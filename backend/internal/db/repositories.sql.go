@@ -13,13 +13,19 @@ import (
 )
 
 const findRepositoryByFullName = `-- name: FindRepositoryByFullName :one
-SELECT id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url
+SELECT id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url, account_id
 FROM repositories
-WHERE full_name = $1
+WHERE account_id = $1
+  AND full_name = $2
 `
 
-func (q *Queries) FindRepositoryByFullName(ctx context.Context, fullName string) (Repository, error) {
-	row := q.db.QueryRowContext(ctx, findRepositoryByFullName, fullName)
+type FindRepositoryByFullNameParams struct {
+	AccountID int64
+	FullName  string
+}
+
+func (q *Queries) FindRepositoryByFullName(ctx context.Context, arg FindRepositoryByFullNameParams) (Repository, error) {
+	row := q.db.QueryRowContext(ctx, findRepositoryByFullName, arg.AccountID, arg.FullName)
 	var i Repository
 	err := row.Scan(
 		&i.ID,
@@ -43,12 +49,13 @@ func (q *Queries) FindRepositoryByFullName(ctx context.Context, fullName string)
 		&i.Raw,
 		&i.OwnerAvatarUrl,
 		&i.OwnerHtmlUrl,
+		&i.AccountID,
 	)
 	return i, err
 }
 
 const getRepositoryByID = `-- name: GetRepositoryByID :one
-SELECT id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url
+SELECT id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url, account_id
 FROM repositories
 WHERE id = $1
 `
@@ -78,12 +85,13 @@ func (q *Queries) GetRepositoryByID(ctx context.Context, id int64) (Repository,
 		&i.Raw,
 		&i.OwnerAvatarUrl,
 		&i.OwnerHtmlUrl,
+		&i.AccountID,
 	)
 	return i, err
 }
 
 const listRepositories = `-- name: ListRepositories :many
-SELECT id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url
+SELECT id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url, account_id
 FROM repositories
 ORDER BY full_name
 `
@@ -119,6 +127,7 @@ func (q *Queries) ListRepositories(ctx context.Context) ([]Repository, error) {
 			&i.Raw,
 			&i.OwnerAvatarUrl,
 			&i.OwnerHtmlUrl,
+			&i.AccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -133,8 +142,84 @@ func (q *Queries) ListRepositories(ctx context.Context) ([]Repository, error) {
 	return items, nil
 }
 
+const listRepositoryFullNames = `-- name: ListRepositoryFullNames :many
+SELECT full_name
+FROM repositories
+WHERE strpos(lower(full_name), lower($1::text)) > 0
+ORDER BY strpos(lower(full_name), lower($1::text)), full_name
+LIMIT $2
+`
+
+type ListRepositoryFullNamesParams struct {
+	Search   string
+	RowLimit int32
+}
+
+func (q *Queries) ListRepositoryFullNames(ctx context.Context, arg ListRepositoryFullNamesParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listRepositoryFullNames, arg.Search, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var fullName string
+		if err := rows.Scan(&fullName); err != nil {
+			return nil, err
+		}
+		items = append(items, fullName)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRepositoryOwners = `-- name: ListRepositoryOwners :many
+SELECT owner::text
+FROM (
+    SELECT DISTINCT split_part(full_name, '/', 1) AS owner
+    FROM repositories
+) owners
+WHERE strpos(lower(owner), lower($1::text)) > 0
+ORDER BY strpos(lower(owner), lower($1::text)), owner
+LIMIT $2
+`
+
+type ListRepositoryOwnersParams struct {
+	Search   string
+	RowLimit int32
+}
+
+func (q *Queries) ListRepositoryOwners(ctx context.Context, arg ListRepositoryOwnersParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listRepositoryOwners, arg.Search, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var owner string
+		if err := rows.Scan(&owner); err != nil {
+			return nil, err
+		}
+		items = append(items, owner)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertRepository = `-- name: UpsertRepository :one
 INSERT INTO repositories (
+    account_id,
     github_id,
     node_id,
     name,
@@ -171,14 +256,15 @@ VALUES (
     $12,
     $13,
     $14,
-    COALESCE($15, FALSE),
-    $16,
+    $15,
+    COALESCE($16, FALSE),
     $17,
     $18,
     $19,
-    $20
+    $20,
+    $21
 )
-ON CONFLICT (full_name) DO UPDATE
+ON CONFLICT (account_id, full_name) DO UPDATE
 SET github_id = EXCLUDED.github_id,
     node_id = EXCLUDED.node_id,
     name = EXCLUDED.name,
@@ -198,10 +284,11 @@ SET github_id = EXCLUDED.github_id,
     created_at = COALESCE(EXCLUDED.created_at, repositories.created_at),
     updated_at = COALESCE(EXCLUDED.updated_at, repositories.updated_at),
     raw = EXCLUDED.raw
-RETURNING id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url
+RETURNING id, github_id, node_id, name, full_name, owner_login, owner_id, private, description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at, created_at, updated_at, raw, owner_avatar_url, owner_html_url, account_id
 `
 
 type UpsertRepositoryParams struct {
+	AccountID      int64
 	GithubID       sql.NullInt64
 	NodeID         sql.NullString
 	Name           string
@@ -226,6 +313,7 @@ type UpsertRepositoryParams struct {
 
 func (q *Queries) UpsertRepository(ctx context.Context, arg UpsertRepositoryParams) (Repository, error) {
 	row := q.db.QueryRowContext(ctx, upsertRepository,
+		arg.AccountID,
 		arg.GithubID,
 		arg.NodeID,
 		arg.Name,
@@ -270,6 +358,7 @@ func (q *Queries) UpsertRepository(ctx context.Context, arg UpsertRepositoryPara
 		&i.Raw,
 		&i.OwnerAvatarUrl,
 		&i.OwnerHtmlUrl,
+		&i.AccountID,
 	)
 	return i, err
 }
@@ -29,4 +29,8 @@ type RiverClient interface {
 		args river.JobArgs,
 		opts *river.InsertOpts,
 	) (*rivertype.JobInsertResult, error)
+	InsertMany(
+		ctx context.Context,
+		params []river.InsertManyParams,
+	) ([]*rivertype.JobInsertResult, error)
 }
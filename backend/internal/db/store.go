@@ -18,6 +18,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/sqlc-dev/pqtype"
 )
@@ -28,33 +29,66 @@ import (
 // This interface allows us to mock database operations in unit tests.
 type Store interface {
 	// Notification methods
-	GetNotificationByGithubID(ctx context.Context, githubID string) (Notification, error)
+	GetNotificationByGithubID(
+		ctx context.Context,
+		arg GetNotificationByGithubIDParams,
+	) (Notification, error)
 	GetNotificationByID(ctx context.Context, id int64) (Notification, error)
+	//nolint:revive // var-naming: subjectUrls matches the generated query
+	ListNotificationsBySubjectURLs(
+		ctx context.Context,
+		subjectUrls []string,
+	) ([]Notification, error)
 	ListNotificationsFromQuery(
 		ctx context.Context,
 		query NotificationQuery,
 	) (ListNotificationsFromQueryResult, error)
-	MarkNotificationRead(ctx context.Context, githubID string) (Notification, error)
-	MarkNotificationUnread(ctx context.Context, githubID string) (Notification, error)
-	ArchiveNotification(ctx context.Context, githubID string) (Notification, error)
-	UnarchiveNotification(ctx context.Context, githubID string) (Notification, error)
-	MuteNotification(ctx context.Context, githubID string) (Notification, error)
-	UnmuteNotification(ctx context.Context, githubID string) (Notification, error)
+	CountNotificationsFromQuery(ctx context.Context, query NotificationQuery) (int64, error)
+	ExplainNotificationsFromQuery(ctx context.Context, query NotificationQuery) ([]string, error)
+	ListNotificationThreadsFromQuery(
+		ctx context.Context,
+		arg ListNotificationThreadsFromQueryParams,
+	) ([]NotificationThread, error)
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error)
+	MarkNotificationUnread(
+		ctx context.Context,
+		arg MarkNotificationUnreadParams,
+	) (Notification, error)
+	MarkNotificationViewed(ctx context.Context, arg MarkNotificationViewedParams) error
+	MarkNotificationSubjectFetched(ctx context.Context, arg MarkNotificationSubjectFetchedParams) error
+	ArchiveNotification(ctx context.Context, arg ArchiveNotificationParams) (Notification, error)
+	UnarchiveNotification(
+		ctx context.Context,
+		arg UnarchiveNotificationParams,
+	) (Notification, error)
+	MuteNotification(ctx context.Context, arg MuteNotificationParams) (Notification, error)
+	UnmuteNotification(ctx context.Context, arg UnmuteNotificationParams) (Notification, error)
 	SnoozeNotification(ctx context.Context, arg SnoozeNotificationParams) (Notification, error)
-	UnsnoozeNotification(ctx context.Context, githubID string) (Notification, error)
-	StarNotification(ctx context.Context, githubID string) (Notification, error)
-	UnstarNotification(ctx context.Context, githubID string) (Notification, error)
-	MarkNotificationFiltered(ctx context.Context, githubID string) (Notification, error)
-	MarkNotificationUnfiltered(ctx context.Context, githubID string) (Notification, error)
+	UnsnoozeNotification(ctx context.Context, arg UnsnoozeNotificationParams) (Notification, error)
+	StarNotification(ctx context.Context, arg StarNotificationParams) (Notification, error)
+	UnstarNotification(ctx context.Context, arg UnstarNotificationParams) (Notification, error)
+	MarkNotificationFiltered(
+		ctx context.Context,
+		arg MarkNotificationFilteredParams,
+	) (Notification, error)
+	MarkNotificationUnfiltered(
+		ctx context.Context,
+		arg MarkNotificationUnfilteredParams,
+	) (Notification, error)
 	BulkSnoozeNotifications(ctx context.Context, arg BulkSnoozeNotificationsParams) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkMarkNotificationsRead(ctx context.Context, githubIds []string) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkMarkNotificationsUnread(ctx context.Context, githubIds []string) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkArchiveNotifications(ctx context.Context, githubIds []string) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkUnarchiveNotifications(ctx context.Context, githubIds []string) (int64, error)
+	BulkMarkNotificationsRead(
+		ctx context.Context,
+		arg BulkMarkNotificationsReadParams,
+	) (int64, error)
+	BulkMarkNotificationsUnread(
+		ctx context.Context,
+		arg BulkMarkNotificationsUnreadParams,
+	) (int64, error)
+	BulkArchiveNotifications(ctx context.Context, arg BulkArchiveNotificationsParams) (int64, error)
+	BulkUnarchiveNotifications(
+		ctx context.Context,
+		arg BulkUnarchiveNotificationsParams,
+	) (int64, error)
 	BulkMarkNotificationsReadByQuery(ctx context.Context, query NotificationQuery) (int64, error)
 	BulkMarkNotificationsUnreadByQuery(ctx context.Context, query NotificationQuery) (int64, error)
 	BulkArchiveNotificationsByQuery(ctx context.Context, query NotificationQuery) (int64, error)
@@ -63,24 +97,65 @@ type Store interface {
 		ctx context.Context,
 		arg BulkSnoozeNotificationsByQueryParams,
 	) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkUnsnoozeNotifications(ctx context.Context, githubIds []string) (int64, error)
+	BulkUnsnoozeNotifications(
+		ctx context.Context,
+		arg BulkUnsnoozeNotificationsParams,
+	) (int64, error)
 	BulkUnsnoozeNotificationsByQuery(ctx context.Context, query NotificationQuery) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkMuteNotifications(ctx context.Context, githubIds []string) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkUnmuteNotifications(ctx context.Context, githubIds []string) (int64, error)
+	BulkMuteNotifications(ctx context.Context, arg BulkMuteNotificationsParams) (int64, error)
+	BulkUnmuteNotifications(ctx context.Context, arg BulkUnmuteNotificationsParams) (int64, error)
 	BulkMuteNotificationsByQuery(ctx context.Context, query NotificationQuery) (int64, error)
 	BulkUnmuteNotificationsByQuery(ctx context.Context, query NotificationQuery) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkStarNotifications(ctx context.Context, githubIds []string) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkUnstarNotifications(ctx context.Context, githubIds []string) (int64, error)
+	BulkStarNotifications(ctx context.Context, arg BulkStarNotificationsParams) (int64, error)
+	BulkUnstarNotifications(ctx context.Context, arg BulkUnstarNotificationsParams) (int64, error)
 	BulkStarNotificationsByQuery(ctx context.Context, query NotificationQuery) (int64, error)
 	BulkUnstarNotificationsByQuery(ctx context.Context, query NotificationQuery) (int64, error)
-	//nolint:revive // var-naming: githubIds matches existing API contract
-	BulkMarkNotificationsUnfiltered(ctx context.Context, githubIds []string) (int64, error)
+	BulkMarkNotificationsUnfiltered(
+		ctx context.Context,
+		arg BulkMarkNotificationsUnfilteredParams,
+	) (int64, error)
+	BulkMarkNotificationsStale(
+		ctx context.Context,
+		arg BulkMarkNotificationsStaleParams,
+	) (int64, error)
+	BulkClearNotificationsStale(
+		ctx context.Context,
+		arg BulkClearNotificationsStaleParams,
+	) (int64, error)
+	BulkDeleteNotifications(ctx context.Context, arg BulkDeleteNotificationsParams) (int64, error)
+	ListNotificationsToReconcile(
+		ctx context.Context,
+		arg ListNotificationsToReconcileParams,
+	) ([]ListNotificationsToReconcileRow, error)
+	ListNotificationsWithStaleSubjects(
+		ctx context.Context,
+		arg ListNotificationsWithStaleSubjectsParams,
+	) ([]string, error)
 	UpdateNotificationTagIds(ctx context.Context, notificationID int64) error
+	ListNotificationAuthorLogins(
+		ctx context.Context,
+		arg ListNotificationAuthorLoginsParams,
+	) ([]string, error)
+	ListNotificationCategories(
+		ctx context.Context,
+		arg ListNotificationCategoriesParams,
+	) ([]string, error)
+	ListNotificationLabels(
+		ctx context.Context,
+		arg ListNotificationLabelsParams,
+	) ([]string, error)
+	ListNotificationMilestones(
+		ctx context.Context,
+		arg ListNotificationMilestonesParams,
+	) ([]string, error)
+	ListNotificationReasons(
+		ctx context.Context,
+		arg ListNotificationReasonsParams,
+	) ([]string, error)
+	ListNotificationSubjectTypes(
+		ctx context.Context,
+		arg ListNotificationSubjectTypesParams,
+	) ([]string, error)
 
 	// Tag methods
 	GetTag(ctx context.Context, id int64) (Tag, error)
@@ -103,6 +178,13 @@ type Store interface {
 	UpdateViewOrder(ctx context.Context, arg UpdateViewOrderParams) error
 	GetRulesByViewID(ctx context.Context, viewID sql.NullInt64) ([]Rule, error)
 
+	// Query macro methods
+	GetQueryMacro(ctx context.Context, id int64) (QueryMacro, error)
+	ListQueryMacros(ctx context.Context) ([]QueryMacro, error)
+	CreateQueryMacro(ctx context.Context, arg CreateQueryMacroParams) (QueryMacro, error)
+	UpdateQueryMacro(ctx context.Context, arg UpdateQueryMacroParams) (QueryMacro, error)
+	DeleteQueryMacro(ctx context.Context, id int64) (int64, error)
+
 	// Rule methods
 	GetRule(ctx context.Context, id int64) (Rule, error)
 	ListRules(ctx context.Context) ([]Rule, error)
@@ -116,13 +198,57 @@ type Store interface {
 	GetRepositoryByID(ctx context.Context, id int64) (Repository, error)
 	ListRepositories(ctx context.Context) ([]Repository, error)
 	UpsertRepository(ctx context.Context, arg UpsertRepositoryParams) (Repository, error)
+	ListRepositoryFullNames(
+		ctx context.Context,
+		arg ListRepositoryFullNamesParams,
+	) ([]string, error)
+	ListRepositoryOwners(ctx context.Context, arg ListRepositoryOwnersParams) ([]string, error)
 
 	// Pull Request methods
 	UpsertPullRequest(ctx context.Context, arg UpsertPullRequestParams) (PullRequest, error)
 
 	// Sync methods
-	GetSyncState(ctx context.Context) (GetSyncStateRow, error)
+	GetSyncState(ctx context.Context, accountID int64) (GetSyncStateRow, error)
 	UpsertSyncState(ctx context.Context, arg UpsertSyncStateParams) (UpsertSyncStateRow, error)
+	UpdateSyncStateRateLimit(ctx context.Context, arg UpdateSyncStateRateLimitParams) error
+	UpdateSyncStateReconciliation(
+		ctx context.Context,
+		arg UpdateSyncStateReconciliationParams,
+	) error
+
+	// GitHub account methods
+	ListGitHubAccounts(ctx context.Context) ([]GithubAccount, error)
+	GetGitHubAccount(ctx context.Context, id int64) (GithubAccount, error)
+	UpsertGitHubAccount(ctx context.Context, arg UpsertGitHubAccountParams) (GithubAccount, error)
+	UpdateGitHubAccountSyncSettings(
+		ctx context.Context,
+		arg UpdateGitHubAccountSyncSettingsParams,
+	) (GithubAccount, error)
+
+	// GitHub outbox methods
+	CreateGitHubOutboxEntries(
+		ctx context.Context,
+		arg CreateGitHubOutboxEntriesParams,
+	) ([]int64, error)
+	GetGitHubOutboxEntry(ctx context.Context, id int64) (GithubOutbox, error)
+	CompleteGitHubOutboxEntry(ctx context.Context, id int64) error
+	RecordGitHubOutboxFailure(ctx context.Context, arg RecordGitHubOutboxFailureParams) error
+	ListPendingGitHubOutboxEntries(
+		ctx context.Context,
+		arg ListPendingGitHubOutboxEntriesParams,
+	) ([]GithubOutbox, error)
+	DeleteGitHubOutboxEntriesCompletedBefore(
+		ctx context.Context,
+		completedBefore time.Time,
+	) (int64, error)
+
+	// Sync run methods
+	CreateSyncRun(ctx context.Context, arg CreateSyncRunParams) (int64, error)
+	FinishSyncRun(ctx context.Context, arg FinishSyncRunParams) error
+	IncrementSyncRunSubjectFetchFailures(ctx context.Context, id int64) error
+	ListSyncRuns(ctx context.Context, arg ListSyncRunsParams) ([]SyncRun, error)
+	CountSyncRuns(ctx context.Context, accountID sql.NullInt64) (int64, error)
+	DeleteSyncRunsStartedBefore(ctx context.Context, startedBefore time.Time) (int64, error)
 
 	// Notification upsert/update methods
 	UpsertNotification(ctx context.Context, arg UpsertNotificationParams) (Notification, error)
@@ -16,12 +16,24 @@ SELECT id,
        last_successful_poll,
        latest_notification_at,
        last_notification_etag,
+       last_notification_modified,
        created_at,
        updated_at,
        initial_sync_completed_at,
-       oldest_notification_synced_at
+       oldest_notification_synced_at,
+       rate_limit_limit,
+       rate_limit_remaining,
+       rate_limit_reset_at,
+       rate_limit_updated_at,
+       reconciled_at,
+       reconcile_policy,
+       reconcile_checked,
+       reconcile_archived,
+       reconcile_flagged,
+       reconcile_deleted,
+       reconcile_unflagged
 FROM sync_state
-WHERE id = 1
+WHERE account_id = $1
 `
 
 type GetSyncStateRow struct {
@@ -29,24 +41,48 @@ type GetSyncStateRow struct {
 	LastSuccessfulPoll         sql.NullTime
 	LatestNotificationAt       sql.NullTime
 	LastNotificationEtag       sql.NullString
+	LastNotificationModified   sql.NullString
 	CreatedAt                  time.Time
 	UpdatedAt                  time.Time
 	InitialSyncCompletedAt     sql.NullTime
 	OldestNotificationSyncedAt sql.NullTime
+	RateLimitLimit             sql.NullInt32
+	RateLimitRemaining         sql.NullInt32
+	RateLimitResetAt           sql.NullTime
+	RateLimitUpdatedAt         sql.NullTime
+	ReconciledAt               sql.NullTime
+	ReconcilePolicy            sql.NullString
+	ReconcileChecked           sql.NullInt32
+	ReconcileArchived          sql.NullInt32
+	ReconcileFlagged           sql.NullInt32
+	ReconcileDeleted           sql.NullInt32
+	ReconcileUnflagged         sql.NullInt32
 }
 
-func (q *Queries) GetSyncState(ctx context.Context) (GetSyncStateRow, error) {
-	row := q.db.QueryRowContext(ctx, getSyncState)
+func (q *Queries) GetSyncState(ctx context.Context, accountID int64) (GetSyncStateRow, error) {
+	row := q.db.QueryRowContext(ctx, getSyncState, accountID)
 	var i GetSyncStateRow
 	err := row.Scan(
 		&i.ID,
 		&i.LastSuccessfulPoll,
 		&i.LatestNotificationAt,
 		&i.LastNotificationEtag,
+		&i.LastNotificationModified,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.InitialSyncCompletedAt,
 		&i.OldestNotificationSyncedAt,
+		&i.RateLimitLimit,
+		&i.RateLimitRemaining,
+		&i.RateLimitResetAt,
+		&i.RateLimitUpdatedAt,
+		&i.ReconciledAt,
+		&i.ReconcilePolicy,
+		&i.ReconcileChecked,
+		&i.ReconcileArchived,
+		&i.ReconcileFlagged,
+		&i.ReconcileDeleted,
+		&i.ReconcileUnflagged,
 	)
 	return i, err
 }
@@ -57,7 +93,7 @@ SET last_successful_poll  = $1,
     latest_notification_at = $2,
     last_notification_etag = $3,
     updated_at = now()
-WHERE id = 1
+WHERE account_id = $4
 RETURNING id,
           last_successful_poll,
           latest_notification_at,
@@ -70,6 +106,7 @@ type UpdateSyncStateParams struct {
 	LastSuccessfulPoll   sql.NullTime
 	LatestNotificationAt sql.NullTime
 	LastNotificationEtag sql.NullString
+	AccountID            int64
 }
 
 type UpdateSyncStateRow struct {
@@ -82,7 +119,12 @@ type UpdateSyncStateRow struct {
 }
 
 func (q *Queries) UpdateSyncState(ctx context.Context, arg UpdateSyncStateParams) (UpdateSyncStateRow, error) {
-	row := q.db.QueryRowContext(ctx, updateSyncState, arg.LastSuccessfulPoll, arg.LatestNotificationAt, arg.LastNotificationEtag)
+	row := q.db.QueryRowContext(ctx, updateSyncState,
+		arg.LastSuccessfulPoll,
+		arg.LatestNotificationAt,
+		arg.LastNotificationEtag,
+		arg.AccountID,
+	)
 	var i UpdateSyncStateRow
 	err := row.Scan(
 		&i.ID,
@@ -95,13 +137,79 @@ func (q *Queries) UpdateSyncState(ctx context.Context, arg UpdateSyncStateParams
 	return i, err
 }
 
+const updateSyncStateRateLimit = `-- name: UpdateSyncStateRateLimit :exec
+INSERT INTO sync_state (account_id, rate_limit_limit, rate_limit_remaining, rate_limit_reset_at, rate_limit_updated_at)
+VALUES ($1, $2, $3, $4, now())
+ON CONFLICT (account_id) DO UPDATE
+SET rate_limit_limit = EXCLUDED.rate_limit_limit,
+    rate_limit_remaining = EXCLUDED.rate_limit_remaining,
+    rate_limit_reset_at = EXCLUDED.rate_limit_reset_at,
+    rate_limit_updated_at = EXCLUDED.rate_limit_updated_at
+`
+
+type UpdateSyncStateRateLimitParams struct {
+	AccountID          int64
+	RateLimitLimit     sql.NullInt32
+	RateLimitRemaining sql.NullInt32
+	RateLimitResetAt   sql.NullTime
+}
+
+func (q *Queries) UpdateSyncStateRateLimit(ctx context.Context, arg UpdateSyncStateRateLimitParams) error {
+	_, err := q.db.ExecContext(ctx, updateSyncStateRateLimit,
+		arg.AccountID,
+		arg.RateLimitLimit,
+		arg.RateLimitRemaining,
+		arg.RateLimitResetAt,
+	)
+	return err
+}
+
+const updateSyncStateReconciliation = `-- name: UpdateSyncStateReconciliation :exec
+INSERT INTO sync_state (account_id, reconciled_at, reconcile_policy, reconcile_checked, reconcile_archived, reconcile_flagged, reconcile_deleted, reconcile_unflagged)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (account_id) DO UPDATE
+SET reconciled_at = EXCLUDED.reconciled_at,
+    reconcile_policy = EXCLUDED.reconcile_policy,
+    reconcile_checked = EXCLUDED.reconcile_checked,
+    reconcile_archived = EXCLUDED.reconcile_archived,
+    reconcile_flagged = EXCLUDED.reconcile_flagged,
+    reconcile_deleted = EXCLUDED.reconcile_deleted,
+    reconcile_unflagged = EXCLUDED.reconcile_unflagged
+`
+
+type UpdateSyncStateReconciliationParams struct {
+	AccountID          int64
+	ReconciledAt       sql.NullTime
+	ReconcilePolicy    sql.NullString
+	ReconcileChecked   sql.NullInt32
+	ReconcileArchived  sql.NullInt32
+	ReconcileFlagged   sql.NullInt32
+	ReconcileDeleted   sql.NullInt32
+	ReconcileUnflagged sql.NullInt32
+}
+
+func (q *Queries) UpdateSyncStateReconciliation(ctx context.Context, arg UpdateSyncStateReconciliationParams) error {
+	_, err := q.db.ExecContext(ctx, updateSyncStateReconciliation,
+		arg.AccountID,
+		arg.ReconciledAt,
+		arg.ReconcilePolicy,
+		arg.ReconcileChecked,
+		arg.ReconcileArchived,
+		arg.ReconcileFlagged,
+		arg.ReconcileDeleted,
+		arg.ReconcileUnflagged,
+	)
+	return err
+}
+
 const upsertSyncState = `-- name: UpsertSyncState :one
-INSERT INTO sync_state (id, last_successful_poll, latest_notification_at, last_notification_etag, initial_sync_completed_at, oldest_notification_synced_at)
-VALUES (1, $1, $2, $3, $4, $5)
-ON CONFLICT (id) DO UPDATE
+INSERT INTO sync_state (account_id, last_successful_poll, latest_notification_at, last_notification_etag, last_notification_modified, initial_sync_completed_at, oldest_notification_synced_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (account_id) DO UPDATE
 SET last_successful_poll  = COALESCE(EXCLUDED.last_successful_poll, sync_state.last_successful_poll),
     latest_notification_at = COALESCE(EXCLUDED.latest_notification_at, sync_state.latest_notification_at),
     last_notification_etag = COALESCE(EXCLUDED.last_notification_etag, sync_state.last_notification_etag),
+    last_notification_modified = COALESCE(EXCLUDED.last_notification_modified, sync_state.last_notification_modified),
     initial_sync_completed_at = COALESCE(EXCLUDED.initial_sync_completed_at, sync_state.initial_sync_completed_at),
     oldest_notification_synced_at = COALESCE(EXCLUDED.oldest_notification_synced_at, sync_state.oldest_notification_synced_at),
     updated_at = now()
@@ -109,6 +217,7 @@ RETURNING id,
           last_successful_poll,
           latest_notification_at,
           last_notification_etag,
+          last_notification_modified,
           created_at,
           updated_at,
           initial_sync_completed_at,
@@ -116,9 +225,11 @@ RETURNING id,
 `
 
 type UpsertSyncStateParams struct {
+	AccountID                  int64
 	LastSuccessfulPoll         sql.NullTime
 	LatestNotificationAt       sql.NullTime
 	LastNotificationEtag       sql.NullString
+	LastNotificationModified   sql.NullString
 	InitialSyncCompletedAt     sql.NullTime
 	OldestNotificationSyncedAt sql.NullTime
 }
@@ -128,6 +239,7 @@ type UpsertSyncStateRow struct {
 	LastSuccessfulPoll         sql.NullTime
 	LatestNotificationAt       sql.NullTime
 	LastNotificationEtag       sql.NullString
+	LastNotificationModified   sql.NullString
 	CreatedAt                  time.Time
 	UpdatedAt                  time.Time
 	InitialSyncCompletedAt     sql.NullTime
@@ -136,9 +248,11 @@ type UpsertSyncStateRow struct {
 
 func (q *Queries) UpsertSyncState(ctx context.Context, arg UpsertSyncStateParams) (UpsertSyncStateRow, error) {
 	row := q.db.QueryRowContext(ctx, upsertSyncState,
+		arg.AccountID,
 		arg.LastSuccessfulPoll,
 		arg.LatestNotificationAt,
 		arg.LastNotificationEtag,
+		arg.LastNotificationModified,
 		arg.InitialSyncCompletedAt,
 		arg.OldestNotificationSyncedAt,
 	)
@@ -148,6 +262,7 @@ func (q *Queries) UpsertSyncState(ctx context.Context, arg UpsertSyncStateParams
 		&i.LastSuccessfulPoll,
 		&i.LatestNotificationAt,
 		&i.LastNotificationEtag,
+		&i.LastNotificationModified,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.InitialSyncCompletedAt,
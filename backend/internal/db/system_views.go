@@ -15,6 +15,8 @@
 
 package db
 
+import "sort"
+
 // SystemView constants
 const (
 	SystemViewInbox      = "inbox"
@@ -31,6 +33,7 @@ type SystemViewDefinition struct {
 	Description string
 	Icon        string
 	IsDefault   bool
+	Query       string // What view:<slug> expands to in other queries
 }
 
 // SystemViews defines the system built in views for notifications.
@@ -41,6 +44,7 @@ var SystemViews = map[string]SystemViewDefinition{
 		Description: "All notifications that need attention",
 		Icon:        "inbox",
 		IsDefault:   true,
+		Query:       "in:inbox",
 	},
 	SystemViewEverything: {
 		Slug:        "everything",
@@ -48,6 +52,7 @@ var SystemViews = map[string]SystemViewDefinition{
 		Description: "All notifications including done",
 		Icon:        "infinity",
 		IsDefault:   false,
+		Query:       "in:anywhere",
 	},
 	// Starred view
 	SystemViewStarred: {
@@ -56,6 +61,7 @@ var SystemViews = map[string]SystemViewDefinition{
 		Description: "Starred notifications",
 		Icon:        "star",
 		IsDefault:   false,
+		Query:       "is:starred",
 	},
 	// Archive view
 	SystemViewArchive: {
@@ -64,6 +70,7 @@ var SystemViews = map[string]SystemViewDefinition{
 		Description: "Archived notifications",
 		Icon:        "archive",
 		IsDefault:   false,
+		Query:       "in:archive",
 	},
 	// Snoozed view
 	SystemViewSnoozed: {
@@ -72,6 +79,7 @@ var SystemViews = map[string]SystemViewDefinition{
 		Description: "Snoozed notifications",
 		Icon:        "snooze",
 		IsDefault:   false,
+		Query:       "in:snoozed",
 	},
 }
 
@@ -80,3 +88,13 @@ func IsSystemView(slug string) bool {
 	_, exists := SystemViews[slug]
 	return exists
 }
+
+// SystemViewSlugs returns the slugs of the system views, sorted
+func SystemViewSlugs() []string {
+	slugs := make([]string, 0, len(SystemViews))
+	for slug := range SystemViews {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
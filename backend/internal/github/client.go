@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,28 +31,50 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/github/types"
 )
 
-const (
-	defaultPerPage = 50
-	githubAPIBase  = "https://api.github.com"
-)
+const defaultPerPage = 50
 
 // clientImpl wraps calls to the GitHub API for interacting with the Notifications API.
 type clientImpl struct {
 	httpClient *http.Client
 	baseURL    string
+	webURL     string
 	perPage    int
 	token      string
+	viewer     string // Login of the token's user, set when the token is validated
+	rateLimits *rateLimitTransport
+}
+
+// ClientOption configures a client built by NewClient
+type ClientOption func(*clientImpl)
+
+// WithEndpoints points the client at a GitHub instance other than github.com, such as a
+// GitHub Enterprise Server (see NewEndpoints).
+func WithEndpoints(endpoints types.Endpoints) ClientOption {
+	return func(c *clientImpl) {
+		c.baseURL = endpoints.APIURL
+		c.webURL = endpoints.WebURL
+	}
 }
 
-// NewClient constructs an HTTP-backed GitHub client.
-func NewClient() githubinterfaces.Client {
-	return &clientImpl{
+// NewClient constructs an HTTP-backed GitHub client, talking to github.com by default.
+// Every request goes through a transport that tracks GitHub's rate limit, so calls made
+// while it's exhausted fail fast with a RateLimitedError.
+func NewClient(opts ...ClientOption) githubinterfaces.Client {
+	rateLimits := newRateLimitTransport(http.DefaultTransport)
+	c := &clientImpl{
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: rateLimits,
 		},
-		baseURL: githubAPIBase,
-		perPage: defaultPerPage,
+		baseURL:    DefaultAPIURL,
+		webURL:     DefaultWebURL,
+		perPage:    defaultPerPage,
+		rateLimits: rateLimits,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // SetToken sets the GitHub token directly and validates it.
@@ -93,9 +116,34 @@ func (c *clientImpl) validateToken(ctx context.Context) error {
 		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
+	// Remember who the token belongs to, for review-requested:@me
+	var user types.SimpleUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err == nil {
+		c.viewer = user.Login
+	}
+
 	return nil
 }
 
+// ViewerLogin returns the login of the token's user, or "" if no token has been validated.
+func (c *clientImpl) ViewerLogin() string {
+	return c.viewer
+}
+
+// RateLimit returns the rate limit reported by the latest GitHub response, or the zero
+// value if there hasn't been one.
+func (c *clientImpl) RateLimit() types.RateLimit {
+	if c.rateLimits == nil {
+		return types.RateLimit{}
+	}
+	return c.rateLimits.RateLimit()
+}
+
+// Endpoints returns the base URLs of the GitHub instance the client talks to.
+func (c *clientImpl) Endpoints() types.Endpoints {
+	return types.Endpoints{APIURL: c.baseURL, WebURL: c.webURL}
+}
+
 // WithPerPage allows the page size to be adjusted for fetching notifications.
 func (c *clientImpl) WithPerPage(perPage int) githubinterfaces.Client {
 	if perPage > 0 {
@@ -128,14 +176,43 @@ func (c *clientImpl) FetchNotifications(
 	before *time.Time,
 	unreadOnly bool,
 ) ([]types.NotificationThread, error) {
+	poll, err := c.fetchNotifications(ctx, since, before, unreadOnly, types.PollValidators{})
+	if err != nil {
+		return nil, err
+	}
+	return poll.Threads, nil
+}
+
+// PollNotifications retrieves notification threads updated since the given instant, like
+// FetchNotifications, as a conditional request: the validators of the previous poll are sent
+// with the first page, and a 304 Not Modified response is reported as NotModified with no
+// threads. The response's validators and X-Poll-Interval are returned for the next poll.
+func (c *clientImpl) PollNotifications(
+	ctx context.Context,
+	since *time.Time,
+	unreadOnly bool,
+	validators types.PollValidators,
+) (types.NotificationPoll, error) {
+	return c.fetchNotifications(ctx, since, nil, unreadOnly, validators)
+}
+
+// fetchNotifications pages through the notifications endpoint. Only the first page is
+// conditional, and only its headers describe the poll.
+func (c *clientImpl) fetchNotifications(
+	ctx context.Context,
+	since *time.Time,
+	before *time.Time,
+	unreadOnly bool,
+	validators types.PollValidators,
+) (types.NotificationPoll, error) {
 	perPage := c.perPage
 	if perPage <= 0 {
 		perPage = defaultPerPage
 	}
 
 	var (
-		allNotifications []types.NotificationThread
-		page             = 1
+		poll types.NotificationPoll
+		page = 1
 	)
 
 	// all=true fetches all notifications (read and unread)
@@ -161,27 +238,56 @@ func (c *clientImpl) FetchNotifications(
 
 		req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
 		if err != nil {
-			return nil, fmt.Errorf("github: create request: %w", err)
+			return types.NotificationPoll{}, fmt.Errorf("github: create request: %w", err)
 		}
 
 		req.Header.Set("Authorization", "Bearer "+c.token)
 		req.Header.Set("Accept", "application/vnd.github+json")
 		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if page == 1 {
+			if validators.ETag != "" {
+				req.Header.Set("If-None-Match", validators.ETag)
+			}
+			if validators.LastModified != "" {
+				req.Header.Set("If-Modified-Since", validators.LastModified)
+			}
+		}
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			return nil, fmt.Errorf("github: fetch notifications page %d: %w", page, err)
+			return types.NotificationPoll{}, fmt.Errorf(
+				"github: fetch notifications page %d: %w",
+				page,
+				err,
+			)
 		}
 
 		payload, err := io.ReadAll(resp.Body)
 		_ = resp.Body.Close() // Body already read, safe to ignore error
 
 		if err != nil {
-			return nil, fmt.Errorf("github: read response body page %d: %w", page, err)
+			return types.NotificationPoll{}, fmt.Errorf(
+				"github: read response body page %d: %w",
+				page,
+				err,
+			)
+		}
+
+		if page == 1 {
+			poll.PollInterval = parsePollInterval(resp.Header.Get("X-Poll-Interval"))
+			if resp.StatusCode == http.StatusNotModified {
+				poll.NotModified = true
+				poll.Validators = validators
+				return poll, nil
+			}
+			poll.Validators = types.PollValidators{
+				ETag:         resp.Header.Get("ETag"),
+				LastModified: resp.Header.Get("Last-Modified"),
+			}
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf(
+			return types.NotificationPoll{}, fmt.Errorf(
 				"github: API returned status %d: %s",
 				resp.StatusCode,
 				string(payload),
@@ -194,7 +300,11 @@ func (c *clientImpl) FetchNotifications(
 
 		var pageItems []types.NotificationThread
 		if err := json.Unmarshal(payload, &pageItems); err != nil {
-			return nil, fmt.Errorf("github: decode notifications page %d: %w", page, err)
+			return types.NotificationPoll{}, fmt.Errorf(
+				"github: decode notifications page %d: %w",
+				page,
+				err,
+			)
 		}
 
 		if len(pageItems) == 0 {
@@ -204,12 +314,15 @@ func (c *clientImpl) FetchNotifications(
 		for i := range pageItems {
 			raw, err := json.Marshal(pageItems[i])
 			if err != nil {
-				return nil, fmt.Errorf("github: encode raw notification payload: %w", err)
+				return types.NotificationPoll{}, fmt.Errorf(
+					"github: encode raw notification payload: %w",
+					err,
+				)
 			}
 			pageItems[i].Raw = raw
 		}
 
-		allNotifications = append(allNotifications, pageItems...)
+		poll.Threads = append(poll.Threads, pageItems...)
 
 		if len(pageItems) < perPage {
 			break
@@ -218,7 +331,17 @@ func (c *clientImpl) FetchNotifications(
 		page++
 	}
 
-	return allNotifications, nil
+	return poll, nil
+}
+
+// parsePollInterval parses an X-Poll-Interval header (seconds), returning 0 if it's missing
+// or invalid
+func parsePollInterval(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
 }
 
 // FetchSubjectRaw retrieves the raw JSON payload for a notification subject.
@@ -350,6 +473,94 @@ func (c *clientImpl) FetchPullRequestReviews(
 	return reviews, nil
 }
 
+// FetchCheckRuns retrieves the check runs for a commit (SHA, branch or tag).
+func (c *clientImpl) FetchCheckRuns(
+	ctx context.Context,
+	owner, repo, ref string,
+) ([]types.CheckRun, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/commits/%s/check-runs?per_page=100",
+		c.baseURL, owner, repo, ref)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("github: create check runs request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: fetch check runs: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Error can be ignored in defer
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("github: read check runs body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github: check runs status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var list types.CheckRunList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("github: unmarshal check runs: %w", err)
+	}
+
+	return list.CheckRuns, nil
+}
+
+// FetchCombinedStatus retrieves the combined commit status for a commit (SHA, branch or tag).
+func (c *clientImpl) FetchCombinedStatus(
+	ctx context.Context,
+	owner, repo, ref string,
+) (types.CombinedStatus, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/commits/%s/status?per_page=100",
+		c.baseURL, owner, repo, ref)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
+	if err != nil {
+		return types.CombinedStatus{}, fmt.Errorf("github: create commit status request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return types.CombinedStatus{}, fmt.Errorf("github: fetch commit status: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Error can be ignored in defer
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return types.CombinedStatus{}, fmt.Errorf("github: read commit status body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return types.CombinedStatus{}, fmt.Errorf(
+			"github: commit status %d: %s",
+			resp.StatusCode,
+			string(body),
+		)
+	}
+
+	var status types.CombinedStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return types.CombinedStatus{}, fmt.Errorf("github: unmarshal commit status: %w", err)
+	}
+
+	return status, nil
+}
+
 // FetchTimeline retrieves timeline events for an issue or pull request.
 func (c *clientImpl) FetchTimeline(
 	ctx context.Context,
@@ -392,3 +603,82 @@ func (c *clientImpl) FetchTimeline(
 
 	return timeline, nil
 }
+
+// MarkThreadRead marks a notification thread as read on GitHub.
+func (c *clientImpl) MarkThreadRead(ctx context.Context, threadID string) error {
+	return c.updateThread(
+		ctx,
+		http.MethodPatch,
+		"/notifications/threads/"+threadID,
+		nil,
+		"mark thread read",
+	)
+}
+
+// MarkThreadDone marks a notification thread as done on GitHub, removing it from the inbox.
+func (c *clientImpl) MarkThreadDone(ctx context.Context, threadID string) error {
+	return c.updateThread(
+		ctx,
+		http.MethodDelete,
+		"/notifications/threads/"+threadID,
+		nil,
+		"mark thread done",
+	)
+}
+
+// IgnoreThread unsubscribes from a notification thread on GitHub, so it no longer
+// notifies until the user is mentioned or comments.
+func (c *clientImpl) IgnoreThread(ctx context.Context, threadID string) error {
+	return c.updateThread(
+		ctx,
+		http.MethodPut,
+		"/notifications/threads/"+threadID+"/subscription",
+		map[string]bool{"ignored": true},
+		"ignore thread",
+	)
+}
+
+// updateThread sends a request that changes a notification thread, failing on any
+// non-2xx status. what describes the change for errors.
+func (c *clientImpl) updateThread(
+	ctx context.Context,
+	method, path string,
+	payload any,
+	what string,
+) error {
+	var body io.Reader = http.NoBody
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("github: marshal %s request: %w", what, err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("github: create %s request: %w", what, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("github: %s: %w", what, err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Error can be ignored in defer
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("github: %s status %d: %s", what, resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -25,16 +26,20 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
 )
 
 const testToken = "test_token"
 
 // newTestClient creates a clientImpl configured to use a test server.
 func newTestClient(serverURL string) *clientImpl {
+	rateLimits := newRateLimitTransport(nil)
 	return &clientImpl{
-		httpClient: &http.Client{Timeout: 5 * time.Second},
+		httpClient: &http.Client{Timeout: 5 * time.Second, Transport: rateLimits},
 		baseURL:    serverURL,
 		perPage:    defaultPerPage,
+		rateLimits: rateLimits,
 	}
 }
 
@@ -111,6 +116,7 @@ func TestSetToken(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tt.token, client.token)
+				require.Equal(t, "testuser", client.ViewerLogin())
 			}
 		})
 	}
@@ -463,6 +469,136 @@ func TestFetchNotifications_BeforeParameter(t *testing.T) {
 	}
 }
 
+func TestPollNotifications(t *testing.T) {
+	previous := types.PollValidators{
+		ETag:         `W/"abc"`,
+		LastModified: "Mon, 15 Jan 2024 10:00:00 GMT",
+	}
+
+	tests := []struct {
+		name         string
+		validators   types.PollValidators
+		serverStatus int
+		serverBody   string
+		headers      map[string]string
+		expectHeader map[string]string
+		wantErr      bool
+		checkResult  func(*testing.T, types.NotificationPoll)
+	}{
+		{
+			name:         "first poll sends no validators and returns the response's",
+			serverStatus: http.StatusOK,
+			serverBody:   `[{"id": "1", "reason": "mention"}]`,
+			headers: map[string]string{
+				"ETag":            `W/"def"`,
+				"Last-Modified":   "Tue, 16 Jan 2024 10:00:00 GMT",
+				"X-Poll-Interval": "60",
+			},
+			expectHeader: map[string]string{"If-None-Match": "", "If-Modified-Since": ""},
+			checkResult: func(t *testing.T, poll types.NotificationPoll) {
+				require.False(t, poll.NotModified)
+				require.Len(t, poll.Threads, 1)
+				require.Equal(t, `W/"def"`, poll.Validators.ETag)
+				require.Equal(t, "Tue, 16 Jan 2024 10:00:00 GMT", poll.Validators.LastModified)
+				require.Equal(t, time.Minute, poll.PollInterval)
+			},
+		},
+		{
+			name:         "304 is reported as not modified and keeps the validators",
+			validators:   previous,
+			serverStatus: http.StatusNotModified,
+			headers:      map[string]string{"X-Poll-Interval": "120"},
+			expectHeader: map[string]string{
+				"If-None-Match":     previous.ETag,
+				"If-Modified-Since": previous.LastModified,
+			},
+			checkResult: func(t *testing.T, poll types.NotificationPoll) {
+				require.True(t, poll.NotModified)
+				require.Empty(t, poll.Threads)
+				require.Equal(t, previous, poll.Validators)
+				require.Equal(t, 2*time.Minute, poll.PollInterval)
+			},
+		},
+		{
+			name:         "invalid poll interval is ignored",
+			serverStatus: http.StatusOK,
+			serverBody:   `[]`,
+			headers:      map[string]string{"X-Poll-Interval": "soon"},
+			checkResult: func(t *testing.T, poll types.NotificationPoll) {
+				require.Zero(t, poll.PollInterval)
+			},
+		},
+		{
+			name:         "error status returns error",
+			validators:   previous,
+			serverStatus: http.StatusForbidden,
+			serverBody:   `{"message": "rate limited"}`,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					for name, value := range tt.expectHeader {
+						require.Equal(t, value, r.Header.Get(name), "header %s", name)
+					}
+					for name, value := range tt.headers {
+						w.Header().Set(name, value)
+					}
+					w.WriteHeader(tt.serverStatus)
+					_, _ = w.Write([]byte(tt.serverBody))
+				}),
+			)
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			client.token = testToken
+
+			poll, err := client.PollNotifications(context.Background(), nil, false, tt.validators)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.checkResult(t, poll)
+		})
+	}
+}
+
+func TestPollNotifications_OnlyFirstPageIsConditional(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			require.Equal(t, `"abc"`, r.Header.Get("If-None-Match"))
+			w.Header().Set("ETag", `"def"`)
+			_, _ = w.Write([]byte(`[{"id": "1"}, {"id": "2"}]`))
+			return
+		}
+		require.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"page-2"`)
+		_, _ = w.Write([]byte(`[{"id": "3"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+	client.perPage = 2
+
+	poll, err := client.PollNotifications(
+		context.Background(),
+		nil,
+		false,
+		types.PollValidators{ETag: `"abc"`},
+	)
+	require.NoError(t, err)
+	require.Len(t, poll.Threads, 3)
+	require.Equal(t, `"def"`, poll.Validators.ETag)
+	require.Equal(t, 2, requests)
+}
+
 func TestFetchSubjectRaw(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -775,6 +911,150 @@ func TestFetchPullRequestReviews(t *testing.T) {
 	}
 }
 
+func TestFetchCheckRuns(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverStatus   int
+		serverResponse string
+		wantCount      int
+		wantErr        bool
+		errContains    string
+	}{
+		{
+			name:         "successful fetch",
+			serverStatus: http.StatusOK,
+			serverResponse: `{"total_count": 2, "check_runs": [
+				{"id": 1, "name": "build", "status": "completed", "conclusion": "success"},
+				{"id": 2, "name": "lint", "status": "in_progress", "conclusion": null}
+			]}`,
+			wantCount: 2,
+			wantErr:   false,
+		},
+		{
+			name:           "not found",
+			serverStatus:   http.StatusNotFound,
+			serverResponse: `{"message": "Not Found"}`,
+			wantErr:        true,
+			errContains:    "check runs status 404",
+		},
+		{
+			name:           "invalid JSON",
+			serverStatus:   http.StatusOK,
+			serverResponse: `{invalid}`,
+			wantErr:        true,
+			errContains:    "unmarshal check runs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					require.Equal(
+						t,
+						"/repos/testowner/testrepo/commits/abc123/check-runs",
+						r.URL.Path,
+					)
+
+					w.WriteHeader(tt.serverStatus)
+					_, err := w.Write([]byte(tt.serverResponse))
+					assert.NoError(t, err, "failed to write response in test server")
+				}),
+			)
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			client.token = testToken
+
+			runs, err := client.FetchCheckRuns(
+				context.Background(),
+				"testowner",
+				"testrepo",
+				"abc123",
+			)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, runs, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestFetchCombinedStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverStatus   int
+		serverResponse string
+		wantState      string
+		wantCount      int
+		wantErr        bool
+		errContains    string
+	}{
+		{
+			name:         "successful fetch",
+			serverStatus: http.StatusOK,
+			serverResponse: `{"state": "pending", "total_count": 1, "statuses": [
+				{"context": "ci/jenkins", "state": "pending"}
+			]}`,
+			wantState: "pending",
+			wantCount: 1,
+			wantErr:   false,
+		},
+		{
+			name:           "not found",
+			serverStatus:   http.StatusNotFound,
+			serverResponse: `{"message": "Not Found"}`,
+			wantErr:        true,
+			errContains:    "commit status 404",
+		},
+		{
+			name:           "invalid JSON",
+			serverStatus:   http.StatusOK,
+			serverResponse: `{invalid}`,
+			wantErr:        true,
+			errContains:    "unmarshal commit status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					require.Equal(t, "/repos/testowner/testrepo/commits/abc123/status", r.URL.Path)
+
+					w.WriteHeader(tt.serverStatus)
+					_, err := w.Write([]byte(tt.serverResponse))
+					assert.NoError(t, err, "failed to write response in test server")
+				}),
+			)
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			client.token = testToken
+
+			status, err := client.FetchCombinedStatus(
+				context.Background(),
+				"testowner",
+				"testrepo",
+				"abc123",
+			)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.wantState, status.State)
+				require.Len(t, status.Statuses, tt.wantCount)
+			}
+		})
+	}
+}
+
 func TestFetchTimeline(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -926,6 +1206,81 @@ func TestFetchTimeline_EventTypes(t *testing.T) {
 	require.Equal(t, "xyz789", timeline[3].CommitID)
 }
 
+func TestUpdateThread(t *testing.T) {
+	tests := []struct {
+		name         string
+		update       func(client *clientImpl) error
+		expectedVerb string
+		expectedPath string
+		expectedBody string
+	}{
+		{
+			name: "MarkThreadRead patches the thread",
+			update: func(client *clientImpl) error {
+				return client.MarkThreadRead(context.Background(), "123")
+			},
+			expectedVerb: http.MethodPatch,
+			expectedPath: "/notifications/threads/123",
+		},
+		{
+			name: "MarkThreadDone deletes the thread",
+			update: func(client *clientImpl) error {
+				return client.MarkThreadDone(context.Background(), "123")
+			},
+			expectedVerb: http.MethodDelete,
+			expectedPath: "/notifications/threads/123",
+		},
+		{
+			name: "IgnoreThread ignores the subscription",
+			update: func(client *clientImpl) error {
+				return client.IgnoreThread(context.Background(), "123")
+			},
+			expectedVerb: http.MethodPut,
+			expectedPath: "/notifications/threads/123/subscription",
+			expectedBody: `{"ignored":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					require.Equal(t, tt.expectedVerb, r.Method)
+					require.Equal(t, tt.expectedPath, r.URL.Path)
+					require.Equal(t, "Bearer test_token", r.Header.Get("Authorization"))
+
+					body, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					require.Equal(t, tt.expectedBody, string(body))
+
+					w.WriteHeader(http.StatusResetContent)
+				}),
+			)
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			client.token = testToken
+
+			require.NoError(t, tt.update(client))
+		})
+	}
+}
+
+func TestUpdateThread_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.token = testToken
+
+	err := client.MarkThreadRead(context.Background(), "123")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 404")
+}
+
 func TestNewClient(t *testing.T) {
 	client := NewClient()
 
@@ -935,10 +1290,50 @@ func TestNewClient(t *testing.T) {
 	impl, ok := client.(*clientImpl)
 	require.True(t, ok)
 	require.NotNil(t, impl.httpClient)
-	require.Equal(t, githubAPIBase, impl.baseURL)
+	require.Equal(t, DefaultAPIURL, impl.baseURL)
+	require.Equal(t, DefaultWebURL, impl.webURL)
 	require.Equal(t, defaultPerPage, impl.perPage)
 }
 
+func TestNewClient_WithEndpoints(t *testing.T) {
+	// A GitHub Enterprise Server serves the REST API under /api/v3
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"login": "octocat"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/api/v3/notifications", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{
+			"id": "1",
+			"repository": {"full_name": "corp/app"},
+			"subject": {
+				"title": "Fix it",
+				"type": "Issue",
+				"url": "` + server.URL + `/api/v3/repos/corp/app/issues/42"
+			}
+		}]`))
+	})
+
+	endpoints := NewEndpoints(server.URL+"/api/v3/", "")
+	client := NewClient(WithEndpoints(endpoints))
+	require.Equal(t, server.URL+"/api/v3", client.Endpoints().APIURL)
+	require.Equal(t, server.URL, client.Endpoints().WebURL)
+
+	require.NoError(t, client.SetToken(context.Background(), testToken))
+	require.Equal(t, "octocat", client.ViewerLogin())
+
+	threads, err := client.FetchNotifications(context.Background(), nil, nil, false)
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+
+	info, err := ExtractSubjectInfo(client.Endpoints(), threads[0].Subject.URL, nil)
+	require.NoError(t, err)
+	require.Equal(t, "corp", info.Owner)
+	require.Equal(t, "app", info.Repo)
+	require.Equal(t, 42, info.Number)
+}
+
 func TestFetchNotifications_ContextCancellation(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		// Simulate slow response
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+)
+
+// Base URLs of github.com
+const (
+	DefaultAPIURL = "https://api.github.com"
+	DefaultWebURL = "https://github.com"
+)
+
+// gheAPIPath is where GitHub Enterprise Server serves the REST API under its web URL
+const gheAPIPath = "/api/v3"
+
+// DefaultEndpoints returns the endpoints of github.com.
+func DefaultEndpoints() types.Endpoints {
+	return types.Endpoints{APIURL: DefaultAPIURL, WebURL: DefaultWebURL}
+}
+
+// NewEndpoints builds the endpoints of a GitHub instance from its API and web base URLs.
+// An empty API URL means github.com. An empty web URL is derived from the API URL: for
+// GitHub Enterprise Server (https://github.example.com/api/v3) it's the URL without /api/v3.
+func NewEndpoints(apiURL, webURL string) types.Endpoints {
+	apiURL = strings.TrimRight(strings.TrimSpace(apiURL), "/")
+	webURL = strings.TrimRight(strings.TrimSpace(webURL), "/")
+
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	if webURL == "" {
+		webURL = deriveWebURL(apiURL)
+	}
+	return types.Endpoints{APIURL: apiURL, WebURL: webURL}
+}
+
+// deriveWebURL guesses the web URL of the instance serving the API at apiURL
+func deriveWebURL(apiURL string) string {
+	if apiURL == DefaultAPIURL {
+		return DefaultWebURL
+	}
+	if strings.HasSuffix(apiURL, gheAPIPath) {
+		return strings.TrimSuffix(apiURL, gheAPIPath)
+	}
+
+	// Some other layout (e.g. a proxy): fall back to the API's origin
+	parsed, err := url.Parse(apiURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return apiURL
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// graphQLURL returns the GraphQL endpoint of the instance serving the REST API at apiURL:
+// {api}/graphql on github.com, and /api/graphql beside /api/v3 on GitHub Enterprise Server
+func graphQLURL(apiURL string) string {
+	if strings.HasSuffix(apiURL, gheAPIPath) {
+		return strings.TrimSuffix(apiURL, gheAPIPath) + "/api/graphql"
+	}
+	return apiURL + "/graphql"
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+)
+
+func TestNewEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiURL   string
+		webURL   string
+		expected types.Endpoints
+	}{
+		{
+			name:     "defaults to github.com",
+			expected: DefaultEndpoints(),
+		},
+		{
+			name:     "github.com API URL",
+			apiURL:   "https://api.github.com/",
+			expected: DefaultEndpoints(),
+		},
+		{
+			name:   "GitHub Enterprise Server web URL is derived",
+			apiURL: "https://github.example.com/api/v3/",
+			expected: types.Endpoints{
+				APIURL: "https://github.example.com/api/v3",
+				WebURL: "https://github.example.com",
+			},
+		},
+		{
+			name:   "other API paths fall back to the origin",
+			apiURL: "http://localhost:9000/github",
+			expected: types.Endpoints{
+				APIURL: "http://localhost:9000/github",
+				WebURL: "http://localhost:9000",
+			},
+		},
+		{
+			name:   "explicit web URL",
+			apiURL: "https://github.example.com/api/v3",
+			webURL: " https://code.example.com/ ",
+			expected: types.Endpoints{
+				APIURL: "https://github.example.com/api/v3",
+				WebURL: "https://code.example.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, NewEndpoints(tt.apiURL, tt.webURL))
+		})
+	}
+}
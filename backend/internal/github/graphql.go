@@ -0,0 +1,304 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+)
+
+// hydrateBatchSize is the number of subjects fetched per GraphQL query. Each pull request
+// brings up to 100 reviews and 100 checks, which keeps a query well within GitHub's node limit.
+const hydrateBatchSize = 50
+
+// Selections of the subject fields read by sync, by subject type
+const (
+	graphQLActorFields = `login avatarUrl url __typename ... on User { databaseId } ` +
+		`... on Bot { databaseId }`
+	issueFragment = `fragment issueFields on Issue {
+  id databaseId number title body url state stateReason createdAt updatedAt closedAt
+  author { ` + graphQLActorFields + ` }
+  labels(first: 100) { nodes { name color } }
+  milestone { title number state }
+  assignees(first: 100) { nodes { ` + graphQLActorFields + ` } }
+  comments { totalCount }
+}`
+	pullRequestFragment = `fragment pullRequestFields on PullRequest {
+  id databaseId number title body url state createdAt updatedAt closedAt mergedAt merged isDraft
+  author { ` + graphQLActorFields + ` }
+  labels(first: 100) { nodes { name color } }
+  milestone { title number state }
+  assignees(first: 100) { nodes { ` + graphQLActorFields + ` } }
+  comments { totalCount }
+  headRefName headRefOid baseRefName additions deletions changedFiles
+  reviewRequests(first: 100) {
+    nodes { requestedReviewer { __typename ... on User { login } ... on Team { slug } } }
+  }
+  latestOpinionatedReviews(first: 100) {
+    nodes { databaseId state submittedAt url author { login } }
+  }
+  commits(last: 1) { nodes { commit { statusCheckRollup { contexts(first: 100) { nodes {
+    __typename
+    ... on CheckRun { databaseId name status conclusion detailsUrl }
+    ... on StatusContext { context state }
+  } } } } } }
+}`
+	discussionFragment = `fragment discussionFields on Discussion {
+  id databaseId number title body url closed stateReason createdAt updatedAt closedAt
+  answerChosenAt
+  author { ` + graphQLActorFields + ` }
+  labels(first: 100) { nodes { name color } }
+  category { name }
+  comments { totalCount }
+}`
+)
+
+// HydrateSubjects fetches subjects through the GraphQL API, hydrateBatchSize per query,
+// grouped by repository. The result is keyed by the refs passed in. Subjects GitHub can't
+// resolve are left out; a failed query fails the whole call.
+func (c *clientImpl) HydrateSubjects(
+	ctx context.Context,
+	refs []types.SubjectRef,
+) (map[types.SubjectRef]types.HydratedSubject, error) {
+	subjects := make(map[types.SubjectRef]types.HydratedSubject, len(refs))
+	for _, batch := range hydrateBatches(refs) {
+		data, err := c.queryGraphQL(ctx, buildHydrateQuery(batch), nil)
+		if err != nil {
+			return nil, err
+		}
+		for repoIndex, repoRefs := range batch {
+			var repo map[string]json.RawMessage
+			if raw, ok := data[repoAlias(repoIndex)]; ok && len(raw) > 0 {
+				if err := json.Unmarshal(raw, &repo); err != nil {
+					return nil, fmt.Errorf("github: decode repository subjects: %w", err)
+				}
+			}
+			for subjectIndex, ref := range repoRefs {
+				raw := repo[subjectAlias(subjectIndex)]
+				if len(raw) == 0 || string(raw) == "null" {
+					continue
+				}
+				var node graphQLSubject
+				if err := json.Unmarshal(raw, &node); err != nil {
+					return nil, fmt.Errorf("github: decode subject: %w", err)
+				}
+				subject, err := node.hydrated(ref.Type, c.subjectAPIURL(ref))
+				if err != nil {
+					return nil, err
+				}
+				subjects[ref] = subject
+			}
+		}
+	}
+	return subjects, nil
+}
+
+// hydrateBatches splits refs into batches of at most hydrateBatchSize subjects. Each batch
+// lists the refs of every repository it covers, so a query looks each repository up once.
+func hydrateBatches(refs []types.SubjectRef) [][][]types.SubjectRef {
+	var (
+		order  []string
+		byRepo = make(map[string][]types.SubjectRef)
+		seen   = make(map[types.SubjectRef]bool)
+	)
+	for _, ref := range refs {
+		if seen[ref] || ref.Owner == "" || ref.Repo == "" || ref.Number <= 0 {
+			continue
+		}
+		seen[ref] = true
+		key := strings.ToLower(ref.Owner + "/" + ref.Repo)
+		if _, ok := byRepo[key]; !ok {
+			order = append(order, key)
+		}
+		byRepo[key] = append(byRepo[key], ref)
+	}
+
+	var (
+		batches [][][]types.SubjectRef
+		batch   [][]types.SubjectRef
+		size    int
+	)
+	for _, key := range order {
+		repoRefs := byRepo[key]
+		for len(repoRefs) > 0 {
+			n := min(len(repoRefs), hydrateBatchSize-size)
+			batch = append(batch, repoRefs[:n])
+			repoRefs = repoRefs[n:]
+			size += n
+			if size == hydrateBatchSize {
+				batches = append(batches, batch)
+				batch, size = nil, 0
+			}
+		}
+	}
+	if size > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func repoAlias(index int) string    { return "r" + strconv.Itoa(index) }
+func subjectAlias(index int) string { return "s" + strconv.Itoa(index) }
+
+// buildHydrateQuery builds the GraphQL query for a batch, aliasing each repository and
+// subject by its position in the batch
+func buildHydrateQuery(batch [][]types.SubjectRef) string {
+	var query strings.Builder
+	used := make(map[string]bool)
+
+	query.WriteString("query {\n")
+	for repoIndex, repoRefs := range batch {
+		fmt.Fprintf(&query, "  %s: repository(owner: %s, name: %s) {\n",
+			repoAlias(repoIndex), graphQLString(repoRefs[0].Owner), graphQLString(repoRefs[0].Repo))
+		for subjectIndex, ref := range repoRefs {
+			field, fragment := "issue", "issueFields"
+			switch ref.Type {
+			case types.SubjectTypePullRequest:
+				field, fragment = "pullRequest", "pullRequestFields"
+			case types.SubjectTypeDiscussion:
+				field, fragment = "discussion", "discussionFields"
+			}
+			used[fragment] = true
+			fmt.Fprintf(&query, "    %s: %s(number: %d) { ...%s }\n",
+				subjectAlias(subjectIndex), field, ref.Number, fragment)
+		}
+		query.WriteString("  }\n")
+	}
+	query.WriteString("}\n")
+
+	// GitHub rejects queries defining fragments they don't use
+	for _, fragment := range []struct{ name, text string }{
+		{"issueFields", issueFragment},
+		{"pullRequestFields", pullRequestFragment},
+		{"discussionFields", discussionFragment},
+	} {
+		if used[fragment.name] {
+			query.WriteString(fragment.text)
+			query.WriteString("\n")
+		}
+	}
+	return query.String()
+}
+
+// graphQLString quotes s as a GraphQL string literal, whose escapes match JSON's
+func graphQLString(s string) string {
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		return `""`
+	}
+	return string(quoted)
+}
+
+// graphQLError is an error reported in a GraphQL response
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// graphQLRequest is the body of a GraphQL query
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// queryGraphQL runs a query and returns the fields of its data. Errors about parts of the
+// query (such as a subject that no longer exists) leave those fields null and are ignored;
+// a query returning no data at all fails.
+func (c *clientImpl) queryGraphQL(
+	ctx context.Context,
+	query string,
+	variables map[string]any,
+) (map[string]json.RawMessage, error) {
+	payload, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, fmt.Errorf("github: marshal GraphQL query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		graphQLURL(c.baseURL),
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("github: create GraphQL request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: GraphQL query: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close() // Error can be ignored in defer
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("github: read GraphQL body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github: GraphQL status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Data   map[string]json.RawMessage `json:"data"`
+		Errors []graphQLError             `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("github: unmarshal GraphQL body: %w", err)
+	}
+
+	// The GraphQL budget running out is reported as an error in a 200 response
+	for _, graphQLErr := range result.Errors {
+		if graphQLErr.Type == "RATE_LIMITED" {
+			resetAt := time.Now().Add(secondaryLimitWait)
+			if limit, ok := parseRateLimit(resp.Header); ok {
+				resetAt = limit.ResetAt
+			}
+			return nil, &RateLimitedError{ResetAt: resetAt}
+		}
+	}
+	if result.Data == nil && len(result.Errors) > 0 {
+		return nil, fmt.Errorf("github: GraphQL query failed: %s", result.Errors[0].Message)
+	}
+	return result.Data, nil
+}
+
+// subjectAPIURL returns the REST API URL of a subject, which notifications use as its URL
+func (c *clientImpl) subjectAPIURL(ref types.SubjectRef) string {
+	kind := "issues"
+	switch ref.Type {
+	case types.SubjectTypePullRequest:
+		kind = "pulls"
+	case types.SubjectTypeDiscussion:
+		kind = "discussions"
+	}
+	return fmt.Sprintf("%s/repos/%s/%s/%s/%d", c.baseURL, ref.Owner, ref.Repo, kind, ref.Number)
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+)
+
+// discussionCommentsPerPage is the number of comments fetched per query, and the number of
+// replies fetched per comment (GitHub's maximum for both)
+const discussionCommentsPerPage = 100
+
+// discussionCommentsQuery fetches a page of a discussion's comments, walking back from the
+// newest, with each comment's replies
+const discussionCommentsQuery = `query($owner: String!, $repo: String!, $number: Int!,
+  $last: Int!, $before: String) {
+  repository(owner: $owner, name: $repo) {
+    discussion(number: $number) {
+      comments(last: $last, before: $before) {
+        totalCount
+        pageInfo { hasPreviousPage startCursor }
+        nodes {
+          ...discussionCommentFields
+          replies(first: 100) { nodes { ...discussionCommentFields } }
+        }
+      }
+    }
+  }
+}
+fragment discussionCommentFields on DiscussionComment {
+  id body url createdAt updatedAt isAnswer upvoteCount
+  author { login avatarUrl url }
+}`
+
+// graphQLDiscussionComment is a discussion comment or reply from discussionCommentsQuery
+type graphQLDiscussionComment struct {
+	ID          string        `json:"id"`
+	Body        string        `json:"body"`
+	URL         string        `json:"url"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+	IsAnswer    bool          `json:"isAnswer"`
+	UpvoteCount int           `json:"upvoteCount"`
+	Author      *graphQLActor `json:"author"`
+	Replies     *struct {
+		Nodes []graphQLDiscussionComment `json:"nodes"`
+	} `json:"replies"`
+}
+
+// FetchDiscussionComments fetches up to limit of a discussion's latest comments, newest
+// first, each with up to 100 replies, oldest first.
+func (c *clientImpl) FetchDiscussionComments(
+	ctx context.Context,
+	owner, repo string,
+	number, limit int,
+) (types.DiscussionComments, error) {
+	var (
+		result types.DiscussionComments
+		before *string
+	)
+	for len(result.Comments) < limit {
+		data, err := c.queryGraphQL(ctx, discussionCommentsQuery, map[string]any{
+			"owner":  owner,
+			"repo":   repo,
+			"number": number,
+			"last":   min(discussionCommentsPerPage, limit-len(result.Comments)),
+			"before": before,
+		})
+		if err != nil {
+			return types.DiscussionComments{}, err
+		}
+
+		var response struct {
+			Discussion *struct {
+				Comments struct {
+					TotalCount int `json:"totalCount"`
+					PageInfo   struct {
+						HasPreviousPage bool   `json:"hasPreviousPage"`
+						StartCursor     string `json:"startCursor"`
+					} `json:"pageInfo"`
+					Nodes []graphQLDiscussionComment `json:"nodes"`
+				} `json:"comments"`
+			} `json:"discussion"`
+		}
+		if raw := data["repository"]; len(raw) > 0 {
+			if err := json.Unmarshal(raw, &response); err != nil {
+				return types.DiscussionComments{}, fmt.Errorf(
+					"github: decode discussion comments: %w",
+					err,
+				)
+			}
+		}
+		if response.Discussion == nil {
+			return types.DiscussionComments{}, fmt.Errorf(
+				"github: discussion %s/%s#%d not found",
+				owner, repo, number,
+			)
+		}
+
+		comments := response.Discussion.Comments
+		result.TotalCount = comments.TotalCount
+		// Pages list comments oldest first
+		for _, node := range slices.Backward(comments.Nodes) {
+			result.Comments = append(result.Comments, node.discussionComment())
+		}
+
+		if !comments.PageInfo.HasPreviousPage || len(comments.Nodes) == 0 {
+			break
+		}
+		before = &comments.PageInfo.StartCursor
+	}
+	return result, nil
+}
+
+// discussionComment converts a comment and its replies
+func (n graphQLDiscussionComment) discussionComment() types.DiscussionComment {
+	comment := types.DiscussionComment{
+		ID:          n.ID,
+		Body:        n.Body,
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+		HTMLURL:     n.URL,
+		IsAnswer:    n.IsAnswer,
+		UpvoteCount: n.UpvoteCount,
+	}
+	if n.Author != nil {
+		comment.User = types.SimpleUser{
+			Login:     n.Author.Login,
+			AvatarURL: n.Author.AvatarURL,
+			HTMLURL:   n.Author.URL,
+		}
+	}
+	if n.Replies != nil {
+		for _, reply := range n.Replies.Nodes {
+			comment.Replies = append(comment.Replies, reply.discussionComment())
+		}
+	}
+	return comment
+}
@@ -0,0 +1,379 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+)
+
+// graphQLActor is the author of a subject, or a user assigned to it
+type graphQLActor struct {
+	Typename   string `json:"__typename"`
+	Login      string `json:"login"`
+	AvatarURL  string `json:"avatarUrl"`
+	URL        string `json:"url"`
+	DatabaseID int64  `json:"databaseId"`
+}
+
+// graphQLCheckContext is a check run or commit status of a pull request's head commit
+type graphQLCheckContext struct {
+	Typename string `json:"__typename"`
+	// CheckRun
+	DatabaseID int64  `json:"databaseId"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	DetailsURL string `json:"detailsUrl"`
+	// StatusContext
+	Context string `json:"context"`
+	State   string `json:"state"`
+}
+
+// graphQLReviewRequest is a user or team asked to review a pull request
+type graphQLReviewRequest struct {
+	RequestedReviewer *struct {
+		Typename string `json:"__typename"`
+		Login    string `json:"login"` // User
+		Slug     string `json:"slug"`  // Team
+	} `json:"requestedReviewer"`
+}
+
+// graphQLSubject holds the fields of the issue, pull request and discussion fragments
+type graphQLSubject struct {
+	ID          string  `json:"id"`
+	DatabaseID  int64   `json:"databaseId"`
+	Number      int     `json:"number"`
+	Title       string  `json:"title"`
+	Body        string  `json:"body"`
+	URL         string  `json:"url"`
+	State       string  `json:"state"`
+	StateReason *string `json:"stateReason"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+	ClosedAt    *string `json:"closedAt"`
+
+	Author *graphQLActor `json:"author"`
+	Labels *struct {
+		Nodes []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Milestone *struct {
+		Title  string `json:"title"`
+		Number int    `json:"number"`
+		State  string `json:"state"`
+	} `json:"milestone"`
+	Assignees *struct {
+		Nodes []graphQLActor `json:"nodes"`
+	} `json:"assignees"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+
+	// Pull requests
+	Merged         bool    `json:"merged"`
+	MergedAt       *string `json:"mergedAt"`
+	IsDraft        bool    `json:"isDraft"`
+	HeadRefName    string  `json:"headRefName"`
+	HeadRefOid     string  `json:"headRefOid"`
+	BaseRefName    string  `json:"baseRefName"`
+	Additions      int     `json:"additions"`
+	Deletions      int     `json:"deletions"`
+	ChangedFiles   int     `json:"changedFiles"`
+	ReviewRequests *struct {
+		Nodes []graphQLReviewRequest `json:"nodes"`
+	} `json:"reviewRequests"`
+	LatestOpinionatedReviews *struct {
+		Nodes []struct {
+			DatabaseID  int64         `json:"databaseId"`
+			State       string        `json:"state"`
+			SubmittedAt *time.Time    `json:"submittedAt"`
+			URL         string        `json:"url"`
+			Author      *graphQLActor `json:"author"`
+		} `json:"nodes"`
+	} `json:"latestOpinionatedReviews"`
+	Commits *struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					Contexts struct {
+						Nodes []graphQLCheckContext `json:"nodes"`
+					} `json:"contexts"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+
+	// Discussions
+	Closed         bool    `json:"closed"`
+	AnswerChosenAt *string `json:"answerChosenAt"`
+	Category       *struct {
+		Name string `json:"name"`
+	} `json:"category"`
+}
+
+// restUser is a user in the shape of the REST API
+type restUser struct {
+	Login     string `json:"login"`
+	ID        int64  `json:"id,omitempty"`
+	Type      string `json:"type,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+	HTMLURL   string `json:"html_url,omitempty"`
+}
+
+// restSubject is an issue, pull request or discussion in the shape of the REST API, with
+// the fields read from subject JSON
+type restSubject struct {
+	ID          int64   `json:"id,omitempty"`
+	NodeID      string  `json:"node_id"`
+	URL         string  `json:"url"`
+	HTMLURL     string  `json:"html_url"`
+	Number      int     `json:"number"`
+	Title       string  `json:"title"`
+	Body        string  `json:"body"`
+	State       string  `json:"state"`
+	StateReason *string `json:"state_reason"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+	ClosedAt    *string `json:"closed_at"`
+
+	User      *restUser      `json:"user"`
+	Labels    []restLabel    `json:"labels"`
+	Milestone *restMilestone `json:"milestone"`
+	Assignees *[]restUser    `json:"assignees,omitempty"`
+	Comments  int            `json:"comments"`
+
+	// Pull requests
+	Draft              *bool       `json:"draft,omitempty"`
+	Merged             *bool       `json:"merged,omitempty"`
+	MergedAt           *string     `json:"merged_at,omitempty"`
+	Head               *restRef    `json:"head,omitempty"`
+	Base               *restRef    `json:"base,omitempty"`
+	Additions          *int        `json:"additions,omitempty"`
+	Deletions          *int        `json:"deletions,omitempty"`
+	ChangedFiles       *int        `json:"changed_files,omitempty"`
+	RequestedReviewers *[]restUser `json:"requested_reviewers,omitempty"`
+	RequestedTeams     *[]restTeam `json:"requested_teams,omitempty"`
+
+	// Discussions
+	AnswerChosenAt *string       `json:"answer_chosen_at,omitempty"`
+	Category       *restCategory `json:"category,omitempty"`
+}
+
+type restLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type restMilestone struct {
+	Title  string `json:"title"`
+	Number int    `json:"number"`
+	State  string `json:"state"`
+}
+
+type restRef struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha,omitempty"`
+}
+
+type restTeam struct {
+	Slug string `json:"slug"`
+}
+
+type restCategory struct {
+	Name string `json:"name"`
+}
+
+// restUserFrom converts a GraphQL actor. Deleted users ("ghost") come back as nil.
+func restUserFrom(actor *graphQLActor) *restUser {
+	if actor == nil || actor.Login == "" {
+		return nil
+	}
+	return &restUser{
+		Login:     actor.Login,
+		ID:        actor.DatabaseID,
+		Type:      actor.Typename,
+		AvatarURL: actor.AvatarURL,
+		HTMLURL:   actor.URL,
+	}
+}
+
+// lowerPtr lowercases a GraphQL enum value, as the REST API spells them
+func lowerPtr(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	lower := strings.ToLower(*value)
+	return &lower
+}
+
+// hydrated converts a subject of the given type to REST-shaped JSON, with a pull request's
+// reviews and checks alongside. apiURL is the subject's REST API URL.
+func (s graphQLSubject) hydrated(
+	subjectType string,
+	apiURL string,
+) (types.HydratedSubject, error) {
+	rest := restSubject{
+		ID:          s.DatabaseID,
+		NodeID:      s.ID,
+		URL:         apiURL,
+		HTMLURL:     s.URL,
+		Number:      s.Number,
+		Title:       s.Title,
+		Body:        s.Body,
+		State:       strings.ToLower(s.State),
+		StateReason: lowerPtr(s.StateReason),
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+		ClosedAt:    s.ClosedAt,
+		User:        restUserFrom(s.Author),
+		Labels:      []restLabel{},
+		Comments:    s.Comments.TotalCount,
+	}
+	if s.Labels != nil {
+		for _, label := range s.Labels.Nodes {
+			rest.Labels = append(rest.Labels, restLabel{Name: label.Name, Color: label.Color})
+		}
+	}
+	if s.Milestone != nil {
+		rest.Milestone = &restMilestone{
+			Title:  s.Milestone.Title,
+			Number: s.Milestone.Number,
+			State:  strings.ToLower(s.Milestone.State),
+		}
+	}
+	if s.Assignees != nil {
+		assignees := []restUser{}
+		for i := range s.Assignees.Nodes {
+			if user := restUserFrom(&s.Assignees.Nodes[i]); user != nil {
+				assignees = append(assignees, *user)
+			}
+		}
+		rest.Assignees = &assignees
+	}
+
+	var subject types.HydratedSubject
+	switch subjectType {
+	case types.SubjectTypeDiscussion:
+		// Discussions are open or closed, with no state field
+		rest.State = "open"
+		if s.Closed {
+			rest.State = "closed"
+		}
+		rest.AnswerChosenAt = s.AnswerChosenAt
+		if s.Category != nil {
+			rest.Category = &restCategory{Name: s.Category.Name}
+		}
+	case types.SubjectTypePullRequest:
+		subject = s.pullRequest(&rest)
+	}
+
+	raw, err := json.Marshal(rest)
+	if err != nil {
+		return types.HydratedSubject{}, fmt.Errorf("github: encode hydrated subject: %w", err)
+	}
+	subject.Raw = raw
+	return subject, nil
+}
+
+// pullRequest fills in the pull request fields of rest, and returns the reviews and checks
+func (s graphQLSubject) pullRequest(rest *restSubject) types.HydratedSubject {
+	// MERGED is closed, with merged set
+	if rest.State == "merged" {
+		rest.State = "closed"
+	}
+	rest.Draft = &s.IsDraft
+	rest.Merged = &s.Merged
+	rest.MergedAt = s.MergedAt
+	rest.Head = &restRef{Ref: s.HeadRefName, SHA: s.HeadRefOid}
+	rest.Base = &restRef{Ref: s.BaseRefName}
+	rest.Additions = &s.Additions
+	rest.Deletions = &s.Deletions
+	rest.ChangedFiles = &s.ChangedFiles
+
+	reviewers, teams := []restUser{}, []restTeam{}
+	var requests []graphQLReviewRequest
+	if s.ReviewRequests != nil {
+		requests = s.ReviewRequests.Nodes
+	}
+	for _, request := range requests {
+		switch reviewer := request.RequestedReviewer; {
+		case reviewer == nil:
+		case reviewer.Typename == "Team":
+			teams = append(teams, restTeam{Slug: reviewer.Slug})
+		case reviewer.Login != "":
+			reviewers = append(reviewers, restUser{Login: reviewer.Login})
+		}
+	}
+	rest.RequestedReviewers = &reviewers
+	rest.RequestedTeams = &teams
+
+	var subject types.HydratedSubject
+	if s.LatestOpinionatedReviews != nil {
+		for _, review := range s.LatestOpinionatedReviews.Nodes {
+			converted := types.PullRequestReview{
+				ID:      review.DatabaseID,
+				State:   review.State,
+				HTMLURL: review.URL,
+			}
+			if review.Author != nil {
+				converted.User = types.SimpleUser{Login: review.Author.Login}
+			}
+			if review.SubmittedAt != nil {
+				converted.SubmittedAt = *review.SubmittedAt
+			}
+			subject.Reviews = append(subject.Reviews, converted)
+		}
+	}
+
+	if s.Commits == nil || len(s.Commits.Nodes) == 0 {
+		return subject
+	}
+	rollup := s.Commits.Nodes[0].Commit.StatusCheckRollup
+	if rollup == nil {
+		return subject
+	}
+	for _, check := range rollup.Contexts.Nodes {
+		switch check.Typename {
+		case "CheckRun":
+			subject.CheckRuns = append(subject.CheckRuns, types.CheckRun{
+				ID:         check.DatabaseID,
+				Name:       check.Name,
+				Status:     strings.ToLower(check.Status),
+				Conclusion: strings.ToLower(check.Conclusion),
+				HTMLURL:    check.DetailsURL,
+			})
+		case "StatusContext":
+			state := strings.ToLower(check.State)
+			if state == "expected" {
+				// A required status that hasn't been reported yet
+				state = "pending"
+			}
+			subject.CombinedStatus.Statuses = append(
+				subject.CombinedStatus.Statuses,
+				types.CommitStatus{Context: check.Context, State: state},
+			)
+		}
+	}
+	subject.CombinedStatus.TotalCount = len(subject.CombinedStatus.Statuses)
+	return subject
+}
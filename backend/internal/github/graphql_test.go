@@ -0,0 +1,305 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+)
+
+// graphQLServer serves GraphQL queries with respond, recording each query it receives
+func graphQLServer(
+	t *testing.T,
+	respond func(query string) string,
+) (*httptest.Server, *[]string) {
+	t.Helper()
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/graphql", r.URL.Path)
+		var body struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		queries = append(queries, body.Query)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(respond(body.Query)))
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func TestHydrateSubjects_PullRequest(t *testing.T) {
+	server, queries := graphQLServer(t, func(string) string {
+		return `{"data": {"r0": {"s0": {
+			"id": "PR_node", "databaseId": 99, "number": 7, "title": "Fix it",
+			"url": "https://github.com/octo/repo/pull/7", "state": "MERGED",
+			"merged": true, "mergedAt": "2024-01-02T00:00:00Z", "isDraft": false,
+			"author": {"login": "alice", "__typename": "User", "databaseId": 1},
+			"labels": {"nodes": [{"name": "bug", "color": "d73a4a"}]},
+			"assignees": {"nodes": []},
+			"comments": {"totalCount": 3},
+			"headRefName": "fix", "headRefOid": "abc123", "baseRefName": "main",
+			"reviewRequests": {"nodes": [
+				{"requestedReviewer": {"__typename": "User", "login": "bob"}},
+				{"requestedReviewer": {"__typename": "Team", "slug": "core"}}
+			]},
+			"latestOpinionatedReviews": {"nodes": [
+				{"databaseId": 5, "state": "APPROVED", "author": {"login": "carol"}}
+			]},
+			"commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {"nodes": [
+				{"__typename": "CheckRun", "databaseId": 11, "name": "build",
+					"status": "COMPLETED", "conclusion": "FAILURE"},
+				{"__typename": "StatusContext", "context": "ci/deploy", "state": "EXPECTED"}
+			]}}}}]}
+		}}}}`
+	})
+
+	client := newTestClient(server.URL)
+	ref := types.SubjectRef{Owner: "octo", Repo: "repo", Number: 7, Type: "PullRequest"}
+
+	subjects, err := client.HydrateSubjects(context.Background(), []types.SubjectRef{ref})
+	require.NoError(t, err)
+	require.Len(t, *queries, 1)
+	require.Contains(t, (*queries)[0], `r0: repository(owner: "octo", name: "repo")`)
+	require.Contains(t, (*queries)[0], "s0: pullRequest(number: 7) { ...pullRequestFields }")
+	require.Contains(t, (*queries)[0], "fragment pullRequestFields on PullRequest")
+	require.NotContains(t, (*queries)[0], "fragment issueFields")
+
+	subject, ok := subjects[ref]
+	require.True(t, ok)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(subject.Raw, &raw))
+	require.Equal(t, server.URL+"/repos/octo/repo/pulls/7", raw["url"])
+	require.Equal(t, "https://github.com/octo/repo/pull/7", raw["html_url"])
+	require.Equal(t, "closed", raw["state"])
+	require.Equal(t, true, raw["merged"])
+	require.Equal(t, "alice", raw["user"].(map[string]any)["login"])
+	require.Equal(t, "fix", raw["head"].(map[string]any)["ref"])
+	require.Equal(t, "abc123", raw["head"].(map[string]any)["sha"])
+	require.Equal(t, []any{map[string]any{"login": "bob"}}, raw["requested_reviewers"])
+	require.Equal(t, []any{map[string]any{"slug": "core"}}, raw["requested_teams"])
+	require.Equal(t, []any{}, raw["assignees"])
+	require.EqualValues(t, 3, raw["comments"])
+
+	require.Len(t, subject.Reviews, 1)
+	require.Equal(t, "APPROVED", subject.Reviews[0].State)
+	require.Equal(t, "carol", subject.Reviews[0].User.Login)
+	require.Equal(t, []types.CheckRun{
+		{ID: 11, Name: "build", Status: "completed", Conclusion: "failure"},
+	}, subject.CheckRuns)
+	require.Equal(t, 1, subject.CombinedStatus.TotalCount)
+	require.Equal(t, "pending", subject.CombinedStatus.Statuses[0].State)
+}
+
+func TestHydrateSubjects_Discussion(t *testing.T) {
+	server, queries := graphQLServer(t, func(string) string {
+		return `{"data": {"r0": {"s0": {
+			"number": 3, "title": "Question", "closed": true,
+			"answerChosenAt": "2024-01-02T00:00:00Z", "category": {"name": "Q&A"}
+		}}}}`
+	})
+
+	client := newTestClient(server.URL)
+	ref := types.SubjectRef{Owner: "octo", Repo: "repo", Number: 3, Type: "Discussion"}
+
+	subjects, err := client.HydrateSubjects(context.Background(), []types.SubjectRef{ref})
+	require.NoError(t, err)
+	require.Contains(t, (*queries)[0], "s0: discussion(number: 3) { ...discussionFields }")
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(subjects[ref].Raw, &raw))
+	require.Equal(t, "closed", raw["state"])
+	require.Equal(t, "2024-01-02T00:00:00Z", raw["answer_chosen_at"])
+	require.Equal(t, "Q&A", raw["category"].(map[string]any)["name"])
+	require.Equal(t, server.URL+"/repos/octo/repo/discussions/3", raw["url"])
+}
+
+func TestHydrateSubjects_SkipsMissingSubjects(t *testing.T) {
+	server, _ := graphQLServer(t, func(string) string {
+		return `{
+			"data": {"r0": {"s0": null, "s1": {"number": 2, "state": "OPEN"}}},
+			"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to an Issue"}]
+		}`
+	})
+
+	client := newTestClient(server.URL)
+	missing := types.SubjectRef{Owner: "octo", Repo: "repo", Number: 1, Type: "Issue"}
+	found := types.SubjectRef{Owner: "octo", Repo: "repo", Number: 2, Type: "Issue"}
+
+	subjects, err := client.HydrateSubjects(
+		context.Background(),
+		[]types.SubjectRef{missing, found},
+	)
+	require.NoError(t, err)
+	require.Len(t, subjects, 1)
+	require.Contains(t, subjects, found)
+}
+
+func TestHydrateSubjects_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		rateLimited bool
+	}{
+		{
+			name:        "rate limited",
+			response:    `{"data": null, "errors": [{"type": "RATE_LIMITED", "message": "limit"}]}`,
+			rateLimited: true,
+		},
+		{
+			name:     "no data",
+			response: `{"errors": [{"message": "Parse error"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := graphQLServer(t, func(string) string { return tt.response })
+			client := newTestClient(server.URL)
+
+			_, err := client.HydrateSubjects(context.Background(), []types.SubjectRef{
+				{Owner: "octo", Repo: "repo", Number: 1, Type: "Issue"},
+			})
+			require.Error(t, err)
+			var rateLimited *RateLimitedError
+			require.Equal(t, tt.rateLimited, errors.As(err, &rateLimited))
+		})
+	}
+}
+
+func TestFetchDiscussionComments(t *testing.T) {
+	comment := func(id string, replies string) string {
+		return fmt.Sprintf(`{"id": %q, "body": "body of %s", "isAnswer": %t, "upvoteCount": 2,
+			"createdAt": "2024-01-01T00:00:00Z", "author": {"login": "alice"},
+			"replies": {"nodes": [%s]}}`, id, id, id == "c2", replies)
+	}
+	var variables []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		variables = append(variables, body.Variables)
+
+		// Three comments, served two per page walking back from the newest
+		page := `{"totalCount": 3,
+			"pageInfo": {"hasPreviousPage": true, "startCursor": "cursor-c2"},
+			"nodes": [` + comment("c2", `{"id": "r1", "body": "reply"}`) + `,` +
+			comment("c3", "") + `]}`
+		if body.Variables["before"] == "cursor-c2" {
+			page = `{"totalCount": 3, "pageInfo": {"hasPreviousPage": false},
+				"nodes": [` + comment("c1", "") + `]}`
+		}
+		_, _ = fmt.Fprintf(w, `{"data": {"repository": {"discussion": {"comments": %s}}}}`, page)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	result, err := client.FetchDiscussionComments(context.Background(), "octo", "repo", 9, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.TotalCount)
+	require.Len(t, result.Comments, 3)
+	require.Equal(t, "c3", result.Comments[0].ID)
+	require.Equal(t, "c2", result.Comments[1].ID)
+	require.Equal(t, "c1", result.Comments[2].ID)
+	require.True(t, result.Comments[1].IsAnswer)
+	require.Equal(t, 2, result.Comments[1].UpvoteCount)
+	require.Equal(t, "alice", result.Comments[1].User.Login)
+	require.Len(t, result.Comments[1].Replies, 1)
+	require.Equal(t, "reply", result.Comments[1].Replies[0].Body)
+
+	require.Len(t, variables, 2)
+	require.Equal(t, "octo", variables[0]["owner"])
+	require.EqualValues(t, 9, variables[0]["number"])
+	require.EqualValues(t, 10, variables[0]["last"])
+	require.Nil(t, variables[0]["before"])
+	require.EqualValues(t, 8, variables[1]["last"])
+}
+
+func TestFetchDiscussionComments_Limit(t *testing.T) {
+	var requests int
+	server, _ := graphQLServer(t, func(string) string {
+		requests++
+		return `{"data": {"repository": {"discussion": {"comments": {"totalCount": 50,
+			"pageInfo": {"hasPreviousPage": true, "startCursor": "c"},
+			"nodes": [{"id": "c49"}, {"id": "c50"}]}}}}}`
+	})
+	client := newTestClient(server.URL)
+
+	result, err := client.FetchDiscussionComments(context.Background(), "octo", "repo", 9, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, requests)
+	require.Equal(t, 50, result.TotalCount)
+	require.Len(t, result.Comments, 2)
+}
+
+func TestFetchDiscussionComments_NotFound(t *testing.T) {
+	server, _ := graphQLServer(t, func(string) string {
+		return `{"data": {"repository": {"discussion": null}},
+			"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Discussion"}]}`
+	})
+	client := newTestClient(server.URL)
+
+	_, err := client.FetchDiscussionComments(context.Background(), "octo", "repo", 9, 10)
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestHydrateBatches(t *testing.T) {
+	var refs []types.SubjectRef
+	for i := 1; i <= hydrateBatchSize+10; i++ {
+		refs = append(refs, types.SubjectRef{Owner: "octo", Repo: "one", Number: i, Type: "Issue"})
+	}
+	refs = append(refs,
+		types.SubjectRef{Owner: "octo", Repo: "two", Number: 1, Type: "PullRequest"},
+		types.SubjectRef{Owner: "octo", Repo: "one", Number: 1, Type: "Issue"}, // duplicate
+		types.SubjectRef{Owner: "octo", Repo: "", Number: 1, Type: "Issue"},    // unresolved
+	)
+
+	batches := hydrateBatches(refs)
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0], 1)
+	require.Len(t, batches[0][0], hydrateBatchSize)
+	require.Len(t, batches[1], 2)
+	require.Len(t, batches[1][0], 10)
+	require.Equal(t, "two", batches[1][1][0].Repo)
+
+	query := buildHydrateQuery(batches[1])
+	for i := range 10 {
+		require.Contains(t, query, fmt.Sprintf("s%d: issue(number: %d)", i, hydrateBatchSize+i+1))
+	}
+	require.Contains(t, query, `r1: repository(owner: "octo", name: "two")`)
+	require.Contains(t, query, "fragment issueFields on Issue")
+	require.Contains(t, query, "fragment pullRequestFields on PullRequest")
+	require.NotContains(t, query, "fragment discussionFields")
+}
+
+func TestGraphQLURL(t *testing.T) {
+	require.Equal(t, "https://api.github.com/graphql", graphQLURL("https://api.github.com"))
+	require.Equal(t,
+		"https://github.example.com/api/graphql",
+		graphQLURL("https://github.example.com/api/v3"),
+	)
+}
@@ -39,7 +39,23 @@ type Client interface {
 		before *time.Time,
 		unreadOnly bool,
 	) ([]types.NotificationThread, error)
+	// PollNotifications is FetchNotifications (with no upper bound) as a conditional request:
+	// validators from the previous poll are sent as If-None-Match/If-Modified-Since, and
+	// a 304 Not Modified response is returned as NotModified with no threads.
+	PollNotifications(
+		ctx context.Context,
+		since *time.Time,
+		unreadOnly bool,
+		validators types.PollValidators,
+	) (types.NotificationPoll, error)
 	FetchSubjectRaw(ctx context.Context, subjectURL string) (json.RawMessage, error)
+	// HydrateSubjects fetches issues, pull requests and discussions through the GraphQL API,
+	// many per request, instead of one FetchSubjectRaw call each. Subjects that can't be
+	// fetched (deleted, or no longer accessible) are missing from the result.
+	HydrateSubjects(
+		ctx context.Context,
+		refs []types.SubjectRef,
+	) (map[types.SubjectRef]types.HydratedSubject, error)
 	FetchTimeline(
 		ctx context.Context,
 		owner, repo string,
@@ -50,9 +66,34 @@ type Client interface {
 		owner, repo string,
 		number, perPage, page int,
 	) ([]types.IssueComment, error)
+	// FetchDiscussionComments fetches up to limit of a discussion's latest comments, with
+	// their replies, through the GraphQL API.
+	FetchDiscussionComments(
+		ctx context.Context,
+		owner, repo string,
+		number, limit int,
+	) (types.DiscussionComments, error)
 	FetchPullRequestReviews(
 		ctx context.Context,
 		owner, repo string,
 		number, perPage, page int,
 	) ([]types.PullRequestReview, error)
+	FetchCheckRuns(ctx context.Context, owner, repo, ref string) ([]types.CheckRun, error)
+	FetchCombinedStatus(
+		ctx context.Context,
+		owner, repo, ref string,
+	) (types.CombinedStatus, error)
+	// MarkThreadRead marks a notification thread as read (PATCH /notifications/threads/{id}).
+	MarkThreadRead(ctx context.Context, threadID string) error
+	// MarkThreadDone marks a notification thread as done (DELETE /notifications/threads/{id}).
+	MarkThreadDone(ctx context.Context, threadID string) error
+	// IgnoreThread unsubscribes from a notification thread
+	// (PUT /notifications/threads/{id}/subscription with ignored=true).
+	IgnoreThread(ctx context.Context, threadID string) error
+	// ViewerLogin returns the login of the token's user, or "" if no token has been validated.
+	ViewerLogin() string
+	// RateLimit returns the rate limit reported by the latest response, or the zero value.
+	RateLimit() types.RateLimit
+	// Endpoints returns the base URLs of the GitHub instance the client talks to.
+	Endpoints() types.Endpoints
 }
@@ -43,6 +43,65 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// Endpoints mocks base method.
+func (m *MockClient) Endpoints() types.Endpoints {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Endpoints")
+	ret0, _ := ret[0].(types.Endpoints)
+	return ret0
+}
+
+// Endpoints indicates an expected call of Endpoints.
+func (mr *MockClientMockRecorder) Endpoints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Endpoints", reflect.TypeOf((*MockClient)(nil).Endpoints))
+}
+
+// FetchCheckRuns mocks base method.
+func (m *MockClient) FetchCheckRuns(ctx context.Context, owner, repo, ref string) ([]types.CheckRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCheckRuns", ctx, owner, repo, ref)
+	ret0, _ := ret[0].([]types.CheckRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCheckRuns indicates an expected call of FetchCheckRuns.
+func (mr *MockClientMockRecorder) FetchCheckRuns(ctx, owner, repo, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCheckRuns", reflect.TypeOf((*MockClient)(nil).FetchCheckRuns), ctx, owner, repo, ref)
+}
+
+// FetchCombinedStatus mocks base method.
+func (m *MockClient) FetchCombinedStatus(ctx context.Context, owner, repo, ref string) (types.CombinedStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCombinedStatus", ctx, owner, repo, ref)
+	ret0, _ := ret[0].(types.CombinedStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCombinedStatus indicates an expected call of FetchCombinedStatus.
+func (mr *MockClientMockRecorder) FetchCombinedStatus(ctx, owner, repo, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCombinedStatus", reflect.TypeOf((*MockClient)(nil).FetchCombinedStatus), ctx, owner, repo, ref)
+}
+
+// FetchDiscussionComments mocks base method.
+func (m *MockClient) FetchDiscussionComments(ctx context.Context, owner, repo string, number, limit int) (types.DiscussionComments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDiscussionComments", ctx, owner, repo, number, limit)
+	ret0, _ := ret[0].(types.DiscussionComments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDiscussionComments indicates an expected call of FetchDiscussionComments.
+func (mr *MockClientMockRecorder) FetchDiscussionComments(ctx, owner, repo, number, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDiscussionComments", reflect.TypeOf((*MockClient)(nil).FetchDiscussionComments), ctx, owner, repo, number, limit)
+}
+
 // FetchIssueComments mocks base method.
 func (m *MockClient) FetchIssueComments(ctx context.Context, owner, repo string, number, perPage, page int) ([]types.IssueComment, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +177,92 @@ func (mr *MockClientMockRecorder) FetchTimeline(ctx, owner, repo, number, perPag
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTimeline", reflect.TypeOf((*MockClient)(nil).FetchTimeline), ctx, owner, repo, number, perPage, page)
 }
 
+// HydrateSubjects mocks base method.
+func (m *MockClient) HydrateSubjects(ctx context.Context, refs []types.SubjectRef) (map[types.SubjectRef]types.HydratedSubject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HydrateSubjects", ctx, refs)
+	ret0, _ := ret[0].(map[types.SubjectRef]types.HydratedSubject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HydrateSubjects indicates an expected call of HydrateSubjects.
+func (mr *MockClientMockRecorder) HydrateSubjects(ctx, refs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HydrateSubjects", reflect.TypeOf((*MockClient)(nil).HydrateSubjects), ctx, refs)
+}
+
+// IgnoreThread mocks base method.
+func (m *MockClient) IgnoreThread(ctx context.Context, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IgnoreThread", ctx, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IgnoreThread indicates an expected call of IgnoreThread.
+func (mr *MockClientMockRecorder) IgnoreThread(ctx, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IgnoreThread", reflect.TypeOf((*MockClient)(nil).IgnoreThread), ctx, threadID)
+}
+
+// MarkThreadDone mocks base method.
+func (m *MockClient) MarkThreadDone(ctx context.Context, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkThreadDone", ctx, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkThreadDone indicates an expected call of MarkThreadDone.
+func (mr *MockClientMockRecorder) MarkThreadDone(ctx, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkThreadDone", reflect.TypeOf((*MockClient)(nil).MarkThreadDone), ctx, threadID)
+}
+
+// MarkThreadRead mocks base method.
+func (m *MockClient) MarkThreadRead(ctx context.Context, threadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkThreadRead", ctx, threadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkThreadRead indicates an expected call of MarkThreadRead.
+func (mr *MockClientMockRecorder) MarkThreadRead(ctx, threadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkThreadRead", reflect.TypeOf((*MockClient)(nil).MarkThreadRead), ctx, threadID)
+}
+
+// PollNotifications mocks base method.
+func (m *MockClient) PollNotifications(ctx context.Context, since *time.Time, unreadOnly bool, validators types.PollValidators) (types.NotificationPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PollNotifications", ctx, since, unreadOnly, validators)
+	ret0, _ := ret[0].(types.NotificationPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PollNotifications indicates an expected call of PollNotifications.
+func (mr *MockClientMockRecorder) PollNotifications(ctx, since, unreadOnly, validators any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PollNotifications", reflect.TypeOf((*MockClient)(nil).PollNotifications), ctx, since, unreadOnly, validators)
+}
+
+// RateLimit mocks base method.
+func (m *MockClient) RateLimit() types.RateLimit {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimit")
+	ret0, _ := ret[0].(types.RateLimit)
+	return ret0
+}
+
+// RateLimit indicates an expected call of RateLimit.
+func (mr *MockClientMockRecorder) RateLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimit", reflect.TypeOf((*MockClient)(nil).RateLimit))
+}
+
 // SetToken mocks base method.
 func (m *MockClient) SetToken(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
@@ -131,3 +276,17 @@ func (mr *MockClientMockRecorder) SetToken(ctx, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetToken", reflect.TypeOf((*MockClient)(nil).SetToken), ctx, token)
 }
+
+// ViewerLogin mocks base method.
+func (m *MockClient) ViewerLogin() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewerLogin")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ViewerLogin indicates an expected call of ViewerLogin.
+func (mr *MockClientMockRecorder) ViewerLogin() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewerLogin", reflect.TypeOf((*MockClient)(nil).ViewerLogin))
+}
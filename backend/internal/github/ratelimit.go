@@ -0,0 +1,250 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+)
+
+const (
+	// lowPriorityReserve is the share of the rate limit kept for normal-priority requests:
+	// low-priority requests are refused once less than this fraction of the budget remains
+	lowPriorityReserve = 0.1
+	// secondaryLimitWait is how long to back off from a secondary rate limit that doesn't
+	// say when to retry, as GitHub recommends
+	secondaryLimitWait = time.Minute
+)
+
+// Rate limit resources, as named by the X-RateLimit-Resource header. Each has a budget of
+// its own.
+const (
+	resourceCore    = "core"
+	resourceGraphQL = "graphql"
+	resourceSearch  = "search"
+)
+
+// RateLimitedError is returned by every client call made while GitHub's rate limit is
+// exhausted, instead of sending the request. Callers should retry after ResetAt.
+type RateLimitedError struct {
+	ResetAt time.Time
+	// Secondary is set for GitHub's secondary (abuse) rate limits
+	Secondary bool
+	// LowPriority is set when a low-priority request was held back to save the remaining budget
+	LowPriority bool
+}
+
+// Error implements error.
+func (e *RateLimitedError) Error() string {
+	switch {
+	case e.Secondary:
+		return fmt.Sprintf(
+			"github: secondary rate limit exceeded, retry after %s",
+			e.ResetAt.Format(time.RFC3339),
+		)
+	case e.LowPriority:
+		return fmt.Sprintf(
+			"github: rate limit budget reserved until %s",
+			e.ResetAt.Format(time.RFC3339),
+		)
+	default:
+		return fmt.Sprintf(
+			"github: rate limit exceeded, resets at %s",
+			e.ResetAt.Format(time.RFC3339),
+		)
+	}
+}
+
+// RetryAfter returns how long to wait before retrying, measured from now.
+func (e *RateLimitedError) RetryAfter(now time.Time) time.Duration {
+	if wait := e.ResetAt.Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+type lowPriorityKey struct{}
+
+// WithLowPriority marks the requests made with ctx as low priority: once the remaining
+// budget falls below a reserve they fail with a RateLimitedError, so background work like
+// subject fetches can't starve the notifications poll.
+func WithLowPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lowPriorityKey{}, true)
+}
+
+func isLowPriority(ctx context.Context) bool {
+	low, _ := ctx.Value(lowPriorityKey{}).(bool)
+	return low
+}
+
+// rateLimitTransport is an http.RoundTripper that tracks GitHub's rate limit headers, per
+// resource, and refuses requests, with a RateLimitedError, while their budget is exhausted.
+type rateLimitTransport struct {
+	base http.RoundTripper
+	now  func() time.Time
+
+	mu           sync.Mutex
+	limits       map[string]types.RateLimit // Keyed by resource
+	blockedUntil time.Time                  // Set by a secondary rate limit, which covers every resource
+}
+
+// newRateLimitTransport wraps base, or http.DefaultTransport if base is nil.
+func newRateLimitTransport(base http.RoundTripper) *rateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{
+		base:   base,
+		now:    time.Now,
+		limits: make(map[string]types.RateLimit),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.admit(req.Context(), requestResource(req)); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.observe(resp); err != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close() // Error can be ignored, the response is dropped
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RateLimit returns the latest known rate limit of the core (REST) resource.
+func (t *rateLimitTransport) RateLimit() types.RateLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limits[resourceCore]
+}
+
+// admit returns a RateLimitedError if a request against resource shouldn't be sent now.
+func (t *rateLimitTransport) admit(ctx context.Context, resource string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if now.Before(t.blockedUntil) {
+		return &RateLimitedError{ResetAt: t.blockedUntil, Secondary: true}
+	}
+
+	// Nothing to go on until a response has been seen, or once the window has reset
+	limit := t.limits[resource]
+	if limit.Limit == 0 || !now.Before(limit.ResetAt) {
+		return nil
+	}
+	if limit.Remaining <= 0 {
+		return &RateLimitedError{ResetAt: limit.ResetAt}
+	}
+	if isLowPriority(ctx) &&
+		float64(limit.Remaining) < float64(limit.Limit)*lowPriorityReserve {
+		return &RateLimitedError{ResetAt: limit.ResetAt, LowPriority: true}
+	}
+	return nil
+}
+
+// observe records the rate limit headers of a response, and returns a RateLimitedError if
+// the response is a rate limit rejection.
+func (t *rateLimitTransport) observe(resp *http.Response) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	limit, ok := parseRateLimit(resp.Header)
+	if ok {
+		resource := resp.Header.Get("X-RateLimit-Resource")
+		if resource == "" {
+			resource = requestResource(resp.Request)
+		}
+		t.limits[resource] = limit
+	}
+
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	now := t.now()
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		seconds, err := strconv.Atoi(strings.TrimSpace(retryAfter))
+		if err != nil || seconds < 0 {
+			seconds = int(secondaryLimitWait / time.Second)
+		}
+		t.blockedUntil = now.Add(time.Duration(seconds) * time.Second)
+		return &RateLimitedError{ResetAt: t.blockedUntil, Secondary: true}
+	}
+	if ok && limit.Remaining == 0 {
+		return &RateLimitedError{ResetAt: limit.ResetAt}
+	}
+	// A 429 without headers is still a secondary limit; a bare 403 is a permissions error
+	if resp.StatusCode == http.StatusTooManyRequests {
+		t.blockedUntil = now.Add(secondaryLimitWait)
+		return &RateLimitedError{ResetAt: t.blockedUntil, Secondary: true}
+	}
+	return nil
+}
+
+// requestResource returns the rate limit resource a request is counted against.
+func requestResource(req *http.Request) string {
+	if req == nil || req.URL == nil {
+		return resourceCore
+	}
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/graphql"):
+		return resourceGraphQL
+	case strings.Contains(req.URL.Path, "/search/"):
+		return resourceSearch
+	default:
+		return resourceCore
+	}
+}
+
+// parseRateLimit reads the X-RateLimit-* headers, reporting whether they were present.
+func parseRateLimit(header http.Header) (types.RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return types.RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return types.RateLimit{}, false
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return types.RateLimit{}, false
+	}
+	used, _ := strconv.Atoi(header.Get("X-RateLimit-Used")) // Optional
+	return types.RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		ResetAt:   time.Unix(reset, 0).UTC(),
+	}, true
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+)
+
+// rateLimitHandler answers every request with the given status and rate limit headers,
+// counting the requests it sees
+func rateLimitHandler(
+	status, limit, remaining int,
+	resetAt time.Time,
+	requests *int,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		*requests++
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Used", strconv.Itoa(limit-remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+		w.Header().Set("X-RateLimit-Resource", "core")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func TestRateLimit_TracksHeaders(t *testing.T) {
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	var requests int
+	server := httptest.NewServer(rateLimitHandler(http.StatusOK, 5000, 4321, resetAt, &requests))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	require.Equal(t, types.RateLimit{}, client.RateLimit())
+
+	_, err := client.FetchSubjectRaw(context.Background(), server.URL+"/repos/o/r/issues/1")
+	require.NoError(t, err)
+
+	require.Equal(t, types.RateLimit{
+		Limit:     5000,
+		Remaining: 4321,
+		Used:      679,
+		ResetAt:   resetAt,
+	}, client.RateLimit())
+}
+
+func TestRateLimit_Exhausted(t *testing.T) {
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	var requests int
+	server := httptest.NewServer(
+		rateLimitHandler(http.StatusForbidden, 5000, 0, resetAt, &requests),
+	)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	_, err := client.FetchSubjectRaw(context.Background(), server.URL+"/repos/o/r/issues/1")
+	var rateLimited *RateLimitedError
+	require.ErrorAs(t, err, &rateLimited)
+	require.Equal(t, resetAt, rateLimited.ResetAt)
+	require.False(t, rateLimited.Secondary)
+	require.Equal(t, 1, requests)
+
+	// Until the reset, calls fail without reaching GitHub
+	_, err = client.FetchCheckRuns(context.Background(), "o", "r", "abc")
+	require.ErrorAs(t, err, &rateLimited)
+	require.Equal(t, 1, requests)
+}
+
+func TestRateLimit_ExhaustedWindowReset(t *testing.T) {
+	var requests int
+	transport := newRateLimitTransport(nil)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	server := httptest.NewServer(
+		rateLimitHandler(http.StatusOK, 5000, 0, now.Add(time.Minute), &requests),
+	)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.httpClient.Transport = transport
+	client.rateLimits = transport
+
+	_, err := client.FetchSubjectRaw(context.Background(), server.URL+"/a")
+	require.NoError(t, err)
+	_, err = client.FetchSubjectRaw(context.Background(), server.URL+"/b")
+	var rateLimited *RateLimitedError
+	require.ErrorAs(t, err, &rateLimited)
+
+	// Once the reset time passes, requests are sent again
+	now = now.Add(2 * time.Minute)
+	_, err = client.FetchSubjectRaw(context.Background(), server.URL+"/c")
+	require.NoError(t, err)
+	require.Equal(t, 2, requests)
+}
+
+func TestRateLimit_PerResource(t *testing.T) {
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	requests := map[string]int{}
+	// REST calls have budget left; the GraphQL budget is spent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, remaining := "core", 4000
+		if r.URL.Path == "/graphql" {
+			resource, remaining = "graphql", 0
+		}
+		requests[resource]++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+		w.Header().Set("X-RateLimit-Resource", resource)
+		if resource == "graphql" {
+			_, _ = w.Write([]byte(`{"errors": [{"type": "RATE_LIMITED", "message": "limit"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	refs := []types.SubjectRef{{Owner: "o", Repo: "r", Number: 1, Type: "Issue"}}
+
+	_, err := client.HydrateSubjects(context.Background(), refs)
+	var rateLimited *RateLimitedError
+	require.ErrorAs(t, err, &rateLimited)
+
+	// The spent GraphQL budget holds back GraphQL queries without reaching GitHub...
+	_, err = client.HydrateSubjects(context.Background(), refs)
+	require.ErrorAs(t, err, &rateLimited)
+	require.Equal(t, resetAt, rateLimited.ResetAt)
+	require.Equal(t, 1, requests["graphql"])
+
+	// ...but not REST calls, and RateLimit reports the core budget
+	_, err = client.FetchSubjectRaw(context.Background(), server.URL+"/repos/o/r/issues/1")
+	require.NoError(t, err)
+	require.Equal(t, 1, requests["core"])
+	require.Equal(t, 4000, client.RateLimit().Remaining)
+}
+
+func TestRateLimit_SecondaryLimit(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	before := time.Now()
+
+	_, err := client.FetchSubjectRaw(context.Background(), server.URL+"/repos/o/r/issues/1")
+	var rateLimited *RateLimitedError
+	require.ErrorAs(t, err, &rateLimited)
+	require.True(t, rateLimited.Secondary)
+	require.WithinDuration(t, before.Add(30*time.Second), rateLimited.ResetAt, 2*time.Second)
+
+	_, err = client.FetchSubjectRaw(context.Background(), server.URL+"/repos/o/r/issues/1")
+	require.ErrorAs(t, err, &rateLimited)
+	require.Equal(t, 1, requests)
+}
+
+func TestRateLimit_LowPriority(t *testing.T) {
+	resetAt := time.Now().Add(time.Hour)
+	var requests int
+	server := httptest.NewServer(rateLimitHandler(http.StatusOK, 5000, 100, resetAt, &requests))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	// The first request has nothing to go on
+	lowPriority := WithLowPriority(context.Background())
+	_, err := client.FetchSubjectRaw(lowPriority, server.URL+"/a")
+	require.NoError(t, err)
+
+	// 100 of 5000 is below the reserve: low-priority requests are held back...
+	_, err = client.FetchSubjectRaw(lowPriority, server.URL+"/b")
+	var rateLimited *RateLimitedError
+	require.ErrorAs(t, err, &rateLimited)
+	require.True(t, rateLimited.LowPriority)
+
+	// ...and others are still sent
+	_, err = client.FetchCheckRuns(context.Background(), "o", "r", "abc")
+	require.NoError(t, err)
+	require.Equal(t, 2, requests)
+}
+
+func TestRateLimit_ForbiddenIsNotRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	_, err := client.FetchSubjectRaw(context.Background(), server.URL+"/repos/o/r/issues/1")
+	require.Error(t, err)
+	var rateLimited *RateLimitedError
+	require.False(t, errors.As(err, &rateLimited))
+	require.Contains(t, err.Error(), "status 403")
+}
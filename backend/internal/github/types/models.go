@@ -35,6 +35,42 @@ type NotificationThread struct {
 	Raw             json.RawMessage     `json:"-"`
 }
 
+// PollValidators are the cache validators of a notifications response. Sending them
+// back as If-None-Match and If-Modified-Since lets GitHub answer 304 Not Modified,
+// which doesn't count against the rate limit.
+type PollValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// NotificationPoll is the result of a conditional notifications request.
+type NotificationPoll struct {
+	Threads []NotificationThread
+	// NotModified is set when GitHub answered 304: nothing changed since the validators were issued
+	NotModified bool
+	// Validators of this response, to send with the next poll (the previous ones on 304)
+	Validators PollValidators
+	// PollInterval is how long GitHub asks clients to wait between polls (X-Poll-Interval),
+	// or 0 if the header wasn't sent
+	PollInterval time.Duration
+}
+
+// RateLimit is the REST API rate limit budget, as reported by the X-RateLimit-* headers of
+// the latest response. The zero value means no response has been seen yet.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Used      int
+	ResetAt   time.Time
+}
+
+// Endpoints are the base URLs of a GitHub instance: github.com or a GitHub Enterprise
+// Server. Neither has a trailing slash.
+type Endpoints struct {
+	APIURL string // e.g. https://api.github.com or https://github.example.com/api/v3
+	WebURL string // e.g. https://github.com or https://github.example.com
+}
+
 // NotificationSubject provides the subject payload for a notification thread.
 type NotificationSubject struct {
 	Title            string `json:"title"`
@@ -90,6 +126,31 @@ type SubjectInfo struct {
 	Number int
 }
 
+// Subject types of notification threads that can be fetched in a batch
+const (
+	SubjectTypeIssue       = "Issue"
+	SubjectTypePullRequest = "PullRequest"
+	SubjectTypeDiscussion  = "Discussion"
+)
+
+// SubjectRef identifies a notification subject to fetch in a batch.
+type SubjectRef struct {
+	Owner  string
+	Repo   string
+	Number int
+	Type   string // SubjectTypeIssue, SubjectTypePullRequest or SubjectTypeDiscussion
+}
+
+// HydratedSubject is a notification subject fetched in a batch. Raw has the shape of the
+// subject's REST API response, so it's read like a subject fetched on its own. A pull
+// request's reviews and checks come with it, saving the calls made for them otherwise.
+type HydratedSubject struct {
+	Raw            json.RawMessage     `json:"raw"`
+	Reviews        []PullRequestReview `json:"reviews,omitempty"`
+	CheckRuns      []CheckRun          `json:"check_runs,omitempty"`
+	CombinedStatus CombinedStatus      `json:"combined_status"`
+}
+
 // IssueComment represents a comment on an issue or pull request.
 type IssueComment struct {
 	ID        int64      `json:"id"`
@@ -100,6 +161,26 @@ type IssueComment struct {
 	HTMLURL   string     `json:"html_url"`
 }
 
+// DiscussionComment represents a comment on a discussion, or a reply to one.
+type DiscussionComment struct {
+	ID          string              `json:"id"`
+	Body        string              `json:"body"`
+	User        SimpleUser          `json:"user"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	HTMLURL     string              `json:"html_url"`
+	IsAnswer    bool                `json:"is_answer"`
+	UpvoteCount int                 `json:"upvote_count"`
+	Replies     []DiscussionComment `json:"replies,omitempty"`
+}
+
+// DiscussionComments is the latest comments of a discussion, newest first, with the total
+// number of top-level comments it has.
+type DiscussionComments struct {
+	TotalCount int
+	Comments   []DiscussionComment
+}
+
 // PullRequestReview represents a review on a pull request.
 type PullRequestReview struct {
 	ID          int64      `json:"id"`
@@ -110,6 +191,34 @@ type PullRequestReview struct {
 	HTMLURL     string     `json:"html_url"`
 }
 
+// CheckRun represents a check run on a commit.
+type CheckRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // queued, in_progress or completed
+	Conclusion string `json:"conclusion"` // Set once completed: success, failure, neutral, ...
+	HTMLURL    string `json:"html_url"`
+}
+
+// CheckRunList is the response of the list check runs endpoint.
+type CheckRunList struct {
+	TotalCount int        `json:"total_count"`
+	CheckRuns  []CheckRun `json:"check_runs"`
+}
+
+// CombinedStatus represents the combined commit status (legacy status API) for a ref.
+type CombinedStatus struct {
+	State      string         `json:"state"` // failure, pending or success
+	TotalCount int            `json:"total_count"`
+	Statuses   []CommitStatus `json:"statuses"`
+}
+
+// CommitStatus represents a single commit status reported by an external service.
+type CommitStatus struct {
+	Context string `json:"context"`
+	State   string `json:"state"` // error, failure, pending or success
+}
+
 // TimelineEvent represents a single event in a PR/issue timeline.
 type TimelineEvent struct {
 	Event       string          `json:"event"`
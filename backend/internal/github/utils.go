@@ -27,7 +27,8 @@ import (
 )
 
 // ExtractAuthorFromSubject extracts author login and ID from subject JSON.
-// Works for PRs, Issues, and other GitHub entities that have a "user" or "sender" field.
+// Works for PRs, Issues, and other GitHub entities that have a "user" or "sender" field,
+// plus releases and commits ("author") and workflow runs ("actor").
 func ExtractAuthorFromSubject(subjectJSON json.RawMessage) (sql.NullString, sql.NullInt64) {
 	var data map[string]interface{}
 	if err := json.Unmarshal(subjectJSON, &data); err != nil {
@@ -44,6 +45,14 @@ func ExtractAuthorFromSubject(subjectJSON json.RawMessage) (sql.NullString, sql.
 		return extractUserFields(sender)
 	}
 
+	// Try "author" field (releases and commits) and "actor" field (workflow runs)
+	if author, ok := data["author"].(map[string]interface{}); ok {
+		return extractUserFields(author)
+	}
+	if actor, ok := data["actor"].(map[string]interface{}); ok {
+		return extractUserFields(actor)
+	}
+
 	return sql.NullString{}, sql.NullInt64{}
 }
 
@@ -81,6 +90,18 @@ func ExtractSubjectNumber(subjectJSON json.RawMessage) sql.NullInt32 {
 	return sql.NullInt32{}
 }
 
+// ExtractSubjectComments extracts the comment count from issue or PR subject JSON.
+// Returns NULL for subjects without a "comments" count (releases, commits, etc.).
+func ExtractSubjectComments(subjectJSON json.RawMessage) sql.NullInt32 {
+	var data struct {
+		Comments *int32 `json:"comments"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Comments == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *data.Comments, Valid: true}
+}
+
 // ExtractSubjectState extracts the subject state from subject JSON.
 // Works for PRs and Issues which have a "state" field (e.g., "open", "closed").
 func ExtractSubjectState(subjectJSON json.RawMessage) sql.NullString {
@@ -129,6 +150,265 @@ func ExtractSubjectStateReason(subjectJSON json.RawMessage) sql.NullString {
 	return sql.NullString{}
 }
 
+// ExtractSubjectCreatedAt extracts the creation time from subject JSON.
+// Works for Issues, Pull Requests, Discussions and Releases which have a "created_at" field.
+func ExtractSubjectCreatedAt(subjectJSON json.RawMessage) sql.NullTime {
+	var data map[string]interface{}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil {
+		return sql.NullTime{}
+	}
+
+	if createdVal, ok := data["created_at"].(string); ok && createdVal != "" {
+		if createdAt, err := time.Parse(time.RFC3339, createdVal); err == nil {
+			return sql.NullTime{Time: createdAt, Valid: true}
+		}
+	}
+
+	return sql.NullTime{}
+}
+
+// ExtractSubjectDraft extracts the draft status from subject JSON.
+// Works for Pull Requests which have a "draft" field (boolean).
+func ExtractSubjectDraft(subjectJSON json.RawMessage) sql.NullBool {
+	var data map[string]interface{}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil {
+		return sql.NullBool{}
+	}
+
+	// Try "draft" field (PRs only)
+	if draftVal, ok := data["draft"].(bool); ok {
+		return sql.NullBool{Bool: draftVal, Valid: true}
+	}
+
+	return sql.NullBool{}
+}
+
+// ViewerMarker marks the token's user among requested reviewers and assignees, so that
+// review-requested:@me and assignee:@me can match without knowing the login at query time
+const ViewerMarker = "@me"
+
+// subjectUser is a user reference in subject JSON
+type subjectUser struct {
+	Login string `json:"login"`
+}
+
+// userLogins returns lowercase logins, plus ViewerMarker when viewerLogin is one of the users
+func userLogins(users []subjectUser, viewerLogin string) []string {
+	logins := []string{}
+	for _, user := range users {
+		if user.Login == "" {
+			continue
+		}
+		logins = append(logins, strings.ToLower(user.Login))
+		if viewerLogin != "" && strings.EqualFold(user.Login, viewerLogin) {
+			logins = append(logins, ViewerMarker)
+		}
+	}
+	return logins
+}
+
+// ExtractRequestedReviewers extracts the pending review requests from subject JSON.
+// Returns lowercase user logins and team slugs, plus ViewerMarker when viewerLogin
+// is one of the requested users. Returns nil for subjects without review requests
+// (anything but Pull Requests).
+func ExtractRequestedReviewers(subjectJSON json.RawMessage, viewerLogin string) []string {
+	var data struct {
+		RequestedReviewers *[]subjectUser `json:"requested_reviewers"`
+		RequestedTeams     []struct {
+			Slug string `json:"slug"`
+		} `json:"requested_teams"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.RequestedReviewers == nil {
+		return nil
+	}
+
+	reviewers := userLogins(*data.RequestedReviewers, viewerLogin)
+	for _, team := range data.RequestedTeams {
+		if team.Slug != "" {
+			reviewers = append(reviewers, strings.ToLower(team.Slug))
+		}
+	}
+
+	return reviewers
+}
+
+// ExtractSubjectAssignees extracts assignees from issue or PR subject JSON.
+// Returns lowercase logins, plus ViewerMarker when viewerLogin is assigned.
+// Returns nil for subjects that can't be assigned.
+func ExtractSubjectAssignees(subjectJSON json.RawMessage, viewerLogin string) []string {
+	var data struct {
+		Assignees *[]subjectUser `json:"assignees"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Assignees == nil {
+		return nil
+	}
+	return userLogins(*data.Assignees, viewerLogin)
+}
+
+// ExtractSubjectLabels extracts label names from issue or PR subject JSON, lowercased.
+// Returns nil for subjects that can't be labeled.
+func ExtractSubjectLabels(subjectJSON json.RawMessage) []string {
+	var data struct {
+		Labels *[]struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Labels == nil {
+		return nil
+	}
+
+	labels := []string{}
+	for _, label := range *data.Labels {
+		if label.Name != "" {
+			labels = append(labels, strings.ToLower(label.Name))
+		}
+	}
+	return labels
+}
+
+// ExtractSubjectMilestone extracts the milestone title from issue or PR subject JSON
+func ExtractSubjectMilestone(subjectJSON json.RawMessage) sql.NullString {
+	var data struct {
+		Milestone *struct {
+			Title string `json:"title"`
+		} `json:"milestone"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Milestone == nil ||
+		data.Milestone.Title == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: data.Milestone.Title, Valid: true}
+}
+
+// ExtractSubjectCategory extracts the category name from discussion subject JSON.
+// Returns NULL for subjects without a category (issues, PRs, etc.).
+func ExtractSubjectCategory(subjectJSON json.RawMessage) sql.NullString {
+	var data struct {
+		Category *struct {
+			Name string `json:"name"`
+		} `json:"category"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil || data.Category == nil ||
+		data.Category.Name == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: data.Category.Name, Valid: true}
+}
+
+// ExtractSubjectAnswered extracts whether a discussion has a chosen answer from discussion
+// subject JSON. Returns NULL for subjects other than discussions, which have no category.
+func ExtractSubjectAnswered(subjectJSON json.RawMessage) sql.NullBool {
+	var data struct {
+		Category       json.RawMessage `json:"category"`
+		AnswerChosenAt *string         `json:"answer_chosen_at"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil ||
+		len(data.Category) == 0 || string(data.Category) == "null" {
+		return sql.NullBool{}
+	}
+	answered := data.AnswerChosenAt != nil && *data.AnswerChosenAt != ""
+	return sql.NullBool{Bool: answered, Valid: true}
+}
+
+// ExtractHeadSHA extracts the head commit SHA from pull request subject JSON.
+// Returns "" if the subject has no head commit.
+func ExtractHeadSHA(subjectJSON json.RawMessage) string {
+	var data struct {
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	if err := json.Unmarshal(subjectJSON, &data); err != nil {
+		return ""
+	}
+	return data.Head.SHA
+}
+
+// Review decisions, as stored for review: queries
+const (
+	ReviewDecisionApproved         = "approved"
+	ReviewDecisionChangesRequested = "changes_requested"
+	ReviewDecisionRequired         = "required"
+)
+
+// ReviewDecision summarizes pull request reviews like GitHub's review decision:
+// changes_requested if any reviewer's latest review requests changes, approved if
+// any reviewer approved, and required (still waiting on a review) otherwise.
+// Comments don't change a reviewer's decision; dismissed reviews clear it.
+func ReviewDecision(reviews []types.PullRequestReview) string {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		login := strings.ToLower(review.User.Login)
+		switch strings.ToUpper(review.State) {
+		case "APPROVED", "CHANGES_REQUESTED":
+			latest[login] = strings.ToUpper(review.State)
+		case "DISMISSED":
+			delete(latest, login)
+		}
+	}
+
+	approved := false
+	for _, state := range latest {
+		if state == "CHANGES_REQUESTED" {
+			return ReviewDecisionChangesRequested
+		}
+		approved = true
+	}
+	if approved {
+		return ReviewDecisionApproved
+	}
+	return ReviewDecisionRequired
+}
+
+// Check statuses, as stored for checks: queries
+const (
+	ChecksFailing = "failing"
+	ChecksPending = "pending"
+	ChecksPassing = "passing"
+)
+
+// failingConclusions are check run conclusions that fail a commit
+var failingConclusions = map[string]bool{
+	"failure":         true,
+	"timed_out":       true,
+	"cancelled":       true,
+	"action_required": true,
+	"startup_failure": true,
+}
+
+// ChecksStatus summarizes a commit's check runs and commit statuses: failing if anything
+// failed, pending if anything is still running, passing otherwise.
+// Returns "" when the commit has no checks at all.
+func ChecksStatus(runs []types.CheckRun, status types.CombinedStatus) string {
+	if len(runs) == 0 && len(status.Statuses) == 0 {
+		return ""
+	}
+
+	pending := false
+	for _, run := range runs {
+		if !strings.EqualFold(run.Status, "completed") {
+			pending = true
+			continue
+		}
+		if failingConclusions[strings.ToLower(run.Conclusion)] {
+			return ChecksFailing
+		}
+	}
+	for _, commitStatus := range status.Statuses {
+		switch strings.ToLower(commitStatus.State) {
+		case "failure", "error":
+			return ChecksFailing
+		case "pending":
+			pending = true
+		}
+	}
+
+	if pending {
+		return ChecksPending
+	}
+	return ChecksPassing
+}
+
 // PullRequestData represents extracted pull request data from GitHub API responses.
 // This is a pure data structure with no database dependencies.
 type PullRequestData struct {
@@ -220,12 +500,18 @@ func ExtractPullRequestData(subjectJSON json.RawMessage) (*PullRequestData, erro
 
 // ExtractSubjectInfo parses a subject URL or raw JSON to extract repository owner, repo, and number.
 // This is used to make GitHub API calls for the subject (e.g., fetching timeline).
+// URLs are matched against the endpoints of the GitHub instance the notification came from.
 // Note: Subject type should come from the notification's SubjectType field, not from URL parsing.
-func ExtractSubjectInfo(subjectURL string, subjectRaw json.RawMessage) (*types.SubjectInfo, error) {
+func ExtractSubjectInfo(
+	endpoints types.Endpoints,
+	subjectURL string,
+	subjectRaw json.RawMessage,
+) (*types.SubjectInfo, error) {
 	// Try to extract from subject URL first
-	// Format: https://api.github.com/repos/{owner}/{repo}/{type}/{number}
-	if subjectURL != "" {
-		parts := strings.Split(strings.TrimPrefix(subjectURL, "https://api.github.com/repos/"), "/")
+	// Format: {api}/repos/{owner}/{repo}/{type}/{number}
+	apiPrefix := endpoints.APIURL + "/repos/"
+	if subjectURL != "" && strings.HasPrefix(subjectURL, apiPrefix) {
+		parts := strings.Split(strings.TrimPrefix(subjectURL, apiPrefix), "/")
 		if len(parts) >= 4 {
 			var number int
 			_, err := fmt.Sscanf(parts[3], "%d", &number)
@@ -245,9 +531,10 @@ func ExtractSubjectInfo(subjectURL string, subjectRaw json.RawMessage) (*types.S
 		if err := json.Unmarshal(subjectRaw, &raw); err == nil {
 			if num, ok := raw["number"].(float64); ok {
 				if htmlURL, ok := raw["html_url"].(string); ok {
-					// Parse HTML URL: https://github.com/{owner}/{repo}/{type}/{number}
-					parts := strings.Split(strings.TrimPrefix(htmlURL, "https://github.com/"), "/")
-					if len(parts) >= 4 {
+					// Parse HTML URL: {web}/{owner}/{repo}/{type}/{number}
+					webPrefix := endpoints.WebURL + "/"
+					parts := strings.Split(strings.TrimPrefix(htmlURL, webPrefix), "/")
+					if strings.HasPrefix(htmlURL, webPrefix) && len(parts) >= 4 {
 						return &types.SubjectInfo{
 							Owner:  parts[0],
 							Repo:   parts[1],
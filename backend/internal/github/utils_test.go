@@ -23,6 +23,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
 )
 
 func TestExtractAuthorFromSubject(t *testing.T) {
@@ -77,6 +79,34 @@ func TestExtractAuthorFromSubject(t *testing.T) {
 			expectedLogin: "user1",
 			expectedID:    111,
 		},
+		{
+			name: "extract from release or commit author field",
+			subjectJSON: json.RawMessage(`{
+				"tag_name": "v1.0.0",
+				"author": {
+					"login": "releaser",
+					"id": 333
+				}
+			}`),
+			expectLogin:   true,
+			expectID:      true,
+			expectedLogin: "releaser",
+			expectedID:    333,
+		},
+		{
+			name: "extract from workflow run actor field",
+			subjectJSON: json.RawMessage(`{
+				"head_sha": "abc123",
+				"actor": {
+					"login": "pusher",
+					"id": 444
+				}
+			}`),
+			expectLogin:   true,
+			expectID:      true,
+			expectedLogin: "pusher",
+			expectedID:    444,
+		},
 		{
 			name: "missing login field",
 			subjectJSON: json.RawMessage(`{
@@ -322,6 +352,415 @@ func TestExtractSubjectMerged(t *testing.T) {
 	}
 }
 
+func TestExtractSubjectDraft(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		want        sql.NullBool
+	}{
+		{
+			name:        "draft PR",
+			subjectJSON: json.RawMessage(`{"draft": true, "state": "open"}`),
+			want:        sql.NullBool{Bool: true, Valid: true},
+		},
+		{
+			name:        "ready PR",
+			subjectJSON: json.RawMessage(`{"draft": false, "state": "open"}`),
+			want:        sql.NullBool{Bool: false, Valid: true},
+		},
+		{
+			name:        "Issue without draft field",
+			subjectJSON: json.RawMessage(`{"state": "open"}`),
+			want:        sql.NullBool{Valid: false},
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+			want:        sql.NullBool{Valid: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractSubjectDraft(tt.subjectJSON))
+		})
+	}
+}
+
+func TestExtractRequestedReviewers(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		viewer      string
+		want        []string
+	}{
+		{
+			name: "users and teams",
+			subjectJSON: json.RawMessage(`{
+				"requested_reviewers": [{"login": "OctoCat"}, {"login": "hubot"}],
+				"requested_teams": [{"slug": "Platform-Team"}]
+			}`),
+			viewer: "someone",
+			want:   []string{"octocat", "hubot", "platform-team"},
+		},
+		{
+			name:        "viewer requested",
+			subjectJSON: json.RawMessage(`{"requested_reviewers": [{"login": "OctoCat"}]}`),
+			viewer:      "octocat",
+			want:        []string{"octocat", ViewerMarker},
+		},
+		{
+			name:        "no pending requests",
+			subjectJSON: json.RawMessage(`{"requested_reviewers": [], "requested_teams": []}`),
+			viewer:      "octocat",
+			want:        []string{},
+		},
+		{
+			name:        "Issue without requested reviewers",
+			subjectJSON: json.RawMessage(`{"state": "open"}`),
+			viewer:      "octocat",
+			want:        nil,
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractRequestedReviewers(tt.subjectJSON, tt.viewer))
+		})
+	}
+}
+
+func TestExtractSubjectAssignees(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		viewer      string
+		want        []string
+	}{
+		{
+			name: "assigned to viewer",
+			subjectJSON: json.RawMessage(
+				`{"assignees": [{"login": "OctoCat"}, {"login": "hubot"}]}`,
+			),
+			viewer: "octocat",
+			want:   []string{"octocat", ViewerMarker, "hubot"},
+		},
+		{
+			name:        "unassigned",
+			subjectJSON: json.RawMessage(`{"assignees": []}`),
+			viewer:      "octocat",
+			want:        []string{},
+		},
+		{
+			name:        "Release without assignees",
+			subjectJSON: json.RawMessage(`{"tag_name": "v1.0"}`),
+			want:        nil,
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractSubjectAssignees(tt.subjectJSON, tt.viewer))
+		})
+	}
+}
+
+func TestExtractSubjectLabels(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		want        []string
+	}{
+		{
+			name: "labels",
+			subjectJSON: json.RawMessage(
+				`{"labels": [{"name": "Bug"}, {"name": "good first issue"}]}`,
+			),
+			want: []string{"bug", "good first issue"},
+		},
+		{
+			name:        "unlabeled",
+			subjectJSON: json.RawMessage(`{"labels": []}`),
+			want:        []string{},
+		},
+		{
+			name:        "Release without labels",
+			subjectJSON: json.RawMessage(`{"tag_name": "v1.0"}`),
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractSubjectLabels(tt.subjectJSON))
+		})
+	}
+}
+
+func TestExtractSubjectMilestone(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		want        sql.NullString
+	}{
+		{
+			name:        "milestone",
+			subjectJSON: json.RawMessage(`{"milestone": {"title": "v2.0", "number": 3}}`),
+			want:        sql.NullString{String: "v2.0", Valid: true},
+		},
+		{
+			name:        "no milestone",
+			subjectJSON: json.RawMessage(`{"milestone": null}`),
+			want:        sql.NullString{},
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+			want:        sql.NullString{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractSubjectMilestone(tt.subjectJSON))
+		})
+	}
+}
+
+func TestExtractSubjectCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		want        sql.NullString
+	}{
+		{
+			name:        "discussion",
+			subjectJSON: json.RawMessage(`{"number": 5, "category": {"name": "Q&A"}}`),
+			want:        sql.NullString{String: "Q&A", Valid: true},
+		},
+		{
+			name:        "issue without a category",
+			subjectJSON: json.RawMessage(`{"number": 5, "labels": []}`),
+			want:        sql.NullString{},
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+			want:        sql.NullString{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractSubjectCategory(tt.subjectJSON))
+		})
+	}
+}
+
+func TestExtractSubjectAnswered(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		want        sql.NullBool
+	}{
+		{
+			name: "answered discussion",
+			subjectJSON: json.RawMessage(
+				`{"category": {"name": "Q&A"}, "answer_chosen_at": "2024-01-02T00:00:00Z"}`,
+			),
+			want: sql.NullBool{Bool: true, Valid: true},
+		},
+		{
+			name:        "unanswered discussion",
+			subjectJSON: json.RawMessage(`{"category": {"name": "Q&A"}, "answer_chosen_at": null}`),
+			want:        sql.NullBool{Bool: false, Valid: true},
+		},
+		{
+			name:        "issue",
+			subjectJSON: json.RawMessage(`{"number": 5, "state": "closed"}`),
+			want:        sql.NullBool{},
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+			want:        sql.NullBool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractSubjectAnswered(tt.subjectJSON))
+		})
+	}
+}
+
+func TestExtractSubjectComments(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		want        sql.NullInt32
+	}{
+		{
+			name:        "issue with comments",
+			subjectJSON: json.RawMessage(`{"number": 7, "comments": 12}`),
+			want:        sql.NullInt32{Int32: 12, Valid: true},
+		},
+		{
+			name:        "no comments yet",
+			subjectJSON: json.RawMessage(`{"comments": 0}`),
+			want:        sql.NullInt32{Int32: 0, Valid: true},
+		},
+		{
+			name:        "release without a comment count",
+			subjectJSON: json.RawMessage(`{"tag_name": "v1.0.0"}`),
+			want:        sql.NullInt32{},
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+			want:        sql.NullInt32{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExtractSubjectComments(tt.subjectJSON))
+		})
+	}
+}
+
+func TestExtractHeadSHA(t *testing.T) {
+	require.Equal(t, "abc123", ExtractHeadSHA(json.RawMessage(`{"head": {"sha": "abc123"}}`)))
+	require.Empty(t, ExtractHeadSHA(json.RawMessage(`{"state": "open"}`)))
+	require.Empty(t, ExtractHeadSHA(json.RawMessage(`{invalid json}`)))
+}
+
+func TestReviewDecision(t *testing.T) {
+	review := func(login, state string) types.PullRequestReview {
+		r := types.PullRequestReview{State: state}
+		r.User.Login = login
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		reviews []types.PullRequestReview
+		want    string
+	}{
+		{
+			name: "no reviews",
+			want: ReviewDecisionRequired,
+		},
+		{
+			name:    "only comments",
+			reviews: []types.PullRequestReview{review("alice", "COMMENTED")},
+			want:    ReviewDecisionRequired,
+		},
+		{
+			name:    "approved",
+			reviews: []types.PullRequestReview{review("alice", "APPROVED")},
+			want:    ReviewDecisionApproved,
+		},
+		{
+			name: "changes requested wins over approval",
+			reviews: []types.PullRequestReview{
+				review("alice", "APPROVED"),
+				review("bob", "CHANGES_REQUESTED"),
+			},
+			want: ReviewDecisionChangesRequested,
+		},
+		{
+			name: "latest review per reviewer counts",
+			reviews: []types.PullRequestReview{
+				review("alice", "CHANGES_REQUESTED"),
+				review("alice", "COMMENTED"),
+				review("alice", "APPROVED"),
+			},
+			want: ReviewDecisionApproved,
+		},
+		{
+			name: "dismissed review is cleared",
+			reviews: []types.PullRequestReview{
+				review("alice", "CHANGES_REQUESTED"),
+				review("alice", "DISMISSED"),
+			},
+			want: ReviewDecisionRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ReviewDecision(tt.reviews))
+		})
+	}
+}
+
+func TestChecksStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		runs   []types.CheckRun
+		status types.CombinedStatus
+		want   string
+	}{
+		{
+			name: "no checks",
+			want: "",
+		},
+		{
+			name: "all passing",
+			runs: []types.CheckRun{
+				{Status: "completed", Conclusion: "success"},
+				{Status: "completed", Conclusion: "skipped"},
+			},
+			status: types.CombinedStatus{Statuses: []types.CommitStatus{{State: "success"}}},
+			want:   ChecksPassing,
+		},
+		{
+			name: "check run in progress",
+			runs: []types.CheckRun{
+				{Status: "completed", Conclusion: "success"},
+				{Status: "in_progress"},
+			},
+			want: ChecksPending,
+		},
+		{
+			name:   "commit status pending",
+			status: types.CombinedStatus{Statuses: []types.CommitStatus{{State: "pending"}}},
+			want:   ChecksPending,
+		},
+		{
+			name: "failure wins over pending",
+			runs: []types.CheckRun{
+				{Status: "queued"},
+				{Status: "completed", Conclusion: "timed_out"},
+			},
+			want: ChecksFailing,
+		},
+		{
+			name:   "commit status error",
+			runs:   []types.CheckRun{{Status: "completed", Conclusion: "success"}},
+			status: types.CombinedStatus{Statuses: []types.CommitStatus{{State: "error"}}},
+			want:   ChecksFailing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ChecksStatus(tt.runs, tt.status))
+		})
+	}
+}
+
 func TestExtractSubjectStateReason(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -381,6 +820,48 @@ func TestExtractSubjectStateReason(t *testing.T) {
 	}
 }
 
+func TestExtractSubjectCreatedAt(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectJSON json.RawMessage
+		want        sql.NullTime
+	}{
+		{
+			name:        "Issue with created_at",
+			subjectJSON: json.RawMessage(`{"number": 1, "created_at": "2024-01-15T10:30:00Z"}`),
+			want: sql.NullTime{
+				Time:  time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+				Valid: true,
+			},
+		},
+		{
+			name:        "Invalid time format",
+			subjectJSON: json.RawMessage(`{"created_at": "yesterday"}`),
+			want:        sql.NullTime{Valid: false},
+		},
+		{
+			name:        "Missing created_at",
+			subjectJSON: json.RawMessage(`{"sha": "abc123"}`),
+			want:        sql.NullTime{Valid: false},
+		},
+		{
+			name:        "Invalid JSON",
+			subjectJSON: json.RawMessage(`{invalid json}`),
+			want:        sql.NullTime{Valid: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractSubjectCreatedAt(tt.subjectJSON)
+			assert.Equal(t, tt.want.Valid, result.Valid)
+			if tt.want.Valid {
+				assert.True(t, tt.want.Time.Equal(result.Time))
+			}
+		})
+	}
+}
+
 func TestExtractSubjectState(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -666,8 +1147,11 @@ func TestExtractPullRequestData_InvalidTimeFormat(t *testing.T) {
 }
 
 func TestExtractSubjectInfo(t *testing.T) {
+	ghes := NewEndpoints("https://github.example.com/api/v3", "")
+
 	tests := []struct {
 		name           string
+		endpoints      types.Endpoints // Defaults to github.com
 		subjectURL     string
 		subjectRaw     json.RawMessage
 		expectedOwner  string
@@ -772,11 +1256,40 @@ func TestExtractSubjectInfo(t *testing.T) {
 			expectedNumber: 100,
 			expectErr:      false,
 		},
+		{
+			name:           "issue from GitHub Enterprise Server API URL",
+			endpoints:      ghes,
+			subjectURL:     "https://github.example.com/api/v3/repos/corp/app/issues/7",
+			expectedOwner:  "corp",
+			expectedRepo:   "app",
+			expectedNumber: 7,
+		},
+		{
+			name:      "pull request from GitHub Enterprise Server HTML URL",
+			endpoints: ghes,
+			subjectRaw: json.RawMessage(`{
+				"number": 8,
+				"html_url": "https://github.example.com/corp/app/pull/8"
+			}`),
+			expectedOwner:  "corp",
+			expectedRepo:   "app",
+			expectedNumber: 8,
+		},
+		{
+			name:       "API URL of another instance",
+			endpoints:  ghes,
+			subjectURL: "https://api.github.com/repos/owner/repo/issues/123",
+			expectErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ExtractSubjectInfo(tt.subjectURL, tt.subjectRaw)
+			endpoints := tt.endpoints
+			if endpoints == (types.Endpoints{}) {
+				endpoints = DefaultEndpoints()
+			}
+			result, err := ExtractSubjectInfo(endpoints, tt.subjectURL, tt.subjectRaw)
 
 			if tt.expectErr {
 				require.Error(t, err)
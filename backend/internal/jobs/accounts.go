@@ -0,0 +1,45 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/riverqueue/river"
+
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+)
+
+// accountSyncs holds the sync service of each GitHub account a worker syncs, by account ID
+type accountSyncs map[int64]sync.SyncOperations
+
+// lookup returns the sync service for a job's account. Jobs without an account ID,
+// including those queued before accounts existed, and all jobs of a worker with no
+// accounts registered use the worker's own sync service. Jobs for an account the worker
+// doesn't know (e.g. one removed from GH_ACCOUNTS) are cancelled, since retrying can't help.
+func (a accountSyncs) lookup(
+	fallback sync.SyncOperations,
+	accountID int64,
+) (sync.SyncOperations, error) {
+	if accountID == 0 || len(a) == 0 {
+		return fallback, nil
+	}
+	syncService, ok := a[accountID]
+	if !ok {
+		return nil, river.JobCancel(fmt.Errorf("unknown GitHub account %d", accountID))
+	}
+	return syncService, nil
+}
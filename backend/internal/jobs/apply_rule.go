@@ -109,6 +109,11 @@ func (w *ApplyRuleWorker) Work(ctx context.Context, job *river.Job[ApplyRuleArgs
 	// Combine with in:anywhere to ensure we include filtered/archived/etc notifications
 	fullQueryStr := fmt.Sprintf("(%s) AND in:anywhere", queryStr)
 
+	defs, err := query.LoadDefinitions(ctx, w.store, fullQueryStr)
+	if err != nil {
+		return fmt.Errorf("failed to load query definitions: %w", err)
+	}
+
 	// Build the query - use a reasonable page size for batch processing
 	const pageSize = 100
 	offset := int32(0)
@@ -116,7 +121,7 @@ func (w *ApplyRuleWorker) Work(ctx context.Context, job *river.Job[ApplyRuleArgs
 	totalMatched := 0
 
 	for {
-		dbQuery, err := query.BuildQuery(fullQueryStr, pageSize, offset)
+		dbQuery, err := query.BuildQuery(fullQueryStr, defs, pageSize, offset)
 		if err != nil {
 			return fmt.Errorf("failed to build query: %w", err)
 		}
@@ -141,7 +146,12 @@ func (w *ApplyRuleWorker) Work(ctx context.Context, job *river.Job[ApplyRuleArgs
 				}
 			}
 
-			if err := w.matcher.ApplyRuleActions(ctx, notification.GithubID, actions); err != nil {
+			if err := w.matcher.ApplyRuleActions(
+				ctx,
+				notification.AccountID,
+				notification.GithubID,
+				actions,
+			); err != nil {
 				// Continue processing other notifications even if one fails
 				continue
 			}
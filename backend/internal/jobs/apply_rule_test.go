@@ -92,10 +92,10 @@ func TestApplyRuleWorker_SuccessWithRuleQuery(t *testing.T) {
 
 	// Expect ApplyRuleActions for each notification
 	mockMatcher.EXPECT().
-		ApplyRuleActions(gomock.Any(), "notif-1", gomock.Any()).
+		ApplyRuleActions(gomock.Any(), gomock.Any(), "notif-1", gomock.Any()).
 		Return(nil)
 	mockMatcher.EXPECT().
-		ApplyRuleActions(gomock.Any(), "notif-2", gomock.Any()).
+		ApplyRuleActions(gomock.Any(), gomock.Any(), "notif-2", gomock.Any()).
 		Return(nil)
 
 	err := worker.Work(context.Background(), job)
@@ -161,7 +161,7 @@ func TestApplyRuleWorker_SuccessWithViewQuery(t *testing.T) {
 
 	// Expect ApplyRuleActions
 	mockMatcher.EXPECT().
-		ApplyRuleActions(gomock.Any(), "notif-1", gomock.Any()).
+		ApplyRuleActions(gomock.Any(), gomock.Any(), "notif-1", gomock.Any()).
 		Return(nil)
 
 	err := worker.Work(context.Background(), job)
@@ -505,10 +505,10 @@ func TestApplyRuleWorker_ApplyRuleActionsError(t *testing.T) {
 	// First notification fails, second succeeds
 	// The code processes all notifications in the result, so both will be called
 	mockMatcher.EXPECT().
-		ApplyRuleActions(gomock.Any(), "notif-1", gomock.Any()).
+		ApplyRuleActions(gomock.Any(), gomock.Any(), "notif-1", gomock.Any()).
 		Return(errors.New("action failed"))
 	mockMatcher.EXPECT().
-		ApplyRuleActions(gomock.Any(), "notif-2", gomock.Any()).
+		ApplyRuleActions(gomock.Any(), gomock.Any(), "notif-2", gomock.Any()).
 		Return(nil)
 
 	// Expect second call for pagination (will return empty and break loop)
@@ -582,13 +582,13 @@ func TestApplyRuleWorker_MultiplePages(t *testing.T) {
 
 	// Expect ApplyRuleActions for all notifications
 	mockMatcher.EXPECT().
-		ApplyRuleActions(gomock.Any(), "notif-1", gomock.Any()).
+		ApplyRuleActions(gomock.Any(), gomock.Any(), "notif-1", gomock.Any()).
 		Return(nil)
 	mockMatcher.EXPECT().
-		ApplyRuleActions(gomock.Any(), "notif-2", gomock.Any()).
+		ApplyRuleActions(gomock.Any(), gomock.Any(), "notif-2", gomock.Any()).
 		Return(nil)
 	mockMatcher.EXPECT().
-		ApplyRuleActions(gomock.Any(), "notif-3", gomock.Any()).
+		ApplyRuleActions(gomock.Any(), gomock.Any(), "notif-3", gomock.Any()).
 		Return(nil)
 
 	err := worker.Work(context.Background(), job)
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/jobs/rule_matcher.go
+// Source: rule_matcher.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/jobs/rule_matcher.go -destination=internal/jobs/mocks/mock_rule_matcher.go -package=mocks
+//	mockgen -source=rule_matcher.go -destination=mocks/mock_rule_matcher.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -42,15 +42,15 @@ func (m *MockRuleMatcherInterface) EXPECT() *MockRuleMatcherInterfaceMockRecorde
 }
 
 // ApplyRuleActions mocks base method.
-func (m *MockRuleMatcherInterface) ApplyRuleActions(ctx context.Context, githubID string, actions models.RuleActions) error {
+func (m *MockRuleMatcherInterface) ApplyRuleActions(ctx context.Context, accountID int64, githubID string, actions models.RuleActions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ApplyRuleActions", ctx, githubID, actions)
+	ret := m.ctrl.Call(m, "ApplyRuleActions", ctx, accountID, githubID, actions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ApplyRuleActions indicates an expected call of ApplyRuleActions.
-func (mr *MockRuleMatcherInterfaceMockRecorder) ApplyRuleActions(ctx, githubID, actions any) *gomock.Call {
+func (mr *MockRuleMatcherInterfaceMockRecorder) ApplyRuleActions(ctx, accountID, githubID, actions any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRuleActions", reflect.TypeOf((*MockRuleMatcherInterface)(nil).ApplyRuleActions), ctx, githubID, actions)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRuleActions", reflect.TypeOf((*MockRuleMatcherInterface)(nil).ApplyRuleActions), ctx, accountID, githubID, actions)
 }
@@ -0,0 +1,51 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// PollSchedule is the River periodic schedule of the notifications sync. It runs every
+// configured interval, or less often while GitHub asks clients to back off with
+// X-Poll-Interval. A new interval applies from the run after the next one, since River
+// schedules each run as the previous one is inserted.
+type PollSchedule struct {
+	interval     time.Duration
+	pollInterval atomic.Int64 // Latest X-Poll-Interval, in nanoseconds (0 = none)
+}
+
+// NewPollSchedule creates a PollSchedule running every interval until GitHub asks for longer
+func NewPollSchedule(interval time.Duration) *PollSchedule {
+	return &PollSchedule{interval: interval}
+}
+
+// Next returns the next time the sync should run, implementing river.PeriodicSchedule
+func (s *PollSchedule) Next(current time.Time) time.Time {
+	return current.Add(s.Interval())
+}
+
+// Interval returns the current time between syncs: the configured interval or GitHub's
+// poll interval, whichever is longer
+func (s *PollSchedule) Interval() time.Duration {
+	return max(s.interval, time.Duration(s.pollInterval.Load()))
+}
+
+// SetPollInterval records the X-Poll-Interval of the latest poll (0 if it wasn't sent)
+func (s *PollSchedule) SetPollInterval(interval time.Duration) {
+	s.pollInterval.Store(int64(max(interval, 0)))
+}
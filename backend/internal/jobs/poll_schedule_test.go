@@ -0,0 +1,43 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollSchedule(t *testing.T) {
+	start := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	schedule := NewPollSchedule(20 * time.Second)
+
+	// Configured interval until GitHub asks for longer
+	require.Equal(t, start.Add(20*time.Second), schedule.Next(start))
+
+	schedule.SetPollInterval(time.Minute)
+	require.Equal(t, start.Add(time.Minute), schedule.Next(start))
+
+	// A shorter poll interval never speeds up the configured one
+	schedule.SetPollInterval(5 * time.Second)
+	require.Equal(t, start.Add(20*time.Second), schedule.Next(start))
+
+	// Back to the configured interval when the header goes away
+	schedule.SetPollInterval(time.Minute)
+	schedule.SetPollInterval(0)
+	require.Equal(t, 20*time.Second, schedule.Interval())
+}
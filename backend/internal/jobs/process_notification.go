@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 
 	"github.com/riverqueue/river"
+	"go.uber.org/zap"
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/github"
 	"github.com/ajbeattie/octobud/backend/internal/github/types"
 	"github.com/ajbeattie/octobud/backend/internal/sync"
 )
@@ -30,6 +32,14 @@ import (
 // ProcessNotificationArgs represents a single notification to process
 type ProcessNotificationArgs struct {
 	NotificationData json.RawMessage `json:"notification_data"`
+	// AccountID is the GitHub account the notification was synced from (0 = the worker's
+	// own sync service)
+	AccountID int64 `json:"account_id,omitempty"`
+	// Subject is the notification's subject, if it was fetched in a batch when syncing
+	Subject *types.HydratedSubject `json:"subject,omitempty"`
+	// SyncRunID is the sync run that queued the job, which its subject fetch failures are
+	// counted against (0 = none)
+	SyncRunID int64 `json:"sync_run_id,omitempty"`
 }
 
 // Kind returns the unique identifier for this job type.
@@ -48,6 +58,7 @@ type ProcessNotificationWorker struct {
 	dbConn      *sql.DB
 	queries     *db.Queries
 	syncService sync.SyncOperations
+	accounts    accountSyncs
 }
 
 // NewProcessNotificationWorker creates a new ProcessNotificationWorker.
@@ -62,11 +73,29 @@ func NewProcessNotificationWorker(
 	}
 }
 
+// WithAccount makes the worker process notifications of a GitHub account, for jobs with
+// its AccountID.
+func (w *ProcessNotificationWorker) WithAccount(
+	accountID int64,
+	syncService sync.SyncOperations,
+) *ProcessNotificationWorker {
+	if w.accounts == nil {
+		w.accounts = accountSyncs{}
+	}
+	w.accounts[accountID] = syncService
+	return w
+}
+
 // Work processes a notification.
 func (w *ProcessNotificationWorker) Work(
 	ctx context.Context,
 	job *river.Job[ProcessNotificationArgs],
 ) error {
+	syncService, err := w.accounts.lookup(w.syncService, job.Args.AccountID)
+	if err != nil {
+		return err
+	}
+
 	var thread types.NotificationThread
 	if err := json.Unmarshal(job.Args.NotificationData, &thread); err != nil {
 		return err
@@ -75,7 +104,13 @@ func (w *ProcessNotificationWorker) Work(
 	// Check if notification already exists (to determine if this is INSERT or UPDATE)
 	var isNewNotification bool
 	if w.dbConn != nil && w.queries != nil {
-		existingNotification, err := w.queries.GetNotificationByGithubID(ctx, thread.ID)
+		existingNotification, err := w.queries.GetNotificationByGithubID(
+			ctx,
+			db.GetNotificationByGithubIDParams{
+				AccountID: syncService.AccountID(),
+				GithubID:  thread.ID,
+			},
+		)
 		isNewNotification = err != nil // If we got an error, notification doesn't exist yet
 		_ = existingNotification       // Suppress unused variable warning
 	} else {
@@ -83,14 +118,28 @@ func (w *ProcessNotificationWorker) Work(
 		isNewNotification = true
 	}
 
-	if err := w.syncService.ProcessNotification(ctx, thread); err != nil {
-		return err
+	// Subject fetches are low priority, so they can't use up the rate limit budget the
+	// notifications poll needs; when they're held back, retry after the reset
+	ctx = sync.WithSyncRun(ctx, job.Args.SyncRunID)
+	if job.Args.Subject != nil {
+		err = syncService.ProcessHydratedNotification(ctx, thread, *job.Args.Subject)
+	} else {
+		err = syncService.ProcessNotification(github.WithLowPriority(ctx), thread)
+	}
+	if err != nil {
+		return snoozeIfRateLimited(err)
 	}
 
 	// Only apply rules to newly created notifications (INSERT), not updates
 	// Skip rule application if db connection is not available (e.g., in tests)
 	if isNewNotification && w.dbConn != nil && w.queries != nil {
-		notification, err := w.queries.GetNotificationByGithubID(ctx, thread.ID)
+		notification, err := w.queries.GetNotificationByGithubID(
+			ctx,
+			db.GetNotificationByGithubIDParams{
+				AccountID: syncService.AccountID(),
+				GithubID:  thread.ID,
+			},
+		)
 		if err == nil {
 			matcher := NewRuleMatcher(w.queries)
 			_, matchErr := matcher.MatchAndApplyRules(ctx, notification.ID)
@@ -103,3 +152,43 @@ func (w *ProcessNotificationWorker) Work(
 
 	return nil
 }
+
+// hydrateSubjects fetches the subjects of threads in batches, for the ProcessNotification
+// jobs queued for them. If that fails, each job fetches its own subject instead.
+func hydrateSubjects(
+	ctx context.Context,
+	logger *zap.Logger,
+	syncService sync.SyncOperations,
+	jobID int64,
+	threads []types.NotificationThread,
+) map[string]types.HydratedSubject {
+	subjects, err := syncService.HydrateSubjects(github.WithLowPriority(ctx), threads)
+	if err != nil {
+		logger.Warn("failed to fetch subjects in batches, fetching them one at a time",
+			zap.Int64("jobID", jobID),
+			zap.Int("count", len(threads)),
+			zap.Error(err))
+		return nil
+	}
+	return subjects
+}
+
+// processNotificationArgs builds the arguments of a ProcessNotification job for thread,
+// queued by the sync run runID, with its subject if it was fetched by hydrateSubjects
+func processNotificationArgs(
+	threadData json.RawMessage,
+	threadID string,
+	accountID int64,
+	runID int64,
+	subjects map[string]types.HydratedSubject,
+) ProcessNotificationArgs {
+	args := ProcessNotificationArgs{
+		NotificationData: threadData,
+		AccountID:        accountID,
+		SyncRunID:        runID,
+	}
+	if subject, ok := subjects[threadID]; ok {
+		args.Subject = &subject
+	}
+	return args
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ajbeattie/octobud/backend/internal/github"
 	"github.com/ajbeattie/octobud/backend/internal/github/types"
 	syncmocks "github.com/ajbeattie/octobud/backend/internal/sync/mocks"
 )
@@ -78,6 +79,38 @@ func TestProcessNotificationWorker_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestProcessNotificationWorker_HydratedSubject tests that a subject fetched when syncing
+// is used instead of fetching it again
+func TestProcessNotificationWorker_HydratedSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadData, err := json.Marshal(types.NotificationThread{ID: "notif-123"})
+	require.NoError(t, err)
+	subject := types.HydratedSubject{Raw: json.RawMessage(`{"number":1}`)}
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		ProcessHydratedNotification(gomock.Any(), gomock.Any(), subject).
+		DoAndReturn(func(_ context.Context, arg types.NotificationThread, _ types.HydratedSubject) error {
+			require.Equal(t, "notif-123", arg.ID)
+			return nil
+		})
+
+	worker := NewProcessNotificationWorker(nil, mockSync)
+
+	job := &river.Job[ProcessNotificationArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args: ProcessNotificationArgs{
+			NotificationData: threadData,
+			Subject:          &subject,
+		},
+	}
+
+	err = worker.Work(context.Background(), job)
+	require.NoError(t, err)
+}
+
 // TestProcessNotificationWorker_UnmarshalError tests handling of invalid JSON
 func TestProcessNotificationWorker_UnmarshalError(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -136,6 +169,64 @@ func TestProcessNotificationWorker_ProcessError(t *testing.T) {
 	require.Contains(t, err.Error(), "database error")
 }
 
+// TestProcessNotificationWorker_RateLimited tests that the job is snoozed until the rate
+// limit resets
+func TestProcessNotificationWorker_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadData, err := json.Marshal(types.NotificationThread{ID: "notif-123"})
+	require.NoError(t, err)
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		ProcessNotification(gomock.Any(), gomock.Any()).
+		Return(&github.RateLimitedError{ResetAt: time.Now().Add(time.Hour), LowPriority: true})
+
+	worker := NewProcessNotificationWorker(nil, mockSync)
+
+	job := &river.Job[ProcessNotificationArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args: ProcessNotificationArgs{
+			NotificationData: threadData,
+		},
+	}
+
+	err = worker.Work(context.Background(), job)
+	var snooze *river.JobSnoozeError
+	require.ErrorAs(t, err, &snooze)
+	require.InDelta(t, time.Hour.Seconds(), snooze.Duration.Seconds(), 5)
+}
+
+// TestProcessNotificationWorker_Account tests that a notification is processed with the
+// sync service of the account it was synced from
+func TestProcessNotificationWorker_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	threadData, err := json.Marshal(types.NotificationThread{ID: "notif-123"})
+	require.NoError(t, err)
+
+	defaultSync := syncmocks.NewMockSyncOperations(ctrl) // No calls expected
+	workSync := syncmocks.NewMockSyncOperations(ctrl)
+	workSync.EXPECT().
+		ProcessNotification(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	worker := NewProcessNotificationWorker(nil, defaultSync).WithAccount(2, workSync)
+
+	job := &river.Job[ProcessNotificationArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args: ProcessNotificationArgs{
+			NotificationData: threadData,
+			AccountID:        2,
+		},
+	}
+
+	err = worker.Work(context.Background(), job)
+	require.NoError(t, err)
+}
+
 // TestProcessNotificationWorker_EmptyData tests handling of empty notification data
 func TestProcessNotificationWorker_EmptyData(t *testing.T) {
 	ctrl := gomock.NewController(t)
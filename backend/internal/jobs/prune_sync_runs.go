@@ -0,0 +1,87 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+)
+
+// PruneSyncRunsArgs are the arguments for the PruneSyncRuns job.
+type PruneSyncRunsArgs struct{}
+
+// Kind returns the unique identifier for this job type.
+func (PruneSyncRunsArgs) Kind() string { return "prune_sync_runs" }
+
+// InsertOpts specifies the queue or other options to use for the job.
+func (PruneSyncRunsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: "sync_notifications", // Share queue with regular sync
+		UniqueOpts: river.UniqueOpts{
+			ByState: []rivertype.JobState{
+				rivertype.JobStateAvailable,
+				rivertype.JobStatePending,
+				rivertype.JobStateRunning,
+				rivertype.JobStateRetryable,
+				rivertype.JobStateScheduled,
+			},
+		},
+	}
+}
+
+// PruneSyncRunsWorker deletes the sync runs of every account older than retention, so the
+// sync run history doesn't grow forever.
+type PruneSyncRunsWorker struct {
+	river.WorkerDefaults[PruneSyncRunsArgs]
+	logger      *zap.Logger
+	syncService sync.SyncOperations
+	retention   time.Duration
+}
+
+// NewPruneSyncRunsWorker creates a new PruneSyncRunsWorker.
+func NewPruneSyncRunsWorker(
+	logger *zap.Logger,
+	syncService sync.SyncOperations,
+	retention time.Duration,
+) *PruneSyncRunsWorker {
+	return &PruneSyncRunsWorker{
+		logger:      logger,
+		syncService: syncService,
+		retention:   retention,
+	}
+}
+
+// Work deletes the sync runs started before the retention period.
+func (w *PruneSyncRunsWorker) Work(
+	ctx context.Context,
+	job *river.Job[PruneSyncRunsArgs],
+) error {
+	deleted, err := w.syncService.PruneSyncRuns(ctx, time.Now().Add(-w.retention))
+	if err != nil {
+		return err
+	}
+
+	w.logger.Debug("pruned sync runs",
+		zap.Int64("jobID", job.ID),
+		zap.Int64("deleted", deleted))
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	syncmocks "github.com/ajbeattie/octobud/backend/internal/sync/mocks"
+)
+
+func pruneSyncRunsJob() *river.Job[PruneSyncRunsArgs] {
+	return &river.Job[PruneSyncRunsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   PruneSyncRunsArgs{},
+	}
+}
+
+// TestPruneSyncRunsWorker_Success tests that runs older than the retention are pruned
+func TestPruneSyncRunsWorker_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		PruneSyncRuns(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, startedBefore time.Time) (int64, error) {
+			require.WithinDuration(t, time.Now().Add(-24*time.Hour), startedBefore, time.Minute)
+			return 5, nil
+		})
+
+	worker := NewPruneSyncRunsWorker(zap.NewNop(), mockSync, 24*time.Hour)
+
+	require.NoError(t, worker.Work(context.Background(), pruneSyncRunsJob()))
+}
+
+// TestPruneSyncRunsWorker_Error tests that a failed prune fails the job
+func TestPruneSyncRunsWorker_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbErr := errors.New("database error")
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().PruneSyncRuns(gomock.Any(), gomock.Any()).Return(int64(0), dbErr)
+
+	worker := NewPruneSyncRunsWorker(zap.NewNop(), mockSync, 24*time.Hour)
+
+	require.ErrorIs(t, worker.Work(context.Background(), pruneSyncRunsJob()), dbErr)
+}
+
+// TestPruneSyncRunsArgs_Kind tests the Kind method
+func TestPruneSyncRunsArgs_Kind(t *testing.T) {
+	require.Equal(t, "prune_sync_runs", PruneSyncRunsArgs{}.Kind())
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/riverqueue/river"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+)
+
+// minRateLimitSnooze keeps a job from being retried straight away when the reset time has
+// already passed, e.g. because of clock skew with GitHub
+const minRateLimitSnooze = time.Second
+
+// snoozeIfRateLimited turns a GitHub rate limit error into a River snooze until the limit
+// resets, so the job is retried then instead of failing. Other errors are returned as is.
+func snoozeIfRateLimited(err error) error {
+	var rateLimited *github.RateLimitedError
+	if !errors.As(err, &rateLimited) {
+		return err
+	}
+	return river.JobSnooze(max(rateLimited.RetryAfter(time.Now()), minRateLimitSnooze))
+}
+
+// saveRateLimit saves the GitHub client's latest rate limit for the sync state API
+func saveRateLimit(
+	ctx context.Context,
+	logger *zap.Logger,
+	syncService sync.SyncOperations,
+	jobID int64,
+) {
+	if err := syncService.SaveRateLimit(ctx); err != nil {
+		// Log but don't fail - it's only reported, not used for syncing
+		logger.Warn("failed to save rate limit",
+			zap.Int64("jobID", jobID),
+			zap.Error(err))
+	}
+}
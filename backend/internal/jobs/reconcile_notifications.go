@@ -0,0 +1,129 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+)
+
+// ReconcileNotificationsArgs are the arguments for the ReconcileNotifications job.
+type ReconcileNotificationsArgs struct {
+	// AccountID is the GitHub account to reconcile (0 = the worker's own sync service)
+	AccountID int64 `json:"account_id,omitempty"`
+}
+
+// Kind returns the unique identifier for this job type.
+func (ReconcileNotificationsArgs) Kind() string { return "reconcile_notifications" }
+
+// InsertOpts specifies the queue or other options to use for the job.
+func (ReconcileNotificationsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: "sync_notifications", // Share queue with regular sync
+		UniqueOpts: river.UniqueOpts{
+			ByArgs: true, // One pending reconciliation per account
+			ByState: []rivertype.JobState{
+				rivertype.JobStateAvailable,
+				rivertype.JobStatePending,
+				rivertype.JobStateRunning,
+				rivertype.JobStateRetryable,
+				rivertype.JobStateScheduled,
+			},
+		},
+	}
+}
+
+// ReconcileNotificationsWorker reconciles local notifications with GitHub's full list, so
+// threads marked done or unsubscribed on github.com, or of deleted repositories, don't stay
+// in the inbox forever. The policy decides what happens to them.
+type ReconcileNotificationsWorker struct {
+	river.WorkerDefaults[ReconcileNotificationsArgs]
+	logger      *zap.Logger
+	syncService sync.SyncOperations
+	policy      models.MissingThreadPolicy
+	accounts    accountSyncs
+}
+
+// NewReconcileNotificationsWorker creates a new ReconcileNotificationsWorker.
+func NewReconcileNotificationsWorker(
+	logger *zap.Logger,
+	syncService sync.SyncOperations,
+	policy models.MissingThreadPolicy,
+) *ReconcileNotificationsWorker {
+	return &ReconcileNotificationsWorker{
+		logger:      logger,
+		syncService: syncService,
+		policy:      policy,
+	}
+}
+
+// WithAccount makes the worker reconcile a GitHub account's notifications, for jobs with
+// its AccountID.
+func (w *ReconcileNotificationsWorker) WithAccount(
+	accountID int64,
+	syncService sync.SyncOperations,
+) *ReconcileNotificationsWorker {
+	if w.accounts == nil {
+		w.accounts = accountSyncs{}
+	}
+	w.accounts[accountID] = syncService
+	return w
+}
+
+// Work reconciles the account's notifications with GitHub.
+func (w *ReconcileNotificationsWorker) Work(
+	ctx context.Context,
+	job *river.Job[ReconcileNotificationsArgs],
+) error {
+	syncService, err := w.accounts.lookup(w.syncService, job.Args.AccountID)
+	if err != nil {
+		return err
+	}
+
+	// Before the initial sync there's nothing to reconcile yet
+	syncCtx, err := syncService.GetSyncContext(ctx)
+	if err != nil {
+		w.logger.Warn("failed to get sync context",
+			zap.Int64("jobID", job.ID),
+			zap.Error(err))
+		return nil
+	}
+	if !syncCtx.IsSyncConfigured || syncCtx.IsInitialSync {
+		w.logger.Debug("initial sync not complete, skipping reconciliation",
+			zap.Int64("jobID", job.ID))
+		return nil
+	}
+
+	_, err = syncService.ReconcileNotifications(ctx, w.policy)
+	saveRateLimit(ctx, w.logger, syncService, job.ID)
+	var rateLimited *github.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		// The schedule runs this job again anyway
+		w.logger.Warn("GitHub rate limit reached, skipping reconciliation",
+			zap.Int64("jobID", job.ID),
+			zap.Time("resetAt", rateLimited.ResetAt))
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+	syncmocks "github.com/ajbeattie/octobud/backend/internal/sync/mocks"
+)
+
+func reconcileJob(accountID int64) *river.Job[ReconcileNotificationsArgs] {
+	return &river.Job[ReconcileNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   ReconcileNotificationsArgs{AccountID: accountID},
+	}
+}
+
+// TestReconcileNotificationsWorker_Success tests that the worker reconciles with its policy
+func TestReconcileNotificationsWorker_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil)
+	mockSync.EXPECT().
+		ReconcileNotifications(gomock.Any(), models.MissingThreadFlag).
+		Return(models.Reconciliation{Policy: models.MissingThreadFlag, Flagged: 2}, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewReconcileNotificationsWorker(zap.NewNop(), mockSync, models.MissingThreadFlag)
+
+	require.NoError(t, worker.Work(context.Background(), reconcileJob(0)))
+}
+
+// TestReconcileNotificationsWorker_BeforeInitialSync tests that nothing is reconciled until
+// the initial sync completes
+func TestReconcileNotificationsWorker_BeforeInitialSync(t *testing.T) {
+	tests := []struct {
+		name    string
+		syncCtx sync.SyncContext
+	}{
+		{name: "not configured", syncCtx: sync.SyncContext{}},
+		{
+			name:    "initial sync",
+			syncCtx: sync.SyncContext{IsSyncConfigured: true, IsInitialSync: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSync := syncmocks.NewMockSyncOperations(ctrl)
+			mockSync.EXPECT().GetSyncContext(gomock.Any()).Return(tt.syncCtx, nil)
+
+			worker := NewReconcileNotificationsWorker(
+				zap.NewNop(),
+				mockSync,
+				models.MissingThreadArchive,
+			)
+
+			require.NoError(t, worker.Work(context.Background(), reconcileJob(0)))
+		})
+	}
+}
+
+// TestReconcileNotificationsWorker_RateLimited tests that a rate limited reconciliation is
+// left to the next scheduled run
+func TestReconcileNotificationsWorker_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil)
+	mockSync.EXPECT().
+		ReconcileNotifications(gomock.Any(), models.MissingThreadArchive).
+		Return(
+			models.Reconciliation{},
+			errors.Join(
+				sync.ErrFailedToFetchNotifications,
+				&github.RateLimitedError{ResetAt: time.Now().Add(time.Hour)},
+			),
+		)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewReconcileNotificationsWorker(zap.NewNop(), mockSync, models.MissingThreadArchive)
+
+	require.NoError(t, worker.Work(context.Background(), reconcileJob(0)))
+}
+
+// TestReconcileNotificationsWorker_Error tests that other errors fail the job, for River to retry
+func TestReconcileNotificationsWorker_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbErr := errors.New("database error")
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil)
+	mockSync.EXPECT().
+		ReconcileNotifications(gomock.Any(), models.MissingThreadArchive).
+		Return(models.Reconciliation{}, dbErr)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewReconcileNotificationsWorker(zap.NewNop(), mockSync, models.MissingThreadArchive)
+
+	require.ErrorIs(t, worker.Work(context.Background(), reconcileJob(0)), dbErr)
+}
+
+// TestReconcileNotificationsWorker_Account tests that an account's notifications are
+// reconciled with its sync service, and jobs for unknown accounts are cancelled
+func TestReconcileNotificationsWorker_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	defaultSync := syncmocks.NewMockSyncOperations(ctrl)
+	accountSync := syncmocks.NewMockSyncOperations(ctrl)
+	accountSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil)
+	accountSync.EXPECT().
+		ReconcileNotifications(gomock.Any(), models.MissingThreadDelete).
+		Return(models.Reconciliation{Policy: models.MissingThreadDelete}, nil)
+	accountSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewReconcileNotificationsWorker(zap.NewNop(), defaultSync, models.MissingThreadDelete)
+	worker = worker.WithAccount(2, accountSync)
+
+	require.NoError(t, worker.Work(context.Background(), reconcileJob(2)))
+
+	err := worker.Work(context.Background(), reconcileJob(3))
+	require.ErrorContains(t, err, "unknown GitHub account 3")
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+
+	"github.com/riverqueue/river"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+)
+
+// RefreshNotificationSubjectsArgs are the arguments for the RefreshNotificationSubjects job,
+// queued for the notifications a webhook delivery is about.
+type RefreshNotificationSubjectsArgs struct {
+	// AccountID is the GitHub account of the notifications (0 = the worker's own sync service)
+	AccountID int64    `json:"account_id,omitempty"`
+	GithubIDs []string `json:"github_ids"`
+}
+
+// Kind returns the unique identifier for this job type.
+func (RefreshNotificationSubjectsArgs) Kind() string { return "refresh_notification_subjects" }
+
+// InsertOpts specifies the queue or other options to use for the job.
+func (RefreshNotificationSubjectsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: "process_notification", // Runs alongside notification processing
+	}
+}
+
+// RefreshNotificationSubjectsWorker refreshes the subjects of an account's notifications,
+// so a pull request merged or an issue closed shows up without waiting for its next
+// notification. While rate limited, the job is snoozed until the limit resets.
+type RefreshNotificationSubjectsWorker struct {
+	river.WorkerDefaults[RefreshNotificationSubjectsArgs]
+	logger      *zap.Logger
+	syncService sync.SyncOperations
+	accounts    accountSyncs
+}
+
+// NewRefreshNotificationSubjectsWorker creates a new RefreshNotificationSubjectsWorker.
+func NewRefreshNotificationSubjectsWorker(
+	logger *zap.Logger,
+	syncService sync.SyncOperations,
+) *RefreshNotificationSubjectsWorker {
+	return &RefreshNotificationSubjectsWorker{
+		logger:      logger,
+		syncService: syncService,
+	}
+}
+
+// WithAccount makes the worker refresh a GitHub account's subjects, for jobs with its
+// AccountID.
+func (w *RefreshNotificationSubjectsWorker) WithAccount(
+	accountID int64,
+	syncService sync.SyncOperations,
+) *RefreshNotificationSubjectsWorker {
+	if w.accounts == nil {
+		w.accounts = accountSyncs{}
+	}
+	w.accounts[accountID] = syncService
+	return w
+}
+
+// Work refreshes the subjects of the job's notifications.
+func (w *RefreshNotificationSubjectsWorker) Work(
+	ctx context.Context,
+	job *river.Job[RefreshNotificationSubjectsArgs],
+) error {
+	syncService, err := w.accounts.lookup(w.syncService, job.Args.AccountID)
+	if err != nil {
+		return err
+	}
+
+	refreshed, err := syncService.RefreshSubjects(ctx, job.Args.GithubIDs)
+	saveRateLimit(ctx, w.logger, syncService, job.ID)
+	if err != nil {
+		return snoozeIfRateLimited(err)
+	}
+
+	w.logger.Debug("refreshed notification subjects",
+		zap.Int64("jobID", job.ID),
+		zap.Int("notifications", len(job.Args.GithubIDs)),
+		zap.Int("refreshed", refreshed))
+	return nil
+}
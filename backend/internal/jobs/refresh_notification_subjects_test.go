@@ -0,0 +1,103 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	syncmocks "github.com/ajbeattie/octobud/backend/internal/sync/mocks"
+)
+
+func refreshNotificationSubjectsJob(
+	accountID int64,
+	githubIDs ...string,
+) *river.Job[RefreshNotificationSubjectsArgs] {
+	return &river.Job[RefreshNotificationSubjectsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   RefreshNotificationSubjectsArgs{AccountID: accountID, GithubIDs: githubIDs},
+	}
+}
+
+// TestRefreshNotificationSubjectsWorker_Success tests that the job's notifications are
+// refreshed with their account's sync service
+func TestRefreshNotificationSubjectsWorker_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	defaultSync := syncmocks.NewMockSyncOperations(ctrl)
+	workSync := syncmocks.NewMockSyncOperations(ctrl)
+	workSync.EXPECT().
+		RefreshSubjects(gomock.Any(), []string{"thread-1", "thread-2"}).
+		Return(2, nil)
+	workSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewRefreshNotificationSubjectsWorker(zap.NewNop(), defaultSync).
+		WithAccount(2, workSync)
+
+	err := worker.Work(
+		context.Background(),
+		refreshNotificationSubjectsJob(2, "thread-1", "thread-2"),
+	)
+	require.NoError(t, err)
+}
+
+// TestRefreshNotificationSubjectsWorker_RateLimited tests that a rate limited refresh is
+// snoozed until the limit resets
+func TestRefreshNotificationSubjectsWorker_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		RefreshSubjects(gomock.Any(), gomock.Any()).
+		Return(0, &github.RateLimitedError{ResetAt: time.Now().Add(time.Hour)})
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewRefreshNotificationSubjectsWorker(zap.NewNop(), mockSync)
+
+	err := worker.Work(context.Background(), refreshNotificationSubjectsJob(0, "thread"))
+	var snooze *river.JobSnoozeError
+	require.ErrorAs(t, err, &snooze)
+}
+
+// TestRefreshNotificationSubjectsWorker_UnknownAccount tests that a job for an account the
+// worker doesn't sync is cancelled
+func TestRefreshNotificationSubjectsWorker_UnknownAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	worker := NewRefreshNotificationSubjectsWorker(
+		zap.NewNop(),
+		syncmocks.NewMockSyncOperations(ctrl),
+	).WithAccount(1, syncmocks.NewMockSyncOperations(ctrl))
+
+	err := worker.Work(context.Background(), refreshNotificationSubjectsJob(3, "thread"))
+	require.ErrorContains(t, err, "unknown GitHub account 3")
+}
+
+// TestRefreshNotificationSubjectsArgs_Kind tests the Kind method
+func TestRefreshNotificationSubjectsArgs_Kind(t *testing.T) {
+	require.Equal(t, "refresh_notification_subjects", RefreshNotificationSubjectsArgs{}.Kind())
+}
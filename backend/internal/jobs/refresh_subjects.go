@@ -0,0 +1,137 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+)
+
+// refreshSubjectsBatchSize is how many subjects a RefreshSubjects job refreshes at most
+const refreshSubjectsBatchSize = 100
+
+// RefreshSubjectsArgs are the arguments for the RefreshSubjects job.
+type RefreshSubjectsArgs struct {
+	// AccountID is the GitHub account to refresh (0 = the worker's own sync service)
+	AccountID int64 `json:"account_id,omitempty"`
+}
+
+// Kind returns the unique identifier for this job type.
+func (RefreshSubjectsArgs) Kind() string { return "refresh_subjects" }
+
+// InsertOpts specifies the queue or other options to use for the job.
+func (RefreshSubjectsArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: "refresh_subjects",
+		UniqueOpts: river.UniqueOpts{
+			ByArgs: true, // One pending refresh per account
+			ByState: []rivertype.JobState{
+				rivertype.JobStateAvailable,
+				rivertype.JobStatePending,
+				rivertype.JobStateRunning,
+				rivertype.JobStateRetryable,
+				rivertype.JobStateScheduled,
+			},
+		},
+	}
+}
+
+// RefreshSubjectsWorker refreshes the subjects of inbox and starred notifications fetched
+// longer than maxAge ago, most recently viewed first, so a pull request merged without a
+// new notification doesn't show as open forever. Its requests have low priority, leaving
+// the end of the rate limit budget to syncing.
+type RefreshSubjectsWorker struct {
+	river.WorkerDefaults[RefreshSubjectsArgs]
+	logger      *zap.Logger
+	syncService sync.SyncOperations
+	maxAge      time.Duration
+	accounts    accountSyncs
+}
+
+// NewRefreshSubjectsWorker creates a new RefreshSubjectsWorker.
+func NewRefreshSubjectsWorker(
+	logger *zap.Logger,
+	syncService sync.SyncOperations,
+	maxAge time.Duration,
+) *RefreshSubjectsWorker {
+	return &RefreshSubjectsWorker{
+		logger:      logger,
+		syncService: syncService,
+		maxAge:      maxAge,
+	}
+}
+
+// WithAccount makes the worker refresh a GitHub account's subjects, for jobs with its
+// AccountID.
+func (w *RefreshSubjectsWorker) WithAccount(
+	accountID int64,
+	syncService sync.SyncOperations,
+) *RefreshSubjectsWorker {
+	if w.accounts == nil {
+		w.accounts = accountSyncs{}
+	}
+	w.accounts[accountID] = syncService
+	return w
+}
+
+// Work refreshes the account's stale subjects.
+func (w *RefreshSubjectsWorker) Work(
+	ctx context.Context,
+	job *river.Job[RefreshSubjectsArgs],
+) error {
+	syncService, err := w.accounts.lookup(w.syncService, job.Args.AccountID)
+	if err != nil {
+		return err
+	}
+
+	// The initial sync fetches every subject anyway, and needs the budget more
+	syncCtx, err := syncService.GetSyncContext(ctx)
+	if err != nil {
+		w.logger.Warn("failed to get sync context",
+			zap.Int64("jobID", job.ID),
+			zap.Error(err))
+		return nil
+	}
+	if !syncCtx.IsSyncConfigured || syncCtx.IsInitialSync {
+		w.logger.Debug("initial sync not complete, skipping subject refresh",
+			zap.Int64("jobID", job.ID))
+		return nil
+	}
+
+	_, err = syncService.RefreshStaleSubjects(
+		github.WithLowPriority(ctx),
+		time.Now().Add(-w.maxAge),
+		refreshSubjectsBatchSize,
+	)
+	saveRateLimit(ctx, w.logger, syncService, job.ID)
+	var rateLimited *github.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		// The schedule runs this job again anyway
+		w.logger.Info("GitHub rate limit budget low, stopping subject refresh",
+			zap.Int64("jobID", job.ID),
+			zap.Time("resetAt", rateLimited.ResetAt))
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+	syncmocks "github.com/ajbeattie/octobud/backend/internal/sync/mocks"
+)
+
+func refreshSubjectsJob(accountID int64) *river.Job[RefreshSubjectsArgs] {
+	return &river.Job[RefreshSubjectsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   RefreshSubjectsArgs{AccountID: accountID},
+	}
+}
+
+// TestRefreshSubjectsWorker_Success tests that subjects older than the max age are
+// refreshed with low priority
+func TestRefreshSubjectsWorker_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil)
+	mockSync.EXPECT().
+		RefreshStaleSubjects(gomock.Any(), gomock.Any(), int32(refreshSubjectsBatchSize)).
+		DoAndReturn(func(_ context.Context, fetchedBefore time.Time, _ int32) (int, error) {
+			require.WithinDuration(t, time.Now().Add(-time.Hour), fetchedBefore, time.Minute)
+			return 3, nil
+		})
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewRefreshSubjectsWorker(zap.NewNop(), mockSync, time.Hour)
+
+	require.NoError(t, worker.Work(context.Background(), refreshSubjectsJob(0)))
+}
+
+// TestRefreshSubjectsWorker_BeforeInitialSync tests that nothing is refreshed until the
+// initial sync completes
+func TestRefreshSubjectsWorker_BeforeInitialSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true, IsInitialSync: true}, nil)
+
+	worker := NewRefreshSubjectsWorker(zap.NewNop(), mockSync, time.Hour)
+
+	require.NoError(t, worker.Work(context.Background(), refreshSubjectsJob(0)))
+}
+
+// TestRefreshSubjectsWorker_RateLimited tests that a refresh held back by the rate limit is
+// left to the next scheduled run
+func TestRefreshSubjectsWorker_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil)
+	mockSync.EXPECT().
+		RefreshStaleSubjects(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(1, errors.Join(
+			sync.ErrFailedToFetchSubject,
+			&github.RateLimitedError{ResetAt: time.Now().Add(time.Hour), LowPriority: true},
+		))
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewRefreshSubjectsWorker(zap.NewNop(), mockSync, time.Hour)
+
+	require.NoError(t, worker.Work(context.Background(), refreshSubjectsJob(0)))
+}
+
+// TestRefreshSubjectsWorker_Account tests that an account's subjects are refreshed with
+// its sync service, and other errors fail the job
+func TestRefreshSubjectsWorker_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbErr := errors.New("database error")
+	defaultSync := syncmocks.NewMockSyncOperations(ctrl)
+	accountSync := syncmocks.NewMockSyncOperations(ctrl)
+	accountSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(sync.SyncContext{IsSyncConfigured: true}, nil)
+	accountSync.EXPECT().
+		RefreshStaleSubjects(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(0, dbErr)
+	accountSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+
+	worker := NewRefreshSubjectsWorker(zap.NewNop(), defaultSync, time.Hour)
+	worker = worker.WithAccount(2, accountSync)
+
+	require.ErrorIs(t, worker.Work(context.Background(), refreshSubjectsJob(2)), dbErr)
+
+	err := worker.Work(context.Background(), refreshSubjectsJob(3))
+	require.ErrorContains(t, err, "unknown GitHub account 3")
+}
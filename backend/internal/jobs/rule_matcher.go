@@ -76,7 +76,7 @@ func (rm *RuleMatcher) MatchAndApplyRules(ctx context.Context, notificationID in
 				}
 			}
 
-			if err := rm.ApplyRuleActions(ctx, notification.GithubID, actions); err != nil {
+			if err := rm.ApplyRuleActions(ctx, notification.AccountID, notification.GithubID, actions); err != nil {
 				// Continue processing other rules even if one fails
 				continue
 			}
@@ -116,8 +116,13 @@ func (rm *RuleMatcher) checkRuleMatch(
 		queryStr = rule.Query.String
 	}
 
+	defs, err := query.LoadDefinitions(ctx, rm.store, queryStr)
+	if err != nil {
+		return false, fmt.Errorf("failed to load query definitions: %w", err)
+	}
+
 	// Parse and build the query
-	dbQuery, err := query.BuildQuery(queryStr, 1, 0)
+	dbQuery, err := query.BuildQuery(queryStr, defs, 1, 0)
 	if err != nil {
 		return false, fmt.Errorf("failed to build query: %w", err)
 	}
@@ -140,50 +145,74 @@ func (rm *RuleMatcher) checkRuleMatch(
 
 // RuleMatcherInterface defines the interface for applying rule actions to notifications
 type RuleMatcherInterface interface {
-	ApplyRuleActions(ctx context.Context, githubID string, actions models.RuleActions) error
+	ApplyRuleActions(
+		ctx context.Context,
+		accountID int64,
+		githubID string,
+		actions models.RuleActions,
+	) error
 }
 
 // ApplyRuleActions applies the actions specified by a rule to a notification
 func (rm *RuleMatcher) ApplyRuleActions(
 	ctx context.Context,
+	accountID int64,
 	githubID string,
 	actions models.RuleActions,
 ) error {
 	var errs []error
 
 	if actions.SkipInbox {
-		if _, err := rm.store.MarkNotificationFiltered(ctx, githubID); err != nil {
+		if _, err := rm.store.MarkNotificationFiltered(ctx, db.MarkNotificationFilteredParams{
+			AccountID: accountID,
+			GithubID:  githubID,
+		}); err != nil {
 			errs = append(errs, fmt.Errorf("failed to mark filtered: %w", err))
 		}
 	}
 
 	if actions.MarkRead {
-		if _, err := rm.store.MarkNotificationRead(ctx, githubID); err != nil {
+		if _, err := rm.store.MarkNotificationRead(ctx, db.MarkNotificationReadParams{
+			AccountID: accountID,
+			GithubID:  githubID,
+		}); err != nil {
 			errs = append(errs, fmt.Errorf("failed to mark read: %w", err))
 		}
 	}
 
 	if actions.Star {
-		if _, err := rm.store.StarNotification(ctx, githubID); err != nil {
+		if _, err := rm.store.StarNotification(ctx, db.StarNotificationParams{
+			AccountID: accountID,
+			GithubID:  githubID,
+		}); err != nil {
 			errs = append(errs, fmt.Errorf("failed to star: %w", err))
 		}
 	}
 
 	if actions.Archive {
-		if _, err := rm.store.ArchiveNotification(ctx, githubID); err != nil {
+		if _, err := rm.store.ArchiveNotification(ctx, db.ArchiveNotificationParams{
+			AccountID: accountID,
+			GithubID:  githubID,
+		}); err != nil {
 			errs = append(errs, fmt.Errorf("failed to archive: %w", err))
 		}
 	}
 
 	if actions.Mute {
-		if _, err := rm.store.MuteNotification(ctx, githubID); err != nil {
+		if _, err := rm.store.MuteNotification(ctx, db.MuteNotificationParams{
+			AccountID: accountID,
+			GithubID:  githubID,
+		}); err != nil {
 			errs = append(errs, fmt.Errorf("failed to mute: %w", err))
 		}
 	}
 
 	// Get notification ID for tag operations
 	if len(actions.AssignTags) > 0 || len(actions.RemoveTags) > 0 {
-		notification, err := rm.store.GetNotificationByGithubID(ctx, githubID)
+		notification, err := rm.store.GetNotificationByGithubID(ctx, db.GetNotificationByGithubIDParams{
+			AccountID: accountID,
+			GithubID:  githubID,
+		})
 		if err != nil {
 			errs = append(
 				errs,
@@ -0,0 +1,99 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/riverqueue/river"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+)
+
+// saveOutboxBatchSize caps the threads saved to the outbox by one SaveGitHubOutbox job
+const saveOutboxBatchSize = 1000
+
+// SaveGitHubOutboxArgs saves a change to an account's threads to the github_outbox table
+type SaveGitHubOutboxArgs struct {
+	Action    string   `json:"action"`
+	AccountID int64    `json:"account_id"`
+	GithubIDs []string `json:"github_ids"`
+}
+
+// Kind returns the unique identifier for this job type.
+func (SaveGitHubOutboxArgs) Kind() string { return "save_github_outbox" }
+
+// InsertOpts specifies the queue or other options to use for the job.
+func (SaveGitHubOutboxArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: "github_write_back"}
+}
+
+// SaveGitHubOutboxWorker saves the outbox entries of a change in one statement, then
+// queues a WriteBack job to deliver each of them.
+type SaveGitHubOutboxWorker struct {
+	river.WorkerDefaults[SaveGitHubOutboxArgs]
+	logger      *zap.Logger
+	store       db.Store
+	riverClient db.RiverClient
+}
+
+// NewSaveGitHubOutboxWorker creates a new SaveGitHubOutboxWorker.
+func NewSaveGitHubOutboxWorker(
+	logger *zap.Logger,
+	store db.Store,
+	riverClient db.RiverClient,
+) *SaveGitHubOutboxWorker {
+	return &SaveGitHubOutboxWorker{
+		logger:      logger,
+		store:       store,
+		riverClient: riverClient,
+	}
+}
+
+// Work saves the entries and queues their delivery.
+func (w *SaveGitHubOutboxWorker) Work(
+	ctx context.Context,
+	job *river.Job[SaveGitHubOutboxArgs],
+) error {
+	if len(job.Args.GithubIDs) == 0 {
+		return nil
+	}
+
+	ids, err := w.store.CreateGitHubOutboxEntries(ctx, db.CreateGitHubOutboxEntriesParams{
+		GithubIds: job.Args.GithubIDs,
+		Action:    job.Args.Action,
+		AccountID: job.Args.AccountID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save %d write-backs to outbox: %w", len(job.Args.GithubIDs), err)
+	}
+
+	params := make([]river.InsertManyParams, len(ids))
+	for i, id := range ids {
+		params[i] = river.InsertManyParams{Args: WriteBackArgs{OutboxID: id}}
+	}
+	if _, err := w.riverClient.InsertMany(ctx, params); err != nil {
+		// Retrying would save the entries twice: SweepGitHubOutbox queues them instead
+		w.logger.Warn("failed to queue write-back jobs",
+			zap.Int64("jobID", job.ID),
+			zap.String("action", job.Args.Action),
+			zap.Int("count", len(ids)),
+			zap.Error(err))
+	}
+	return nil
+}
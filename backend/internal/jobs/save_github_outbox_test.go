@@ -0,0 +1,104 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	dbmocks "github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+func saveGitHubOutboxJob(githubIDs ...string) *river.Job[SaveGitHubOutboxArgs] {
+	return &river.Job[SaveGitHubOutboxArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args: SaveGitHubOutboxArgs{
+			Action:    string(models.GitHubActionMarkDone),
+			AccountID: 2,
+			GithubIDs: githubIDs,
+		},
+	}
+}
+
+func TestSaveGitHubOutboxWorker_SavesAndQueues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockRiver := dbmocks.NewMockRiverClient(ctrl)
+
+	mockStore.EXPECT().
+		CreateGitHubOutboxEntries(gomock.Any(), db.CreateGitHubOutboxEntriesParams{
+			GithubIds: []string{"thread-1", "thread-2"},
+			Action:    string(models.GitHubActionMarkDone),
+			AccountID: 2,
+		}).
+		Return([]int64{10, 11}, nil)
+	mockRiver.EXPECT().
+		InsertMany(gomock.Any(), []river.InsertManyParams{
+			{Args: WriteBackArgs{OutboxID: 10}},
+			{Args: WriteBackArgs{OutboxID: 11}},
+		}).
+		Return(nil, nil)
+
+	worker := NewSaveGitHubOutboxWorker(zap.NewNop(), mockStore, mockRiver)
+	require.NoError(t, worker.Work(context.Background(), saveGitHubOutboxJob("thread-1", "thread-2")))
+}
+
+func TestSaveGitHubOutboxWorker_SaveFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockRiver := dbmocks.NewMockRiverClient(ctrl)
+
+	mockStore.EXPECT().
+		CreateGitHubOutboxEntries(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("database error"))
+
+	worker := NewSaveGitHubOutboxWorker(zap.NewNop(), mockStore, mockRiver)
+	err := worker.Work(context.Background(), saveGitHubOutboxJob("thread-1"))
+	require.ErrorContains(t, err, "database error")
+}
+
+// TestSaveGitHubOutboxWorker_QueueFails tests that saved entries aren't saved again when
+// their jobs fail to queue: the sweep queues them
+func TestSaveGitHubOutboxWorker_QueueFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockRiver := dbmocks.NewMockRiverClient(ctrl)
+
+	mockStore.EXPECT().
+		CreateGitHubOutboxEntries(gomock.Any(), gomock.Any()).
+		Return([]int64{10}, nil)
+	mockRiver.EXPECT().
+		InsertMany(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("queue error"))
+
+	worker := NewSaveGitHubOutboxWorker(zap.NewNop(), mockStore, mockRiver)
+	require.NoError(t, worker.Work(context.Background(), saveGitHubOutboxJob("thread-1")))
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+)
+
+const (
+	// sweepOutboxGracePeriod leaves newly saved entries to the job queued with them
+	sweepOutboxGracePeriod = 5 * time.Minute
+	// sweepOutboxMaxAttempts matches River's default, after which an entry is given up on
+	sweepOutboxMaxAttempts = 25
+	// sweepOutboxBatchSize caps the entries re-queued by one sweep
+	sweepOutboxBatchSize = 100
+)
+
+// SweepGitHubOutboxArgs are the arguments for the SweepGitHubOutbox job.
+type SweepGitHubOutboxArgs struct{}
+
+// Kind returns the unique identifier for this job type.
+func (SweepGitHubOutboxArgs) Kind() string { return "sweep_github_outbox" }
+
+// InsertOpts specifies the queue or other options to use for the job.
+func (SweepGitHubOutboxArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: "github_write_back",
+		UniqueOpts: river.UniqueOpts{
+			ByState: []rivertype.JobState{
+				rivertype.JobStateAvailable,
+				rivertype.JobStatePending,
+				rivertype.JobStateRunning,
+				rivertype.JobStateRetryable,
+				rivertype.JobStateScheduled,
+			},
+		},
+	}
+}
+
+// SweepGitHubOutboxWorker re-queues undelivered github_outbox entries and deletes the
+// delivered ones older than retention. An entry is saved before its WriteBack job is
+// queued, so an entry whose job failed to queue (or was lost) is still delivered.
+type SweepGitHubOutboxWorker struct {
+	river.WorkerDefaults[SweepGitHubOutboxArgs]
+	logger      *zap.Logger
+	store       db.Store
+	riverClient db.RiverClient
+	retention   time.Duration
+}
+
+// NewSweepGitHubOutboxWorker creates a new SweepGitHubOutboxWorker.
+func NewSweepGitHubOutboxWorker(
+	logger *zap.Logger,
+	store db.Store,
+	riverClient db.RiverClient,
+	retention time.Duration,
+) *SweepGitHubOutboxWorker {
+	return &SweepGitHubOutboxWorker{
+		logger:      logger,
+		store:       store,
+		riverClient: riverClient,
+		retention:   retention,
+	}
+}
+
+// Work queues a WriteBack job for each pending entry, then prunes the delivered entries.
+// WriteBack jobs are unique by entry, so entries whose job is still queued are skipped.
+func (w *SweepGitHubOutboxWorker) Work(
+	ctx context.Context,
+	job *river.Job[SweepGitHubOutboxArgs],
+) error {
+	now := time.Now()
+	entries, err := w.store.ListPendingGitHubOutboxEntries(
+		ctx,
+		db.ListPendingGitHubOutboxEntriesParams{
+			CreatedBefore: now.Add(-sweepOutboxGracePeriod),
+			MaxAttempts:   sweepOutboxMaxAttempts,
+			RowLimit:      sweepOutboxBatchSize,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list pending outbox entries: %w", err)
+	}
+
+	requeued := 0
+	for _, entry := range entries {
+		res, err := w.riverClient.Insert(ctx, WriteBackArgs{OutboxID: entry.ID}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to queue write-back job for outbox entry %d: %w", entry.ID, err)
+		}
+		if !res.UniqueSkippedAsDuplicate {
+			requeued++
+		}
+	}
+
+	deleted, err := w.store.DeleteGitHubOutboxEntriesCompletedBefore(ctx, now.Add(-w.retention))
+	if err != nil {
+		return fmt.Errorf("failed to prune outbox entries: %w", err)
+	}
+
+	w.logger.Debug("swept GitHub outbox",
+		zap.Int64("jobID", job.ID),
+		zap.Int("requeued", requeued),
+		zap.Int64("deleted", deleted))
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	dbmocks "github.com/ajbeattie/octobud/backend/internal/db/mocks"
+)
+
+func sweepGitHubOutboxJob() *river.Job[SweepGitHubOutboxArgs] {
+	return &river.Job[SweepGitHubOutboxArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   SweepGitHubOutboxArgs{},
+	}
+}
+
+// TestSweepGitHubOutboxWorker_Success tests that pending entries are re-queued and
+// delivered entries older than the retention are pruned
+func TestSweepGitHubOutboxWorker_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		ListPendingGitHubOutboxEntries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			arg db.ListPendingGitHubOutboxEntriesParams,
+		) ([]db.GithubOutbox, error) {
+			require.WithinDuration(t, time.Now().Add(-sweepOutboxGracePeriod), arg.CreatedBefore, time.Minute)
+			require.Equal(t, int32(sweepOutboxMaxAttempts), arg.MaxAttempts)
+			require.Equal(t, int32(sweepOutboxBatchSize), arg.RowLimit)
+			return []db.GithubOutbox{{ID: 7}, {ID: 8}}, nil
+		})
+	mockStore.EXPECT().
+		DeleteGitHubOutboxEntriesCompletedBefore(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, completedBefore time.Time) (int64, error) {
+			require.WithinDuration(t, time.Now().Add(-24*time.Hour), completedBefore, time.Minute)
+			return 3, nil
+		})
+
+	mockRiver := dbmocks.NewMockRiverClient(ctrl)
+	var queued []int64
+	mockRiver.EXPECT().
+		Insert(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, args river.JobArgs, _ *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+			writeBackArgs, ok := args.(WriteBackArgs)
+			require.True(t, ok)
+			queued = append(queued, writeBackArgs.OutboxID)
+			// Entry 8 still has a job queued
+			return &rivertype.JobInsertResult{
+				Job:                      &rivertype.JobRow{ID: 1},
+				UniqueSkippedAsDuplicate: writeBackArgs.OutboxID == 8,
+			}, nil
+		}).
+		Times(2)
+
+	worker := NewSweepGitHubOutboxWorker(zap.NewNop(), mockStore, mockRiver, 24*time.Hour)
+
+	require.NoError(t, worker.Work(context.Background(), sweepGitHubOutboxJob()))
+	require.Equal(t, []int64{7, 8}, queued)
+}
+
+// TestSweepGitHubOutboxWorker_QueueError tests that a failed re-queue fails the job
+// before anything is pruned
+func TestSweepGitHubOutboxWorker_QueueError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		ListPendingGitHubOutboxEntries(gomock.Any(), gomock.Any()).
+		Return([]db.GithubOutbox{{ID: 7}}, nil)
+
+	riverErr := errors.New("queue error")
+	mockRiver := dbmocks.NewMockRiverClient(ctrl)
+	mockRiver.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, riverErr)
+
+	worker := NewSweepGitHubOutboxWorker(zap.NewNop(), mockStore, mockRiver, 24*time.Hour)
+
+	require.ErrorIs(t, worker.Work(context.Background(), sweepGitHubOutboxJob()), riverErr)
+}
+
+// TestSweepGitHubOutboxWorker_PruneError tests that a failed prune fails the job
+func TestSweepGitHubOutboxWorker_PruneError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbErr := errors.New("database error")
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		ListPendingGitHubOutboxEntries(gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+	mockStore.EXPECT().
+		DeleteGitHubOutboxEntriesCompletedBefore(gomock.Any(), gomock.Any()).
+		Return(int64(0), dbErr)
+
+	worker := NewSweepGitHubOutboxWorker(
+		zap.NewNop(),
+		mockStore,
+		dbmocks.NewMockRiverClient(ctrl),
+		24*time.Hour,
+	)
+
+	require.ErrorIs(t, worker.Work(context.Background(), sweepGitHubOutboxJob()), dbErr)
+}
+
+// TestSweepGitHubOutboxArgs_Kind tests the Kind method
+func TestSweepGitHubOutboxArgs_Kind(t *testing.T) {
+	require.Equal(t, "sweep_github_outbox", SweepGitHubOutboxArgs{}.Kind())
+}
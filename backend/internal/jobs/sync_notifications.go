@@ -18,25 +18,44 @@ package jobs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
 	"go.uber.org/zap"
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 	"github.com/ajbeattie/octobud/backend/internal/sync"
 )
 
 // SyncNotificationsArgs are the arguments for the SyncNotifications job.
-type SyncNotificationsArgs struct{}
+type SyncNotificationsArgs struct {
+	// AccountID is the GitHub account to sync (0 = the worker's own sync service)
+	AccountID int64 `json:"account_id,omitempty"`
+}
 
 // Kind returns the unique identifier for this job type.
 func (SyncNotificationsArgs) Kind() string { return "sync_notifications" }
 
 // InsertOpts specifies the queue or other options to use for the job.
+// Periodic, manual and webhook-triggered syncs of an account collapse into one pending job.
 func (SyncNotificationsArgs) InsertOpts() river.InsertOpts {
 	return river.InsertOpts{
 		Queue: "sync_notifications",
+		UniqueOpts: river.UniqueOpts{
+			ByArgs: true, // One pending sync per account
+			ByState: []rivertype.JobState{
+				rivertype.JobStateAvailable,
+				rivertype.JobStatePending,
+				rivertype.JobStateRunning,
+				rivertype.JobStateRetryable,
+				rivertype.JobStateScheduled,
+			},
+		},
 	}
 }
 
@@ -47,6 +66,9 @@ type SyncNotificationsWorker struct {
 	logger      *zap.Logger
 	syncService sync.SyncOperations
 	riverClient db.RiverClient
+	schedule    *PollSchedule // Adapted to GitHub's X-Poll-Interval, if set
+	accounts    accountSyncs
+	schedules   map[int64]*PollSchedule // Per-account schedules, by account ID
 }
 
 // NewSyncNotificationsWorker creates a new SyncNotificationsWorker.
@@ -62,13 +84,49 @@ func NewSyncNotificationsWorker(
 	}
 }
 
+// WithPollSchedule makes the worker report GitHub's X-Poll-Interval to the schedule that
+// runs it, so an account is polled no more often than GitHub asks.
+func (w *SyncNotificationsWorker) WithPollSchedule(
+	schedule *PollSchedule,
+) *SyncNotificationsWorker {
+	w.schedule = schedule
+	return w
+}
+
+// WithAccount makes the worker sync a GitHub account for jobs with its AccountID, reporting
+// the account's X-Poll-Interval to the schedule that runs them (if not nil).
+func (w *SyncNotificationsWorker) WithAccount(
+	accountID int64,
+	syncService sync.SyncOperations,
+	schedule *PollSchedule,
+) *SyncNotificationsWorker {
+	if w.accounts == nil {
+		w.accounts = accountSyncs{}
+		w.schedules = map[int64]*PollSchedule{}
+	}
+	w.accounts[accountID] = syncService
+	if schedule != nil {
+		w.schedules[accountID] = schedule
+	}
+	return w
+}
+
 // Work executes a single sync operation by fetching notifications and queuing processing jobs.
 func (w *SyncNotificationsWorker) Work(
 	ctx context.Context,
 	job *river.Job[SyncNotificationsArgs],
 ) error {
+	syncService, err := w.accounts.lookup(w.syncService, job.Args.AccountID)
+	if err != nil {
+		return err
+	}
+	schedule := w.schedule
+	if job.Args.AccountID != 0 && w.schedules != nil {
+		schedule = w.schedules[job.Args.AccountID]
+	}
+
 	// Get all sync context at the start - single source of truth
-	syncCtx, err := w.syncService.GetSyncContext(ctx)
+	syncCtx, err := syncService.GetSyncContext(ctx)
 	if err != nil {
 		w.logger.Warn("failed to get sync context",
 			zap.Int64("jobID", job.ID),
@@ -93,11 +151,50 @@ func (w *SyncNotificationsWorker) Work(
 		}
 	}
 
+	// Record the run in the sync run history, however it ends
+	runID := startSyncRun(
+		ctx,
+		w.logger,
+		syncService,
+		job.ID,
+		models.SyncRunKindSync,
+		syncCtx.SinceTimestamp,
+		nil,
+	)
+	var result models.SyncRunResult
+	var runErr error
+	defer func() {
+		finishSyncRun(ctx, w.logger, syncService, job.ID, runID, result, runErr)
+	}()
+
 	// Fetch notifications from GitHub using the pre-computed context
-	threads, err := w.syncService.FetchNotificationsToSync(ctx, syncCtx)
+	poll, err := syncService.FetchNotificationsToSync(ctx, syncCtx)
+	saveRateLimit(ctx, w.logger, syncService, job.ID)
+	runErr = err
+	var rateLimited *github.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		// The schedule runs this job again anyway, and until the reset those runs fail fast
+		// without calling GitHub
+		w.logger.Warn("GitHub rate limit reached, skipping sync",
+			zap.Int64("jobID", job.ID),
+			zap.Time("resetAt", rateLimited.ResetAt))
+		return nil
+	}
 	if err != nil {
 		return err
 	}
+	if schedule != nil {
+		schedule.SetPollInterval(poll.PollInterval)
+	}
+
+	// 304 Not Modified: nothing changed since the last poll, and it cost no rate limit
+	result.NotModified = poll.NotModified
+	if poll.NotModified {
+		w.logger.Debug("notifications not modified", zap.Int64("jobID", job.ID))
+		return nil
+	}
+	threads := poll.Threads
+	result.ThreadsFetched = len(threads)
 
 	// If this was an initial sync and we found zero notifications, mark as complete immediately
 	// This handles the case where user has no notifications (empty account)
@@ -106,7 +203,7 @@ func (w *SyncNotificationsWorker) Work(
 			zap.Int64("jobID", job.ID))
 		now := time.Now().UTC()
 		var zeroTime time.Time // No latest notification time (empty account)
-		if err := w.syncService.UpdateSyncStateAfterProcessingWithInitialSync(
+		if err := syncService.UpdateSyncStateAfterProcessingWithInitialSync(
 			ctx,
 			zeroTime, // No latest notification time
 			&now,     // Mark initial sync as complete now
@@ -116,11 +213,13 @@ func (w *SyncNotificationsWorker) Work(
 				zap.Int64("jobID", job.ID),
 				zap.Error(err))
 		}
+		w.savePollValidators(ctx, syncService, job, poll)
 		return nil
 	}
 
 	if len(threads) == 0 {
 		w.logger.Debug("no new notifications found", zap.Int64("jobID", job.ID))
+		w.savePollValidators(ctx, syncService, job, poll)
 		return nil
 	}
 
@@ -131,6 +230,10 @@ func (w *SyncNotificationsWorker) Work(
 	// Track the latest and oldest update times for sync state
 	var latestUpdate time.Time
 	var oldestNotification time.Time
+	queued := 0
+
+	// Fetch the subjects in batches, rather than one by one in each processing job
+	subjects := hydrateSubjects(ctx, w.logger, syncService, job.ID, threads)
 
 	// Queue individual processing jobs for each notification
 	for _, thread := range threads {
@@ -143,9 +246,11 @@ func (w *SyncNotificationsWorker) Work(
 			continue
 		}
 
-		_, err = w.riverClient.Insert(ctx, ProcessNotificationArgs{
-			NotificationData: threadData,
-		}, nil)
+		_, err = w.riverClient.Insert(
+			ctx,
+			processNotificationArgs(threadData, thread.ID, job.Args.AccountID, runID, subjects),
+			nil,
+		)
 
 		if err != nil {
 			w.logger.Warn("failed to queue notification processing job",
@@ -154,6 +259,7 @@ func (w *SyncNotificationsWorker) Work(
 				zap.Error(err))
 			continue
 		}
+		queued++
 
 		if thread.UpdatedAt.After(latestUpdate) {
 			latestUpdate = thread.UpdatedAt
@@ -167,6 +273,8 @@ func (w *SyncNotificationsWorker) Work(
 		}
 	}
 
+	result.JobsQueued = queued
+
 	// Update sync state after queuing all jobs
 	if !latestUpdate.IsZero() {
 		if syncCtx.IsInitialSync {
@@ -182,7 +290,7 @@ func (w *SyncNotificationsWorker) Work(
 				w.logger.Info("marking initial sync as complete",
 					zap.Int64("jobID", job.ID),
 					zap.Time("oldestNotification", oldestToUse))
-				if err := w.syncService.UpdateSyncStateAfterProcessingWithInitialSync(
+				if err := syncService.UpdateSyncStateAfterProcessingWithInitialSync(
 					ctx,
 					latestUpdate,
 					&now,
@@ -196,7 +304,7 @@ func (w *SyncNotificationsWorker) Work(
 			}
 		} else {
 			// Regular update without initial sync tracking
-			if err := w.syncService.UpdateSyncStateAfterProcessing(ctx, latestUpdate); err != nil {
+			if err := syncService.UpdateSyncStateAfterProcessing(ctx, latestUpdate); err != nil {
 				// Log but don't fail - jobs are already queued
 				w.logger.Warn("failed to update sync state after processing",
 					zap.Int64("jobID", job.ID),
@@ -205,5 +313,29 @@ func (w *SyncNotificationsWorker) Work(
 		}
 	}
 
+	// A 304 on the next poll would skip threads that failed to queue, so only keep the
+	// validators when every thread made it
+	if queued == len(threads) {
+		w.savePollValidators(ctx, syncService, job, poll)
+	}
+
 	return nil
 }
+
+// savePollValidators saves the poll's validators for the next conditional poll
+func (w *SyncNotificationsWorker) savePollValidators(
+	ctx context.Context,
+	syncService sync.SyncOperations,
+	job *river.Job[SyncNotificationsArgs],
+	poll types.NotificationPoll,
+) {
+	if poll.Validators == (types.PollValidators{}) {
+		return
+	}
+	if err := syncService.UpdatePollValidators(ctx, poll.Validators); err != nil {
+		// Log but don't fail - the next poll is just unconditional
+		w.logger.Warn("failed to save poll validators",
+			zap.Int64("jobID", job.ID),
+			zap.Error(err))
+	}
+}
@@ -29,11 +29,28 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/github"
 	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 	"github.com/ajbeattie/octobud/backend/internal/sync"
 	syncmocks "github.com/ajbeattie/octobud/backend/internal/sync/mocks"
 )
 
+// syncRunID is the ID of the sync runs recorded in these tests
+const syncRunID = int64(42)
+
+// expectSyncRun expects a sync run of kind to be recorded, finishing with result and no error
+func expectSyncRun(
+	mockSync *syncmocks.MockSyncOperations,
+	kind models.SyncRunKind,
+	result models.SyncRunResult,
+) {
+	mockSync.EXPECT().
+		StartSyncRun(gomock.Any(), kind, gomock.Any(), gomock.Any()).
+		Return(syncRunID, nil)
+	mockSync.EXPECT().FinishSyncRun(gomock.Any(), syncRunID, result, nil).Return(nil)
+}
+
 // TestSyncNotificationsWorker_Success tests successful sync with multiple notifications
 func TestSyncNotificationsWorker_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -64,9 +81,16 @@ func TestSyncNotificationsWorker_Success(t *testing.T) {
 	mockSync.EXPECT().
 		GetSyncContext(gomock.Any()).
 		Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindSync,
+		models.SyncRunResult{ThreadsFetched: 2, JobsQueued: 2},
+	)
 	mockSync.EXPECT().
 		FetchNotificationsToSync(gomock.Any(), syncCtx).
-		Return(notifications, nil)
+		Return(types.NotificationPoll{Threads: notifications}, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessing(gomock.Any(), time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)).
 		Return(nil)
@@ -84,6 +108,7 @@ func TestSyncNotificationsWorker_Success(t *testing.T) {
 			err := json.Unmarshal(processArgs.NotificationData, &thread)
 			require.NoError(t, err)
 			require.Contains(t, []string{"notif-1", "notif-2"}, thread.ID)
+			require.Equal(t, syncRunID, processArgs.SyncRunID)
 
 			return &rivertype.JobInsertResult{Job: &rivertype.JobRow{ID: 1}}, nil
 		}).
@@ -100,6 +125,107 @@ func TestSyncNotificationsWorker_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestSyncNotificationsWorker_HydratedSubjects tests that subjects fetched in a batch are
+// passed to the ProcessNotification jobs, and that threads without one fetch their own
+func TestSyncNotificationsWorker_HydratedSubjects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	updatedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	notifications := []types.NotificationThread{
+		{ID: "notif-1", UpdatedAt: updatedAt},
+		{ID: "notif-2", UpdatedAt: updatedAt},
+	}
+	subject := types.HydratedSubject{Raw: json.RawMessage(`{"number":1}`)}
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	syncCtx := sync.SyncContext{IsSyncConfigured: true}
+	mockSync.EXPECT().GetSyncContext(gomock.Any()).Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindSync,
+		models.SyncRunResult{ThreadsFetched: 2, JobsQueued: 2},
+	)
+	mockSync.EXPECT().
+		FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(types.NotificationPoll{Threads: notifications}, nil)
+	mockSync.EXPECT().
+		HydrateSubjects(gomock.Any(), notifications).
+		Return(map[string]types.HydratedSubject{"notif-1": subject}, nil)
+	mockSync.EXPECT().UpdateSyncStateAfterProcessing(gomock.Any(), updatedAt).Return(nil)
+
+	subjects := map[string]*types.HydratedSubject{}
+	mockRiver := mocks.NewMockRiverClient(ctrl)
+	mockRiver.EXPECT().
+		Insert(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, args river.JobArgs, _ *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+			processArgs := args.(ProcessNotificationArgs)
+			var thread types.NotificationThread
+			require.NoError(t, json.Unmarshal(processArgs.NotificationData, &thread))
+			subjects[thread.ID] = processArgs.Subject
+			return &rivertype.JobInsertResult{Job: &rivertype.JobRow{ID: 1}}, nil
+		}).
+		Times(2)
+
+	worker := NewSyncNotificationsWorker(zap.NewNop(), mockSync, mockRiver)
+
+	job := &river.Job[SyncNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   SyncNotificationsArgs{},
+	}
+
+	require.NoError(t, worker.Work(context.Background(), job))
+	require.Equal(t, map[string]*types.HydratedSubject{
+		"notif-1": &subject,
+		"notif-2": nil,
+	}, subjects)
+}
+
+// TestSyncNotificationsWorker_HydrateError tests that threads are still queued when the
+// batched subject fetch fails
+func TestSyncNotificationsWorker_HydrateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	updatedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	notifications := []types.NotificationThread{{ID: "notif-1", UpdatedAt: updatedAt}}
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	syncCtx := sync.SyncContext{IsSyncConfigured: true}
+	mockSync.EXPECT().GetSyncContext(gomock.Any()).Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindSync,
+		models.SyncRunResult{ThreadsFetched: 1, JobsQueued: 1},
+	)
+	mockSync.EXPECT().
+		FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(types.NotificationPoll{Threads: notifications}, nil)
+	mockSync.EXPECT().
+		HydrateSubjects(gomock.Any(), notifications).
+		Return(nil, errors.New("graphql error"))
+	mockSync.EXPECT().UpdateSyncStateAfterProcessing(gomock.Any(), updatedAt).Return(nil)
+
+	mockRiver := mocks.NewMockRiverClient(ctrl)
+	mockRiver.EXPECT().
+		Insert(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, args river.JobArgs, _ *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+			require.Nil(t, args.(ProcessNotificationArgs).Subject)
+			return &rivertype.JobInsertResult{Job: &rivertype.JobRow{ID: 1}}, nil
+		})
+
+	worker := NewSyncNotificationsWorker(zap.NewNop(), mockSync, mockRiver)
+
+	job := &river.Job[SyncNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   SyncNotificationsArgs{},
+	}
+
+	require.NoError(t, worker.Work(context.Background(), job))
+}
+
 // TestSyncNotificationsWorker_EmptyResults tests successful sync with no new notifications
 func TestSyncNotificationsWorker_EmptyResults(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -110,9 +236,11 @@ func TestSyncNotificationsWorker_EmptyResults(t *testing.T) {
 	mockSync.EXPECT().
 		GetSyncContext(gomock.Any()).
 		Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(mockSync, models.SyncRunKindSync, models.SyncRunResult{})
 	mockSync.EXPECT().
 		FetchNotificationsToSync(gomock.Any(), syncCtx).
-		Return([]types.NotificationThread{}, nil)
+		Return(types.NotificationPoll{}, nil)
 
 	mockRiver := mocks.NewMockRiverClient(ctrl)
 	// No Insert calls expected for empty results
@@ -163,9 +291,16 @@ func TestSyncNotificationsWorker_FetchError(t *testing.T) {
 	mockSync.EXPECT().
 		GetSyncContext(gomock.Any()).
 		Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	mockSync.EXPECT().
+		StartSyncRun(gomock.Any(), models.SyncRunKindSync, nil, nil).
+		Return(syncRunID, nil)
+	mockSync.EXPECT().
+		FinishSyncRun(gomock.Any(), syncRunID, models.SyncRunResult{}, gomock.Not(gomock.Nil())).
+		Return(nil)
 	mockSync.EXPECT().
 		FetchNotificationsToSync(gomock.Any(), syncCtx).
-		Return(nil, errors.New("API error"))
+		Return(types.NotificationPoll{}, errors.New("API error"))
 
 	mockRiver := mocks.NewMockRiverClient(ctrl)
 	// No Insert calls expected when fetch fails
@@ -182,6 +317,43 @@ func TestSyncNotificationsWorker_FetchError(t *testing.T) {
 	require.Contains(t, err.Error(), "API error")
 }
 
+// TestSyncNotificationsWorker_RateLimited tests that a rate-limited poll is skipped, not failed
+func TestSyncNotificationsWorker_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	syncCtx := sync.SyncContext{IsSyncConfigured: true, IsInitialSync: false}
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	mockSync.EXPECT().
+		StartSyncRun(gomock.Any(), models.SyncRunKindSync, nil, nil).
+		Return(syncRunID, nil)
+	mockSync.EXPECT().
+		FinishSyncRun(gomock.Any(), syncRunID, models.SyncRunResult{}, gomock.Not(gomock.Nil())).
+		Return(nil)
+	mockSync.EXPECT().
+		FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(
+			types.NotificationPoll{},
+			&github.RateLimitedError{ResetAt: time.Now().Add(time.Hour)},
+		)
+
+	mockRiver := mocks.NewMockRiverClient(ctrl)
+
+	worker := NewSyncNotificationsWorker(zap.NewNop(), mockSync, mockRiver)
+
+	job := &river.Job[SyncNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   SyncNotificationsArgs{},
+	}
+
+	err := worker.Work(context.Background(), job)
+	require.NoError(t, err)
+}
+
 // TestSyncNotificationsWorker_QueueingFailure tests partial success when queuing fails
 func TestSyncNotificationsWorker_QueueingFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -203,9 +375,12 @@ func TestSyncNotificationsWorker_QueueingFailure(t *testing.T) {
 	mockSync.EXPECT().
 		GetSyncContext(gomock.Any()).
 		Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(mockSync, models.SyncRunKindSync, models.SyncRunResult{ThreadsFetched: 1})
 	mockSync.EXPECT().
 		FetchNotificationsToSync(gomock.Any(), syncCtx).
-		Return(notifications, nil)
+		Return(types.NotificationPoll{Threads: notifications}, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 	mockRiver := mocks.NewMockRiverClient(ctrl)
 	mockRiver.EXPECT().
@@ -249,9 +424,16 @@ func TestSyncNotificationsWorker_MarshalError(t *testing.T) {
 	mockSync.EXPECT().
 		GetSyncContext(gomock.Any()).
 		Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindSync,
+		models.SyncRunResult{ThreadsFetched: 1, JobsQueued: 1},
+	)
 	mockSync.EXPECT().
 		FetchNotificationsToSync(gomock.Any(), syncCtx).
-		Return(notifications, nil)
+		Return(types.NotificationPoll{Threads: notifications}, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessing(gomock.Any(), gomock.Any()).
 		Return(nil)
@@ -298,9 +480,16 @@ func TestSyncNotificationsWorker_UpdateSyncStateError(t *testing.T) {
 	mockSync.EXPECT().
 		GetSyncContext(gomock.Any()).
 		Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindSync,
+		models.SyncRunResult{ThreadsFetched: 1, JobsQueued: 1},
+	)
 	mockSync.EXPECT().
 		FetchNotificationsToSync(gomock.Any(), syncCtx).
-		Return(notifications, nil)
+		Return(types.NotificationPoll{Threads: notifications}, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessing(gomock.Any(), gomock.Any()).
 		Return(errors.New("database error"))
@@ -324,6 +513,238 @@ func TestSyncNotificationsWorker_UpdateSyncStateError(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestSyncNotificationsWorker_NotModified tests that a 304 queues nothing, leaves the sync
+// state alone and passes GitHub's poll interval to the schedule
+func TestSyncNotificationsWorker_NotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	syncCtx := sync.SyncContext{
+		IsSyncConfigured: true,
+		IsInitialSync:    false,
+		PollValidators:   types.PollValidators{ETag: `W/"abc"`},
+	}
+	mockSync.EXPECT().
+		GetSyncContext(gomock.Any()).
+		Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(mockSync, models.SyncRunKindSync, models.SyncRunResult{NotModified: true})
+	mockSync.EXPECT().
+		FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(types.NotificationPoll{
+			NotModified:  true,
+			Validators:   syncCtx.PollValidators,
+			PollInterval: 2 * time.Minute,
+		}, nil)
+
+	// No Insert or sync state calls expected
+	mockRiver := mocks.NewMockRiverClient(ctrl)
+
+	schedule := NewPollSchedule(20 * time.Second)
+	worker := NewSyncNotificationsWorker(zap.NewNop(), mockSync, mockRiver).
+		WithPollSchedule(schedule)
+
+	job := &river.Job[SyncNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   SyncNotificationsArgs{},
+	}
+
+	err := worker.Work(context.Background(), job)
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Minute, schedule.Interval())
+}
+
+// TestSyncNotificationsWorker_Account tests that a job for an account syncs it with the
+// account's sync service and schedule, and queues processing jobs for the account
+func TestSyncNotificationsWorker_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	updatedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	defaultSync := syncmocks.NewMockSyncOperations(ctrl) // No calls expected
+	workSync := syncmocks.NewMockSyncOperations(ctrl)
+	syncCtx := sync.SyncContext{IsSyncConfigured: true, IsInitialSync: false}
+	workSync.EXPECT().GetSyncContext(gomock.Any()).Return(syncCtx, nil)
+	workSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		workSync,
+		models.SyncRunKindSync,
+		models.SyncRunResult{ThreadsFetched: 1, JobsQueued: 1},
+	)
+	workSync.EXPECT().
+		FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(types.NotificationPoll{
+			Threads:      []types.NotificationThread{{ID: "notif-1", UpdatedAt: updatedAt}},
+			PollInterval: time.Minute,
+		}, nil)
+	workSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
+	workSync.EXPECT().UpdateSyncStateAfterProcessing(gomock.Any(), updatedAt).Return(nil)
+
+	mockRiver := mocks.NewMockRiverClient(ctrl)
+	mockRiver.EXPECT().
+		Insert(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, args river.JobArgs, _ *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+			processArgs, ok := args.(ProcessNotificationArgs)
+			require.True(t, ok)
+			require.Equal(t, int64(2), processArgs.AccountID)
+			return &rivertype.JobInsertResult{Job: &rivertype.JobRow{ID: 2}}, nil
+		})
+
+	defaultSchedule := NewPollSchedule(20 * time.Second)
+	workSchedule := NewPollSchedule(20 * time.Second)
+	worker := NewSyncNotificationsWorker(zap.NewNop(), defaultSync, mockRiver).
+		WithPollSchedule(defaultSchedule).
+		WithAccount(2, workSync, workSchedule)
+
+	job := &river.Job[SyncNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   SyncNotificationsArgs{AccountID: 2},
+	}
+
+	err := worker.Work(context.Background(), job)
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, workSchedule.Interval())
+	require.Equal(t, 20*time.Second, defaultSchedule.Interval())
+}
+
+// TestSyncNotificationsWorker_UnknownAccount tests that a job for an account the worker
+// doesn't sync fails without syncing anything
+func TestSyncNotificationsWorker_UnknownAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No calls expected on either sync service
+	worker := NewSyncNotificationsWorker(
+		zap.NewNop(),
+		syncmocks.NewMockSyncOperations(ctrl),
+		mocks.NewMockRiverClient(ctrl),
+	).WithAccount(2, syncmocks.NewMockSyncOperations(ctrl), nil)
+
+	job := &river.Job[SyncNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   SyncNotificationsArgs{AccountID: 3},
+	}
+
+	err := worker.Work(context.Background(), job)
+	require.ErrorContains(t, err, "unknown GitHub account 3")
+}
+
+// TestSyncNotificationsWorker_SavesPollValidators tests that the validators are saved once
+// every notification is queued, and not when one failed to queue
+func TestSyncNotificationsWorker_SavesPollValidators(t *testing.T) {
+	validators := types.PollValidators{
+		ETag:         `W/"def"`,
+		LastModified: "Mon, 15 Jan 2024 11:00:00 GMT",
+	}
+	notifications := []types.NotificationThread{
+		{ID: "notif-1", UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{ID: "notif-2", UpdatedAt: time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name        string
+		queueErrors []error
+		queued      int
+		expectSaved bool
+	}{
+		{
+			name:        "all queued",
+			queueErrors: []error{nil, nil},
+			queued:      2,
+			expectSaved: true,
+		},
+		{
+			name:        "one failed to queue",
+			queueErrors: []error{nil, errors.New("queue full")},
+			queued:      1,
+			expectSaved: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSync := syncmocks.NewMockSyncOperations(ctrl)
+			syncCtx := sync.SyncContext{IsSyncConfigured: true, IsInitialSync: false}
+			mockSync.EXPECT().
+				GetSyncContext(gomock.Any()).
+				Return(syncCtx, nil)
+			mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+			expectSyncRun(
+				mockSync,
+				models.SyncRunKindSync,
+				models.SyncRunResult{ThreadsFetched: 2, JobsQueued: tt.queued},
+			)
+			mockSync.EXPECT().
+				FetchNotificationsToSync(gomock.Any(), syncCtx).
+				Return(types.NotificationPoll{Threads: notifications, Validators: validators}, nil)
+			mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
+			mockSync.EXPECT().
+				UpdateSyncStateAfterProcessing(gomock.Any(), gomock.Any()).
+				Return(nil)
+			if tt.expectSaved {
+				mockSync.EXPECT().
+					UpdatePollValidators(gomock.Any(), validators).
+					Return(nil)
+			}
+
+			mockRiver := mocks.NewMockRiverClient(ctrl)
+			for _, queueErr := range tt.queueErrors {
+				var result *rivertype.JobInsertResult
+				if queueErr == nil {
+					result = &rivertype.JobInsertResult{Job: &rivertype.JobRow{ID: 1}}
+				}
+				mockRiver.EXPECT().
+					Insert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(result, queueErr)
+			}
+
+			worker := NewSyncNotificationsWorker(zap.NewNop(), mockSync, mockRiver)
+
+			job := &river.Job[SyncNotificationsArgs]{
+				JobRow: &rivertype.JobRow{ID: 1},
+				Args:   SyncNotificationsArgs{},
+			}
+
+			err := worker.Work(context.Background(), job)
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestSyncNotificationsWorker_SyncRunNotRecorded tests that a sync still runs when the sync
+// run history can't be saved
+func TestSyncNotificationsWorker_SyncRunNotRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	syncCtx := sync.SyncContext{IsSyncConfigured: true, IsInitialSync: false}
+	mockSync.EXPECT().GetSyncContext(gomock.Any()).Return(syncCtx, nil)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	mockSync.EXPECT().
+		StartSyncRun(gomock.Any(), models.SyncRunKindSync, nil, nil).
+		Return(int64(0), errors.New("database error"))
+	mockSync.EXPECT().
+		FinishSyncRun(gomock.Any(), int64(0), models.SyncRunResult{}, nil).
+		Return(nil)
+	mockSync.EXPECT().
+		FetchNotificationsToSync(gomock.Any(), syncCtx).
+		Return(types.NotificationPoll{}, nil)
+
+	worker := NewSyncNotificationsWorker(zap.NewNop(), mockSync, mocks.NewMockRiverClient(ctrl))
+
+	job := &river.Job[SyncNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   SyncNotificationsArgs{},
+	}
+
+	require.NoError(t, worker.Work(context.Background(), job))
+}
+
 // TestSyncNotificationsArgs_Kind tests the Kind method
 func TestSyncNotificationsArgs_Kind(t *testing.T) {
 	args := SyncNotificationsArgs{}
@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 	"github.com/ajbeattie/octobud/backend/internal/sync"
 )
 
@@ -39,6 +40,8 @@ type SyncOlderNotificationsArgs struct {
 	MaxCount *int `json:"maxCount,omitempty"`
 	// UnreadOnly filters to only sync unread notifications
 	UnreadOnly bool `json:"unreadOnly"`
+	// AccountID is the GitHub account to sync (0 = the worker's own sync service)
+	AccountID int64 `json:"accountId,omitempty"`
 }
 
 // Kind returns the unique identifier for this job type.
@@ -58,6 +61,7 @@ type SyncOlderNotificationsWorker struct {
 	logger      *zap.Logger
 	syncService sync.SyncOperations
 	riverClient db.RiverClient
+	accounts    accountSyncs
 }
 
 // NewSyncOlderNotificationsWorker creates a new SyncOlderNotificationsWorker.
@@ -73,12 +77,29 @@ func NewSyncOlderNotificationsWorker(
 	}
 }
 
+// WithAccount makes the worker sync older notifications of a GitHub account, for jobs with
+// its AccountID.
+func (w *SyncOlderNotificationsWorker) WithAccount(
+	accountID int64,
+	syncService sync.SyncOperations,
+) *SyncOlderNotificationsWorker {
+	if w.accounts == nil {
+		w.accounts = accountSyncs{}
+	}
+	w.accounts[accountID] = syncService
+	return w
+}
+
 // Work executes a sync operation for older notifications.
 func (w *SyncOlderNotificationsWorker) Work(
 	ctx context.Context,
 	job *river.Job[SyncOlderNotificationsArgs],
 ) error {
 	args := job.Args
+	syncService, err := w.accounts.lookup(w.syncService, args.AccountID)
+	if err != nil {
+		return err
+	}
 
 	// Compute the time range
 	since := args.UntilTime.AddDate(0, 0, -args.Days)
@@ -91,22 +112,41 @@ func (w *SyncOlderNotificationsWorker) Work(
 		zap.Any("maxCount", args.MaxCount),
 		zap.Bool("unreadOnly", args.UnreadOnly))
 
+	// Record the run in the sync run history, however it ends
+	runID := startSyncRun(
+		ctx,
+		w.logger,
+		syncService,
+		job.ID,
+		models.SyncRunKindBackfill,
+		&since,
+		&args.UntilTime,
+	)
+	var result models.SyncRunResult
+	var runErr error
+	defer func() {
+		finishSyncRun(ctx, w.logger, syncService, job.ID, runID, result, runErr)
+	}()
+
 	// Fetch older notifications using the sync service
 	// The service handles GitHub API calls and filtering
-	threads, err := w.syncService.FetchOlderNotificationsToSync(
+	threads, err := syncService.FetchOlderNotificationsToSync(
 		ctx,
 		since,
 		args.UntilTime,
 		args.MaxCount,
 		args.UnreadOnly,
 	)
+	saveRateLimit(ctx, w.logger, syncService, job.ID)
 	if err != nil {
+		runErr = err
 		w.logger.Error("failed to fetch older notifications",
 			zap.Int64("jobID", job.ID),
 			zap.Error(err))
-		return err
+		return snoozeIfRateLimited(err)
 	}
 
+	result.ThreadsFetched = len(threads)
 	if len(threads) == 0 {
 		w.logger.Info("no older notifications found in time range",
 			zap.Int64("jobID", job.ID))
@@ -120,6 +160,9 @@ func (w *SyncOlderNotificationsWorker) Work(
 	// Track the oldest notification for updating sync state
 	var oldestNotification time.Time
 
+	// Fetch the subjects in batches, rather than one by one in each processing job
+	subjects := hydrateSubjects(ctx, w.logger, syncService, job.ID, threads)
+
 	// Queue individual processing jobs for each notification
 	for _, thread := range threads {
 		threadData, err := json.Marshal(thread)
@@ -131,9 +174,11 @@ func (w *SyncOlderNotificationsWorker) Work(
 			continue
 		}
 
-		_, err = w.riverClient.Insert(ctx, ProcessNotificationArgs{
-			NotificationData: threadData,
-		}, nil)
+		_, err = w.riverClient.Insert(
+			ctx,
+			processNotificationArgs(threadData, thread.ID, args.AccountID, runID, subjects),
+			nil,
+		)
 
 		if err != nil {
 			w.logger.Warn("failed to queue notification processing job",
@@ -142,6 +187,7 @@ func (w *SyncOlderNotificationsWorker) Work(
 				zap.Error(err))
 			continue
 		}
+		result.JobsQueued++
 
 		// Track oldest notification
 		if oldestNotification.IsZero() || thread.UpdatedAt.Before(oldestNotification) {
@@ -157,7 +203,7 @@ func (w *SyncOlderNotificationsWorker) Work(
 
 		// Use UpdateSyncStateAfterProcessingWithInitialSync to update oldest_notification_synced_at
 		// Pass nil for initialSyncCompletedAt (don't change it) and the new oldest timestamp
-		if err := w.syncService.UpdateSyncStateAfterProcessingWithInitialSync(
+		if err := syncService.UpdateSyncStateAfterProcessingWithInitialSync(
 			ctx,
 			time.Time{}, // Don't update latest_notification_at
 			nil,         // Don't change initial_sync_completed_at
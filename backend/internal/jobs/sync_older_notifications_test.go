@@ -30,6 +30,7 @@ import (
 
 	"github.com/ajbeattie/octobud/backend/internal/db/mocks"
 	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 	syncmocks "github.com/ajbeattie/octobud/backend/internal/sync/mocks"
 )
 
@@ -61,9 +62,22 @@ func TestSyncOlderNotificationsWorker_Success(t *testing.T) {
 	}
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	mockSync.EXPECT().
+		StartSyncRun(gomock.Any(), models.SyncRunKindBackfill, &sinceTime, &untilTime).
+		Return(syncRunID, nil)
+	mockSync.EXPECT().
+		FinishSyncRun(
+			gomock.Any(),
+			syncRunID,
+			models.SyncRunResult{ThreadsFetched: 2, JobsQueued: 2},
+			nil,
+		).
+		Return(nil)
 	mockSync.EXPECT().
 		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
 		Return(notifications, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessingWithInitialSync(
 			gomock.Any(),
@@ -86,6 +100,7 @@ func TestSyncOlderNotificationsWorker_Success(t *testing.T) {
 			err := json.Unmarshal(processArgs.NotificationData, &thread)
 			require.NoError(t, err)
 			require.Contains(t, []string{"notif-old-1", "notif-old-2"}, thread.ID)
+			require.Equal(t, syncRunID, processArgs.SyncRunID)
 
 			return &rivertype.JobInsertResult{Job: &rivertype.JobRow{ID: 1}}, nil
 		}).
@@ -107,6 +122,63 @@ func TestSyncOlderNotificationsWorker_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestSyncOlderNotificationsWorker_Account tests that older notifications of an account are
+// fetched with the account's sync service and processed for the account
+func TestSyncOlderNotificationsWorker_Account(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	untilTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC)
+
+	defaultSync := syncmocks.NewMockSyncOperations(ctrl) // No calls expected
+	workSync := syncmocks.NewMockSyncOperations(ctrl)
+	workSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		workSync,
+		models.SyncRunKindBackfill,
+		models.SyncRunResult{ThreadsFetched: 1, JobsQueued: 1},
+	)
+	since := untilTime.AddDate(0, 0, -7)
+	workSync.EXPECT().
+		FetchOlderNotificationsToSync(gomock.Any(), since, untilTime, (*int)(nil), false).
+		Return([]types.NotificationThread{{ID: "notif-old-1", UpdatedAt: updatedAt}}, nil)
+	workSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
+	workSync.EXPECT().
+		UpdateSyncStateAfterProcessingWithInitialSync(
+			gomock.Any(),
+			time.Time{},
+			(*time.Time)(nil),
+			&updatedAt,
+		).
+		Return(nil)
+
+	mockRiver := mocks.NewMockRiverClient(ctrl)
+	mockRiver.EXPECT().
+		Insert(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, args river.JobArgs, _ *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+			processArgs, ok := args.(ProcessNotificationArgs)
+			require.True(t, ok)
+			require.Equal(t, int64(2), processArgs.AccountID)
+			return &rivertype.JobInsertResult{Job: &rivertype.JobRow{ID: 2}}, nil
+		})
+
+	worker := NewSyncOlderNotificationsWorker(zap.NewNop(), defaultSync, mockRiver).
+		WithAccount(2, workSync)
+
+	job := &river.Job[SyncOlderNotificationsArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args: SyncOlderNotificationsArgs{
+			Days:      7,
+			UntilTime: untilTime,
+			AccountID: 2,
+		},
+	}
+
+	err := worker.Work(context.Background(), job)
+	require.NoError(t, err)
+}
+
 // TestSyncOlderNotificationsWorker_EmptyResults tests when no older notifications are found
 func TestSyncOlderNotificationsWorker_EmptyResults(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -116,6 +188,8 @@ func TestSyncOlderNotificationsWorker_EmptyResults(t *testing.T) {
 	sinceTime := untilTime.AddDate(0, 0, -30)
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(mockSync, models.SyncRunKindBackfill, models.SyncRunResult{})
 	mockSync.EXPECT().
 		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
 		Return([]types.NotificationThread{}, nil)
@@ -157,9 +231,16 @@ func TestSyncOlderNotificationsWorker_WithMaxCount(t *testing.T) {
 	}
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindBackfill,
+		models.SyncRunResult{ThreadsFetched: 1, JobsQueued: 1},
+	)
 	mockSync.EXPECT().
 		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, &maxCount, false).
 		Return(notifications, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessingWithInitialSync(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil)
@@ -202,10 +283,17 @@ func TestSyncOlderNotificationsWorker_WithUnreadOnly(t *testing.T) {
 	}
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindBackfill,
+		models.SyncRunResult{ThreadsFetched: 1, JobsQueued: 1},
+	)
 	mockSync.EXPECT().
 		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), true).
 		// unreadOnly=true
 		Return(notifications, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessingWithInitialSync(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil)
@@ -240,6 +328,13 @@ func TestSyncOlderNotificationsWorker_FetchError(t *testing.T) {
 	sinceTime := untilTime.AddDate(0, 0, -30)
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	mockSync.EXPECT().
+		StartSyncRun(gomock.Any(), models.SyncRunKindBackfill, gomock.Any(), gomock.Any()).
+		Return(syncRunID, nil)
+	mockSync.EXPECT().
+		FinishSyncRun(gomock.Any(), syncRunID, models.SyncRunResult{}, gomock.Not(gomock.Nil())).
+		Return(nil)
 	mockSync.EXPECT().
 		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
 		Return(nil, errors.New("API error"))
@@ -280,9 +375,12 @@ func TestSyncOlderNotificationsWorker_QueueingFailure(t *testing.T) {
 	}
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(mockSync, models.SyncRunKindBackfill, models.SyncRunResult{ThreadsFetched: 1})
 	mockSync.EXPECT().
 		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
 		Return(notifications, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	// No sync state update expected when queueing fails (no successful inserts)
 
 	mockRiver := mocks.NewMockRiverClient(ctrl)
@@ -324,9 +422,16 @@ func TestSyncOlderNotificationsWorker_SyncStateUpdateError(t *testing.T) {
 	}
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindBackfill,
+		models.SyncRunResult{ThreadsFetched: 1, JobsQueued: 1},
+	)
 	mockSync.EXPECT().
 		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
 		Return(notifications, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessingWithInitialSync(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(errors.New("database error"))
@@ -380,9 +485,16 @@ func TestSyncOlderNotificationsWorker_TracksOldestNotification(t *testing.T) {
 	}
 
 	mockSync := syncmocks.NewMockSyncOperations(ctrl)
+	mockSync.EXPECT().SaveRateLimit(gomock.Any()).Return(nil)
+	expectSyncRun(
+		mockSync,
+		models.SyncRunKindBackfill,
+		models.SyncRunResult{ThreadsFetched: 3, JobsQueued: 3},
+	)
 	mockSync.EXPECT().
 		FetchOlderNotificationsToSync(gomock.Any(), sinceTime, untilTime, (*int)(nil), false).
 		Return(notifications, nil)
+	mockSync.EXPECT().HydrateSubjects(gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockSync.EXPECT().
 		UpdateSyncStateAfterProcessingWithInitialSync(
 			gomock.Any(),
@@ -0,0 +1,66 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/models"
+	"github.com/ajbeattie/octobud/backend/internal/sync"
+)
+
+// startSyncRun records the start of a sync or backfill run for the sync run history, and
+// returns its ID, or 0 if it couldn't be recorded
+func startSyncRun(
+	ctx context.Context,
+	logger *zap.Logger,
+	syncService sync.SyncOperations,
+	jobID int64,
+	kind models.SyncRunKind,
+	since, before *time.Time,
+) int64 {
+	runID, err := syncService.StartSyncRun(ctx, kind, since, before)
+	if err != nil {
+		// Log but don't fail - the history is only for diagnostics
+		logger.Warn("failed to start sync run",
+			zap.Int64("jobID", jobID),
+			zap.Error(err))
+		return 0
+	}
+	return runID
+}
+
+// finishSyncRun records what a sync run did and the error that ended it, if any
+func finishSyncRun(
+	ctx context.Context,
+	logger *zap.Logger,
+	syncService sync.SyncOperations,
+	jobID int64,
+	runID int64,
+	result models.SyncRunResult,
+	runErr error,
+) {
+	if err := syncService.FinishSyncRun(ctx, runID, result, runErr); err != nil {
+		// Log but don't fail - the history is only for diagnostics
+		logger.Warn("failed to finish sync run",
+			zap.Int64("jobID", jobID),
+			zap.Int64("runID", runID),
+			zap.Error(err))
+	}
+}
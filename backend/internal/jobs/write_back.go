@@ -0,0 +1,220 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	githubinterfaces "github.com/ajbeattie/octobud/backend/internal/github/interfaces"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// WriteBackArgs delivers one github_outbox entry to GitHub
+type WriteBackArgs struct {
+	OutboxID int64 `json:"outbox_id"`
+}
+
+// Kind returns the unique identifier for this job type.
+func (WriteBackArgs) Kind() string { return "github_write_back" }
+
+// InsertOpts specifies the queue or other options to use for the job. Jobs are unique by
+// entry, so SweepGitHubOutbox re-queueing an entry never delivers it twice at once.
+func (WriteBackArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{
+		Queue: "github_write_back",
+		UniqueOpts: river.UniqueOpts{
+			ByArgs: true,
+			ByState: []rivertype.JobState{
+				rivertype.JobStateAvailable,
+				rivertype.JobStatePending,
+				rivertype.JobStateRunning,
+				rivertype.JobStateRetryable,
+				rivertype.JobStateScheduled,
+			},
+		},
+	}
+}
+
+// WriteBackWorker writes a local change back to GitHub. Failed attempts are recorded on
+// the outbox entry and retried by River with backoff, so changes survive GitHub outages.
+type WriteBackWorker struct {
+	river.WorkerDefaults[WriteBackArgs]
+	logger *zap.Logger
+	store  db.Store
+	client githubinterfaces.Client
+	// Per-account clients, by account ID. Entries of other accounts use client.
+	accountClients map[int64]githubinterfaces.Client
+}
+
+// NewWriteBackWorker creates a new WriteBackWorker.
+func NewWriteBackWorker(
+	logger *zap.Logger,
+	store db.Store,
+	client githubinterfaces.Client,
+) *WriteBackWorker {
+	return &WriteBackWorker{
+		logger: logger,
+		store:  store,
+		client: client,
+	}
+}
+
+// WithAccountClient makes the worker deliver the changes of a GitHub account's
+// notifications with the account's client.
+func (w *WriteBackWorker) WithAccountClient(
+	accountID int64,
+	client githubinterfaces.Client,
+) *WriteBackWorker {
+	if w.accountClients == nil {
+		w.accountClients = map[int64]githubinterfaces.Client{}
+	}
+	w.accountClients[accountID] = client
+	return w
+}
+
+// Work delivers the outbox entry, unless it already has been.
+func (w *WriteBackWorker) Work(ctx context.Context, job *river.Job[WriteBackArgs]) error {
+	entry, err := w.store.GetGitHubOutboxEntry(ctx, job.Args.OutboxID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get outbox entry %d: %w", job.Args.OutboxID, err)
+	}
+	if entry.CompletedAt.Valid {
+		return nil
+	}
+
+	if err := w.deliver(ctx, entry); err != nil {
+		// A rate limit isn't a failed attempt: counting it would let a long rate limit
+		// use up the entry's attempts, after which SweepGitHubOutbox gives up on it
+		var rateLimited *github.RateLimitedError
+		if errors.As(err, &rateLimited) {
+			return snoozeIfRateLimited(err)
+		}
+		if recordErr := w.store.RecordGitHubOutboxFailure(ctx, db.RecordGitHubOutboxFailureParams{
+			ID:        entry.ID,
+			LastError: sql.NullString{String: err.Error(), Valid: true},
+		}); recordErr != nil {
+			w.logger.Warn("failed to record write-back failure",
+				zap.Int64("outboxID", entry.ID),
+				zap.Error(recordErr))
+		}
+		return err
+	}
+
+	if err := w.store.CompleteGitHubOutboxEntry(ctx, entry.ID); err != nil {
+		// Retrying resends the change, which GitHub treats as a no-op
+		return fmt.Errorf("failed to complete outbox entry %d: %w", entry.ID, err)
+	}
+	return nil
+}
+
+// deliver makes the entry's change on GitHub
+func (w *WriteBackWorker) deliver(ctx context.Context, entry db.GithubOutbox) error {
+	client, ok := w.accountClients[entry.AccountID]
+	if !ok {
+		client = w.client
+	}
+
+	switch models.GitHubAction(entry.Action) {
+	case models.GitHubActionMarkRead:
+		return client.MarkThreadRead(ctx, entry.GithubID)
+	case models.GitHubActionMarkDone:
+		return client.MarkThreadDone(ctx, entry.GithubID)
+	case models.GitHubActionUnsubscribe:
+		return client.IgnoreThread(ctx, entry.GithubID)
+	default:
+		// Retrying can't help
+		return river.JobCancel(fmt.Errorf("unknown write-back action %q", entry.Action))
+	}
+}
+
+// WriteBackQueue queues local changes to be written back to GitHub: each change is saved
+// to the github_outbox table by a SaveGitHubOutbox job, then delivered by a WriteBackWorker
+// job. It implements notification.WriteBack.
+type WriteBackQueue struct {
+	logger      *zap.Logger
+	store       db.Store
+	riverClient db.RiverClient
+}
+
+// NewWriteBackQueue creates a new WriteBackQueue.
+func NewWriteBackQueue(
+	logger *zap.Logger,
+	store db.Store,
+	riverClient db.RiverClient,
+) *WriteBackQueue {
+	return &WriteBackQueue{
+		logger:      logger,
+		store:       store,
+		riverClient: riverClient,
+	}
+}
+
+// Enabled reports whether the user opted in to writing changes back to GitHub.
+func (q *WriteBackQueue) Enabled(ctx context.Context) bool {
+	user, err := q.store.GetUser(ctx)
+	if err != nil {
+		q.logger.Warn("failed to get user for write-back setting", zap.Error(err))
+		return false
+	}
+	settings, err := models.SyncSettingsFromJSON(user.SyncSettings.RawMessage)
+	if err != nil || settings == nil {
+		return false
+	}
+	return settings.WriteBackToGitHub
+}
+
+// Enqueue queues SaveGitHubOutbox jobs, saveOutboxBatchSize threads each, to save an
+// outbox entry for each of an account's threads and deliver it. Failures are logged: the
+// local change has already been made.
+func (q *WriteBackQueue) Enqueue(
+	ctx context.Context,
+	action models.GitHubAction,
+	accountID int64,
+	githubIDs []string,
+) {
+	var params []river.InsertManyParams
+	for batch := range slices.Chunk(githubIDs, saveOutboxBatchSize) {
+		params = append(params, river.InsertManyParams{Args: SaveGitHubOutboxArgs{
+			Action:    string(action),
+			AccountID: accountID,
+			GithubIDs: batch,
+		}})
+	}
+	if len(params) == 0 {
+		return
+	}
+
+	if _, err := q.riverClient.InsertMany(ctx, params); err != nil {
+		q.logger.Warn("failed to queue write-backs",
+			zap.String("action", string(action)),
+			zap.Int64("accountID", accountID),
+			zap.Int("count", len(githubIDs)),
+			zap.Error(err))
+	}
+}
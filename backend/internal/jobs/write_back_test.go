@@ -0,0 +1,310 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	dbmocks "github.com/ajbeattie/octobud/backend/internal/db/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	githubmocks "github.com/ajbeattie/octobud/backend/internal/github/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+func writeBackJob(outboxID int64) *river.Job[WriteBackArgs] {
+	return &river.Job[WriteBackArgs]{
+		JobRow: &rivertype.JobRow{ID: 1},
+		Args:   WriteBackArgs{OutboxID: outboxID},
+	}
+}
+
+func TestWriteBackWorker_Delivers(t *testing.T) {
+	tests := []struct {
+		name   string
+		action models.GitHubAction
+		expect func(client *githubmocks.MockClient)
+	}{
+		{
+			name:   "mark read",
+			action: models.GitHubActionMarkRead,
+			expect: func(client *githubmocks.MockClient) {
+				client.EXPECT().MarkThreadRead(gomock.Any(), "thread-1").Return(nil)
+			},
+		},
+		{
+			name:   "mark done",
+			action: models.GitHubActionMarkDone,
+			expect: func(client *githubmocks.MockClient) {
+				client.EXPECT().MarkThreadDone(gomock.Any(), "thread-1").Return(nil)
+			},
+		},
+		{
+			name:   "unsubscribe",
+			action: models.GitHubActionUnsubscribe,
+			expect: func(client *githubmocks.MockClient) {
+				client.EXPECT().IgnoreThread(gomock.Any(), "thread-1").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := dbmocks.NewMockStore(ctrl)
+			mockClient := githubmocks.NewMockClient(ctrl)
+
+			mockStore.EXPECT().
+				GetGitHubOutboxEntry(gomock.Any(), int64(7)).
+				Return(db.GithubOutbox{ID: 7, GithubID: "thread-1", Action: string(tt.action)}, nil)
+			tt.expect(mockClient)
+			mockStore.EXPECT().CompleteGitHubOutboxEntry(gomock.Any(), int64(7)).Return(nil)
+
+			worker := NewWriteBackWorker(zap.NewNop(), mockStore, mockClient)
+			require.NoError(t, worker.Work(context.Background(), writeBackJob(7)))
+		})
+	}
+}
+
+func TestWriteBackWorker_AccountClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	defaultClient := githubmocks.NewMockClient(ctrl) // No calls expected
+	workClient := githubmocks.NewMockClient(ctrl)
+
+	mockStore.EXPECT().
+		GetGitHubOutboxEntry(gomock.Any(), int64(7)).
+		Return(db.GithubOutbox{
+			ID:        7,
+			GithubID:  "thread-1",
+			Action:    string(models.GitHubActionMarkRead),
+			AccountID: 2,
+		}, nil)
+	workClient.EXPECT().MarkThreadRead(gomock.Any(), "thread-1").Return(nil)
+	mockStore.EXPECT().CompleteGitHubOutboxEntry(gomock.Any(), int64(7)).Return(nil)
+
+	worker := NewWriteBackWorker(zap.NewNop(), mockStore, defaultClient).
+		WithAccountClient(2, workClient)
+	require.NoError(t, worker.Work(context.Background(), writeBackJob(7)))
+}
+
+func TestWriteBackWorker_AlreadyCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockClient := githubmocks.NewMockClient(ctrl)
+
+	mockStore.EXPECT().
+		GetGitHubOutboxEntry(gomock.Any(), int64(7)).
+		Return(db.GithubOutbox{
+			ID:          7,
+			GithubID:    "thread-1",
+			Action:      string(models.GitHubActionMarkRead),
+			CompletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		}, nil)
+
+	worker := NewWriteBackWorker(zap.NewNop(), mockStore, mockClient)
+	require.NoError(t, worker.Work(context.Background(), writeBackJob(7)))
+}
+
+func TestWriteBackWorker_EntryMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockClient := githubmocks.NewMockClient(ctrl)
+
+	mockStore.EXPECT().
+		GetGitHubOutboxEntry(gomock.Any(), int64(7)).
+		Return(db.GithubOutbox{}, sql.ErrNoRows)
+
+	worker := NewWriteBackWorker(zap.NewNop(), mockStore, mockClient)
+	require.NoError(t, worker.Work(context.Background(), writeBackJob(7)))
+}
+
+func TestWriteBackWorker_RecordsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockClient := githubmocks.NewMockClient(ctrl)
+
+	mockStore.EXPECT().
+		GetGitHubOutboxEntry(gomock.Any(), int64(7)).
+		Return(db.GithubOutbox{
+			ID:       7,
+			GithubID: "thread-1",
+			Action:   string(models.GitHubActionMarkDone),
+		}, nil)
+	mockClient.EXPECT().
+		MarkThreadDone(gomock.Any(), "thread-1").
+		Return(errors.New("github: mark thread done status 502"))
+	mockStore.EXPECT().
+		RecordGitHubOutboxFailure(gomock.Any(), db.RecordGitHubOutboxFailureParams{
+			ID: 7,
+			LastError: sql.NullString{
+				String: "github: mark thread done status 502",
+				Valid:  true,
+			},
+		}).
+		Return(nil)
+
+	worker := NewWriteBackWorker(zap.NewNop(), mockStore, mockClient)
+	err := worker.Work(context.Background(), writeBackJob(7))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 502")
+}
+
+func TestWriteBackWorker_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockClient := githubmocks.NewMockClient(ctrl)
+
+	mockStore.EXPECT().
+		GetGitHubOutboxEntry(gomock.Any(), int64(7)).
+		Return(db.GithubOutbox{
+			ID:       7,
+			GithubID: "thread-1",
+			Action:   string(models.GitHubActionMarkRead),
+		}, nil)
+	mockClient.EXPECT().
+		MarkThreadRead(gomock.Any(), "thread-1").
+		Return(&github.RateLimitedError{ResetAt: time.Now().Add(time.Hour)})
+	// A rate limit doesn't use up one of the entry's attempts
+	mockStore.EXPECT().RecordGitHubOutboxFailure(gomock.Any(), gomock.Any()).Times(0)
+
+	worker := NewWriteBackWorker(zap.NewNop(), mockStore, mockClient)
+	err := worker.Work(context.Background(), writeBackJob(7))
+	var snooze *river.JobSnoozeError
+	require.ErrorAs(t, err, &snooze)
+	require.InDelta(t, time.Hour.Seconds(), snooze.Duration.Seconds(), 5)
+}
+
+func TestWriteBackQueue_Enabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings pqtype.NullRawMessage
+		expected bool
+	}{
+		{
+			name: "opted in",
+			settings: pqtype.NullRawMessage{
+				RawMessage: json.RawMessage(`{"writeBackToGitHub": true}`),
+				Valid:      true,
+			},
+			expected: true,
+		},
+		{
+			name: "opted out",
+			settings: pqtype.NullRawMessage{
+				RawMessage: json.RawMessage(`{"setupCompleted": true}`),
+				Valid:      true,
+			},
+			expected: false,
+		},
+		{
+			name:     "no settings",
+			settings: pqtype.NullRawMessage{},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := dbmocks.NewMockStore(ctrl)
+			mockStore.EXPECT().GetUser(gomock.Any()).Return(db.User{SyncSettings: tt.settings}, nil)
+
+			queue := NewWriteBackQueue(zap.NewNop(), mockStore, dbmocks.NewMockRiverClient(ctrl))
+			require.Equal(t, tt.expected, queue.Enabled(context.Background()))
+		})
+	}
+}
+
+func TestWriteBackQueue_Enqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := dbmocks.NewMockStore(ctrl)
+	mockRiver := dbmocks.NewMockRiverClient(ctrl)
+
+	githubIDs := make([]string, saveOutboxBatchSize+1)
+	for i := range githubIDs {
+		githubIDs[i] = fmt.Sprintf("thread-%d", i)
+	}
+
+	// One job per batch, queued in a single call
+	mockRiver.EXPECT().
+		InsertMany(gomock.Any(), []river.InsertManyParams{
+			{Args: SaveGitHubOutboxArgs{
+				Action:    string(models.GitHubActionMarkRead),
+				AccountID: 2,
+				GithubIDs: githubIDs[:saveOutboxBatchSize],
+			}},
+			{Args: SaveGitHubOutboxArgs{
+				Action:    string(models.GitHubActionMarkRead),
+				AccountID: 2,
+				GithubIDs: githubIDs[saveOutboxBatchSize:],
+			}},
+		}).
+		Return(nil, nil)
+
+	queue := NewWriteBackQueue(zap.NewNop(), mockStore, mockRiver)
+	queue.Enqueue(context.Background(), models.GitHubActionMarkRead, 2, githubIDs)
+}
+
+func TestWriteBackQueue_EnqueueNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No calls expected
+	queue := NewWriteBackQueue(
+		zap.NewNop(),
+		dbmocks.NewMockStore(ctrl),
+		dbmocks.NewMockRiverClient(ctrl),
+	)
+	queue.Enqueue(context.Background(), models.GitHubActionMarkRead, 2, nil)
+}
+
+// TestWriteBackArgs_UniqueByEntry tests that re-queueing an entry can't duplicate a
+// queued job
+func TestWriteBackArgs_UniqueByEntry(t *testing.T) {
+	opts := WriteBackArgs{}.InsertOpts()
+	require.True(t, opts.UniqueOpts.ByArgs)
+	require.Contains(t, opts.UniqueOpts.ByState, rivertype.JobStateRetryable)
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"time"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+)
+
+// DefaultAccountID is the GitHub account that owns notifications synced before accounts
+// were introduced, and jobs queued without an account
+const DefaultAccountID int64 = 1
+
+// DefaultAccountName is the name of the DefaultAccountID account
+const DefaultAccountName = "default"
+
+// GitHubAccount is a GitHub identity whose notifications feed the inbox
+type GitHubAccount struct {
+	ID     int64
+	Name   string
+	APIURL string
+	WebURL string
+	// SyncSettings override the user's sync settings for this account (nil to use the user's)
+	SyncSettings *SyncSettings
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// GitHubAccountFromDB converts a db.GithubAccount to a models.GitHubAccount
+func GitHubAccountFromDB(account db.GithubAccount) (GitHubAccount, error) {
+	settings, err := SyncSettingsFromJSON(account.SyncSettings.RawMessage)
+	if err != nil {
+		return GitHubAccount{}, err
+	}
+	return GitHubAccount{
+		ID:           account.ID,
+		Name:         account.Name,
+		APIURL:       account.ApiUrl,
+		WebURL:       account.WebUrl,
+		SyncSettings: settings,
+		CreatedAt:    account.CreatedAt,
+		UpdatedAt:    account.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+// GitHubAction is a local change written back to a GitHub notification thread
+type GitHubAction string
+
+// GitHubAction constants
+const (
+	GitHubActionMarkRead    GitHubAction = "mark-read"   // Marking read marks the thread read
+	GitHubActionMarkDone    GitHubAction = "mark-done"   // Archiving marks the thread done
+	GitHubActionUnsubscribe GitHubAction = "unsubscribe" // Muting ignores the thread
+)
+
+// GitHubActionForBulkOp returns the action written back for a bulk operation, if any
+func GitHubActionForBulkOp(op BulkOperationType) (GitHubAction, bool) {
+	switch op {
+	case BulkOpMarkRead:
+		return GitHubActionMarkRead, true
+	case BulkOpArchive:
+		return GitHubActionMarkDone, true
+	case BulkOpMute:
+		return GitHubActionUnsubscribe, true
+	default:
+		return "", false
+	}
+}
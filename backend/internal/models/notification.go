@@ -22,11 +22,13 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/db"
 )
 
-// Notification represents a notification with all enriched data (repository, tags, action hints)
+// Notification represents a notification with all enriched data (repository, tags, action hints).
+// AccountID is the GitHub account it was synced from.
 type Notification struct {
 	ID                      int64           `json:"id"`
 	GithubID                string          `json:"githubId"`
 	RepositoryID            int64           `json:"repositoryId"`
+	AccountID               int64           `json:"accountId"`
 	PullRequestID           *int64          `json:"pullRequestId,omitempty"`
 	SubjectType             string          `json:"subjectType"`
 	SubjectTitle            string          `json:"subjectTitle"`
@@ -38,6 +40,7 @@ type Notification struct {
 	Muted                   bool            `json:"muted"`
 	SnoozedUntil            *time.Time      `json:"snoozedUntil,omitempty"`
 	SnoozedAt               *time.Time      `json:"snoozedAt,omitempty"`
+	StaleAt                 *time.Time      `json:"staleAt,omitempty"`
 	EffectiveSortDate       time.Time       `json:"effectiveSortDate"`
 	Starred                 bool            `json:"starred"`
 	Filtered                bool            `json:"filtered"`
@@ -76,6 +79,7 @@ func NotificationFromDB(notification db.Notification) Notification {
 		ID:                      notification.ID,
 		GithubID:                notification.GithubID,
 		RepositoryID:            notification.RepositoryID,
+		AccountID:               notification.AccountID,
 		PullRequestID:           NullInt64Ptr(notification.PullRequestID),
 		SubjectType:             notification.SubjectType,
 		SubjectTitle:            notification.SubjectTitle,
@@ -87,6 +91,7 @@ func NotificationFromDB(notification db.Notification) Notification {
 		Muted:                   notification.Muted,
 		SnoozedUntil:            NullTimePtr(notification.SnoozedUntil),
 		SnoozedAt:               NullTimePtr(notification.SnoozedAt),
+		StaleAt:                 NullTimePtr(notification.StaleAt),
 		EffectiveSortDate:       notification.EffectiveSortDate,
 		Starred:                 notification.Starred,
 		Filtered:                notification.Filtered,
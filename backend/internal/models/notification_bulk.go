@@ -37,6 +37,9 @@ const (
 type BulkOperationTarget struct {
 	// IDs is a list of GitHub notification IDs. Mutually exclusive with Query.
 	IDs []string
+	// AccountID is the GitHub account the IDs belong to, since thread IDs are only
+	// unique within an account
+	AccountID int64
 	// Query is a query string to find notifications. Mutually exclusive with IDs.
 	Query string
 }
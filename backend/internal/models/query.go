@@ -0,0 +1,61 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+// Query completion kinds
+const (
+	QueryCompletionField = "field"
+	QueryCompletionValue = "value"
+	QueryCompletionMacro = "macro"
+)
+
+// QueryCompletion is a single suggestion for the query editor
+type QueryCompletion struct {
+	Text string `json:"text"` // Replacement for the completion span, e.g. "repo:" or "cli/cli"
+	Kind string `json:"kind"` // "field", "value" or "macro"
+}
+
+// QueryCompletions are the suggestions for a cursor position.
+// Start and End are byte offsets of the text the chosen suggestion replaces.
+type QueryCompletions struct {
+	Start int               `json:"start"`
+	End   int               `json:"end"`
+	Field string            `json:"field,omitempty"` // Field being completed, for value suggestions
+	Items []QueryCompletion `json:"items"`
+}
+
+// QueryExplanation shows how a query is parsed and run, for debugging view results
+type QueryExplanation struct {
+	Query    string                   `json:"query"`
+	AST      any                      `json:"ast"`      // Parsed query tree (null if empty)
+	Defaults string                   `json:"defaults"` // inbox, muted-only or none
+	Joins    []string                 `json:"joins"`
+	Where    []string                 `json:"where"`
+	Args     []any                    `json:"args"`
+	OrderBy  []string                 `json:"orderBy"`
+	Total    int64                    `json:"total"` // Rows matched by the whole query
+	Clauses  []QueryExplanationClause `json:"clauses"`
+	Plan     []string                 `json:"plan,omitempty"` // Postgres EXPLAIN, if requested
+}
+
+// QueryExplanationClause is one top-level AND clause of an explained query
+type QueryExplanationClause struct {
+	Source string   `json:"source"` // "query" or "default"
+	Expr   string   `json:"expr"`
+	Where  []string `json:"where"`
+	Args   []any    `json:"args"`
+	Count  int64    `json:"count"` // Rows matched by this clause on its own
+}
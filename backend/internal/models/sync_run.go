@@ -0,0 +1,79 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SyncRunKind is the kind of job a sync run was
+type SyncRunKind string
+
+// SyncRunKind constants
+const (
+	SyncRunKindSync     SyncRunKind = "sync"     // A periodic, manual or webhook-triggered sync
+	SyncRunKindBackfill SyncRunKind = "backfill" // A sync of notifications older than synced
+)
+
+// SyncRun is a recorded sync or backfill run
+type SyncRun struct {
+	ID        int64
+	AccountID int64
+	Kind      SyncRunKind
+	StartedAt time.Time
+	// FinishedAt is NULL while the run is in progress, or if the worker stopped during it
+	FinishedAt sql.NullTime
+	// The window of notifications fetched (updated since WindowSince and before WindowBefore)
+	WindowSince          sql.NullTime
+	WindowBefore         sql.NullTime
+	NotModified          bool
+	ThreadsFetched       int
+	JobsQueued           int
+	SubjectFetchFailures int // Added by the processing jobs the run queued
+	Error                sql.NullString
+	RateLimited          bool
+	RateLimitRemaining   sql.NullInt32
+	RateLimitResetAt     sql.NullTime
+}
+
+// SyncRunResult is what a sync run did, recorded when it finishes
+type SyncRunResult struct {
+	NotModified    bool // GitHub answered 304 Not Modified
+	ThreadsFetched int
+	JobsQueued     int
+	Error          sql.NullString
+	// RateLimited is set when the run stopped at GitHub's rate limit. The remaining budget
+	// and its reset are as seen at the end of the run.
+	RateLimited        bool
+	RateLimitRemaining sql.NullInt32
+	RateLimitResetAt   sql.NullTime
+}
+
+// SyncRunListOptions are the filter and pagination of a sync run listing
+type SyncRunListOptions struct {
+	AccountID int64 // 0 lists the runs of every account
+	Page      int
+	PageSize  int
+}
+
+// SyncRunPage is a page of sync runs, newest first
+type SyncRunPage struct {
+	Runs     []SyncRun
+	Total    int64
+	Page     int
+	PageSize int
+}
@@ -17,6 +17,9 @@ package models
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -27,4 +30,60 @@ type SyncState struct {
 	UpdatedAt                  time.Time
 	InitialSyncCompletedAt     sql.NullTime
 	OldestNotificationSyncedAt sql.NullTime
+	// Cache validators of the latest notifications poll (ETag and Last-Modified headers)
+	LastNotificationEtag     sql.NullString
+	LastNotificationModified sql.NullString
+	// GitHub rate limit budget as last seen by the worker
+	RateLimitLimit     sql.NullInt32
+	RateLimitRemaining sql.NullInt32
+	RateLimitResetAt   sql.NullTime
+	RateLimitUpdatedAt sql.NullTime
+	// What the latest reconciliation with GitHub changed (ReconciledAt is NULL before the first)
+	ReconciledAt       sql.NullTime
+	ReconcilePolicy    sql.NullString
+	ReconcileChecked   sql.NullInt32
+	ReconcileArchived  sql.NullInt32
+	ReconcileFlagged   sql.NullInt32
+	ReconcileDeleted   sql.NullInt32
+	ReconcileUnflagged sql.NullInt32
+}
+
+// MissingThreadPolicy is what reconciliation does with local notifications whose threads
+// GitHub no longer returns (marked done or unsubscribed on github.com, or their repository
+// deleted)
+type MissingThreadPolicy string
+
+// MissingThreadPolicy constants
+const (
+	MissingThreadArchive MissingThreadPolicy = "archive" // Archive the notification
+	MissingThreadFlag    MissingThreadPolicy = "flag"    // Flag it, for is:stale
+	MissingThreadDelete  MissingThreadPolicy = "delete"  // Delete it
+)
+
+// ErrInvalidMissingThreadPolicy is returned for an unknown missing thread policy
+var ErrInvalidMissingThreadPolicy = errors.New("invalid missing thread policy")
+
+// ParseMissingThreadPolicy parses a missing thread policy, defaulting to archive when empty
+func ParseMissingThreadPolicy(value string) (MissingThreadPolicy, error) {
+	switch policy := MissingThreadPolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return MissingThreadArchive, nil
+	case MissingThreadArchive, MissingThreadFlag, MissingThreadDelete:
+		return policy, nil
+	default:
+		return "", errors.Join(
+			ErrInvalidMissingThreadPolicy,
+			fmt.Errorf("%q (valid: archive, flag, delete)", value),
+		)
+	}
+}
+
+// Reconciliation is what a reconciliation of local notifications with GitHub changed
+type Reconciliation struct {
+	Policy    MissingThreadPolicy
+	Checked   int // Unarchived local notifications compared with GitHub's list
+	Archived  int // Missing threads archived
+	Flagged   int // Missing threads newly flagged as stale
+	Deleted   int // Missing threads deleted
+	Unflagged int // Stale threads GitHub returned again
 }
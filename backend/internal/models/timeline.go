@@ -33,7 +33,10 @@ type TimelineItem struct {
 	Message         string
 	SHA             string
 	HTMLURL         string
-	Timestamp       time.Time // For sorting
+	IsAnswer        bool           // Discussion comments marked as the answer
+	UpvoteCount     int            // Discussion comments
+	Replies         []TimelineItem // Replies to a discussion comment, oldest first
+	Timestamp       time.Time      // For sorting
 }
 
 // TimelineResult contains paginated timeline results.
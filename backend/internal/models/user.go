@@ -31,6 +31,8 @@ type SyncSettings struct {
 	InitialSyncMaxCount   *int `json:"initialSyncMaxCount,omitempty"` // Maximum notifications (null = no limit)
 	InitialSyncUnreadOnly bool `json:"initialSyncUnreadOnly"`         // Only sync unread notifications initially
 	SetupCompleted        bool `json:"setupCompleted"`                // Whether setup was completed
+	// WriteBackToGitHub writes mark read, archive and mute back to GitHub (opt-in)
+	WriteBackToGitHub bool `json:"writeBackToGitHub"`
 }
 
 // ToJSON converts SyncSettings to JSON bytes
@@ -63,3 +63,19 @@ func ViewFromDB(view db.View) View {
 		DisplayOrder: int(view.DisplayOrder),
 	}
 }
+
+// QueryMacro is a named query fragment that other queries use as @name
+type QueryMacro struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// QueryMacroFromDB converts a db.QueryMacro to a QueryMacro
+func QueryMacroFromDB(macro db.QueryMacro) QueryMacro {
+	return QueryMacro{
+		ID:    strconv.FormatInt(macro.ID, 10),
+		Name:  macro.Name,
+		Query: macro.Query,
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/query/parse"
+)
+
+// Error definitions
+var (
+	ErrFailedToLoadDefinitions = errors.New("failed to load view and macro definitions")
+)
+
+// DefinitionStore is the part of db.Store that LoadDefinitions reads
+type DefinitionStore interface {
+	ListViews(ctx context.Context) ([]db.View, error)
+	ListQueryMacros(ctx context.Context) ([]db.QueryMacro, error)
+}
+
+// LoadDefinitions returns the views and macros a query's view: terms and @macro references
+// can name. Most queries have none, so the store is only read when queryStr has references
+// (a query that doesn't parse gets no definitions; ParseAndValidate reports why).
+func LoadDefinitions(
+	ctx context.Context,
+	store DefinitionStore,
+	queryStr string,
+) (Definitions, error) {
+	if !HasReferences(queryStr) {
+		return Definitions{}, nil
+	}
+	return LoadAllDefinitions(ctx, store)
+}
+
+// LoadAllDefinitions returns every view and macro, including the system views
+func LoadAllDefinitions(ctx context.Context, store DefinitionStore) (Definitions, error) {
+	defs := Definitions{
+		Views:  make(map[string]string),
+		Macros: make(map[string]string),
+	}
+	for slug, view := range db.SystemViews {
+		defs.Views[slug] = view.Query
+	}
+
+	views, err := store.ListViews(ctx)
+	if err != nil {
+		return Definitions{}, errors.Join(ErrFailedToLoadDefinitions, err)
+	}
+	for _, view := range views {
+		defs.Views[strings.ToLower(view.Slug)] = view.Query.String
+	}
+
+	macros, err := store.ListQueryMacros(ctx)
+	if err != nil {
+		return Definitions{}, errors.Join(ErrFailedToLoadDefinitions, err)
+	}
+	for _, macro := range macros {
+		defs.Macros[strings.ToLower(macro.Name)] = macro.Query
+	}
+
+	return defs, nil
+}
+
+// References returns the view slugs and macro names a query string refers to directly
+// (none if it doesn't parse)
+func References(queryStr string) (views, macros []string) {
+	if queryStr == "" {
+		return nil, nil
+	}
+
+	tokens, err := parse.NewLexer(queryStr).Tokenize()
+	if err != nil {
+		return nil, nil
+	}
+	ast, err := parse.NewParser(tokens).Parse()
+	if err != nil {
+		return nil, nil
+	}
+	return parse.References(ast)
+}
+
+// HasReferences reports whether a query string contains view: terms or @macro references
+func HasReferences(queryStr string) bool {
+	views, macros := References(queryStr)
+	return len(views) > 0 || len(macros) > 0
+}
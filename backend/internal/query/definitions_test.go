@@ -0,0 +1,151 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+)
+
+// fakeDefinitionStore serves fixed views and macros, counting how often it is read
+type fakeDefinitionStore struct {
+	views  []db.View
+	macros []db.QueryMacro
+	err    error
+	reads  int
+}
+
+func (s *fakeDefinitionStore) ListViews(context.Context) ([]db.View, error) {
+	s.reads++
+	return s.views, s.err
+}
+
+func (s *fakeDefinitionStore) ListQueryMacros(context.Context) ([]db.QueryMacro, error) {
+	s.reads++
+	return s.macros, s.err
+}
+
+func newFakeDefinitionStore() *fakeDefinitionStore {
+	return &fakeDefinitionStore{
+		views: []db.View{
+			{Slug: "Work", Query: sql.NullString{String: "org:acme @bots", Valid: true}},
+		},
+		macros: []db.QueryMacro{{Name: "bots", Query: "author:*[bot]"}},
+	}
+}
+
+func TestLoadDefinitions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("queries without references don't read the store", func(t *testing.T) {
+		store := newFakeDefinitionStore()
+		for _, queryStr := range []string{"", "is:unread", `"@bots"`, "@me", "repo:("} {
+			defs, err := LoadDefinitions(ctx, store, queryStr)
+			if err != nil {
+				t.Fatalf("LoadDefinitions(%q) error = %v", queryStr, err)
+			}
+			if defs.Views != nil || defs.Macros != nil {
+				t.Errorf("LoadDefinitions(%q) = %+v, want none", queryStr, defs)
+			}
+		}
+		if store.reads != 0 {
+			t.Errorf("store read %d times, want 0", store.reads)
+		}
+	})
+
+	t.Run("references load every view, system view and macro", func(t *testing.T) {
+		defs, err := LoadDefinitions(ctx, newFakeDefinitionStore(), "view:work")
+		if err != nil {
+			t.Fatalf("LoadDefinitions() error = %v", err)
+		}
+		if defs.Views["work"] != "org:acme @bots" {
+			t.Errorf("Views[work] = %q, want the view's query", defs.Views["work"])
+		}
+		if defs.Views["archive"] != "in:archive" {
+			t.Errorf("Views[archive] = %q, want in:archive", defs.Views["archive"])
+		}
+		if defs.Macros["bots"] != "author:*[bot]" {
+			t.Errorf("Macros[bots] = %q, want the macro's query", defs.Macros["bots"])
+		}
+	})
+
+	t.Run("store errors are wrapped", func(t *testing.T) {
+		store := &fakeDefinitionStore{err: errors.New("database error")}
+		if _, err := LoadDefinitions(ctx, store, "@bots"); !errors.Is(err, ErrFailedToLoadDefinitions) {
+			t.Errorf("LoadDefinitions() error = %v, want ErrFailedToLoadDefinitions", err)
+		}
+	})
+}
+
+func TestReferences(t *testing.T) {
+	views, macros := References("view:work,inbox -@bots OR author:@me")
+	if want := []string{"work", "inbox"}; !slices.Equal(views, want) {
+		t.Errorf("views = %v, want %v", views, want)
+	}
+	if want := []string{"bots"}; !slices.Equal(macros, want) {
+		t.Errorf("macros = %v, want %v", macros, want)
+	}
+	if HasReferences("is:unread") {
+		t.Error(`HasReferences("is:unread") = true, want false`)
+	}
+}
+
+func TestBuildQuery_References(t *testing.T) {
+	defs, err := LoadAllDefinitions(context.Background(), newFakeDefinitionStore())
+	if err != nil {
+		t.Fatalf("LoadAllDefinitions() error = %v", err)
+	}
+
+	t.Run("references build the same SQL as the queries they name", func(t *testing.T) {
+		got, err := BuildQuery("view:work is:unread", defs, 50, 0)
+		if err != nil {
+			t.Fatalf("BuildQuery() error = %v", err)
+		}
+		want, err := BuildQuery("((org:acme (author:*[bot]))) is:unread", Definitions{}, 50, 0)
+		if err != nil {
+			t.Fatalf("BuildQuery() error = %v", err)
+		}
+		if !slices.Equal(got.Where, want.Where) || !slices.Equal(got.Args, want.Args) {
+			t.Errorf("BuildQuery() = %v %v, want %v %v", got.Where, got.Args, want.Where, want.Args)
+		}
+	})
+
+	t.Run("inlined in: terms decide the defaults", func(t *testing.T) {
+		explanation, err := Explain("view:archive", defs)
+		if err != nil {
+			t.Fatalf("Explain() error = %v", err)
+		}
+		if explanation.Defaults != DefaultsNone {
+			t.Errorf("Defaults = %q, want %q", explanation.Defaults, DefaultsNone)
+		}
+	})
+
+	t.Run("unknown references fail expansion", func(t *testing.T) {
+		_, err := BuildQuery("@robots", defs, 50, 0)
+		if !errors.Is(err, ErrExpansionFailed) {
+			t.Fatalf("BuildQuery() error = %v, want ErrExpansionFailed", err)
+		}
+		if errs := QueryErrors(err); len(errs) != 1 || !strings.Contains(errs[0].Message, "@bots") {
+			t.Errorf("QueryErrors() = %v, want one error suggesting @bots", errs)
+		}
+	})
+}
@@ -0,0 +1,623 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"context"
+	gosql "database/sql"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+	"github.com/sqlc-dev/pqtype"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/query/parse"
+)
+
+// differentialDatabaseEnv points the differential tests at a Postgres database.
+// The tests create (and drop) their own schema, so any scratch database works.
+const differentialDatabaseEnv = "OCTOBUD_TEST_DATABASE_URL"
+
+const differentialQueryCount = 500
+
+// TestDifferential_SQLMatchesEvaluator runs random queries through both the SQL builder
+// (against a Postgres fixture) and the in-memory evaluator and checks they select the
+// same notifications. Action hints rely on the evaluator, so any disagreement shows up
+// as a hint that doesn't match what the list returns.
+func TestDifferential_SQLMatchesEvaluator(t *testing.T) {
+	databaseURL := os.Getenv(differentialDatabaseEnv)
+	if databaseURL == "" {
+		t.Skipf("%s not set, skipping differential tests", differentialDatabaseEnv)
+	}
+
+	ctx := context.Background()
+	conn := openDifferentialSchema(ctx, t, databaseURL)
+	rng := rand.New(rand.NewSource(1))
+	seedDifferentialFixture(ctx, t, conn, rng)
+
+	queries := db.New(conn)
+	notifications, repos, tags, accounts := loadDifferentialFixture(ctx, t, queries)
+
+	gen := &queryGenerator{rng: rng}
+	for i := 0; i < differentialQueryCount; i++ {
+		queryStr := gen.query()
+
+		dbQuery, err := BuildQuery(queryStr, differentialDefinitions, int32(len(notifications)+1), 0)
+		if err != nil {
+			t.Errorf("BuildQuery(%q) error = %v", queryStr, err)
+			continue
+		}
+		result, err := queries.ListNotificationsFromQuery(ctx, dbQuery)
+		if err != nil {
+			t.Fatalf("ListNotificationsFromQuery(%q) error = %v", queryStr, err)
+		}
+		var sqlIDs []int64
+		for _, notif := range result.Notifications {
+			sqlIDs = append(sqlIDs, notif.ID)
+		}
+
+		evaluator, err := NewEvaluator(queryStr, differentialDefinitions)
+		if err != nil {
+			t.Fatalf("NewEvaluator(%q) error = %v", queryStr, err)
+		}
+		evaluator.SetTags(tags)
+		evaluator.SetAccounts(accounts)
+		var evalIDs []int64
+		for i := range notifications {
+			repo := repos[notifications[i].RepositoryID]
+			if evaluator.Matches(&notifications[i], &repo) {
+				evalIDs = append(evalIDs, notifications[i].ID)
+			}
+		}
+
+		slices.Sort(sqlIDs)
+		if !slices.Equal(sqlIDs, evalIDs) {
+			t.Errorf(
+				"query %q: SQL matched %v, evaluator matched %v\nWHERE %s",
+				queryStr,
+				sqlIDs,
+				evalIDs,
+				strings.Join(dbQuery.Where, " AND "),
+			)
+		}
+	}
+}
+
+// TestDifferential_GeneratedQueriesAreValid checks the query generator only produces
+// queries that build, so the differential test never skips a query
+func TestDifferential_GeneratedQueriesAreValid(t *testing.T) {
+	gen := &queryGenerator{rng: rand.New(rand.NewSource(1))}
+	for i := 0; i < differentialQueryCount; i++ {
+		queryStr := gen.query()
+		if _, err := BuildQuery(queryStr, differentialDefinitions, 50, 0); err != nil {
+			t.Errorf("BuildQuery(%q) error = %v", queryStr, err)
+		}
+		if _, err := NewEvaluator(queryStr, differentialDefinitions); err != nil {
+			t.Errorf("NewEvaluator(%q) error = %v", queryStr, err)
+		}
+	}
+}
+
+// openDifferentialSchema migrates a fresh schema and returns a connection using it.
+// The schema is dropped when the test finishes.
+func openDifferentialSchema(ctx context.Context, t *testing.T, databaseURL string) *gosql.DB {
+	t.Helper()
+
+	conn, err := gosql.Open("postgres", databaseURL)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	// A single connection keeps the search_path set below for every query
+	conn.SetMaxOpenConns(1)
+	conn.SetMaxIdleConns(1)
+
+	schema := fmt.Sprintf("query_differential_%d", time.Now().UnixNano())
+	if _, err := conn.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = conn.ExecContext(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		_ = conn.Close()
+	})
+	if _, err := conn.ExecContext(ctx, "SET search_path TO "+schema+", public"); err != nil {
+		t.Fatalf("failed to set search_path: %v", err)
+	}
+
+	goose.SetLogger(goose.NopLogger())
+	if err := goose.SetDialect("postgres"); err != nil {
+		t.Fatalf("failed to set goose dialect: %v", err)
+	}
+	if err := goose.UpContext(ctx, conn, "../../migrations"); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	return conn
+}
+
+// Fixture values. They include NULLs, mixed case and LIKE wildcard characters
+// so the evaluator's NULL and ILIKE handling is exercised.
+var (
+	fixtureRepos = []string{
+		"cli/cli", "cli/go-gh", "octo-org/octo-repo", "octo-org/docs", "Acme/Widgets_v2",
+	}
+	fixtureTags    = []string{"urgent", "urgent-bug", "docs", "needs_review"}
+	fixtureAuthors = []string{"octocat", "dependabot[bot]", "Mona_Lisa", "cli-bot"}
+	fixtureReasons = []string{
+		"review_requested", "mention", "author", "subscribed", "ci_activity", "team_mention",
+	}
+	fixtureTypes        = []string{"PullRequest", "Issue", "Release", "Discussion", "CheckSuite"}
+	fixtureStates       = []string{"open", "closed", "merged", "Open"}
+	fixtureStateReasons = []string{"completed", "not_planned", "reopened"}
+	fixtureTitles       = []string{
+		"Fix bug in parser", "100% coverage", "Update docs", "fix_flaky_test", "Release v2.0",
+		"Bump deps from 1.2 to 1.3", "Octocat says hi",
+	}
+	fixtureBodies = []string{
+		"Steps to reproduce the crash", "Octocat approved these changes", "Closes #42",
+	}
+	fixtureReviewers   = []string{"octocat", "@me", "core-team", "mona_lisa"}
+	fixtureLabels      = []string{"bug", "good first issue", "wontfix", "needs-triage"}
+	fixtureMilestones  = []string{"v2.0", "v2.1", "Backlog", "Q3 planning"}
+	fixtureAssignees   = []string{"octocat", "@me", "hubot"}
+	fixtureCategories  = []string{"Q&A", "Ideas", "General", "Show and tell"}
+	fixtureReleaseTags = []string{"v2.0.0", "v2.1.0-rc.1", "nightly"}
+	fixtureSHAs        = []string{"0ff1ce5", "c0ffee0d15ea5e", "deadbeef"}
+	fixtureAccounts    = []string{"work", "Acme_GHE"} // Alongside the default account
+)
+
+func seedDifferentialFixture(ctx context.Context, t *testing.T, conn *gosql.DB, rng *rand.Rand) {
+	t.Helper()
+
+	var repoIDs []int64
+	for _, fullName := range fixtureRepos {
+		var id int64
+		name := fullName[strings.Index(fullName, "/")+1:]
+		err := conn.QueryRowContext(
+			ctx,
+			"INSERT INTO repositories (name, full_name) VALUES ($1, $2) RETURNING id",
+			name,
+			fullName,
+		).Scan(&id)
+		if err != nil {
+			t.Fatalf("failed to insert repository: %v", err)
+		}
+		repoIDs = append(repoIDs, id)
+	}
+
+	var tagIDs []int64
+	for _, slug := range fixtureTags {
+		var id int64
+		err := conn.QueryRowContext(
+			ctx,
+			"INSERT INTO tags (name, slug) VALUES ($1, $1) RETURNING id",
+			slug,
+		).Scan(&id)
+		if err != nil {
+			t.Fatalf("failed to insert tag: %v", err)
+		}
+		tagIDs = append(tagIDs, id)
+	}
+
+	accountIDs := []int64{1}
+	for _, name := range fixtureAccounts {
+		var id int64
+		err := conn.QueryRowContext(
+			ctx,
+			"INSERT INTO github_accounts (name, api_url, web_url) VALUES ($1, '', '') RETURNING id",
+			name,
+		).Scan(&id)
+		if err != nil {
+			t.Fatalf("failed to insert account: %v", err)
+		}
+		accountIDs = append(accountIDs, id)
+	}
+
+	now := time.Now()
+	nullString := func(values []string) gosql.NullString {
+		if rng.Intn(5) == 0 {
+			return gosql.NullString{}
+		}
+		return gosql.NullString{String: values[rng.Intn(len(values))], Valid: true}
+	}
+	nullSubset := func(values []string) any {
+		if rng.Intn(3) == 0 {
+			return nil
+		}
+		subset := []string{}
+		for _, value := range values {
+			if rng.Intn(3) == 0 {
+				subset = append(subset, value)
+			}
+		}
+		return pq.Array(subset)
+	}
+	// Times are spread over the last two years, away from the boundaries of relative values
+	pastTime := func() gosql.NullTime {
+		if rng.Intn(5) == 0 {
+			return gosql.NullTime{}
+		}
+		offset := time.Duration(rng.Int63n(int64(2 * 365 * 24 * time.Hour)))
+		return gosql.NullTime{Time: now.Add(-offset), Valid: true}
+	}
+
+	for i := 0; i < 200; i++ {
+		var snoozedUntil gosql.NullTime
+		switch rng.Intn(3) {
+		case 0:
+			snoozedUntil = gosql.NullTime{Time: now.AddDate(0, 0, -10), Valid: true}
+		case 1:
+			snoozedUntil = gosql.NullTime{Time: now.AddDate(0, 0, 10), Valid: true}
+		}
+
+		var number gosql.NullInt32
+		if rng.Intn(4) != 0 {
+			number = gosql.NullInt32{Int32: rng.Int31n(2000) + 1, Valid: true}
+		}
+
+		var comments gosql.NullInt32
+		if rng.Intn(4) != 0 {
+			comments = gosql.NullInt32{Int32: rng.Int31n(30), Valid: true}
+		}
+
+		var merged gosql.NullBool
+		if rng.Intn(3) != 0 {
+			merged = gosql.NullBool{Bool: rng.Intn(2) == 0, Valid: true}
+		}
+
+		var notifTags []int64
+		for _, id := range tagIDs {
+			if rng.Intn(4) == 0 {
+				notifTags = append(notifTags, id)
+			}
+		}
+
+		importedAt := pastTime()
+		if !importedAt.Valid {
+			importedAt = gosql.NullTime{Time: now.AddDate(0, 0, -1), Valid: true}
+		}
+
+		// The search vector reads the repository name from the thread payload
+		repoIndex := rng.Intn(len(repoIDs))
+		payload, err := json.Marshal(map[string]any{
+			"repository": map[string]string{"full_name": fixtureRepos[repoIndex]},
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal payload: %v", err)
+		}
+
+		var draft gosql.NullBool
+		if rng.Intn(3) != 0 {
+			draft = gosql.NullBool{Bool: rng.Intn(2) == 0, Valid: true}
+		}
+		var answered gosql.NullBool
+		if rng.Intn(3) == 0 {
+			answered = gosql.NullBool{Bool: rng.Intn(2) == 0, Valid: true}
+		}
+		var staleAt gosql.NullTime
+		if rng.Intn(5) == 0 {
+			staleAt = gosql.NullTime{Time: now.AddDate(0, 0, -rng.Intn(30)), Valid: true}
+		}
+
+		// Array columns are NULL for subjects without them, otherwise a (possibly empty) subset
+		reviewers := nullSubset(fixtureReviewers)
+		labels := nullSubset(fixtureLabels)
+		assignees := nullSubset(fixtureAssignees)
+
+		var subjectRaw []byte
+		if body := nullString(fixtureBodies); body.Valid {
+			subjectRaw, err = json.Marshal(map[string]string{"body": body.String})
+			if err != nil {
+				t.Fatalf("failed to marshal subject: %v", err)
+			}
+		}
+
+		_, err = conn.ExecContext(
+			ctx,
+			`INSERT INTO notifications (
+				github_id, repository_id, subject_type, subject_title, reason, archived,
+				github_updated_at, imported_at, author_login, is_read, muted, snoozed_until,
+				starred, filtered, tag_ids, subject_number, subject_state, subject_merged,
+				subject_state_reason, subject_created_at, payload, subject_raw, subject_draft,
+				subject_review_decision, subject_review_requested, subject_checks_status,
+				subject_labels, subject_milestone, subject_assignees, subject_comments, account_id,
+				subject_category, subject_answered, subject_tag, subject_conclusion, subject_sha,
+				subject_severity, stale_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
+				$17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32,
+				$33, $34, $35, $36, $37, $38)`,
+			fmt.Sprintf("thread-%d", i),
+			repoIDs[repoIndex],
+			fixtureTypes[rng.Intn(len(fixtureTypes))],
+			fixtureTitles[rng.Intn(len(fixtureTitles))],
+			nullString(fixtureReasons),
+			rng.Intn(4) == 0,
+			pastTime(),
+			importedAt.Time,
+			nullString(fixtureAuthors),
+			rng.Intn(2) == 0,
+			rng.Intn(5) == 0,
+			snoozedUntil,
+			rng.Intn(4) == 0,
+			rng.Intn(5) == 0,
+			pq.Array(notifTags),
+			number,
+			nullString(fixtureStates),
+			merged,
+			nullString(fixtureStateReasons),
+			pastTime(),
+			payload,
+			pqtype.NullRawMessage{RawMessage: subjectRaw, Valid: subjectRaw != nil},
+			draft,
+			nullString(parse.ReviewValues),
+			reviewers,
+			nullString(parse.ChecksValues),
+			labels,
+			nullString(fixtureMilestones),
+			assignees,
+			comments,
+			accountIDs[rng.Intn(len(accountIDs))],
+			nullString(fixtureCategories),
+			answered,
+			nullString(fixtureReleaseTags),
+			nullString(parse.ConclusionValues),
+			nullString(fixtureSHAs),
+			nullString(parse.SeverityValues),
+			staleAt,
+		)
+		if err != nil {
+			t.Fatalf("failed to insert notification: %v", err)
+		}
+	}
+}
+
+func loadDifferentialFixture(
+	ctx context.Context,
+	t *testing.T,
+	queries *db.Queries,
+) ([]db.Notification, map[int64]db.Repository, []db.Tag, []db.GithubAccount) {
+	t.Helper()
+
+	all, err := BuildQuery("in:anywhere", Definitions{}, 10000, 0)
+	if err != nil {
+		t.Fatalf("BuildQuery() error = %v", err)
+	}
+	result, err := queries.ListNotificationsFromQuery(ctx, all)
+	if err != nil {
+		t.Fatalf("failed to list notifications: %v", err)
+	}
+	notifications := result.Notifications
+	slices.SortFunc(notifications, func(a, b db.Notification) int {
+		return int(a.ID - b.ID)
+	})
+
+	repoList, err := queries.ListRepositories(ctx)
+	if err != nil {
+		t.Fatalf("failed to list repositories: %v", err)
+	}
+	repos := make(map[int64]db.Repository, len(repoList))
+	for _, repo := range repoList {
+		repos[repo.ID] = repo
+	}
+
+	tags, err := queries.ListAllTags(ctx)
+	if err != nil {
+		t.Fatalf("failed to list tags: %v", err)
+	}
+
+	accounts, err := queries.ListGitHubAccounts(ctx)
+	if err != nil {
+		t.Fatalf("failed to list accounts: %v", err)
+	}
+
+	return notifications, repos, tags, accounts
+}
+
+// queryGenerator builds random valid queries from the field registry
+type queryGenerator struct {
+	rng *rand.Rand
+}
+
+// differentialValues are candidate values for string columns. Some only partially match
+// fixture values, differ in case or contain LIKE wildcards.
+var differentialValues = map[string][]string{
+	parse.ColumnRepoFullName:       {"cli", "go-gh", "octo", "DOCS", "widgets_", `"/"`, "c_i"},
+	parse.ColumnReason:             {"review", "mention", "requested", "ci_", "team", "Author"},
+	parse.ColumnSubjectType:        {"pull", "Issue", "release", "check", "disc"},
+	parse.ColumnAuthorLogin:        {"octo", "bot", "[bot]", "lisa", "Mona_", "cli"},
+	parse.ColumnSubjectState:       {"open", "closed", "merged", "Open", "clos"},
+	parse.ColumnSubjectStateReason: {"complet", "not_", "reopened", "Not_Planned"},
+	parse.ColumnTagIDs:             {"urg", "urgent-bug", "docs", "_", "review", "missing"},
+	parse.ColumnReviewRequested:    {"octocat", "@me", "Core-Team", "mona", "nobody"},
+	parse.ColumnSubjectLabels:      {"bug", `"Good First Issue"`, "wontfix", "needs", "missing"},
+	parse.ColumnSubjectMilestone:   {"v2", "v2.0", "backlog", "Q3", "planning"},
+	parse.ColumnSubjectCategory:    {"q", "Ideas", "general", `"show and"`},
+	parse.ColumnSubjectTag:         {"v2", "v2.1.0-rc.1", "NIGHTLY", "rc"},
+	parse.ColumnSubjectSha:         {"0ff1ce5", "c0ffee", "DEADBEEF", "e5"},
+	parse.ColumnSubjectAssignees:   {"octocat", "@me", "HUBOT", "octo", "nobody"},
+	parse.ColumnAccountID:          {"default", "work", "WORK", "acme_ghe", "acme", "missing"},
+}
+
+// differentialNumbers are candidate values for integer columns, around the fixture ranges
+var differentialNumbers = map[string][]string{
+	parse.ColumnSubjectNumber:   {"1", "42", "1000", "1999", "2001"},
+	parse.ColumnSubjectComments: {"0", "1", "10", "29", "30"},
+}
+
+var (
+	differentialOrgs       = []string{"cli", "octo-org", "acme", "octo", "CLI"}
+	differentialBooleans   = []string{"true", "false", "yes", "no", "1", "0"}
+	differentialMerged     = []string{"true", "false", "merged", "unmerged"}
+	differentialTimes      = []string{"today", "yesterday", "7d", "2w", "3mo", "1y", "30d", "12h"}
+	differentialComparison = []string{">", ">=", "<", "<="}
+	// Search words and phrases are chosen to be unchanged by stemming and not stop words,
+	// where the evaluator only approximates Postgres full-text search
+	differentialFreeText = []string{
+		"fix", "docs", "cli", "octocat", "100", "open", "v2", "1", "reproduce", "crash", "42",
+		`"fix bug"`, `"octocat says"`, `"reproduce the crash"`, `"bug fix"`,
+	}
+	differentialWildcards = []string{`"o%t"`, `"_li"`, `"%bot%"`, `"\\_"`, `"e_r"`}
+	// Globs and regexes, using only regex syntax that Go and Postgres agree on
+	differentialPatterns = []string{
+		"cli/*", "*[bot]", "octo?org/*", "*_*", "/^cli/", `/\[bot\]$/`, "/DOCS|widgets/",
+		"/^o.*t$/",
+	}
+	differentialOrgPatterns = []string{"cli*", "?cme", "/^octo/", "/-org$/", "*"}
+	differentialSortKeys    = []string{"updated", "created-asc", "repo", "number", "title-desc"}
+	differentialMacros      = []string{"@bots", "@Mine", "@team"}
+)
+
+// differentialDefinitions are the views and macros generated queries can reference.
+// They nest, negate and sort, so expansion is compared along with the fields it inlines.
+var differentialDefinitions = Definitions{
+	Views: map[string]string{
+		"inbox":    "in:inbox",
+		"starred":  "is:starred",
+		"cli":      "repo:cli/cli -is:read",
+		"triage":   "(view:cli OR no:label) AND @bots sort:updated",
+		"not-bots": "NOT @bots",
+	},
+	Macros: map[string]string{
+		"bots": "author:*[bot] OR author:/^dependabot/",
+		"mine": "assignee:@me OR review-requested:@me",
+		"team": "@mine view:starred",
+	},
+}
+
+func (g *queryGenerator) pick(values []string) string {
+	return values[g.rng.Intn(len(values))]
+}
+
+// query returns a random query, occasionally empty or with a trailing sort: term
+func (g *queryGenerator) query() string {
+	if g.rng.Intn(20) == 0 {
+		return ""
+	}
+	q := g.expr(3)
+	if g.rng.Intn(5) == 0 {
+		// Parenthesize so the sort: term is always in the top-level AND chain
+		q = "(" + q + ") sort:" + g.pick(differentialSortKeys)
+	}
+	return q
+}
+
+func (g *queryGenerator) expr(depth int) string {
+	if depth == 0 || g.rng.Intn(3) == 0 {
+		return g.term()
+	}
+	switch g.rng.Intn(5) {
+	case 0:
+		return g.expr(depth-1) + " " + g.expr(depth-1)
+	case 1:
+		return g.expr(depth-1) + " AND " + g.expr(depth-1)
+	case 2:
+		return g.expr(depth-1) + " OR " + g.expr(depth-1)
+	case 3:
+		return "NOT (" + g.expr(depth-1) + ")"
+	default:
+		return "(" + g.expr(depth-1) + ")"
+	}
+}
+
+func (g *queryGenerator) term() string {
+	if g.rng.Intn(8) == 0 {
+		return g.pick(differentialFreeText)
+	}
+	if g.rng.Intn(16) == 0 {
+		return g.pick(differentialMacros)
+	}
+
+	names := parse.FieldNames()
+	name := g.pick(names)
+	spec, _ := parse.LookupField(name)
+	if spec.Kind == parse.FieldSort {
+		// sort: is only valid at the top level, see query()
+		name, spec = "is", parse.FieldSpec{Name: "is", Kind: parse.FieldIs}
+	}
+
+	if spec.Kind == parse.FieldTime && g.rng.Intn(2) == 0 {
+		if g.rng.Intn(4) == 0 {
+			return name + ":" + g.pick(differentialTimes) + ".." + g.pick(differentialTimes)
+		}
+		return name + ":" + g.pick(differentialComparison) + g.pick(differentialTimes)
+	}
+
+	if spec.Kind == parse.FieldNumber && g.rng.Intn(2) == 0 {
+		numbers := differentialNumbers[spec.Column]
+		if g.rng.Intn(4) == 0 {
+			return name + ":" + g.pick(numbers) + ".." + g.pick(numbers)
+		}
+		return name + ":" + g.pick(differentialComparison) + g.pick(numbers)
+	}
+
+	values := []string{g.value(spec)}
+	if g.rng.Intn(4) == 0 {
+		values = append(values, g.value(spec))
+	}
+
+	prefix := ""
+	if g.rng.Intn(4) == 0 {
+		prefix = "-"
+	}
+	return prefix + name + ":" + strings.Join(values, ",")
+}
+
+func (g *queryGenerator) value(spec parse.FieldSpec) string {
+	switch spec.Kind {
+	case parse.FieldIn:
+		return g.pick(parse.InValues)
+	case parse.FieldIs:
+		return g.pick(parse.IsValues)
+	case parse.FieldPrefix:
+		if g.rng.Intn(4) == 0 {
+			return g.pick(differentialOrgPatterns)
+		}
+		return g.pick(differentialOrgs)
+	case parse.FieldBoolean, parse.FieldSnoozed:
+		return g.pick(differentialBooleans)
+	case parse.FieldMerged:
+		return g.pick(differentialMerged)
+	case parse.FieldTime:
+		return g.pick(differentialTimes)
+	case parse.FieldEnum:
+		return g.pick(append(slices.Clone(spec.Values), strings.ToUpper(spec.Values[0])))
+	case parse.FieldNo, parse.FieldHas:
+		return g.pick(spec.Values)
+	case parse.FieldNumber:
+		return g.pick(differentialNumbers[spec.Column])
+	case parse.FieldArray:
+		return g.pick(differentialValues[spec.Column])
+	case parse.FieldView:
+		return g.pick([]string{"inbox", "Starred", "cli", "triage", "not-bots"})
+	case parse.FieldAccount:
+		return g.pick(differentialValues[spec.Column])
+	default:
+		if g.rng.Intn(6) == 0 {
+			return g.pick(differentialWildcards)
+		}
+		if parse.SupportsPatterns(spec.Kind) && g.rng.Intn(5) == 0 {
+			return g.pick(differentialPatterns)
+		}
+		return g.pick(differentialValues[spec.Column])
+	}
+}
@@ -17,7 +17,10 @@
 package eval
 
 import (
+	"database/sql"
 	"encoding/json"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -26,15 +29,81 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/query/parse"
 )
 
-// Evaluator evaluates whether a notification matches a query AST
+// Evaluator evaluates whether a notification matches a query AST.
+// It mirrors the SQL builder field for field (both dispatch on parse.FieldKind),
+// including SQL's NULL handling, so action hints agree with what the list returns.
 type Evaluator struct {
-	ast parse.Node
+	ast      parse.Node
+	now      func() time.Time          // Resolves relative time values (7d, today)
+	tagSlugs map[int64]string          // Tag slugs by ID, needed to evaluate tags:
+	accounts map[int64]string          // GitHub account names by ID, needed to evaluate account:
+	regexps  map[string]*regexp.Regexp // Compiled /.../ values by body (invalid ones are left out)
 }
 
 // NewEvaluator creates a new evaluator for the given AST node
 // This is a pure translation - no default filters are applied.
 func NewEvaluator(ast parse.Node) *Evaluator {
-	return &Evaluator{ast: ast}
+	e := &Evaluator{ast: ast, now: time.Now, regexps: map[string]*regexp.Regexp{}}
+	e.compileRegexps(ast)
+	return e
+}
+
+// compileRegexps compiles every /.../ value up front, so Matches only reads e.regexps
+func (e *Evaluator) compileRegexps(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.Term:
+		spec, ok := parse.LookupField(n.Field)
+		if !ok || !parse.SupportsPatterns(spec.Kind) {
+			return
+		}
+		for _, value := range n.Values {
+			if kind, body := parse.ClassifyPattern(value); kind == parse.PatternRegex {
+				if re, err := parse.CompileRegex(body); err == nil {
+					e.regexps[body] = re
+				}
+			}
+		}
+	case *parse.BinaryExpr:
+		e.compileRegexps(n.Left)
+		e.compileRegexps(n.Right)
+	case *parse.NotExpr:
+		e.compileRegexps(n.Expr)
+	case *parse.ParenExpr:
+		e.compileRegexps(n.Expr)
+	}
+}
+
+// NeedsTags reports whether the query uses tags: and needs SetTags to evaluate correctly
+func (e *Evaluator) NeedsTags() bool {
+	return usesKind(e.ast, parse.FieldTags)
+}
+
+// NeedsSubject reports whether the query searches free text, which includes the subject body
+func (e *Evaluator) NeedsSubject() bool {
+	return usesFreeText(e.ast)
+}
+
+// SetTags provides the tags used to resolve tags: values against notification tag IDs
+func (e *Evaluator) SetTags(tags []db.Tag) {
+	e.tagSlugs = make(map[int64]string, len(tags))
+	for _, tag := range tags {
+		e.tagSlugs[tag.ID] = tag.Slug
+	}
+}
+
+// NeedsAccounts reports whether the query uses account: and needs SetAccounts to evaluate
+// correctly
+func (e *Evaluator) NeedsAccounts() bool {
+	return usesKind(e.ast, parse.FieldAccount)
+}
+
+// SetAccounts provides the GitHub accounts used to resolve account: values against
+// notification account IDs
+func (e *Evaluator) SetAccounts(accounts []db.GithubAccount) {
+	e.accounts = make(map[int64]string, len(accounts))
+	for _, account := range accounts {
+		e.accounts[account.ID] = account.Name
+	}
 }
 
 // Matches returns true if the notification matches the query with explicit query context:
@@ -50,7 +119,7 @@ func (e *Evaluator) Matches(notif *db.Notification, repo *db.Repository) bool {
 	// 2. Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
 	// This includes in:inbox, in:archive, in:snoozed, in:filtered, in:anywhere, etc.
 	if parse.HasInOperator(e.ast) {
-		return e.evaluateNode(notif, repo, e.ast)
+		return e.evaluateNode(notif, repo, e.ast) == truthTrue
 	}
 
 	// 3. Query without in: operator (non-empty) → Apply muted-only default
@@ -60,7 +129,7 @@ func (e *Evaluator) Matches(notif *db.Notification, repo *db.Repository) bool {
 	}
 
 	// Evaluate the query AST
-	return e.evaluateNode(notif, repo, e.ast)
+	return e.evaluateNode(notif, repo, e.ast) == truthTrue
 }
 
 // matchesInboxDefaults checks if notification passes inbox default filters
@@ -69,7 +138,7 @@ func (e *Evaluator) matchesInboxDefaults(notif *db.Notification) bool {
 	if notif.Archived {
 		return false
 	}
-	if notif.SnoozedUntil.Valid && notif.SnoozedUntil.Time.After(time.Now()) {
+	if isSnoozed(notif, e.now()) {
 		return false
 	}
 	if notif.Muted {
@@ -85,22 +154,22 @@ func (e *Evaluator) evaluateNode(
 	notif *db.Notification,
 	repo *db.Repository,
 	node parse.Node,
-) bool {
+) truth {
 	switch n := node.(type) {
 	case *parse.BinaryExpr:
 		left := e.evaluateNode(notif, repo, n.Left)
 		right := e.evaluateNode(notif, repo, n.Right)
 		switch n.Op {
 		case "AND":
-			return left && right
+			return left.and(right)
 		case "OR":
-			return left || right
+			return left.or(right)
 		default:
-			return false
+			return truthUnknown
 		}
 
 	case *parse.NotExpr:
-		return !e.evaluateNode(notif, repo, n.Expr)
+		return e.evaluateNode(notif, repo, n.Expr).not()
 
 	case *parse.ParenExpr:
 		return e.evaluateNode(notif, repo, n.Expr)
@@ -108,15 +177,28 @@ func (e *Evaluator) evaluateNode(
 	case *parse.Term:
 		result := e.evaluateTerm(notif, repo, n)
 		if n.Negated {
-			return !result
+			return result.not()
 		}
 		return result
 
+	case *parse.Comparison:
+		spec, ok := parse.LookupField(n.Field)
+		switch {
+		case !ok:
+			return truthUnknown
+		case spec.Kind == parse.FieldTime:
+			return e.evaluateTime(notif, spec.Column, n.Op, n.Value, n.Upper)
+		case spec.Kind == parse.FieldNumber:
+			return evaluateNumber(notif, spec.Column, n.Op, n.Value, n.Upper)
+		default:
+			return truthUnknown
+		}
+
 	case *parse.FreeText:
-		return e.evaluateFreeText(notif, repo, n.Text)
+		return truthOf(e.evaluateFreeText(notif, repo, n))
 
 	default:
-		return false
+		return truthUnknown
 	}
 }
 
@@ -124,193 +206,474 @@ func (e *Evaluator) evaluateTerm(
 	notif *db.Notification,
 	repo *db.Repository,
 	term *parse.Term,
-) bool {
-	// Check if ANY value matches (OR logic within a term)
+) truth {
+	spec, ok := parse.LookupField(term.Field)
+	if !ok {
+		// The SQL builder rejects unknown fields, so nothing matches
+		return truthUnknown
+	}
+
+	// Values are ORed together, like the SQL builder's (a OR b) conditions
+	result := truthFalse
 	for _, value := range term.Values {
-		if e.evaluateFieldValue(notif, repo, term.Field, value) {
-			return true
-		}
+		result = result.or(e.evaluateFieldValue(notif, repo, spec, value))
 	}
-	return false
+	return result
 }
 
 func (e *Evaluator) evaluateFieldValue(
 	notif *db.Notification,
 	repo *db.Repository,
-	field, value string,
-) bool {
-	switch field {
-	case "is":
-		return e.evaluateIsCondition(notif, value)
-	case "in":
+	spec parse.FieldSpec,
+	value string,
+) truth {
+	switch spec.Kind {
+	case parse.FieldIn:
 		return e.evaluateInCondition(notif, value)
-	case "repo":
-		if repo != nil {
-			return strings.Contains(strings.ToLower(repo.FullName), strings.ToLower(value))
+	case parse.FieldIs:
+		return e.evaluateIsCondition(notif, value)
+	case parse.FieldContains:
+		column := stringColumn(notif, repo, spec.Column)
+		if result, ok := e.matchPattern(column, value); ok {
+			return result
 		}
-		return false
-	case "author":
-		if notif.AuthorLogin.Valid {
-			return notif.AuthorLogin.String == value
+		return matchString(column, func(s string) bool {
+			return ilike(s, "%"+value+"%")
+		})
+	case parse.FieldPrefix:
+		column := stringColumn(notif, repo, spec.Column)
+		if result, ok := e.matchPattern(ownerOf(column), value); ok {
+			return result
 		}
-		return false
-	case "reason":
-		if notif.Reason.Valid {
-			return notif.Reason.String == value
+		return matchString(column, func(s string) bool {
+			return ilike(s, value+"/%")
+		})
+	case parse.FieldEquals:
+		return matchString(stringColumn(notif, repo, spec.Column), func(s string) bool {
+			return s == value
+		})
+	case parse.FieldBoolean:
+		want, ok := parse.ParseBool(value)
+		if !ok {
+			return truthUnknown
 		}
-		return false
-	case "type":
-		return strings.EqualFold(notif.SubjectType, value)
-	// Add other fields as needed (participant, label, etc.)
+		return truthOf(boolColumn(notif, spec.Column) == want)
+	case parse.FieldMerged:
+		want, ok := parse.ParseMerged(value)
+		if !ok || !notif.SubjectMerged.Valid {
+			return truthUnknown
+		}
+		return truthOf(notif.SubjectMerged.Bool == want)
+	case parse.FieldSnoozed:
+		want, ok := parse.ParseBool(value)
+		if !ok {
+			return truthUnknown
+		}
+		return truthOf(isSnoozed(notif, e.now()) == want)
+	case parse.FieldTime:
+		// Plain time values: updated:today matches that day, updated:7d matches the last 7 days
+		return e.evaluateTime(notif, spec.Column, "", value, "")
+	case parse.FieldTags:
+		return e.evaluateTags(notif, value)
+	case parse.FieldAccount:
+		name, ok := e.accounts[notif.AccountID]
+		return truthOf(ok && strings.ToLower(name) == strings.ToLower(value))
+	case parse.FieldEnum:
+		return matchString(stringColumn(notif, repo, spec.Column), func(s string) bool {
+			return s == strings.ToLower(strings.TrimSpace(value))
+		})
+	case parse.FieldArray:
+		return evaluateArray(arrayColumn(notif, spec.Column), value)
+	case parse.FieldNo:
+		return evaluateNo(notif, repo, value)
+	case parse.FieldHas:
+		return evaluateNo(notif, repo, value).not()
+	case parse.FieldNumber:
+		// Plain numbers match exactly
+		return evaluateNumber(notif, spec.Column, "", value, "")
+	case parse.FieldSort:
+		return truthTrue // Ordering only, never filters
+	case parse.FieldView:
+		return truthUnknown // The SQL builder rejects view: terms that weren't expanded
 	default:
-		return true // Unknown fields don't filter
+		return truthUnknown
+	}
+}
+
+// evaluateTime matches a time column against a comparison, mirroring the SQL builder:
+// notifications without the timestamp never match.
+func (e *Evaluator) evaluateTime(notif *db.Notification, column, op, value, upper string) truth {
+	timestamp := timeColumn(notif, column)
+	if !timestamp.Valid {
+		return truthFalse
+	}
+
+	bounds, err := parse.ResolveTimeBounds(op, value, upper, e.now())
+	if err != nil {
+		return truthUnknown
+	}
+	return truthOf(bounds.Contains(timestamp.Time))
+}
+
+// evaluateNumber matches a number column against a comparison, mirroring the SQL builder:
+// notifications without the number never match.
+func evaluateNumber(notif *db.Notification, column, op, value, upper string) truth {
+	number := numberColumn(notif, column)
+	if !number.Valid {
+		return truthFalse
+	}
+
+	bounds, err := parse.ResolveNumberBounds(op, value, upper)
+	if err != nil {
+		return truthUnknown
+	}
+	return truthOf(bounds.Contains(int64(number.Int32)))
+}
+
+// evaluateTags matches notifications with any tag whose slug contains the value
+func (e *Evaluator) evaluateTags(notif *db.Notification, value string) truth {
+	for _, tagID := range notif.TagIds {
+		if slug, ok := e.tagSlugs[tagID]; ok && ilike(slug, "%"+value+"%") {
+			return truthTrue
+		}
 	}
+	return truthFalse
 }
 
-func (e *Evaluator) evaluateIsCondition(notif *db.Notification, value string) bool {
-	switch value {
+func (e *Evaluator) evaluateIsCondition(notif *db.Notification, value string) truth {
+	switch strings.ToLower(strings.TrimSpace(value)) {
 	case "read":
-		return notif.IsRead
+		return truthOf(notif.IsRead)
 	case "unread":
-		return !notif.IsRead
+		return truthOf(!notif.IsRead)
 	case "archived":
-		return notif.Archived
-	case "inbox":
-		return !notif.Archived
+		return truthOf(notif.Archived)
 	case "muted":
-		return notif.Muted
-	case "unmuted":
-		return !notif.Muted
+		return truthOf(notif.Muted)
 	case "starred":
-		return notif.Starred
-	case "unstarred":
-		return !notif.Starred
+		return truthOf(notif.Starred)
 	case "snoozed":
-		return notif.SnoozedUntil.Valid && notif.SnoozedUntil.Time.After(time.Now())
-	case "unsnoozed", "active":
-		return !notif.SnoozedUntil.Valid || notif.SnoozedUntil.Time.Before(time.Now())
+		return truthOf(isSnoozed(notif, e.now()))
 	case "filtered":
-		return notif.Filtered
+		return truthOf(notif.Filtered)
+	case "draft":
+		// Mirrors n.subject_draft IS TRUE, which is never NULL
+		return truthOf(notif.SubjectDraft.Valid && notif.SubjectDraft.Bool)
+	case "answered":
+		// Mirrors n.subject_answered IS TRUE, which is never NULL
+		return truthOf(notif.SubjectAnswered.Valid && notif.SubjectAnswered.Bool)
+	case "stale":
+		return truthOf(notif.StaleAt.Valid)
 	default:
-		return true
+		// The SQL builder rejects unknown values, so nothing matches
+		return truthUnknown
 	}
 }
 
-func (e *Evaluator) evaluateInCondition(notif *db.Notification, value string) bool {
-	switch value {
+func (e *Evaluator) evaluateInCondition(notif *db.Notification, value string) truth {
+	snoozed := isSnoozed(notif, e.now())
+	switch strings.ToLower(strings.TrimSpace(value)) {
 	case "inbox":
 		// in:inbox - exclude archived, snoozed, muted, filtered
-		return !notif.Archived &&
-			(!notif.SnoozedUntil.Valid || notif.SnoozedUntil.Time.Before(time.Now())) &&
-			!notif.Muted &&
-			!notif.Filtered
+		return truthOf(!notif.Archived && !snoozed && !notif.Muted && !notif.Filtered)
 	case "archive":
 		// in:archive - show only archived (exclude muted)
-		return notif.Archived && !notif.Muted
+		return truthOf(notif.Archived && !notif.Muted)
 	case "snoozed":
 		// in:snoozed - show only snoozed (exclude archived, muted)
-		return notif.SnoozedUntil.Valid &&
-			notif.SnoozedUntil.Time.After(time.Now()) &&
-			!notif.Archived &&
-			!notif.Muted
+		return truthOf(snoozed && !notif.Archived && !notif.Muted)
 	case "filtered":
 		// in:filtered - exclude snoozed, archived, muted
-		return notif.Filtered &&
-			!notif.Archived &&
-			(!notif.SnoozedUntil.Valid || notif.SnoozedUntil.Time.Before(time.Now())) &&
-			!notif.Muted
+		return truthOf(notif.Filtered && !notif.Archived && !snoozed && !notif.Muted)
 	case "anywhere":
 		// in:anywhere - show all (no filters)
-		return true
+		return truthTrue
 	default:
-		return true
+		// The SQL builder rejects unknown values, so nothing matches
+		return truthUnknown
 	}
 }
 
-// evaluateFreeText matches free text against multiple fields
-// This matches the SQL builder's free text search logic:
-// - Subject title
-// - Subject type
-// - Repository full name
-// - Author login
-// - subject_state (extracted column, preferred over subject_raw JSON parsing)
-// - subject_number (cast to text for search)
-//
-
+// evaluateFreeText approximates the SQL builder's full-text search over the search_vector
+// columns: title, repository, author, type, state, number and body. Words match as prefixes
+// of indexed words and quoted text as a phrase. Postgres also stems words and drops stop
+// words, so inflected forms (fixes vs fix) can differ.
 func (e *Evaluator) evaluateFreeText(
 	notif *db.Notification,
 	repo *db.Repository,
-	text string,
+	node *parse.FreeText,
 ) bool {
-	lowerText := strings.ToLower(text)
-
-	// Check subject title
-	if strings.Contains(strings.ToLower(notif.SubjectTitle), lowerText) {
-		return true
+	var fields [][]string
+	for _, text := range searchableText(notif, repo) {
+		fields = append(fields, parse.SearchWords(text))
 	}
 
-	// Check subject type
-	if strings.Contains(strings.ToLower(notif.SubjectType), lowerText) {
-		return true
+	if node.Quoted {
+		phrase := parse.SearchWords(node.Text)
+		for _, words := range fields {
+			if containsPhrase(words, phrase) {
+				return true
+			}
+		}
+		return false
 	}
 
-	// Check repository full name
-	if repo != nil && strings.Contains(strings.ToLower(repo.FullName), lowerText) {
-		return true
+	words := parse.SearchWords(node.Text)
+	if len(words) == 0 {
+		return false
 	}
-
-	// Check author login
-	if notif.AuthorLogin.Valid &&
-		strings.Contains(strings.ToLower(notif.AuthorLogin.String), lowerText) {
-		return true
+	for _, word := range words {
+		if !hasWordWithPrefix(fields, word) {
+			return false
+		}
 	}
+	return true
+}
 
-	// Check subject_state column (extracted column, preferred over JSON parsing)
-	// Note: We use the extracted column instead of subject_raw->>'state' for performance
-	// since the JSON path index has been removed in favor of the column index
-	if notif.SubjectState.Valid &&
-		strings.Contains(strings.ToLower(notif.SubjectState.String), lowerText) {
-		return true
+// searchableText returns the text indexed by the search_vector column
+func searchableText(notif *db.Notification, repo *db.Repository) []string {
+	text := []string{notif.SubjectTitle, notif.SubjectType}
+	if repo != nil {
+		text = append(text, repo.FullName)
+	}
+	if notif.AuthorLogin.Valid {
+		text = append(text, notif.AuthorLogin.String)
+	}
+	if notif.SubjectState.Valid {
+		text = append(text, notif.SubjectState.String)
+	}
+	if notif.SubjectNumber.Valid {
+		text = append(text, strconv.Itoa(int(notif.SubjectNumber.Int32)))
 	}
+	if notif.SubjectRaw.Valid {
+		var subject struct {
+			Body string `json:"body"`
+		}
+		if err := json.Unmarshal(notif.SubjectRaw.RawMessage, &subject); err == nil {
+			text = append(text, subject.Body)
+		}
+	}
+	return text
+}
 
-	// Fallback to parsing from subject_raw only if subject_state is not available
-	// This is a legacy fallback for edge cases where the column might not be populated
-	if !notif.SubjectState.Valid && notif.SubjectRaw.Valid {
-		var subjectData map[string]interface{}
-		if err := json.Unmarshal(notif.SubjectRaw.RawMessage, &subjectData); err == nil {
-			if state, ok := subjectData["state"].(string); ok {
-				if strings.Contains(strings.ToLower(state), lowerText) {
-					return true
-				}
+// hasWordWithPrefix checks if any field has a word starting with prefix
+func hasWordWithPrefix(fields [][]string, prefix string) bool {
+	for _, words := range fields {
+		for _, word := range words {
+			if strings.HasPrefix(word, prefix) {
+				return true
 			}
 		}
 	}
+	return false
+}
 
-	// Check subject_number (cast to text for search)
-	if notif.SubjectNumber.Valid {
-		numberStr := strconv.Itoa(int(notif.SubjectNumber.Int32))
-		if strings.Contains(strings.ToLower(numberStr), lowerText) {
+// containsPhrase checks if words contains phrase as consecutive words
+func containsPhrase(words, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(phrase)], phrase) {
 			return true
 		}
 	}
+	return false
+}
 
-	// Check subject_state_reason column
-	if notif.SubjectStateReason.Valid &&
-		strings.Contains(strings.ToLower(notif.SubjectStateReason.String), lowerText) {
-		return true
+// matchString applies match to a nullable column; NULL columns are unknown
+func matchString(column sql.NullString, match func(string) bool) truth {
+	if !column.Valid {
+		return truthUnknown
 	}
+	return truthOf(match(column.String))
+}
 
-	// Check subject_merged (convert boolean to text for search)
-	if notif.SubjectMerged.Valid {
-		mergedStr := "false"
-		if notif.SubjectMerged.Bool {
-			mergedStr = "true"
+// stringColumn returns the value of a text column from the registry
+func stringColumn(notif *db.Notification, repo *db.Repository, column string) sql.NullString {
+	switch column {
+	case parse.ColumnRepoFullName:
+		if repo == nil {
+			return sql.NullString{}
 		}
-		if strings.Contains(strings.ToLower(mergedStr), lowerText) ||
-			(notif.SubjectMerged.Bool && strings.Contains(lowerText, "merged")) {
-			return true
+		return sql.NullString{String: repo.FullName, Valid: true}
+	case parse.ColumnReason:
+		return notif.Reason
+	case parse.ColumnSubjectType:
+		return sql.NullString{String: notif.SubjectType, Valid: true}
+	case parse.ColumnAuthorLogin:
+		return notif.AuthorLogin
+	case parse.ColumnSubjectState:
+		return notif.SubjectState
+	case parse.ColumnSubjectStateReason:
+		return notif.SubjectStateReason
+	case parse.ColumnReviewDecision:
+		return notif.SubjectReviewDecision
+	case parse.ColumnChecksStatus:
+		return notif.SubjectChecksStatus
+	case parse.ColumnSubjectMilestone:
+		return notif.SubjectMilestone
+	case parse.ColumnSubjectCategory:
+		return notif.SubjectCategory
+	case parse.ColumnSubjectTag:
+		return notif.SubjectTag
+	case parse.ColumnSubjectConclusion:
+		return notif.SubjectConclusion
+	case parse.ColumnSubjectSha:
+		return notif.SubjectSha
+	case parse.ColumnSubjectSeverity:
+		return notif.SubjectSeverity
+	default:
+		return sql.NullString{}
+	}
+}
+
+// matchPattern matches a glob or /regex/ value like the SQL builder's ILIKE and ~*.
+// Returns false for plain values, which each field kind matches its own way.
+func (e *Evaluator) matchPattern(column sql.NullString, value string) (truth, bool) {
+	kind, body := parse.ClassifyPattern(value)
+	switch kind {
+	case parse.PatternGlob:
+		return matchString(column, func(s string) bool {
+			return ilike(s, parse.GlobToLike(body))
+		}), true
+	case parse.PatternRegex:
+		re, ok := e.regexps[body]
+		if !ok {
+			// The SQL builder rejects invalid regexes, so nothing matches
+			return truthUnknown, true
 		}
+		return matchString(column, re.MatchString), true
+	default:
+		return truthUnknown, false
 	}
+}
 
-	return false
+// ownerOf mirrors split_part(full_name, '/', 1)
+func ownerOf(fullName sql.NullString) sql.NullString {
+	if !fullName.Valid {
+		return fullName
+	}
+	owner, _, _ := strings.Cut(fullName.String, "/")
+	return sql.NullString{String: owner, Valid: true}
+}
+
+// arrayColumn returns the value of a text array column from the registry (nil for NULL)
+func arrayColumn(notif *db.Notification, column string) []string {
+	switch column {
+	case parse.ColumnReviewRequested:
+		return notif.SubjectReviewRequested
+	case parse.ColumnSubjectLabels:
+		return notif.SubjectLabels
+	case parse.ColumnSubjectAssignees:
+		return notif.SubjectAssignees
+	default:
+		return nil
+	}
+}
+
+// evaluateArray mirrors column @> ARRAY[value]: unknown for a NULL array
+func evaluateArray(values []string, value string) truth {
+	if values == nil {
+		return truthUnknown
+	}
+	return truthOf(slices.Contains(values, strings.ToLower(strings.TrimSpace(value))))
+}
+
+// evaluateNo mirrors the no: conditions: true when the named field is NULL or empty.
+// has: is its negation; unknown only for unsupported values, which the SQL builder rejects.
+func evaluateNo(notif *db.Notification, repo *db.Repository, value string) truth {
+	spec, ok := parse.LookupField(value)
+	if !ok || !slices.Contains(parse.NoValues, spec.Name) {
+		return truthUnknown
+	}
+	if spec.Kind == parse.FieldTags {
+		return truthOf(len(notif.TagIds) == 0)
+	}
+	if spec.Kind == parse.FieldArray {
+		return truthOf(len(arrayColumn(notif, spec.Column)) == 0)
+	}
+	return truthOf(!stringColumn(notif, repo, spec.Column).Valid)
+}
+
+// boolColumn returns the value of a boolean column from the registry
+func boolColumn(notif *db.Notification, column string) bool {
+	switch column {
+	case parse.ColumnIsRead:
+		return notif.IsRead
+	case parse.ColumnArchived:
+		return notif.Archived
+	case parse.ColumnMuted:
+		return notif.Muted
+	case parse.ColumnFiltered:
+		return notif.Filtered
+	default:
+		return false
+	}
+}
+
+// timeColumn returns the value of a timestamp column from the registry
+func timeColumn(notif *db.Notification, column string) sql.NullTime {
+	switch column {
+	case parse.ColumnGithubUpdatedAt:
+		return notif.GithubUpdatedAt
+	case parse.ColumnSubjectCreatedAt:
+		return notif.SubjectCreatedAt
+	case parse.ColumnImportedAt:
+		return sql.NullTime{Time: notif.ImportedAt, Valid: true}
+	case parse.ColumnSnoozedUntil:
+		return notif.SnoozedUntil
+	default:
+		return sql.NullTime{}
+	}
+}
+
+// numberColumn returns the value of an integer column from the registry
+func numberColumn(notif *db.Notification, column string) sql.NullInt32 {
+	switch column {
+	case parse.ColumnSubjectNumber:
+		return notif.SubjectNumber
+	case parse.ColumnSubjectComments:
+		return notif.SubjectComments
+	default:
+		return sql.NullInt32{}
+	}
+}
+
+// isSnoozed mirrors (n.snoozed_until IS NOT NULL AND n.snoozed_until > NOW())
+func isSnoozed(notif *db.Notification, now time.Time) bool {
+	return notif.SnoozedUntil.Valid && notif.SnoozedUntil.Time.After(now)
+}
+
+// usesKind checks if the AST contains a term whose field has the given kind
+func usesKind(node parse.Node, kind parse.FieldKind) bool {
+	switch n := node.(type) {
+	case *parse.Term:
+		spec, ok := parse.LookupField(n.Field)
+		return ok && spec.Kind == kind
+	case *parse.BinaryExpr:
+		return usesKind(n.Left, kind) || usesKind(n.Right, kind)
+	case *parse.NotExpr:
+		return usesKind(n.Expr, kind)
+	case *parse.ParenExpr:
+		return usesKind(n.Expr, kind)
+	default:
+		return false
+	}
+}
+
+// usesFreeText checks if the AST contains free text anywhere
+func usesFreeText(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.FreeText:
+		return true
+	case *parse.BinaryExpr:
+		return usesFreeText(n.Left) || usesFreeText(n.Right)
+	case *parse.NotExpr:
+		return usesFreeText(n.Expr)
+	case *parse.ParenExpr:
+		return usesFreeText(n.Expr)
+	default:
+		return false
+	}
 }
@@ -103,7 +103,7 @@ func TestEvaluator_Matches_BinaryExpr(t *testing.T) {
 			expr: &parse.BinaryExpr{
 				Op:    "AND",
 				Left:  &parse.Term{Field: "is", Values: []string{"unread"}},
-				Right: &parse.Term{Field: "is", Values: []string{"unmuted"}},
+				Right: &parse.Term{Field: "muted", Values: []string{"false"}},
 			},
 			expected: true,
 		},
@@ -115,7 +115,7 @@ func TestEvaluator_Matches_BinaryExpr(t *testing.T) {
 			expr: &parse.BinaryExpr{
 				Op:    "AND",
 				Left:  &parse.Term{Field: "is", Values: []string{"unread"}},
-				Right: &parse.Term{Field: "is", Values: []string{"unmuted"}},
+				Right: &parse.Term{Field: "muted", Values: []string{"false"}},
 			},
 			expected: false,
 		},
@@ -127,7 +127,7 @@ func TestEvaluator_Matches_BinaryExpr(t *testing.T) {
 			expr: &parse.BinaryExpr{
 				Op:    "OR",
 				Left:  &parse.Term{Field: "is", Values: []string{"read"}},
-				Right: &parse.Term{Field: "is", Values: []string{"unmuted"}},
+				Right: &parse.Term{Field: "muted", Values: []string{"false"}},
 			},
 			expected: true,
 		},
@@ -139,7 +139,7 @@ func TestEvaluator_Matches_BinaryExpr(t *testing.T) {
 			expr: &parse.BinaryExpr{
 				Op:    "OR",
 				Left:  &parse.Term{Field: "is", Values: []string{"read"}},
-				Right: &parse.Term{Field: "is", Values: []string{"unmuted"}},
+				Right: &parse.Term{Field: "muted", Values: []string{"false"}},
 			},
 			expected: false,
 		},
@@ -267,11 +267,8 @@ func TestEvaluator_evaluateIsCondition(t *testing.T) {
 		{"read", &db.Notification{IsRead: true}, "read", true},
 		{"unread", &db.Notification{IsRead: false}, "unread", true},
 		{"archived", &db.Notification{Archived: true}, "archived", true},
-		{"inbox", &db.Notification{Archived: false}, "inbox", true},
 		{"muted", &db.Notification{Muted: true}, "muted", true},
-		{"unmuted", &db.Notification{Muted: false}, "unmuted", true},
 		{"starred", &db.Notification{Starred: true}, "starred", true},
-		{"unstarred", &db.Notification{Starred: false}, "unstarred", true},
 		{
 			"snoozed",
 			&db.Notification{SnoozedUntil: sql.NullTime{Valid: true, Time: now.Add(1 * time.Hour)}},
@@ -279,14 +276,19 @@ func TestEvaluator_evaluateIsCondition(t *testing.T) {
 			true,
 		},
 		{
-			"unsnoozed",
+			"not snoozed",
 			&db.Notification{SnoozedUntil: sql.NullTime{Valid: false}},
-			"unsnoozed",
-			true,
+			"snoozed",
+			false,
 		},
-		{"active", &db.Notification{SnoozedUntil: sql.NullTime{Valid: false}}, "active", true},
 		{"filtered", &db.Notification{Filtered: true}, "filtered", true},
-		{"unknown", &db.Notification{}, "unknown", true}, // Unknown values default to true
+		{"stale", &db.Notification{StaleAt: sql.NullTime{Valid: true, Time: now}}, "stale", true},
+		{"not stale", &db.Notification{}, "stale", false},
+		// Values the SQL builder rejects never match
+		{"inbox", &db.Notification{Archived: false}, "inbox", false},
+		{"unmuted", &db.Notification{Muted: false}, "unmuted", false},
+		{"unstarred", &db.Notification{Starred: false}, "unstarred", false},
+		{"unknown", &db.Notification{}, "unknown", false},
 	}
 
 	for _, tt := range tests {
@@ -428,10 +430,10 @@ func TestEvaluator_evaluateInCondition(t *testing.T) {
 			true,
 		},
 		{
-			"unknown - defaults to true",
+			"unknown - never matches",
 			&db.Notification{},
 			"unknown",
-			true,
+			false,
 		},
 	}
 
@@ -568,12 +570,908 @@ func TestEvaluator_evaluateFieldValue_UnknownField(t *testing.T) {
 	notif := &db.Notification{}
 	repo := &db.Repository{}
 
-	// Unknown fields should not filter (return true)
+	// The SQL builder rejects unknown fields, so they never match
 	term := &parse.Term{Field: "unknown", Values: []string{"value"}}
 	eval := NewEvaluator(term)
 
-	if !eval.Matches(notif, repo) {
-		t.Error("Unknown fields should not filter (default to true)")
+	if eval.Matches(notif, repo) {
+		t.Error("Unknown fields should never match")
+	}
+}
+
+func TestEvaluator_Matches_RegistryFields(t *testing.T) {
+	notif := &db.Notification{
+		SubjectType:        "PullRequest",
+		Reason:             sql.NullString{String: "review_requested", Valid: true},
+		AuthorLogin:        sql.NullString{String: "Octocat", Valid: true},
+		SubjectState:       sql.NullString{String: "closed", Valid: true},
+		SubjectMerged:      sql.NullBool{Bool: true, Valid: true},
+		SubjectStateReason: sql.NullString{String: "completed", Valid: true},
+	}
+	repo := &db.Repository{FullName: "cli/cli"}
+
+	tests := []struct {
+		name     string
+		term     *parse.Term
+		expected bool
+	}{
+		{"org matches owner prefix", &parse.Term{Field: "org", Values: []string{"cli"}}, true},
+		{"org is case-insensitive", &parse.Term{Field: "org", Values: []string{"CLI"}}, true},
+		{"org does not match repo name", &parse.Term{Field: "org", Values: []string{"cl"}}, false},
+		{"state matches exactly", &parse.Term{Field: "state", Values: []string{"closed"}}, true},
+		{"state is case-sensitive", &parse.Term{Field: "state", Values: []string{"Closed"}}, false},
+		{
+			"state does not match partially",
+			&parse.Term{Field: "state", Values: []string{"close"}},
+			false,
+		},
+		{"merged:true", &parse.Term{Field: "merged", Values: []string{"true"}}, true},
+		{"merged:unmerged", &parse.Term{Field: "merged", Values: []string{"unmerged"}}, false},
+		{
+			"state_reason contains",
+			&parse.Term{Field: "state_reason", Values: []string{"complete"}},
+			true,
+		},
+		{
+			"author is a contains match",
+			&parse.Term{Field: "author", Values: []string{"octo"}},
+			true,
+		},
+		{
+			"reason is a contains match",
+			&parse.Term{Field: "reason", Values: []string{"review"}},
+			true,
+		},
+		{"repository alias", &parse.Term{Field: "repository", Values: []string{"cli/"}}, true},
+		{"subject_type alias", &parse.Term{Field: "subject_type", Values: []string{"pull"}}, true},
+		{
+			"underscore is a wildcard",
+			&parse.Term{Field: "reason", Values: []string{"review_r"}},
+			true,
+		},
+		{"percent is a wildcard", &parse.Term{Field: "author", Values: []string{"oct%at"}}, true},
+		{
+			"escaped wildcard is literal",
+			&parse.Term{Field: "author", Values: []string{`oct\%`}},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.term)
+			result := eval.Matches(notif, repo)
+			if result != tt.expected {
+				t.Errorf("Matches(%s) = %v, want %v", tt.term.String(), result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Matches_NullColumns(t *testing.T) {
+	// Like SQL, comparing a NULL column is unknown, and NOT unknown never matches
+	notif := &db.Notification{SubjectType: "Release", SubjectTitle: "v1.0"}
+
+	tests := []struct {
+		name     string
+		ast      parse.Node
+		expected bool
+	}{
+		{
+			name:     "author on NULL author",
+			ast:      &parse.Term{Field: "author", Values: []string{"octocat"}},
+			expected: false,
+		},
+		{
+			name:     "negated author on NULL author",
+			ast:      &parse.Term{Field: "author", Values: []string{"octocat"}, Negated: true},
+			expected: false,
+		},
+		{
+			name: "NOT state on NULL state",
+			ast: &parse.NotExpr{
+				Expr: &parse.Term{Field: "state", Values: []string{"open"}},
+			},
+			expected: false,
+		},
+		{
+			name:     "negated merged on NULL merged",
+			ast:      &parse.Term{Field: "merged", Values: []string{"true"}, Negated: true},
+			expected: false,
+		},
+		{
+			name: "unknown OR true matches",
+			ast: &parse.BinaryExpr{
+				Op:    "OR",
+				Left:  &parse.Term{Field: "author", Values: []string{"octocat"}},
+				Right: &parse.Term{Field: "type", Values: []string{"release"}},
+			},
+			expected: true,
+		},
+		{
+			name: "NOT (unknown AND false) matches",
+			ast: &parse.NotExpr{
+				Expr: &parse.BinaryExpr{
+					Op:    "AND",
+					Left:  &parse.Term{Field: "author", Values: []string{"octocat"}},
+					Right: &parse.Term{Field: "type", Values: []string{"issue"}},
+				},
+			},
+			expected: true,
+		},
+		{
+			// The search vector coalesces NULL columns, so free text is never unknown
+			name:     "negated free text with NULL columns",
+			ast:      &parse.NotExpr{Expr: &parse.FreeText{Text: "octocat"}},
+			expected: true,
+		},
+		{
+			name:     "negated repo without a repository",
+			ast:      &parse.Term{Field: "repo", Values: []string{"cli"}, Negated: true},
+			expected: false,
+		},
+		{
+			name: "negated review-requested on NULL reviewers",
+			ast: &parse.Term{
+				Field:   "review-requested",
+				Values:  []string{"@me"},
+				Negated: true,
+			},
+			expected: false,
+		},
+		{
+			name:     "negated checks on NULL checks",
+			ast:      &parse.Term{Field: "checks", Values: []string{"failing"}, Negated: true},
+			expected: false,
+		},
+		{
+			// is:draft mirrors subject_draft IS TRUE, so its negation is never unknown
+			name:     "NOT is:draft on NULL draft",
+			ast:      &parse.NotExpr{Expr: &parse.Term{Field: "is", Values: []string{"draft"}}},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.ast)
+			result := eval.Matches(notif, nil)
+			if result != tt.expected {
+				t.Errorf("Matches() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Matches_PullRequestFields(t *testing.T) {
+	notif := &db.Notification{
+		SubjectType:            "PullRequest",
+		SubjectDraft:           sql.NullBool{Bool: true, Valid: true},
+		SubjectReviewDecision:  sql.NullString{String: "changes_requested", Valid: true},
+		SubjectReviewRequested: []string{"@me", "octocat", "platform-team"},
+		SubjectChecksStatus:    sql.NullString{String: "failing", Valid: true},
+	}
+
+	tests := []struct {
+		name     string
+		term     *parse.Term
+		expected bool
+	}{
+		{
+			name:     "is:draft",
+			term:     &parse.Term{Field: "is", Values: []string{"draft"}},
+			expected: true,
+		},
+		{
+			name:     "review decision",
+			term:     &parse.Term{Field: "review", Values: []string{"changes_requested"}},
+			expected: true,
+		},
+		{
+			name:     "review decision is case-insensitive",
+			term:     &parse.Term{Field: "review", Values: []string{"CHANGES_REQUESTED"}},
+			expected: true,
+		},
+		{
+			name:     "other review decision",
+			term:     &parse.Term{Field: "review", Values: []string{"approved"}},
+			expected: false,
+		},
+		{
+			name:     "checks",
+			term:     &parse.Term{Field: "checks", Values: []string{"failing"}},
+			expected: true,
+		},
+		{
+			name:     "negated checks",
+			term:     &parse.Term{Field: "checks", Values: []string{"failing"}, Negated: true},
+			expected: false,
+		},
+		{
+			name:     "requested from viewer",
+			term:     &parse.Term{Field: "review-requested", Values: []string{"@me"}},
+			expected: true,
+		},
+		{
+			name:     "requested from team",
+			term:     &parse.Term{Field: "review-requested", Values: []string{"Platform-Team"}},
+			expected: true,
+		},
+		{
+			name:     "not requested",
+			term:     &parse.Term{Field: "review_requested", Values: []string{"hubot"}},
+			expected: false,
+		},
+		{
+			name:     "requested is an exact match",
+			term:     &parse.Term{Field: "review-requested", Values: []string{"octo"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.term)
+			result := eval.Matches(notif, nil)
+			if result != tt.expected {
+				t.Errorf("Matches(%s) = %v, want %v", tt.term.String(), result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Matches_SubjectMetadataFields(t *testing.T) {
+	issue := &db.Notification{
+		SubjectType:      "Issue",
+		SubjectLabels:    []string{"bug", "good first issue"},
+		SubjectMilestone: sql.NullString{String: "v2.0", Valid: true},
+		SubjectAssignees: []string{},
+	}
+	release := &db.Notification{SubjectType: "Release"}
+	workflowRun := &db.Notification{
+		SubjectType:       "CheckSuite",
+		SubjectConclusion: sql.NullString{String: "failure", Valid: true},
+		SubjectSha:        sql.NullString{String: "0ff1ce5", Valid: true},
+	}
+	alert := &db.Notification{
+		SubjectType:     "RepositoryDependabotAlertsThread",
+		SubjectSeverity: sql.NullString{String: "critical", Valid: true},
+	}
+	discussion := &db.Notification{
+		SubjectType:     "Discussion",
+		SubjectCategory: sql.NullString{String: "Q&A", Valid: true},
+		SubjectAnswered: sql.NullBool{Bool: true, Valid: true},
+	}
+
+	tests := []struct {
+		name     string
+		notif    *db.Notification
+		term     *parse.Term
+		expected bool
+	}{
+		{
+			name:     "category",
+			notif:    discussion,
+			term:     &parse.Term{Field: "category", Values: []string{"q&a"}},
+			expected: true,
+		},
+		{
+			name:     "negated category on a release",
+			notif:    release,
+			term:     &parse.Term{Field: "category", Values: []string{"ideas"}, Negated: true},
+			expected: false,
+		},
+		{
+			name:     "conclusion",
+			notif:    workflowRun,
+			term:     &parse.Term{Field: "conclusion", Values: []string{"FAILURE"}},
+			expected: true,
+		},
+		{
+			name:     "sha prefix",
+			notif:    workflowRun,
+			term:     &parse.Term{Field: "sha", Values: []string{"0ff1"}},
+			expected: true,
+		},
+		{
+			name:     "severity",
+			notif:    alert,
+			term:     &parse.Term{Field: "severity", Values: []string{"high", "critical"}},
+			expected: true,
+		},
+		{
+			name:     "negated severity on a workflow run",
+			notif:    workflowRun,
+			term:     &parse.Term{Field: "severity", Values: []string{"low"}, Negated: true},
+			expected: false,
+		},
+		{
+			name:     "has:conclusion on an alert",
+			notif:    alert,
+			term:     &parse.Term{Field: "has", Values: []string{"conclusion"}},
+			expected: false,
+		},
+		{
+			name:     "is:answered on an answered discussion",
+			notif:    discussion,
+			term:     &parse.Term{Field: "is", Values: []string{"answered"}},
+			expected: true,
+		},
+		{
+			// Like is:draft, is:answered is never unknown
+			name:     "negated is:answered on a release",
+			notif:    release,
+			term:     &parse.Term{Field: "is", Values: []string{"answered"}, Negated: true},
+			expected: true,
+		},
+		{
+			name:     "no:category on a release",
+			notif:    release,
+			term:     &parse.Term{Field: "no", Values: []string{"category"}},
+			expected: true,
+		},
+		{
+			name:     "label",
+			notif:    issue,
+			term:     &parse.Term{Field: "label", Values: []string{"Good First Issue"}},
+			expected: true,
+		},
+		{
+			name:     "negated label",
+			notif:    issue,
+			term:     &parse.Term{Field: "label", Values: []string{"wontfix"}, Negated: true},
+			expected: true,
+		},
+		{
+			name:     "milestone",
+			notif:    issue,
+			term:     &parse.Term{Field: "milestone", Values: []string{"V2"}},
+			expected: true,
+		},
+		{
+			name:     "assignee on an unassigned issue",
+			notif:    issue,
+			term:     &parse.Term{Field: "assignee", Values: []string{"@me"}},
+			expected: false,
+		},
+		{
+			name:     "no:assignee on an unassigned issue",
+			notif:    issue,
+			term:     &parse.Term{Field: "no", Values: []string{"assignee"}},
+			expected: true,
+		},
+		{
+			name:     "no:label on a labeled issue",
+			notif:    issue,
+			term:     &parse.Term{Field: "no", Values: []string{"label"}},
+			expected: false,
+		},
+		{
+			name:     "no:milestone or label on a labeled issue",
+			notif:    issue,
+			term:     &parse.Term{Field: "no", Values: []string{"milestone", "label"}},
+			expected: false,
+		},
+		{
+			// no: is never unknown: NULL counts as empty, unlike -label:
+			name:     "no:label on a release",
+			notif:    release,
+			term:     &parse.Term{Field: "no", Values: []string{"label"}},
+			expected: true,
+		},
+		{
+			name:     "negated label on a release",
+			notif:    release,
+			term:     &parse.Term{Field: "label", Values: []string{"bug"}, Negated: true},
+			expected: false,
+		},
+		{
+			name:     "no: with a field it doesn't support",
+			notif:    release,
+			term:     &parse.Term{Field: "no", Values: []string{"repo"}},
+			expected: false,
+		},
+		{
+			name:     "no:author on a release",
+			notif:    release,
+			term:     &parse.Term{Field: "no", Values: []string{"author"}},
+			expected: true,
+		},
+		{
+			name:     "no:tags on an untagged issue",
+			notif:    issue,
+			term:     &parse.Term{Field: "no", Values: []string{"tags"}},
+			expected: true,
+		},
+		{
+			name:     "has:label on a labeled issue",
+			notif:    issue,
+			term:     &parse.Term{Field: "has", Values: []string{"label"}},
+			expected: true,
+		},
+		{
+			name:     "has:assignee on an unassigned issue",
+			notif:    issue,
+			term:     &parse.Term{Field: "has", Values: []string{"assignee"}},
+			expected: false,
+		},
+		{
+			name:     "has:tags on an untagged issue",
+			notif:    issue,
+			term:     &parse.Term{Field: "has", Values: []string{"tags"}},
+			expected: false,
+		},
+		{
+			// has: is never unknown either, so its negation matches a release
+			name:     "negated has:milestone on a release",
+			notif:    release,
+			term:     &parse.Term{Field: "has", Values: []string{"milestone"}, Negated: true},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.term)
+			result := eval.Matches(tt.notif, nil)
+			if result != tt.expected {
+				t.Errorf("Matches(%s) = %v, want %v", tt.term.String(), result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Matches_Patterns(t *testing.T) {
+	bot := &db.Notification{AuthorLogin: sql.NullString{String: "dependabot[bot]", Valid: true}}
+	human := &db.Notification{AuthorLogin: sql.NullString{String: "octocat", Valid: true}}
+	anonymous := &db.Notification{}
+	repo := &db.Repository{FullName: "acme/billing-service"}
+
+	tests := []struct {
+		name     string
+		notif    *db.Notification
+		term     *parse.Term
+		expected bool
+	}{
+		{
+			name:     "repo glob",
+			notif:    human,
+			term:     &parse.Term{Field: "repo", Values: []string{"ACME/*-service"}},
+			expected: true,
+		},
+		{
+			name:     "glob matches the whole value",
+			notif:    human,
+			term:     &parse.Term{Field: "repo", Values: []string{"acme/*-serv"}},
+			expected: false,
+		},
+		{
+			name:     "single character glob",
+			notif:    human,
+			term:     &parse.Term{Field: "author", Values: []string{"octoca?"}},
+			expected: true,
+		},
+		{
+			name:     "bot author regex",
+			notif:    bot,
+			term:     &parse.Term{Field: "author", Values: []string{`/\[bot\]$/`}},
+			expected: true,
+		},
+		{
+			name:     "excluding bot authors keeps humans",
+			notif:    human,
+			term:     &parse.Term{Field: "author", Values: []string{`/\[bot\]$/`}, Negated: true},
+			expected: true,
+		},
+		{
+			name:     "excluding bot authors drops bots",
+			notif:    bot,
+			term:     &parse.Term{Field: "author", Values: []string{`/\[bot\]$/`}, Negated: true},
+			expected: false,
+		},
+		{
+			// NULL author is unknown, so neither the regex nor its negation matches
+			name:     "negated regex on a NULL author",
+			notif:    anonymous,
+			term:     &parse.Term{Field: "author", Values: []string{"/bot/"}, Negated: true},
+			expected: false,
+		},
+		{
+			name:     "org glob matches the owner",
+			notif:    human,
+			term:     &parse.Term{Field: "org", Values: []string{"ac*"}},
+			expected: true,
+		},
+		{
+			name:     "org regex only sees the owner",
+			notif:    human,
+			term:     &parse.Term{Field: "org", Values: []string{"/service$/"}},
+			expected: false,
+		},
+		{
+			name:     "invalid regex matches nothing",
+			notif:    human,
+			term:     &parse.Term{Field: "author", Values: []string{"/[octo/"}, Negated: true},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.term)
+			result := eval.Matches(tt.notif, repo)
+			if result != tt.expected {
+				t.Errorf("Matches(%s) = %v, want %v", tt.term.String(), result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Matches_Tags(t *testing.T) {
+	tags := []db.Tag{
+		{ID: 1, Slug: "urgent-bug"},
+		{ID: 2, Slug: "docs"},
+	}
+	notif := &db.Notification{TagIds: []int64{1}}
+
+	tests := []struct {
+		name     string
+		term     *parse.Term
+		expected bool
+	}{
+		{"partial slug match", &parse.Term{Field: "tags", Values: []string{"urg"}}, true},
+		{"case-insensitive", &parse.Term{Field: "tags", Values: []string{"URGENT"}}, true},
+		{"tag not on notification", &parse.Term{Field: "tags", Values: []string{"docs"}}, false},
+		{
+			"any of several values",
+			&parse.Term{Field: "tags", Values: []string{"docs", "bug"}},
+			true,
+		},
+		{
+			"negated",
+			&parse.Term{Field: "tags", Values: []string{"docs"}, Negated: true},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.term)
+			if !eval.NeedsTags() {
+				t.Fatal("NeedsTags() = false, want true")
+			}
+			eval.SetTags(tags)
+			result := eval.Matches(notif, nil)
+			if result != tt.expected {
+				t.Errorf("Matches(%s) = %v, want %v", tt.term.String(), result, tt.expected)
+			}
+		})
+	}
+
+	if NewEvaluator(&parse.Term{Field: "is", Values: []string{"unread"}}).NeedsTags() {
+		t.Error("NeedsTags() = true for a query without tags:")
+	}
+}
+
+func TestEvaluator_Matches_Account(t *testing.T) {
+	accounts := []db.GithubAccount{
+		{ID: 1, Name: "default"},
+		{ID: 2, Name: "work"},
+	}
+	notif := &db.Notification{AccountID: 2}
+
+	tests := []struct {
+		name     string
+		term     *parse.Term
+		expected bool
+	}{
+		{"exact name", &parse.Term{Field: "account", Values: []string{"work"}}, true},
+		{"case-insensitive", &parse.Term{Field: "account", Values: []string{"WORK"}}, true},
+		{"partial name", &parse.Term{Field: "account", Values: []string{"wor"}}, false},
+		{"other account", &parse.Term{Field: "account", Values: []string{"default"}}, false},
+		{"unknown account", &parse.Term{Field: "account", Values: []string{"missing"}}, false},
+		{
+			"any of several values",
+			&parse.Term{Field: "account", Values: []string{"default", "work"}},
+			true,
+		},
+		{
+			"negated",
+			&parse.Term{Field: "account", Values: []string{"default"}, Negated: true},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.term)
+			if !eval.NeedsAccounts() {
+				t.Fatal("NeedsAccounts() = false, want true")
+			}
+			eval.SetAccounts(accounts)
+			result := eval.Matches(notif, nil)
+			if result != tt.expected {
+				t.Errorf("Matches(%s) = %v, want %v", tt.term.String(), result, tt.expected)
+			}
+		})
+	}
+
+	if NewEvaluator(&parse.Term{Field: "is", Values: []string{"unread"}}).NeedsAccounts() {
+		t.Error("NeedsAccounts() = true for a query without account:")
+	}
+}
+
+func TestEvaluator_Matches_EveryRegisteredField(t *testing.T) {
+	// With no NULL columns, every field must evaluate to true or false (never unknown),
+	// so exactly one of field:value and -field:value matches
+	now := time.Now()
+	notif := &db.Notification{
+		SubjectType:        "PullRequest",
+		SubjectTitle:       "Fix bug",
+		Reason:             sql.NullString{String: "mention", Valid: true},
+		AuthorLogin:        sql.NullString{String: "octocat", Valid: true},
+		SubjectState:       sql.NullString{String: "open", Valid: true},
+		SubjectMerged:      sql.NullBool{Bool: false, Valid: true},
+		SubjectStateReason: sql.NullString{String: "reopened", Valid: true},
+		SnoozedUntil:       sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+		GithubUpdatedAt:    sql.NullTime{Time: now, Valid: true},
+		SubjectCreatedAt:   sql.NullTime{Time: now, Valid: true},
+		ImportedAt:         now,
+		TagIds:             []int64{1},
+		SubjectNumber:      sql.NullInt32{Int32: 42, Valid: true},
+		SubjectComments:    sql.NullInt32{Int32: 3, Valid: true},
+
+		SubjectDraft:           sql.NullBool{Bool: false, Valid: true},
+		SubjectReviewDecision:  sql.NullString{String: "approved", Valid: true},
+		SubjectReviewRequested: []string{"octocat", "@me"},
+		SubjectChecksStatus:    sql.NullString{String: "passing", Valid: true},
+		SubjectLabels:          []string{"bug"},
+		SubjectMilestone:       sql.NullString{String: "cli v2", Valid: true},
+		SubjectAssignees:       []string{},
+		SubjectCategory:        sql.NullString{String: "cli help", Valid: true},
+		SubjectTag:             sql.NullString{String: "cli-v2.0.0", Valid: true},
+		SubjectConclusion:      sql.NullString{String: "action_required", Valid: true},
+		SubjectSha:             sql.NullString{String: "cli0ff1ce", Valid: true},
+		SubjectSeverity:        sql.NullString{String: "low", Valid: true},
+		AccountID:              1,
+	}
+	repo := &db.Repository{FullName: "cli/cli"}
+	values := map[parse.FieldKind]string{
+		parse.FieldContains: "cli",
+		parse.FieldPrefix:   "cli",
+		parse.FieldEquals:   "open",
+		parse.FieldBoolean:  "true",
+		parse.FieldMerged:   "merged",
+		parse.FieldSnoozed:  "false",
+		parse.FieldTime:     "7d",
+		parse.FieldIn:       "inbox",
+		parse.FieldIs:       "unread",
+		parse.FieldTags:     "urgent",
+		parse.FieldSort:     "updated",
+		parse.FieldArray:    "@me",
+		parse.FieldNumber:   "42",
+		parse.FieldAccount:  "default",
+	}
+
+	for _, name := range parse.FieldNames() {
+		t.Run(name, func(t *testing.T) {
+			spec, _ := parse.LookupField(name)
+			if spec.Kind == parse.FieldView {
+				t.Skip("view: is inlined by parse.Expand before evaluation")
+			}
+			value, ok := values[spec.Kind]
+			switch spec.Kind {
+			case parse.FieldEnum, parse.FieldNo, parse.FieldHas:
+				value, ok = spec.Values[0], true
+			}
+			if !ok {
+				t.Fatalf("no sample value for the kind of field %s", name)
+			}
+
+			term := &parse.Term{Field: name, Values: []string{value}}
+			negated := &parse.Term{Field: name, Values: []string{value}, Negated: true}
+			matches := 0
+			for _, ast := range []parse.Node{term, negated} {
+				eval := NewEvaluator(ast)
+				eval.SetTags([]db.Tag{{ID: 1, Slug: "urgent"}})
+				eval.SetAccounts([]db.GithubAccount{{ID: 1, Name: "default"}})
+				if eval.Matches(notif, repo) {
+					matches++
+				}
+			}
+			if matches != 1 {
+				t.Errorf("%s:%s and its negation matched %d times, want 1", name, value, matches)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Matches_TimeFields(t *testing.T) {
+	now := time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC)
+	updated := func(ts time.Time) *db.Notification {
+		return &db.Notification{GithubUpdatedAt: sql.NullTime{Time: ts, Valid: true}}
+	}
+
+	tests := []struct {
+		name     string
+		notif    *db.Notification
+		node     parse.Node
+		expected bool
+	}{
+		{
+			name:     "updated:today matches today",
+			notif:    updated(now.Add(-time.Hour)),
+			node:     &parse.Term{Field: "updated", Values: []string{"today"}},
+			expected: true,
+		},
+		{
+			name:     "updated:today does not match yesterday",
+			notif:    updated(now.AddDate(0, 0, -1)),
+			node:     &parse.Term{Field: "updated", Values: []string{"today"}},
+			expected: false,
+		},
+		{
+			name:     "updated:>7d matches recent",
+			notif:    updated(now.AddDate(0, 0, -2)),
+			node:     &parse.Comparison{Field: "updated", Op: ">", Value: "7d"},
+			expected: true,
+		},
+		{
+			name:     "updated:<7d matches older",
+			notif:    updated(now.AddDate(0, 0, -10)),
+			node:     &parse.Comparison{Field: "updated", Op: "<", Value: "7d"},
+			expected: true,
+		},
+		{
+			name:     "updated:<7d does not match recent",
+			notif:    updated(now.AddDate(0, 0, -2)),
+			node:     &parse.Comparison{Field: "updated", Op: "<", Value: "7d"},
+			expected: false,
+		},
+		{
+			name: "created range includes end day",
+			notif: &db.Notification{
+				SubjectCreatedAt: sql.NullTime{
+					Time:  time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC),
+					Valid: true,
+				},
+			},
+			node: &parse.Comparison{
+				Field: "created",
+				Op:    parse.OpRange,
+				Value: "2024-03-01",
+				Upper: "2024-03-10",
+			},
+			expected: true,
+		},
+		{
+			name:     "imported uses imported_at",
+			notif:    &db.Notification{ImportedAt: now.Add(-time.Hour)},
+			node:     &parse.Comparison{Field: "imported", Op: ">=", Value: "today"},
+			expected: true,
+		},
+		{
+			name:     "missing timestamp never matches",
+			notif:    &db.Notification{},
+			node:     &parse.Comparison{Field: "created", Op: "<", Value: "now"},
+			expected: false,
+		},
+		{
+			name:  "negated comparison matches missing timestamp",
+			notif: &db.Notification{},
+			node: &parse.NotExpr{
+				Expr: &parse.Comparison{Field: "snoozed_until", Op: ">", Value: "now"},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.node)
+			eval.now = func() time.Time { return now }
+			result := eval.Matches(tt.notif, &db.Repository{})
+			if result != tt.expected {
+				t.Errorf("Matches() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Matches_NumberFields(t *testing.T) {
+	issue := &db.Notification{
+		SubjectNumber:   sql.NullInt32{Int32: 1234, Valid: true},
+		SubjectComments: sql.NullInt32{Int32: 12, Valid: true},
+	}
+	release := &db.Notification{SubjectType: "Release"}
+
+	tests := []struct {
+		name     string
+		notif    *db.Notification
+		node     parse.Node
+		expected bool
+	}{
+		{
+			name:     "exact number",
+			notif:    issue,
+			node:     &parse.Term{Field: "number", Values: []string{"1234"}},
+			expected: true,
+		},
+		{
+			name:     "any of several numbers",
+			notif:    issue,
+			node:     &parse.Term{Field: "number", Values: []string{"1", "1234"}},
+			expected: true,
+		},
+		{
+			name:     "number:>1000",
+			notif:    issue,
+			node:     &parse.Comparison{Field: "number", Op: ">", Value: "1000"},
+			expected: true,
+		},
+		{
+			name:     "comments:>12 excludes 12",
+			notif:    issue,
+			node:     &parse.Comparison{Field: "comments", Op: ">", Value: "12"},
+			expected: false,
+		},
+		{
+			name:  "comments range includes its ends",
+			notif: issue,
+			node: &parse.Comparison{
+				Field: "comments",
+				Op:    parse.OpRange,
+				Value: "5",
+				Upper: "12",
+			},
+			expected: true,
+		},
+		{
+			name:     "missing number never matches",
+			notif:    release,
+			node:     &parse.Comparison{Field: "comments", Op: "<", Value: "10"},
+			expected: false,
+		},
+		{
+			name:  "negated comparison matches missing number",
+			notif: release,
+			node: &parse.NotExpr{
+				Expr: &parse.Comparison{Field: "number", Op: ">=", Value: "1"},
+			},
+			expected: true,
+		},
+		{
+			name:     "invalid number",
+			notif:    issue,
+			node:     &parse.Comparison{Field: "number", Op: ">", Value: "many"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := NewEvaluator(tt.node)
+			result := eval.Matches(tt.notif, &db.Repository{})
+			if result != tt.expected {
+				t.Errorf("Matches() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Matches_SortIsNotAFilter(t *testing.T) {
+	node := &parse.BinaryExpr{
+		Op:    "AND",
+		Left:  &parse.Term{Field: "is", Values: []string{"unread"}},
+		Right: &parse.Term{Field: "sort", Values: []string{"updated-asc"}},
+	}
+
+	eval := NewEvaluator(node)
+	if !eval.Matches(&db.Notification{IsRead: false}, &db.Repository{}) {
+		t.Error("sort: should not filter unread notifications")
+	}
+	if eval.Matches(&db.Notification{IsRead: true}, &db.Repository{}) {
+		t.Error("is:unread should still filter read notifications")
 	}
 }
 
@@ -596,6 +1494,7 @@ func TestEvaluator_evaluateFreeText(t *testing.T) {
 		notif    *db.Notification
 		repo     *db.Repository
 		text     string
+		quoted   bool
 		expected bool
 	}{
 		{
@@ -634,7 +1533,7 @@ func TestEvaluator_evaluateFreeText(t *testing.T) {
 			expected: true,
 		},
 		{
-			name: "matches free text in subject_raw fallback when subject_state not available",
+			name: "does not search subject_raw when subject_state not available",
 			notif: &db.Notification{
 				SubjectTitle: "Fix bug",
 				SubjectType:  "Issue",
@@ -643,7 +1542,7 @@ func TestEvaluator_evaluateFreeText(t *testing.T) {
 			},
 			repo:     repo,
 			text:     "open",
-			expected: true,
+			expected: false,
 		},
 		{
 			name:     "does not match unrelated free text",
@@ -652,11 +1551,62 @@ func TestEvaluator_evaluateFreeText(t *testing.T) {
 			text:     "nonexistent",
 			expected: false,
 		},
+		{
+			name: "matches free text in subject body",
+			notif: &db.Notification{
+				SubjectTitle: "Fix bug",
+				SubjectType:  "Issue",
+				SubjectRaw: pqtype.NullRawMessage{
+					Valid:      true,
+					RawMessage: json.RawMessage(`{"body": "Steps to reproduce the crash"}`),
+				},
+			},
+			repo:     repo,
+			text:     "reproduce",
+			expected: true,
+		},
+		{
+			name:     "matches word prefixes",
+			notif:    baseNotif,
+			repo:     repo,
+			text:     "auth",
+			expected: true,
+		},
+		{
+			name:     "does not match the middle of a word",
+			notif:    baseNotif,
+			repo:     repo,
+			text:     "thentication",
+			expected: false,
+		},
+		{
+			name:     "quoted text matches a phrase",
+			notif:    baseNotif,
+			repo:     repo,
+			text:     "authentication bug",
+			quoted:   true,
+			expected: true,
+		},
+		{
+			name:     "quoted text needs the words in order",
+			notif:    baseNotif,
+			repo:     repo,
+			text:     "bug authentication",
+			quoted:   true,
+			expected: false,
+		},
+		{
+			name:     "punctuation only never matches",
+			notif:    baseNotif,
+			repo:     repo,
+			text:     "[.]",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			freeText := &parse.FreeText{Text: tt.text}
+			freeText := &parse.FreeText{Text: tt.text, Quoted: tt.quoted}
 			eval := NewEvaluator(freeText)
 			result := eval.Matches(tt.notif, tt.repo)
 			if result != tt.expected {
@@ -833,13 +1783,13 @@ func TestEvaluator_ComplexNestedExpression(t *testing.T) {
 	}
 	repo := &db.Repository{FullName: "owner/repo"}
 
-	// ((is:unread OR is:read) AND is:unmuted)
+	// ((is:unread OR is:read) AND muted:false)
 	left := &parse.BinaryExpr{
 		Op:    "OR",
 		Left:  &parse.Term{Field: "is", Values: []string{"unread"}},
 		Right: &parse.Term{Field: "is", Values: []string{"read"}},
 	}
-	right := &parse.Term{Field: "is", Values: []string{"unmuted"}}
+	right := &parse.Term{Field: "muted", Values: []string{"false"}}
 	inner := &parse.BinaryExpr{Op: "AND", Left: left, Right: right}
 	outer := &parse.ParenExpr{Expr: inner}
 	eval := NewEvaluator(outer)
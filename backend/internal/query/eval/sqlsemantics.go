@@ -0,0 +1,114 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package eval
+
+import "strings"
+
+// truth is a SQL three-valued logic result.
+// Comparing a NULL column yields unknown, and NOT unknown is still unknown,
+// so a row only matches when the whole expression is true - exactly like a WHERE clause.
+type truth int8
+
+const (
+	truthUnknown truth = iota
+	truthFalse
+	truthTrue
+)
+
+// truthOf converts a boolean to a truth value
+func truthOf(b bool) truth {
+	if b {
+		return truthTrue
+	}
+	return truthFalse
+}
+
+// and combines two truth values like SQL AND
+func (t truth) and(other truth) truth {
+	switch {
+	case t == truthFalse || other == truthFalse:
+		return truthFalse
+	case t == truthTrue && other == truthTrue:
+		return truthTrue
+	default:
+		return truthUnknown
+	}
+}
+
+// or combines two truth values like SQL OR
+func (t truth) or(other truth) truth {
+	switch {
+	case t == truthTrue || other == truthTrue:
+		return truthTrue
+	case t == truthFalse && other == truthFalse:
+		return truthFalse
+	default:
+		return truthUnknown
+	}
+}
+
+// not negates a truth value like SQL NOT
+func (t truth) not() truth {
+	switch t {
+	case truthTrue:
+		return truthFalse
+	case truthFalse:
+		return truthTrue
+	default:
+		return truthUnknown
+	}
+}
+
+// ilike reports whether s matches a Postgres ILIKE pattern:
+// % matches any run of characters, _ matches one character and \ escapes the next one.
+func ilike(s, pattern string) bool {
+	return likeMatch([]rune(strings.ToLower(s)), []rune(strings.ToLower(pattern)))
+}
+
+func likeMatch(s, p []rune) bool {
+	for len(p) > 0 {
+		switch p[0] {
+		case '%':
+			// Collapse consecutive wildcards, then try every split point
+			for len(p) > 0 && p[0] == '%' {
+				p = p[1:]
+			}
+			if len(p) == 0 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if likeMatch(s[i:], p) {
+					return true
+				}
+			}
+			return false
+		case '_':
+			if len(s) == 0 {
+				return false
+			}
+			s, p = s[1:], p[1:]
+		default:
+			if p[0] == '\\' && len(p) > 1 {
+				p = p[1:]
+			}
+			if len(s) == 0 || s[0] != p[0] {
+				return false
+			}
+			s, p = s[1:], p[1:]
+		}
+	}
+	return len(s) == 0
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package eval
+
+import "testing"
+
+func TestTruth(t *testing.T) {
+	values := []truth{truthTrue, truthFalse, truthUnknown}
+	names := map[truth]string{truthTrue: "true", truthFalse: "false", truthUnknown: "unknown"}
+
+	// Expected results indexed by values order
+	and := [3][3]truth{
+		{truthTrue, truthFalse, truthUnknown},
+		{truthFalse, truthFalse, truthFalse},
+		{truthUnknown, truthFalse, truthUnknown},
+	}
+	or := [3][3]truth{
+		{truthTrue, truthTrue, truthTrue},
+		{truthTrue, truthFalse, truthUnknown},
+		{truthTrue, truthUnknown, truthUnknown},
+	}
+	not := [3]truth{truthFalse, truthTrue, truthUnknown}
+
+	for i, a := range values {
+		if got := a.not(); got != not[i] {
+			t.Errorf("NOT %s = %s, want %s", names[a], names[got], names[not[i]])
+		}
+		for j, b := range values {
+			if got := a.and(b); got != and[i][j] {
+				t.Errorf("%s AND %s = %s, want %s", names[a], names[b], names[got], names[and[i][j]])
+			}
+			if got := a.or(b); got != or[i][j] {
+				t.Errorf("%s OR %s = %s, want %s", names[a], names[b], names[got], names[or[i][j]])
+			}
+		}
+	}
+}
+
+func TestIlike(t *testing.T) {
+	tests := []struct {
+		s        string
+		pattern  string
+		expected bool
+	}{
+		{"review_requested", "%review%", true},
+		{"Review_Requested", "%REVIEW%", true},
+		{"review_requested", "review", false},
+		{"review_requested", "review%", true},
+		{"review_requested", "%requested", true},
+		{"cli/cli", "cli/%", true},
+		{"clio/cli", "cli/%", false},
+		{"abc", "a_c", true},
+		{"ac", "a_c", false},
+		{"a%c", `a\%c`, true},
+		{"abc", `a\%c`, false},
+		{"a_c", `%\_%`, true},
+		{"abc", `%\_%`, false},
+		{"", "%", true},
+		{"", "%%", true},
+		{"", "_", false},
+		{"über", "%Ü%", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s+" ILIKE "+tt.pattern, func(t *testing.T) {
+			if got := ilike(tt.s, tt.pattern); got != tt.expected {
+				t.Errorf("ilike(%q, %q) = %v, want %v", tt.s, tt.pattern, got, tt.expected)
+			}
+		})
+	}
+}
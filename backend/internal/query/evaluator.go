@@ -22,12 +22,12 @@ import (
 
 // NewEvaluator creates a new evaluator for the given query string
 // This is a convenience wrapper around eval.NewEvaluator
-func NewEvaluator(queryStr string) (*eval.Evaluator, error) {
+func NewEvaluator(queryStr string, defs Definitions) (*eval.Evaluator, error) {
 	if queryStr == "" {
 		return eval.NewEvaluator(nil), nil
 	}
 
-	ast, err := ParseAndValidate(queryStr)
+	ast, err := ParseAndValidate(queryStr, defs)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +33,7 @@ func TestEvaluatorBasic(t *testing.T) {
 	}
 
 	// Test empty query
-	eval, err := NewEvaluator("")
+	eval, err := NewEvaluator("", Definitions{})
 	if err != nil {
 		t.Fatalf("Failed to create evaluator: %v", err)
 	}
@@ -43,7 +43,7 @@ func TestEvaluatorBasic(t *testing.T) {
 	}
 
 	// Test is:unread
-	eval, err = NewEvaluator("is:unread")
+	eval, err = NewEvaluator("is:unread", Definitions{})
 	if err != nil {
 		t.Fatalf("Failed to create evaluator: %v", err)
 	}
@@ -53,7 +53,7 @@ func TestEvaluatorBasic(t *testing.T) {
 	}
 
 	// Test is:read (should not match)
-	eval, err = NewEvaluator("is:read")
+	eval, err = NewEvaluator("is:read", Definitions{})
 	if err != nil {
 		t.Fatalf("Failed to create evaluator: %v", err)
 	}
@@ -75,7 +75,7 @@ func TestActionHintsBasic(t *testing.T) {
 	}
 
 	// Test inbox query - archiving should dismiss
-	hints := ComputeActionHints(notif, repo, "")
+	hints := ComputeActionHints(notif, repo, "", Definitions{})
 	if len(hints.DismissedOn) == 0 {
 		t.Error("Expected some dismissal actions for inbox query")
 	}
@@ -102,7 +102,7 @@ func TestFreeTextSearch(t *testing.T) {
 	}
 
 	// Test matching title
-	eval, err := NewEvaluator("authentication")
+	eval, err := NewEvaluator("authentication", Definitions{})
 	if err != nil {
 		t.Fatalf("Failed to create evaluator: %v", err)
 	}
@@ -112,7 +112,7 @@ func TestFreeTextSearch(t *testing.T) {
 	}
 
 	// Test matching repo name
-	eval, err = NewEvaluator("myorg")
+	eval, err = NewEvaluator("myorg", Definitions{})
 	if err != nil {
 		t.Fatalf("Failed to create evaluator: %v", err)
 	}
@@ -122,7 +122,7 @@ func TestFreeTextSearch(t *testing.T) {
 	}
 
 	// Test not matching
-	eval, err = NewEvaluator("something-else")
+	eval, err = NewEvaluator("something-else", Definitions{})
 	if err != nil {
 		t.Fatalf("Failed to create evaluator: %v", err)
 	}
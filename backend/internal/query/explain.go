@@ -0,0 +1,107 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"errors"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/query/parse"
+	"github.com/ajbeattie/octobud/backend/internal/query/sql"
+)
+
+// Clause sources in an Explanation
+const (
+	ClauseSourceQuery   = "query"   // Written in the query
+	ClauseSourceDefault = "default" // Added by the implicit defaults
+)
+
+// ExplainedClause is one top-level AND clause of a query, built as a standalone
+// query so the rows it matches on its own can be counted
+type ExplainedClause struct {
+	Source string // ClauseSourceQuery or ClauseSourceDefault
+	Expr   string // The clause in canonical form, or the SQL condition for defaults
+	Query  db.NotificationQuery
+}
+
+// Explanation shows how a query string is turned into SQL
+type Explanation struct {
+	AST      Node
+	Defaults string               // DefaultsInbox, DefaultsMutedOnly or DefaultsNone
+	Query    db.NotificationQuery // The full query, as BuildQuery generates it
+	Clauses  []ExplainedClause
+}
+
+// Explain parses a query string and generates its SQL like BuildQuery, and also
+// breaks it into top-level clauses, including the implicit defaults
+func Explain(queryStr string, defs Definitions) (Explanation, error) {
+	ast, err := ParseAndValidate(queryStr, defs)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	full, err := sql.NewBuilder().Build(ast)
+	if err != nil {
+		return Explanation{}, errors.Join(ErrSQLGenerationFailed, err)
+	}
+	full = applyUnifiedDefaults(full, ast, queryStr)
+
+	var clauses []ExplainedClause
+	filter, _ := parse.SplitSort(ast)
+	for _, clause := range andClauses(filter) {
+		clauseQuery, err := sql.NewBuilder().Build(clause)
+		if err != nil {
+			return Explanation{}, errors.Join(ErrSQLGenerationFailed, err)
+		}
+		clauseQuery.OrderBy = nil
+		clauses = append(clauses, ExplainedClause{
+			Source: ClauseSourceQuery,
+			Expr:   parse.Format(clause),
+			Query:  clauseQuery,
+		})
+	}
+
+	defaults := applyUnifiedDefaults(db.NotificationQuery{}, ast, queryStr)
+	for _, where := range defaults.Where {
+		clauses = append(clauses, ExplainedClause{
+			Source: ClauseSourceDefault,
+			Expr:   where,
+			Query: db.NotificationQuery{
+				Joins: []string{},
+				Where: []string{where},
+				Args:  []interface{}{},
+			},
+		})
+	}
+
+	return Explanation{
+		AST:      ast,
+		Defaults: DefaultsFor(ast, queryStr),
+		Query:    full,
+		Clauses:  clauses,
+	}, nil
+}
+
+// andClauses flattens the top-level AND chain of a query into its operands
+func andClauses(node Node) []Node {
+	if node == nil {
+		return nil
+	}
+	if binary, ok := node.(*parse.BinaryExpr); ok && binary.Op == "AND" {
+		return append(andClauses(binary.Left), andClauses(binary.Right)...)
+	}
+	return []Node{node}
+}
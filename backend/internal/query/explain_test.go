@@ -0,0 +1,134 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ajbeattie/octobud/backend/internal/query/parse"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		expectedDefaults string
+		expectedClauses  []string // Expr of each clause written in the query
+		expectedDefault  int      // Number of clauses added by the defaults
+	}{
+		{
+			name:             "empty query gets inbox defaults",
+			query:            "",
+			expectedDefaults: DefaultsInbox,
+			expectedDefault:  4,
+		},
+		{
+			name:             "top-level AND is split into clauses",
+			query:            "repo:cli/cli is:unread",
+			expectedDefaults: DefaultsMutedOnly,
+			expectedClauses:  []string{"repo:cli/cli", "is:unread"},
+			expectedDefault:  1,
+		},
+		{
+			name:             "OR stays one clause",
+			query:            "(repo:cli OR repo:go) AND is:unread",
+			expectedDefaults: DefaultsMutedOnly,
+			expectedClauses:  []string{"repo:cli OR repo:go", "is:unread"},
+			expectedDefault:  1,
+		},
+		{
+			name:             "in: disables defaults",
+			query:            "in:anywhere author:octocat",
+			expectedDefaults: DefaultsNone,
+			expectedClauses:  []string{"in:anywhere", "author:octocat"},
+		},
+		{
+			name:             "explicit muted disables defaults",
+			query:            "is:muted",
+			expectedDefaults: DefaultsNone,
+			expectedClauses:  []string{"is:muted"},
+		},
+		{
+			name:             "sort terms are not clauses",
+			query:            "repo:cli sort:created",
+			expectedDefaults: DefaultsMutedOnly,
+			expectedClauses:  []string{"repo:cli"},
+			expectedDefault:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explanation, err := Explain(tt.query, Definitions{})
+			if err != nil {
+				t.Fatalf("Explain(%q) failed: %v", tt.query, err)
+			}
+
+			if explanation.Defaults != tt.expectedDefaults {
+				t.Errorf("Defaults = %q, want %q", explanation.Defaults, tt.expectedDefaults)
+			}
+
+			// The full query must match what the list endpoint runs
+			built, err := BuildQuery(tt.query, Definitions{}, 0, 0)
+			if err != nil {
+				t.Fatalf("BuildQuery(%q) failed: %v", tt.query, err)
+			}
+			if !slices.Equal(explanation.Query.Where, built.Where) {
+				t.Errorf("Where = %v, want %v", explanation.Query.Where, built.Where)
+			}
+
+			var queryClauses []string
+			defaultClauses := 0
+			for _, clause := range explanation.Clauses {
+				switch clause.Source {
+				case ClauseSourceQuery:
+					queryClauses = append(queryClauses, clause.Expr)
+				case ClauseSourceDefault:
+					defaultClauses++
+				}
+				if len(clause.Query.Where) == 0 {
+					t.Errorf("clause %q has no WHERE conditions", clause.Expr)
+				}
+				if len(clause.Query.OrderBy) != 0 {
+					t.Errorf("clause %q should not be ordered", clause.Expr)
+				}
+			}
+			if !slices.Equal(queryClauses, tt.expectedClauses) {
+				t.Errorf("query clauses = %v, want %v", queryClauses, tt.expectedClauses)
+			}
+			if defaultClauses != tt.expectedDefault {
+				t.Errorf("default clauses = %d, want %d", defaultClauses, tt.expectedDefault)
+			}
+		})
+	}
+}
+
+func TestExplain_InvalidQuery(t *testing.T) {
+	_, err := Explain("repo:cli AND (is:unread", Definitions{})
+	if err == nil {
+		t.Fatal("expected error for unbalanced parentheses")
+	}
+	if len(QueryErrors(err)) == 0 {
+		t.Errorf("expected positioned query errors, got %v", err)
+	}
+
+	_, err = Explain("bogus:value", Definitions{})
+	if !errors.Is(err, parse.ErrUnknownField) {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+}
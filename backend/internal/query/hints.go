@@ -29,8 +29,9 @@ func ComputeActionHints(
 	notif *db.Notification,
 	repo *db.Repository,
 	queryStr string,
+	defs Definitions,
 ) *models.ActionHints {
-	evaluator, err := NewEvaluator(queryStr)
+	evaluator, err := NewEvaluator(queryStr, defs)
 	if err != nil {
 		// If query parsing fails, be conservative
 		return &models.ActionHints{DismissedOn: []string{}}
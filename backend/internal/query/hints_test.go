@@ -204,7 +204,7 @@ func TestComputeActionHints(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			hints := ComputeActionHints(tt.notif, repo, tt.query)
+			hints := ComputeActionHints(tt.notif, repo, tt.query, Definitions{})
 
 			// Check that all expected dismissive actions are present
 			for _, wantAction := range tt.wantDismissed {
@@ -315,7 +315,7 @@ func TestComputeActionHints_CustomViewScenarios(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			hints := ComputeActionHints(tt.notif, repo, tt.query)
+			hints := ComputeActionHints(tt.notif, repo, tt.query, Definitions{})
 
 			// Check that all expected dismissive actions are present
 			for _, wantAction := range tt.wantDismissed {
@@ -370,7 +370,7 @@ func TestComputeActionHintsWithEvaluator_ReuseEvaluator(t *testing.T) {
 	repo := &db.Repository{FullName: "owner/repo"}
 
 	// Create evaluator once
-	evaluator, err := NewEvaluator("is:unread")
+	evaluator, err := NewEvaluator("is:unread", Definitions{})
 	if err != nil {
 		t.Fatalf("Failed to create evaluator: %v", err)
 	}
@@ -401,7 +401,7 @@ func TestComputeActionHints_InvalidQuery(t *testing.T) {
 	repo := &db.Repository{FullName: "owner/repo"}
 
 	// Invalid query should return empty hints (conservative)
-	hints := ComputeActionHints(notif, repo, "invalid:query:syntax")
+	hints := ComputeActionHints(notif, repo, "invalid:query:syntax", Definitions{})
 
 	if len(hints.DismissedOn) != 0 {
 		t.Errorf("Expected empty hints for invalid query, got: %v", hints.DismissedOn)
@@ -16,6 +16,8 @@
 package query
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -120,8 +122,8 @@ func TestIntegration_EndToEnd(t *testing.T) {
 		{
 			name:         "free text search",
 			input:        "urgent fix",
-			wantContains: []string{"n.subject_title", "ILIKE", "OR", "r.full_name"},
-			wantJoins:    1,
+			wantContains: []string{"n.search_vector @@ to_tsquery('english', $1)", "$2"},
+			wantJoins:    0,
 		},
 
 		// Complex real-world queries
@@ -169,7 +171,7 @@ func TestIntegration_EndToEnd(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query, err := BuildQuery(tt.input, 50, 0)
+			query, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 
 			if tt.wantErr {
 				if err == nil {
@@ -245,7 +247,7 @@ func TestIntegration_InboxQuery(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query, err := BuildQuery(tt.input, 50, 0)
+			query, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -315,11 +317,56 @@ func TestIntegration_ValidationErrors(t *testing.T) {
 			input:      "read:maybe",
 			wantErrMsg: "invalid boolean value",
 		},
+		{
+			name:       "invalid time value",
+			input:      "updated:soon",
+			wantErrMsg: "invalid time value for updated",
+		},
+		{
+			name:       "invalid time comparison",
+			input:      "created:>later",
+			wantErrMsg: "invalid time comparison for created",
+		},
+		{
+			name:       "comparison on non-time field",
+			input:      "repo:>cli",
+			wantErrMsg: "does not support comparisons",
+		},
+		{
+			name:       "invalid number comparison",
+			input:      "number:>big",
+			wantErrMsg: "invalid number comparison for number",
+		},
+		{
+			name:       "unknown sort key",
+			input:      "sort:priority",
+			wantErrMsg: "invalid sort key: priority",
+		},
+		{
+			name:       "sort under OR",
+			input:      "is:unread OR sort:repo",
+			wantErrMsg: "sort: cannot be used inside OR or NOT",
+		},
+		{
+			name:       "negated sort",
+			input:      "-sort:repo",
+			wantErrMsg: "sort: cannot be used inside OR or NOT",
+		},
+		{
+			name:       "relevance without search terms",
+			input:      "is:unread sort:relevance",
+			wantErrMsg: "sort:relevance requires free-text search terms",
+		},
+		{
+			name:       "relevance with only negated search terms",
+			input:      "NOT flaky sort:relevance",
+			wantErrMsg: "sort:relevance requires free-text search terms",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := BuildQuery(tt.input, 50, 0)
+			_, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 			if err == nil {
 				t.Fatal("expected error, got none")
 			}
@@ -350,9 +397,21 @@ func TestIntegration_RealWorldQueries(t *testing.T) {
 		"repo:cli reason:review_requested,mention is:unread",
 		"NOT (author:bot OR author:dependabot)",
 
+		// Time filters
+		"reason:review_requested state:open updated:<3d",
+		"created:2024-01-01..2024-01-31 type:Issue",
+		"in:snoozed snoozed_until:<=tomorrow",
+		"imported:today -updated:<1w",
+
+		// Sorting
+		"reason:review_requested sort:updated-asc",
+		"in:inbox sort:repo,updated",
+
 		// With free text
 		"repo:cli urgent",
 		`"fix: memory leak" repo:cli`,
+		`repo:cli "fix: memory leak"`,
+		"memory leak sort:relevance",
 
 		// Very complex
 		"((repo:cli/cli AND is:unread) OR (in:snoozed AND repo:github/docs)) AND NOT author:bot",
@@ -361,7 +420,7 @@ func TestIntegration_RealWorldQueries(t *testing.T) {
 
 	for _, query := range queries {
 		t.Run(query, func(t *testing.T) {
-			result, err := BuildQuery(query, 50, 0)
+			result, err := BuildQuery(query, Definitions{}, 50, 0)
 			if err != nil {
 				t.Fatalf("failed to build query: %v", err)
 			}
@@ -409,7 +468,7 @@ func TestIntegration_APIUsagePatterns(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Test ParseAndValidate (used in API validation)
-			_, err := ParseAndValidate(tt.input)
+			_, err := ParseAndValidate(tt.input, Definitions{})
 			if tt.wantErr && err == nil {
 				t.Error("expected validation error, got none")
 			}
@@ -419,7 +478,7 @@ func TestIntegration_APIUsagePatterns(t *testing.T) {
 
 			// Test BuildQuery (used in everything/archive views)
 			if !tt.wantErr {
-				_, err := BuildQuery(tt.input, 50, 0)
+				_, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 				if err != nil {
 					t.Errorf("BuildQuery failed: %v", err)
 				}
@@ -427,7 +486,7 @@ func TestIntegration_APIUsagePatterns(t *testing.T) {
 
 			// Test BuildQuery (unified query builder)
 			if !tt.wantErr {
-				_, err := BuildQuery(tt.input, 50, 0)
+				_, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 				if err != nil {
 					t.Errorf("BuildQuery failed: %v", err)
 				}
@@ -439,7 +498,7 @@ func TestIntegration_APIUsagePatterns(t *testing.T) {
 // TestIntegration_InboxDefaults tests that inbox queries apply defaults correctly
 func TestIntegration_InboxDefaults(t *testing.T) {
 	// Empty query should get default filters
-	q1, err := BuildQuery("", 50, 0)
+	q1, err := BuildQuery("", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("empty query failed: %v", err)
 	}
@@ -448,7 +507,7 @@ func TestIntegration_InboxDefaults(t *testing.T) {
 	}
 
 	// Query with in:anywhere should not get defaults
-	q2, err := BuildQuery("in:anywhere", 50, 0)
+	q2, err := BuildQuery("in:anywhere", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("in:anywhere query failed: %v", err)
 	}
@@ -461,7 +520,7 @@ func TestIntegration_InboxDefaults(t *testing.T) {
 // TestIntegration_EverythingView tests the everything view pattern
 func TestIntegration_EverythingView(t *testing.T) {
 	// Everything view: is:unread in:anywhere
-	query, err := BuildQuery("is:unread in:anywhere", 50, 0)
+	query, err := BuildQuery("is:unread in:anywhere", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("everything view query failed: %v", err)
 	}
@@ -508,7 +567,7 @@ func TestIntegration_SystemViews(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Test with BuildQuery (no defaults)
-			query1, err := BuildQuery(tt.input, 50, 0)
+			query1, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 			if err != nil {
 				t.Fatalf("BuildQuery failed: %v", err)
 			}
@@ -517,7 +576,7 @@ func TestIntegration_SystemViews(t *testing.T) {
 			}
 
 			// Test with BuildQuery (unified query builder)
-			query2, err := BuildQuery(tt.input, 50, 0)
+			query2, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 			if err != nil {
 				t.Fatalf("BuildQuery failed: %v", err)
 			}
@@ -749,7 +808,7 @@ func TestIntegration_FullSQL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query, err := BuildQuery(tt.input, 50, 0)
+			query, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 
 			if tt.wantErr {
 				if err == nil {
@@ -867,7 +926,7 @@ func TestIntegration_ComplexSQL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query, err := BuildQuery(tt.input, 50, 0)
+			query, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -953,7 +1012,7 @@ func TestIntegration_ErrorMessages(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := BuildQuery(tt.input, 50, 0)
+			_, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 			if err == nil {
 				t.Fatal("expected error, got none")
 			}
@@ -1021,7 +1080,7 @@ func TestIntegration_InboxQueryExact(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query, err := BuildQuery(tt.input, 50, 0)
+			query, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -1105,7 +1164,7 @@ func TestIntegration_QueryContext(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query, err := BuildQuery(tt.query, 50, 0)
+			query, err := BuildQuery(tt.query, Definitions{}, 50, 0)
 			if err != nil {
 				t.Fatalf("BuildQuery failed: %v", err)
 			}
@@ -1165,43 +1224,159 @@ func TestIntegration_QueryContext(t *testing.T) {
 
 // TestIntegration_FreeText tests free text search SQL generation
 func TestIntegration_FreeText(t *testing.T) {
-	query, err := BuildQuery("urgent fix", 50, 0)
+	query, err := BuildQuery("urgent fix", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Free text generates complex OR clause across multiple fields
+	// Each word is a prefix full-text search on the search_vector column
 	// Non-empty query without in: also gets muted-only default
 	if len(query.Where) != 2 {
 		t.Fatalf("expected 2 WHERE clauses (query + muted default), got %d", len(query.Where))
 	}
 
 	where := query.Where[0]
+	expected := "(n.search_vector @@ to_tsquery('english', $1) AND " +
+		"n.search_vector @@ to_tsquery('english', $2))"
+	if where != expected {
+		t.Errorf("expected WHERE %q, got: %s", expected, where)
+	}
 
-	// Should search across title, type, repo, author, state
-	expectedParts := []string{
-		"n.subject_title ILIKE",
-		"n.subject_type ILIKE",
-		"r.full_name ILIKE",
-		"n.author_login ILIKE",
-		"n.subject_state ILIKE",
-		"OR",
+	// One arg per word, matched as a prefix
+	if len(query.Args) != 2 || query.Args[0] != "urgent:*" || query.Args[1] != "fix:*" {
+		t.Errorf("expected args [urgent:* fix:*], got %v", query.Args)
 	}
 
-	for _, part := range expectedParts {
-		if !strings.Contains(where, part) {
-			t.Errorf("expected WHERE to contain %q, got: %s", part, where)
-		}
+	// The search vector includes the repository name, so no join is needed
+	if len(query.Joins) != 0 {
+		t.Errorf("expected no joins, got %d", len(query.Joins))
 	}
+}
 
-	// Should have 12 args (6 fields × 2 words: title, type, repo, author, state, subject_number)
-	if len(query.Args) != 12 {
-		t.Errorf("expected 12 args for 2 words across 6 fields, got %d", len(query.Args))
+// TestIntegration_FreeTextSearch tests phrase, prefix and relevance SQL generation
+func TestIntegration_FreeTextSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantWhere   string
+		wantArgs    []interface{}
+		wantOrderBy []string
+	}{
+		{
+			name:      "quoted phrase",
+			input:     `"memory leak" in:anywhere`,
+			wantWhere: "n.search_vector @@ websearch_to_tsquery('english', $1)",
+			wantArgs:  []interface{}{`"memory leak"`},
+		},
+		{
+			name:      "quoted phrase after a filter",
+			input:     `in:anywhere "memory leak"`,
+			wantWhere: "n.search_vector @@ websearch_to_tsquery('english', $1)",
+			wantArgs:  []interface{}{`"memory leak"`},
+		},
+		{
+			name:      "hyphenated word matches each part as a prefix",
+			input:     "in:anywhere go-gh",
+			wantWhere: "n.search_vector @@ to_tsquery('english', $1)",
+			wantArgs:  []interface{}{"go:* & gh:*"},
+		},
+		{
+			name:      "punctuation only never matches",
+			input:     "in:anywhere [.]",
+			wantWhere: "FALSE",
+			wantArgs:  []interface{}{},
+		},
+		{
+			name:      "sort by relevance",
+			input:     "in:anywhere leak sort:relevance",
+			wantWhere: "n.search_vector @@ to_tsquery('english', $1)",
+			wantArgs:  []interface{}{"leak:*"},
+			wantOrderBy: []string{
+				"ts_rank(n.search_vector, to_tsquery('english', $1)) DESC NULLS LAST",
+			},
+		},
+		{
+			name:      "relevance ranks by every search term outside NOT",
+			input:     `in:anywhere leak "out of memory" NOT flaky sort:relevance`,
+			wantWhere: "n.search_vector @@ to_tsquery('english', $1)",
+			wantArgs:  []interface{}{"leak:*", `"out of memory"`, "flaky:*"},
+			wantOrderBy: []string{
+				"ts_rank(n.search_vector, to_tsquery('english', $1) || " +
+					"websearch_to_tsquery('english', $2)) DESC NULLS LAST",
+			},
+		},
 	}
 
-	// Should require repo join
-	if len(query.Joins) != 1 {
-		t.Errorf("expected 1 join, got %d", len(query.Joins))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := BuildQuery(tt.input, Definitions{}, 50, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			where := strings.Join(query.Where, " AND ")
+			if !strings.Contains(where, tt.wantWhere) {
+				t.Errorf("expected WHERE to contain %q, got: %s", tt.wantWhere, where)
+			}
+			if !reflect.DeepEqual(query.Args, tt.wantArgs) {
+				t.Errorf("expected args %v, got %v", tt.wantArgs, query.Args)
+			}
+			if !reflect.DeepEqual(query.OrderBy, tt.wantOrderBy) {
+				t.Errorf("expected ORDER BY %v, got %v", tt.wantOrderBy, query.OrderBy)
+			}
+		})
+	}
+}
+
+// TestIntegration_Normalize tests that normalized queries are canonical and build the same SQL
+func TestIntegration_Normalize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: ""},
+		{input: "repository:cli/cli AND is:unread", want: "repo:cli/cli is:unread"},
+		{
+			input: "((repo:cli OR repo:go)) AND NOT author:bot",
+			want:  "(repo:cli OR repo:go) -author:bot",
+		},
+		{input: "subject_type:PullRequest sort:updated", want: "type:PullRequest sort:updated"},
+		{input: `in:anywhere "memory leak" NOT flaky`, want: `in:anywhere "memory leak" -flaky`},
+		{input: "view:triage AND @mine", want: "view:triage @mine"},
+		{input: "updated:>=2024-01-01 number:10..20", want: "updated:>=2024-01-01 number:10..20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			normalized, err := Normalize(tt.input)
+			if err != nil {
+				t.Fatalf("Normalize(%q) failed: %v", tt.input, err)
+			}
+			if normalized != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, normalized, tt.want)
+			}
+			if again, _ := Normalize(normalized); again != normalized {
+				t.Errorf("Normalize(%q) = %q, not stable", normalized, again)
+			}
+
+			if HasReferences(tt.input) {
+				return
+			}
+			original, err := BuildQuery(tt.input, Definitions{}, 50, 0)
+			if err != nil {
+				t.Fatalf("BuildQuery(%q) failed: %v", tt.input, err)
+			}
+			rebuilt, err := BuildQuery(normalized, Definitions{}, 50, 0)
+			if err != nil {
+				t.Fatalf("BuildQuery(%q) failed: %v", normalized, err)
+			}
+			if !reflect.DeepEqual(original, rebuilt) {
+				t.Errorf("normalized query builds %+v, want %+v", rebuilt, original)
+			}
+		})
+	}
+
+	if _, err := Normalize("repo:cli AND"); !errors.Is(err, ErrParseFailed) {
+		t.Errorf("expected ErrParseFailed for an incomplete query, got %v", err)
 	}
 }
 
@@ -1290,7 +1465,7 @@ func TestCoverage_BooleanValueVariations(t *testing.T) {
 
 	for _, val := range boolValues {
 		// Test read field
-		query, err := BuildQuery("read:"+val, 50, 0)
+		query, err := BuildQuery("read:"+val, Definitions{}, 50, 0)
 		if err != nil {
 			t.Errorf("read:%s failed: %v", val, err)
 		}
@@ -1299,7 +1474,7 @@ func TestCoverage_BooleanValueVariations(t *testing.T) {
 		}
 
 		// Test archived field
-		query, err = BuildQuery("archived:"+val, 50, 0)
+		query, err = BuildQuery("archived:"+val, Definitions{}, 50, 0)
 		if err != nil {
 			t.Errorf("archived:%s failed: %v", val, err)
 		}
@@ -1308,7 +1483,7 @@ func TestCoverage_BooleanValueVariations(t *testing.T) {
 		}
 
 		// Test muted field
-		query, err = BuildQuery("muted:"+val, 50, 0)
+		query, err = BuildQuery("muted:"+val, Definitions{}, 50, 0)
 		if err != nil {
 			t.Errorf("muted:%s failed: %v", val, err)
 		}
@@ -1317,7 +1492,7 @@ func TestCoverage_BooleanValueVariations(t *testing.T) {
 		}
 
 		// Test snoozed field
-		query, err = BuildQuery("snoozed:"+val, 50, 0)
+		query, err = BuildQuery("snoozed:"+val, Definitions{}, 50, 0)
 		if err != nil {
 			t.Errorf("snoozed:%s failed: %v", val, err)
 		}
@@ -1338,7 +1513,7 @@ func TestCoverage_MultipleValuesForBooleans(t *testing.T) {
 	}
 
 	for _, queryStr := range tests {
-		query, err := BuildQuery(queryStr, 50, 0)
+		query, err := BuildQuery(queryStr, Definitions{}, 50, 0)
 		if err != nil {
 			t.Errorf("%s failed: %v", queryStr, err)
 		}
@@ -1368,7 +1543,7 @@ func TestCoverage_ErrorPaths(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := BuildQuery(tt.input, 50, 0)
+			_, err := BuildQuery(tt.input, Definitions{}, 50, 0)
 			if err == nil {
 				t.Error("expected error, got none")
 			}
@@ -1379,13 +1554,13 @@ func TestCoverage_ErrorPaths(t *testing.T) {
 // TestCoverage_EdgeCases tests edge cases
 func TestCoverage_EdgeCases(t *testing.T) {
 	// Empty value after colon (should error in parser)
-	_, err := BuildQuery("repo:", 50, 0)
+	_, err := BuildQuery("repo:", Definitions{}, 50, 0)
 	if err == nil {
 		t.Error("expected error for empty value")
 	}
 
 	// Only whitespace - parses as empty query, gets inbox defaults
-	query, err := BuildQuery("   ", 50, 0)
+	query, err := BuildQuery("   ", Definitions{}, 50, 0)
 	if err != nil {
 		t.Errorf("whitespace-only query should parse as empty: %v", err)
 	}
@@ -1395,19 +1570,19 @@ func TestCoverage_EdgeCases(t *testing.T) {
 	}
 
 	// Multiple operators in sequence
-	_, err = BuildQuery("AND AND", 50, 0)
+	_, err = BuildQuery("AND AND", Definitions{}, 50, 0)
 	if err == nil {
 		t.Error("expected error for operators without operands")
 	}
 
 	// Negation without operand
-	_, err = BuildQuery("NOT", 50, 0)
+	_, err = BuildQuery("NOT", Definitions{}, 50, 0)
 	if err == nil {
 		t.Error("expected error for NOT without operand")
 	}
 
 	// Empty parentheses
-	_, err = BuildQuery("()", 50, 0)
+	_, err = BuildQuery("()", Definitions{}, 50, 0)
 	if err == nil {
 		t.Error("expected error for empty parentheses")
 	}
@@ -1416,7 +1591,7 @@ func TestCoverage_EdgeCases(t *testing.T) {
 // TestCoverage_LexerEdgeCases tests lexer edge cases
 func TestCoverage_LexerEdgeCases(t *testing.T) {
 	// Hyphen in middle of word (not NOT operator)
-	query, err := BuildQuery("some-word", 50, 0)
+	query, err := BuildQuery("some-word", Definitions{}, 50, 0)
 	if err != nil {
 		t.Errorf("hyphenated word should work: %v", err)
 	}
@@ -1425,25 +1600,25 @@ func TestCoverage_LexerEdgeCases(t *testing.T) {
 	}
 
 	// Multiple hyphens
-	query, err = BuildQuery("my-test-word", 50, 0)
+	query, err = BuildQuery("my-test-word", Definitions{}, 50, 0)
 	if err != nil {
 		t.Errorf("multi-hyphenated word should work: %v", err)
 	}
 
 	// Special characters in values
-	query, err = BuildQuery("repo:org/repo-name", 50, 0)
+	query, err = BuildQuery("repo:org/repo-name", Definitions{}, 50, 0)
 	if err != nil {
 		t.Errorf("slash and hyphen in value should work: %v", err)
 	}
 
 	// At sign in values
-	query, err = BuildQuery("author:user@example.com", 50, 0)
+	query, err = BuildQuery("author:user@example.com", Definitions{}, 50, 0)
 	if err != nil {
 		t.Errorf("at sign in value should work: %v", err)
 	}
 
 	// Dots in values
-	query, err = BuildQuery("file:config.yaml", 50, 0)
+	query, err = BuildQuery("file:config.yaml", Definitions{}, 50, 0)
 	if err == nil {
 		// file is not a known field, so should error in validation
 		t.Error("unknown field should error")
@@ -1460,13 +1635,13 @@ func TestCoverage_BuildInboxQueryError(t *testing.T) {
 	// (though this is hard since validation catches most issues)
 
 	// Test with invalid syntax
-	_, err := BuildQuery("(repo:cli", 50, 0)
+	_, err := BuildQuery("(repo:cli", Definitions{}, 50, 0)
 	if err == nil {
 		t.Error("expected error for invalid syntax")
 	}
 
 	// Test with validation error
-	_, err = BuildQuery("badfield:value", 50, 0)
+	_, err = BuildQuery("badfield:value", Definitions{}, 50, 0)
 	if err == nil {
 		t.Error("expected error for unknown field")
 	}
@@ -1521,7 +1696,7 @@ func TestCoverage_HasInOperatorAllPaths(t *testing.T) {
 
 // TestCoverage_OrgMultipleValues tests org with multiple values
 func TestCoverage_OrgMultipleValues(t *testing.T) {
-	query, err := BuildQuery("org:github,microsoft", 50, 0)
+	query, err := BuildQuery("org:github,microsoft", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("org with multiple values failed: %v", err)
 	}
@@ -1551,7 +1726,7 @@ func TestCoverage_OrgMultipleValues(t *testing.T) {
 
 // TestCoverage_StateMultipleValues tests state with multiple values
 func TestCoverage_StateMultipleValues(t *testing.T) {
-	query, err := BuildQuery("state:open,closed", 50, 0)
+	query, err := BuildQuery("state:open,closed", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("state with multiple values failed: %v", err)
 	}
@@ -1573,7 +1748,7 @@ func TestCoverage_StateMultipleValues(t *testing.T) {
 
 // TestCoverage_InOperatorMultipleValues tests in: with multiple values
 func TestCoverage_InOperatorMultipleValues(t *testing.T) {
-	query, err := BuildQuery("in:inbox,archive", 50, 0)
+	query, err := BuildQuery("in:inbox,archive", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("in: with multiple values failed: %v", err)
 	}
@@ -1590,7 +1765,7 @@ func TestCoverage_InOperatorMultipleValues(t *testing.T) {
 
 // TestCoverage_IsOperatorMultipleValues tests is: with multiple values
 func TestCoverage_IsOperatorMultipleValues(t *testing.T) {
-	query, err := BuildQuery("is:read,unread", 50, 0)
+	query, err := BuildQuery("is:read,unread", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("is: with multiple values failed: %v", err)
 	}
@@ -1636,7 +1811,7 @@ func TestCoverage_LexerQuotedStrings(t *testing.T) {
 // TestCoverage_ParserImplicitAND tests implicit AND behavior
 func TestCoverage_ParserImplicitAND(t *testing.T) {
 	// Implicit AND (space between terms)
-	query, err := BuildQuery("repo:cli is:unread", 50, 0)
+	query, err := BuildQuery("repo:cli is:unread", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("implicit AND failed: %v", err)
 	}
@@ -1654,10 +1829,11 @@ func TestCoverage_ParserImplicitAND(t *testing.T) {
 // TestCoverage_ParserComplexNesting tests deeply nested expressions
 func TestCoverage_ParserComplexNesting(t *testing.T) {
 	query, err := BuildQuery(
-		"((repo:cli OR repo:other) AND is:unread) OR (repo:test AND is:read)",
+		"((repo:cli OR repo:other) AND is:unread) OR (repo:test AND is:read)", Definitions{},
+
 		50,
-		0,
-	)
+		0)
+
 	if err != nil {
 		t.Fatalf("complex nesting failed: %v", err)
 	}
@@ -1687,7 +1863,7 @@ func TestCoverage_FreeTextEdgeCases(t *testing.T) {
 
 	for _, input := range tests {
 		t.Run(input, func(t *testing.T) {
-			query, err := BuildQuery(input, 50, 0)
+			query, err := BuildQuery(input, Definitions{}, 50, 0)
 			if err != nil {
 				t.Errorf("free text %q failed: %v", input, err)
 			}
@@ -1700,7 +1876,7 @@ func TestCoverage_FreeTextEdgeCases(t *testing.T) {
 
 // TestCoverage_NegatedTerms tests negated field:value terms
 func TestCoverage_NegatedTerms(t *testing.T) {
-	query, err := BuildQuery("-repo:unwanted", 50, 0)
+	query, err := BuildQuery("-repo:unwanted", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("negated term failed: %v", err)
 	}
@@ -1717,7 +1893,7 @@ func TestCoverage_NegatedTerms(t *testing.T) {
 
 // TestCoverage_NegatedComplexExpression tests NOT with complex expressions
 func TestCoverage_NegatedComplexExpression(t *testing.T) {
-	query, err := BuildQuery("NOT (repo:unwanted OR author:bot)", 50, 0)
+	query, err := BuildQuery("NOT (repo:unwanted OR author:bot)", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("negated complex expression failed: %v", err)
 	}
@@ -1735,7 +1911,7 @@ func TestCoverage_NegatedComplexExpression(t *testing.T) {
 
 // TestCoverage_MultipleNegations tests multiple NOT operators
 func TestCoverage_MultipleNegations(t *testing.T) {
-	query, err := BuildQuery("-repo:unwanted -author:bot", 50, 0)
+	query, err := BuildQuery("-repo:unwanted -author:bot", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("multiple negations failed: %v", err)
 	}
@@ -1763,7 +1939,7 @@ func TestCoverage_InOperatorAllValues(t *testing.T) {
 
 	for _, val := range values {
 		t.Run("in:"+val, func(t *testing.T) {
-			query, err := BuildQuery("in:"+val, 50, 0)
+			query, err := BuildQuery("in:"+val, Definitions{}, 50, 0)
 			if err != nil {
 				t.Errorf("in:%s failed: %v", val, err)
 			}
@@ -1788,7 +1964,7 @@ func TestCoverage_IsOperatorAllValues(t *testing.T) {
 
 	for _, tc := range values {
 		t.Run("is:"+tc.val, func(t *testing.T) {
-			_, err := BuildQuery("is:"+tc.val, 50, 0)
+			_, err := BuildQuery("is:"+tc.val, Definitions{}, 50, 0)
 			if tc.wantErr && err == nil {
 				t.Errorf("is:%s should error but didn't", tc.val)
 			}
@@ -1803,8 +1979,8 @@ func TestCoverage_IsOperatorAllValues(t *testing.T) {
 func TestCoverage_ValidatorWithComplexAST(t *testing.T) {
 	// Test validator with deeply nested structures
 	ast, err := ParseAndValidate(
-		"((repo:cli OR repo:other) AND (is:unread OR is:read)) OR (author:bot AND -repo:test)",
-	)
+		"((repo:cli OR repo:other) AND (is:unread OR is:read)) OR (author:bot AND -repo:test)", Definitions{})
+
 	if err != nil {
 		t.Fatalf("complex validation failed: %v", err)
 	}
@@ -1841,7 +2017,7 @@ func TestCoverage_ParserTrailingWhitespace(t *testing.T) {
 
 	for _, input := range inputs {
 		t.Run("input with whitespace", func(t *testing.T) {
-			query, err := BuildQuery(input, 50, 0)
+			query, err := BuildQuery(input, Definitions{}, 50, 0)
 			if err != nil {
 				t.Errorf("query with whitespace failed: %v", err)
 			}
@@ -1855,7 +2031,7 @@ func TestCoverage_ParserTrailingWhitespace(t *testing.T) {
 // TestCoverage_SQLBuilderComplexORCombinations tests complex OR combinations
 func TestCoverage_SQLBuilderComplexORCombinations(t *testing.T) {
 	// Test OR with different field types
-	query, err := BuildQuery("(repo:cli OR reason:mention) AND (author:user OR is:unread)", 50, 0)
+	query, err := BuildQuery("(repo:cli OR reason:mention) AND (author:user OR is:unread)", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("complex OR combinations failed: %v", err)
 	}
@@ -1872,7 +2048,7 @@ func TestCoverage_SQLBuilderComplexORCombinations(t *testing.T) {
 
 // TestCoverage_NegatedFreeText tests negated free text
 func TestCoverage_NegatedFreeText(t *testing.T) {
-	query, err := BuildQuery("-urgent", 50, 0)
+	query, err := BuildQuery("-urgent", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("negated free text failed: %v", err)
 	}
@@ -1889,7 +2065,7 @@ func TestCoverage_NegatedFreeText(t *testing.T) {
 
 // TestCoverage_EmptyInboxQuery tests BuildQuery with empty query
 func TestCoverage_EmptyInboxQuery(t *testing.T) {
-	query, err := BuildQuery("", 100, 10)
+	query, err := BuildQuery("", Definitions{}, 100, 10)
 	if err != nil {
 		t.Fatalf("empty inbox query failed: %v", err)
 	}
@@ -1914,7 +2090,7 @@ func TestCoverage_EmptyInboxQuery(t *testing.T) {
 // TestCoverage_MixedOperatorsAndGrouping tests mixing explicit and implicit operators
 func TestCoverage_MixedOperatorsAndGrouping(t *testing.T) {
 	// Mix of implicit AND, explicit OR, and grouping
-	query, err := BuildQuery("repo:cli (is:unread OR is:read) reason:mention", 50, 0)
+	query, err := BuildQuery("repo:cli (is:unread OR is:read) reason:mention", Definitions{}, 50, 0)
 	if err != nil {
 		t.Fatalf("mixed operators failed: %v", err)
 	}
@@ -1970,7 +2146,7 @@ func TestIntegration_TagsQuery(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query, err := BuildQuery(tt.query, 50, 0)
+			query, err := BuildQuery(tt.query, Definitions{}, 50, 0)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("BuildQuery() error = %v, wantErr %v", err, tt.wantErr)
@@ -17,6 +17,7 @@
 package parse
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -26,6 +27,12 @@ type Node interface {
 	String() string // For debugging
 }
 
+// Span is a byte range of the query string; End is exclusive
+type Span struct {
+	Start int
+	End   int
+}
+
 // BinaryExpr represents AND/OR operations
 type BinaryExpr struct {
 	Op    string // "AND" or "OR"
@@ -51,6 +58,18 @@ type Term struct {
 	Field   string
 	Values  []string
 	Negated bool // For -field:value syntax (deprecated in favor of NOT)
+
+	FieldSpan  Span   // Where the field name appears in the query
+	ValueSpans []Span // Where each value appears, parallel to Values
+}
+
+// Span returns the range covering the whole term
+func (t *Term) Span() Span {
+	span := t.FieldSpan
+	if len(t.ValueSpans) > 0 {
+		span.End = t.ValueSpans[len(t.ValueSpans)-1].End
+	}
+	return span
 }
 
 func (t *Term) String() string {
@@ -64,9 +83,30 @@ func (t *Term) String() string {
 	return fmt.Sprintf("%s%s:%s", prefix, t.Field, strings.Join(t.Values, ","))
 }
 
+// Comparison represents field:>value, field:<=value and field:low..high
+type Comparison struct {
+	Field string
+	Op    string // ">", ">=", "<", "<=", or ".." for ranges
+	Value string // Compared value, or the lower bound for ranges
+	Upper string // Upper bound for ".." ranges
+
+	FieldSpan Span // Where the field name appears in the query
+	ValueSpan Span // Where the operator and value(s) appear
+}
+
+func (c *Comparison) String() string {
+	if c.Op == OpRange {
+		return fmt.Sprintf("%s:%s..%s", c.Field, c.Value, c.Upper)
+	}
+	return fmt.Sprintf("%s:%s%s", c.Field, c.Op, c.Value)
+}
+
 // FreeText represents unstructured search text
 type FreeText struct {
-	Text string
+	Text   string
+	Quoted bool // Quoted text is searched as a phrase
+
+	TextSpan Span // Where the text (including any quotes) appears in the query
 }
 
 func (f *FreeText) String() string {
@@ -81,3 +121,57 @@ type ParenExpr struct {
 func (p *ParenExpr) String() string {
 	return fmt.Sprintf("(%s)", p.Expr.String())
 }
+
+// nodeJSON is the JSON form of an AST node, used to show how a query was parsed
+type nodeJSON struct {
+	Type   string   `json:"type"`
+	Op     string   `json:"op,omitempty"`
+	Field  string   `json:"field,omitempty"`
+	Values []string `json:"values,omitempty"`
+	Value  string   `json:"value,omitempty"`
+	Upper  string   `json:"upper,omitempty"`
+	Text   string   `json:"text,omitempty"`
+	Quoted bool     `json:"quoted,omitempty"`
+	Left   Node     `json:"left,omitempty"`
+	Right  Node     `json:"right,omitempty"`
+	Expr   Node     `json:"expr,omitempty"`
+}
+
+// MarshalJSON encodes the expression as {"type": "binary", "op": ..., "left": ..., "right": ...}
+func (b *BinaryExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeJSON{Type: "binary", Op: b.Op, Left: b.Left, Right: b.Right})
+}
+
+// MarshalJSON encodes the expression as {"type": "not", "expr": ...}
+func (n *NotExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeJSON{Type: "not", Expr: n.Expr})
+}
+
+// MarshalJSON encodes the term as {"type": "term", "field": ..., "values": [...]}
+func (t *Term) MarshalJSON() ([]byte, error) {
+	if t.Negated {
+		return json.Marshal(nodeJSON{Type: "not", Expr: &Term{Field: t.Field, Values: t.Values}})
+	}
+	return json.Marshal(nodeJSON{Type: "term", Field: t.Field, Values: t.Values})
+}
+
+// MarshalJSON encodes the comparison as {"type": "comparison", "field": ..., "op": ..., ...}
+func (c *Comparison) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeJSON{
+		Type:  "comparison",
+		Field: c.Field,
+		Op:    c.Op,
+		Value: c.Value,
+		Upper: c.Upper,
+	})
+}
+
+// MarshalJSON encodes the text as {"type": "text", "text": ..., "quoted": ...}
+func (f *FreeText) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeJSON{Type: "text", Text: f.Text, Quoted: f.Quoted})
+}
+
+// MarshalJSON encodes the group as {"type": "group", "expr": ...}
+func (p *ParenExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeJSON{Type: "group", Expr: p.Expr})
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// CompletionKind says what the text at the cursor completes to
+type CompletionKind int
+
+const (
+	// CompleteNone means there is nothing sensible to complete at the cursor
+	CompleteNone CompletionKind = iota
+	// CompleteField completes a field name (repo:, is:, ...)
+	CompleteField
+	// CompleteValue completes a value for the field in CompletionContext.Field
+	CompleteValue
+)
+
+// CompletionContext describes what is being typed at a cursor position.
+// Start and End are byte offsets of the text a completion should replace.
+type CompletionContext struct {
+	Kind   CompletionKind
+	Field  string // Field whose value is being typed (CompleteValue only)
+	Prefix string // Text typed so far, before the cursor
+	Start  int
+	End    int
+}
+
+// CompletionContextAt works out what is being typed at the cursor (a byte offset) by
+// tokenizing the query up to it: a word after field: or a comma is a value, any other
+// word, or the start of a new expression, is a field name.
+func CompletionContextAt(input string, cursor int) CompletionContext {
+	cursor = min(max(cursor, 0), len(input))
+	before := input[:cursor]
+
+	// A completion replaces the whole word under the cursor, not just the part before it
+	end := cursor
+	for end < len(input) && isWordChar(rune(input[end])) {
+		end++
+	}
+
+	tokens, err := NewLexer(before).Tokenize()
+	if err != nil {
+		return quotedCompletionContext(before, err)
+	}
+	tokens = tokens[:len(tokens)-1] // Drop EOF
+
+	n := len(tokens)
+	if n > 0 && tokens[n-1].End == cursor {
+		last := tokens[n-1]
+		switch last.Type {
+		case TokenFreeText:
+			ctx := CompletionContext{
+				Kind:   CompleteField,
+				Prefix: last.Value,
+				Start:  last.Pos,
+				End:    end,
+			}
+			if field, ok := valueField(tokens[:n-1]); ok {
+				ctx.Kind, ctx.Field = CompleteValue, field
+			}
+			return ctx
+		case TokenColon, TokenComma:
+			if field, ok := valueField(tokens); ok {
+				return CompletionContext{Kind: CompleteValue, Field: field, Start: cursor, End: end}
+			}
+			return CompletionContext{}
+		case TokenLParen, TokenNot:
+			// A new expression starts right here
+		default:
+			return CompletionContext{}
+		}
+	}
+
+	return CompletionContext{Kind: CompleteField, Start: cursor, End: end}
+}
+
+// quotedCompletionContext handles a cursor inside an unterminated quoted value like repo:"cli/c
+func quotedCompletionContext(before string, err error) CompletionContext {
+	var queryErr *QueryError
+	if !errors.As(err, &queryErr) || queryErr.Code != CodeUnterminatedString {
+		return CompletionContext{}
+	}
+
+	tokens, err := NewLexer(before[:queryErr.Start]).Tokenize()
+	if err != nil {
+		return CompletionContext{}
+	}
+
+	field, ok := valueField(tokens[:len(tokens)-1])
+	if !ok {
+		return CompletionContext{}
+	}
+	return CompletionContext{
+		Kind:   CompleteValue,
+		Field:  field,
+		Prefix: before[queryErr.Start+1:],
+		Start:  queryErr.Start,
+		End:    len(before),
+	}
+}
+
+// valueField returns the field whose value list the tokens end in: "field:" or "field:a,b,"
+func valueField(tokens []Token) (string, bool) {
+	i := len(tokens) - 1
+	for i >= 0 && tokens[i].Type == TokenComma {
+		i-- // The comma's value
+		if i < 0 || (tokens[i].Type != TokenFreeText && tokens[i].Type != TokenValue) {
+			return "", false
+		}
+		i--
+	}
+
+	if i < 1 || tokens[i].Type != TokenColon || tokens[i-1].Type != TokenFreeText {
+		return "", false
+	}
+	return tokens[i-1].Value, true
+}
+
+// FieldCompletions returns the field names starting with prefix (case-insensitive)
+func FieldCompletions(prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	var names []string
+	for _, name := range FieldNames() {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// StaticValueCompletions returns the fixed values a field accepts that start with prefix.
+// Fields whose values come from data (repo:, author:, tags:, ...) return nil.
+func StaticValueCompletions(field, prefix string) []string {
+	spec, ok := LookupField(field)
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	switch spec.Kind {
+	case FieldIn:
+		values = InValues
+	case FieldIs:
+		values = IsValues
+	case FieldBoolean, FieldSnoozed:
+		values = []string{"true", "false"}
+	case FieldMerged:
+		values = []string{"true", "false", "merged", "unmerged"}
+	case FieldTime:
+		values = []string{"today", "yesterday"}
+	case FieldSort:
+		values = sortKeyVariants()
+	case FieldEnum:
+		values = spec.Values
+	case FieldArray:
+		if !spec.MatchesViewer() {
+			return nil
+		}
+		values = []string{"@me"}
+	case FieldNo, FieldHas:
+		values = spec.Values
+	case FieldContains, FieldPrefix, FieldEquals, FieldTags, FieldView, FieldNumber, FieldAccount:
+		return nil
+	}
+
+	prefix = strings.ToLower(prefix)
+	return slices.DeleteFunc(slices.Clone(values), func(value string) bool {
+		return !strings.HasPrefix(value, prefix)
+	})
+}
+
+// QuoteValue quotes a value that wouldn't lex back as a single unquoted word
+func QuoteValue(value string) string {
+	plain := value != "" &&
+		!strings.HasPrefix(value, "-") &&
+		!strings.ContainsFunc(value, func(r rune) bool { return !isWordChar(r) }) &&
+		NewLexer(value).classifyWord(value, 0).Type == TokenFreeText
+	if plain {
+		return value
+	}
+	return quote(value)
+}
+
+// quote wraps a value in double quotes, escaping backslashes and quotes
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompletionContextAt(t *testing.T) {
+	// The cursor is marked with | in each input
+	tests := []struct {
+		input  string
+		kind   CompletionKind
+		field  string
+		prefix string
+		span   string // Text the completion replaces
+	}{
+		{input: "|", kind: CompleteField},
+		{input: "re|", kind: CompleteField, prefix: "re", span: "re"},
+		{input: "is:unread re|", kind: CompleteField, prefix: "re", span: "re"},
+		{input: "rep|o:cli", kind: CompleteField, prefix: "rep", span: "repo"},
+		{input: "is:unread |", kind: CompleteField},
+		{input: "(|", kind: CompleteField},
+		{input: "-au|", kind: CompleteField, prefix: "au", span: "au"},
+		{input: "NOT |", kind: CompleteField},
+		{input: "repo:|", kind: CompleteValue, field: "repo"},
+		{input: "repo:cl|", kind: CompleteValue, field: "repo", prefix: "cl", span: "cl"},
+		{input: "repo:cl| is:unread", kind: CompleteValue, field: "repo", prefix: "cl", span: "cl"},
+		{input: "author:a,b|", kind: CompleteValue, field: "author", prefix: "b", span: "b"},
+		{input: "author:a,|", kind: CompleteValue, field: "author"},
+		{input: `repo:"a b",c|`, kind: CompleteValue, field: "repo", prefix: "c", span: "c"},
+		{
+			input:  `repo:"cli/c|`,
+			kind:   CompleteValue,
+			field:  "repo",
+			prefix: "cli/c",
+			span:   `"cli/c`,
+		},
+		{input: `"fix bu|`, kind: CompleteNone},
+		{input: `repo:"cli"|`, kind: CompleteNone},
+		{input: "updated:>|", kind: CompleteNone},
+		{input: "repo:cli)|", kind: CompleteNone},
+		{input: "repo:cli !|", kind: CompleteNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cursor := strings.Index(tt.input, "|")
+			input := strings.Replace(tt.input, "|", "", 1)
+
+			got := CompletionContextAt(input, cursor)
+			if got.Kind != tt.kind {
+				t.Fatalf("Kind = %d, want %d", got.Kind, tt.kind)
+			}
+			if tt.kind == CompleteNone {
+				return
+			}
+			if got.Field != tt.field {
+				t.Errorf("Field = %q, want %q", got.Field, tt.field)
+			}
+			if got.Prefix != tt.prefix {
+				t.Errorf("Prefix = %q, want %q", got.Prefix, tt.prefix)
+			}
+			if span := input[got.Start:got.End]; span != tt.span {
+				t.Errorf("span = %q, want %q", span, tt.span)
+			}
+		})
+	}
+}
+
+func TestCompletionContextAt_CursorOutOfRange(t *testing.T) {
+	got := CompletionContextAt("is:unread", 100)
+	if got.Kind != CompleteValue || got.Field != "is" {
+		t.Errorf("expected the cursor to be clamped to the end, got %+v", got)
+	}
+	got = CompletionContextAt("is:unread", -5)
+	if got.Kind != CompleteField || got.End != 2 {
+		t.Errorf("expected the cursor to be clamped to the start, got %+v", got)
+	}
+}
+
+func TestFieldCompletions(t *testing.T) {
+	expected := []string{
+		"read", "reason", "release", "repo", "repository", "review", "review-requested",
+		"review_requested",
+	}
+	if got := FieldCompletions("re"); !slices.Equal(got, expected) {
+		t.Errorf("FieldCompletions(re) = %v", got)
+	}
+	if got := FieldCompletions("ZZ"); len(got) != 0 {
+		t.Errorf("FieldCompletions(ZZ) = %v, want none", got)
+	}
+	if got := FieldCompletions(""); len(got) != len(FieldNames()) {
+		t.Errorf("expected every field for an empty prefix, got %d", len(got))
+	}
+}
+
+func TestStaticValueCompletions(t *testing.T) {
+	tests := []struct {
+		field    string
+		prefix   string
+		expected []string
+	}{
+		{"in", "a", []string{"archive", "anywhere"}},
+		{"IS", "s", []string{"snoozed", "starred", "stale"}},
+		{"read", "", []string{"true", "false"}},
+		{"merged", "UN", []string{"unmerged"}},
+		{"sort", "updated", []string{"updated", "updated-asc", "updated-desc"}},
+		{"no", "", []string{
+			"assignee", "author", "category", "conclusion", "label", "milestone", "reason",
+			"release", "severity", "sha", "tags",
+		}},
+		{"severity", "", []string{"low", "medium", "high", "critical"}},
+		{"conclusion", "s", []string{"skipped", "stale", "startup_failure", "success"}},
+		{"has", "a", []string{"assignee", "author"}},
+		{"number", "", nil},
+		{"assignee", "@", []string{"@me"}},
+		{"label", "", nil},
+		{"repo", "", nil},
+		{"unknown", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+":"+tt.prefix, func(t *testing.T) {
+			got := StaticValueCompletions(tt.field, tt.prefix)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf(
+					"StaticValueCompletions(%q, %q) = %v, want %v",
+					tt.field,
+					tt.prefix,
+					got,
+					tt.expected,
+				)
+			}
+		})
+	}
+}
+
+func TestQuoteValue(t *testing.T) {
+	tests := map[string]string{
+		"cli/cli":    "cli/cli",
+		"has space":  `"has space"`,
+		`say "hi"`:   `"say \"hi\""`,
+		"-leading":   `"-leading"`,
+		"OR":         `"OR"`,
+		"":           `""`,
+		"team:infra": `"team:infra"`,
+	}
+
+	for input, expected := range tests {
+		if got := QuoteValue(input); got != expected {
+			t.Errorf("QuoteValue(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrorCode identifies the kind of problem a QueryError reports
+type ErrorCode string
+
+// Error codes reported by the lexer, parser, expander and validator
+const (
+	CodeUnexpectedCharacter ErrorCode = "unexpected_character"
+	CodeUnterminatedString  ErrorCode = "unterminated_string"
+	CodeUnexpectedToken     ErrorCode = "unexpected_token"
+	CodeMissingParen        ErrorCode = "missing_paren"
+	CodeExpectedColon       ErrorCode = "expected_colon"
+	CodeExpectedValue       ErrorCode = "expected_value"
+	CodeUnknownField        ErrorCode = "unknown_field"
+	CodeInvalidValue        ErrorCode = "invalid_value"
+	CodeInvalidComparison   ErrorCode = "invalid_comparison"
+	CodeInvalidSort         ErrorCode = "invalid_sort"
+	CodeInvalidRegex        ErrorCode = "invalid_regex"
+	CodeUnknownView         ErrorCode = "unknown_view"
+	CodeUnknownMacro        ErrorCode = "unknown_macro"
+	CodeReferenceCycle      ErrorCode = "reference_cycle"
+	CodeInvalidReference    ErrorCode = "invalid_reference"
+)
+
+// maxSuggestions caps the number of "did you mean" suggestions per error
+const maxSuggestions = 3
+
+// QueryError describes a problem at a specific span of the query string.
+// Start and End are byte offsets (End is exclusive), so clients can underline
+// the offending text. errors.Is matches the sentinel the error was created for.
+type QueryError struct {
+	Code        ErrorCode `json:"code"`
+	Message     string    `json:"message"`
+	Start       int       `json:"start"`
+	End         int       `json:"end"`
+	Expected    []string  `json:"expected,omitempty"`
+	Suggestions []string  `json:"suggestions,omitempty"`
+	err         error
+}
+
+// Error returns the human-readable message
+func (e *QueryError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the sentinel error
+func (e *QueryError) Unwrap() error {
+	return e.err
+}
+
+// QueryErrors returns every QueryError in err's tree, in order
+func QueryErrors(err error) []*QueryError {
+	var result []*QueryError
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case nil:
+		case *QueryError:
+			result = append(result, e)
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		default:
+			walk(errors.Unwrap(err))
+		}
+	}
+	walk(err)
+	return result
+}
+
+// Suggest returns the candidates closest to input by edit distance,
+// best first, skipping any that are too different to be a likely typo
+func Suggest(input string, candidates []string) []string {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return nil
+	}
+
+	// Allow one edit for short words and roughly one per three characters beyond that
+	maxDistance := max(1, len(input)/3)
+
+	type match struct {
+		value    string
+		distance int
+	}
+	var matches []match
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if seen[candidate] || candidate == input {
+			continue
+		}
+		seen[candidate] = true
+		if d := editDistance(input, candidate); d <= maxDistance {
+			matches = append(matches, match{value: candidate, distance: d})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].value < matches[j].value
+	})
+
+	var suggestions []string
+	for _, m := range matches {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, m.value)
+	}
+	return suggestions
+}
+
+// editDistance returns the optimal string alignment distance between two strings:
+// the number of insertions, deletions, substitutions and adjacent swaps needed
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	d := make([][]int, len(ar)+1)
+	for i := range d {
+		d[i] = make([]int, len(br)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ar)][len(br)]
+}
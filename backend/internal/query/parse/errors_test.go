@@ -0,0 +1,272 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// queryErrors runs the lexer, parser and validator and returns the reported errors
+func queryErrors(t *testing.T, input string) ([]*QueryError, error) {
+	t.Helper()
+
+	tokens, err := NewLexer(input).Tokenize()
+	if err != nil {
+		return QueryErrors(err), err
+	}
+	ast, err := NewParser(tokens).Parse()
+	if err != nil {
+		return QueryErrors(err), err
+	}
+	err = NewValidator().Validate(ast)
+	return QueryErrors(err), err
+}
+
+func TestQueryErrors_Spans(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		code     ErrorCode
+		sentinel error
+		span     string // Text the error should underline
+	}{
+		{
+			name:     "unexpected character",
+			input:    "repo:cli !",
+			code:     CodeUnexpectedCharacter,
+			sentinel: ErrUnexpectedCharacter,
+			span:     "!",
+		},
+		{
+			name:     "unterminated quote",
+			input:    `repo:cli "fix bug`,
+			code:     CodeUnterminatedString,
+			sentinel: ErrUnterminatedQuotedString,
+			span:     `"fix bug`,
+		},
+		{
+			name:     "missing closing paren",
+			input:    "(repo:cli",
+			code:     CodeMissingParen,
+			sentinel: ErrExpectedClosingParen,
+			span:     "",
+		},
+		{
+			name:     "stray closing paren",
+			input:    "repo:cli )",
+			code:     CodeUnexpectedToken,
+			sentinel: ErrUnexpectedTokenAfterExpression,
+			span:     ")",
+		},
+		{
+			name:     "missing value",
+			input:    "repo: OR is:unread",
+			code:     CodeExpectedValue,
+			sentinel: ErrExpectedValue,
+			span:     "OR",
+		},
+		{
+			name:     "unknown field",
+			input:    "is:unread reasn:mention",
+			code:     CodeUnknownField,
+			sentinel: ErrUnknownField,
+			span:     "reasn",
+		},
+		{
+			name:     "invalid second value",
+			input:    "is:unread,stared",
+			code:     CodeInvalidValue,
+			sentinel: ErrInvalidFieldValue,
+			span:     "stared",
+		},
+		{
+			name:     "quoted invalid value",
+			input:    `in:"inbx"`,
+			code:     CodeInvalidValue,
+			sentinel: ErrInvalidFieldValue,
+			span:     `"inbx"`,
+		},
+		{
+			name:     "comparison on text field",
+			input:    "repo:>cli",
+			code:     CodeInvalidComparison,
+			sentinel: ErrInvalidComparison,
+			span:     "repo:>cli",
+		},
+		{
+			name:     "invalid time value",
+			input:    "updated:>someday",
+			code:     CodeInvalidValue,
+			sentinel: ErrInvalidComparison,
+			span:     ">someday",
+		},
+		{
+			name:     "invalid number comparison",
+			input:    "comments:>lots",
+			code:     CodeInvalidValue,
+			sentinel: ErrInvalidComparison,
+			span:     ">lots",
+		},
+		{
+			name:     "invalid number value",
+			input:    "is:unread number:12a",
+			code:     CodeInvalidValue,
+			sentinel: ErrInvalidFieldValue,
+			span:     "12a",
+		},
+		{
+			name:     "unsupported has: value",
+			input:    "has:state",
+			code:     CodeInvalidValue,
+			sentinel: ErrInvalidFieldValue,
+			span:     "state",
+		},
+		{
+			name:     "sort under OR",
+			input:    "is:unread OR sort:repo",
+			code:     CodeInvalidSort,
+			sentinel: ErrInvalidSort,
+			span:     "sort:repo",
+		},
+		{
+			name:     "relevance without free text",
+			input:    "is:unread sort:relevance",
+			code:     CodeInvalidSort,
+			sentinel: ErrInvalidSort,
+			span:     "sort:relevance",
+		},
+		{
+			name:     "invalid regex",
+			input:    "is:unread author:/[bot/",
+			code:     CodeInvalidRegex,
+			sentinel: ErrInvalidRegex,
+			span:     "/[bot/",
+		},
+		{
+			name:     "regex Postgres reads differently",
+			input:    `is:unread author:/\p{Greek}/`,
+			code:     CodeInvalidRegex,
+			sentinel: ErrInvalidRegex,
+			span:     `/\p{Greek}/`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, err := queryErrors(t, tt.input)
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 query error, got %d (err: %v)", len(errs), err)
+			}
+
+			got := errs[0]
+			if got.Code != tt.code {
+				t.Errorf("Code = %q, want %q", got.Code, tt.code)
+			}
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("expected error to match %v, got %v", tt.sentinel, err)
+			}
+			if span := tt.input[got.Start:got.End]; span != tt.span {
+				t.Errorf("span = %q, want %q", span, tt.span)
+			}
+		})
+	}
+}
+
+func TestQueryErrors_Multiple(t *testing.T) {
+	errs, err := queryErrors(t, "reasn:mention is:stared")
+	if !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 query errors, got %d", len(errs))
+	}
+	if errs[0].Code != CodeUnknownField || errs[1].Code != CodeInvalidValue {
+		t.Errorf("unexpected codes: %q, %q", errs[0].Code, errs[1].Code)
+	}
+}
+
+func TestQueryErrors_Suggestions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+		message  string
+	}{
+		{"reasn:mention", []string{"reason"}, "unknown field: reasn (did you mean reason:?)"},
+		{"is:unred", []string{"unread"}, "did you mean unread?"},
+		{"in:archve", []string{"archive"}, "did you mean archive?"},
+		{"read:ture", []string{"true"}, "did you mean true?"},
+		{"sort:updatd-asc", []string{"updated-asc", "updated-desc"}, "invalid sort key: updatd-asc"},
+		{"review:aproved", []string{"approved"}, "invalid value for review: aproved"},
+		{"checks:passng", []string{"passing"}, "did you mean passing?"},
+		{"no:asignee", []string{"assignee"}, "invalid value for no: asignee"},
+		{"xyzzy:1", nil, "unknown field: xyzzy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			errs, _ := queryErrors(t, tt.input)
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 query error, got %d", len(errs))
+			}
+			if !slices.Equal(errs[0].Suggestions, tt.expected) {
+				t.Errorf("Suggestions = %v, want %v", errs[0].Suggestions, tt.expected)
+			}
+			if !strings.Contains(errs[0].Message, tt.message) {
+				t.Errorf("Message = %q, want it to contain %q", errs[0].Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestQueryErrors_Expected(t *testing.T) {
+	errs, _ := queryErrors(t, "(repo:cli")
+	if len(errs) != 1 || !slices.Equal(errs[0].Expected, []string{")"}) {
+		t.Fatalf("expected a missing ')' error, got %+v", errs)
+	}
+
+	errs, _ = queryErrors(t, "in:nowhere")
+	if len(errs) != 1 || !slices.Equal(errs[0].Expected, InValues) {
+		t.Fatalf("expected in: values to be listed, got %+v", errs)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"repo", "reason", "read", "author", "archived"}
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"reasn", []string{"reason"}},
+		{"rep", []string{"repo"}},
+		{"raed", []string{"read"}}, // Swapped letters count as one edit
+		{"REPO", nil},              // Exact matches need no suggestion
+		{"autor", []string{"author"}},
+		{"zzz", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Suggest(tt.input, candidates); !slices.Equal(got, tt.expected) {
+				t.Errorf("Suggest(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Error definitions
+var (
+	ErrExpansionFailed  = errors.New("reference expansion failed")
+	ErrUnknownView      = errors.New("unknown view")
+	ErrUnknownMacro     = errors.New("unknown macro")
+	ErrReferenceCycle   = errors.New("reference cycle")
+	ErrInvalidReference = errors.New("invalid referenced query")
+)
+
+// ViewField is the field name of view: terms
+const ViewField = "view"
+
+// MacroPrefix starts a macro reference in free text (@bots)
+const MacroPrefix = "@"
+
+// viewerName is the name in @me, which means the token's user and is never a macro
+const viewerName = "me"
+
+// Definitions holds the saved queries that view: terms and @macro references name
+type Definitions struct {
+	Views  map[string]string // Query of each view, by slug
+	Macros map[string]string // Query of each macro, by name (without the @)
+}
+
+// MacroName returns the name of the macro a free-text node refers to, if any.
+// Quoted text is always searched, so "@bots" looks for the literal text, as is @me.
+func MacroName(node *FreeText) (string, bool) {
+	if node.Quoted || len(node.Text) <= len(MacroPrefix) ||
+		!strings.HasPrefix(node.Text, MacroPrefix) {
+		return "", false
+	}
+	name := strings.ToLower(node.Text[len(MacroPrefix):])
+	if name == viewerName {
+		return "", false
+	}
+	return name, true
+}
+
+// References returns the view slugs and macro names an AST refers to directly, in query order
+func References(node Node) (views, macros []string) {
+	switch n := node.(type) {
+	case *Term:
+		if isViewTerm(n) {
+			for _, value := range n.Values {
+				views = append(views, normalizeReference(value))
+			}
+		}
+	case *FreeText:
+		if name, ok := MacroName(n); ok {
+			macros = append(macros, name)
+		}
+	case *BinaryExpr:
+		leftViews, leftMacros := References(n.Left)
+		rightViews, rightMacros := References(n.Right)
+		views = append(leftViews, rightViews...)
+		macros = append(leftMacros, rightMacros...)
+	case *NotExpr:
+		return References(n.Expr)
+	case *ParenExpr:
+		return References(n.Expr)
+	}
+	return views, macros
+}
+
+// HasReferences reports whether an AST contains view: terms or @macro references
+func HasReferences(node Node) bool {
+	views, macros := References(node)
+	return len(views) > 0 || len(macros) > 0
+}
+
+// Expand replaces view: terms and @macro references with the ASTs of the queries they name,
+// so the SQL builder and evaluator never see them. Each referenced query is parsed, expanded
+// and validated on its own, and any problem with it (including a reference cycle) is
+// reported at the reference. Sort terms in referenced queries are dropped: a view's
+// ordering doesn't apply where it is used as a filter.
+func Expand(node Node, defs Definitions) (Node, error) {
+	return expand(node, defs, nil)
+}
+
+// expander inlines references, tracking the chain being expanded to detect cycles
+type expander struct {
+	defs   Definitions
+	stack  []string // References being expanded, outermost first (view:a, @bots, ...)
+	errors []*QueryError
+}
+
+func expand(node Node, defs Definitions, stack []string) (Node, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	e := &expander{defs: defs, stack: stack}
+	expanded := e.expandNode(node)
+	if len(e.errors) > 0 {
+		errs := []error{ErrExpansionFailed}
+		for _, err := range e.errors {
+			errs = append(errs, err)
+		}
+		return nil, errors.Join(errs...)
+	}
+	return expanded, nil
+}
+
+// addError records an expansion error and returns it so callers can add details
+func (e *expander) addError(sentinel error, code ErrorCode, span Span, message string) *QueryError {
+	err := &QueryError{
+		Code:    code,
+		Message: message,
+		Start:   span.Start,
+		End:     span.End,
+		err:     sentinel,
+	}
+	e.errors = append(e.errors, err)
+	return err
+}
+
+// expandNode returns a copy of node with its references inlined
+func (e *expander) expandNode(node Node) Node {
+	switch n := node.(type) {
+	case *BinaryExpr:
+		return &BinaryExpr{Op: n.Op, Left: e.expandNode(n.Left), Right: e.expandNode(n.Right)}
+	case *NotExpr:
+		return &NotExpr{Expr: e.expandNode(n.Expr)}
+	case *ParenExpr:
+		return &ParenExpr{Expr: e.expandNode(n.Expr)}
+	case *Term:
+		if isViewTerm(n) {
+			return e.expandView(n)
+		}
+	case *FreeText:
+		if name, ok := MacroName(n); ok {
+			return e.expandMacro(n, name)
+		}
+	}
+	return node
+}
+
+// expandView replaces view:a,b with (a's query OR b's query), negated for -view:
+func (e *expander) expandView(term *Term) Node {
+	var expanded Node
+	for i, value := range term.Values {
+		span := term.Span()
+		if i < len(term.ValueSpans) {
+			span = term.ValueSpans[i]
+		}
+
+		slug := normalizeReference(value)
+		queryStr, ok := e.defs.Views[slug]
+		if !ok {
+			message := fmt.Sprintf("unknown view: %s", slug)
+			names := sortedKeys(e.defs.Views)
+			suggestions := Suggest(slug, names)
+			if len(suggestions) > 0 {
+				message += fmt.Sprintf(" (did you mean %s:%s?)", ViewField, suggestions[0])
+			}
+			err := e.addError(ErrUnknownView, CodeUnknownView, span, message)
+			err.Expected = names
+			err.Suggestions = suggestions
+			continue
+		}
+
+		node, ok := e.resolve(ViewField+":"+slug, queryStr, span)
+		if !ok {
+			continue
+		}
+		if expanded == nil {
+			expanded = node
+		} else {
+			expanded = &BinaryExpr{Op: "OR", Left: expanded, Right: node}
+		}
+	}
+
+	if expanded == nil {
+		// Every value failed and was reported; keep the term so the tree stays whole
+		return term
+	}
+	if len(term.Values) > 1 {
+		expanded = &ParenExpr{Expr: expanded}
+	}
+	if term.Negated {
+		return &NotExpr{Expr: expanded}
+	}
+	return expanded
+}
+
+// expandMacro replaces @name with the macro's query
+func (e *expander) expandMacro(node *FreeText, name string) Node {
+	queryStr, ok := e.defs.Macros[name]
+	if !ok {
+		message := fmt.Sprintf("unknown macro: %s%s", MacroPrefix, name)
+		names := sortedKeys(e.defs.Macros)
+		suggestions := Suggest(name, names)
+		if len(suggestions) > 0 {
+			message += fmt.Sprintf(" (did you mean %s%s?)", MacroPrefix, suggestions[0])
+		}
+		err := e.addError(ErrUnknownMacro, CodeUnknownMacro, node.TextSpan, message)
+		err.Expected = names
+		err.Suggestions = suggestions
+		return node
+	}
+
+	expanded, ok := e.resolve(MacroPrefix+name, queryStr, node.TextSpan)
+	if !ok {
+		return node
+	}
+	return expanded
+}
+
+// resolve parses, expands and validates the query a reference names.
+// Returns false after reporting the problem at span if the query can't be inlined.
+func (e *expander) resolve(ref, queryStr string, span Span) (Node, bool) {
+	if idx := slices.Index(e.stack, ref); idx >= 0 {
+		cycle := append(slices.Clone(e.stack[idx:]), ref)
+		e.addError(
+			ErrReferenceCycle,
+			CodeReferenceCycle,
+			span,
+			fmt.Sprintf("reference cycle: %s", strings.Join(cycle, " -> ")),
+		)
+		return nil, false
+	}
+
+	if strings.TrimSpace(queryStr) == "" {
+		e.addError(
+			ErrInvalidReference,
+			CodeInvalidReference,
+			span,
+			fmt.Sprintf("%s has an empty query", ref),
+		)
+		return nil, false
+	}
+
+	node, err := e.parseReferenced(ref, queryStr)
+	if err != nil {
+		var messages []string
+		for _, queryErr := range QueryErrors(err) {
+			messages = append(messages, queryErr.Message)
+		}
+		if len(messages) == 0 {
+			messages = append(messages, err.Error())
+		}
+
+		// A cycle further down is still a cycle; report it as one, with the full chain
+		if errors.Is(err, ErrReferenceCycle) {
+			e.addError(ErrReferenceCycle, CodeReferenceCycle, span, strings.Join(messages, "; "))
+			return nil, false
+		}
+		e.addError(
+			ErrInvalidReference,
+			CodeInvalidReference,
+			span,
+			fmt.Sprintf("%s has an invalid query: %s", ref, strings.Join(messages, "; ")),
+		)
+		return nil, false
+	}
+
+	filter, _ := SplitSort(node)
+	if filter == nil {
+		e.addError(
+			ErrInvalidReference,
+			CodeInvalidReference,
+			span,
+			fmt.Sprintf("%s only sorts and can't be used as a filter", ref),
+		)
+		return nil, false
+	}
+
+	// Spans of the inlined nodes point into another query; move them to the reference
+	respan(filter, span)
+	return &ParenExpr{Expr: filter}, true
+}
+
+// parseReferenced parses, expands and validates a referenced query on its own
+func (e *expander) parseReferenced(ref, queryStr string) (Node, error) {
+	tokens, err := NewLexer(queryStr).Tokenize()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, err := NewParser(tokens).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, err = expand(ast, e.defs, append(slices.Clone(e.stack), ref))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := NewValidator().Validate(ast); err != nil {
+		return nil, err
+	}
+	return ast, nil
+}
+
+// respan points every span in an inlined AST at the reference it came from
+func respan(node Node, span Span) {
+	switch n := node.(type) {
+	case *Term:
+		n.FieldSpan = span
+		for i := range n.ValueSpans {
+			n.ValueSpans[i] = span
+		}
+	case *Comparison:
+		n.FieldSpan = span
+		n.ValueSpan = span
+	case *FreeText:
+		n.TextSpan = span
+	case *BinaryExpr:
+		respan(n.Left, span)
+		respan(n.Right, span)
+	case *NotExpr:
+		respan(n.Expr, span)
+	case *ParenExpr:
+		respan(n.Expr, span)
+	}
+}
+
+// isViewTerm checks if a node is a view: term
+func isViewTerm(term *Term) bool {
+	return strings.EqualFold(strings.TrimSpace(term.Field), ViewField)
+}
+
+// normalizeReference lowercases a view slug or macro name, which are matched case-insensitively
+func normalizeReference(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// sortedKeys returns the names in a definitions map, sorted
+func sortedKeys(definitions map[string]string) []string {
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// expandTestDefinitions are the views and macros the expansion tests reference
+var expandTestDefinitions = Definitions{
+	Views: map[string]string{
+		"inbox":     "in:inbox",
+		"cli":       "repo:cli/cli is:unread",
+		"sorted":    "is:starred sort:created-asc",
+		"sort-only": "sort:updated",
+		"loop-a":    "view:loop-b",
+		"loop-b":    "is:unread OR view:loop-a",
+		"broken":    "is:bogus",
+		"empty":     "",
+	},
+	Macros: map[string]string{
+		"bots": "author:*[bot]",
+		"team": "@bots OR view:cli",
+		"self": "@self",
+	},
+}
+
+// expandQuery parses input and expands it with expandTestDefinitions
+func expandQuery(t *testing.T, input string) (Node, error) {
+	t.Helper()
+
+	tokens, err := NewLexer(input).Tokenize()
+	if err != nil {
+		t.Fatalf("Tokenize(%q) error = %v", input, err)
+	}
+	ast, err := NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", input, err)
+	}
+	return Expand(ast, expandTestDefinitions)
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"view:inbox", "(in:inbox)"},
+		{"view:Inbox", "(in:inbox)"},
+		{"-view:inbox", "NOT((in:inbox))"},
+		{"NOT view:inbox", "NOT((in:inbox))"},
+		{"-@bots", "NOT((author:*[bot]))"},
+		{"view:inbox,cli", "(((in:inbox) OR ((repo:cli/cli AND is:unread))))"},
+		{"is:unread view:inbox", "(is:unread AND (in:inbox))"},
+		{"view:sorted", "(is:starred)"},
+		{"@bots", "(author:*[bot])"},
+		{"@BOTS fix", `((author:*[bot]) AND FREE("fix"))`},
+		{"@team", "(((author:*[bot]) OR ((repo:cli/cli AND is:unread))))"},
+		{`"@bots"`, `FREE("@bots")`},
+		{"@", `FREE("@")`},
+		{"@Me", `FREE("@Me")`},
+		{"is:unread", "is:unread"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := expandQuery(t, tt.input)
+			if err != nil {
+				t.Fatalf("Expand(%q) error = %v", tt.input, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Expand(%q) = %s, want %s", tt.input, got.String(), tt.want)
+			}
+			if err := NewValidator().Validate(got); err != nil {
+				t.Errorf("Validate(Expand(%q)) error = %v", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestExpand_Errors(t *testing.T) {
+	tests := []struct {
+		input       string
+		sentinel    error
+		code        ErrorCode
+		message     string
+		start, end  int
+		suggestions []string
+	}{
+		{
+			input:       "is:unread view:inbx",
+			sentinel:    ErrUnknownView,
+			code:        CodeUnknownView,
+			message:     "unknown view: inbx (did you mean view:inbox?)",
+			start:       15,
+			end:         19,
+			suggestions: []string{"inbox"},
+		},
+		{
+			input:       "@bot",
+			sentinel:    ErrUnknownMacro,
+			code:        CodeUnknownMacro,
+			message:     "unknown macro: @bot (did you mean @bots?)",
+			start:       0,
+			end:         4,
+			suggestions: []string{"bots"},
+		},
+		{
+			input:    "view:loop-a",
+			sentinel: ErrReferenceCycle,
+			code:     CodeReferenceCycle,
+			message:  "reference cycle: view:loop-a -> view:loop-b -> view:loop-a",
+			start:    5,
+			end:      11,
+		},
+		{
+			input:    "fix @self",
+			sentinel: ErrReferenceCycle,
+			code:     CodeReferenceCycle,
+			message:  "reference cycle: @self -> @self",
+			start:    4,
+			end:      9,
+		},
+		{
+			input:    "view:broken",
+			sentinel: ErrInvalidReference,
+			code:     CodeInvalidReference,
+			message:  "view:broken has an invalid query: invalid value for is: operator: bogus",
+			start:    5,
+			end:      11,
+		},
+		{
+			input:    "view:empty",
+			sentinel: ErrInvalidReference,
+			code:     CodeInvalidReference,
+			message:  "view:empty has an empty query",
+			start:    5,
+			end:      10,
+		},
+		{
+			input:    "view:sort-only",
+			sentinel: ErrInvalidReference,
+			code:     CodeInvalidReference,
+			message:  "view:sort-only only sorts and can't be used as a filter",
+			start:    5,
+			end:      14,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := expandQuery(t, tt.input)
+			if !errors.Is(err, ErrExpansionFailed) || !errors.Is(err, tt.sentinel) {
+				t.Fatalf("Expand(%q) error = %v, want %v", tt.input, err, tt.sentinel)
+			}
+
+			errs := QueryErrors(err)
+			if len(errs) != 1 {
+				t.Fatalf("Expand(%q) reported %d errors, want 1: %v", tt.input, len(errs), err)
+			}
+			got := errs[0]
+			if got.Code != tt.code {
+				t.Errorf("Code = %s, want %s", got.Code, tt.code)
+			}
+			if !strings.HasPrefix(got.Message, tt.message) {
+				t.Errorf("Message = %q, want prefix %q", got.Message, tt.message)
+			}
+			if got.Start != tt.start || got.End != tt.end {
+				t.Errorf("span = [%d, %d), want [%d, %d)", got.Start, got.End, tt.start, tt.end)
+			}
+			if tt.suggestions != nil && !slices.Equal(got.Suggestions, tt.suggestions) {
+				t.Errorf("Suggestions = %v, want %v", got.Suggestions, tt.suggestions)
+			}
+		})
+	}
+}
+
+func TestExpand_ReportsEveryReference(t *testing.T) {
+	_, err := expandQuery(t, "view:nope OR @nope")
+
+	errs := QueryErrors(err)
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), err)
+	}
+	if errs[0].Code != CodeUnknownView || errs[1].Code != CodeUnknownMacro {
+		t.Errorf("codes = %s, %s, want %s, %s",
+			errs[0].Code, errs[1].Code, CodeUnknownView, CodeUnknownMacro)
+	}
+}
+
+func TestExpand_SpansPointAtReference(t *testing.T) {
+	got, err := expandQuery(t, "is:unread view:cli")
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+
+	// (is:unread AND ((repo:cli/cli AND is:unread))) - every inlined span is the view name's
+	inlined := got.(*BinaryExpr).Right.(*ParenExpr).Expr.(*BinaryExpr)
+	want := Span{Start: 15, End: 18}
+	for _, node := range []Node{inlined.Left, inlined.Right} {
+		term := node.(*Term)
+		if term.FieldSpan != want || term.ValueSpans[0] != want {
+			t.Errorf("%s spans = %v %v, want %v", term, term.FieldSpan, term.ValueSpans, want)
+		}
+	}
+}
+
+func TestReferences(t *testing.T) {
+	tokens, err := NewLexer(`view:Inbox,cli (@bots OR -view:triage) "@quoted" @me`).Tokenize()
+	if err != nil {
+		t.Fatalf("Tokenize() error = %v", err)
+	}
+	ast, err := NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	views, macros := References(ast)
+	if want := []string{"inbox", "cli", "triage"}; !slices.Equal(views, want) {
+		t.Errorf("views = %v, want %v", views, want)
+	}
+	if want := []string{"bots"}; !slices.Equal(macros, want) {
+		t.Errorf("macros = %v, want %v", macros, want)
+	}
+	if !HasReferences(ast) {
+		t.Error("HasReferences() = false, want true")
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"sort"
+	"strings"
+)
+
+// FieldKind describes how a field's values are matched.
+// The SQL builder and the in-memory evaluator both dispatch on the kind,
+// so a field behaves the same in both as long as its kind is implemented in both.
+type FieldKind int
+
+const (
+	// FieldContains is a case-insensitive contains match (ILIKE %value%)
+	FieldContains FieldKind = iota
+	// FieldPrefix matches repositories by owner (ILIKE value/%)
+	FieldPrefix
+	// FieldEquals is an exact, case-sensitive match
+	FieldEquals
+	// FieldBoolean accepts true/false, yes/no, 1/0
+	FieldBoolean
+	// FieldMerged accepts boolean values plus merged/unmerged
+	FieldMerged
+	// FieldSnoozed is true while a snooze is active
+	FieldSnoozed
+	// FieldTime matches timestamps and supports comparisons and ranges
+	FieldTime
+	// FieldIn selects lifecycle locations (inbox, archive, ...)
+	FieldIn
+	// FieldIs selects status shortcuts (unread, starred, ...)
+	FieldIs
+	// FieldTags matches tag slugs (contains)
+	FieldTags
+	// FieldSort orders results and never filters
+	FieldSort
+	// FieldEnum is an exact, case-insensitive match against a fixed set (FieldSpec.Values)
+	FieldEnum
+	// FieldArray matches when a text array column contains the value (case-insensitive)
+	FieldArray
+	// FieldNo matches when the fields named by its values are empty (FieldSpec.Values)
+	FieldNo
+	// FieldView inlines the queries of the views named by its values (see Expand)
+	FieldView
+	// FieldHas matches when the fields named by its values are set, the opposite of FieldNo
+	FieldHas
+	// FieldNumber matches integers and supports comparisons and ranges
+	FieldNumber
+	// FieldAccount matches the name of the GitHub account a notification was synced from
+	// (exact, case-insensitive)
+	FieldAccount
+)
+
+// Field columns shared by the SQL builder and the evaluator
+const (
+	ColumnRepoFullName       = "r.full_name"
+	ColumnReason             = "n.reason"
+	ColumnSubjectType        = "n.subject_type"
+	ColumnAuthorLogin        = "n.author_login"
+	ColumnSubjectState       = "n.subject_state"
+	ColumnSubjectMerged      = "n.subject_merged"
+	ColumnSubjectStateReason = "n.subject_state_reason"
+	ColumnIsRead             = "n.is_read"
+	ColumnArchived           = "n.archived"
+	ColumnMuted              = "n.muted"
+	ColumnFiltered           = "n.filtered"
+	ColumnSnoozedUntil       = "n.snoozed_until"
+	ColumnGithubUpdatedAt    = "n.github_updated_at"
+	ColumnSubjectCreatedAt   = "n.subject_created_at"
+	ColumnImportedAt         = "n.imported_at"
+	ColumnTagIDs             = "n.tag_ids"
+	ColumnSubjectDraft       = "n.subject_draft"
+	ColumnReviewDecision     = "n.subject_review_decision"
+	ColumnReviewRequested    = "n.subject_review_requested"
+	ColumnChecksStatus       = "n.subject_checks_status"
+	ColumnSubjectLabels      = "n.subject_labels"
+	ColumnSubjectMilestone   = "n.subject_milestone"
+	ColumnSubjectAssignees   = "n.subject_assignees"
+	ColumnSubjectNumber      = "n.subject_number"
+	ColumnSubjectComments    = "n.subject_comments"
+	ColumnAccountID          = "n.account_id"
+	ColumnSubjectCategory    = "n.subject_category"
+	ColumnSubjectTag         = "n.subject_tag"
+	ColumnSubjectConclusion  = "n.subject_conclusion"
+	ColumnSubjectSha         = "n.subject_sha"
+	ColumnSubjectSeverity    = "n.subject_severity"
+)
+
+// FieldSpec describes a query field
+type FieldSpec struct {
+	Name   string // Canonical field name
+	Kind   FieldKind
+	Column string   // Column the field reads ("" for in:, is:, has:, no:, sort: and view:)
+	Values []string // Accepted values of FieldEnum, FieldHas and FieldNo fields
+}
+
+// NeedsRepoJoin reports whether the field reads a repositories column
+func (f FieldSpec) NeedsRepoJoin() bool {
+	return strings.HasPrefix(f.Column, "r.")
+}
+
+// MatchesViewer reports whether the field's column marks the token's user with @me
+func (f FieldSpec) MatchesViewer() bool {
+	return f.Column == ColumnReviewRequested || f.Column == ColumnSubjectAssignees
+}
+
+// fields is the registry of every supported query field, keyed by name and alias
+var fields = map[string]FieldSpec{
+	"in":            {Name: "in", Kind: FieldIn},
+	"is":            {Name: "is", Kind: FieldIs},
+	"repo":          {Name: "repo", Kind: FieldContains, Column: ColumnRepoFullName},
+	"repository":    {Name: "repo", Kind: FieldContains, Column: ColumnRepoFullName},
+	"org":           {Name: "org", Kind: FieldPrefix, Column: ColumnRepoFullName},
+	"reason":        {Name: "reason", Kind: FieldContains, Column: ColumnReason},
+	"type":          {Name: "type", Kind: FieldContains, Column: ColumnSubjectType},
+	"subject_type":  {Name: "type", Kind: FieldContains, Column: ColumnSubjectType},
+	"author":        {Name: "author", Kind: FieldContains, Column: ColumnAuthorLogin},
+	"state":         {Name: "state", Kind: FieldEquals, Column: ColumnSubjectState},
+	"merged":        {Name: "merged", Kind: FieldMerged, Column: ColumnSubjectMerged},
+	"state_reason":  {Name: "state_reason", Kind: FieldContains, Column: ColumnSubjectStateReason},
+	"read":          {Name: "read", Kind: FieldBoolean, Column: ColumnIsRead},
+	"archived":      {Name: "archived", Kind: FieldBoolean, Column: ColumnArchived},
+	"muted":         {Name: "muted", Kind: FieldBoolean, Column: ColumnMuted},
+	"snoozed":       {Name: "snoozed", Kind: FieldSnoozed, Column: ColumnSnoozedUntil},
+	"filtered":      {Name: "filtered", Kind: FieldBoolean, Column: ColumnFiltered},
+	"tags":          {Name: "tags", Kind: FieldTags, Column: ColumnTagIDs},
+	"updated":       {Name: "updated", Kind: FieldTime, Column: ColumnGithubUpdatedAt},
+	"created":       {Name: "created", Kind: FieldTime, Column: ColumnSubjectCreatedAt},
+	"imported":      {Name: "imported", Kind: FieldTime, Column: ColumnImportedAt},
+	"snoozed_until": {Name: "snoozed_until", Kind: FieldTime, Column: ColumnSnoozedUntil},
+	SortField:       {Name: SortField, Kind: FieldSort},
+	"review": {
+		Name:   "review",
+		Kind:   FieldEnum,
+		Column: ColumnReviewDecision,
+		Values: ReviewValues,
+	},
+	"review-requested": {
+		Name:   "review-requested",
+		Kind:   FieldArray,
+		Column: ColumnReviewRequested,
+	},
+	"review_requested": {
+		Name:   "review-requested",
+		Kind:   FieldArray,
+		Column: ColumnReviewRequested,
+	},
+	"checks": {
+		Name:   "checks",
+		Kind:   FieldEnum,
+		Column: ColumnChecksStatus,
+		Values: ChecksValues,
+	},
+	"label":     {Name: "label", Kind: FieldArray, Column: ColumnSubjectLabels},
+	"milestone": {Name: "milestone", Kind: FieldContains, Column: ColumnSubjectMilestone},
+	"assignee":  {Name: "assignee", Kind: FieldArray, Column: ColumnSubjectAssignees},
+	"category":  {Name: "category", Kind: FieldContains, Column: ColumnSubjectCategory},
+	"release":   {Name: "release", Kind: FieldContains, Column: ColumnSubjectTag},
+	"sha":       {Name: "sha", Kind: FieldContains, Column: ColumnSubjectSha},
+	"conclusion": {
+		Name:   "conclusion",
+		Kind:   FieldEnum,
+		Column: ColumnSubjectConclusion,
+		Values: ConclusionValues,
+	},
+	"severity": {
+		Name:   "severity",
+		Kind:   FieldEnum,
+		Column: ColumnSubjectSeverity,
+		Values: SeverityValues,
+	},
+	"no":       {Name: "no", Kind: FieldNo, Values: NoValues},
+	"has":      {Name: "has", Kind: FieldHas, Values: NoValues},
+	"number":   {Name: "number", Kind: FieldNumber, Column: ColumnSubjectNumber},
+	"comments": {Name: "comments", Kind: FieldNumber, Column: ColumnSubjectComments},
+	"account":  {Name: "account", Kind: FieldAccount, Column: ColumnAccountID},
+	ViewField:  {Name: ViewField, Kind: FieldView},
+}
+
+// InValues are the values accepted by in:
+var InValues = []string{"inbox", "archive", "snoozed", "filtered", "anywhere"}
+
+// IsValues are the values accepted by is:
+var IsValues = []string{
+	"unread", "read", "archived", "muted", "snoozed", "starred", "filtered", "draft",
+	"answered", "stale",
+}
+
+// ReviewValues are the values accepted by review: (a pull request's review decision)
+var ReviewValues = []string{"approved", "changes_requested", "required"}
+
+// ChecksValues are the values accepted by checks: (the CI status of a pull request's head)
+var ChecksValues = []string{"failing", "passing", "pending"}
+
+// ConclusionValues are the values accepted by conclusion: (how a workflow run finished)
+var ConclusionValues = []string{
+	"action_required", "cancelled", "failure", "neutral", "skipped", "stale",
+	"startup_failure", "success", "timed_out",
+}
+
+// SeverityValues are the values accepted by severity: (a security advisory's severity)
+var SeverityValues = []string{"low", "medium", "high", "critical"}
+
+// NoValues are the fields accepted by no: and has:
+var NoValues = []string{
+	"assignee", "author", "category", "conclusion", "label", "milestone", "reason",
+	"release", "severity", "sha", "tags",
+}
+
+// LookupField returns the spec for a field name or alias (case-insensitive)
+func LookupField(name string) (FieldSpec, bool) {
+	spec, ok := fields[strings.ToLower(strings.TrimSpace(name))]
+	return spec, ok
+}
+
+// FieldNames returns every accepted field name and alias, sorted
+func FieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseBool parses a boolean query value (true/false, yes/no, 1/0)
+func ParseBool(value string) (val, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "1":
+		return true, true
+	case "false", "no", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// ParseMerged parses a merged: value, which also accepts merged/unmerged
+func ParseMerged(value string) (val, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "merged":
+		return true, true
+	case "unmerged":
+		return false, true
+	default:
+		return ParseBool(value)
+	}
+}
+
+// SupportsComparison reports whether a field kind accepts field:>value and field:low..high
+func SupportsComparison(kind FieldKind) bool {
+	return kind == FieldTime || kind == FieldNumber
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLookupField(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantName string
+		wantKind FieldKind
+		wantOK   bool
+	}{
+		{"repo", "repo", FieldContains, true},
+		{"repository", "repo", FieldContains, true},
+		{"  REPO ", "repo", FieldContains, true},
+		{"subject_type", "type", FieldContains, true},
+		{"org", "org", FieldPrefix, true},
+		{"state", "state", FieldEquals, true},
+		{"merged", "merged", FieldMerged, true},
+		{"snoozed", "snoozed", FieldSnoozed, true},
+		{"snoozed_until", "snoozed_until", FieldTime, true},
+		{"sort", "sort", FieldSort, true},
+		{"unknown", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, ok := LookupField(tt.name)
+			if ok != tt.wantOK {
+				t.Fatalf("LookupField(%q) ok = %v, want %v", tt.name, ok, tt.wantOK)
+			}
+			if ok && (spec.Name != tt.wantName || spec.Kind != tt.wantKind) {
+				t.Errorf(
+					"LookupField(%q) = {%s, %d}, want {%s, %d}",
+					tt.name,
+					spec.Name,
+					spec.Kind,
+					tt.wantName,
+					tt.wantKind,
+				)
+			}
+		})
+	}
+}
+
+func TestFieldNames(t *testing.T) {
+	names := FieldNames()
+	if !slices.IsSorted(names) {
+		t.Errorf("FieldNames() = %v, want sorted", names)
+	}
+	for _, name := range names {
+		if _, ok := LookupField(name); !ok {
+			t.Errorf("field %s should be registered", name)
+		}
+	}
+	for _, name := range []string{"repository", "state_reason", "merged"} {
+		if !slices.Contains(names, name) {
+			t.Errorf("FieldNames() should contain %s", name)
+		}
+	}
+}
+
+func TestFieldSpec_NeedsRepoJoin(t *testing.T) {
+	repo, _ := LookupField("repo")
+	org, _ := LookupField("org")
+	author, _ := LookupField("author")
+
+	if !repo.NeedsRepoJoin() || !org.NeedsRepoJoin() {
+		t.Error("repo and org should need the repository join")
+	}
+	if author.NeedsRepoJoin() {
+		t.Error("author should not need the repository join")
+	}
+}
+
+func TestParseBoolAndMerged(t *testing.T) {
+	tests := []struct {
+		value      string
+		wantBool   bool
+		wantBoolOK bool
+		wantMerged bool
+		wantOK     bool
+	}{
+		{"true", true, true, true, true},
+		{"YES", true, true, true, true},
+		{"1", true, true, true, true},
+		{"false", false, true, false, true},
+		{"no", false, true, false, true},
+		{"0", false, true, false, true},
+		{"merged", false, false, true, true},
+		{"unmerged", false, false, false, true},
+		{"maybe", false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got, ok := ParseBool(tt.value); got != tt.wantBool || ok != tt.wantBoolOK {
+				t.Errorf(
+					"ParseBool(%q) = %v, %v, want %v, %v",
+					tt.value,
+					got,
+					ok,
+					tt.wantBool,
+					tt.wantBoolOK,
+				)
+			}
+			if got, ok := ParseMerged(tt.value); got != tt.wantMerged || ok != tt.wantOK {
+				t.Errorf(
+					"ParseMerged(%q) = %v, %v, want %v, %v",
+					tt.value,
+					got,
+					ok,
+					tt.wantMerged,
+					tt.wantOK,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Operator precedence, lowest first, used to decide where Format needs parentheses
+const (
+	precOr = iota + 1
+	precAnd
+	precNot
+	precAtom
+)
+
+// Format prints an AST as a canonical query string.
+// Field aliases are replaced by their canonical names (repository: → repo:), AND is written
+// as a space, NOT as - before terms, and parentheses only appear where the tree needs them,
+// so parsing the result gives back the same tree without its redundant groups.
+func Format(node Node) string {
+	if node == nil {
+		return ""
+	}
+	text, _ := formatNode(node)
+	return text
+}
+
+// formatNode prints a node and returns the precedence of its outermost operator
+func formatNode(node Node) (string, int) {
+	switch n := node.(type) {
+	case *BinaryExpr:
+		prec, sep := precAnd, " "
+		if n.Op == "OR" {
+			prec, sep = precOr, " OR "
+		}
+		// The parser is left-associative, so a right operand of the same precedence
+		// keeps its parentheses
+		return formatOperand(n.Left, prec) + sep + formatOperand(n.Right, prec+1), prec
+	case *NotExpr:
+		if isDashNegatable(n.Expr) {
+			return "-" + formatOperand(n.Expr, precAtom), precNot
+		}
+		return "NOT " + formatOperand(n.Expr, precNot), precNot
+	case *ParenExpr:
+		return formatNode(n.Expr)
+	case *Term:
+		return formatTerm(n), precAtom
+	case *Comparison:
+		return formatComparison(n), precAtom
+	case *FreeText:
+		if n.Quoted {
+			return quote(n.Text), precAtom
+		}
+		return QuoteValue(n.Text), precAtom
+	default:
+		return "", precAtom
+	}
+}
+
+// formatOperand prints an operand, parenthesized if it binds more loosely than minPrec
+func formatOperand(node Node, minPrec int) string {
+	text, prec := formatNode(node)
+	if prec < minPrec {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+// isDashNegatable checks if NOT node can be written as -node: the lexer only reads - as
+// NOT before a letter or @, so this holds for terms, comparisons and plain words
+func isDashNegatable(node Node) bool {
+	for {
+		paren, ok := node.(*ParenExpr)
+		if !ok {
+			break
+		}
+		node = paren.Expr
+	}
+
+	var text string
+	switch n := node.(type) {
+	case *Term:
+		if n.Negated {
+			return false
+		}
+		text = n.Field
+	case *Comparison:
+		text = n.Field
+	case *FreeText:
+		if n.Quoted || QuoteValue(n.Text) != n.Text {
+			return false
+		}
+		text = n.Text
+	default:
+		return false
+	}
+
+	first, _ := utf8.DecodeRuneInString(text)
+	return unicode.IsLetter(first) || first == '@'
+}
+
+// formatTerm prints field:value1,value2 with the field's canonical name
+func formatTerm(term *Term) string {
+	values := make([]string, len(term.Values))
+	for i, value := range term.Values {
+		values[i] = formatValue(value)
+	}
+
+	prefix := ""
+	if term.Negated {
+		prefix = "-"
+	}
+	return prefix + canonicalField(term.Field) + ":" + strings.Join(values, ",")
+}
+
+// formatComparison prints field:>value or field:low..high with the field's canonical name
+func formatComparison(comparison *Comparison) string {
+	field := canonicalField(comparison.Field)
+	if comparison.Op == OpRange {
+		return field + ":" + comparison.Value + OpRange + comparison.Upper
+	}
+	return field + ":" + comparison.Op + QuoteValue(comparison.Value)
+}
+
+// formatValue prints a term value so it lexes back unchanged. Regular expressions keep their
+// /.../ form, and values that would read as a low..high range are quoted.
+func formatValue(value string) string {
+	if isRegexLiteral(value) {
+		return value
+	}
+	if _, _, ok := splitRange(value); ok {
+		return quote(value)
+	}
+	return QuoteValue(value)
+}
+
+// isRegexLiteral checks if a value can be written as-is between slashes:
+// its first unescaped slash after the opening one must be the last character
+func isRegexLiteral(value string) bool {
+	if len(value) < 2 || value[0] != '/' {
+		return false
+	}
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '/':
+			return i == len(value)-1
+		}
+	}
+	return false
+}
+
+// canonicalField returns the registry name of a field, or the field unchanged if unknown
+func canonicalField(field string) string {
+	if spec, ok := LookupField(field); ok {
+		return spec.Name
+	}
+	return field
+}
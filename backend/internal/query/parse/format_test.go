@@ -0,0 +1,212 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// parseForFormat parses input, failing the test on errors
+func parseForFormat(t *testing.T, input string) Node {
+	t.Helper()
+
+	tokens, err := NewLexer(input).Tokenize()
+	if err != nil {
+		t.Fatalf("Tokenize(%q) error = %v", input, err)
+	}
+	ast, err := NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", input, err)
+	}
+	return ast
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"is:unread", "is:unread"},
+		{"repository:cli/cli", "repo:cli/cli"},
+		{"Subject_Type:issue review_requested:@me", "type:issue review-requested:@me"},
+		{"is:unread AND repo:cli", "is:unread repo:cli"},
+		{"(is:unread) AND (repo:cli OR repo:go-gh)", "is:unread (repo:cli OR repo:go-gh)"},
+		{"((a OR b)) OR c", "a OR b OR c"},
+		{"a OR (b OR c)", "a OR (b OR c)"},
+		{"a AND b OR c", "a b OR c"},
+		{"a AND (b OR c)", "a (b OR c)"},
+		{"NOT repo:cli", "-repo:cli"},
+		{"-(is:read OR is:archived)", "NOT (is:read OR is:archived)"},
+		{"NOT NOT fix", "NOT -fix"},
+		{"-@bots", "-@bots"},
+		{`NOT "fix bug"`, `NOT "fix bug"`},
+		{`"fix bug" crash`, `"fix bug" crash`},
+		{`label:"good first issue",bug`, `label:"good first issue",bug`},
+		{`title:"say \"hi\""`, `title:"say \"hi\""`},
+		{`author:/\[bot\]$/`, `author:/\[bot\]$/`},
+		{`repo:"/a b/"`, `repo:/a b/`},
+		{`repo:cli/* author:*[bot]`, `repo:cli/* author:*[bot]`},
+		{
+			`updated:>=7d created:2024-01-01..2024-01-31`,
+			`updated:>=7d created:2024-01-01..2024-01-31`,
+		},
+		{`number:"1..5"`, `number:"1..5"`},
+		{`repo:"AND"`, `repo:"AND"`},
+		{"in:inbox sort:updated-asc", "in:inbox sort:updated-asc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Format(parseForFormat(t, tt.input))
+			if got != tt.want {
+				t.Errorf("Format(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// normalizeForFormat rewrites an AST into the form Format preserves:
+// no groups, canonical field names and negated terms as NOT expressions
+func normalizeForFormat(node Node) Node {
+	switch n := node.(type) {
+	case *BinaryExpr:
+		return &BinaryExpr{
+			Op:    n.Op,
+			Left:  normalizeForFormat(n.Left),
+			Right: normalizeForFormat(n.Right),
+		}
+	case *NotExpr:
+		return &NotExpr{Expr: normalizeForFormat(n.Expr)}
+	case *ParenExpr:
+		return normalizeForFormat(n.Expr)
+	case *Term:
+		term := &Term{Field: canonicalField(n.Field), Values: n.Values}
+		if n.Negated {
+			return &NotExpr{Expr: term}
+		}
+		return term
+	case *Comparison:
+		return &Comparison{
+			Field: canonicalField(n.Field),
+			Op:    n.Op,
+			Value: n.Value,
+			Upper: n.Upper,
+		}
+	default:
+		return node
+	}
+}
+
+// formatGenerator builds random ASTs, including values that need quoting or escaping
+type formatGenerator struct {
+	rng *rand.Rand
+}
+
+var (
+	formatWords  = []string{"fix", "crash", "v2.0", "cli/cli", "@bots", "@me", "über", "a-b"}
+	formatValues = []string{
+		"cli", "cli/cli", "@me", "good first issue", `say "hi"`, `back\slash`, "", "a,b",
+		"AND", "or", "-draft", "(x)", "x:y", "1..5", "*[bot]", "cli/*", "/^cli/", `/\[bot\]$/`,
+		"/a b/", "/a/b/", "ünïcode", ">5", "=5",
+	}
+	formatComparisonValues = []string{"7d", "2024-01-01", "today", "10", "1000"}
+	formatComparisonOps    = []string{">", ">=", "<", "<="}
+)
+
+func (g *formatGenerator) pick(values []string) string {
+	return values[g.rng.Intn(len(values))]
+}
+
+func (g *formatGenerator) node(depth int) Node {
+	if depth == 0 || g.rng.Intn(3) == 0 {
+		return g.leaf()
+	}
+	switch g.rng.Intn(5) {
+	case 0:
+		return &BinaryExpr{Op: "AND", Left: g.node(depth - 1), Right: g.node(depth - 1)}
+	case 1:
+		return &BinaryExpr{Op: "OR", Left: g.node(depth - 1), Right: g.node(depth - 1)}
+	case 2:
+		return &NotExpr{Expr: g.node(depth - 1)}
+	default:
+		return &ParenExpr{Expr: g.node(depth - 1)}
+	}
+}
+
+func (g *formatGenerator) leaf() Node {
+	fields := FieldNames()
+	switch g.rng.Intn(6) {
+	case 0:
+		return &FreeText{Text: g.pick(formatWords)}
+	case 1:
+		return &FreeText{Text: g.pick(append(formatWords, formatValues...)), Quoted: true}
+	case 2:
+		field := g.pick(fields)
+		if g.rng.Intn(3) == 0 {
+			return &Comparison{
+				Field: field,
+				Op:    OpRange,
+				Value: g.pick(formatComparisonValues),
+				Upper: g.pick(formatComparisonValues),
+			}
+		}
+		return &Comparison{
+			Field: field,
+			Op:    g.pick(formatComparisonOps),
+			Value: g.pick(append(formatComparisonValues, formatValues...)),
+		}
+	default:
+		values := []string{g.pick(formatValues)}
+		for g.rng.Intn(3) == 0 {
+			values = append(values, g.pick(formatValues))
+		}
+		field := g.pick(fields)
+		if g.rng.Intn(2) == 0 {
+			field = strings.ToUpper(field)
+		}
+		return &Term{Field: field, Values: values, Negated: g.rng.Intn(5) == 0}
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	gen := &formatGenerator{rng: rand.New(rand.NewSource(1))}
+
+	for i := 0; i < 2000; i++ {
+		ast := gen.node(4)
+		formatted := Format(ast)
+
+		reparsed := parseForFormat(t, formatted)
+		want, err := json.Marshal(normalizeForFormat(ast))
+		if err != nil {
+			t.Fatalf("failed to marshal AST: %v", err)
+		}
+		got, err := json.Marshal(normalizeForFormat(reparsed))
+		if err != nil {
+			t.Fatalf("failed to marshal reparsed AST: %v", err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("parse(Format(ast)) != ast for %q\n got: %s\nwant: %s", formatted, got, want)
+		}
+
+		if again := Format(reparsed); again != formatted {
+			t.Fatalf("Format is not stable: %q then %q", formatted, again)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Error definitions
@@ -30,9 +31,11 @@ var (
 
 // Lexer tokenizes a query string
 type Lexer struct {
-	input string
-	pos   int
-	ch    rune
+	input  string
+	pos    int       // Byte offset after the current character
+	offset int       // Byte offset of the current character
+	ch     rune      // Current character, decoded as UTF-8
+	prev   TokenType // Type of the last token, to tell values from free text
 }
 
 // NewLexer creates a new lexer for the given input
@@ -63,11 +66,22 @@ func (l *Lexer) Tokenize() ([]Token, error) {
 	return tokens, nil
 }
 
-// nextToken returns the next token from the input
+// nextToken returns the next token from the input, recording where it ends
 func (l *Lexer) nextToken() (Token, error) {
+	tok, err := l.scanToken()
+	if err != nil {
+		return Token{}, err
+	}
+	tok.End = max(l.offset, tok.Pos)
+	l.prev = tok.Type
+	return tok, nil
+}
+
+// scanToken scans the next token from the input
+func (l *Lexer) scanToken() (Token, error) {
 	l.skipWhitespace()
 
-	pos := l.pos
+	pos := l.offset
 
 	switch l.ch {
 	case 0:
@@ -84,21 +98,40 @@ func (l *Lexer) nextToken() (Token, error) {
 	case ',':
 		l.readChar()
 		return Token{Type: TokenComma, Value: ",", Pos: pos}, nil
+	case '<', '>':
+		// Comparison operator (used as field:>value, field:<=value)
+		op := string(l.ch)
+		l.readChar()
+		if l.ch == '=' {
+			op += "="
+			l.readChar()
+		}
+		return Token{Type: TokenComparator, Value: op, Pos: pos}, nil
 	case '"':
 		// Quoted string
-		str, err := l.readQuotedString()
+		str, err := l.readQuotedString(pos)
 		if err != nil {
 			return Token{}, err
 		}
 		return Token{Type: TokenValue, Value: str, Pos: pos}, nil
+	case '/':
+		// A value wrapped in slashes is a regular expression (author:/\[bot\]$/)
+		if l.prev == TokenColon || l.prev == TokenComma {
+			if regex, ok := l.readRegex(); ok {
+				return Token{Type: TokenValue, Value: regex, Pos: pos}, nil
+			}
+		}
+		word := l.readWord()
+		return l.classifyWord(word, pos), nil
 	case '-':
 		// Could be NOT operator or part of a word/value
 		// If at start of token and followed by a letter (like -repo:), it's NOT
 		// If followed by whitespace or special char, it's also NOT
+		// If followed by @, it negates a macro reference (-@bots)
 		// Otherwise it's part of a hyphenated word
 		nextChar := l.peekChar()
 		if nextChar == 0 || unicode.IsSpace(nextChar) || nextChar == '(' ||
-			unicode.IsLetter(nextChar) {
+			nextChar == '@' || unicode.IsLetter(nextChar) {
 			l.readChar()
 			return Token{Type: TokenNot, Value: "-", Pos: pos}, nil
 		}
@@ -110,21 +143,27 @@ func (l *Lexer) nextToken() (Token, error) {
 			word := l.readWord()
 			return l.classifyWord(word, pos), nil
 		}
-		return Token{}, errors.Join(
-			ErrUnexpectedCharacter,
-			fmt.Errorf("character %q at position %d", l.ch, pos),
-		)
+		return Token{}, &QueryError{
+			Code:    CodeUnexpectedCharacter,
+			Message: fmt.Sprintf("unexpected character %q at position %d", l.ch, pos),
+			Start:   pos,
+			End:     l.pos,
+			err:     ErrUnexpectedCharacter,
+		}
 	}
 }
 
 // readChar reads the next character from input
 func (l *Lexer) readChar() {
+	l.offset = l.pos
 	if l.pos >= len(l.input) {
 		l.ch = 0
-	} else {
-		l.ch = rune(l.input[l.pos])
+		l.pos++
+		return
 	}
-	l.pos++
+	ch, width := utf8.DecodeRuneInString(l.input[l.pos:])
+	l.ch = ch
+	l.pos += width
 }
 
 // peekChar returns the next character without advancing
@@ -132,7 +171,8 @@ func (l *Lexer) peekChar() rune {
 	if l.pos >= len(l.input) {
 		return 0
 	}
-	return rune(l.input[l.pos])
+	ch, _ := utf8.DecodeRuneInString(l.input[l.pos:])
+	return ch
 }
 
 // skipWhitespace skips whitespace characters
@@ -142,18 +182,51 @@ func (l *Lexer) skipWhitespace() {
 	}
 }
 
-// readWord reads a word (letters, digits, hyphens, underscores, slashes, dots)
+// readWord reads a word (letters, digits, hyphens, underscores, slashes, dots, globs)
 func (l *Lexer) readWord() string {
-	start := l.pos - 1
+	start := l.offset
 	for isWordChar(l.ch) {
 		l.readChar()
 	}
-	return l.input[start : l.pos-1]
+	return l.input[start:l.offset]
 }
 
-// readQuotedString reads a quoted string
-func (l *Lexer) readQuotedString() (string, error) {
-	start := l.pos
+// readRegex reads a /.../ value, keeping the slashes and any escapes.
+// Returns false without consuming anything if there is no closing slash, or if it doesn't end
+// the value (repo:/ AND title:a/b is a / value, not a regex).
+func (l *Lexer) readRegex() (string, bool) {
+	start := l.offset
+	end := -1
+	for i := start + 1; i < len(l.input); i++ {
+		if l.input[i] == '\\' {
+			i++
+			continue
+		}
+		if l.input[i] == '/' {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return "", false
+	}
+	if end+1 < len(l.input) && !isRegexTerminator(l.input[end+1]) {
+		return "", false
+	}
+
+	for l.offset <= end {
+		l.readChar()
+	}
+	return l.input[start : end+1], true
+}
+
+// isRegexTerminator checks if a character can follow the closing slash of a regex
+func isRegexTerminator(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',' || ch == ')'
+}
+
+// readQuotedString reads a quoted string starting at the given offset
+func (l *Lexer) readQuotedString(start int) (string, error) {
 	l.readChar() // skip opening quote
 
 	var result strings.Builder
@@ -161,10 +234,7 @@ func (l *Lexer) readQuotedString() (string, error) {
 		if l.ch == '\\' {
 			l.readChar()
 			if l.ch == 0 {
-				return "", errors.Join(
-					ErrUnterminatedQuotedString,
-					fmt.Errorf("at position %d", start),
-				)
+				return "", l.unterminatedError(start)
 			}
 			// Handle escape sequences
 			switch l.ch {
@@ -186,13 +256,25 @@ func (l *Lexer) readQuotedString() (string, error) {
 	}
 
 	if l.ch == 0 {
-		return "", errors.Join(ErrUnterminatedQuotedString, fmt.Errorf("at position %d", start))
+		return "", l.unterminatedError(start)
 	}
 
 	l.readChar() // skip closing quote
 	return result.String(), nil
 }
 
+// unterminatedError reports a quoted string that runs to the end of the input
+func (l *Lexer) unterminatedError(start int) *QueryError {
+	return &QueryError{
+		Code:     CodeUnterminatedString,
+		Message:  fmt.Sprintf("unterminated quoted string at position %d", start),
+		Start:    start,
+		End:      len(l.input),
+		Expected: []string{`"`},
+		err:      ErrUnterminatedQuotedString,
+	}
+}
+
 // classifyWord classifies a word as an operator or field/value/freetext
 func (l *Lexer) classifyWord(word string, pos int) Token {
 	switch word {
@@ -213,5 +295,6 @@ func isWordChar(ch rune) bool {
 	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '-' || ch == '_' || ch == '/' ||
 		ch == '.' ||
 		ch == '@' ||
-		ch == '[' || ch == ']'
+		ch == '[' || ch == ']' ||
+		ch == '*' || ch == '?'
 }
@@ -193,6 +193,11 @@ func TestLexer_QuotedStrings(t *testing.T) {
 			input:    `"path\\to\\file"`,
 			expected: `path\to\file`,
 		},
+		{
+			name:     "quoted string with non-ASCII characters",
+			input:    `"naïve café ✓"`,
+			expected: "naïve café ✓",
+		},
 	}
 
 	for _, tt := range tests {
@@ -275,6 +280,189 @@ func TestLexer_SpecialChars(t *testing.T) {
 	}
 }
 
+func TestLexer_Comparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Token
+	}{
+		{
+			name:  "greater than",
+			input: "updated:>7d",
+			expected: []Token{
+				{Type: TokenFreeText, Value: "updated"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenComparator, Value: ">"},
+				{Type: TokenFreeText, Value: "7d"},
+				{Type: TokenEOF},
+			},
+		},
+		{
+			name:  "less than or equal",
+			input: "created:<=2024-01-01",
+			expected: []Token{
+				{Type: TokenFreeText, Value: "created"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenComparator, Value: "<="},
+				{Type: TokenFreeText, Value: "2024-01-01"},
+				{Type: TokenEOF},
+			},
+		},
+		{
+			name:  "range is a single word",
+			input: "updated:2024-01-01..2024-02-01",
+			expected: []Token{
+				{Type: TokenFreeText, Value: "updated"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenFreeText, Value: "2024-01-01..2024-02-01"},
+				{Type: TokenEOF},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input)
+			tokens, err := lexer.Tokenize()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tokens) != len(tt.expected) {
+				t.Fatalf("expected %d tokens, got %d", len(tt.expected), len(tokens))
+			}
+
+			for i, tok := range tokens {
+				if tok.Type != tt.expected[i].Type {
+					t.Errorf("token %d: expected type %s, got %s", i, tt.expected[i].Type, tok.Type)
+				}
+				if tok.Value != tt.expected[i].Value {
+					t.Errorf(
+						"token %d: expected value %q, got %q",
+						i,
+						tt.expected[i].Value,
+						tok.Value,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestLexer_Patterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Token
+	}{
+		{
+			name:  "glob is a single word",
+			input: "repo:acme/*-service?",
+			expected: []Token{
+				{Type: TokenFreeText, Value: "repo"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenFreeText, Value: "acme/*-service?"},
+				{Type: TokenEOF},
+			},
+		},
+		{
+			name:  "regex keeps spaces, escapes and slashes",
+			input: `author:/\[bot\]$|a b/ is:unread`,
+			expected: []Token{
+				{Type: TokenFreeText, Value: "author"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenValue, Value: `/\[bot\]$|a b/`},
+				{Type: TokenFreeText, Value: "is"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenFreeText, Value: "unread"},
+				{Type: TokenEOF},
+			},
+		},
+		{
+			name:  "escaped slash inside regex",
+			input: `repo:/^cli\/.*/`,
+			expected: []Token{
+				{Type: TokenFreeText, Value: "repo"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenValue, Value: `/^cli\/.*/`},
+				{Type: TokenEOF},
+			},
+		},
+		{
+			name:  "regex after comma",
+			input: "author:octocat,/bot$/",
+			expected: []Token{
+				{Type: TokenFreeText, Value: "author"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenFreeText, Value: "octocat"},
+				{Type: TokenComma, Value: ","},
+				{Type: TokenValue, Value: "/bot$/"},
+				{Type: TokenEOF},
+			},
+		},
+		{
+			name:  "slash without a closing slash is a word",
+			input: "repo:/cli",
+			expected: []Token{
+				{Type: TokenFreeText, Value: "repo"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenFreeText, Value: "/cli"},
+				{Type: TokenEOF},
+			},
+		},
+		{
+			name:  "closing slash inside a later word is not a regex",
+			input: "repo:/ AND label:a/b",
+			expected: []Token{
+				{Type: TokenFreeText, Value: "repo"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenFreeText, Value: "/"},
+				{Type: TokenAnd, Value: "AND"},
+				{Type: TokenFreeText, Value: "label"},
+				{Type: TokenColon, Value: ":"},
+				{Type: TokenFreeText, Value: "a/b"},
+				{Type: TokenEOF},
+			},
+		},
+		{
+			name:  "slashes in free text are not a regex",
+			input: "/tmp/ cache",
+			expected: []Token{
+				{Type: TokenFreeText, Value: "/tmp/"},
+				{Type: TokenFreeText, Value: "cache"},
+				{Type: TokenEOF},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := NewLexer(tt.input).Tokenize()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tokens) != len(tt.expected) {
+				t.Fatalf("expected %d tokens, got %d: %v", len(tt.expected), len(tokens), tokens)
+			}
+
+			for i, tok := range tokens {
+				if tok.Type != tt.expected[i].Type {
+					t.Errorf("token %d: expected type %s, got %s", i, tt.expected[i].Type, tok.Type)
+				}
+				if tok.Value != tt.expected[i].Value {
+					t.Errorf(
+						"token %d: expected value %q, got %q",
+						i,
+						tt.expected[i].Value,
+						tok.Value,
+					)
+				}
+			}
+		})
+	}
+}
+
 func TestLexer_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -351,3 +539,48 @@ func TestLexer_ComplexQueries(t *testing.T) {
 		})
 	}
 }
+
+func TestLexer_TokenSpans(t *testing.T) {
+	input := `(repo:cli  "fix bug")`
+	expected := []string{"(", "repo", ":", "cli", `"fix bug"`, ")", ""}
+
+	tokens, err := NewLexer(input).Tokenize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, tok := range tokens {
+		if got := input[tok.Pos:tok.End]; got != expected[i] {
+			t.Errorf("token %d: expected span %q, got %q", i, expected[i], got)
+		}
+	}
+}
+
+func TestLexer_TokenSpansNonASCII(t *testing.T) {
+	// Spans are byte offsets, so multi-byte characters widen them
+	input := `label:über ✓`
+	tokens, err := NewLexer(input).Tokenize()
+	if err == nil {
+		t.Fatalf("expected an error for ✓, got tokens %v", tokens)
+	}
+	errs := QueryErrors(err)
+	if len(errs) != 1 || input[errs[0].Start:errs[0].End] != "✓" {
+		t.Fatalf("expected the error to span ✓, got %v", errs)
+	}
+
+	tokens, err = NewLexer(`label:über`).Tokenize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens[2].Value != "über" || tokens[2].Pos != 6 || tokens[2].End != 11 {
+		t.Errorf(
+			"expected über at [6, 11), got %q at [%d, %d)",
+			tokens[2].Value,
+			tokens[2].Pos,
+			tokens[2].End,
+		)
+	}
+}
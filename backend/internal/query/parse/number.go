@@ -0,0 +1,93 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Error definitions
+var (
+	ErrInvalidNumberValue = errors.New("invalid number value")
+)
+
+// NumberBounds is the set of integers matched by a number comparison.
+// A nil bound is unbounded on that side.
+type NumberBounds struct {
+	Low           *int64
+	LowInclusive  bool
+	High          *int64
+	HighInclusive bool
+}
+
+// Contains reports whether n falls within the bounds
+func (b NumberBounds) Contains(n int64) bool {
+	if b.Low != nil {
+		if n < *b.Low || (!b.LowInclusive && n == *b.Low) {
+			return false
+		}
+	}
+	if b.High != nil {
+		if n > *b.High || (!b.HighInclusive && n == *b.High) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseNumberValue parses a query number value.
+// Numbers are stored as 32-bit INTEGER columns, so larger values are rejected.
+func ParseNumberValue(value string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return 0, errors.Join(ErrInvalidNumberValue, fmt.Errorf("value: %s", value))
+	}
+	return n, nil
+}
+
+// ResolveNumberBounds turns a number comparison into concrete bounds.
+// op is one of ">", ">=", "<", "<=", ".." (upper holds the inclusive range end) or "" for a
+// plain field:value term, which matches that exact number.
+func ResolveNumberBounds(op, value, upper string) (NumberBounds, error) {
+	n, err := ParseNumberValue(value)
+	if err != nil {
+		return NumberBounds{}, err
+	}
+
+	switch op {
+	case "":
+		return NumberBounds{Low: &n, LowInclusive: true, High: &n, HighInclusive: true}, nil
+	case ">":
+		return NumberBounds{Low: &n}, nil
+	case ">=":
+		return NumberBounds{Low: &n, LowInclusive: true}, nil
+	case "<":
+		return NumberBounds{High: &n}, nil
+	case "<=":
+		return NumberBounds{High: &n, HighInclusive: true}, nil
+	case OpRange:
+		high, err := ParseNumberValue(upper)
+		if err != nil {
+			return NumberBounds{}, err
+		}
+		return NumberBounds{Low: &n, LowInclusive: true, High: &high, HighInclusive: true}, nil
+	default:
+		return NumberBounds{}, errors.Join(ErrInvalidComparison, fmt.Errorf("operator: %s", op))
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveNumberBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		value    string
+		upper    string
+		inside   []int64
+		outside  []int64
+		hasError bool
+	}{
+		{
+			name:    "plain value matches exactly",
+			value:   "42",
+			inside:  []int64{42},
+			outside: []int64{41, 43},
+		},
+		{
+			name:    "greater than excludes the value",
+			op:      ">",
+			value:   "1000",
+			inside:  []int64{1001},
+			outside: []int64{1000, 999},
+		},
+		{
+			name:    "greater than or equal includes the value",
+			op:      ">=",
+			value:   "10",
+			inside:  []int64{10, 11},
+			outside: []int64{9},
+		},
+		{
+			name:    "less than excludes the value",
+			op:      "<",
+			value:   "5",
+			inside:  []int64{4, 0},
+			outside: []int64{5},
+		},
+		{
+			name:    "less than or equal includes the value",
+			op:      "<=",
+			value:   "5",
+			inside:  []int64{5},
+			outside: []int64{6},
+		},
+		{
+			name:    "range is inclusive",
+			op:      OpRange,
+			value:   "100",
+			upper:   "200",
+			inside:  []int64{100, 150, 200},
+			outside: []int64{99, 201},
+		},
+		{
+			name:     "invalid value",
+			op:       ">",
+			value:    "many",
+			hasError: true,
+		},
+		{
+			name:     "value out of range",
+			value:    "99999999999",
+			hasError: true,
+		},
+		{
+			name:     "invalid range bound",
+			op:       OpRange,
+			value:    "1",
+			upper:    "lots",
+			hasError: true,
+		},
+		{
+			name:     "invalid operator",
+			op:       "=",
+			value:    "1",
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounds, err := ResolveNumberBounds(tt.op, tt.value, tt.upper)
+			if tt.hasError {
+				if err == nil {
+					t.Error("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, n := range tt.inside {
+				if !bounds.Contains(n) {
+					t.Errorf("expected %d to be within bounds", n)
+				}
+			}
+			for _, n := range tt.outside {
+				if bounds.Contains(n) {
+					t.Errorf("expected %d to be outside bounds", n)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNumberValue(t *testing.T) {
+	if n, err := ParseNumberValue(" 123 "); err != nil || n != 123 {
+		t.Errorf("expected 123, got %d (err: %v)", n, err)
+	}
+	if _, err := ParseNumberValue("12abc"); !errors.Is(err, ErrInvalidNumberValue) {
+		t.Errorf("expected ErrInvalidNumberValue, got %v", err)
+	}
+}
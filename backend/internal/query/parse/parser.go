@@ -18,6 +18,7 @@ package parse
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // Error definitions
@@ -31,8 +32,12 @@ var (
 	ErrExpectedColon                  = errors.New("expected colon after field")
 	ErrExpectedValue                  = errors.New("expected value after colon")
 	ErrExpectedAtLeastOneValue        = errors.New("expected at least one value for field")
+	ErrExpectedComparisonValue        = errors.New("expected value after comparison operator")
 )
 
+// OpRange is the Comparison operator for field:low..high ranges
+const OpRange = ".."
+
 // Parser parses tokens into an Abstract Syntax Tree
 type Parser struct {
 	tokens  []Token
@@ -66,9 +71,15 @@ func (p *Parser) Parse() (Node, error) {
 
 	// Check for unexpected tokens after the expression
 	if p.current.Type != TokenEOF {
-		return nil, errors.Join(
+		return nil, p.errorAtCurrent(
 			ErrUnexpectedTokenAfterExpression,
-			fmt.Errorf("token %s at position %d", p.current.Type, p.current.Pos),
+			CodeUnexpectedToken,
+			fmt.Sprintf(
+				"unexpected token after expression: %s at position %d",
+				p.current.Type,
+				p.current.Pos,
+			),
+			"AND", "OR", "end of query",
 		)
 	}
 
@@ -170,9 +181,11 @@ func (p *Parser) parsePrimary() (Node, error) {
 
 	// Check for unexpected closing paren
 	if p.current.Type == TokenRParen {
-		return nil, errors.Join(
+		return nil, p.errorAtCurrent(
 			ErrUnexpectedClosingParen,
-			fmt.Errorf("at position %d", p.current.Pos),
+			CodeUnexpectedToken,
+			fmt.Sprintf("unexpected closing parenthesis at position %d", p.current.Pos),
+			primaryExpected...,
 		)
 	}
 
@@ -183,23 +196,31 @@ func (p *Parser) parsePrimary() (Node, error) {
 
 	// Free text (including quoted strings)
 	if p.current.Type == TokenFreeText || p.current.Type == TokenValue {
-		text := p.current.Value
+		node := &FreeText{
+			Text:     p.current.Value,
+			Quoted:   p.current.Type == TokenValue,
+			TextSpan: Span{Start: p.current.Pos, End: p.current.End},
+		}
 		p.advance()
-		return &FreeText{Text: text}, nil
+		return node, nil
 	}
 
-	return nil, errors.Join(
+	return nil, p.errorAtCurrent(
 		ErrUnexpectedToken,
-		fmt.Errorf("token %s at position %d", p.current.Type, p.current.Pos),
+		CodeUnexpectedToken,
+		fmt.Sprintf("unexpected token %s at position %d", p.current.Type, p.current.Pos),
+		primaryExpected...,
 	)
 }
 
 // parseParenExpr parses a parenthesized expression
 func (p *Parser) parseParenExpr() (Node, error) {
 	if p.current.Type != TokenLParen {
-		return nil, errors.Join(
+		return nil, p.errorAtCurrent(
 			ErrExpectedOpeningParen,
-			fmt.Errorf("at position %d", p.current.Pos),
+			CodeMissingParen,
+			fmt.Sprintf("expected opening parenthesis at position %d", p.current.Pos),
+			"(",
 		)
 	}
 	p.advance() // consume '('
@@ -210,9 +231,15 @@ func (p *Parser) parseParenExpr() (Node, error) {
 	}
 
 	if p.current.Type != TokenRParen {
-		return nil, errors.Join(
+		return nil, p.errorAtCurrent(
 			ErrExpectedClosingParen,
-			fmt.Errorf("at position %d, got %s", p.current.Pos, p.current.Type),
+			CodeMissingParen,
+			fmt.Sprintf(
+				"expected closing parenthesis at position %d, got %s",
+				p.current.Pos,
+				p.current.Type,
+			),
+			")",
 		)
 	}
 	p.advance() // consume ')'
@@ -220,36 +247,74 @@ func (p *Parser) parseParenExpr() (Node, error) {
 	return &ParenExpr{Expr: expr}, nil
 }
 
-// parseTerm parses a field:value1,value2 term
-func (p *Parser) parseTerm() (*Term, error) {
+// parseTerm parses a field:value1,value2 term, or a comparison
+// (field:>value, field:low..high) when the value calls for one
+func (p *Parser) parseTerm() (Node, error) {
 	if p.current.Type != TokenFreeText {
-		return nil, errors.Join(ErrExpectedFieldName, fmt.Errorf("at position %d", p.current.Pos))
+		return nil, p.errorAtCurrent(
+			ErrExpectedFieldName,
+			CodeUnexpectedToken,
+			fmt.Sprintf("expected field name at position %d", p.current.Pos),
+			"field",
+		)
 	}
 
 	field := p.current.Value
+	fieldSpan := Span{Start: p.current.Pos, End: p.current.End}
 	p.advance()
 
 	if p.current.Type != TokenColon {
-		return nil, errors.Join(
+		return nil, p.errorAtCurrent(
 			ErrExpectedColon,
-			fmt.Errorf("after field %q at position %d", field, p.current.Pos),
+			CodeExpectedColon,
+			fmt.Sprintf("expected colon after field %q at position %d", field, p.current.Pos),
+			":",
 		)
 	}
 	p.advance() // consume ':'
 
+	// Comparison operator: field:>value, field:<=value
+	if p.current.Type == TokenComparator {
+		return p.parseComparison(field, fieldSpan)
+	}
+
+	// Unquoted low..high is a range comparison (quote the value to match it literally)
+	if p.current.Type == TokenFreeText && p.peekType() != TokenComma {
+		if low, high, ok := splitRange(p.current.Value); ok {
+			valueSpan := Span{Start: p.current.Pos, End: p.current.End}
+			p.advance()
+			return &Comparison{
+				Field:     field,
+				Op:        OpRange,
+				Value:     low,
+				Upper:     high,
+				FieldSpan: fieldSpan,
+				ValueSpan: valueSpan,
+			}, nil
+		}
+	}
+
 	// Parse values (comma-separated)
 	var values []string
+	var valueSpans []Span
 
 	for {
 		// Values can be TokenFreeText or TokenValue (quoted strings)
 		if p.current.Type != TokenFreeText && p.current.Type != TokenValue {
-			return nil, errors.Join(
+			return nil, p.errorAtCurrent(
 				ErrExpectedValue,
-				fmt.Errorf("at position %d, got %s", p.current.Pos, p.current.Type),
+				CodeExpectedValue,
+				fmt.Sprintf(
+					"expected value after colon at position %d, got %s",
+					p.current.Pos,
+					p.current.Type,
+				),
+				"value",
 			)
 		}
 
 		values = append(values, p.current.Value)
+		valueSpans = append(valueSpans, Span{Start: p.current.Pos, End: p.current.End})
 		p.advance()
 
 		// Check for comma (OR within field)
@@ -262,19 +327,90 @@ func (p *Parser) parseTerm() (*Term, error) {
 	}
 
 	if len(values) == 0 {
-		return nil, errors.Join(ErrExpectedAtLeastOneValue, fmt.Errorf("for field %q", field))
+		return nil, p.errorAtCurrent(
+			ErrExpectedAtLeastOneValue,
+			CodeExpectedValue,
+			fmt.Sprintf("expected at least one value for field %q", field),
+			"value",
+		)
 	}
 
 	return &Term{
-		Field:  field,
-		Values: values,
+		Field:      field,
+		Values:     values,
+		FieldSpan:  fieldSpan,
+		ValueSpans: valueSpans,
 	}, nil
 }
 
+// parseComparison parses the operator and value of a field:>value comparison
+func (p *Parser) parseComparison(field string, fieldSpan Span) (*Comparison, error) {
+	op := p.current.Value
+	opStart := p.current.Pos
+	p.advance() // consume comparator
+
+	if p.current.Type != TokenFreeText && p.current.Type != TokenValue {
+		return nil, p.errorAtCurrent(
+			ErrExpectedComparisonValue,
+			CodeExpectedValue,
+			fmt.Sprintf(
+				"expected value after %s%s at position %d, got %s",
+				field,
+				op,
+				p.current.Pos,
+				p.current.Type,
+			),
+			"value",
+		)
+	}
+
+	value := p.current.Value
+	valueSpan := Span{Start: opStart, End: p.current.End}
+	p.advance()
+
+	return &Comparison{
+		Field:     field,
+		Op:        op,
+		Value:     value,
+		FieldSpan: fieldSpan,
+		ValueSpan: valueSpan,
+	}, nil
+}
+
+// primaryExpected lists what may start an expression, for error reporting
+var primaryExpected = []string{"field:value", "text", "(", "NOT"}
+
+// errorAtCurrent builds a QueryError spanning the current token
+func (p *Parser) errorAtCurrent(
+	sentinel error,
+	code ErrorCode,
+	message string,
+	expected ...string,
+) *QueryError {
+	return &QueryError{
+		Code:     code,
+		Message:  message,
+		Start:    p.current.Pos,
+		End:      p.current.End,
+		Expected: expected,
+		err:      sentinel,
+	}
+}
+
+// splitRange splits a low..high range value into its bounds
+func splitRange(value string) (low, high string, ok bool) {
+	low, high, found := strings.Cut(value, OpRange)
+	if !found || low == "" || high == "" || strings.Contains(high, OpRange) {
+		return "", "", false
+	}
+	return low, high, true
+}
+
 // isStartOfPrimary checks if current token can start a primary expression
 func (p *Parser) isStartOfPrimary() bool {
 	return p.current.Type == TokenLParen ||
 		p.current.Type == TokenFreeText ||
+		p.current.Type == TokenValue ||
 		p.current.Type == TokenNot
 }
 
@@ -292,6 +428,14 @@ func (p *Parser) isStartOfTerm() bool {
 	return false
 }
 
+// peekType returns the type of the token after the current one
+func (p *Parser) peekType() TokenType {
+	if p.pos+1 < len(p.tokens) {
+		return p.tokens[p.pos+1].Type
+	}
+	return TokenEOF
+}
+
 // advance moves to the next token
 func (p *Parser) advance() {
 	p.pos++
@@ -16,6 +16,7 @@
 package parse
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -284,6 +285,16 @@ func TestParser_FreeText(t *testing.T) {
 			input:    "urgent fix",
 			expected: `(FREE("urgent") AND FREE("fix"))`,
 		},
+		{
+			name:     "quoted phrase after a term",
+			input:    `repo:cli "memory leak"`,
+			expected: `(repo:cli AND FREE("memory leak"))`,
+		},
+		{
+			name:     "quoted phrase between words",
+			input:    `fix "memory leak" now`,
+			expected: `((FREE("fix") AND FREE("memory leak")) AND FREE("now"))`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -307,6 +318,28 @@ func TestParser_FreeText(t *testing.T) {
 	}
 }
 
+func TestParser_FreeTextQuoted(t *testing.T) {
+	tokens, err := NewLexer(`"memory leak" leak`).Tokenize()
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+	ast, err := NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+
+	expr, ok := ast.(*BinaryExpr)
+	if !ok {
+		t.Fatalf("expected *BinaryExpr, got %T", ast)
+	}
+	if phrase, ok := expr.Left.(*FreeText); !ok || !phrase.Quoted {
+		t.Errorf("expected quoted free text, got %#v", expr.Left)
+	}
+	if word, ok := expr.Right.(*FreeText); !ok || word.Quoted {
+		t.Errorf("expected unquoted free text, got %#v", expr.Right)
+	}
+}
+
 func TestParser_ComplexQueries(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -350,6 +383,99 @@ func TestParser_ComplexQueries(t *testing.T) {
 	}
 }
 
+func TestParser_Comparisons(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "greater than",
+			input:    "updated:>7d",
+			expected: "updated:>7d",
+		},
+		{
+			name:     "greater than or equal",
+			input:    "created:>=2024-01-01",
+			expected: "created:>=2024-01-01",
+		},
+		{
+			name:     "less than",
+			input:    "imported:<yesterday",
+			expected: "imported:<yesterday",
+		},
+		{
+			name:     "range",
+			input:    "updated:2024-01-01..2024-02-01",
+			expected: "updated:2024-01-01..2024-02-01",
+		},
+		{
+			name:     "comparison combined with term",
+			input:    "repo:cli updated:>1w",
+			expected: "(repo:cli AND updated:>1w)",
+		},
+		{
+			name:     "negated comparison",
+			input:    "-updated:<30d",
+			expected: "NOT(updated:<30d)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input)
+			tokens, err := lexer.Tokenize()
+			if err != nil {
+				t.Fatalf("lexer error: %v", err)
+			}
+
+			parser := NewParser(tokens)
+			ast, err := parser.Parse()
+			if err != nil {
+				t.Fatalf("parser error: %v", err)
+			}
+
+			if ast.String() != tt.expected {
+				t.Errorf("expected AST %q, got %q", tt.expected, ast.String())
+			}
+		})
+	}
+}
+
+func TestParser_RangeNodeType(t *testing.T) {
+	tokens, err := NewLexer("updated:2024-01-01..2024-02-01").Tokenize()
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+
+	ast, err := NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+
+	cmp, ok := ast.(*Comparison)
+	if !ok {
+		t.Fatalf("expected *Comparison, got %T", ast)
+	}
+	if cmp.Op != OpRange || cmp.Value != "2024-01-01" || cmp.Upper != "2024-02-01" {
+		t.Errorf("unexpected range: %+v", cmp)
+	}
+
+	tokens, err = NewLexer(`updated:"2024-01-01..2024-02-01"`).Tokenize()
+	if err != nil {
+		t.Fatalf("lexer error: %v", err)
+	}
+
+	ast, err = NewParser(tokens).Parse()
+	if err != nil {
+		t.Fatalf("parser error: %v", err)
+	}
+
+	if _, ok := ast.(*Term); !ok {
+		t.Errorf("expected quoted range to parse as *Term, got %T", ast)
+	}
+}
+
 func TestParser_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -375,6 +501,14 @@ func TestParser_ErrorCases(t *testing.T) {
 			name:  "empty parens",
 			input: "()",
 		},
+		{
+			name:  "comparator without value",
+			input: "updated:>",
+		},
+		{
+			name:  "comparator followed by paren",
+			input: "updated:>=)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -394,3 +528,71 @@ func TestParser_ErrorCases(t *testing.T) {
 		})
 	}
 }
+
+func TestNode_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "term",
+			input:    "repo:cli,go",
+			expected: `{"type":"term","field":"repo","values":["cli","go"]}`,
+		},
+		{
+			name:  "negated term becomes not",
+			input: "-author:bot",
+			expected: `{"type":"not","expr":` +
+				`{"type":"term","field":"author","values":["bot"]}}`,
+		},
+		{
+			name:     "comparison",
+			input:    "updated:>7d",
+			expected: `{"type":"comparison","op":"\u003e","field":"updated","value":"7d"}`,
+		},
+		{
+			name:  "range",
+			input: "created:2024-01-01..2024-02-01",
+			expected: `{"type":"comparison","op":"..","field":"created",` +
+				`"value":"2024-01-01","upper":"2024-02-01"}`,
+		},
+		{
+			name:     "quoted free text",
+			input:    `"flaky test"`,
+			expected: `{"type":"text","text":"flaky test","quoted":true}`,
+		},
+		{
+			name:  "group, binary and not",
+			input: "NOT (is:read OR is:muted)",
+			expected: `{"type":"not","expr":{"type":"group","expr":` +
+				`{"type":"binary","op":"OR",` +
+				`"left":{"type":"term","field":"is","values":["read"]},` +
+				`"right":{"type":"term","field":"is","values":["muted"]}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(tt.input)
+			tokens, err := lexer.Tokenize()
+			if err != nil {
+				t.Fatalf("lexer error: %v", err)
+			}
+
+			parser := NewParser(tokens)
+			ast, err := parser.Parse()
+			if err != nil {
+				t.Fatalf("parser error: %v", err)
+			}
+
+			data, err := json.Marshal(ast)
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("json = %s\nwant   %s", data, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Error definitions
+var (
+	ErrInvalidRegex = errors.New("invalid regular expression")
+)
+
+// PatternKind is how a string field value is matched
+type PatternKind int
+
+const (
+	// PatternLiteral is a plain value, matched as the field's kind describes
+	PatternLiteral PatternKind = iota
+	// PatternGlob contains * (any run of characters) or ? (one character) and must match
+	// the whole value, case-insensitively
+	PatternGlob
+	// PatternRegex is written /.../ and is a case-insensitive regular expression
+	PatternRegex
+)
+
+// SupportsPatterns reports whether values of a field kind may be globs or regexes
+func SupportsPatterns(kind FieldKind) bool {
+	return kind == FieldContains || kind == FieldPrefix
+}
+
+// ClassifyPattern returns how a value is matched, and the regex body for PatternRegex
+func ClassifyPattern(value string) (PatternKind, string) {
+	if len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		return PatternRegex, value[1 : len(value)-1]
+	}
+	if strings.ContainsAny(value, "*?") {
+		return PatternGlob, value
+	}
+	return PatternLiteral, value
+}
+
+// GlobToLike converts a glob to a LIKE pattern: * becomes %, ? becomes _, and the LIKE
+// wildcards and escape character already in the glob are matched literally
+func GlobToLike(glob string) string {
+	var pattern strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			pattern.WriteRune('%')
+		case '?':
+			pattern.WriteRune('_')
+		case '%', '_', '\\':
+			pattern.WriteRune('\\')
+			pattern.WriteRune(r)
+		default:
+			pattern.WriteRune(r)
+		}
+	}
+	return pattern.String()
+}
+
+// CompileRegex compiles the body of a /.../ value like Postgres' ~* operator:
+// case-insensitive and unanchored. The validator rejects anything Go can't compile, and
+// anything PostgresRegex can't translate.
+func CompileRegex(body string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("(?i)" + body)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidRegex, fmt.Errorf("regex: %s", body), err)
+	}
+	return re, nil
+}
+
+// UnsupportedRegexError reports Go regex syntax that Postgres reads differently or not at all
+type UnsupportedRegexError struct {
+	Construct string // The syntax as written, e.g. \p or (?P<
+}
+
+func (e *UnsupportedRegexError) Error() string {
+	return fmt.Sprintf("%s isn't supported", e.Construct)
+}
+
+// postgresEscapes are the escapes Postgres spells differently from Go
+var postgresEscapes = map[byte]string{
+	'b': `\y`, // Word boundary
+	'B': `\Y`, // Not a word boundary
+	'z': `\Z`, // End of text
+}
+
+// PostgresRegex translates the body of a /.../ value that CompileRegex accepts into
+// Postgres' dialect, so the database matches what the evaluator does. Syntax the two read
+// differently is rejected: named groups, \Q...\E, Unicode classes (\p and \P), \C, and
+// flags anywhere but a leading (?i) or (?s).
+func PostgresRegex(body string) (string, error) {
+	var pattern strings.Builder
+	inClass := false
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body):
+			i++
+			escaped := body[i]
+			switch escaped {
+			case 'Q', 'E', 'p', 'P', 'C':
+				return "", unsupportedRegex(body, `\`+string(escaped))
+			}
+			if translated, ok := postgresEscapes[escaped]; ok && !inClass {
+				pattern.WriteString(translated)
+				continue
+			}
+			pattern.WriteByte(c)
+			pattern.WriteByte(escaped)
+			continue
+		case c == '[' && !inClass:
+			inClass = true
+			pattern.WriteByte(c)
+			// A ] first in the class, after any ^, is literal
+			if i+1 < len(body) && body[i+1] == '^' {
+				i++
+				pattern.WriteByte('^')
+			}
+			if i+1 < len(body) && body[i+1] == ']' {
+				i++
+				pattern.WriteByte(']')
+			}
+			continue
+		case c == '[' && inClass && strings.HasPrefix(body[i:], "[:"):
+			// A named class like [:alpha:], whose ] doesn't end the class
+			if end := strings.Index(body[i:], ":]"); end > 0 {
+				pattern.WriteString(body[i : i+end+2])
+				i += end + 1
+				continue
+			}
+		case c == ']' && inClass:
+			inClass = false
+		case c == '(' && !inClass && strings.HasPrefix(body[i:], "(?"):
+			if err := checkRegexGroup(body, i); err != nil {
+				return "", err
+			}
+		}
+		pattern.WriteByte(c)
+	}
+	return pattern.String(), nil
+}
+
+// checkRegexGroup rejects the (? group starting at body[i] unless Postgres reads it like
+// Go: a non-capturing group, or i or s flags at the start of the body
+func checkRegexGroup(body string, i int) error {
+	rest := body[i+2:]
+	switch {
+	case strings.HasPrefix(rest, ":"):
+		return nil
+	case strings.HasPrefix(rest, "P<") || strings.HasPrefix(rest, "<"):
+		return unsupportedRegex(body, "(?P<name>...)")
+	}
+
+	flags, _, closed := strings.Cut(rest, ")")
+	if i == 0 && closed && flags != "" && strings.Trim(flags, "is") == "" {
+		return nil
+	}
+	end := strings.IndexAny(rest, ":)")
+	if end < 0 {
+		end = len(rest)
+	}
+	return unsupportedRegex(body, "(?"+rest[:end]+")")
+}
+
+// unsupportedRegex returns the error for syntax PostgresRegex doesn't translate
+func unsupportedRegex(body, construct string) error {
+	return errors.Join(
+		ErrInvalidRegex,
+		fmt.Errorf("regex: %s", body),
+		&UnsupportedRegexError{Construct: construct},
+	)
+}
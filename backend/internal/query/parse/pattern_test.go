@@ -0,0 +1,146 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifyPattern(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantKind PatternKind
+		wantBody string
+	}{
+		{"cli", PatternLiteral, "cli"},
+		{"github/cli", PatternLiteral, "github/cli"},
+		{"acme/*-service", PatternGlob, "acme/*-service"},
+		{"v?", PatternGlob, "v?"},
+		{`/\[bot\]$/`, PatternRegex, `\[bot\]$`},
+		{"//", PatternRegex, ""},
+		{"/", PatternLiteral, "/"},
+		{"/cli", PatternLiteral, "/cli"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			kind, body := ClassifyPattern(tt.value)
+			if kind != tt.wantKind || body != tt.wantBody {
+				t.Errorf(
+					"ClassifyPattern(%q) = (%d, %q), want (%d, %q)",
+					tt.value,
+					kind,
+					body,
+					tt.wantKind,
+					tt.wantBody,
+				)
+			}
+		})
+	}
+}
+
+func TestGlobToLike(t *testing.T) {
+	tests := []struct {
+		glob string
+		want string
+	}{
+		{"acme/*-service", "acme/%-service"},
+		{"v?.?", "v_._"},
+		{"*[bot]", "%[bot]"},
+		{"100%_done*", `100\%\_done%`},
+		{`a\b`, `a\\b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob, func(t *testing.T) {
+			if got := GlobToLike(tt.glob); got != tt.want {
+				t.Errorf("GlobToLike(%q) = %q, want %q", tt.glob, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileRegex(t *testing.T) {
+	re, err := CompileRegex(`\[bot\]$`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !re.MatchString("Dependabot[BOT]") {
+		t.Error("expected regex to match case-insensitively")
+	}
+	if re.MatchString("[bot]-helper") {
+		t.Error("expected anchored regex not to match")
+	}
+
+	if _, err := CompileRegex("[bot"); !errors.Is(err, ErrInvalidRegex) {
+		t.Errorf("expected ErrInvalidRegex, got %v", err)
+	}
+}
+
+func TestPostgresRegex(t *testing.T) {
+	tests := []struct {
+		body      string
+		want      string
+		construct string // Set when the body is rejected
+	}{
+		{body: `\[bot\]$`, want: `\[bot\]$`},
+		{body: `\bfix\b`, want: `\yfix\y`},
+		{body: `\Bbot\z`, want: `\Ybot\Z`},
+		{body: `\\b`, want: `\\b`},
+		{body: `[\b]`, want: `[\b]`},
+		{body: `[]\b]\b`, want: `[]\b]\y`},
+		{body: `[[:alpha:]\b]\b`, want: `[[:alpha:]\b]\y`},
+		{body: `(?:acme|globex)-\d+`, want: `(?:acme|globex)-\d+`},
+		{body: `(?i)^renovate`, want: `(?i)^renovate`},
+		{body: `(?is)a.b`, want: `(?is)a.b`},
+		{body: `(?P<name>bot)`, construct: `(?P<name>...)`},
+		{body: `(?<name>bot)`, construct: `(?P<name>...)`},
+		{body: `\Q[bot]\E`, construct: `\Q`},
+		{body: `\p{Greek}`, construct: `\p`},
+		{body: `\PL`, construct: `\P`},
+		{body: `a\C`, construct: `\C`},
+		{body: `bot(?i)s`, construct: `(?i)`},
+		{body: `(?i:bot)`, construct: `(?i)`},
+		{body: `(?m)^bot$`, construct: `(?m)`},
+		{body: `(?U)a+`, construct: `(?U)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			got, err := PostgresRegex(tt.body)
+			if tt.construct == "" {
+				if err != nil || got != tt.want {
+					t.Errorf("PostgresRegex(%q) = (%q, %v), want %q", tt.body, got, err, tt.want)
+				}
+				return
+			}
+
+			var unsupported *UnsupportedRegexError
+			if !errors.Is(err, ErrInvalidRegex) || !errors.As(err, &unsupported) {
+				t.Fatalf("PostgresRegex(%q) error = %v, want unsupported syntax", tt.body, err)
+			}
+			if unsupported.Construct != tt.construct {
+				t.Errorf(
+					"PostgresRegex(%q) rejected %q, want %q",
+					tt.body,
+					unsupported.Construct,
+					tt.construct,
+				)
+			}
+		})
+	}
+}
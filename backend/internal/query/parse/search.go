@@ -0,0 +1,65 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SearchConfig is the Postgres text search configuration of notifications.search_vector
+const SearchConfig = "english"
+
+// SortRelevance orders free-text search results by rank
+const SortRelevance = "relevance"
+
+// searchWordPattern matches the words free text is split into
+var searchWordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// SearchWords splits text into lowercase words, dropping punctuation
+func SearchWords(text string) []string {
+	return searchWordPattern.FindAllString(strings.ToLower(text), -1)
+}
+
+// PrefixSearchQuery builds a to_tsquery expression matching every word of text as a prefix,
+// so octo matches octocat. Only letters and digits are kept, so the result is always valid
+// tsquery syntax. Returns "" if text has no words.
+func PrefixSearchQuery(text string) string {
+	words := SearchWords(text)
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// PhraseSearchQuery builds a websearch_to_tsquery expression matching text as a phrase
+func PhraseSearchQuery(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, " ") + `"`
+}
+
+// HasFreeText checks if the AST contains free text outside NOT expressions
+func HasFreeText(node Node) bool {
+	switch n := node.(type) {
+	case *FreeText:
+		return true
+	case *BinaryExpr:
+		return HasFreeText(n.Left) || HasFreeText(n.Right)
+	case *ParenExpr:
+		return HasFreeText(n.Expr)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSearchWords(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"Fix Bug", []string{"fix", "bug"}},
+		{"cli/go-gh", []string{"cli", "go", "gh"}},
+		{"dependabot[bot]", []string{"dependabot", "bot"}},
+		{"fix_flaky_test", []string{"fix", "flaky", "test"}},
+		{"v2.0", []string{"v2", "0"}},
+		{"über", []string{"über"}},
+		{"[.]", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := SearchWords(tt.text); !slices.Equal(got, tt.expected) {
+				t.Errorf("SearchWords(%q) = %v, want %v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPrefixSearchQuery(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"octo", "octo:*"},
+		{"Go-GH", "go:* & gh:*"},
+		// tsquery operators are dropped rather than passed through
+		{"a&b|!c", "a:* & b:* & c:*"},
+		{"'quoted':*", "quoted:*"},
+		{"--", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := PrefixSearchQuery(tt.text); got != tt.expected {
+				t.Errorf("PrefixSearchQuery(%q) = %q, want %q", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPhraseSearchQuery(t *testing.T) {
+	if got := PhraseSearchQuery(`memory leak`); got != `"memory leak"` {
+		t.Errorf("PhraseSearchQuery() = %q, want %q", got, `"memory leak"`)
+	}
+	if got := PhraseSearchQuery(`say "hi"`); got != `"say  hi "` {
+		t.Errorf("PhraseSearchQuery() = %q, want %q", got, `"say  hi "`)
+	}
+}
+
+func TestHasFreeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     Node
+		expected bool
+	}{
+		{"free text", &FreeText{Text: "leak"}, true},
+		{
+			"free text in AND",
+			&BinaryExpr{Op: "AND", Left: &Term{Field: "is"}, Right: &FreeText{Text: "leak"}},
+			true,
+		},
+		{"free text in parens", &ParenExpr{Expr: &FreeText{Text: "leak"}}, true},
+		{"negated free text", &NotExpr{Expr: &FreeText{Text: "leak"}}, false},
+		{"term only", &Term{Field: "is", Values: []string{"unread"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasFreeText(tt.node); got != tt.expected {
+				t.Errorf("HasFreeText() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Error definitions
+var (
+	ErrInvalidSortKey = errors.New("invalid sort key")
+)
+
+// SortField is the field name of sort: terms
+const SortField = "sort"
+
+// sortFieldDescending maps sortable fields to their default direction (true = descending).
+// Times, numbers and relevance default to highest first, text fields to alphabetical.
+var sortFieldDescending = map[string]bool{
+	"updated":  true,
+	"created":  true,
+	"imported": true,
+	"number":   true,
+	"repo":     false,
+	"title":    false,
+	"author":   false,
+	// Free-text search rank, best match first
+	SortRelevance: true,
+}
+
+// SortKey is a single ordering key from a sort: term
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSortKey parses a sort value like updated, updated-asc or repo-desc
+func ParseSortKey(value string) (SortKey, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	field, direction := value, ""
+	if idx := strings.LastIndex(value, "-"); idx > 0 {
+		field, direction = value[:idx], value[idx+1:]
+	}
+
+	descending, ok := sortFieldDescending[field]
+	if !ok {
+		return SortKey{}, errors.Join(ErrInvalidSortKey, fmt.Errorf("key: %s", value))
+	}
+
+	switch direction {
+	case "":
+	case "asc":
+		descending = false
+	case "desc":
+		descending = true
+	default:
+		return SortKey{}, errors.Join(ErrInvalidSortKey, fmt.Errorf("key: %s", value))
+	}
+
+	return SortKey{Field: field, Descending: descending}, nil
+}
+
+// SortKeys returns the sortable field names, sorted
+func SortKeys() []string {
+	keys := make([]string, 0, len(sortFieldDescending))
+	for key := range sortFieldDescending {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortKeyVariants returns every accepted sort value, with and without a direction
+func sortKeyVariants() []string {
+	var variants []string
+	for _, key := range SortKeys() {
+		variants = append(variants, key, key+"-asc", key+"-desc")
+	}
+	return variants
+}
+
+// IsSortTerm checks if a node is a sort: term
+func IsSortTerm(node Node) bool {
+	term, ok := node.(*Term)
+	return ok && strings.EqualFold(strings.TrimSpace(term.Field), SortField)
+}
+
+// HasSort checks if the AST contains a sort: term anywhere
+func HasSort(node Node) bool {
+	switch n := node.(type) {
+	case *Term:
+		return IsSortTerm(n)
+	case *BinaryExpr:
+		return HasSort(n.Left) || HasSort(n.Right)
+	case *NotExpr:
+		return HasSort(n.Expr)
+	case *ParenExpr:
+		return HasSort(n.Expr)
+	default:
+		return false
+	}
+}
+
+// SplitSort separates sort: terms from the filter part of a query.
+// Sort terms are only taken from the top-level AND chain (including parentheses);
+// the validator rejects them anywhere else. Returns the remaining filter (nil if the
+// query only sorts) and the sort terms in query order.
+func SplitSort(node Node) (Node, []*Term) {
+	switch n := node.(type) {
+	case *Term:
+		if IsSortTerm(n) {
+			return nil, []*Term{n}
+		}
+		return n, nil
+	case *BinaryExpr:
+		if n.Op != "AND" {
+			return n, nil
+		}
+		left, leftSort := SplitSort(n.Left)
+		right, rightSort := SplitSort(n.Right)
+		sorts := append(leftSort, rightSort...)
+		switch {
+		case left == nil:
+			return right, sorts
+		case right == nil:
+			return left, sorts
+		case len(sorts) == 0:
+			return n, nil
+		default:
+			return &BinaryExpr{Op: n.Op, Left: left, Right: right}, sorts
+		}
+	case *ParenExpr:
+		inner, sorts := SplitSort(n.Expr)
+		if inner == nil {
+			return nil, sorts
+		}
+		if len(sorts) == 0 {
+			return n, nil
+		}
+		return &ParenExpr{Expr: inner}, sorts
+	default:
+		return node, nil
+	}
+}
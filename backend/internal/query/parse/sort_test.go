@@ -0,0 +1,131 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"testing"
+)
+
+func TestParseSortKey(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected SortKey
+		hasError bool
+	}{
+		{value: "updated", expected: SortKey{Field: "updated", Descending: true}},
+		{value: "updated-asc", expected: SortKey{Field: "updated", Descending: false}},
+		{value: "Created-DESC", expected: SortKey{Field: "created", Descending: true}},
+		{value: "repo", expected: SortKey{Field: "repo", Descending: false}},
+		{value: "repo-desc", expected: SortKey{Field: "repo", Descending: true}},
+		{value: "number", expected: SortKey{Field: "number", Descending: true}},
+		{value: "relevance", expected: SortKey{Field: "relevance", Descending: true}},
+		{value: "priority", hasError: true},
+		{value: "updated-sideways", hasError: true},
+		{value: "-asc", hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			key, err := ParseSortKey(tt.value)
+			if tt.hasError {
+				if err == nil {
+					t.Error("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, key)
+			}
+		})
+	}
+}
+
+func TestSplitSort(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectFilter string // "" means no filter remains
+		expectSorts  []string
+	}{
+		{
+			name:        "sort only",
+			input:       "sort:updated-asc",
+			expectSorts: []string{"sort:updated-asc"},
+		},
+		{
+			name:         "sort with filter",
+			input:        "reason:review_requested sort:updated-asc",
+			expectFilter: "reason:review_requested",
+			expectSorts:  []string{"sort:updated-asc"},
+		},
+		{
+			name:         "sort inside parentheses",
+			input:        "(is:unread sort:repo) AND type:Issue",
+			expectFilter: "((is:unread) AND type:Issue)",
+			expectSorts:  []string{"sort:repo"},
+		},
+		{
+			name:         "multiple sorts keep query order",
+			input:        "sort:repo is:unread sort:number",
+			expectFilter: "is:unread",
+			expectSorts:  []string{"sort:repo", "sort:number"},
+		},
+		{
+			name:         "sort under OR is left in place",
+			input:        "is:unread OR sort:repo",
+			expectFilter: "(is:unread OR sort:repo)",
+		},
+		{
+			name:         "no sort",
+			input:        "is:unread type:Issue",
+			expectFilter: "(is:unread AND type:Issue)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := NewLexer(tt.input).Tokenize()
+			if err != nil {
+				t.Fatalf("lexer error: %v", err)
+			}
+			ast, err := NewParser(tokens).Parse()
+			if err != nil {
+				t.Fatalf("parser error: %v", err)
+			}
+
+			filter, sorts := SplitSort(ast)
+			if tt.expectFilter == "" {
+				if filter != nil {
+					t.Errorf("expected no filter, got %q", filter.String())
+				}
+			} else if filter == nil || filter.String() != tt.expectFilter {
+				t.Errorf("expected filter %q, got %v", tt.expectFilter, filter)
+			}
+
+			if len(sorts) != len(tt.expectSorts) {
+				t.Fatalf("expected %d sort terms, got %d", len(tt.expectSorts), len(sorts))
+			}
+			for i, sort := range sorts {
+				if sort.String() != tt.expectSorts[i] {
+					t.Errorf("sort %d: expected %q, got %q", i, tt.expectSorts[i], sort.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error definitions
+var (
+	ErrInvalidTimeValue  = errors.New("invalid time value")
+	ErrInvalidComparison = errors.New("invalid comparison operator")
+)
+
+const dateLayout = "2006-01-02"
+
+// relativeDurationPattern matches relative durations like 12h, 7d, 2w, 3mo, 1y
+var relativeDurationPattern = regexp.MustCompile(`^(\d+)(h|d|w|mo|y)$`)
+
+// TimeValue is a resolved time value from a query.
+// Days (2024-01-01, today, yesterday, tomorrow) cover [Start, End).
+// Instants (relative durations like 7d, RFC3339 timestamps, now) have Start == End.
+type TimeValue struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsInstant reports whether the value is a single point in time rather than a day
+func (v TimeValue) IsInstant() bool {
+	return v.Start.Equal(v.End)
+}
+
+// ParseTimeValue resolves a query time value relative to now.
+// Supported forms:
+// - Dates: 2024-01-01 (the whole day, in now's location)
+// - Keywords: today, yesterday, tomorrow (whole days), now (instant)
+// - Relative durations: 12h, 7d, 2w, 3mo, 1y (the instant that long before now)
+// - Timestamps: 2024-01-01T10:00:00Z (RFC3339, must be quoted in queries)
+func ParseTimeValue(value string, now time.Time) (TimeValue, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch value {
+	case "now":
+		return TimeValue{Start: now, End: now}, nil
+	case "today":
+		return dayValue(today), nil
+	case "yesterday":
+		return dayValue(today.AddDate(0, 0, -1)), nil
+	case "tomorrow":
+		return dayValue(today.AddDate(0, 0, 1)), nil
+	}
+
+	if match := relativeDurationPattern.FindStringSubmatch(value); match != nil {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil {
+			return TimeValue{}, errors.Join(ErrInvalidTimeValue, fmt.Errorf("value: %s", value))
+		}
+		var instant time.Time
+		switch match[2] {
+		case "h":
+			instant = now.Add(-time.Duration(amount) * time.Hour)
+		case "d":
+			instant = now.AddDate(0, 0, -amount)
+		case "w":
+			instant = now.AddDate(0, 0, -7*amount)
+		case "mo":
+			instant = now.AddDate(0, -amount, 0)
+		case "y":
+			instant = now.AddDate(-amount, 0, 0)
+		}
+		return TimeValue{Start: instant, End: instant}, nil
+	}
+
+	if day, err := time.ParseInLocation(dateLayout, value, loc); err == nil {
+		return dayValue(day), nil
+	}
+
+	if instant, err := time.Parse(time.RFC3339, strings.ToUpper(value)); err == nil {
+		return TimeValue{Start: instant, End: instant}, nil
+	}
+
+	return TimeValue{}, errors.Join(ErrInvalidTimeValue, fmt.Errorf("value: %s", value))
+}
+
+// dayValue returns the TimeValue covering the day starting at start
+func dayValue(start time.Time) TimeValue {
+	return TimeValue{Start: start, End: start.AddDate(0, 0, 1)}
+}
+
+// TimeBounds is the set of times matched by a time comparison.
+// A nil bound is unbounded on that side.
+type TimeBounds struct {
+	After           *time.Time
+	AfterInclusive  bool
+	Before          *time.Time
+	BeforeInclusive bool
+}
+
+// Contains reports whether t falls within the bounds
+func (b TimeBounds) Contains(t time.Time) bool {
+	if b.After != nil {
+		if t.Before(*b.After) || (!b.AfterInclusive && t.Equal(*b.After)) {
+			return false
+		}
+	}
+	if b.Before != nil {
+		if t.After(*b.Before) || (!b.BeforeInclusive && t.Equal(*b.Before)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveTimeBounds turns a time comparison into concrete bounds.
+// op is one of ">", ">=", "<", "<=", ".." (upper holds the range end) or "" for a plain
+// field:value term. Comparisons order times, so updated:<7d means "updated more than 7 days ago".
+// A plain day value matches that whole day; a plain instant matches everything since it.
+func ResolveTimeBounds(op, value, upper string, now time.Time) (TimeBounds, error) {
+	tv, err := ParseTimeValue(value, now)
+	if err != nil {
+		return TimeBounds{}, err
+	}
+
+	switch op {
+	case "":
+		if tv.IsInstant() {
+			return TimeBounds{After: &tv.Start, AfterInclusive: true}, nil
+		}
+		return TimeBounds{After: &tv.Start, AfterInclusive: true, Before: &tv.End}, nil
+	case ">":
+		if tv.IsInstant() {
+			return TimeBounds{After: &tv.End}, nil
+		}
+		return TimeBounds{After: &tv.End, AfterInclusive: true}, nil
+	case ">=":
+		return TimeBounds{After: &tv.Start, AfterInclusive: true}, nil
+	case "<":
+		return TimeBounds{Before: &tv.Start}, nil
+	case "<=":
+		if tv.IsInstant() {
+			return TimeBounds{Before: &tv.End, BeforeInclusive: true}, nil
+		}
+		return TimeBounds{Before: &tv.End}, nil
+	case OpRange:
+		upperValue, err := ParseTimeValue(upper, now)
+		if err != nil {
+			return TimeBounds{}, err
+		}
+		bounds := TimeBounds{After: &tv.Start, AfterInclusive: true, Before: &upperValue.End}
+		if upperValue.IsInstant() {
+			bounds.BeforeInclusive = true
+		}
+		return bounds, nil
+	default:
+		return TimeBounds{}, errors.Join(ErrInvalidComparison, fmt.Errorf("operator: %s", op))
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package parse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeValue(t *testing.T) {
+	now := time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name          string
+		value         string
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name:          "date",
+			value:         "2024-01-01",
+			expectedStart: day(2024, 1, 1),
+			expectedEnd:   day(2024, 1, 2),
+		},
+		{
+			name:          "today",
+			value:         "today",
+			expectedStart: day(2024, 3, 15),
+			expectedEnd:   day(2024, 3, 16),
+		},
+		{
+			name:          "yesterday",
+			value:         "Yesterday",
+			expectedStart: day(2024, 3, 14),
+			expectedEnd:   day(2024, 3, 15),
+		},
+		{
+			name:          "now",
+			value:         "now",
+			expectedStart: now,
+			expectedEnd:   now,
+		},
+		{
+			name:          "hours",
+			value:         "12h",
+			expectedStart: now.Add(-12 * time.Hour),
+			expectedEnd:   now.Add(-12 * time.Hour),
+		},
+		{
+			name:          "weeks",
+			value:         "2w",
+			expectedStart: now.AddDate(0, 0, -14),
+			expectedEnd:   now.AddDate(0, 0, -14),
+		},
+		{
+			name:          "months",
+			value:         "3mo",
+			expectedStart: now.AddDate(0, -3, 0),
+			expectedEnd:   now.AddDate(0, -3, 0),
+		},
+		{
+			name:          "timestamp",
+			value:         "2024-01-01T10:00:00Z",
+			expectedStart: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tv, err := ParseTimeValue(tt.value, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tv.Start.Equal(tt.expectedStart) {
+				t.Errorf("expected start %v, got %v", tt.expectedStart, tv.Start)
+			}
+			if !tv.End.Equal(tt.expectedEnd) {
+				t.Errorf("expected end %v, got %v", tt.expectedEnd, tv.End)
+			}
+		})
+	}
+
+	for _, value := range []string{"", "7", "7x", "last-week", "2024-13-01"} {
+		if _, err := ParseTimeValue(value, now); err == nil {
+			t.Errorf("expected error for %q, got none", value)
+		}
+	}
+}
+
+func TestResolveTimeBounds(t *testing.T) {
+	now := time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		op       string
+		value    string
+		upper    string
+		inside   []time.Time
+		outside  []time.Time
+		hasError bool
+	}{
+		{
+			name:    "plain day matches the whole day",
+			value:   "2024-03-10",
+			inside:  []time.Time{time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+			outside: []time.Time{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "plain duration matches since",
+			value:   "7d",
+			inside:  []time.Time{now.AddDate(0, 0, -7), now},
+			outside: []time.Time{now.AddDate(0, 0, -8)},
+		},
+		{
+			name:    "greater than day excludes the day",
+			op:      ">",
+			value:   "2024-03-10",
+			inside:  []time.Time{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+			outside: []time.Time{time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "greater than or equal day includes the day",
+			op:      ">=",
+			value:   "2024-03-10",
+			inside:  []time.Time{time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+			outside: []time.Time{time.Date(2024, 3, 9, 23, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:    "less than duration is older",
+			op:      "<",
+			value:   "7d",
+			inside:  []time.Time{now.AddDate(0, 0, -8)},
+			outside: []time.Time{now.AddDate(0, 0, -7), now},
+		},
+		{
+			name:    "less than or equal day includes the day",
+			op:      "<=",
+			value:   "2024-03-10",
+			inside:  []time.Time{time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC)},
+			outside: []time.Time{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:  "range of days is inclusive",
+			op:    OpRange,
+			value: "2024-03-01",
+			upper: "2024-03-10",
+			inside: []time.Time{
+				time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC),
+			},
+			outside: []time.Time{
+				time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "invalid range bound",
+			op:       OpRange,
+			value:    "2024-03-01",
+			upper:    "soon",
+			hasError: true,
+		},
+		{
+			name:     "invalid operator",
+			op:       "=",
+			value:    "2024-03-01",
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounds, err := ResolveTimeBounds(tt.op, tt.value, tt.upper, now)
+			if tt.hasError {
+				if err == nil {
+					t.Error("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, ts := range tt.inside {
+				if !bounds.Contains(ts) {
+					t.Errorf("expected %v to be within bounds", ts)
+				}
+			}
+			for _, ts := range tt.outside {
+				if bounds.Contains(ts) {
+					t.Errorf("expected %v to be outside bounds", ts)
+				}
+			}
+		})
+	}
+}
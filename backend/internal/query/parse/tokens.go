@@ -22,24 +22,26 @@ type TokenType int
 
 // TokenType constants
 const (
-	TokenEOF      TokenType = iota
-	TokenLParen             // (
-	TokenRParen             // )
-	TokenAnd                // AND
-	TokenOr                 // OR
-	TokenNot                // NOT or -
-	TokenColon              // :
-	TokenComma              // ,
-	TokenField              // field name
-	TokenValue              // field value
-	TokenFreeText           // free text word
+	TokenEOF        TokenType = iota
+	TokenLParen               // (
+	TokenRParen               // )
+	TokenAnd                  // AND
+	TokenOr                   // OR
+	TokenNot                  // NOT or -
+	TokenColon                // :
+	TokenComma                // ,
+	TokenField                // field name
+	TokenValue                // field value
+	TokenFreeText             // free text word
+	TokenComparator           // >, >=, <, <=
 )
 
 // Token represents a lexical token
 type Token struct {
 	Type  TokenType
 	Value string
-	Pos   int // Position in original string
+	Pos   int // Byte offset of the token's first character in the original string
+	End   int // Byte offset just past the token's last character
 }
 
 // String returns a string representation of the token type
@@ -67,6 +69,8 @@ func (t TokenType) String() string {
 		return "VALUE"
 	case TokenFreeText:
 		return "FREETEXT"
+	case TokenComparator:
+		return "COMPARATOR"
 	default:
 		return fmt.Sprintf("UNKNOWN(%d)", t)
 	}
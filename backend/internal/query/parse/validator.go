@@ -18,45 +18,106 @@ package parse
 import (
 	"errors"
 	"fmt"
+	"regexp/syntax"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Error definitions
 var (
-	ErrValidationFailed = errors.New("validation failed")
+	ErrValidationFailed  = errors.New("validation failed")
+	ErrUnknownField      = errors.New("unknown field")
+	ErrInvalidFieldValue = errors.New("invalid field value")
+	ErrInvalidSort       = errors.New("invalid sort")
 )
 
+// timeValueExpected lists the accepted time value forms, for error reporting
+var timeValueExpected = []string{
+	"YYYY-MM-DD", "today", "yesterday", "tomorrow", "now", "12h", "7d", "2w", "3mo", "1y",
+}
+
+// numberValueExpected lists the accepted number value forms, for error reporting
+var numberValueExpected = []string{"42", ">10", ">=10", "<10", "<=10", "10..20"}
+
+// comparisonFields lists the fields that accept comparisons, for error reporting
+var comparisonFields = []string{
+	"updated", "created", "imported", "snoozed_until", "number", "comments",
+}
+
+// booleanExpected lists the accepted boolean values, for error reporting
+var booleanExpected = []string{"true", "false", "yes", "no", "1", "0"}
+
 // Validator validates query AST nodes
 type Validator struct {
-	errors []string
+	errors []*QueryError
 }
 
 // NewValidator creates a new validator
 func NewValidator() *Validator {
 	return &Validator{
-		errors: []string{},
+		errors: []*QueryError{},
 	}
 }
 
-// Validate validates an AST node and returns an error if invalid
+// Validate validates an AST node and returns an error if invalid.
+// Every problem found is included as a *QueryError (see QueryErrors).
 func (v *Validator) Validate(node Node) error {
 	if node == nil {
 		return nil
 	}
 
-	v.errors = []string{}
+	v.errors = []*QueryError{}
 	v.validateNode(node)
 
-	if len(v.errors) > 0 {
-		return errors.Join(
-			ErrValidationFailed,
-			fmt.Errorf("errors: %s", strings.Join(v.errors, "; ")),
+	// sort: orders the whole result, so it can't be nested under OR or NOT
+	filter, sortTerms := SplitSort(node)
+	for _, term := range nestedSortTerms(filter) {
+		v.addError(
+			ErrInvalidSort,
+			CodeInvalidSort,
+			term.Span(),
+			"sort: cannot be used inside OR or NOT expressions",
+		)
+	}
+	if term := relevanceSortTerm(sortTerms); term != nil && !HasFreeText(filter) {
+		v.addError(
+			ErrInvalidSort,
+			CodeInvalidSort,
+			term.Span(),
+			"sort:relevance requires free-text search terms",
 		)
 	}
 
+	if len(v.errors) > 0 {
+		errs := []error{ErrValidationFailed}
+		for _, err := range v.errors {
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+
 	return nil
 }
 
+// addError records a validation error and returns it so callers can add details
+func (v *Validator) addError(
+	sentinel error,
+	code ErrorCode,
+	span Span,
+	message string,
+) *QueryError {
+	err := &QueryError{
+		Code:    code,
+		Message: message,
+		Start:   span.Start,
+		End:     span.End,
+		err:     sentinel,
+	}
+	v.errors = append(v.errors, err)
+	return err
+}
+
 // validateNode recursively validates a node
 func (v *Validator) validateNode(node Node) {
 	switch n := node.(type) {
@@ -66,6 +127,8 @@ func (v *Validator) validateNode(node Node) {
 		v.validateNotExpr(n)
 	case *Term:
 		v.validateTerm(n)
+	case *Comparison:
+		v.validateComparison(n)
 	case *FreeText:
 		// Free text is always valid
 	case *ParenExpr:
@@ -89,118 +152,312 @@ func (v *Validator) validateTerm(node *Term) {
 	field := strings.ToLower(strings.TrimSpace(node.Field))
 
 	// Check if field is known
-	if !isKnownField(field) {
-		v.errors = append(v.errors, fmt.Sprintf("unknown field: %s", field))
+	spec, ok := LookupField(field)
+	if !ok {
+		v.unknownField(field, node.FieldSpan)
 		return
 	}
 
 	// Validate field-specific values
-	switch field {
-	case "in":
-		v.validateInValues(node.Values)
-	case "is":
-		v.validateIsValues(node.Values)
-	case "read", "archived", "muted", "snoozed", "filtered":
-		v.validateBooleanValues(field, node.Values)
+	for i, value := range node.Values {
+		span := node.Span()
+		if i < len(node.ValueSpans) {
+			span = node.ValueSpans[i]
+		}
+
+		if SupportsPatterns(spec.Kind) {
+			v.validatePatternValue(field, value, span)
+		}
+
+		switch spec.Kind {
+		case FieldIn:
+			v.validateInValue(value, span)
+		case FieldIs:
+			v.validateIsValue(value, span)
+		case FieldBoolean, FieldSnoozed:
+			v.validateBooleanValue(field, value, span)
+		case FieldMerged:
+			v.validateMergedValue(value, span)
+		case FieldTime:
+			v.validateTimeValue(field, value, span)
+		case FieldSort:
+			v.validateSortValue(value, span)
+		case FieldNumber:
+			v.validateNumberValue(field, value, span)
+		case FieldEnum, FieldNo, FieldHas:
+			v.validateEnumValue(spec, value, span)
+		case FieldContains, FieldPrefix, FieldEquals, FieldTags, FieldArray, FieldView,
+			FieldAccount:
+			// Any value is valid (views are checked when Expand inlines them)
+		}
 	}
 }
 
-// validateInValues validates values for the in: operator
-func (v *Validator) validateInValues(values []string) {
-	validValues := map[string]bool{
-		"inbox":    true,
-		"archive":  true,
-		"snoozed":  true,
-		"filtered": true,
-		"anywhere": true,
+// unknownField reports a field that isn't in the registry, suggesting close matches
+func (v *Validator) unknownField(field string, span Span) {
+	message := fmt.Sprintf("unknown field: %s", field)
+	suggestions := Suggest(field, FieldNames())
+	if len(suggestions) > 0 {
+		message += fmt.Sprintf(" (did you mean %s:?)", suggestions[0])
 	}
 
-	for _, value := range values {
-		value = strings.ToLower(strings.TrimSpace(value))
-		if !validValues[value] {
-			v.errors = append(
-				v.errors,
-				fmt.Sprintf(
-					"invalid value for in: operator: %s (valid: inbox, archive, snoozed, filtered, anywhere)",
-					value,
-				),
+	err := v.addError(ErrUnknownField, CodeUnknownField, span, message)
+	err.Expected = FieldNames()
+	err.Suggestions = suggestions
+}
+
+// validateComparison validates a comparison (field:>value, field:low..high)
+func (v *Validator) validateComparison(node *Comparison) {
+	field := strings.ToLower(strings.TrimSpace(node.Field))
+
+	spec, ok := LookupField(field)
+	if !ok {
+		v.unknownField(field, node.FieldSpan)
+		return
+	}
+
+	if !SupportsComparison(spec.Kind) {
+		err := v.addError(
+			ErrInvalidComparison,
+			CodeInvalidComparison,
+			Span{Start: node.FieldSpan.Start, End: node.ValueSpan.End},
+			fmt.Sprintf(
+				"field %s does not support comparisons (supported: %s)",
+				field,
+				strings.Join(comparisonFields, ", "),
+			),
+		)
+		err.Expected = comparisonFields
+		return
+	}
+
+	if spec.Kind == FieldNumber {
+		if _, err := ResolveNumberBounds(node.Op, node.Value, node.Upper); err != nil {
+			qerr := v.addError(
+				ErrInvalidComparison,
+				CodeInvalidValue,
+				node.ValueSpan,
+				fmt.Sprintf("invalid number comparison for %s: %s", field, node.String()),
 			)
+			qerr.Expected = numberValueExpected
 		}
+		return
+	}
+
+	if _, err := ResolveTimeBounds(node.Op, node.Value, node.Upper, time.Now()); err != nil {
+		qerr := v.addError(
+			ErrInvalidComparison,
+			CodeInvalidValue,
+			node.ValueSpan,
+			fmt.Sprintf("invalid time comparison for %s: %s", field, node.String()),
+		)
+		qerr.Expected = timeValueExpected
 	}
 }
 
-// validateIsValues validates values for the is: operator
-func (v *Validator) validateIsValues(values []string) {
-	validValues := map[string]bool{
-		"unread":   true,
-		"read":     true,
-		"archived": true,
-		"muted":    true,
-		"snoozed":  true,
-		"starred":  true,
-		"filtered": true,
+// validateNumberValue validates a value for a number field
+func (v *Validator) validateNumberValue(field, value string, span Span) {
+	if _, err := ParseNumberValue(value); err != nil {
+		qerr := v.addError(
+			ErrInvalidFieldValue,
+			CodeInvalidValue,
+			span,
+			fmt.Sprintf("invalid number value for %s: %s (expected a whole number)", field, value),
+		)
+		qerr.Expected = numberValueExpected
 	}
+}
 
-	for _, value := range values {
-		value = strings.ToLower(strings.TrimSpace(value))
-		if !validValues[value] {
-			v.errors = append(
-				v.errors,
-				fmt.Sprintf(
-					"invalid value for is: operator: %s (valid: unread, read, archived, muted, snoozed, starred, filtered)",
-					value,
-				),
-			)
+// validateTimeValue validates a value for a time field
+func (v *Validator) validateTimeValue(field, value string, span Span) {
+	if _, err := ParseTimeValue(value, time.Now()); err != nil {
+		qerr := v.addError(
+			ErrInvalidFieldValue,
+			CodeInvalidValue,
+			span,
+			fmt.Sprintf(
+				"invalid time value for %s: %s (valid: YYYY-MM-DD, today, yesterday, "+
+					"tomorrow, now, or a duration like 12h, 7d, 2w, 3mo, 1y)",
+				field,
+				value,
+			),
+		)
+		qerr.Expected = timeValueExpected
+		qerr.Suggestions = Suggest(value, []string{"today", "yesterday", "tomorrow", "now"})
+	}
+}
+
+// validatePatternValue validates a /.../ value, so a bad regex, or one Postgres would read
+// differently, fails before it reaches Postgres
+func (v *Validator) validatePatternValue(field, value string, span Span) {
+	kind, body := ClassifyPattern(value)
+	if kind != PatternRegex {
+		return
+	}
+	_, err := CompileRegex(body)
+	if err == nil {
+		_, err = PostgresRegex(body)
+	}
+	if err != nil {
+		var syntaxErr *syntax.Error
+		var unsupportedErr *UnsupportedRegexError
+		reason := err.Error()
+		switch {
+		case errors.As(err, &syntaxErr):
+			reason = syntaxErr.Code.String()
+		case errors.As(err, &unsupportedErr):
+			reason = unsupportedErr.Error()
 		}
+		v.addError(
+			ErrInvalidRegex,
+			CodeInvalidRegex,
+			span,
+			fmt.Sprintf("invalid regular expression for %s: %s (%s)", field, value, reason),
+		)
 	}
 }
 
-// validateBooleanValues validates boolean values
-func (v *Validator) validateBooleanValues(field string, values []string) {
-	validValues := map[string]bool{
-		"true":  true,
-		"false": true,
-		"yes":   true,
-		"no":    true,
-		"1":     true,
-		"0":     true,
+// validateSortValue validates a value for the sort: operator
+func (v *Validator) validateSortValue(value string, span Span) {
+	if _, err := ParseSortKey(value); err != nil {
+		qerr := v.addError(
+			ErrInvalidSort,
+			CodeInvalidValue,
+			span,
+			fmt.Sprintf(
+				"invalid sort key: %s (valid: updated, created, imported, number, repo, "+
+					"title, author, relevance, optionally suffixed with -asc or -desc)",
+				value,
+			),
+		)
+		qerr.Expected = SortKeys()
+		qerr.Suggestions = Suggest(value, sortKeyVariants())
 	}
+}
 
-	for _, value := range values {
-		value = strings.ToLower(strings.TrimSpace(value))
-		if !validValues[value] {
-			v.errors = append(
-				v.errors,
-				fmt.Sprintf(
-					"invalid boolean value for %s: %s (valid: true, false, yes, no, 1, 0)",
-					field,
-					value,
-				),
-			)
+// nestedSortTerms returns sort: terms left in the filter after SplitSort,
+// which are the ones nested under OR or NOT
+func nestedSortTerms(node Node) []*Term {
+	switch n := node.(type) {
+	case *Term:
+		if IsSortTerm(n) {
+			return []*Term{n}
 		}
+	case *BinaryExpr:
+		return append(nestedSortTerms(n.Left), nestedSortTerms(n.Right)...)
+	case *NotExpr:
+		return nestedSortTerms(n.Expr)
+	case *ParenExpr:
+		return nestedSortTerms(n.Expr)
+	}
+	return nil
+}
+
+// relevanceSortTerm returns the first sort: term that orders by search rank, if any
+func relevanceSortTerm(terms []*Term) *Term {
+	for _, term := range terms {
+		for _, value := range term.Values {
+			if key, err := ParseSortKey(value); err == nil && key.Field == SortRelevance {
+				return term
+			}
+		}
+	}
+	return nil
+}
+
+// validateInValue validates a value for the in: operator
+func (v *Validator) validateInValue(value string, span Span) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !slices.Contains(InValues, value) {
+		v.invalidValue(
+			span,
+			value,
+			InValues,
+			fmt.Sprintf(
+				"invalid value for in: operator: %s (valid: inbox, archive, snoozed, filtered, anywhere)",
+				value,
+			),
+		)
+	}
+}
+
+// validateIsValue validates a value for the is: operator
+func (v *Validator) validateIsValue(value string, span Span) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !slices.Contains(IsValues, value) {
+		v.invalidValue(
+			span,
+			value,
+			IsValues,
+			fmt.Sprintf(
+				"invalid value for is: operator: %s "+
+					"(valid: unread, read, archived, muted, snoozed, starred, filtered, draft, "+
+					"answered, stale)",
+				value,
+			),
+		)
+	}
+}
+
+// validateEnumValue validates a value for a field with a fixed set of values (spec.Values)
+func (v *Validator) validateEnumValue(spec FieldSpec, value string, span Span) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !slices.Contains(spec.Values, value) {
+		v.invalidValue(
+			span,
+			value,
+			spec.Values,
+			fmt.Sprintf(
+				"invalid value for %s: %s (valid: %s)",
+				spec.Name,
+				value,
+				strings.Join(spec.Values, ", "),
+			),
+		)
+	}
+}
+
+// validateBooleanValue validates a boolean value
+func (v *Validator) validateBooleanValue(field, value string, span Span) {
+	if _, ok := ParseBool(value); !ok {
+		value = strings.ToLower(strings.TrimSpace(value))
+		v.invalidValue(
+			span,
+			value,
+			booleanExpected,
+			fmt.Sprintf(
+				"invalid boolean value for %s: %s (valid: true, false, yes, no, 1, 0)",
+				field,
+				value,
+			),
+		)
 	}
 }
 
-// isKnownField checks if a field name is supported
-func isKnownField(field string) bool {
-	knownFields := map[string]bool{
-		"in":           true,
-		"is":           true,
-		"repo":         true,
-		"repository":   true,
-		"org":          true,
-		"reason":       true,
-		"type":         true,
-		"subject_type": true,
-		"author":       true,
-		"state":        true,
-		"read":         true,
-		"archived":     true,
-		"muted":        true,
-		"snoozed":      true,
-		"filtered":     true,
-		"tags":         true,
-	}
-
-	return knownFields[field]
+// validateMergedValue validates a value for the merged: field
+func (v *Validator) validateMergedValue(value string, span Span) {
+	if _, ok := ParseMerged(value); !ok {
+		value = strings.ToLower(strings.TrimSpace(value))
+		v.invalidValue(
+			span,
+			value,
+			append(slices.Clone(booleanExpected), "merged", "unmerged"),
+			fmt.Sprintf(
+				"invalid value for merged: %s (valid: true, false, yes, no, 1, 0, merged, unmerged)",
+				value,
+			),
+		)
+	}
+}
+
+// invalidValue reports a value outside a fixed set, suggesting close matches
+func (v *Validator) invalidValue(span Span, value string, valid []string, message string) {
+	suggestions := Suggest(value, valid)
+	if len(suggestions) > 0 {
+		message += fmt.Sprintf(" (did you mean %s?)", suggestions[0])
+	}
+
+	err := v.addError(ErrInvalidFieldValue, CodeInvalidValue, span, message)
+	err.Expected = valid
+	err.Suggestions = suggestions
 }
@@ -27,15 +27,33 @@ import (
 var (
 	ErrTokenizationFailed  = errors.New("tokenization failed")
 	ErrParseFailed         = errors.New("parse failed")
+	ErrExpansionFailed     = errors.New("reference expansion failed")
 	ErrSQLGenerationFailed = errors.New("SQL generation failed")
 )
 
 // Node is an alias for parse.Node for convenience
 type Node = parse.Node
 
-// ParseAndValidate parses a query string and validates it
-// Returns the AST node if successful
-func ParseAndValidate(queryStr string) (Node, error) {
+// QueryError is an alias for parse.QueryError for convenience
+type QueryError = parse.QueryError
+
+// Definitions is an alias for parse.Definitions for convenience
+type Definitions = parse.Definitions
+
+// Reference syntax, re-exported for callers that name views and macros
+const (
+	ViewField   = parse.ViewField
+	MacroPrefix = parse.MacroPrefix
+)
+
+// QueryErrors returns the positioned errors reported for a query, if any
+func QueryErrors(err error) []*QueryError {
+	return parse.QueryErrors(err)
+}
+
+// ParseAndValidate parses a query string, inlines its view: and @macro references
+// from defs and validates it. Returns the AST node if successful
+func ParseAndValidate(queryStr string, defs Definitions) (Node, error) {
 	if queryStr == "" {
 		return nil, nil
 	}
@@ -52,6 +70,11 @@ func ParseAndValidate(queryStr string) (Node, error) {
 		return nil, errors.Join(ErrParseFailed, err)
 	}
 
+	ast, err = parse.Expand(ast, defs)
+	if err != nil {
+		return nil, errors.Join(ErrExpansionFailed, err)
+	}
+
 	validator := parse.NewValidator()
 	if err := validator.Validate(ast); err != nil {
 		return nil, errors.Join(parse.ErrValidationFailed, err)
@@ -60,21 +83,50 @@ func ParseAndValidate(queryStr string) (Node, error) {
 	return ast, nil
 }
 
+// Format prints an AST as a canonical query string (see parse.Format)
+func Format(node Node) string {
+	return parse.Format(node)
+}
+
+// Normalize rewrites a query string in canonical form, keeping its view: and @macro
+// references as written. Saved views, macros and rules store their queries normalized,
+// so they read the same however they were typed and can be rewritten safely in code.
+func Normalize(queryStr string) (string, error) {
+	if queryStr == "" {
+		return "", nil
+	}
+
+	tokens, err := parse.NewLexer(queryStr).Tokenize()
+	if err != nil {
+		return "", errors.Join(ErrTokenizationFailed, err)
+	}
+	ast, err := parse.NewParser(tokens).Parse()
+	if err != nil {
+		return "", errors.Join(ErrParseFailed, err)
+	}
+	return parse.Format(ast), nil
+}
+
 // BuildQuery parses a query string and generates SQL with explicit query context:
 // - Empty query "" → Default inbox: exclude archived, snoozed (active), muted, filtered (backward compatibility)
 // - Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
 // - Query without in: operator (non-empty) → Apply muted-only default (exclude muted unless explicitly requested)
-func BuildQuery(queryStr string, limit, offset int32) (db.NotificationQuery, error) {
-	return BuildQueryWithOptions(queryStr, limit, offset, true)
+func BuildQuery(
+	queryStr string,
+	defs Definitions,
+	limit, offset int32,
+) (db.NotificationQuery, error) {
+	return BuildQueryWithOptions(queryStr, defs, limit, offset, true)
 }
 
 // BuildQueryWithOptions parses a query string and generates SQL with unified business rules
 func BuildQueryWithOptions(
 	queryStr string,
+	defs Definitions,
 	limit, offset int32,
 	includeSubject bool,
 ) (db.NotificationQuery, error) {
-	ast, err := ParseAndValidate(queryStr)
+	ast, err := ParseAndValidate(queryStr, defs)
 	if err != nil {
 		return db.NotificationQuery{}, err
 	}
@@ -95,31 +147,50 @@ func BuildQueryWithOptions(
 	return query, nil
 }
 
-// applyUnifiedDefaults applies default filters based on explicit query context:
+// Implicit defaults added to a query by applyUnifiedDefaults
+const (
+	DefaultsInbox     = "inbox"      // Exclude archived, snoozed (active), muted and filtered
+	DefaultsMutedOnly = "muted-only" // Exclude muted only
+	DefaultsNone      = "none"       // The query handles lifecycle itself
+)
+
+// DefaultsFor reports which implicit defaults apply to a query, based on explicit query context:
 // - Empty query → Default inbox: exclude archived, snoozed (active), muted, filtered (backward compatibility)
 // - Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
 // - Query without in: operator (non-empty) → Apply muted-only default (exclude muted unless explicitly requested)
-func applyUnifiedDefaults(
-	query db.NotificationQuery,
-	ast Node,
-	queryStr string,
-) db.NotificationQuery {
+func DefaultsFor(ast Node, queryStr string) string {
 	// 1. Empty query → Default inbox: exclude archived, snoozed (active), muted, filtered (backward compatibility)
 	if queryStr == "" || ast == nil {
-		return ApplyInboxDefaults(query)
+		return DefaultsInbox
 	}
 
 	// 2. Query with in: operator (any value) → No defaults (in: operator explicitly handles lifecycle)
 	// This includes in:inbox, in:archive, in:snoozed, in:filtered, in:anywhere, etc.
 	if parse.HasInOperator(ast) {
-		return query
+		return DefaultsNone
 	}
 
 	// 3. Query without in: operator (non-empty) → Apply muted-only default
 	// This allows archived, snoozed, and filtered to show unless explicitly excluded
 	// BUT: if query explicitly asks for muted (is:muted or muted:true), don't apply the default
 	if parse.HasExplicitMuted(ast) {
+		return DefaultsNone
+	}
+	return DefaultsMutedOnly
+}
+
+// applyUnifiedDefaults applies the default filters chosen by DefaultsFor
+func applyUnifiedDefaults(
+	query db.NotificationQuery,
+	ast Node,
+	queryStr string,
+) db.NotificationQuery {
+	switch DefaultsFor(ast, queryStr) {
+	case DefaultsInbox:
+		return ApplyInboxDefaults(query)
+	case DefaultsMutedOnly:
+		return ApplyMutedOnlyDefaults(query)
+	default:
 		return query
 	}
-	return ApplyMutedOnlyDefaults(query)
 }
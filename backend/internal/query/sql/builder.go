@@ -19,6 +19,7 @@ package sql
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -29,28 +30,35 @@ import (
 const (
 	queryValueSnoozed  = "snoozed"
 	queryValueFiltered = "filtered"
-	queryValueTrue     = "true"
-	queryValueFalse    = "false"
-	queryValueYes      = "yes"
 )
 
 // Error definitions
 var (
-	ErrUnknownNodeType        = errors.New("unknown node type")
-	ErrUnsupportedField       = errors.New("unsupported field")
-	ErrInvalidInOperatorValue = errors.New("invalid value for in: operator")
-	ErrInvalidIsOperatorValue = errors.New("invalid value for is: operator")
-	ErrInvalidBooleanValue    = errors.New("invalid boolean value")
-	ErrInvalidSnoozedValue    = errors.New("invalid boolean value for snoozed")
-	ErrInvalidMergedValue     = errors.New("invalid value for merged field")
-	ErrTagsFieldRequiresValue = errors.New("tags field requires at least one value")
+	ErrUnknownNodeType           = errors.New("unknown node type")
+	ErrUnsupportedField          = errors.New("unsupported field")
+	ErrInvalidInOperatorValue    = errors.New("invalid value for in: operator")
+	ErrInvalidIsOperatorValue    = errors.New("invalid value for is: operator")
+	ErrInvalidBooleanValue       = errors.New("invalid boolean value")
+	ErrInvalidSnoozedValue       = errors.New("invalid boolean value for snoozed")
+	ErrInvalidMergedValue        = errors.New("invalid value for merged field")
+	ErrTagsFieldRequiresValue    = errors.New("tags field requires at least one value")
+	ErrAccountFieldRequiresValue = errors.New("account field requires at least one value")
+	ErrInvalidTimeValue          = errors.New("invalid time value")
+	ErrInvalidSortKey            = errors.New("invalid sort key")
+	ErrInvalidNoValue            = errors.New("invalid value for no: operator")
+	ErrInvalidHasValue           = errors.New("invalid value for has: operator")
+	ErrInvalidNumberValue        = errors.New("invalid number value")
+	ErrUnexpandedView            = errors.New("view: must be expanded before building SQL")
 )
 
 // Builder builds SQL queries from AST nodes
 type Builder struct {
-	joins      map[string]bool
-	args       []interface{}
-	argCounter int
+	joins         map[string]bool
+	args          []interface{}
+	argCounter    int
+	now           func() time.Time // Resolves relative time values (7d, today)
+	notDepth      int              // Number of enclosing NOT expressions
+	searchQueries []string         // tsquery expressions of free text outside NOT, for ranking
 }
 
 // NewBuilder creates a new SQL builder
@@ -59,6 +67,7 @@ func NewBuilder() *Builder {
 		joins:      make(map[string]bool),
 		args:       []interface{}{},
 		argCounter: 0,
+		now:        time.Now,
 	}
 }
 
@@ -77,7 +86,20 @@ func (b *Builder) Build(node parse.Node) (db.NotificationQuery, error) {
 		}, nil
 	}
 
-	whereExpr, err := b.visitNode(node)
+	// sort: terms become ORDER BY rather than WHERE conditions
+	filter, sortTerms := parse.SplitSort(node)
+
+	var whereExpr string
+	var err error
+	if filter != nil {
+		whereExpr, err = b.visitNode(filter)
+		if err != nil {
+			return db.NotificationQuery{}, err
+		}
+	}
+
+	// Built after the filter so sort:relevance can rank by its search terms
+	orderBy, err := b.buildOrderBy(sortTerms)
 	if err != nil {
 		return db.NotificationQuery{}, err
 	}
@@ -94,14 +116,70 @@ func (b *Builder) Build(node parse.Node) (db.NotificationQuery, error) {
 	}
 
 	return db.NotificationQuery{
-		Joins:  joins,
-		Where:  where,
-		Args:   b.args,
-		Limit:  0,
-		Offset: 0,
+		Joins:   joins,
+		Where:   where,
+		Args:    b.args,
+		OrderBy: orderBy,
+		Limit:   0,
+		Offset:  0,
 	}, nil
 }
 
+// buildOrderBy builds ORDER BY expressions from sort: terms, in query order
+func (b *Builder) buildOrderBy(terms []*parse.Term) ([]string, error) {
+	var orderBy []string
+	for _, term := range terms {
+		for _, value := range term.Values {
+			key, err := parse.ParseSortKey(value)
+			if err != nil {
+				return nil, errors.Join(ErrInvalidSortKey, err)
+			}
+
+			if key.Field == parse.SortRelevance && len(b.searchQueries) == 0 {
+				// Only negated search terms, so there is nothing to rank by
+				continue
+			}
+
+			column := b.sortColumn(key.Field)
+			if column == "" {
+				return nil, errors.Join(ErrInvalidSortKey, fmt.Errorf("key: %s", value))
+			}
+
+			direction := "ASC"
+			if key.Descending {
+				direction = "DESC"
+			}
+			orderBy = append(orderBy, fmt.Sprintf("%s %s NULLS LAST", column, direction))
+		}
+	}
+	return orderBy, nil
+}
+
+// sortColumn returns the column to order by for a sort field, adding any join it needs
+func (b *Builder) sortColumn(field string) string {
+	switch field {
+	case "updated":
+		return "n.github_updated_at"
+	case "created":
+		return "n.subject_created_at"
+	case "imported":
+		return "n.imported_at"
+	case "number":
+		return "n.subject_number"
+	case "repo":
+		b.requireRepoJoin()
+		return "r.full_name"
+	case "title":
+		return "n.subject_title"
+	case "author":
+		return "n.author_login"
+	case parse.SortRelevance:
+		return fmt.Sprintf("ts_rank(n.search_vector, %s)", strings.Join(b.searchQueries, " || "))
+	default:
+		return ""
+	}
+}
+
 // visitNode visits a node and generates SQL
 func (b *Builder) visitNode(node parse.Node) (string, error) {
 	switch n := node.(type) {
@@ -111,6 +189,8 @@ func (b *Builder) visitNode(node parse.Node) (string, error) {
 		return b.visitNotExpr(n)
 	case *parse.Term:
 		return b.visitTerm(n)
+	case *parse.Comparison:
+		return b.visitComparison(n)
 	case *parse.FreeText:
 		return b.visitFreeText(n)
 	case *parse.ParenExpr:
@@ -138,7 +218,9 @@ func (b *Builder) visitBinaryExpr(node *parse.BinaryExpr) (string, error) {
 
 // visitNotExpr handles NOT expressions
 func (b *Builder) visitNotExpr(node *parse.NotExpr) (string, error) {
+	b.notDepth++
 	expr, err := b.visitNode(node.Expr)
+	b.notDepth--
 	if err != nil {
 		return "", err
 	}
@@ -146,71 +228,111 @@ func (b *Builder) visitNotExpr(node *parse.NotExpr) (string, error) {
 	return fmt.Sprintf("NOT (%s)", expr), nil
 }
 
-// visitTerm handles field:value terms
+// visitTerm handles field:value terms, dispatching on the field's kind in the registry
 func (b *Builder) visitTerm(node *parse.Term) (string, error) {
 	field := strings.ToLower(strings.TrimSpace(node.Field))
 
-	// Handle special operators
-	switch field {
-	case "in":
+	spec, ok := parse.LookupField(field)
+	if !ok {
+		return "", errors.Join(ErrUnsupportedField, fmt.Errorf("field: %s", field))
+	}
+
+	if spec.NeedsRepoJoin() {
+		b.requireRepoJoin()
+	}
+
+	switch spec.Kind {
+	case parse.FieldIn:
 		return b.handleInOperator(node.Values)
-	case "is":
+	case parse.FieldIs:
 		return b.handleIsOperator(node.Values)
-	case "repo", "repository":
-		return b.handleRepoField(node.Values)
-	case "org":
-		return b.handleOrgField(node.Values)
-	case "reason":
-		return b.handleReasonField(node.Values)
-	case "type", "subject_type":
-		return b.handleTypeField(node.Values)
-	case "author":
-		return b.handleAuthorField(node.Values)
-	case "state":
-		return b.handleStateField(node.Values)
-	case "merged":
-		return b.handleMergedField(node.Values)
-	case "state_reason":
-		return b.handleStateReasonField(node.Values)
-	case "read":
-		return b.handleReadField(node.Values)
-	case "archived":
-		return b.handleArchivedField(node.Values)
-	case "muted":
-		return b.handleMutedField(node.Values)
-	case queryValueSnoozed:
+	case parse.FieldContains:
+		return b.buildStringFilter(spec.Column, node.Values)
+	case parse.FieldPrefix:
+		return b.handlePrefixField(spec.Column, node.Values)
+	case parse.FieldEquals:
+		return b.handleEqualsField(spec.Column, node.Values)
+	case parse.FieldBoolean:
+		return b.buildBooleanFilter(spec.Column, node.Values)
+	case parse.FieldMerged:
+		return b.handleMergedField(spec.Column, node.Values)
+	case parse.FieldSnoozed:
 		return b.handleSnoozedField(node.Values)
-	case queryValueFiltered:
-		return b.handleFilteredField(node.Values)
-	case "tags":
+	case parse.FieldTime:
+		return b.handleTimeField(spec.Column, node.Values)
+	case parse.FieldTags:
 		return b.handleTagsField(node.Values)
+	case parse.FieldAccount:
+		return b.handleAccountField(node.Values)
+	case parse.FieldEnum:
+		return b.handleEnumField(spec.Column, node.Values), nil
+	case parse.FieldArray:
+		return b.handleArrayField(spec.Column, node.Values), nil
+	case parse.FieldNo:
+		return b.handleNoField(node.Values)
+	case parse.FieldHas:
+		return b.handleHasField(node.Values)
+	case parse.FieldNumber:
+		return b.handleNumberField(spec.Column, node.Values)
+	case parse.FieldView:
+		// view: terms are inlined by parse.Expand, so reaching one here is a misuse
+		return "", errors.Join(ErrUnexpandedView, fmt.Errorf("view: %s", node.String()))
 	default:
+		// sort: terms are split off before visiting, so reaching one here is a misuse
 		return "", errors.Join(ErrUnsupportedField, fmt.Errorf("field: %s", field))
 	}
 }
 
+// visitComparison handles field:>value and field:low..high comparisons
+func (b *Builder) visitComparison(node *parse.Comparison) (string, error) {
+	field := strings.ToLower(strings.TrimSpace(node.Field))
+
+	spec, ok := parse.LookupField(field)
+	if !ok || !parse.SupportsComparison(spec.Kind) {
+		return "", errors.Join(ErrUnsupportedField, fmt.Errorf("field: %s", field))
+	}
+
+	if spec.Kind == parse.FieldNumber {
+		bounds, err := parse.ResolveNumberBounds(node.Op, node.Value, node.Upper)
+		if err != nil {
+			return "", errors.Join(ErrInvalidNumberValue, err)
+		}
+		return b.buildNumberCondition(spec.Column, bounds), nil
+	}
+
+	bounds, err := parse.ResolveTimeBounds(node.Op, node.Value, node.Upper, b.now())
+	if err != nil {
+		return "", errors.Join(ErrInvalidTimeValue, err)
+	}
+
+	return b.buildTimeCondition(spec.Column, bounds), nil
+}
+
 // visitFreeText handles free text search
 func (b *Builder) visitFreeText(node *parse.FreeText) (string, error) {
-	b.requireRepoJoin()
-	pattern := "%" + node.Text + "%"
-
-	placeholder1 := b.addArg(pattern)
-	placeholder2 := b.addArg(pattern)
-	placeholder3 := b.addArg(pattern)
-	placeholder4 := b.addArg(pattern)
-	placeholder5 := b.addArg(pattern)
-	placeholder6 := b.addArg(pattern)
+	// Free text uses the search_vector column (title, repository, author, type, state,
+	// number and body) and its GIN index. Quoted text matches as a phrase; words match
+	// as prefixes so partial words keep working.
+	var tsquery string
+	if node.Quoted {
+		tsquery = fmt.Sprintf(
+			"websearch_to_tsquery('%s', %s)",
+			parse.SearchConfig,
+			b.addArg(parse.PhraseSearchQuery(node.Text)),
+		)
+	} else {
+		prefixQuery := parse.PrefixSearchQuery(node.Text)
+		if prefixQuery == "" {
+			// Only punctuation, which isn't indexed
+			return "FALSE", nil
+		}
+		tsquery = fmt.Sprintf("to_tsquery('%s', %s)", parse.SearchConfig, b.addArg(prefixQuery))
+	}
 
-	return fmt.Sprintf(
-		"(n.subject_title ILIKE %s OR n.subject_type ILIKE %s OR r.full_name ILIKE %s OR "+
-			"n.author_login ILIKE %s OR n.subject_state ILIKE %s OR n.subject_number::text ILIKE %s)",
-		placeholder1,
-		placeholder2,
-		placeholder3,
-		placeholder4,
-		placeholder5,
-		placeholder6,
-	), nil
+	if b.notDepth == 0 {
+		b.searchQueries = append(b.searchQueries, tsquery)
+	}
+	return "n.search_vector @@ " + tsquery, nil
 }
 
 // Field handler methods
@@ -283,6 +405,15 @@ func (b *Builder) handleIsOperator(values []string) (string, error) {
 			conditions = append(conditions, "n.starred = TRUE")
 		case queryValueFiltered:
 			conditions = append(conditions, "n.filtered = TRUE")
+		case "draft":
+			// IS TRUE so NOT is:draft still matches issues, whose draft flag is NULL
+			conditions = append(conditions, "n.subject_draft IS TRUE")
+		case "answered":
+			// Only discussions have an answered flag; IS TRUE keeps NOT is:answered total
+			conditions = append(conditions, "n.subject_answered IS TRUE")
+		case "stale":
+			// Flagged by reconciliation as missing from GitHub
+			conditions = append(conditions, "n.stale_at IS NOT NULL")
 		default:
 			return "", errors.Join(ErrInvalidIsOperatorValue, fmt.Errorf("value: %s", value))
 		}
@@ -294,19 +425,19 @@ func (b *Builder) handleIsOperator(values []string) (string, error) {
 	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
-func (b *Builder) handleRepoField(values []string) (string, error) {
-	b.requireRepoJoin()
-	return b.buildStringFilter("r.full_name", values), nil
-}
-
-func (b *Builder) handleOrgField(values []string) (string, error) {
-	b.requireRepoJoin()
-	// Org is prefix matching: org:cli matches cli/*
+func (b *Builder) handlePrefixField(column string, values []string) (string, error) {
+	// Org is prefix matching: org:cli matches cli/*. Globs and regexes match the whole owner.
 	var conditions []string
 	for _, value := range values {
-		pattern := value + "/%"
-		placeholder := b.addArg(pattern)
-		conditions = append(conditions, fmt.Sprintf("r.full_name ILIKE %s", placeholder))
+		owner := fmt.Sprintf("split_part(%s, '/', 1)", column)
+		condition, ok, err := b.patternCondition(owner, value)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			condition = fmt.Sprintf("%s ILIKE %s", column, b.addArg(value+"/%"))
+		}
+		conditions = append(conditions, condition)
 	}
 
 	if len(conditions) == 1 {
@@ -315,26 +446,35 @@ func (b *Builder) handleOrgField(values []string) (string, error) {
 	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
-func (b *Builder) handleReasonField(values []string) (string, error) {
-	return b.buildStringFilter("n.reason", values), nil
-}
-
-func (b *Builder) handleTypeField(values []string) (string, error) {
-	return b.buildStringFilter("n.subject_type", values), nil
-}
-
-func (b *Builder) handleAuthorField(values []string) (string, error) {
-	return b.buildStringFilter("n.author_login", values), nil
+// patternCondition builds the condition for a glob or /regex/ value of a string field.
+// Returns false for plain values, which each field kind matches its own way.
+func (b *Builder) patternCondition(column, value string) (string, bool, error) {
+	kind, body := parse.ClassifyPattern(value)
+	switch kind {
+	case parse.PatternGlob:
+		return fmt.Sprintf("%s ILIKE %s", column, b.addArg(parse.GlobToLike(body))), true, nil
+	case parse.PatternRegex:
+		if _, err := parse.CompileRegex(body); err != nil {
+			return "", false, err
+		}
+		pattern, err := parse.PostgresRegex(body)
+		if err != nil {
+			return "", false, err
+		}
+		return fmt.Sprintf("%s ~* %s", column, b.addArg(pattern)), true, nil
+	default:
+		return "", false, nil
+	}
 }
 
-func (b *Builder) handleStateField(values []string) (string, error) {
+func (b *Builder) handleEqualsField(column string, values []string) (string, error) {
 	// State is stored in subject_state column (extracted from subject_raw)
 	// We use the column instead of subject_raw->>'state' for performance
 	// since the JSON path index has been removed in favor of the column index
 	var conditions []string
 	for _, value := range values {
 		placeholder := b.addArg(value)
-		conditions = append(conditions, fmt.Sprintf("n.subject_state = %s", placeholder))
+		conditions = append(conditions, fmt.Sprintf("%s = %s", column, placeholder))
 	}
 
 	if len(conditions) == 1 {
@@ -343,23 +483,17 @@ func (b *Builder) handleStateField(values []string) (string, error) {
 	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
-func (b *Builder) handleMergedField(values []string) (string, error) {
+func (b *Builder) handleMergedField(column string, values []string) (string, error) {
 	// Merged is stored in subject_merged column (extracted from subject_raw)
 	// Only applies to Pull Requests
 	var conditions []string
 	for _, value := range values {
-		value = strings.ToLower(strings.TrimSpace(value))
-		var boolVal bool
-		switch value {
-		case queryValueTrue, queryValueYes, "1", "merged":
-			boolVal = true
-		case queryValueFalse, "no", "0", "unmerged":
-			boolVal = false
-		default:
+		boolVal, ok := parse.ParseMerged(value)
+		if !ok {
 			return "", errors.Join(ErrInvalidMergedValue, fmt.Errorf("value: %s", value))
 		}
 		placeholder := b.addArg(boolVal)
-		conditions = append(conditions, fmt.Sprintf("n.subject_merged = %s", placeholder))
+		conditions = append(conditions, fmt.Sprintf("%s = %s", column, placeholder))
 	}
 
 	if len(conditions) == 1 {
@@ -368,39 +502,84 @@ func (b *Builder) handleMergedField(values []string) (string, error) {
 	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
-func (b *Builder) handleStateReasonField(values []string) (string, error) {
-	// State reason is stored in subject_state_reason column (extracted from subject_raw)
-	// Only applies to Issues
-	return b.buildStringFilter("n.subject_state_reason", values), nil
+func (b *Builder) handleSnoozedField(values []string) (string, error) {
+	var conditions []string
+	for _, value := range values {
+		snoozed, ok := parse.ParseBool(value)
+		switch {
+		case !ok:
+			return "", errors.Join(ErrInvalidSnoozedValue, fmt.Errorf("value: %s", value))
+		case snoozed:
+			conditions = append(
+				conditions,
+				"(n.snoozed_until IS NOT NULL AND n.snoozed_until > NOW())",
+			)
+		default:
+			conditions = append(conditions, "(n.snoozed_until IS NULL OR n.snoozed_until <= NOW())")
+		}
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
-func (b *Builder) handleReadField(values []string) (string, error) {
-	return b.buildBooleanFilter("n.is_read", values)
+func (b *Builder) handleTimeField(column string, values []string) (string, error) {
+	// Plain time values: updated:today matches that day, updated:7d matches the last 7 days
+	now := b.now()
+	var conditions []string
+	for _, value := range values {
+		bounds, err := parse.ResolveTimeBounds("", value, "", now)
+		if err != nil {
+			return "", errors.Join(ErrInvalidTimeValue, err)
+		}
+		conditions = append(conditions, b.buildTimeCondition(column, bounds))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
-func (b *Builder) handleArchivedField(values []string) (string, error) {
-	return b.buildBooleanFilter("n.archived", values)
+func (b *Builder) handleEnumField(column string, values []string) string {
+	// Review decisions and check statuses are stored in lowercase
+	var conditions []string
+	for _, value := range values {
+		placeholder := b.addArg(strings.ToLower(strings.TrimSpace(value)))
+		conditions = append(conditions, fmt.Sprintf("%s = %s", column, placeholder))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
 }
 
-func (b *Builder) handleMutedField(values []string) (string, error) {
-	return b.buildBooleanFilter("n.muted", values)
+func (b *Builder) handleArrayField(column string, values []string) string {
+	// Requested reviewers are stored in lowercase; @> can use the column's GIN index
+	var conditions []string
+	for _, value := range values {
+		placeholder := b.addArg(strings.ToLower(strings.TrimSpace(value)))
+		conditions = append(conditions, fmt.Sprintf("%s @> ARRAY[%s]::text[]", column, placeholder))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
 }
 
-func (b *Builder) handleSnoozedField(values []string) (string, error) {
+func (b *Builder) handleNoField(values []string) (string, error) {
+	// no:label matches when the named field is NULL or empty, so it is never unknown
 	var conditions []string
 	for _, value := range values {
-		value = strings.ToLower(strings.TrimSpace(value))
-		switch value {
-		case "true", "yes", "1":
-			conditions = append(
-				conditions,
-				"(n.snoozed_until IS NOT NULL AND n.snoozed_until > NOW())",
-			)
-		case "false", "no", "0":
-			conditions = append(conditions, "(n.snoozed_until IS NULL OR n.snoozed_until <= NOW())")
-		default:
-			return "", errors.Join(ErrInvalidSnoozedValue, fmt.Errorf("value: %s", value))
+		spec, ok := parse.LookupField(value)
+		if !ok || !slices.Contains(parse.NoValues, spec.Name) {
+			return "", errors.Join(ErrInvalidNoValue, fmt.Errorf("value: %s", value))
 		}
+		conditions = append(conditions, existenceCondition(spec, false))
 	}
 
 	if len(conditions) == 1 {
@@ -409,8 +588,53 @@ func (b *Builder) handleSnoozedField(values []string) (string, error) {
 	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
-func (b *Builder) handleFilteredField(values []string) (string, error) {
-	return b.buildBooleanFilter("n.filtered", values)
+func (b *Builder) handleHasField(values []string) (string, error) {
+	// has:label is the negation of no:label, so it is never unknown either
+	var conditions []string
+	for _, value := range values {
+		spec, ok := parse.LookupField(value)
+		if !ok || !slices.Contains(parse.NoValues, spec.Name) {
+			return "", errors.Join(ErrInvalidHasValue, fmt.Errorf("value: %s", value))
+		}
+		conditions = append(conditions, existenceCondition(spec, true))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+// existenceCondition builds a condition matching rows where the field is set (has) or
+// NULL/empty (!has). Arrays count as empty when they have no elements.
+func existenceCondition(spec parse.FieldSpec, has bool) string {
+	if spec.Kind == parse.FieldArray || spec.Kind == parse.FieldTags {
+		if has {
+			return fmt.Sprintf("COALESCE(cardinality(%s), 0) > 0", spec.Column)
+		}
+		return fmt.Sprintf("COALESCE(cardinality(%s), 0) = 0", spec.Column)
+	}
+	if has {
+		return fmt.Sprintf("%s IS NOT NULL", spec.Column)
+	}
+	return fmt.Sprintf("%s IS NULL", spec.Column)
+}
+
+func (b *Builder) handleNumberField(column string, values []string) (string, error) {
+	// Plain numbers match exactly: number:42 OR number:43 for number:42,43
+	var conditions []string
+	for _, value := range values {
+		bounds, err := parse.ResolveNumberBounds("", value, "")
+		if err != nil {
+			return "", errors.Join(ErrInvalidNumberValue, err)
+		}
+		conditions = append(conditions, b.buildNumberCondition(column, bounds))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
 func (b *Builder) handleTagsField(values []string) (string, error) {
@@ -439,33 +663,54 @@ func (b *Builder) handleTagsField(values []string) (string, error) {
 	), nil
 }
 
+func (b *Builder) handleAccountField(values []string) (string, error) {
+	if len(values) == 0 {
+		return "", ErrAccountFieldRequiresValue
+	}
+
+	// account:personal,work matches notifications from either account
+	var conditions []string
+	for _, value := range values {
+		conditions = append(conditions, fmt.Sprintf("lower(name) = lower(%s)", b.addArg(value)))
+	}
+	return fmt.Sprintf(
+		"n.account_id IN (SELECT id FROM github_accounts WHERE %s)",
+		strings.Join(conditions, " OR "),
+	), nil
+}
+
 // Helper methods
 
-func (b *Builder) buildStringFilter(column string, values []string) string {
+func (b *Builder) buildStringFilter(column string, values []string) (string, error) {
 	var conditions []string
 	for _, value := range values {
-		pattern := "%" + value + "%"
-		placeholder := b.addArg(pattern)
-		conditions = append(conditions, fmt.Sprintf("%s ILIKE %s", column, placeholder))
+		condition, ok, err := b.patternCondition(column, value)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			condition = fmt.Sprintf("%s ILIKE %s", column, b.addArg("%"+value+"%"))
+		}
+		conditions = append(conditions, condition)
 	}
 
 	if len(conditions) == 1 {
-		return conditions[0]
+		return conditions[0], nil
 	}
-	return "(" + strings.Join(conditions, " OR ") + ")"
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
 func (b *Builder) buildBooleanFilter(column string, values []string) (string, error) {
 	var conditions []string
 	for _, value := range values {
-		value = strings.ToLower(strings.TrimSpace(value))
-		switch value {
-		case "true", "yes", "1":
+		boolVal, ok := parse.ParseBool(value)
+		switch {
+		case !ok:
+			return "", errors.Join(ErrInvalidBooleanValue, fmt.Errorf("value: %s", value))
+		case boolVal:
 			conditions = append(conditions, fmt.Sprintf("%s = TRUE", column))
-		case "false", "no", "0":
-			conditions = append(conditions, fmt.Sprintf("%s = FALSE", column))
 		default:
-			return "", errors.Join(ErrInvalidBooleanValue, fmt.Errorf("value: %s", value))
+			conditions = append(conditions, fmt.Sprintf("%s = FALSE", column))
 		}
 	}
 
@@ -475,6 +720,69 @@ func (b *Builder) buildBooleanFilter(column string, values []string) (string, er
 	return "(" + strings.Join(conditions, " OR ") + ")", nil
 }
 
+// buildTimeCondition builds a condition matching non-NULL timestamps within bounds.
+// The explicit IS NOT NULL keeps NOT (...) matching rows with no timestamp.
+func (b *Builder) buildTimeCondition(column string, bounds parse.TimeBounds) string {
+	conditions := []string{column + " IS NOT NULL"}
+
+	if bounds.After != nil {
+		op := ">"
+		if bounds.AfterInclusive {
+			op = ">="
+		}
+		conditions = append(
+			conditions,
+			fmt.Sprintf("%s %s %s", column, op, b.addArg(*bounds.After)),
+		)
+	}
+
+	if bounds.Before != nil {
+		op := "<"
+		if bounds.BeforeInclusive {
+			op = "<="
+		}
+		conditions = append(
+			conditions,
+			fmt.Sprintf("%s %s %s", column, op, b.addArg(*bounds.Before)),
+		)
+	}
+
+	return "(" + strings.Join(conditions, " AND ") + ")"
+}
+
+// buildNumberCondition builds a condition matching non-NULL integers within bounds.
+// Like buildTimeCondition, the explicit IS NOT NULL keeps the condition from being unknown.
+func (b *Builder) buildNumberCondition(column string, bounds parse.NumberBounds) string {
+	conditions := []string{column + " IS NOT NULL"}
+
+	if bounds.Low != nil && bounds.High != nil && *bounds.Low == *bounds.High &&
+		bounds.LowInclusive && bounds.HighInclusive {
+		conditions = append(conditions, fmt.Sprintf("%s = %s", column, b.addArg(*bounds.Low)))
+		return "(" + strings.Join(conditions, " AND ") + ")"
+	}
+
+	if bounds.Low != nil {
+		op := ">"
+		if bounds.LowInclusive {
+			op = ">="
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", column, op, b.addArg(*bounds.Low)))
+	}
+
+	if bounds.High != nil {
+		op := "<"
+		if bounds.HighInclusive {
+			op = "<="
+		}
+		conditions = append(
+			conditions,
+			fmt.Sprintf("%s %s %s", column, op, b.addArg(*bounds.High)),
+		)
+	}
+
+	return "(" + strings.Join(conditions, " AND ") + ")"
+}
+
 func (b *Builder) addArg(arg interface{}) string {
 	b.args = append(b.args, arg)
 	b.argCounter++
@@ -491,6 +799,3 @@ func (b *Builder) requireRepoJoin() {
 func (b *Builder) requirePRJoin() {
 	b.joins["LEFT JOIN pull_requests pr ON pr.id = n.pull_request_id"] = true
 }
-
-// For use in checking "now" for snoozed queries
-var _ = time.Now
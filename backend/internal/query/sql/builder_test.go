@@ -16,7 +16,9 @@
 package sql
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/ajbeattie/octobud/backend/internal/db"
 	"github.com/ajbeattie/octobud/backend/internal/query/parse"
@@ -236,6 +238,11 @@ func TestBuilder_IsOperator(t *testing.T) {
 			input:     "is:snoozed",
 			wantWhere: "n.snoozed_until IS NOT NULL AND n.snoozed_until > NOW()",
 		},
+		{
+			name:      "is:draft",
+			input:     "is:draft",
+			wantWhere: "n.subject_draft IS TRUE",
+		},
 	}
 
 	for _, tt := range tests {
@@ -263,6 +270,422 @@ func TestBuilder_IsOperator(t *testing.T) {
 	}
 }
 
+func TestBuilder_PullRequestFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "review decision",
+			input:     "review:Approved",
+			wantWhere: "n.subject_review_decision = $1",
+			wantArgs:  []interface{}{"approved"},
+		},
+		{
+			name:      "multiple review decisions",
+			input:     "review:changes_requested,required",
+			wantWhere: "(n.subject_review_decision = $1 OR n.subject_review_decision = $2)",
+			wantArgs:  []interface{}{"changes_requested", "required"},
+		},
+		{
+			name:      "checks status",
+			input:     "checks:failing",
+			wantWhere: "n.subject_checks_status = $1",
+			wantArgs:  []interface{}{"failing"},
+		},
+		{
+			name:      "review requested from viewer",
+			input:     "review-requested:@me",
+			wantWhere: "n.subject_review_requested @> ARRAY[$1]::text[]",
+			wantArgs:  []interface{}{"@me"},
+		},
+		{
+			name:      "review requested from team",
+			input:     "review_requested:Platform-Team",
+			wantWhere: "n.subject_review_requested @> ARRAY[$1]::text[]",
+			wantArgs:  []interface{}{"platform-team"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, err := parseQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			query, err := NewBuilder().Build(ast)
+			if err != nil {
+				t.Fatalf("build error: %v", err)
+			}
+
+			if len(query.Where) == 0 {
+				t.Fatal("expected non-empty WHERE clause")
+			}
+
+			if query.Where[0] != tt.wantWhere {
+				t.Errorf("expected WHERE %q, got %q", tt.wantWhere, query.Where[0])
+			}
+
+			if len(query.Args) != len(tt.wantArgs) {
+				t.Fatalf("expected %d args, got %d", len(tt.wantArgs), len(query.Args))
+			}
+			for i, want := range tt.wantArgs {
+				if query.Args[i] != want {
+					t.Errorf("arg %d: expected %v, got %v", i, want, query.Args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuilder_SubjectMetadataFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "label",
+			input:     `label:"Good First Issue"`,
+			wantWhere: "n.subject_labels @> ARRAY[$1]::text[]",
+			wantArgs:  []interface{}{"good first issue"},
+		},
+		{
+			name:      "negated label",
+			input:     "-label:wontfix",
+			wantWhere: "NOT (n.subject_labels @> ARRAY[$1]::text[])",
+			wantArgs:  []interface{}{"wontfix"},
+		},
+		{
+			name:      "milestone",
+			input:     "milestone:v2.0",
+			wantWhere: "n.subject_milestone ILIKE $1",
+			wantArgs:  []interface{}{"%v2.0%"},
+		},
+		{
+			name:      "category",
+			input:     "category:q",
+			wantWhere: "n.subject_category ILIKE $1",
+			wantArgs:  []interface{}{"%q%"},
+		},
+		{
+			name:      "conclusion",
+			input:     "conclusion:failure",
+			wantWhere: "n.subject_conclusion = $1",
+			wantArgs:  []interface{}{"failure"},
+		},
+		{
+			name:      "either severity",
+			input:     "severity:critical,HIGH",
+			wantWhere: "(n.subject_severity = $1 OR n.subject_severity = $2)",
+			wantArgs:  []interface{}{"critical", "high"},
+		},
+		{
+			name:      "release tag",
+			input:     "release:v2",
+			wantWhere: "n.subject_tag ILIKE $1",
+			wantArgs:  []interface{}{"%v2%"},
+		},
+		{
+			name:      "commit SHA",
+			input:     "sha:0ff1ce",
+			wantWhere: "n.subject_sha ILIKE $1",
+			wantArgs:  []interface{}{"%0ff1ce%"},
+		},
+		{
+			name:      "answered",
+			input:     "is:answered",
+			wantWhere: "n.subject_answered IS TRUE",
+		},
+		{
+			name:      "unanswered",
+			input:     "-is:answered",
+			wantWhere: "NOT (n.subject_answered IS TRUE)",
+		},
+		{
+			name:      "stale",
+			input:     "is:stale",
+			wantWhere: "n.stale_at IS NOT NULL",
+		},
+		{
+			name:      "assigned to viewer",
+			input:     "assignee:@me",
+			wantWhere: "n.subject_assignees @> ARRAY[$1]::text[]",
+			wantArgs:  []interface{}{"@me"},
+		},
+		{
+			name:      "no assignee",
+			input:     "no:assignee",
+			wantWhere: "COALESCE(cardinality(n.subject_assignees), 0) = 0",
+		},
+		{
+			name:  "no label or milestone",
+			input: "no:label,milestone",
+			wantWhere: "(COALESCE(cardinality(n.subject_labels), 0) = 0 " +
+				"OR n.subject_milestone IS NULL)",
+		},
+		{
+			name:      "no tags",
+			input:     "no:tags",
+			wantWhere: "COALESCE(cardinality(n.tag_ids), 0) = 0",
+		},
+		{
+			name:      "no reason",
+			input:     "no:reason",
+			wantWhere: "n.reason IS NULL",
+		},
+		{
+			name:      "has tags",
+			input:     "has:tags",
+			wantWhere: "COALESCE(cardinality(n.tag_ids), 0) > 0",
+		},
+		{
+			name:  "has author or label",
+			input: "has:author,label",
+			wantWhere: "(n.author_login IS NOT NULL OR " +
+				"COALESCE(cardinality(n.subject_labels), 0) > 0)",
+		},
+		{
+			name:  "account",
+			input: "account:work",
+			wantWhere: "n.account_id IN (SELECT id FROM github_accounts " +
+				"WHERE lower(name) = lower($1))",
+			wantArgs: []interface{}{"work"},
+		},
+		{
+			name:  "either account",
+			input: "account:personal,work",
+			wantWhere: "n.account_id IN (SELECT id FROM github_accounts " +
+				"WHERE lower(name) = lower($1) OR lower(name) = lower($2))",
+			wantArgs: []interface{}{"personal", "work"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, err := parseQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			query, err := NewBuilder().Build(ast)
+			if err != nil {
+				t.Fatalf("build error: %v", err)
+			}
+
+			if len(query.Where) == 0 {
+				t.Fatal("expected non-empty WHERE clause")
+			}
+
+			if query.Where[0] != tt.wantWhere {
+				t.Errorf("expected WHERE %q, got %q", tt.wantWhere, query.Where[0])
+			}
+
+			if len(query.Args) != len(tt.wantArgs) {
+				t.Fatalf("expected %d args, got %d", len(tt.wantArgs), len(query.Args))
+			}
+			for i, want := range tt.wantArgs {
+				if query.Args[i] != want {
+					t.Errorf("arg %d: expected %v, got %v", i, want, query.Args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuilder_InvalidNoValue(t *testing.T) {
+	_, err := NewBuilder().Build(&parse.Term{Field: "no", Values: []string{"repo"}})
+	if !errors.Is(err, ErrInvalidNoValue) {
+		t.Fatalf("expected ErrInvalidNoValue, got %v", err)
+	}
+}
+
+func TestBuilder_InvalidHasValue(t *testing.T) {
+	_, err := NewBuilder().Build(&parse.Term{Field: "has", Values: []string{"state"}})
+	if !errors.Is(err, ErrInvalidHasValue) {
+		t.Fatalf("expected ErrInvalidHasValue, got %v", err)
+	}
+}
+
+func TestBuilder_NumberFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "exact number",
+			input:     "number:42",
+			wantWhere: "(n.subject_number IS NOT NULL AND n.subject_number = $1)",
+			wantArgs:  []interface{}{int64(42)},
+		},
+		{
+			name:  "several numbers",
+			input: "number:1,2",
+			wantWhere: "((n.subject_number IS NOT NULL AND n.subject_number = $1) OR " +
+				"(n.subject_number IS NOT NULL AND n.subject_number = $2))",
+			wantArgs: []interface{}{int64(1), int64(2)},
+		},
+		{
+			name:      "greater than",
+			input:     "number:>1000",
+			wantWhere: "(n.subject_number IS NOT NULL AND n.subject_number > $1)",
+			wantArgs:  []interface{}{int64(1000)},
+		},
+		{
+			name:      "comments at most",
+			input:     "comments:<=10",
+			wantWhere: "(n.subject_comments IS NOT NULL AND n.subject_comments <= $1)",
+			wantArgs:  []interface{}{int64(10)},
+		},
+		{
+			name:  "range",
+			input: "comments:5..20",
+			wantWhere: "(n.subject_comments IS NOT NULL AND n.subject_comments >= $1 AND " +
+				"n.subject_comments <= $2)",
+			wantArgs: []interface{}{int64(5), int64(20)},
+		},
+		{
+			name:      "negated comparison",
+			input:     "-number:<100",
+			wantWhere: "NOT ((n.subject_number IS NOT NULL AND n.subject_number < $1))",
+			wantArgs:  []interface{}{int64(100)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, err := parseQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			query, err := NewBuilder().Build(ast)
+			if err != nil {
+				t.Fatalf("build error: %v", err)
+			}
+
+			if len(query.Where) == 0 {
+				t.Fatal("expected non-empty WHERE clause")
+			}
+
+			if query.Where[0] != tt.wantWhere {
+				t.Errorf("expected WHERE %q, got %q", tt.wantWhere, query.Where[0])
+			}
+
+			if len(query.Args) != len(tt.wantArgs) {
+				t.Fatalf("expected %d args, got %d", len(tt.wantArgs), len(query.Args))
+			}
+			for i, want := range tt.wantArgs {
+				if query.Args[i] != want {
+					t.Errorf("arg %d: expected %v, got %v", i, want, query.Args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuilder_Patterns(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "repo glob",
+			input:     "repo:acme/*-service",
+			wantWhere: "r.full_name ILIKE $1",
+			wantArgs:  []interface{}{"acme/%-service"},
+		},
+		{
+			name:      "glob escapes LIKE wildcards",
+			input:     "reason:team_?",
+			wantWhere: "n.reason ILIKE $1",
+			wantArgs:  []interface{}{`team\__`},
+		},
+		{
+			name:      "author regex",
+			input:     `author:/\[bot\]$/`,
+			wantWhere: "n.author_login ~* $1",
+			wantArgs:  []interface{}{`\[bot\]$`},
+		},
+		{
+			name:      "negated author regex",
+			input:     `-author:/\[bot\]$/`,
+			wantWhere: "NOT (n.author_login ~* $1)",
+			wantArgs:  []interface{}{`\[bot\]$`},
+		},
+		{
+			name:      "regex and plain value",
+			input:     "author:octocat,/^renovate/",
+			wantWhere: "(n.author_login ILIKE $1 OR n.author_login ~* $2)",
+			wantArgs:  []interface{}{"%octocat%", "^renovate"},
+		},
+		{
+			name:      "org glob matches the owner",
+			input:     "org:acme-*",
+			wantWhere: "split_part(r.full_name, '/', 1) ILIKE $1",
+			wantArgs:  []interface{}{"acme-%"},
+		},
+		{
+			name:      "regex word boundaries are translated",
+			input:     `author:/\bbot\b/`,
+			wantWhere: "n.author_login ~* $1",
+			wantArgs:  []interface{}{`\ybot\y`},
+		},
+		{
+			name:      "org regex matches the owner",
+			input:     "org:/^(acme|globex)$/",
+			wantWhere: "split_part(r.full_name, '/', 1) ~* $1",
+			wantArgs:  []interface{}{"^(acme|globex)$"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, err := parseQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			query, err := NewBuilder().Build(ast)
+			if err != nil {
+				t.Fatalf("build error: %v", err)
+			}
+
+			if len(query.Where) == 0 {
+				t.Fatal("expected non-empty WHERE clause")
+			}
+
+			if query.Where[0] != tt.wantWhere {
+				t.Errorf("expected WHERE %q, got %q", tt.wantWhere, query.Where[0])
+			}
+
+			if len(query.Args) != len(tt.wantArgs) {
+				t.Fatalf("expected %d args, got %d", len(tt.wantArgs), len(query.Args))
+			}
+			for i, want := range tt.wantArgs {
+				if query.Args[i] != want {
+					t.Errorf("arg %d: expected %v, got %v", i, want, query.Args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuilder_InvalidRegex(t *testing.T) {
+	_, err := NewBuilder().Build(&parse.Term{Field: "author", Values: []string{"/[bot/"}})
+	if !errors.Is(err, parse.ErrInvalidRegex) {
+		t.Fatalf("expected ErrInvalidRegex, got %v", err)
+	}
+}
+
 func TestBuilder_CommaOR(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -377,9 +800,9 @@ func TestBuilder_Joins(t *testing.T) {
 			wantJoins: []string{},
 		},
 		{
-			name:      "free text requires repo join",
+			name:      "free text uses the search vector, no join",
 			input:     "urgent",
-			wantJoins: []string{"repositories"},
+			wantJoins: []string{},
 		},
 	}
 
@@ -416,6 +839,186 @@ func TestBuilder_Joins(t *testing.T) {
 	}
 }
 
+func TestBuilder_TimeFields(t *testing.T) {
+	now := time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC)
+	day := func(d int) time.Time {
+		return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:  "plain day",
+			input: "updated:today",
+			wantWhere: "(n.github_updated_at IS NOT NULL AND n.github_updated_at >= $1 AND " +
+				"n.github_updated_at < $2)",
+			wantArgs: []interface{}{day(15), day(16)},
+		},
+		{
+			name:      "plain duration",
+			input:     "imported:7d",
+			wantWhere: "(n.imported_at IS NOT NULL AND n.imported_at >= $1)",
+			wantArgs:  []interface{}{now.AddDate(0, 0, -7)},
+		},
+		{
+			name:      "greater than date",
+			input:     "created:>2024-03-10",
+			wantWhere: "(n.subject_created_at IS NOT NULL AND n.subject_created_at >= $1)",
+			wantArgs:  []interface{}{day(11)},
+		},
+		{
+			name:      "less than duration",
+			input:     "updated:<1w",
+			wantWhere: "(n.github_updated_at IS NOT NULL AND n.github_updated_at < $1)",
+			wantArgs:  []interface{}{day(8).Add(14*time.Hour + 30*time.Minute)},
+		},
+		{
+			name:  "range",
+			input: "snoozed_until:2024-03-01..2024-03-10",
+			wantWhere: "(n.snoozed_until IS NOT NULL AND n.snoozed_until >= $1 AND " +
+				"n.snoozed_until < $2)",
+			wantArgs: []interface{}{day(1), day(11)},
+		},
+		{
+			name:  "multiple values use OR",
+			input: "updated:today,yesterday",
+			wantWhere: "((n.github_updated_at IS NOT NULL AND n.github_updated_at >= $1 AND " +
+				"n.github_updated_at < $2) OR (n.github_updated_at IS NOT NULL AND " +
+				"n.github_updated_at >= $3 AND n.github_updated_at < $4))",
+			wantArgs: []interface{}{day(15), day(16), day(14), day(15)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, err := parseQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			builder := NewBuilder()
+			builder.now = func() time.Time { return now }
+			query, err := builder.Build(ast)
+			if err != nil {
+				t.Fatalf("build error: %v", err)
+			}
+
+			if len(query.Where) != 1 || query.Where[0] != tt.wantWhere {
+				t.Errorf("expected WHERE %q, got %v", tt.wantWhere, query.Where)
+			}
+
+			if len(query.Args) != len(tt.wantArgs) {
+				t.Fatalf("expected %d args, got %d", len(tt.wantArgs), len(query.Args))
+			}
+			for i, want := range tt.wantArgs {
+				got, ok := query.Args[i].(time.Time)
+				if !ok || !got.Equal(want.(time.Time)) {
+					t.Errorf("arg %d: expected %v, got %v", i, want, query.Args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuilder_TimeFieldErrors(t *testing.T) {
+	for _, input := range []string{
+		"updated:soon", "repo:>cli", "created:2024-01-01..later", "number:>many", "comments:x",
+	} {
+		t.Run(input, func(t *testing.T) {
+			ast, err := parseQuery(input)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			if _, err := NewBuilder().Build(ast); err == nil {
+				t.Error("expected build error, got none")
+			}
+		})
+	}
+}
+
+func TestBuilder_Sort(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantOrderBy []string
+		wantWhere   int
+		wantJoins   int
+	}{
+		{
+			name:        "no sort",
+			input:       "is:unread",
+			wantOrderBy: nil,
+			wantWhere:   1,
+		},
+		{
+			name:        "ascending update time",
+			input:       "reason:review_requested sort:updated-asc",
+			wantOrderBy: []string{"n.github_updated_at ASC NULLS LAST"},
+			wantWhere:   1,
+		},
+		{
+			name:        "default direction",
+			input:       "sort:created",
+			wantOrderBy: []string{"n.subject_created_at DESC NULLS LAST"},
+			wantWhere:   0,
+		},
+		{
+			name:        "repo sort requires repo join",
+			input:       "sort:repo,number",
+			wantOrderBy: []string{"r.full_name ASC NULLS LAST", "n.subject_number DESC NULLS LAST"},
+			wantWhere:   0,
+			wantJoins:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, err := parseQuery(tt.input)
+			if err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			query, err := NewBuilder().Build(ast)
+			if err != nil {
+				t.Fatalf("build error: %v", err)
+			}
+
+			if len(query.OrderBy) != len(tt.wantOrderBy) {
+				t.Fatalf("expected ORDER BY %v, got %v", tt.wantOrderBy, query.OrderBy)
+			}
+			for i, want := range tt.wantOrderBy {
+				if query.OrderBy[i] != want {
+					t.Errorf("ORDER BY %d: expected %q, got %q", i, want, query.OrderBy[i])
+				}
+			}
+
+			if len(query.Where) != tt.wantWhere {
+				t.Errorf("expected %d WHERE conditions, got %v", tt.wantWhere, query.Where)
+			}
+
+			if len(query.Joins) != tt.wantJoins {
+				t.Errorf("expected %d joins, got %v", tt.wantJoins, query.Joins)
+			}
+		})
+	}
+}
+
+func TestBuilder_SortInvalidKey(t *testing.T) {
+	ast, err := parseQuery("sort:priority")
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	if _, err := NewBuilder().Build(ast); err == nil {
+		t.Error("expected build error, got none")
+	}
+}
+
 // Helper functions
 
 func parseQuery(input string) (parse.Node, error) {
@@ -15,6 +15,7 @@ import (
 	time "time"
 
 	types "github.com/ajbeattie/octobud/backend/internal/github/types"
+	models "github.com/ajbeattie/octobud/backend/internal/models"
 	sync "github.com/ajbeattie/octobud/backend/internal/sync"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -43,11 +44,25 @@ func (m *MockSyncOperations) EXPECT() *MockSyncOperationsMockRecorder {
 	return m.recorder
 }
 
+// AccountID mocks base method.
+func (m *MockSyncOperations) AccountID() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountID")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// AccountID indicates an expected call of AccountID.
+func (mr *MockSyncOperationsMockRecorder) AccountID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountID", reflect.TypeOf((*MockSyncOperations)(nil).AccountID))
+}
+
 // FetchNotificationsToSync mocks base method.
-func (m *MockSyncOperations) FetchNotificationsToSync(ctx context.Context, syncCtx sync.SyncContext) ([]types.NotificationThread, error) {
+func (m *MockSyncOperations) FetchNotificationsToSync(ctx context.Context, syncCtx sync.SyncContext) (types.NotificationPoll, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FetchNotificationsToSync", ctx, syncCtx)
-	ret0, _ := ret[0].([]types.NotificationThread)
+	ret0, _ := ret[0].(types.NotificationPoll)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -73,6 +88,20 @@ func (mr *MockSyncOperationsMockRecorder) FetchOlderNotificationsToSync(ctx, sin
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOlderNotificationsToSync", reflect.TypeOf((*MockSyncOperations)(nil).FetchOlderNotificationsToSync), ctx, since, until, maxCount, unreadOnly)
 }
 
+// FinishSyncRun mocks base method.
+func (m *MockSyncOperations) FinishSyncRun(ctx context.Context, runID int64, result models.SyncRunResult, runErr error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSyncRun", ctx, runID, result, runErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishSyncRun indicates an expected call of FinishSyncRun.
+func (mr *MockSyncOperationsMockRecorder) FinishSyncRun(ctx, runID, result, runErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSyncRun", reflect.TypeOf((*MockSyncOperations)(nil).FinishSyncRun), ctx, runID, result, runErr)
+}
+
 // GetSyncContext mocks base method.
 func (m *MockSyncOperations) GetSyncContext(ctx context.Context) (sync.SyncContext, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +117,35 @@ func (mr *MockSyncOperationsMockRecorder) GetSyncContext(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncContext", reflect.TypeOf((*MockSyncOperations)(nil).GetSyncContext), ctx)
 }
 
+// HydrateSubjects mocks base method.
+func (m *MockSyncOperations) HydrateSubjects(ctx context.Context, threads []types.NotificationThread) (map[string]types.HydratedSubject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HydrateSubjects", ctx, threads)
+	ret0, _ := ret[0].(map[string]types.HydratedSubject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HydrateSubjects indicates an expected call of HydrateSubjects.
+func (mr *MockSyncOperationsMockRecorder) HydrateSubjects(ctx, threads any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HydrateSubjects", reflect.TypeOf((*MockSyncOperations)(nil).HydrateSubjects), ctx, threads)
+}
+
+// ProcessHydratedNotification mocks base method.
+func (m *MockSyncOperations) ProcessHydratedNotification(ctx context.Context, thread types.NotificationThread, subject types.HydratedSubject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessHydratedNotification", ctx, thread, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessHydratedNotification indicates an expected call of ProcessHydratedNotification.
+func (mr *MockSyncOperationsMockRecorder) ProcessHydratedNotification(ctx, thread, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessHydratedNotification", reflect.TypeOf((*MockSyncOperations)(nil).ProcessHydratedNotification), ctx, thread, subject)
+}
+
 // ProcessNotification mocks base method.
 func (m *MockSyncOperations) ProcessNotification(ctx context.Context, thread types.NotificationThread) error {
 	m.ctrl.T.Helper()
@@ -102,6 +160,109 @@ func (mr *MockSyncOperationsMockRecorder) ProcessNotification(ctx, thread any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessNotification", reflect.TypeOf((*MockSyncOperations)(nil).ProcessNotification), ctx, thread)
 }
 
+// PruneSyncRuns mocks base method.
+func (m *MockSyncOperations) PruneSyncRuns(ctx context.Context, startedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneSyncRuns", ctx, startedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneSyncRuns indicates an expected call of PruneSyncRuns.
+func (mr *MockSyncOperationsMockRecorder) PruneSyncRuns(ctx, startedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneSyncRuns", reflect.TypeOf((*MockSyncOperations)(nil).PruneSyncRuns), ctx, startedBefore)
+}
+
+// ReconcileNotifications mocks base method.
+func (m *MockSyncOperations) ReconcileNotifications(ctx context.Context, policy models.MissingThreadPolicy) (models.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileNotifications", ctx, policy)
+	ret0, _ := ret[0].(models.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileNotifications indicates an expected call of ReconcileNotifications.
+func (mr *MockSyncOperationsMockRecorder) ReconcileNotifications(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileNotifications", reflect.TypeOf((*MockSyncOperations)(nil).ReconcileNotifications), ctx, policy)
+}
+
+// RefreshStaleSubjects mocks base method.
+func (m *MockSyncOperations) RefreshStaleSubjects(ctx context.Context, fetchedBefore time.Time, limit int32) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshStaleSubjects", ctx, fetchedBefore, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshStaleSubjects indicates an expected call of RefreshStaleSubjects.
+func (mr *MockSyncOperationsMockRecorder) RefreshStaleSubjects(ctx, fetchedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshStaleSubjects", reflect.TypeOf((*MockSyncOperations)(nil).RefreshStaleSubjects), ctx, fetchedBefore, limit)
+}
+
+// RefreshSubjects mocks base method.
+func (m *MockSyncOperations) RefreshSubjects(ctx context.Context, githubIDs []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSubjects", ctx, githubIDs)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSubjects indicates an expected call of RefreshSubjects.
+func (mr *MockSyncOperationsMockRecorder) RefreshSubjects(ctx, githubIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSubjects", reflect.TypeOf((*MockSyncOperations)(nil).RefreshSubjects), ctx, githubIDs)
+}
+
+// SaveRateLimit mocks base method.
+func (m *MockSyncOperations) SaveRateLimit(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRateLimit", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRateLimit indicates an expected call of SaveRateLimit.
+func (mr *MockSyncOperationsMockRecorder) SaveRateLimit(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRateLimit", reflect.TypeOf((*MockSyncOperations)(nil).SaveRateLimit), ctx)
+}
+
+// StartSyncRun mocks base method.
+func (m *MockSyncOperations) StartSyncRun(ctx context.Context, kind models.SyncRunKind, since, before *time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSyncRun", ctx, kind, since, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSyncRun indicates an expected call of StartSyncRun.
+func (mr *MockSyncOperationsMockRecorder) StartSyncRun(ctx, kind, since, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSyncRun", reflect.TypeOf((*MockSyncOperations)(nil).StartSyncRun), ctx, kind, since, before)
+}
+
+// UpdatePollValidators mocks base method.
+func (m *MockSyncOperations) UpdatePollValidators(ctx context.Context, validators types.PollValidators) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollValidators", ctx, validators)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollValidators indicates an expected call of UpdatePollValidators.
+func (mr *MockSyncOperationsMockRecorder) UpdatePollValidators(ctx, validators any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollValidators", reflect.TypeOf((*MockSyncOperations)(nil).UpdatePollValidators), ctx, validators)
+}
+
 // UpdateSyncStateAfterProcessing mocks base method.
 func (m *MockSyncOperations) UpdateSyncStateAfterProcessing(ctx context.Context, latestUpdate time.Time) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,176 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// reconcilePageSize is how many local notifications are compared with GitHub's list at a time
+const reconcilePageSize = 500
+
+// Error definitions
+var (
+	ErrFailedToListNotifications      = errors.New("failed to list notifications")
+	ErrFailedToReconcileNotifications = errors.New("failed to reconcile notifications")
+)
+
+// ReconcileNotifications compares the account's unarchived notifications with the full
+// list of threads GitHub returns, applying the policy to those GitHub no longer returns,
+// and saves what it changed to the sync state.
+//
+// GitHub only lists threads of the last few months, so a thread is only judged missing if
+// it was updated no earlier than the oldest thread GitHub returned: GitHub would have
+// listed it since, like any thread, it can only have been updated again. Threads updated
+// after the list was fetched may have arrived since, and aren't judged either. An empty
+// list can't tell how far back GitHub looked, so it changes nothing.
+func (s *Service) ReconcileNotifications(
+	ctx context.Context,
+	policy models.MissingThreadPolicy,
+) (models.Reconciliation, error) {
+	reconciliation := models.Reconciliation{Policy: policy}
+
+	fetchedAt := s.clock()
+	threads, err := s.client.FetchNotifications(ctx, nil, nil, false)
+	if err != nil {
+		return reconciliation, errors.Join(ErrFailedToFetchNotifications, err)
+	}
+
+	listed := make(map[string]bool, len(threads))
+	var oldest time.Time
+	for _, thread := range threads {
+		listed[thread.ID] = true
+		if oldest.IsZero() || thread.UpdatedAt.Before(oldest) {
+			oldest = thread.UpdatedAt
+		}
+	}
+
+	// Page through the local notifications, unless the list is empty (see above)
+	afterID := int64(0)
+	for len(threads) > 0 {
+		rows, err := s.userStore.ListNotificationsToReconcile(
+			ctx,
+			db.ListNotificationsToReconcileParams{
+				AccountID: s.AccountID(),
+				AfterID:   afterID,
+				RowLimit:  reconcilePageSize,
+			},
+		)
+		if err != nil {
+			return reconciliation, errors.Join(ErrFailedToListNotifications, err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+		afterID = rows[len(rows)-1].ID
+
+		var missing, returned []string
+		for _, row := range rows {
+			reconciliation.Checked++
+			switch {
+			case listed[row.GithubID]:
+				if row.StaleAt.Valid {
+					returned = append(returned, row.GithubID)
+				}
+			case row.GithubUpdatedAt.Valid &&
+				!row.GithubUpdatedAt.Time.Before(oldest) &&
+				row.GithubUpdatedAt.Time.Before(fetchedAt):
+				missing = append(missing, row.GithubID)
+			}
+		}
+
+		if err := s.applyMissingThreadPolicy(ctx, policy, missing, &reconciliation); err != nil {
+			return reconciliation, err
+		}
+		if len(returned) > 0 {
+			unflagged, err := s.userStore.BulkClearNotificationsStale(
+				ctx,
+				db.BulkClearNotificationsStaleParams{AccountID: s.AccountID(), GithubIds: returned},
+			)
+			if err != nil {
+				return reconciliation, errors.Join(ErrFailedToReconcileNotifications, err)
+			}
+			reconciliation.Unflagged += int(unflagged)
+		}
+
+		if len(rows) < reconcilePageSize {
+			break
+		}
+	}
+
+	s.logger.Info("reconciled notifications with GitHub",
+		zap.Int64("accountID", s.AccountID()),
+		zap.String("policy", string(policy)),
+		zap.Int("threads", len(threads)),
+		zap.Int("checked", reconciliation.Checked),
+		zap.Int("archived", reconciliation.Archived),
+		zap.Int("flagged", reconciliation.Flagged),
+		zap.Int("deleted", reconciliation.Deleted),
+		zap.Int("unflagged", reconciliation.Unflagged))
+
+	if err := s.syncStateService.UpdateReconciliation(ctx, reconciliation, fetchedAt); err != nil {
+		return reconciliation, errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+	return reconciliation, nil
+}
+
+// applyMissingThreadPolicy archives, flags or deletes the notifications of missing threads
+func (s *Service) applyMissingThreadPolicy(
+	ctx context.Context,
+	policy models.MissingThreadPolicy,
+	githubIDs []string,
+	reconciliation *models.Reconciliation,
+) error {
+	if len(githubIDs) == 0 {
+		return nil
+	}
+
+	var (
+		changed int64
+		err     error
+	)
+	switch policy {
+	case models.MissingThreadFlag:
+		changed, err = s.userStore.BulkMarkNotificationsStale(ctx, db.BulkMarkNotificationsStaleParams{
+			AccountID: s.AccountID(),
+			GithubIds: githubIDs,
+		})
+		reconciliation.Flagged += int(changed)
+	case models.MissingThreadDelete:
+		changed, err = s.userStore.BulkDeleteNotifications(ctx, db.BulkDeleteNotificationsParams{
+			AccountID: s.AccountID(),
+			GithubIds: githubIDs,
+		})
+		reconciliation.Deleted += int(changed)
+	default:
+		changed, err = s.userStore.BulkArchiveNotifications(ctx, db.BulkArchiveNotificationsParams{
+			AccountID: s.AccountID(),
+			GithubIds: githubIDs,
+		})
+		reconciliation.Archived += int(changed)
+	}
+	if err != nil {
+		return errors.Join(ErrFailedToReconcileNotifications, err)
+	}
+	return nil
+}
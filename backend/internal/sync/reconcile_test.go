@@ -0,0 +1,225 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	githubmocks "github.com/ajbeattie/octobud/backend/internal/github/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// reconcileThreads are the threads GitHub lists in the reconciliation tests. The oldest was
+// updated at 08:00, so local notifications updated before then can't be judged.
+var reconcileThreads = []types.NotificationThread{
+	{ID: "listed", UpdatedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+	{ID: "oldest", UpdatedAt: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)},
+}
+
+// expectNotificationsToReconcile expects one page of local notifications: a stale one
+// GitHub listed again, one missing from GitHub, one older than GitHub's list and one
+// updated after the list was fetched (mockClock is 12:00)
+func expectNotificationsToReconcile(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT id, github_id, github_updated_at, stale_at`).
+		WithArgs(models.DefaultAccountID, int64(0), int32(reconcilePageSize)).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "github_id", "github_updated_at", "stale_at",
+		}).
+			AddRow(1, "listed", time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), time.Now()).
+			AddRow(2, "missing", time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), nil).
+			AddRow(3, "too-old", time.Date(2024, 1, 15, 7, 0, 0, 0, time.UTC), nil).
+			AddRow(4, "too-new", time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC), nil))
+}
+
+// expectReconciliationSaved expects the reconciliation to be saved to the sync state
+func expectReconciliationSaved(mock sqlmock.Sqlmock, policy string, counts ...int32) {
+	args := []driver.Value{
+		models.DefaultAccountID,
+		sql.NullTime{Time: mockClock(), Valid: true},
+		sql.NullString{String: policy, Valid: true},
+	}
+	for _, count := range counts {
+		args = append(args, sql.NullInt32{Int32: count, Valid: true})
+	}
+	mock.ExpectExec(`INSERT INTO sync_state`).
+		WithArgs(args...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// TestReconcileNotifications_Flag tests flagging missing threads and unflagging returned ones
+func TestReconcileNotifications_Flag(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	expectNotificationsToReconcile(mock)
+	mock.ExpectExec(`UPDATE notifications\s+SET stale_at = now\(\)`).
+		WithArgs(models.DefaultAccountID, `{"missing"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE notifications\s+SET stale_at = NULL`).
+		WithArgs(models.DefaultAccountID, `{"listed"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// Checked, archived, flagged, deleted, unflagged
+	expectReconciliationSaved(mock, "flag", 4, 0, 1, 0, 1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		FetchNotifications(gomock.Any(), nil, nil, false).
+		Return(reconcileThreads, nil)
+	service := setupSyncService(t, dbConn, mockClient)
+
+	reconciliation, err := service.ReconcileNotifications(
+		context.Background(),
+		models.MissingThreadFlag,
+	)
+	require.NoError(t, err)
+	require.Equal(t, models.Reconciliation{
+		Policy:    models.MissingThreadFlag,
+		Checked:   4,
+		Flagged:   1,
+		Unflagged: 1,
+	}, reconciliation)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestReconcileNotifications_Archive tests archiving missing threads
+func TestReconcileNotifications_Archive(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	expectNotificationsToReconcile(mock)
+	mock.ExpectExec(`UPDATE notifications\s+SET archived = true`).
+		WithArgs(models.DefaultAccountID, `{"missing"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE notifications\s+SET stale_at = NULL`).
+		WithArgs(models.DefaultAccountID, `{"listed"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectReconciliationSaved(mock, "archive", 4, 1, 0, 0, 1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		FetchNotifications(gomock.Any(), nil, nil, false).
+		Return(reconcileThreads, nil)
+	service := setupSyncService(t, dbConn, mockClient)
+
+	reconciliation, err := service.ReconcileNotifications(
+		context.Background(),
+		models.MissingThreadArchive,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, reconciliation.Archived)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestReconcileNotifications_Delete tests deleting missing threads
+func TestReconcileNotifications_Delete(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	expectNotificationsToReconcile(mock)
+	mock.ExpectExec(`DELETE FROM notifications`).
+		WithArgs(models.DefaultAccountID, `{"missing"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE notifications\s+SET stale_at = NULL`).
+		WithArgs(models.DefaultAccountID, `{"listed"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectReconciliationSaved(mock, "delete", 4, 0, 0, 1, 1)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		FetchNotifications(gomock.Any(), nil, nil, false).
+		Return(reconcileThreads, nil)
+	service := setupSyncService(t, dbConn, mockClient)
+
+	reconciliation, err := service.ReconcileNotifications(
+		context.Background(),
+		models.MissingThreadDelete,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, reconciliation.Deleted)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestReconcileNotifications_EmptyList tests that an empty list from GitHub changes nothing
+func TestReconcileNotifications_EmptyList(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	expectReconciliationSaved(mock, "delete", 0, 0, 0, 0, 0)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		FetchNotifications(gomock.Any(), nil, nil, false).
+		Return(nil, nil)
+	service := setupSyncService(t, dbConn, mockClient)
+
+	reconciliation, err := service.ReconcileNotifications(
+		context.Background(),
+		models.MissingThreadDelete,
+	)
+	require.NoError(t, err)
+	require.Zero(t, reconciliation.Checked)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestReconcileNotifications_FetchError tests that nothing changes when GitHub's list fails
+func TestReconcileNotifications_FetchError(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rateLimited := &github.RateLimitedError{ResetAt: mockClock().Add(time.Hour)}
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		FetchNotifications(gomock.Any(), nil, nil, false).
+		Return(nil, rateLimited)
+	service := setupSyncService(t, dbConn, mockClient)
+
+	_, err = service.ReconcileNotifications(context.Background(), models.MissingThreadArchive)
+	require.ErrorIs(t, err, ErrFailedToFetchNotifications)
+	var rateLimitedErr *github.RateLimitedError
+	require.True(t, errors.As(err, &rateLimitedErr))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
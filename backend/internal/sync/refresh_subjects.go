@@ -0,0 +1,174 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// refreshBatchSize is how many stale subjects are fetched per GraphQL query
+const refreshBatchSize = 50
+
+// RefreshStaleSubjects refreshes the subjects of up to limit inbox and starred
+// notifications last fetched before fetchedBefore, most recently viewed first, and
+// returns how many were refreshed.
+//
+// Subject state only changes locally when a thread's updated_at does, so a pull request
+// merged without a new notification would otherwise stay open. Issues, pull requests and
+// discussions are fetched refreshBatchSize at a time through GraphQL, other subjects one
+// by one. A notification that can't be refreshed is logged and counts as fetched, so it's
+// tried again once it's stale again rather than first in line; a rate limit error stops
+// the refresh and is returned.
+func (s *Service) RefreshStaleSubjects(
+	ctx context.Context,
+	fetchedBefore time.Time,
+	limit int32,
+) (int, error) {
+	githubIDs, err := s.userStore.ListNotificationsWithStaleSubjects(
+		ctx,
+		db.ListNotificationsWithStaleSubjectsParams{
+			AccountID:     s.AccountID(),
+			FetchedBefore: models.SQLNullTime(&fetchedBefore),
+			RowLimit:      limit,
+		},
+	)
+	if err != nil {
+		return 0, errors.Join(ErrFailedToListNotifications, err)
+	}
+
+	refreshed, failed, err := s.refreshSubjects(ctx, githubIDs)
+	if err != nil {
+		return refreshed, err
+	}
+
+	s.logger.Info("refreshed stale subjects",
+		zap.Int64("accountID", s.AccountID()),
+		zap.Int("stale", len(githubIDs)),
+		zap.Int("refreshed", refreshed),
+		zap.Int("failed", failed))
+	return refreshed, nil
+}
+
+// RefreshSubjects refreshes the subjects of the account's notifications with the given
+// GitHub IDs like RefreshStaleSubjects does, and returns how many were refreshed.
+func (s *Service) RefreshSubjects(ctx context.Context, githubIDs []string) (int, error) {
+	refreshed, failed, err := s.refreshSubjects(ctx, githubIDs)
+	if err != nil {
+		return refreshed, err
+	}
+
+	s.logger.Debug("refreshed subjects",
+		zap.Int64("accountID", s.AccountID()),
+		zap.Int("refreshed", refreshed),
+		zap.Int("failed", failed))
+	return refreshed, nil
+}
+
+// refreshSubjects refreshes the subjects of notifications refreshBatchSize at a time and
+// returns how many were refreshed and how many failed. Only a rate limit error is returned.
+func (s *Service) refreshSubjects(
+	ctx context.Context,
+	githubIDs []string,
+) (refreshed, failed int, err error) {
+	for start := 0; start < len(githubIDs); start += refreshBatchSize {
+		batch := githubIDs[start:min(start+refreshBatchSize, len(githubIDs))]
+		batchRefreshed, batchFailed, err := s.refreshSubjectBatch(ctx, batch)
+		refreshed += batchRefreshed
+		failed += batchFailed
+		if err != nil {
+			return refreshed, failed, err
+		}
+	}
+	return refreshed, failed, nil
+}
+
+// refreshSubjectBatch refreshes the subjects of a batch of notifications and returns how
+// many were refreshed and how many failed. Only a rate limit error is returned.
+func (s *Service) refreshSubjectBatch(
+	ctx context.Context,
+	githubIDs []string,
+) (refreshed, failed int, err error) {
+	notifications := make([]db.Notification, 0, len(githubIDs))
+	threads := make([]types.NotificationThread, 0, len(githubIDs))
+	for _, githubID := range githubIDs {
+		notification, err := s.notificationService.GetByGithubID(ctx, s.AccountID(), githubID)
+		if err != nil {
+			failed++
+			s.logger.Warn("failed to get notification with stale subject",
+				zap.String("githubID", githubID),
+				zap.Error(err))
+			continue
+		}
+		notifications = append(notifications, notification)
+		threads = append(threads, subjectThread(notification))
+	}
+
+	hydrated, err := s.HydrateSubjects(ctx, threads)
+	if err != nil {
+		if isRateLimited(err) || ctx.Err() != nil {
+			return 0, failed, err
+		}
+		// Fall back to fetching each subject on its own
+		s.logger.Warn("failed to fetch stale subjects in a batch", zap.Error(err))
+	}
+
+	for _, notification := range notifications {
+		var subject *types.HydratedSubject
+		if hydratedSubject, ok := hydrated[notification.GithubID]; ok {
+			subject = &hydratedSubject
+		}
+
+		err := s.refreshSubject(ctx, notification, subject)
+		if err == nil {
+			refreshed++
+			continue
+		}
+		if isRateLimited(err) || ctx.Err() != nil {
+			return refreshed, failed, err
+		}
+		failed++
+		s.logger.Warn("failed to refresh stale subject",
+			zap.String("githubID", notification.GithubID),
+			zap.Error(err))
+		s.markSubjectFetched(ctx, notification.GithubID)
+	}
+	return refreshed, failed, nil
+}
+
+// markSubjectFetched sets a notification's subject_fetched_at to now after a failed
+// refresh, so subjects that keep failing (deleted or inaccessible ones, say) don't take
+// every run's slots ahead of the others
+func (s *Service) markSubjectFetched(ctx context.Context, githubID string) {
+	fetched := s.clock().UTC()
+	err := s.userStore.MarkNotificationSubjectFetched(ctx, db.MarkNotificationSubjectFetchedParams{
+		FetchedAt: models.SQLNullTime(&fetched),
+		AccountID: s.AccountID(),
+		GithubID:  githubID,
+	})
+	if err != nil {
+		s.logger.Warn("failed to mark stale subject as fetched",
+			zap.String("githubID", githubID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	githubmocks "github.com/ajbeattie/octobud/backend/internal/github/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// notificationRows returns rows of a notifications query, one column per field of
+// db.Notification in the order sqlc scans them
+func notificationRows(t *testing.T, notifications ...db.Notification) *sqlmock.Rows {
+	t.Helper()
+	notificationType := reflect.TypeOf(db.Notification{})
+	columns := make([]string, notificationType.NumField())
+	for i := range columns {
+		columns[i] = fmt.Sprintf("column%d", i)
+	}
+
+	rows := sqlmock.NewRows(columns)
+	for _, notification := range notifications {
+		value := reflect.ValueOf(notification)
+		row := make([]driver.Value, value.NumField())
+		for i := range row {
+			field := value.Field(i).Interface()
+			switch field := field.(type) {
+			case driver.Valuer:
+				v, err := field.Value()
+				require.NoError(t, err)
+				row[i] = v
+			case []int64, []string:
+				v, err := pq.Array(field).Value()
+				require.NoError(t, err)
+				row[i] = v
+			default:
+				row[i] = field
+			}
+		}
+		rows.AddRow(row...)
+	}
+	return rows
+}
+
+// TestRefreshStaleSubjects_SkipsFailures tests that a notification that can't be
+// refreshed doesn't stop the others
+func TestRefreshStaleSubjects_SkipsFailures(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	fetchedBefore := time.Date(2024, 1, 15, 6, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT github_id\s+FROM notifications`).
+		WithArgs(
+			models.DefaultAccountID,
+			sql.NullTime{Time: fetchedBefore, Valid: true},
+			int32(50),
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"github_id"}).
+			AddRow("viewed").
+			AddRow("unviewed"))
+	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE account_id = \$1\s+AND github_id`).
+		WithArgs(models.DefaultAccountID, "viewed").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE account_id = \$1\s+AND github_id`).
+		WithArgs(models.DefaultAccountID, "unviewed").
+		WillReturnError(sql.ErrNoRows)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := setupSyncService(t, dbConn, githubmocks.NewMockClient(ctrl))
+
+	refreshed, err := service.RefreshStaleSubjects(context.Background(), fetchedBefore, 50)
+	require.NoError(t, err)
+	require.Zero(t, refreshed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRefreshStaleSubjects_ListError tests that failing to list notifications is returned
+func TestRefreshStaleSubjects_ListError(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	mock.ExpectQuery(`SELECT github_id\s+FROM notifications`).
+		WillReturnError(errors.New("database error"))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := setupSyncService(t, dbConn, githubmocks.NewMockClient(ctrl))
+
+	_, err = service.RefreshStaleSubjects(context.Background(), mockClock(), 50)
+	require.ErrorIs(t, err, ErrFailedToListNotifications)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRefreshStaleSubjects_Batch tests that issues and pull requests are fetched in one
+// GraphQL query, and that a subject missing from it is fetched on its own and, failing
+// that, marked as fetched
+func TestRefreshStaleSubjects_Batch(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	fetchedBefore := time.Date(2024, 1, 15, 6, 0, 0, 0, time.UTC)
+	issue := func(githubID string, number int) db.Notification {
+		return db.Notification{
+			GithubID:     githubID,
+			AccountID:    models.DefaultAccountID,
+			SubjectType:  "Issue",
+			SubjectTitle: "Issue " + githubID,
+			SubjectUrl: sql.NullString{
+				String: fmt.Sprintf("https://api.github.com/repos/owner/repo/issues/%d", number),
+				Valid:  true,
+			},
+		}
+	}
+	mock.ExpectQuery(`SELECT github_id\s+FROM notifications`).
+		WillReturnRows(sqlmock.NewRows([]string{"github_id"}).
+			AddRow("closed").
+			AddRow("deleted"))
+	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE account_id = \$1\s+AND github_id`).
+		WithArgs(models.DefaultAccountID, "closed").
+		WillReturnRows(notificationRows(t, issue("closed", 1)))
+	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE account_id = \$1\s+AND github_id`).
+		WithArgs(models.DefaultAccountID, "deleted").
+		WillReturnRows(notificationRows(t, issue("deleted", 2)))
+	mock.ExpectExec(`UPDATE notifications\s+SET subject_raw`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The subject that failed to refresh waits for the next refresh
+	mock.ExpectExec(`UPDATE notifications\s+SET subject_fetched_at`).
+		WithArgs(sql.NullTime{Time: mockClock(), Valid: true}, models.DefaultAccountID, "deleted").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	closed := types.SubjectRef{Owner: "owner", Repo: "repo", Number: 1, Type: "Issue"}
+	deleted := types.SubjectRef{Owner: "owner", Repo: "repo", Number: 2, Type: "Issue"}
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().Endpoints().Return(github.NewEndpoints("", "")).AnyTimes()
+	mockClient.EXPECT().ViewerLogin().Return("").AnyTimes()
+	mockClient.EXPECT().
+		HydrateSubjects(gomock.Any(), []types.SubjectRef{closed, deleted}).
+		Return(map[types.SubjectRef]types.HydratedSubject{
+			closed: {Raw: []byte(`{"number": 1, "state": "closed"}`)},
+		}, nil)
+	mockClient.EXPECT().
+		FetchSubjectRaw(gomock.Any(), "https://api.github.com/repos/owner/repo/issues/2").
+		Return(nil, errors.New("not found"))
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	refreshed, err := service.RefreshStaleSubjects(context.Background(), fetchedBefore, 50)
+	require.NoError(t, err)
+	require.Equal(t, 1, refreshed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRefreshStaleSubjects_RateLimited tests that a rate limited batch stops the refresh
+func TestRefreshStaleSubjects_RateLimited(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	mock.ExpectQuery(`SELECT github_id\s+FROM notifications`).
+		WillReturnRows(sqlmock.NewRows([]string{"github_id"}).AddRow("open"))
+	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE account_id = \$1\s+AND github_id`).
+		WithArgs(models.DefaultAccountID, "open").
+		WillReturnRows(notificationRows(t, db.Notification{
+			GithubID:    "open",
+			AccountID:   models.DefaultAccountID,
+			SubjectType: "PullRequest",
+			SubjectUrl: sql.NullString{
+				String: "https://api.github.com/repos/owner/repo/pulls/3",
+				Valid:  true,
+			},
+		}))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().Endpoints().Return(github.NewEndpoints("", "")).AnyTimes()
+	mockClient.EXPECT().
+		HydrateSubjects(gomock.Any(), gomock.Any()).
+		Return(nil, &github.RateLimitedError{ResetAt: time.Now().Add(time.Hour)})
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	refreshed, err := service.RefreshStaleSubjects(context.Background(), mockClock(), 50)
+	var rateLimited *github.RateLimitedError
+	require.ErrorAs(t, err, &rateLimited)
+	require.Zero(t, refreshed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/db"
 	githubinterfaces "github.com/ajbeattie/octobud/backend/internal/github/interfaces"
 	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 )
 
 // SyncContext contains ALL state needed for a sync operation.
@@ -52,6 +53,10 @@ type SyncContext struct { //nolint:revive // exported type name stutters with pa
 	MaxCount   *int // Maximum notifications to sync (nil = no limit)
 	UnreadOnly bool // Only sync unread notifications
 
+	// PollValidators are the ETag and Last-Modified of the previous poll, sent with a regular
+	// sync so GitHub can answer 304 Not Modified (empty for the initial sync)
+	PollValidators types.PollValidators
+
 	// OldestNotificationSyncedAt is for tracking purposes (used by job)
 	// Existing oldest notification timestamp from previous partial sync (zero if none)
 	OldestNotificationSyncedAt time.Time
@@ -59,6 +64,9 @@ type SyncContext struct { //nolint:revive // exported type name stutters with pa
 
 // SyncOperations interface defines operations for syncing notifications
 type SyncOperations interface { //nolint:revive // exported type name stutters with package name
+	// AccountID returns the GitHub account whose notifications this service syncs.
+	AccountID() int64
+
 	// GetSyncContext gathers ALL necessary state for a sync operation.
 	// This should be called once at the start of a sync job.
 	// All state fetching happens here - no other method should fetch state.
@@ -67,10 +75,11 @@ type SyncOperations interface { //nolint:revive // exported type name stutters w
 	// FetchNotificationsToSync fetches notifications from GitHub using the provided context.
 	// This method ONLY fetches and filters - it does NOT fetch or update any state.
 	// The syncCtx parameter MUST come from GetSyncContext().
+	// The poll is conditional: NotModified is set when nothing changed since the previous poll.
 	FetchNotificationsToSync(
 		ctx context.Context,
 		syncCtx SyncContext,
-	) ([]types.NotificationThread, error)
+	) (types.NotificationPoll, error)
 
 	// FetchOlderNotificationsToSync fetches notifications older than the specified until time.
 	// This is used for backfilling older notifications that weren't included in initial sync.
@@ -89,6 +98,10 @@ type SyncOperations interface { //nolint:revive // exported type name stutters w
 	// UpdateSyncStateAfterProcessing updates sync state after notifications are processed.
 	UpdateSyncStateAfterProcessing(ctx context.Context, latestUpdate time.Time) error
 
+	// UpdatePollValidators saves the validators of a poll whose notifications were all queued,
+	// to send with the next poll.
+	UpdatePollValidators(ctx context.Context, validators types.PollValidators) error
+
 	// UpdateSyncStateAfterProcessingWithInitialSync updates sync state including initial sync markers.
 	UpdateSyncStateAfterProcessingWithInitialSync(
 		ctx context.Context,
@@ -97,8 +110,68 @@ type SyncOperations interface { //nolint:revive // exported type name stutters w
 		oldestNotificationSyncedAt *time.Time,
 	) error
 
+	// SaveRateLimit saves the GitHub client's latest rate limit budget, for the sync state API.
+	SaveRateLimit(ctx context.Context) error
+
+	// ReconcileNotifications compares the unarchived notifications with GitHub's full list,
+	// applying the policy to threads GitHub no longer returns, and saves what it changed to
+	// the sync state. A *github.RateLimitedError from GitHub is returned.
+	ReconcileNotifications(
+		ctx context.Context,
+		policy models.MissingThreadPolicy,
+	) (models.Reconciliation, error)
+
+	// RefreshStaleSubjects refreshes the subjects of up to limit inbox and starred
+	// notifications fetched before fetchedBefore, most recently viewed first. A
+	// *github.RateLimitedError stops the refresh and is returned.
+	RefreshStaleSubjects(ctx context.Context, fetchedBefore time.Time, limit int32) (int, error)
+
+	// RefreshSubjects refreshes the subjects of the account's notifications with the given
+	// GitHub IDs. A *github.RateLimitedError stops the refresh and is returned.
+	RefreshSubjects(ctx context.Context, githubIDs []string) (int, error)
+
+	// StartSyncRun records the start of a sync or backfill run fetching the notifications
+	// updated since since and before before (either may be nil), and returns its ID.
+	StartSyncRun(
+		ctx context.Context,
+		kind models.SyncRunKind,
+		since, before *time.Time,
+	) (int64, error)
+
+	// FinishSyncRun records what a sync run did and the error that ended it, if any, with
+	// the GitHub client's latest rate limit budget. A runID of 0 is ignored.
+	FinishSyncRun(
+		ctx context.Context,
+		runID int64,
+		result models.SyncRunResult,
+		runErr error,
+	) error
+
+	// PruneSyncRuns deletes the sync runs of every account started before startedBefore,
+	// and returns how many were deleted.
+	PruneSyncRuns(ctx context.Context, startedBefore time.Time) (int64, error)
+
 	// ProcessNotification processes a single notification (upserts repo, fetches subject, etc.)
+	// A *github.RateLimitedError from a GitHub call is returned, so the job can be retried
+	// after the reset, rather than saving the notification without its subject. Other subject
+	// fetch failures are counted against the sync run set on ctx by WithSyncRun.
 	ProcessNotification(ctx context.Context, thread types.NotificationThread) error
+
+	// HydrateSubjects fetches the subjects of threads in batches through GitHub's GraphQL API,
+	// keyed by thread ID. Subjects that can't be batched (commits, releases, CI activity) or
+	// weren't found are left out, for ProcessNotification to fetch one at a time.
+	HydrateSubjects(
+		ctx context.Context,
+		threads []types.NotificationThread,
+	) (map[string]types.HydratedSubject, error)
+
+	// ProcessHydratedNotification is ProcessNotification with the subject already fetched by
+	// HydrateSubjects, so it makes no GitHub calls.
+	ProcessHydratedNotification(
+		ctx context.Context,
+		thread types.NotificationThread,
+		subject types.HydratedSubject,
+	) error
 }
 
 // Service coordinates fetching notifications from GitHub and persisting them.
@@ -110,7 +183,8 @@ type Service struct {
 	repositoryService   *repository.Service
 	pullRequestService  *pullrequest.Service
 	notificationService *notification.Service
-	userStore           db.Store // Used only for GetUser (sync settings)
+	userStore           db.Store // Used for sync settings and reconciliation
+	accountID           int64    // Set by WithAccount; 0 syncs the default account
 }
 
 // NewService assembles a Service with the provided dependencies.
@@ -136,6 +210,23 @@ func NewService(
 	}
 }
 
+// WithAccount makes the service sync the notifications of a GitHub account: they are
+// tagged with the account, its sync state is used (the sync state service is switched to
+// the account too) and the account's sync settings override the user's.
+func (s *Service) WithAccount(accountID int64) *Service {
+	s.accountID = accountID
+	s.syncStateService.WithAccount(accountID)
+	return s
+}
+
+// AccountID returns the GitHub account whose notifications this service syncs.
+func (s *Service) AccountID() int64 {
+	if s.accountID == 0 {
+		return models.DefaultAccountID
+	}
+	return s.accountID
+}
+
 // GitHubClient returns the GitHub client used by this service.
 func (s *Service) GitHubClient() githubinterfaces.Client {
 	return s.client
@@ -0,0 +1,317 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	githubinterfaces "github.com/ajbeattie/octobud/backend/internal/github/interfaces"
+)
+
+// workflowRunsPerPage is how many of a branch's latest workflow runs are searched for the
+// run a check suite notification is about
+const workflowRunsPerPage = 20
+
+// subjectSource is what a subject handler knows about a subject before fetching it
+type subjectSource struct {
+	Type         string // The notification's subject type
+	URL          string // The subject's API URL, empty for CI activity and some alerts
+	Title        string
+	RepoFullName string
+}
+
+// subjectFields are the type-specific fields a subject handler reads from a subject.
+// Fields a type doesn't have are left NULL.
+type subjectFields struct {
+	Tag        sql.NullString // A release's tag
+	Conclusion sql.NullString // A workflow run's conclusion (success, failure, ...)
+	SHA        sql.NullString // A commit's SHA, or the head SHA of a workflow run
+	Severity   sql.NullString // An advisory's severity (low, medium, high or critical)
+}
+
+// subjectHandler fetches and normalises the subjects of one notification subject type
+type subjectHandler interface {
+	// FetchSubject fetches the subject's JSON, or returns nil when there is nothing to fetch
+	FetchSubject(
+		ctx context.Context,
+		client githubinterfaces.Client,
+		source subjectSource,
+	) (json.RawMessage, error)
+
+	// Fields reads the type's fields from a subject. subjectRaw is nil when the subject
+	// couldn't be fetched, so handlers can fall back to the notification itself.
+	Fields(source subjectSource, subjectRaw json.RawMessage) subjectFields
+}
+
+// subjectHandlers is the registry of subject handlers, keyed by normalised subject type.
+// Issues, pull requests and discussions use the default handler: their fields are read by
+// the github package's extractors, which every subject goes through.
+var subjectHandlers = map[string]subjectHandler{
+	"release":                          releaseHandler{},
+	"commit":                           commitHandler{},
+	"checksuite":                       checkSuiteHandler{},
+	"securityadvisory":                 advisoryHandler{},
+	"repositoryvulnerabilityalert":     dependabotAlertHandler{},
+	"repositorydependabotalertsthread": dependabotAlertHandler{},
+}
+
+// subjectHandlerFor returns the handler of a subject type, falling back to fetching the
+// subject URL with no type-specific fields
+func subjectHandlerFor(subjectType string) subjectHandler {
+	if handler, ok := subjectHandlers[normalizeSubjectType(subjectType)]; ok {
+		return handler
+	}
+	return urlSubjectHandler{}
+}
+
+// normalizeSubjectType lowercases a subject type and drops underscores, so PullRequest and
+// pull_request are the same type
+func normalizeSubjectType(subjectType string) string {
+	return strings.ToLower(strings.ReplaceAll(subjectType, "_", ""))
+}
+
+// urlSubjectHandler fetches the subject URL and reads no fields of its own
+type urlSubjectHandler struct{}
+
+func (urlSubjectHandler) FetchSubject(
+	ctx context.Context,
+	client githubinterfaces.Client,
+	source subjectSource,
+) (json.RawMessage, error) {
+	return client.FetchSubjectRaw(ctx, source.URL)
+}
+
+func (urlSubjectHandler) Fields(subjectSource, json.RawMessage) subjectFields {
+	return subjectFields{}
+}
+
+// releaseHandler reads the tag of a release
+type releaseHandler struct{ urlSubjectHandler }
+
+func (releaseHandler) Fields(_ subjectSource, subjectRaw json.RawMessage) subjectFields {
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	_ = json.Unmarshal(subjectRaw, &release)
+	return subjectFields{Tag: nullString(release.TagName)}
+}
+
+// commitHandler reads the SHA of a commit, from the subject URL when it wasn't fetched
+type commitHandler struct{ urlSubjectHandler }
+
+func (commitHandler) Fields(source subjectSource, subjectRaw json.RawMessage) subjectFields {
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	_ = json.Unmarshal(subjectRaw, &commit)
+	if commit.SHA == "" {
+		if _, sha, ok := strings.Cut(source.URL, "/commits/"); ok {
+			commit.SHA = sha
+		}
+	}
+	return subjectFields{SHA: nullString(commit.SHA)}
+}
+
+// advisoryHandler reads the severity of a security advisory
+type advisoryHandler struct{ urlSubjectHandler }
+
+func (advisoryHandler) Fields(_ subjectSource, subjectRaw json.RawMessage) subjectFields {
+	return subjectFields{Severity: alertSeverity(subjectRaw)}
+}
+
+// dependabotAlertHandler reads the severity of Dependabot alerts. Alert threads usually
+// have no subject URL, so the repository's most severe open alert is fetched instead.
+type dependabotAlertHandler struct{}
+
+func (dependabotAlertHandler) FetchSubject(
+	ctx context.Context,
+	client githubinterfaces.Client,
+	source subjectSource,
+) (json.RawMessage, error) {
+	if source.URL != "" || source.RepoFullName == "" {
+		return client.FetchSubjectRaw(ctx, source.URL)
+	}
+
+	alertsURL := fmt.Sprintf(
+		"%s/repos/%s/dependabot/alerts?state=open&sort=created&direction=desc&per_page=100",
+		client.Endpoints().APIURL,
+		source.RepoFullName,
+	)
+	raw, err := client.FetchSubjectRaw(ctx, alertsURL)
+	if err != nil {
+		return nil, err
+	}
+	var alerts []json.RawMessage
+	if err := json.Unmarshal(raw, &alerts); err != nil {
+		return nil, fmt.Errorf("decode dependabot alerts: %w", err)
+	}
+
+	var worst json.RawMessage
+	for _, alert := range alerts {
+		if worst == nil || severityRank(alertSeverity(alert)) > severityRank(alertSeverity(worst)) {
+			worst = alert
+		}
+	}
+	return worst, nil
+}
+
+func (dependabotAlertHandler) Fields(_ subjectSource, subjectRaw json.RawMessage) subjectFields {
+	return subjectFields{Severity: alertSeverity(subjectRaw)}
+}
+
+// checkSuiteTitlePattern matches the titles of check suite notifications, such as
+// "CI workflow run failed for main branch"
+var checkSuiteTitlePattern = regexp.MustCompile(`^(.+) workflow run (.+) for (.+) branch$`)
+
+// checkSuiteOutcomes maps the outcomes in check suite titles to workflow run conclusions
+var checkSuiteOutcomes = map[string]string{
+	"failed":    "failure",
+	"succeeded": "success",
+	"cancelled": "cancelled",
+	"skipped":   "skipped",
+	"timed out": "timed_out",
+}
+
+// checkSuiteTitle is what a check suite notification's title says about its workflow run
+type checkSuiteTitle struct {
+	Workflow   string
+	Conclusion string
+	Branch     string
+}
+
+// parseCheckSuiteTitle parses a check suite notification's title, reporting false for
+// titles in another format
+func parseCheckSuiteTitle(title string) (checkSuiteTitle, bool) {
+	match := checkSuiteTitlePattern.FindStringSubmatch(title)
+	if match == nil {
+		return checkSuiteTitle{}, false
+	}
+	return checkSuiteTitle{
+		Workflow:   match[1],
+		Conclusion: checkSuiteOutcomes[match[2]],
+		Branch:     match[3],
+	}, true
+}
+
+// checkSuiteHandler reads the conclusion and head SHA of the workflow run a check suite
+// notification is about. Check suites have no subject URL, so the run is found among the
+// latest runs on the branch named in the title.
+type checkSuiteHandler struct{}
+
+func (checkSuiteHandler) FetchSubject(
+	ctx context.Context,
+	client githubinterfaces.Client,
+	source subjectSource,
+) (json.RawMessage, error) {
+	title, ok := parseCheckSuiteTitle(source.Title)
+	if source.URL != "" || !ok || source.RepoFullName == "" {
+		return client.FetchSubjectRaw(ctx, source.URL)
+	}
+
+	runsURL := fmt.Sprintf(
+		"%s/repos/%s/actions/runs?branch=%s&per_page=%d",
+		client.Endpoints().APIURL,
+		source.RepoFullName,
+		url.QueryEscape(title.Branch),
+		workflowRunsPerPage,
+	)
+	raw, err := client.FetchSubjectRaw(ctx, runsURL)
+	if err != nil {
+		return nil, err
+	}
+	var runs struct {
+		WorkflowRuns []json.RawMessage `json:"workflow_runs"`
+	}
+	if err := json.Unmarshal(raw, &runs); err != nil {
+		return nil, fmt.Errorf("decode workflow runs: %w", err)
+	}
+
+	// Runs are newest first, so the first finished run of the workflow is the one notified
+	for _, run := range runs.WorkflowRuns {
+		var summary struct {
+			Name       string `json:"name"`
+			Conclusion string `json:"conclusion"`
+		}
+		if json.Unmarshal(run, &summary) == nil && summary.Name == title.Workflow &&
+			summary.Conclusion != "" {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+func (checkSuiteHandler) Fields(source subjectSource, subjectRaw json.RawMessage) subjectFields {
+	var run struct {
+		Conclusion string `json:"conclusion"`
+		HeadSHA    string `json:"head_sha"`
+	}
+	_ = json.Unmarshal(subjectRaw, &run)
+	if run.Conclusion == "" {
+		// Fall back to the title when the run wasn't found
+		title, _ := parseCheckSuiteTitle(source.Title)
+		run.Conclusion = title.Conclusion
+	}
+	return subjectFields{
+		Conclusion: nullString(strings.ToLower(run.Conclusion)),
+		SHA:        nullString(run.HeadSHA),
+	}
+}
+
+// severities are the advisory severities, least severe first
+var severities = []string{"low", "medium", "high", "critical"}
+
+// alertSeverity reads the severity of a security advisory or a Dependabot alert, which
+// nests it in its advisory. GitHub's older "moderate" is read as medium.
+func alertSeverity(subjectRaw json.RawMessage) sql.NullString {
+	var alert struct {
+		Severity         string `json:"severity"`
+		SecurityAdvisory struct {
+			Severity string `json:"severity"`
+		} `json:"security_advisory"`
+	}
+	if err := json.Unmarshal(subjectRaw, &alert); err != nil {
+		return sql.NullString{}
+	}
+
+	severity := strings.ToLower(alert.Severity)
+	if severity == "" {
+		severity = strings.ToLower(alert.SecurityAdvisory.Severity)
+	}
+	if severity == "moderate" {
+		severity = "medium"
+	}
+	if !slices.Contains(severities, severity) {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: severity, Valid: true}
+}
+
+// severityRank orders severities, with unknown severities below low
+func severityRank(severity sql.NullString) int {
+	return slices.Index(severities, severity.String)
+}
+
+// nullString returns a valid NullString for a non-empty value
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
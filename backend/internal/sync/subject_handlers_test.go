@@ -0,0 +1,246 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	githubmocks "github.com/ajbeattie/octobud/backend/internal/github/mocks"
+)
+
+func TestSubjectHandlerFor(t *testing.T) {
+	require.IsType(t, releaseHandler{}, subjectHandlerFor("Release"))
+	require.IsType(t, checkSuiteHandler{}, subjectHandlerFor("check_suite"))
+	require.IsType(t, commitHandler{}, subjectHandlerFor("Commit"))
+	require.IsType(t, advisoryHandler{}, subjectHandlerFor("SecurityAdvisory"))
+	require.IsType(t, dependabotAlertHandler{}, subjectHandlerFor("RepositoryVulnerabilityAlert"))
+	require.IsType(
+		t,
+		dependabotAlertHandler{},
+		subjectHandlerFor("RepositoryDependabotAlertsThread"),
+	)
+	require.IsType(t, urlSubjectHandler{}, subjectHandlerFor("Issue"))
+	require.IsType(t, urlSubjectHandler{}, subjectHandlerFor("PullRequest"))
+}
+
+func TestSubjectHandler_Fields(t *testing.T) {
+	tests := []struct {
+		name        string
+		subjectType string
+		source      subjectSource
+		subjectJSON string
+		want        subjectFields
+	}{
+		{
+			name:        "release tag",
+			subjectType: "Release",
+			subjectJSON: `{"tag_name": "v2.1.0", "name": "Version 2.1"}`,
+			want:        subjectFields{Tag: sql.NullString{String: "v2.1.0", Valid: true}},
+		},
+		{
+			name:        "commit SHA",
+			subjectType: "Commit",
+			subjectJSON: `{"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e", "commit": {}}`,
+			want: subjectFields{
+				SHA: sql.NullString{
+					String: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+					Valid:  true,
+				},
+			},
+		},
+		{
+			name:        "commit SHA from the subject URL",
+			subjectType: "Commit",
+			source:      subjectSource{URL: "https://api.github.com/repos/o/r/commits/6dcb09b"},
+			want:        subjectFields{SHA: sql.NullString{String: "6dcb09b", Valid: true}},
+		},
+		{
+			name:        "advisory severity",
+			subjectType: "SecurityAdvisory",
+			subjectJSON: `{"ghsa_id": "GHSA-xxxx", "severity": "CRITICAL"}`,
+			want:        subjectFields{Severity: sql.NullString{String: "critical", Valid: true}},
+		},
+		{
+			name:        "dependabot alert severity",
+			subjectType: "RepositoryDependabotAlertsThread",
+			subjectJSON: `{"number": 2, "security_advisory": {"severity": "moderate"}}`,
+			want:        subjectFields{Severity: sql.NullString{String: "medium", Valid: true}},
+		},
+		{
+			name:        "unknown severity",
+			subjectType: "SecurityAdvisory",
+			subjectJSON: `{"severity": "unknown"}`,
+			want:        subjectFields{},
+		},
+		{
+			name:        "workflow run conclusion and SHA",
+			subjectType: "CheckSuite",
+			source:      subjectSource{Title: "CI workflow run failed for main branch"},
+			subjectJSON: `{"name": "CI", "conclusion": "timed_out", "head_sha": "abc123"}`,
+			want: subjectFields{
+				Conclusion: sql.NullString{String: "timed_out", Valid: true},
+				SHA:        sql.NullString{String: "abc123", Valid: true},
+			},
+		},
+		{
+			name:        "conclusion from the title when the run wasn't found",
+			subjectType: "CheckSuite",
+			source:      subjectSource{Title: "Deploy workflow run failed for release/1.x branch"},
+			want:        subjectFields{Conclusion: sql.NullString{String: "failure", Valid: true}},
+		},
+		{
+			name:        "check suite with an unknown title",
+			subjectType: "CheckSuite",
+			source:      subjectSource{Title: "Something else happened"},
+			want:        subjectFields{},
+		},
+		{
+			name:        "issues have no fields of their own",
+			subjectType: "Issue",
+			subjectJSON: `{"number": 1, "tag_name": "v1"}`,
+			want:        subjectFields{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var subjectRaw json.RawMessage
+			if tt.subjectJSON != "" {
+				subjectRaw = json.RawMessage(tt.subjectJSON)
+			}
+			got := subjectHandlerFor(tt.subjectType).Fields(tt.source, subjectRaw)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCheckSuiteTitle(t *testing.T) {
+	title, ok := parseCheckSuiteTitle("Build and test workflow run succeeded for main branch")
+	require.True(t, ok)
+	require.Equal(t, checkSuiteTitle{
+		Workflow:   "Build and test",
+		Conclusion: "success",
+		Branch:     "main",
+	}, title)
+
+	title, ok = parseCheckSuiteTitle("CI workflow run timed out for feature/x branch")
+	require.True(t, ok)
+	require.Equal(t, "timed_out", title.Conclusion)
+	require.Equal(t, "feature/x", title.Branch)
+
+	_, ok = parseCheckSuiteTitle("Fix the build")
+	require.False(t, ok)
+}
+
+func TestCheckSuiteHandler_FetchSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	client := githubmocks.NewMockClient(ctrl)
+	client.EXPECT().Endpoints().Return(github.NewEndpoints("", ""))
+	client.EXPECT().
+		FetchSubjectRaw(
+			ctx,
+			"https://api.github.com/repos/octo/app/actions/runs?branch=feature%2Fx&per_page=20",
+		).
+		Return(json.RawMessage(`{"workflow_runs": [
+			{"id": 3, "name": "CI", "conclusion": null, "head_sha": "ccc"},
+			{"id": 2, "name": "Lint", "conclusion": "failure", "head_sha": "bbb"},
+			{"id": 1, "name": "CI", "conclusion": "failure", "head_sha": "aaa"}
+		]}`), nil)
+
+	source := subjectSource{
+		Type:         "CheckSuite",
+		Title:        "CI workflow run failed for feature/x branch",
+		RepoFullName: "octo/app",
+	}
+	raw, err := checkSuiteHandler{}.FetchSubject(ctx, client, source)
+	require.NoError(t, err)
+	require.JSONEq(
+		t,
+		`{"id": 1, "name": "CI", "conclusion": "failure", "head_sha": "aaa"}`,
+		string(raw),
+	)
+}
+
+func TestCheckSuiteHandler_FetchSubject_UnknownTitle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Without a workflow and branch to look for, the (empty) subject URL is fetched
+	ctx := context.Background()
+	client := githubmocks.NewMockClient(ctrl)
+	client.EXPECT().FetchSubjectRaw(ctx, "").Return(nil, nil)
+
+	source := subjectSource{Type: "CheckSuite", Title: "Checks ran", RepoFullName: "octo/app"}
+	raw, err := checkSuiteHandler{}.FetchSubject(ctx, client, source)
+	require.NoError(t, err)
+	require.Nil(t, raw)
+}
+
+func TestDependabotAlertHandler_FetchSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	client := githubmocks.NewMockClient(ctrl)
+	client.EXPECT().Endpoints().Return(github.NewEndpoints("", ""))
+	client.EXPECT().
+		FetchSubjectRaw(
+			ctx,
+			"https://api.github.com/repos/octo/app/dependabot/alerts"+
+				"?state=open&sort=created&direction=desc&per_page=100",
+		).
+		Return(json.RawMessage(`[
+			{"number": 3, "security_advisory": {"severity": "low"}},
+			{"number": 2, "security_advisory": {"severity": "high"}},
+			{"number": 1, "security_advisory": {"severity": "moderate"}}
+		]`), nil)
+
+	source := subjectSource{
+		Type:         "RepositoryDependabotAlertsThread",
+		Title:        "Your repository has dependencies with security vulnerabilities",
+		RepoFullName: "octo/app",
+	}
+	raw, err := dependabotAlertHandler{}.FetchSubject(ctx, client, source)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"number": 2, "security_advisory": {"severity": "high"}}`, string(raw))
+}
+
+func TestDependabotAlertHandler_FetchSubject_AlertURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	alertURL := "https://api.github.com/repos/octo/app/dependabot/alerts/7"
+	client := githubmocks.NewMockClient(ctrl)
+	client.EXPECT().
+		FetchSubjectRaw(ctx, alertURL).
+		Return(json.RawMessage(`{"number": 7}`), nil)
+
+	source := subjectSource{URL: alertURL, RepoFullName: "octo/app"}
+	raw, err := dependabotAlertHandler{}.FetchSubject(ctx, client, source)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"number": 7}`, string(raw))
+}
@@ -48,7 +48,7 @@ var (
 // This should be called once at the start of a sync job.
 func (s *Service) GetSyncContext(ctx context.Context) (SyncContext, error) {
 	// Get sync settings
-	syncSettings, err := s.getUserSyncSettings(ctx)
+	syncSettings, err := s.getSyncSettings(ctx)
 	if err != nil {
 		s.logger.Error("failed to get sync settings", zap.Error(err))
 		return SyncContext{}, errors.Join(ErrFailedToGetSyncState, err)
@@ -89,6 +89,16 @@ func (s *Service) GetSyncContext(ctx context.Context) (SyncContext, error) {
 		// If no LatestNotificationAt, sinceTimestamp stays nil
 	}
 
+	// Conditional polling only applies to regular syncs, which repeat the same request
+	// while nothing changes
+	var validators types.PollValidators
+	if !isInitialSync {
+		validators = types.PollValidators{
+			ETag:         state.LastNotificationEtag.String,
+			LastModified: state.LastNotificationModified.String,
+		}
+	}
+
 	// Build the context with all needed values
 	syncCtx := SyncContext{
 		IsSyncConfigured: true,
@@ -96,6 +106,7 @@ func (s *Service) GetSyncContext(ctx context.Context) (SyncContext, error) {
 		SinceTimestamp:   sinceTimestamp,
 		MaxCount:         syncSettings.InitialSyncMaxCount,
 		UnreadOnly:       syncSettings.InitialSyncUnreadOnly,
+		PollValidators:   validators,
 	}
 
 	if state.OldestNotificationSyncedAt.Valid {
@@ -117,11 +128,11 @@ func (s *Service) GetSyncContext(ctx context.Context) (SyncContext, error) {
 func (s *Service) FetchNotificationsToSync(
 	ctx context.Context,
 	syncCtx SyncContext,
-) ([]types.NotificationThread, error) {
+) (types.NotificationPoll, error) {
 	// Defensive check - caller should have checked IsSyncConfigured
 	if !syncCtx.IsSyncConfigured {
 		s.logger.Warn("FetchNotificationsToSync called but sync not configured")
-		return []types.NotificationThread{}, nil
+		return types.NotificationPoll{}, nil
 	}
 
 	s.logger.Info("fetching notifications from GitHub",
@@ -130,30 +141,36 @@ func (s *Service) FetchNotificationsToSync(
 
 	// Fetch from GitHub
 	// Pass UnreadOnly to control whether to fetch all or only unread notifications
-	// Regular sync has no upper bound (before=nil)
-	threads, err := s.client.FetchNotifications(
+	// Regular sync has no upper bound, and sends the previous poll's validators
+	poll, err := s.client.PollNotifications(
 		ctx,
 		syncCtx.SinceTimestamp,
-		nil,
 		syncCtx.UnreadOnly,
+		syncCtx.PollValidators,
 	)
 	if err != nil {
 		s.logger.Error("failed to fetch notifications from GitHub", zap.Error(err))
-		return nil, errors.Join(ErrFailedToFetchNotifications, err)
+		return types.NotificationPoll{}, errors.Join(ErrFailedToFetchNotifications, err)
+	}
+
+	if poll.NotModified {
+		s.logger.Debug("notifications not modified since the last poll",
+			zap.Duration("pollInterval", poll.PollInterval))
+		return poll, nil
 	}
 
 	s.logger.Info("fetched notifications from GitHub",
-		zap.Int("count", len(threads)),
+		zap.Int("count", len(poll.Threads)),
 		zap.Bool("isInitialSync", syncCtx.IsInitialSync))
 
 	// Apply initial sync limits if this is the initial sync
 	if syncCtx.IsInitialSync && (syncCtx.MaxCount != nil || syncCtx.UnreadOnly) {
-		threads = applyInitialSyncLimitsFromContext(threads, syncCtx)
+		poll.Threads = applyInitialSyncLimitsFromContext(poll.Threads, syncCtx)
 		s.logger.Info("applied initial sync limits",
-			zap.Int("countAfterLimits", len(threads)))
+			zap.Int("countAfterLimits", len(poll.Threads)))
 	}
 
-	return threads, nil
+	return poll, nil
 }
 
 // FetchOlderNotificationsToSync fetches notifications older than the specified until time.
@@ -229,6 +246,46 @@ func (s *Service) UpdateSyncStateAfterProcessingWithInitialSync(
 	return nil
 }
 
+// UpdatePollValidators saves the validators of the latest poll for the next one.
+// Only call it once every notification of the poll has been queued: the next poll may be
+// answered with 304 Not Modified, which would skip anything that wasn't.
+func (s *Service) UpdatePollValidators(
+	ctx context.Context,
+	validators types.PollValidators,
+) error {
+	if _, err := s.syncStateService.UpdatePollValidators(
+		ctx,
+		validators.ETag,
+		validators.LastModified,
+	); err != nil {
+		s.logger.Error("failed to save poll validators", zap.Error(err))
+		return errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+
+	return nil
+}
+
+// SaveRateLimit saves the GitHub client's latest rate limit budget. Nothing is saved until
+// the client has seen a response.
+func (s *Service) SaveRateLimit(ctx context.Context) error {
+	limit := s.client.RateLimit()
+	if limit.Limit == 0 {
+		return nil
+	}
+
+	if err := s.syncStateService.UpdateRateLimit(
+		ctx,
+		limit.Limit,
+		limit.Remaining,
+		limit.ResetAt,
+	); err != nil {
+		s.logger.Error("failed to save rate limit", zap.Error(err))
+		return errors.Join(ErrFailedToUpdateSyncState, err)
+	}
+
+	return nil
+}
+
 // IsInitialSyncComplete checks if the initial sync has been completed
 func (s *Service) IsInitialSyncComplete(ctx context.Context) (bool, error) {
 	state, err := s.syncStateService.GetSyncState(ctx)
@@ -243,10 +300,30 @@ func (s *Service) IsInitialSyncComplete(ctx context.Context) (bool, error) {
 func (s *Service) ProcessNotification(
 	ctx context.Context,
 	thread types.NotificationThread,
+) error {
+	return s.processNotification(ctx, thread, nil)
+}
+
+// ProcessHydratedNotification processes a notification like ProcessNotification, using the
+// subject, reviews and checks fetched by HydrateSubjects instead of calling GitHub.
+func (s *Service) ProcessHydratedNotification(
+	ctx context.Context,
+	thread types.NotificationThread,
+	subject types.HydratedSubject,
+) error {
+	return s.processNotification(ctx, thread, &subject)
+}
+
+// processNotification processes a notification, fetching its subject unless hydrated is set
+func (s *Service) processNotification(
+	ctx context.Context,
+	thread types.NotificationThread,
+	hydrated *types.HydratedSubject,
 ) error {
 	// Upsert repository
 	rawRepo := thread.Repository.Raw()
 	repoParams := db.UpsertRepositoryParams{
+		AccountID:      s.AccountID(),
 		GithubID:       models.SQLNullInt64(thread.Repository.ID),
 		NodeID:         models.SQLNullString(thread.Repository.NodeID),
 		Name:           thread.Repository.Name,
@@ -287,8 +364,22 @@ func (s *Service) ProcessNotification(
 		subjectPayload   pqtype.NullRawMessage
 		subjectFetchedAt sql.NullTime
 	)
+	handler := subjectHandlerFor(thread.Subject.Type)
+	source := subjectSource{
+		Type:         thread.Subject.Type,
+		URL:          thread.Subject.URL,
+		Title:        thread.Subject.Title,
+		RepoFullName: thread.Repository.FullName,
+	}
 
-	if rawSubject, err := s.client.FetchSubjectRaw(ctx, thread.Subject.URL); err == nil &&
+	if hydrated != nil {
+		subjectPayload = pqtype.NullRawMessage{
+			RawMessage: hydrated.Raw,
+			Valid:      len(hydrated.Raw) > 0,
+		}
+		fetched := s.clock().UTC()
+		subjectFetchedAt = models.SQLNullTime(&fetched)
+	} else if rawSubject, err := handler.FetchSubject(ctx, s.client, source); err == nil &&
 		len(rawSubject) > 0 {
 		subjectPayload = pqtype.NullRawMessage{
 			RawMessage: rawSubject,
@@ -296,10 +387,13 @@ func (s *Service) ProcessNotification(
 		}
 		fetched := s.clock().UTC()
 		subjectFetchedAt = models.SQLNullTime(&fetched)
+	} else if isRateLimited(err) {
+		return err
 	} else if err != nil {
 		// Log but don't fail - subject fetch is optional
 		//nolint:lll // Long warning message with multiple zap fields
 		s.logger.Warn("failed to fetch subject data (continuing without it)", zap.String("githubID", thread.ID), zap.String("subjectURL", thread.Subject.URL), zap.Error(err))
+		s.recordSubjectFetchFailure(ctx)
 	}
 
 	// Process pull request metadata if subject is a PullRequest
@@ -322,12 +416,40 @@ func (s *Service) ProcessNotification(
 	var subjectState sql.NullString
 	var subjectMerged sql.NullBool
 	var subjectStateReason sql.NullString
+	var subjectCreatedAt sql.NullTime
+	var subjectLabels []string
+	var subjectMilestone sql.NullString
+	var subjectAssignees []string
+	var subjectComments sql.NullInt32
+	var subjectCategory sql.NullString
+	var subjectAnswered sql.NullBool
+	var prStatus pullRequestStatus
+	fields := handler.Fields(source, subjectPayload.RawMessage)
 	if subjectPayload.Valid {
 		authorLogin, authorID = github.ExtractAuthorFromSubject(subjectPayload.RawMessage)
 		subjectNumber = github.ExtractSubjectNumber(subjectPayload.RawMessage)
 		subjectState = github.ExtractSubjectState(subjectPayload.RawMessage)
 		subjectMerged = github.ExtractSubjectMerged(subjectPayload.RawMessage)
 		subjectStateReason = github.ExtractSubjectStateReason(subjectPayload.RawMessage)
+		subjectCreatedAt = github.ExtractSubjectCreatedAt(subjectPayload.RawMessage)
+		subjectLabels = github.ExtractSubjectLabels(subjectPayload.RawMessage)
+		subjectMilestone = github.ExtractSubjectMilestone(subjectPayload.RawMessage)
+		subjectAssignees = github.ExtractSubjectAssignees(
+			subjectPayload.RawMessage,
+			s.client.ViewerLogin(),
+		)
+		subjectComments = github.ExtractSubjectComments(subjectPayload.RawMessage)
+		subjectCategory = github.ExtractSubjectCategory(subjectPayload.RawMessage)
+		subjectAnswered = github.ExtractSubjectAnswered(subjectPayload.RawMessage)
+		if strings.EqualFold(thread.Subject.Type, "PullRequest") && hydrated != nil {
+			prStatus = s.hydratedPullRequestStatus(*hydrated)
+		} else if strings.EqualFold(thread.Subject.Type, "PullRequest") {
+			prStatus = s.fetchPullRequestStatus(
+				ctx,
+				thread.Repository.FullName,
+				subjectPayload.RawMessage,
+			)
+		}
 	}
 
 	// Upsert notification
@@ -349,14 +471,30 @@ func (s *Service) ProcessNotification(
 			RawMessage: thread.Raw,
 			Valid:      len(thread.Raw) > 0,
 		},
-		SubjectRaw:         subjectPayload,
-		SubjectFetchedAt:   subjectFetchedAt,
-		AuthorLogin:        authorLogin,
-		AuthorID:           authorID,
-		SubjectNumber:      subjectNumber,
-		SubjectState:       subjectState,
-		SubjectMerged:      subjectMerged,
-		SubjectStateReason: subjectStateReason,
+		SubjectRaw:             subjectPayload,
+		SubjectFetchedAt:       subjectFetchedAt,
+		AuthorLogin:            authorLogin,
+		AuthorID:               authorID,
+		SubjectNumber:          subjectNumber,
+		SubjectState:           subjectState,
+		SubjectMerged:          subjectMerged,
+		SubjectStateReason:     subjectStateReason,
+		SubjectCreatedAt:       subjectCreatedAt,
+		SubjectDraft:           prStatus.Draft,
+		SubjectReviewDecision:  prStatus.ReviewDecision,
+		SubjectReviewRequested: prStatus.ReviewRequested,
+		SubjectChecksStatus:    prStatus.ChecksStatus,
+		SubjectLabels:          subjectLabels,
+		SubjectMilestone:       subjectMilestone,
+		SubjectAssignees:       subjectAssignees,
+		SubjectComments:        subjectComments,
+		SubjectCategory:        subjectCategory,
+		SubjectAnswered:        subjectAnswered,
+		SubjectTag:             fields.Tag,
+		SubjectConclusion:      fields.Conclusion,
+		SubjectSha:             fields.SHA,
+		SubjectSeverity:        fields.Severity,
+		AccountID:              s.AccountID(),
 	}
 
 	if _, err := s.notificationService.UpsertNotification(ctx, notificationParams); err != nil {
@@ -420,10 +558,204 @@ func (s *Service) upsertPullRequestFromSubject(
 	return &pr, nil
 }
 
+// reviewsPerPage is how many reviews are fetched for a pull request's review decision: one
+// page, oldest first, so later reviews of pull requests with more are missed
+const reviewsPerPage = 100
+
+// pullRequestStatus holds the pull request attributes queried by is:draft, review:,
+// review-requested: and checks:
+type pullRequestStatus struct {
+	Draft           sql.NullBool
+	ReviewDecision  sql.NullString
+	ReviewRequested []string
+	ChecksStatus    sql.NullString
+}
+
+// fetchPullRequestStatus reads the draft flag and review requests from PR subject JSON and,
+// for open PRs, fetches the review decision and CI status of the head commit.
+//
+// Those take three more requests per pull request, so they're made with low priority:
+// with little rate limit budget left, or while rate limited, they're skipped. Skipped and
+// failed fetches leave that attribute unset, for the next subject refresh to fill in.
+func (s *Service) fetchPullRequestStatus(
+	ctx context.Context,
+	repoFullName string,
+	subjectJSON json.RawMessage,
+) pullRequestStatus {
+	status := pullRequestStatus{
+		Draft:           github.ExtractSubjectDraft(subjectJSON),
+		ReviewRequested: github.ExtractRequestedReviewers(subjectJSON, s.client.ViewerLogin()),
+	}
+
+	// Reviews and checks only matter while the PR is open, so skip the extra API calls otherwise
+	state := github.ExtractSubjectState(subjectJSON)
+	if !state.Valid || !strings.EqualFold(state.String, "open") {
+		return status
+	}
+
+	owner, repo, ok := strings.Cut(repoFullName, "/")
+	number := github.ExtractSubjectNumber(subjectJSON)
+	if !ok || !number.Valid {
+		return status
+	}
+
+	ctx = github.WithLowPriority(ctx)
+	reviews, err := s.client.FetchPullRequestReviews(
+		ctx,
+		owner,
+		repo,
+		int(number.Int32),
+		reviewsPerPage,
+		1,
+	)
+	if err != nil {
+		s.logPullRequestStatusError("reviews", repoFullName, number.Int32, err)
+		// Checks would be rate limited too
+		if isRateLimited(err) {
+			return status
+		}
+	} else {
+		status.ReviewDecision = models.SQLNullString(github.ReviewDecision(reviews))
+	}
+
+	sha := github.ExtractHeadSHA(subjectJSON)
+	if sha == "" {
+		return status
+	}
+	runs, err := s.client.FetchCheckRuns(ctx, owner, repo, sha)
+	if err != nil {
+		s.logPullRequestStatusError("check runs", repoFullName, number.Int32, err)
+		return status
+	}
+	combined, err := s.client.FetchCombinedStatus(ctx, owner, repo, sha)
+	if err != nil {
+		s.logPullRequestStatusError("commit status", repoFullName, number.Int32, err)
+		return status
+	}
+	status.ChecksStatus = models.SQLNullString(github.ChecksStatus(runs, combined))
+
+	return status
+}
+
+// logPullRequestStatusError logs a failed or skipped fetch of a pull request's status
+func (s *Service) logPullRequestStatusError(what, repoFullName string, number int32, err error) {
+	fields := []zap.Field{
+		zap.String("repo", repoFullName),
+		zap.Int32("number", number),
+		zap.Error(err),
+	}
+	if isRateLimited(err) {
+		s.logger.Debug("skipped fetching pull request "+what+" to save rate limit", fields...)
+		return
+	}
+	s.logger.Warn("failed to fetch pull request "+what+", continuing without", fields...)
+}
+
+// hydratedPullRequestStatus reads the same attributes as fetchPullRequestStatus from a pull
+// request fetched by HydrateSubjects, whose reviews and checks came with it
+func (s *Service) hydratedPullRequestStatus(subject types.HydratedSubject) pullRequestStatus {
+	status := pullRequestStatus{
+		Draft:           github.ExtractSubjectDraft(subject.Raw),
+		ReviewRequested: github.ExtractRequestedReviewers(subject.Raw, s.client.ViewerLogin()),
+	}
+
+	state := github.ExtractSubjectState(subject.Raw)
+	if !state.Valid || !strings.EqualFold(state.String, "open") {
+		return status
+	}
+	status.ReviewDecision = models.SQLNullString(github.ReviewDecision(subject.Reviews))
+	if github.ExtractHeadSHA(subject.Raw) != "" {
+		status.ChecksStatus = models.SQLNullString(
+			github.ChecksStatus(subject.CheckRuns, subject.CombinedStatus),
+		)
+	}
+	return status
+}
+
+// HydrateSubjects fetches the subjects of issue, pull request and discussion threads in
+// batches, keyed by thread ID. Threads of other types, and subjects GitHub didn't return,
+// are left out.
+func (s *Service) HydrateSubjects(
+	ctx context.Context,
+	threads []types.NotificationThread,
+) (map[string]types.HydratedSubject, error) {
+	refs := make(map[string]types.SubjectRef)
+	var batch []types.SubjectRef
+	for _, thread := range threads {
+		ref, ok := s.subjectRef(thread)
+		if !ok {
+			continue
+		}
+		refs[thread.ID] = ref
+		batch = append(batch, ref)
+	}
+	if len(batch) == 0 {
+		return map[string]types.HydratedSubject{}, nil
+	}
+
+	subjects, err := s.client.HydrateSubjects(ctx, batch)
+	if err != nil {
+		return nil, errors.Join(ErrFailedToFetchSubject, err)
+	}
+
+	hydrated := make(map[string]types.HydratedSubject, len(subjects))
+	for threadID, ref := range refs {
+		if subject, ok := subjects[ref]; ok {
+			hydrated[threadID] = subject
+		}
+	}
+	return hydrated, nil
+}
+
+// subjectThread returns the thread of a stored notification, with what HydrateSubjects
+// needs to fetch its subject
+func subjectThread(notification db.Notification) types.NotificationThread {
+	return types.NotificationThread{
+		ID: notification.GithubID,
+		Subject: types.NotificationSubject{
+			Type: notification.SubjectType,
+			URL:  notification.SubjectUrl.String,
+		},
+	}
+}
+
+// subjectRef identifies the subject of a thread for HydrateSubjects, reporting false for
+// subjects that can't be fetched in a batch
+func (s *Service) subjectRef(thread types.NotificationThread) (types.SubjectRef, bool) {
+	var subjectType string
+	switch {
+	case strings.EqualFold(thread.Subject.Type, types.SubjectTypeIssue):
+		subjectType = types.SubjectTypeIssue
+	case strings.EqualFold(thread.Subject.Type, types.SubjectTypePullRequest):
+		subjectType = types.SubjectTypePullRequest
+	case strings.EqualFold(thread.Subject.Type, types.SubjectTypeDiscussion):
+		subjectType = types.SubjectTypeDiscussion
+	default:
+		return types.SubjectRef{}, false
+	}
+
+	info, err := github.ExtractSubjectInfo(s.client.Endpoints(), thread.Subject.URL, nil)
+	if err != nil {
+		return types.SubjectRef{}, false
+	}
+	return types.SubjectRef{
+		Owner:  info.Owner,
+		Repo:   info.Repo,
+		Number: info.Number,
+		Type:   subjectType,
+	}, true
+}
+
+// isRateLimited reports whether err is a GitHub rate limit error
+func isRateLimited(err error) bool {
+	var rateLimited *github.RateLimitedError
+	return errors.As(err, &rateLimited)
+}
+
 // RefreshSubjectData fetches fresh subject data from GitHub and updates the notification
 func (s *Service) RefreshSubjectData(ctx context.Context, githubID string) error {
 	// Get the notification
-	notification, err := s.notificationService.GetByGithubID(ctx, githubID)
+	notification, err := s.notificationService.GetByGithubID(ctx, s.AccountID(), githubID)
 	if err != nil {
 		s.logger.Error(
 			"failed to get notification",
@@ -433,10 +765,21 @@ func (s *Service) RefreshSubjectData(ctx context.Context, githubID string) error
 		return err
 	}
 
-	// Skip refresh for CI activity and Discussions (no API endpoint available)
-	normalizedType := strings.ToLower(strings.ReplaceAll(notification.SubjectType, "_", ""))
-	if normalizedType == "checkrun" || normalizedType == "checksuite" ||
-		normalizedType == "discussion" {
+	return s.refreshSubject(ctx, notification, nil)
+}
+
+// refreshSubject updates a notification with fresh subject data, fetching the subject
+// unless hydrated is set
+func (s *Service) refreshSubject(
+	ctx context.Context,
+	notification db.Notification,
+	hydrated *types.HydratedSubject,
+) error {
+	githubID := notification.GithubID
+
+	// Skip refresh for check runs (no API endpoint available)
+	normalizedType := normalizeSubjectType(notification.SubjectType)
+	if normalizedType == "checkrun" {
 		s.logger.Debug(
 			"skipping subject refresh for unsupported type",
 			zap.String("githubID", githubID),
@@ -445,13 +788,43 @@ func (s *Service) RefreshSubjectData(ctx context.Context, githubID string) error
 		return ErrNotificationMissingSubjectURL // Return same error as missing URL for consistency
 	}
 
-	if !notification.SubjectUrl.Valid || notification.SubjectUrl.String == "" {
-		s.logger.Warn("notification has no subject URL", zap.String("githubID", githubID))
-		return ErrNotificationMissingSubjectURL
+	// Discussions have no REST endpoint, so they're fetched through GraphQL
+	if normalizedType == "discussion" && hydrated == nil {
+		subjects, err := s.HydrateSubjects(ctx, []types.NotificationThread{subjectThread(notification)})
+		if err != nil {
+			return err
+		}
+		subject, ok := subjects[githubID]
+		if !ok {
+			s.logger.Warn("discussion has no subject to refresh", zap.String("githubID", githubID))
+			return ErrNotificationMissingSubjectURL
+		}
+		hydrated = &subject
+	}
+
+	handler := subjectHandlerFor(notification.SubjectType)
+	source := subjectSource{
+		Type:  notification.SubjectType,
+		URL:   notification.SubjectUrl.String,
+		Title: notification.SubjectTitle,
+	}
+	if source.URL == "" {
+		// Some subjects without a URL, like check suites, are looked up in their repository
+		repo, repoErr := s.repositoryService.GetRepositoryByID(ctx, notification.RepositoryID)
+		if repoErr != nil {
+			return errors.Join(ErrFailedToGetRepository, repoErr)
+		}
+		source.RepoFullName = repo.FullName
 	}
 
 	// Fetch fresh subject data
-	subjectRaw, err := s.client.FetchSubjectRaw(ctx, notification.SubjectUrl.String)
+	var subjectRaw json.RawMessage
+	var err error
+	if hydrated != nil {
+		subjectRaw = hydrated.Raw
+	} else {
+		subjectRaw, err = handler.FetchSubject(ctx, s.client, source)
+	}
 	if err != nil {
 		s.logger.Error(
 			"failed to fetch subject from GitHub",
@@ -461,6 +834,10 @@ func (s *Service) RefreshSubjectData(ctx context.Context, githubID string) error
 		)
 		return errors.Join(ErrFailedToFetchSubject, err)
 	}
+	if len(subjectRaw) == 0 {
+		s.logger.Warn("notification has no subject to refresh", zap.String("githubID", githubID))
+		return ErrNotificationMissingSubjectURL
+	}
 
 	// Update the notification with fresh subject data
 	subjectPayload := pqtype.NullRawMessage{
@@ -472,6 +849,7 @@ func (s *Service) RefreshSubjectData(ctx context.Context, githubID string) error
 
 	// If it's a PR, update the pull_request table too
 	var pullRequestID sql.NullInt64
+	var prStatus pullRequestStatus
 	if strings.EqualFold(notification.SubjectType, "PullRequest") && subjectPayload.Valid {
 		repo, repoErr := s.repositoryService.GetRepositoryByID(ctx, notification.RepositoryID)
 		if repoErr != nil {
@@ -495,6 +873,12 @@ func (s *Service) RefreshSubjectData(ctx context.Context, githubID string) error
 				zap.Error(prErr),
 			)
 		}
+
+		if hydrated != nil {
+			prStatus = s.hydratedPullRequestStatus(*hydrated)
+		} else {
+			prStatus = s.fetchPullRequestStatus(ctx, repo.FullName, subjectPayload.RawMessage)
+		}
 	}
 
 	// Extract subject metadata from fresh subject data
@@ -502,23 +886,57 @@ func (s *Service) RefreshSubjectData(ctx context.Context, githubID string) error
 	var subjectState sql.NullString
 	var subjectMerged sql.NullBool
 	var subjectStateReason sql.NullString
+	var subjectCreatedAt sql.NullTime
+	var subjectLabels []string
+	var subjectMilestone sql.NullString
+	var subjectAssignees []string
+	var subjectComments sql.NullInt32
+	var subjectCategory sql.NullString
+	var subjectAnswered sql.NullBool
+	fields := handler.Fields(source, subjectPayload.RawMessage)
 	if subjectPayload.Valid {
 		subjectNumber = github.ExtractSubjectNumber(subjectPayload.RawMessage)
 		subjectState = github.ExtractSubjectState(subjectPayload.RawMessage)
 		subjectMerged = github.ExtractSubjectMerged(subjectPayload.RawMessage)
 		subjectStateReason = github.ExtractSubjectStateReason(subjectPayload.RawMessage)
+		subjectCreatedAt = github.ExtractSubjectCreatedAt(subjectPayload.RawMessage)
+		subjectLabels = github.ExtractSubjectLabels(subjectPayload.RawMessage)
+		subjectMilestone = github.ExtractSubjectMilestone(subjectPayload.RawMessage)
+		subjectAssignees = github.ExtractSubjectAssignees(
+			subjectPayload.RawMessage,
+			s.client.ViewerLogin(),
+		)
+		subjectComments = github.ExtractSubjectComments(subjectPayload.RawMessage)
+		subjectCategory = github.ExtractSubjectCategory(subjectPayload.RawMessage)
+		subjectAnswered = github.ExtractSubjectAnswered(subjectPayload.RawMessage)
 	}
 
 	// Update the notification with the fresh subject data
 	err = s.notificationService.UpdateNotificationSubject(ctx, db.UpdateNotificationSubjectParams{
-		GithubID:           githubID,
-		SubjectRaw:         subjectPayload,
-		SubjectFetchedAt:   subjectFetchedAt,
-		PullRequestID:      pullRequestID,
-		SubjectNumber:      subjectNumber,
-		SubjectState:       subjectState,
-		SubjectMerged:      subjectMerged,
-		SubjectStateReason: subjectStateReason,
+		AccountID:              s.AccountID(),
+		GithubID:               githubID,
+		SubjectRaw:             subjectPayload,
+		SubjectFetchedAt:       subjectFetchedAt,
+		PullRequestID:          pullRequestID,
+		SubjectNumber:          subjectNumber,
+		SubjectState:           subjectState,
+		SubjectMerged:          subjectMerged,
+		SubjectStateReason:     subjectStateReason,
+		SubjectCreatedAt:       subjectCreatedAt,
+		SubjectDraft:           prStatus.Draft,
+		SubjectReviewDecision:  prStatus.ReviewDecision,
+		SubjectReviewRequested: prStatus.ReviewRequested,
+		SubjectChecksStatus:    prStatus.ChecksStatus,
+		SubjectLabels:          subjectLabels,
+		SubjectMilestone:       subjectMilestone,
+		SubjectAssignees:       subjectAssignees,
+		SubjectComments:        subjectComments,
+		SubjectCategory:        subjectCategory,
+		SubjectAnswered:        subjectAnswered,
+		SubjectTag:             fields.Tag,
+		SubjectConclusion:      fields.Conclusion,
+		SubjectSha:             fields.SHA,
+		SubjectSeverity:        fields.Severity,
 	})
 	if err != nil {
 		s.logger.Error(
@@ -0,0 +1,118 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// ErrFailedToRecordSyncRun is returned when a sync run can't be saved
+var ErrFailedToRecordSyncRun = errors.New("failed to record sync run")
+
+type syncRunKey struct{}
+
+// WithSyncRun returns a context whose subject fetch failures are counted against a sync run
+func WithSyncRun(ctx context.Context, runID int64) context.Context {
+	if runID == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, syncRunKey{}, runID)
+}
+
+// syncRunFromContext returns the sync run set by WithSyncRun, or 0
+func syncRunFromContext(ctx context.Context) int64 {
+	runID, _ := ctx.Value(syncRunKey{}).(int64)
+	return runID
+}
+
+// StartSyncRun records the start of a sync or backfill run fetching the notifications
+// updated since since and before before (either may be nil), and returns the run's ID.
+func (s *Service) StartSyncRun(
+	ctx context.Context,
+	kind models.SyncRunKind,
+	since, before *time.Time,
+) (int64, error) {
+	runID, err := s.syncStateService.StartSyncRun(ctx, kind, since, before)
+	if err != nil {
+		s.logger.Error("failed to start sync run", zap.Error(err))
+		return 0, errors.Join(ErrFailedToRecordSyncRun, err)
+	}
+	return runID, nil
+}
+
+// FinishSyncRun records what a sync run did. runErr is the error that ended the run, if
+// any; a *github.RateLimitedError marks the run as rate limited. The GitHub client's
+// latest rate limit budget is saved with the run. A runID of 0 (a run that couldn't be
+// started) is ignored.
+func (s *Service) FinishSyncRun(
+	ctx context.Context,
+	runID int64,
+	result models.SyncRunResult,
+	runErr error,
+) error {
+	if runID == 0 {
+		return nil
+	}
+
+	if runErr != nil {
+		result.Error = sql.NullString{String: runErr.Error(), Valid: true}
+		var rateLimited *github.RateLimitedError
+		result.RateLimited = errors.As(runErr, &rateLimited)
+	}
+	if limit := s.client.RateLimit(); limit.Limit != 0 {
+		result.RateLimitRemaining = sql.NullInt32{Int32: int32(limit.Remaining), Valid: true}
+		result.RateLimitResetAt = models.SQLNullTime(&limit.ResetAt)
+	}
+
+	if err := s.syncStateService.FinishSyncRun(ctx, runID, result); err != nil {
+		s.logger.Error("failed to finish sync run", zap.Int64("runID", runID), zap.Error(err))
+		return errors.Join(ErrFailedToRecordSyncRun, err)
+	}
+	return nil
+}
+
+// recordSubjectFetchFailure counts a failed subject fetch against the context's sync run.
+// Failing to count it is only logged, like the failed fetch itself.
+func (s *Service) recordSubjectFetchFailure(ctx context.Context) {
+	runID := syncRunFromContext(ctx)
+	if runID == 0 {
+		return
+	}
+	if err := s.syncStateService.AddSyncRunSubjectFetchFailure(ctx, runID); err != nil {
+		s.logger.Warn("failed to count subject fetch failure",
+			zap.Int64("runID", runID),
+			zap.Error(err))
+	}
+}
+
+// PruneSyncRuns deletes the sync runs of every account started before startedBefore, and
+// returns how many were deleted.
+func (s *Service) PruneSyncRuns(ctx context.Context, startedBefore time.Time) (int64, error) {
+	deleted, err := s.syncStateService.PruneSyncRuns(ctx, startedBefore)
+	if err != nil {
+		s.logger.Error("failed to prune sync runs", zap.Error(err))
+		return 0, errors.Join(ErrFailedToRecordSyncRun, err)
+	}
+	return deleted, nil
+}
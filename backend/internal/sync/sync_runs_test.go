@@ -0,0 +1,188 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ajbeattie/octobud/backend/internal/github"
+	githubmocks "github.com/ajbeattie/octobud/backend/internal/github/mocks"
+	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
+)
+
+// TestStartSyncRun tests that a run is recorded for the service's account
+func TestStartSyncRun(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`INSERT INTO sync_runs`).
+		WithArgs(
+			models.DefaultAccountID,
+			"sync",
+			sql.NullTime{Time: since, Valid: true},
+			sql.NullTime{},
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(7)))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := setupSyncService(t, dbConn, githubmocks.NewMockClient(ctrl))
+
+	runID, err := service.StartSyncRun(context.Background(), models.SyncRunKindSync, &since, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), runID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestFinishSyncRun tests that the result is saved with the client's rate limit budget
+func TestFinishSyncRun(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	resetAt := time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`UPDATE sync_runs`).
+		WithArgs(
+			false,
+			int32(12),
+			int32(10),
+			sql.NullString{},
+			false,
+			sql.NullInt32{Int32: 42, Valid: true},
+			sql.NullTime{Time: resetAt, Valid: true},
+			int64(7),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		RateLimit().
+		Return(types.RateLimit{Limit: 5000, Remaining: 42, ResetAt: resetAt})
+	service := setupSyncService(t, dbConn, mockClient)
+
+	result := models.SyncRunResult{ThreadsFetched: 12, JobsQueued: 10}
+	require.NoError(t, service.FinishSyncRun(context.Background(), 7, result, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestFinishSyncRun_RateLimited tests that a run ended by GitHub's rate limit is marked as
+// rate limited, with the error saved
+func TestFinishSyncRun_RateLimited(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	runErr := errors.Join(
+		ErrFailedToFetchNotifications,
+		&github.RateLimitedError{ResetAt: time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)},
+	)
+	mock.ExpectExec(`UPDATE sync_runs`).
+		WithArgs(
+			false,
+			int32(0),
+			int32(0),
+			sql.NullString{String: runErr.Error(), Valid: true},
+			true,
+			sql.NullInt32{},
+			sql.NullTime{},
+			int64(7),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().RateLimit().Return(types.RateLimit{})
+	service := setupSyncService(t, dbConn, mockClient)
+
+	err = service.FinishSyncRun(context.Background(), 7, models.SyncRunResult{}, runErr)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestFinishSyncRun_NotStarted tests that a run that couldn't be started isn't saved
+func TestFinishSyncRun_NotStarted(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := setupSyncService(t, dbConn, githubmocks.NewMockClient(ctrl))
+
+	require.NoError(t, service.FinishSyncRun(context.Background(), 0, models.SyncRunResult{}, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRecordSubjectFetchFailure tests that failures are only counted against the
+// context's sync run
+func TestRecordSubjectFetchFailure(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	mock.ExpectExec(`UPDATE sync_runs`).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := setupSyncService(t, dbConn, githubmocks.NewMockClient(ctrl))
+
+	service.recordSubjectFetchFailure(context.Background())
+	service.recordSubjectFetchFailure(WithSyncRun(context.Background(), 7))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestPruneSyncRuns tests that runs started before the cutoff are deleted
+func TestPruneSyncRuns(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	cutoff := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`DELETE FROM sync_runs`).
+		WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := setupSyncService(t, dbConn, githubmocks.NewMockClient(ctrl))
+
+	deleted, err := service.PruneSyncRuns(context.Background(), cutoff)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), deleted)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
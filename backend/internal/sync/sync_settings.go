@@ -17,6 +17,8 @@ package sync
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/ajbeattie/octobud/backend/internal/github/types"
@@ -32,6 +34,34 @@ func (s *Service) getUserSyncSettings(ctx context.Context) (*models.SyncSettings
 	return models.SyncSettingsFromJSON(user.SyncSettings.RawMessage)
 }
 
+// getSyncSettings retrieves the user's sync settings, with the initial sync limits
+// replaced by the account's when the service syncs an account (see WithAccount) that has
+// its own. Setup and write-back are the user's choice, so they always come from the user.
+func (s *Service) getSyncSettings(ctx context.Context) (*models.SyncSettings, error) {
+	settings, err := s.getUserSyncSettings(ctx)
+	if err != nil || settings == nil || s.accountID == 0 {
+		return settings, err
+	}
+
+	account, err := s.userStore.GetGitHubAccount(ctx, s.accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	accountSettings, err := models.SyncSettingsFromJSON(account.SyncSettings.RawMessage)
+	if err != nil || accountSettings == nil {
+		return settings, err
+	}
+
+	merged := *settings
+	merged.InitialSyncDays = accountSettings.InitialSyncDays
+	merged.InitialSyncMaxCount = accountSettings.InitialSyncMaxCount
+	merged.InitialSyncUnreadOnly = accountSettings.InitialSyncUnreadOnly
+	return &merged, nil
+}
+
 // calculateSyncSinceDate calculates the timestamp for N days ago
 func calculateSyncSinceDate(days int) time.Time {
 	return time.Now().UTC().AddDate(0, 0, -days)
@@ -32,9 +32,11 @@ import (
 	"github.com/ajbeattie/octobud/backend/internal/core/repository"
 	"github.com/ajbeattie/octobud/backend/internal/core/syncstate"
 	"github.com/ajbeattie/octobud/backend/internal/db"
+	"github.com/ajbeattie/octobud/backend/internal/github"
 	githubinterfaces "github.com/ajbeattie/octobud/backend/internal/github/interfaces"
 	githubmocks "github.com/ajbeattie/octobud/backend/internal/github/mocks"
 	"github.com/ajbeattie/octobud/backend/internal/github/types"
+	"github.com/ajbeattie/octobud/backend/internal/models"
 )
 
 // mockClock returns a fixed time for testing
@@ -92,8 +94,8 @@ func TestFetchNotificationsToSync_InitialSync(t *testing.T) {
 
 	mockClient := githubmocks.NewMockClient(ctrl)
 	mockClient.EXPECT().
-		FetchNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(notifications, nil)
+		PollNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(types.NotificationPoll{Threads: notifications}, nil)
 
 	// FetchNotificationsToSync is a pure function - no DB calls expected
 	service := setupSyncService(t, dbConn, mockClient)
@@ -105,11 +107,11 @@ func TestFetchNotificationsToSync_InitialSync(t *testing.T) {
 		SinceTimestamp:   nil, // All time
 	}
 
-	threads, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+	poll, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
 
 	require.NoError(t, err)
-	require.Len(t, threads, 1)
-	require.Equal(t, "notif-1", threads[0].ID)
+	require.Len(t, poll.Threads, 1)
+	require.Equal(t, "notif-1", poll.Threads[0].ID)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -138,10 +140,10 @@ func TestFetchNotificationsToSync_WithExistingState(t *testing.T) {
 	var capturedSince *time.Time
 	mockClient := githubmocks.NewMockClient(ctrl)
 	mockClient.EXPECT().
-		FetchNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(_ context.Context, since *time.Time, _ *time.Time, _ bool) ([]types.NotificationThread, error) {
+		PollNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, since *time.Time, _ bool, _ types.PollValidators) (types.NotificationPoll, error) {
 			capturedSince = since
-			return notifications, nil
+			return types.NotificationPoll{Threads: notifications}, nil
 		})
 
 	// FetchNotificationsToSync is a pure function - no DB calls expected
@@ -154,11 +156,11 @@ func TestFetchNotificationsToSync_WithExistingState(t *testing.T) {
 		SinceTimestamp:   &latestNotification,
 	}
 
-	threads, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+	poll, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
 
 	require.NoError(t, err)
-	require.Len(t, threads, 1)
-	require.Equal(t, "notif-2", threads[0].ID)
+	require.Len(t, poll.Threads, 1)
+	require.Equal(t, "notif-2", poll.Threads[0].ID)
 	require.NotNil(t, capturedSince)
 	require.Equal(t, latestNotification, *capturedSince)
 	require.NoError(t, mock.ExpectationsWereMet())
@@ -176,8 +178,8 @@ func TestFetchNotificationsToSync_EmptyResults(t *testing.T) {
 
 	mockClient := githubmocks.NewMockClient(ctrl)
 	mockClient.EXPECT().
-		FetchNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		Return([]types.NotificationThread{}, nil)
+		PollNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(types.NotificationPoll{}, nil)
 
 	// FetchNotificationsToSync is a pure function - no DB calls expected
 	service := setupSyncService(t, dbConn, mockClient)
@@ -189,10 +191,10 @@ func TestFetchNotificationsToSync_EmptyResults(t *testing.T) {
 		IsInitialSync:    false,
 	}
 
-	threads, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+	poll, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
 
 	require.NoError(t, err)
-	require.Len(t, threads, 0)
+	require.Len(t, poll.Threads, 0)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -207,8 +209,8 @@ func TestFetchNotificationsToSync_ClientError(t *testing.T) {
 
 	mockClient := githubmocks.NewMockClient(ctrl)
 	mockClient.EXPECT().
-		FetchNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(nil, errors.New("API error"))
+		PollNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(types.NotificationPoll{}, errors.New("API error"))
 
 	// FetchNotificationsToSync is a pure function - no DB calls expected
 	service := setupSyncService(t, dbConn, mockClient)
@@ -218,11 +220,130 @@ func TestFetchNotificationsToSync_ClientError(t *testing.T) {
 		IsInitialSync:    true,
 	}
 
-	threads, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+	poll, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
 
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "fetch notifications")
-	require.Nil(t, threads)
+	require.Nil(t, poll.Threads)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestFetchNotificationsToSync_NotModified tests that the previous poll's validators are sent
+// and a 304 is passed on as not modified
+func TestFetchNotificationsToSync_NotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	validators := types.PollValidators{ETag: `W/"abc"`}
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		PollNotifications(gomock.Any(), gomock.Any(), false, validators).
+		Return(types.NotificationPoll{
+			NotModified:  true,
+			Validators:   validators,
+			PollInterval: time.Minute,
+		}, nil)
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	syncCtx := SyncContext{
+		IsSyncConfigured: true,
+		IsInitialSync:    false,
+		PollValidators:   validators,
+	}
+
+	poll, err := service.FetchNotificationsToSync(context.Background(), syncCtx)
+
+	require.NoError(t, err)
+	require.True(t, poll.NotModified)
+	require.Empty(t, poll.Threads)
+	require.Equal(t, time.Minute, poll.PollInterval)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestUpdatePollValidators tests saving the validators of a poll
+func TestUpdatePollValidators(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	mock.ExpectQuery(`INSERT INTO sync_state`).
+		WithArgs(
+			models.DefaultAccountID,
+			sql.NullTime{}, sql.NullTime{},
+			sql.NullString{Valid: true, String: `W/"abc"`}, sql.NullString{},
+			sql.NullTime{}, sql.NullTime{},
+		).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "last_successful_poll", "latest_notification_at",
+			"last_notification_etag", "last_notification_modified",
+			"created_at", "updated_at",
+			"initial_sync_completed_at", "oldest_notification_synced_at",
+		}).AddRow(1, time.Now(), sql.NullTime{}, `W/"abc"`, sql.NullString{}, time.Now(),
+			time.Now(), sql.NullTime{}, sql.NullTime{}))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	service := setupSyncService(t, dbConn, mockClient)
+
+	err = service.UpdatePollValidators(
+		context.Background(),
+		types.PollValidators{ETag: `W/"abc"`},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSaveRateLimit tests saving the client's rate limit budget
+func TestSaveRateLimit(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	resetAt := time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`INSERT INTO sync_state`).
+		WithArgs(
+			models.DefaultAccountID,
+			sql.NullInt32{Int32: 5000, Valid: true},
+			sql.NullInt32{Int32: 42, Valid: true},
+			sql.NullTime{Time: resetAt, Valid: true},
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		RateLimit().
+		Return(types.RateLimit{Limit: 5000, Remaining: 42, ResetAt: resetAt})
+	service := setupSyncService(t, dbConn, mockClient)
+
+	require.NoError(t, service.SaveRateLimit(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSaveRateLimit_Unknown tests that nothing is saved before the client has seen a response
+func TestSaveRateLimit_Unknown(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().RateLimit().Return(types.RateLimit{})
+	service := setupSyncService(t, dbConn, mockClient)
+
+	require.NoError(t, service.SaveRateLimit(context.Background()))
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -234,15 +355,20 @@ func TestUpdateSyncStateAfterProcessing_Success(t *testing.T) {
 
 	latestUpdate := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
 
-	// UpsertSyncStateWithInitialSync expects 5 args now
+	// UpsertSyncStateWithInitialSync expects the account and 6 args
 	mock.ExpectQuery(`INSERT INTO sync_state`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(
+			models.DefaultAccountID,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+		).
 		WillReturnRows(sqlmock.NewRows([]string{
 			"id", "last_successful_poll", "latest_notification_at",
-			"last_notification_etag", "created_at", "updated_at",
+			"last_notification_etag", "last_notification_modified",
+			"created_at", "updated_at",
 			"initial_sync_completed_at", "oldest_notification_synced_at",
-		}).AddRow(1, time.Now(), latestUpdate, sql.NullString{}, time.Now(), time.Now(),
-			sql.NullTime{}, sql.NullTime{}))
+		}).AddRow(1, time.Now(), latestUpdate, sql.NullString{}, sql.NullString{}, time.Now(),
+			time.Now(), sql.NullTime{}, sql.NullTime{}))
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -262,15 +388,20 @@ func TestUpdateSyncStateAfterProcessing_ZeroTime(t *testing.T) {
 	require.NoError(t, err)
 	defer dbConn.Close()
 
-	// UpsertSyncStateWithInitialSync expects 5 args now
+	// UpsertSyncStateWithInitialSync expects the account and 6 args
 	mock.ExpectQuery(`INSERT INTO sync_state`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(
+			models.DefaultAccountID,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+		).
 		WillReturnRows(sqlmock.NewRows([]string{
 			"id", "last_successful_poll", "latest_notification_at",
-			"last_notification_etag", "created_at", "updated_at",
+			"last_notification_etag", "last_notification_modified",
+			"created_at", "updated_at",
 			"initial_sync_completed_at", "oldest_notification_synced_at",
-		}).AddRow(1, time.Now(), sql.NullTime{}, sql.NullString{}, time.Now(), time.Now(),
-			sql.NullTime{}, sql.NullTime{}))
+		}).AddRow(1, time.Now(), sql.NullTime{}, sql.NullString{}, sql.NullString{}, time.Now(),
+			time.Now(), sql.NullTime{}, sql.NullTime{}))
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -357,8 +488,8 @@ func TestRefreshSubjectData_NotificationNotFound(t *testing.T) {
 
 	mockClient := githubmocks.NewMockClient(ctrl)
 
-	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE github_id`).
-		WithArgs("nonexistent").
+	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE account_id = \$1\s+AND github_id`).
+		WithArgs(models.DefaultAccountID, "nonexistent").
 		WillReturnError(sql.ErrNoRows)
 
 	service := setupSyncService(t, dbConn, mockClient)
@@ -370,6 +501,332 @@ func TestRefreshSubjectData_NotificationNotFound(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+// discussionNotification returns a stored discussion notification
+func discussionNotification(githubID string) db.Notification {
+	return db.Notification{
+		GithubID:     githubID,
+		AccountID:    models.DefaultAccountID,
+		SubjectType:  "Discussion",
+		SubjectTitle: "A discussion",
+		SubjectUrl: sql.NullString{
+			String: "https://api.github.com/repos/owner/repo/discussions/9",
+			Valid:  true,
+		},
+	}
+}
+
+// TestRefreshSubjectData_Discussion tests that a discussion is fetched through GraphQL
+func TestRefreshSubjectData_Discussion(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	discussion := types.SubjectRef{Owner: "owner", Repo: "repo", Number: 9, Type: "Discussion"}
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().Endpoints().Return(github.NewEndpoints("", "")).AnyTimes()
+	mockClient.EXPECT().ViewerLogin().Return("").AnyTimes()
+	mockClient.EXPECT().
+		HydrateSubjects(gomock.Any(), []types.SubjectRef{discussion}).
+		Return(map[types.SubjectRef]types.HydratedSubject{
+			discussion: {Raw: []byte(`{"number": 9, "state": "closed", "answered": true}`)},
+		}, nil)
+
+	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE account_id = \$1\s+AND github_id`).
+		WithArgs(models.DefaultAccountID, "discussion").
+		WillReturnRows(notificationRows(t, discussionNotification("discussion")))
+	mock.ExpectExec(`UPDATE notifications\s+SET subject_raw`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	require.NoError(t, service.RefreshSubjectData(context.Background(), "discussion"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRefreshSubjectData_DiscussionNotFound tests that a discussion GitHub doesn't return
+// isn't refreshed
+func TestRefreshSubjectData_DiscussionNotFound(t *testing.T) {
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().Endpoints().Return(github.NewEndpoints("", "")).AnyTimes()
+	mockClient.EXPECT().
+		HydrateSubjects(gomock.Any(), gomock.Any()).
+		Return(map[types.SubjectRef]types.HydratedSubject{}, nil)
+
+	mock.ExpectQuery(`SELECT (.+) FROM notifications WHERE account_id = \$1\s+AND github_id`).
+		WithArgs(models.DefaultAccountID, "discussion").
+		WillReturnRows(notificationRows(t, discussionNotification("discussion")))
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	err = service.RefreshSubjectData(context.Background(), "discussion")
+	require.ErrorIs(t, err, ErrNotificationMissingSubjectURL)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestFetchPullRequestStatus_OpenPullRequest tests review and check status for an open PR
+func TestFetchPullRequestStatus_OpenPullRequest(t *testing.T) {
+	dbConn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().ViewerLogin().Return("octocat")
+
+	reviews := []types.PullRequestReview{{State: "CHANGES_REQUESTED"}}
+	reviews[0].User.Login = "hubot"
+	mockClient.EXPECT().
+		FetchPullRequestReviews(gomock.Any(), "owner", "repo", 42, reviewsPerPage, 1).
+		Return(reviews, nil)
+	mockClient.EXPECT().
+		FetchCheckRuns(gomock.Any(), "owner", "repo", "abc123").
+		Return([]types.CheckRun{{Status: "in_progress"}}, nil)
+	mockClient.EXPECT().
+		FetchCombinedStatus(gomock.Any(), "owner", "repo", "abc123").
+		Return(types.CombinedStatus{}, nil)
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	status := service.fetchPullRequestStatus(
+		context.Background(),
+		"owner/repo",
+		[]byte(`{
+			"number": 42,
+			"state": "open",
+			"draft": true,
+			"head": {"sha": "abc123"},
+			"requested_reviewers": [{"login": "OctoCat"}],
+			"requested_teams": [{"slug": "core"}]
+		}`),
+	)
+
+	require.Equal(t, sql.NullBool{Bool: true, Valid: true}, status.Draft)
+	require.Equal(t, []string{"octocat", "@me", "core"}, status.ReviewRequested)
+	require.Equal(t, "changes_requested", status.ReviewDecision.String)
+	require.Equal(t, "pending", status.ChecksStatus.String)
+}
+
+// TestFetchPullRequestStatus_ClosedPullRequest tests that closed PRs skip the extra API calls
+func TestFetchPullRequestStatus_ClosedPullRequest(t *testing.T) {
+	dbConn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().ViewerLogin().Return("octocat")
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	status := service.fetchPullRequestStatus(
+		context.Background(),
+		"owner/repo",
+		[]byte(`{"number": 42, "state": "closed", "draft": false, "requested_reviewers": []}`),
+	)
+
+	require.Equal(t, sql.NullBool{Bool: false, Valid: true}, status.Draft)
+	require.Empty(t, status.ReviewRequested)
+	require.False(t, status.ReviewDecision.Valid)
+	require.False(t, status.ChecksStatus.Valid)
+}
+
+// TestFetchPullRequestStatus_ReviewsError tests that API failures leave the status unset
+func TestFetchPullRequestStatus_ReviewsError(t *testing.T) {
+	dbConn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().ViewerLogin().Return("")
+	mockClient.EXPECT().
+		FetchPullRequestReviews(gomock.Any(), "owner", "repo", 7, reviewsPerPage, 1).
+		Return(nil, errors.New("api error"))
+	mockClient.EXPECT().
+		FetchCheckRuns(gomock.Any(), "owner", "repo", "def456").
+		Return(nil, errors.New("api error"))
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	status := service.fetchPullRequestStatus(
+		context.Background(),
+		"owner/repo",
+		[]byte(`{"number": 7, "state": "open", "head": {"sha": "def456"}}`),
+	)
+
+	require.False(t, status.ReviewDecision.Valid)
+	require.False(t, status.ChecksStatus.Valid)
+}
+
+// TestFetchPullRequestStatus_RateLimited tests that reviews and checks are skipped while
+// rate limited, leaving the status unset
+func TestFetchPullRequestStatus_RateLimited(t *testing.T) {
+	dbConn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rateLimited := &github.RateLimitedError{ResetAt: time.Now().Add(time.Hour), LowPriority: true}
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().ViewerLogin().Return("")
+	mockClient.EXPECT().
+		FetchPullRequestReviews(gomock.Any(), "owner", "repo", 7, reviewsPerPage, 1).
+		Return(nil, rateLimited)
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	status := service.fetchPullRequestStatus(
+		context.Background(),
+		"owner/repo",
+		[]byte(`{"number": 7, "state": "open", "draft": true, "head": {"sha": "def456"}}`),
+	)
+
+	require.Equal(t, sql.NullBool{Bool: true, Valid: true}, status.Draft)
+	require.False(t, status.ReviewDecision.Valid)
+	require.False(t, status.ChecksStatus.Valid)
+}
+
+// TestHydrateSubjects tests that subjects are fetched in one batch and keyed by thread
+func TestHydrateSubjects(t *testing.T) {
+	dbConn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issue := types.SubjectRef{Owner: "owner", Repo: "repo", Number: 1, Type: "Issue"}
+	pr := types.SubjectRef{Owner: "owner", Repo: "repo", Number: 2, Type: "PullRequest"}
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().
+		Endpoints().
+		Return(github.NewEndpoints("", "")).
+		AnyTimes()
+	mockClient.EXPECT().
+		HydrateSubjects(gomock.Any(), []types.SubjectRef{issue, pr}).
+		Return(map[types.SubjectRef]types.HydratedSubject{
+			issue: {Raw: []byte(`{"number": 1}`)},
+		}, nil)
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	thread := func(id, subjectType, url string) types.NotificationThread {
+		return types.NotificationThread{
+			ID:      id,
+			Subject: types.NotificationSubject{Type: subjectType, URL: url},
+		}
+	}
+	subjects, err := service.HydrateSubjects(context.Background(), []types.NotificationThread{
+		thread("t1", "Issue", "https://api.github.com/repos/owner/repo/issues/1"),
+		thread("t2", "PullRequest", "https://api.github.com/repos/owner/repo/pulls/2"),
+		thread("t3", "Release", "https://api.github.com/repos/owner/repo/releases/3"),
+		thread("t4", "Issue", ""),
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]types.HydratedSubject{
+		"t1": {Raw: []byte(`{"number": 1}`)},
+	}, subjects)
+}
+
+// TestHydrateSubjects_NothingToFetch tests that no query is made without supported subjects
+func TestHydrateSubjects_NothingToFetch(t *testing.T) {
+	dbConn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	service := setupSyncService(t, dbConn, mockClient)
+
+	subjects, err := service.HydrateSubjects(context.Background(), []types.NotificationThread{
+		{ID: "t1", Subject: types.NotificationSubject{Type: "CheckSuite"}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, subjects)
+}
+
+// TestHydrateSubjects_ClientError tests that a failed batch is reported
+func TestHydrateSubjects_ClientError(t *testing.T) {
+	dbConn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().Endpoints().Return(github.NewEndpoints("", ""))
+	mockClient.EXPECT().
+		HydrateSubjects(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("api error"))
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	_, err = service.HydrateSubjects(context.Background(), []types.NotificationThread{{
+		ID: "t1",
+		Subject: types.NotificationSubject{
+			Type: "Issue",
+			URL:  "https://api.github.com/repos/owner/repo/issues/1",
+		},
+	}})
+	require.ErrorIs(t, err, ErrFailedToFetchSubject)
+}
+
+// TestHydratedPullRequestStatus tests that a hydrated PR's status needs no API calls
+func TestHydratedPullRequestStatus(t *testing.T) {
+	dbConn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := githubmocks.NewMockClient(ctrl)
+	mockClient.EXPECT().ViewerLogin().Return("octocat")
+
+	service := setupSyncService(t, dbConn, mockClient)
+
+	subject := types.HydratedSubject{
+		Raw: []byte(`{
+			"number": 42,
+			"state": "open",
+			"draft": false,
+			"head": {"sha": "abc123"},
+			"requested_reviewers": [{"login": "octocat"}],
+			"requested_teams": []
+		}`),
+		Reviews:   []types.PullRequestReview{{State: "APPROVED"}},
+		CheckRuns: []types.CheckRun{{Status: "completed", Conclusion: "success"}},
+	}
+	status := service.hydratedPullRequestStatus(subject)
+
+	require.Equal(t, sql.NullBool{Bool: false, Valid: true}, status.Draft)
+	require.Equal(t, []string{"octocat", "@me"}, status.ReviewRequested)
+	require.Equal(t, "approved", status.ReviewDecision.String)
+	require.Equal(t, "passing", status.ChecksStatus.String)
+}
+
 // ======================================
 // Tests for sync_settings.go helpers
 // ======================================
@@ -595,8 +1052,14 @@ func TestGetSyncContext(t *testing.T) {
 				// GetSyncState returns empty state (no initial sync completed)
 				syncStateRows := sqlmock.NewRows([]string{
 					"id", "last_successful_poll", "latest_notification_at",
-					"last_notification_etag", "created_at", "updated_at",
+					"last_notification_etag", "last_notification_modified",
+					"created_at", "updated_at",
 					"initial_sync_completed_at", "oldest_notification_synced_at",
+					"rate_limit_limit", "rate_limit_remaining",
+					"rate_limit_reset_at", "rate_limit_updated_at",
+					"reconciled_at", "reconcile_policy", "reconcile_checked",
+					"reconcile_archived", "reconcile_flagged",
+					"reconcile_deleted", "reconcile_unflagged",
 				})
 				mock.ExpectQuery(`SELECT (.+) FROM sync_state`).WillReturnRows(syncStateRows)
 			},
@@ -646,12 +1109,23 @@ func TestGetSyncContext(t *testing.T) {
 				latestNotification := time.Date(2024, 1, 14, 10, 0, 0, 0, time.UTC)
 				syncStateRows := sqlmock.NewRows([]string{
 					"id", "last_successful_poll", "latest_notification_at",
-					"last_notification_etag", "created_at", "updated_at",
+					"last_notification_etag", "last_notification_modified",
+					"created_at", "updated_at",
 					"initial_sync_completed_at", "oldest_notification_synced_at",
+					"rate_limit_limit", "rate_limit_remaining",
+					"rate_limit_reset_at", "rate_limit_updated_at",
+					"reconciled_at", "reconcile_policy", "reconcile_checked",
+					"reconcile_archived", "reconcile_flagged",
+					"reconcile_deleted", "reconcile_unflagged",
 				}).AddRow(
 					1, time.Now(), sql.NullTime{Valid: true, Time: latestNotification},
-					sql.NullString{}, time.Now(), time.Now(),
+					sql.NullString{Valid: true, String: `W/"abc"`},
+					sql.NullString{Valid: true, String: "Sun, 14 Jan 2024 10:00:00 GMT"},
+					time.Now(), time.Now(),
 					sql.NullTime{Valid: true, Time: completedAt}, sql.NullTime{},
+					sql.NullInt32{}, sql.NullInt32{}, sql.NullTime{}, sql.NullTime{},
+					sql.NullTime{}, sql.NullString{}, sql.NullInt32{}, sql.NullInt32{},
+					sql.NullInt32{}, sql.NullInt32{}, sql.NullInt32{},
 				)
 				mock.ExpectQuery(`SELECT (.+) FROM sync_state`).WillReturnRows(syncStateRows)
 			},
@@ -660,6 +1134,10 @@ func TestGetSyncContext(t *testing.T) {
 				IsInitialSync:    false,
 				// SinceTimestamp should be set to latestNotification
 				UnreadOnly: false,
+				PollValidators: types.PollValidators{
+					ETag:         `W/"abc"`,
+					LastModified: "Sun, 14 Jan 2024 10:00:00 GMT",
+				},
 			},
 			expectError: false,
 		},
@@ -743,6 +1221,7 @@ func TestGetSyncContext(t *testing.T) {
 				require.Equal(t, tt.expectedContext.IsSyncConfigured, result.IsSyncConfigured)
 				require.Equal(t, tt.expectedContext.IsInitialSync, result.IsInitialSync)
 				require.Equal(t, tt.expectedContext.UnreadOnly, result.UnreadOnly)
+				require.Equal(t, tt.expectedContext.PollValidators, result.PollValidators)
 
 				// Check MaxCount if expected
 				if tt.expectedContext.MaxCount != nil {
@@ -760,6 +1239,48 @@ func TestGetSyncContext(t *testing.T) {
 	}
 }
 
+// TestGetSyncContext_AccountSettings tests that an account's sync settings replace the
+// user's initial sync limits
+func TestGetSyncContext_AccountSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	syncSettingsJSON := `{"initialSyncDays": 30, "initialSyncMaxCount": 100, "initialSyncUnreadOnly": true, "setupCompleted": true}`
+	userRows := sqlmock.NewRows([]string{
+		"id", "username", "password_hash", "created_at", "updated_at", "sync_settings",
+	}).AddRow(1, "testuser", "hash", time.Now(), time.Now(), []byte(syncSettingsJSON))
+	mock.ExpectQuery(`SELECT (.+) FROM users`).WillReturnRows(userRows)
+
+	accountRows := sqlmock.NewRows([]string{
+		"id", "name", "api_url", "web_url", "sync_settings", "created_at", "updated_at",
+	}).AddRow(
+		2, "work", "https://api.github.com", "https://github.com",
+		[]byte(`{"initialSyncMaxCount": 10}`), time.Now(), time.Now(),
+	)
+	mock.ExpectQuery(`SELECT (.+) FROM github_accounts`).
+		WithArgs(int64(2)).
+		WillReturnRows(accountRows)
+
+	mock.ExpectQuery(`SELECT (.+) FROM sync_state`).
+		WithArgs(int64(2)).
+		WillReturnError(sql.ErrNoRows)
+
+	service := setupSyncService(t, dbConn, githubmocks.NewMockClient(ctrl)).WithAccount(2)
+
+	result, err := service.GetSyncContext(context.Background())
+	require.NoError(t, err)
+	require.True(t, result.IsSyncConfigured)
+	require.True(t, result.IsInitialSync)
+	require.Nil(t, result.SinceTimestamp)
+	require.Equal(t, intPtr(10), result.MaxCount)
+	require.False(t, result.UnreadOnly)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 // TestIsInitialSyncComplete tests the IsInitialSyncComplete method
 func TestIsInitialSyncComplete(t *testing.T) {
 	tests := []struct {
@@ -774,12 +1295,21 @@ func TestIsInitialSyncComplete(t *testing.T) {
 				completedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
 				rows := sqlmock.NewRows([]string{
 					"id", "last_successful_poll", "latest_notification_at",
-					"last_notification_etag", "created_at", "updated_at",
+					"last_notification_etag", "last_notification_modified",
+					"created_at", "updated_at",
 					"initial_sync_completed_at", "oldest_notification_synced_at",
+					"rate_limit_limit", "rate_limit_remaining",
+					"rate_limit_reset_at", "rate_limit_updated_at",
+					"reconciled_at", "reconcile_policy", "reconcile_checked",
+					"reconcile_archived", "reconcile_flagged",
+					"reconcile_deleted", "reconcile_unflagged",
 				}).AddRow(
 					1, time.Now(), sql.NullTime{Valid: true, Time: time.Now()},
-					sql.NullString{}, time.Now(), time.Now(),
+					sql.NullString{}, sql.NullString{}, time.Now(), time.Now(),
 					sql.NullTime{Valid: true, Time: completedAt}, sql.NullTime{},
+					sql.NullInt32{}, sql.NullInt32{}, sql.NullTime{}, sql.NullTime{},
+					sql.NullTime{}, sql.NullString{}, sql.NullInt32{}, sql.NullInt32{},
+					sql.NullInt32{}, sql.NullInt32{}, sql.NullInt32{},
 				)
 				mock.ExpectQuery(`SELECT (.+) FROM sync_state`).WillReturnRows(rows)
 			},
@@ -791,12 +1321,21 @@ func TestIsInitialSyncComplete(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
 					"id", "last_successful_poll", "latest_notification_at",
-					"last_notification_etag", "created_at", "updated_at",
+					"last_notification_etag", "last_notification_modified",
+					"created_at", "updated_at",
 					"initial_sync_completed_at", "oldest_notification_synced_at",
+					"rate_limit_limit", "rate_limit_remaining",
+					"rate_limit_reset_at", "rate_limit_updated_at",
+					"reconciled_at", "reconcile_policy", "reconcile_checked",
+					"reconcile_archived", "reconcile_flagged",
+					"reconcile_deleted", "reconcile_unflagged",
 				}).AddRow(
 					1, time.Now(), sql.NullTime{Valid: true, Time: time.Now()},
-					sql.NullString{}, time.Now(), time.Now(),
+					sql.NullString{}, sql.NullString{}, time.Now(), time.Now(),
 					sql.NullTime{Valid: false}, sql.NullTime{},
+					sql.NullInt32{}, sql.NullInt32{}, sql.NullTime{}, sql.NullTime{},
+					sql.NullTime{}, sql.NullString{}, sql.NullInt32{}, sql.NullInt32{},
+					sql.NullInt32{}, sql.NullInt32{}, sql.NullInt32{},
 				)
 				mock.ExpectQuery(`SELECT (.+) FROM sync_state`).WillReturnRows(rows)
 			},
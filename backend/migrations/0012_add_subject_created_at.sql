@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_created_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_created_at ON notifications(subject_created_at) WHERE subject_created_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_subject_created_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_created_at;
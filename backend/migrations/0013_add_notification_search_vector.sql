@@ -0,0 +1,16 @@
+-- +goose Up
+-- Full-text search over the fields free-text query terms search. Generated columns can only
+-- read their own row, so the repository name comes from the thread payload rather than the
+-- repositories table. Slashes are replaced so owner and name are indexed as separate words.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(subject_title, '')), 'A') ||
+    setweight(to_tsvector('english', replace(coalesce(payload->'repository'->>'full_name', ''), '/', ' ')), 'B') ||
+    setweight(to_tsvector('english', coalesce(author_login, '')), 'B') ||
+    setweight(to_tsvector('english', subject_type || ' ' || coalesce(subject_state, '') || ' ' || coalesce(subject_number::text, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(subject_raw->>'body', '')), 'D')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_notifications_search_vector ON notifications USING GIN (search_vector);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_search_vector;
+ALTER TABLE notifications DROP COLUMN IF EXISTS search_vector;
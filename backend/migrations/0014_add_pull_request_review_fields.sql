@@ -0,0 +1,21 @@
+-- +goose Up
+-- Pull request attributes for is:draft, review:, review-requested: and checks:, copied onto
+-- notifications like subject_state and subject_merged so queries don't need a join.
+-- subject_review_requested holds lowercase reviewer logins and team slugs, plus '@me' when the
+-- token's user is one of the requested reviewers.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_draft BOOLEAN NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_review_decision TEXT NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_review_requested TEXT[] NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_checks_status TEXT NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_review_decision ON notifications(subject_review_decision) WHERE subject_review_decision IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_review_requested ON notifications USING GIN (subject_review_requested);
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_checks_status ON notifications(subject_checks_status) WHERE subject_checks_status IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_subject_checks_status;
+DROP INDEX IF EXISTS idx_notifications_subject_review_requested;
+DROP INDEX IF EXISTS idx_notifications_subject_review_decision;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_checks_status;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_review_requested;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_review_decision;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_draft;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- Issue and pull request labels, milestone and assignees for label:, milestone:, assignee:
+-- and no:, copied onto notifications from subject_raw so queries don't need to parse JSON.
+-- subject_labels holds lowercase label names and subject_assignees lowercase logins, plus '@me'
+-- when the token's user is assigned. Subjects without labels or assignees (releases, commits, ...)
+-- leave them NULL.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_labels TEXT[] NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_milestone TEXT NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_assignees TEXT[] NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_labels ON notifications USING GIN (subject_labels);
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_milestone ON notifications(subject_milestone) WHERE subject_milestone IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_assignees ON notifications USING GIN (subject_assignees);
+
+-- Backfill from already-fetched subjects; '@me' is added the next time a notification syncs
+UPDATE notifications
+SET subject_labels = ARRAY(
+        SELECT lower(label->>'name') FROM jsonb_array_elements(subject_raw->'labels') AS label
+    ),
+    subject_milestone = subject_raw->'milestone'->>'title',
+    subject_assignees = ARRAY(
+        SELECT lower(assignee->>'login')
+        FROM jsonb_array_elements(subject_raw->'assignees') AS assignee
+    )
+WHERE jsonb_typeof(subject_raw->'labels') = 'array'
+  AND jsonb_typeof(subject_raw->'assignees') = 'array';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_subject_assignees;
+DROP INDEX IF EXISTS idx_notifications_subject_milestone;
+DROP INDEX IF EXISTS idx_notifications_subject_labels;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_assignees;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_milestone;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_labels;
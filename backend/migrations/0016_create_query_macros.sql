@@ -0,0 +1,12 @@
+-- +goose Up
+-- Named query fragments that queries reference as @name
+CREATE TABLE IF NOT EXISTS query_macros (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    query TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS query_macros;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Issue and pull request comment counts for comments:, copied onto notifications from
+-- subject_raw so queries don't need to parse JSON. Subjects without a comment count
+-- (releases, commits, ...) leave it NULL.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_comments INTEGER NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_comments ON notifications(subject_comments) WHERE subject_comments IS NOT NULL;
+
+-- Backfill from already-fetched subjects
+UPDATE notifications
+SET subject_comments = (subject_raw->>'comments')::INTEGER
+WHERE jsonb_typeof(subject_raw->'comments') = 'number';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_subject_comments;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_comments;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Last-Modified of the latest notifications poll, sent back as If-Modified-Since alongside
+-- last_notification_etag (If-None-Match) so an idle account gets 304 Not Modified
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS last_notification_modified TEXT NULL;
+
+-- +goose Down
+ALTER TABLE sync_state DROP COLUMN IF EXISTS last_notification_modified;
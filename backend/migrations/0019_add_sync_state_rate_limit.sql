@@ -0,0 +1,12 @@
+-- +goose Up
+-- GitHub rate limit budget as last seen by the worker, so the API can report it
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS rate_limit_limit INTEGER NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS rate_limit_remaining INTEGER NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS rate_limit_reset_at TIMESTAMPTZ NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS rate_limit_updated_at TIMESTAMPTZ NULL;
+
+-- +goose Down
+ALTER TABLE sync_state DROP COLUMN IF EXISTS rate_limit_limit;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS rate_limit_remaining;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS rate_limit_reset_at;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS rate_limit_updated_at;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Changes to write back to GitHub (mark read, mark done, unsubscribe), one row per thread
+-- and action. A row is delivered by a github_write_back job and completed once GitHub
+-- accepts it; until then it records the attempts made and the last error.
+CREATE TABLE IF NOT EXISTS github_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    github_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_github_outbox_pending
+    ON github_outbox (created_at)
+    WHERE completed_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS github_outbox;
@@ -0,0 +1,51 @@
+-- +goose Up
+-- GitHub identities feeding the inbox. Tokens stay in the worker's environment; an account
+-- row only records where it syncs from and, optionally, sync settings overriding the
+-- user's. The 'default' account owns everything synced before accounts existed.
+CREATE TABLE IF NOT EXISTS github_accounts (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    api_url TEXT NOT NULL,
+    web_url TEXT NOT NULL,
+    sync_settings JSONB NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO github_accounts (id, name, api_url, web_url)
+VALUES (1, 'default', 'https://api.github.com', 'https://github.com')
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('github_accounts', 'id'), (SELECT MAX(id) FROM github_accounts));
+
+-- One sync state row per account, rather than the single row with id 1
+ALTER TABLE sync_state
+    ADD COLUMN IF NOT EXISTS account_id BIGINT NOT NULL DEFAULT 1
+    REFERENCES github_accounts (id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sync_state_account_id ON sync_state (account_id);
+CREATE SEQUENCE IF NOT EXISTS sync_state_id_seq OWNED BY sync_state.id;
+SELECT setval('sync_state_id_seq', COALESCE((SELECT MAX(id) FROM sync_state), 0) + 1, false);
+ALTER TABLE sync_state ALTER COLUMN id SET DEFAULT nextval('sync_state_id_seq');
+
+-- The account each notification was synced from. Thread IDs are assumed not to collide
+-- between accounts, so github_id stays unique on its own.
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS account_id BIGINT NOT NULL DEFAULT 1
+    REFERENCES github_accounts (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_notifications_account_id ON notifications (account_id);
+
+-- Write-backs are delivered with the token of the notification's account
+ALTER TABLE github_outbox
+    ADD COLUMN IF NOT EXISTS account_id BIGINT NOT NULL DEFAULT 1
+    REFERENCES github_accounts (id) ON DELETE CASCADE;
+
+-- +goose Down
+ALTER TABLE github_outbox DROP COLUMN IF EXISTS account_id;
+DROP INDEX IF EXISTS idx_notifications_account_id;
+ALTER TABLE notifications DROP COLUMN IF EXISTS account_id;
+DELETE FROM sync_state WHERE account_id <> 1;
+ALTER TABLE sync_state ALTER COLUMN id SET DEFAULT 1;
+DROP SEQUENCE IF EXISTS sync_state_id_seq;
+DROP INDEX IF EXISTS idx_sync_state_account_id;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS account_id;
+DROP TABLE IF EXISTS github_accounts;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Webhook deliveries are matched to notifications by their subject's API URL
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_url ON notifications (subject_url);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_subject_url;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Discussion category and answered state for category: and is:answered, copied onto
+-- notifications from subject_raw so queries don't need to parse JSON. Subjects other than
+-- discussions leave them NULL.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_category TEXT NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_answered BOOLEAN NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_category ON notifications(subject_category) WHERE subject_category IS NOT NULL;
+
+-- Backfill from already-fetched subjects
+UPDATE notifications
+SET subject_category = subject_raw->'category'->>'name',
+    subject_answered = COALESCE(subject_raw->>'answer_chosen_at', '') <> ''
+WHERE jsonb_typeof(subject_raw->'category') = 'object';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_subject_category;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_answered;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_category;
@@ -0,0 +1,46 @@
+-- +goose Up
+-- Fields read from the subjects of other notification types by the sync's subject handlers:
+-- a release's tag, a workflow run's conclusion, the SHA of a commit or workflow run and the
+-- severity of a security advisory or Dependabot alert. Each is NULL for other subjects.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_tag TEXT NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_conclusion TEXT NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_sha TEXT NULL;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS subject_severity TEXT NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_conclusion ON notifications(subject_conclusion) WHERE subject_conclusion IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_subject_severity ON notifications(subject_severity) WHERE subject_severity IS NOT NULL;
+
+-- Backfill from already-fetched subjects
+UPDATE notifications
+SET subject_tag = subject_raw->>'tag_name'
+WHERE subject_type = 'Release' AND subject_raw IS NOT NULL;
+
+UPDATE notifications
+SET subject_sha = subject_raw->>'sha'
+WHERE subject_type = 'Commit' AND subject_raw IS NOT NULL;
+
+UPDATE notifications
+SET subject_severity = CASE lower(subject_raw->>'severity')
+        WHEN 'moderate' THEN 'medium'
+        ELSE lower(subject_raw->>'severity')
+    END
+WHERE subject_type = 'SecurityAdvisory' AND subject_raw->>'severity' IS NOT NULL;
+
+-- Check suites were never fetched, but their titles read "<workflow> workflow run <outcome>
+-- for <branch> branch"
+UPDATE notifications
+SET subject_conclusion = CASE substring(subject_title FROM ' workflow run (.+) for .+ branch$')
+        WHEN 'failed' THEN 'failure'
+        WHEN 'succeeded' THEN 'success'
+        WHEN 'cancelled' THEN 'cancelled'
+        WHEN 'skipped' THEN 'skipped'
+        WHEN 'timed out' THEN 'timed_out'
+    END
+WHERE subject_type = 'CheckSuite';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_subject_severity;
+DROP INDEX IF EXISTS idx_notifications_subject_conclusion;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_severity;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_sha;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_conclusion;
+ALTER TABLE notifications DROP COLUMN IF EXISTS subject_tag;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- When reconciliation found a thread missing from GitHub (marked done or unsubscribed on
+-- github.com, or its repository deleted), under the flag policy. Cleared when GitHub
+-- returns the thread again.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS stale_at TIMESTAMPTZ NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_stale_at ON notifications(stale_at) WHERE stale_at IS NOT NULL;
+
+-- What the latest reconciliation of each account changed, for the sync state API
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS reconciled_at TIMESTAMPTZ NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS reconcile_policy TEXT NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS reconcile_checked INTEGER NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS reconcile_archived INTEGER NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS reconcile_flagged INTEGER NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS reconcile_deleted INTEGER NULL;
+ALTER TABLE sync_state ADD COLUMN IF NOT EXISTS reconcile_unflagged INTEGER NULL;
+
+-- +goose Down
+ALTER TABLE sync_state DROP COLUMN IF EXISTS reconcile_unflagged;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS reconcile_deleted;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS reconcile_flagged;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS reconcile_archived;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS reconcile_checked;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS reconcile_policy;
+ALTER TABLE sync_state DROP COLUMN IF EXISTS reconciled_at;
+DROP INDEX IF EXISTS idx_notifications_stale_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS stale_at;
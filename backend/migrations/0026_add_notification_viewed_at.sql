@@ -0,0 +1,12 @@
+-- +goose Up
+-- When the notification was last opened in Octobud. Background subject refreshes go to
+-- the most recently viewed notifications first.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS viewed_at TIMESTAMPTZ NULL;
+
+-- Background subject refreshes look for each account's oldest fetched subjects
+CREATE INDEX IF NOT EXISTS idx_notifications_account_subject_fetched_at
+    ON notifications (account_id, subject_fetched_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_account_subject_fetched_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS viewed_at;
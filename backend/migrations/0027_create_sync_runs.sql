@@ -0,0 +1,29 @@
+-- +goose Up
+-- History of sync and backfill runs, for diagnosing notifications that never showed up.
+-- A row is created when a run starts and completed when it finishes; subject fetch
+-- failures are added by the processing jobs the run queued. Old runs are pruned by the
+-- worker.
+CREATE TABLE IF NOT EXISTS sync_runs (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES github_accounts (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ NULL,
+    window_since TIMESTAMPTZ NULL,
+    window_before TIMESTAMPTZ NULL,
+    not_modified BOOLEAN NOT NULL DEFAULT FALSE,
+    threads_fetched INTEGER NOT NULL DEFAULT 0,
+    jobs_queued INTEGER NOT NULL DEFAULT 0,
+    subject_fetch_failures INTEGER NOT NULL DEFAULT 0,
+    error TEXT NULL,
+    rate_limited BOOLEAN NOT NULL DEFAULT FALSE,
+    rate_limit_remaining INTEGER NULL,
+    rate_limit_reset_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_runs_started_at ON sync_runs (started_at);
+CREATE INDEX IF NOT EXISTS idx_sync_runs_account_id_started_at
+    ON sync_runs (account_id, started_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS sync_runs;
@@ -0,0 +1,55 @@
+-- +goose Up
+-- Thread IDs and repository names are only unique within one GitHub instance, so a
+-- github.com account and a GitHub Enterprise Server account can both have thread 123 or
+-- repository octo/app. Key notifications and repositories by the account they were
+-- synced from, rather than letting one account's upsert take over another's row.
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_github_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_account_id_github_id
+    ON notifications (account_id, github_id);
+
+ALTER TABLE repositories DROP CONSTRAINT IF EXISTS repositories_full_name_key;
+ALTER TABLE repositories
+    ADD COLUMN IF NOT EXISTS account_id BIGINT NOT NULL DEFAULT 1
+    REFERENCES github_accounts (id) ON DELETE CASCADE;
+
+-- Repositories were shared between accounts, so give each other account with
+-- notifications in a repository its own copy of it
+INSERT INTO repositories (
+    account_id, github_id, node_id, name, full_name, owner_login, owner_id, private,
+    description, html_url, fork, visibility, default_branch, archived, disabled, pushed_at,
+    created_at, updated_at, raw, owner_avatar_url, owner_html_url
+)
+SELECT DISTINCT ON (n.account_id, r.id)
+    n.account_id, r.github_id, r.node_id, r.name, r.full_name, r.owner_login, r.owner_id,
+    r.private, r.description, r.html_url, r.fork, r.visibility, r.default_branch,
+    r.archived, r.disabled, r.pushed_at, r.created_at, r.updated_at, r.raw,
+    r.owner_avatar_url, r.owner_html_url
+FROM notifications n
+JOIN repositories r ON r.id = n.repository_id
+WHERE n.account_id <> r.account_id;
+
+UPDATE notifications n
+SET repository_id = copy.id
+FROM repositories r, repositories copy
+WHERE r.id = n.repository_id
+  AND n.account_id <> r.account_id
+  AND copy.account_id = n.account_id
+  AND copy.full_name = r.full_name;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_repositories_account_id_full_name
+    ON repositories (account_id, full_name);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_repositories_account_id_full_name;
+UPDATE notifications n
+SET repository_id = keep.id
+FROM repositories r, repositories keep
+WHERE r.id = n.repository_id
+  AND keep.id = (SELECT MIN(id) FROM repositories WHERE full_name = r.full_name)
+  AND keep.id <> r.id;
+DELETE FROM repositories r
+WHERE r.id <> (SELECT MIN(id) FROM repositories WHERE full_name = r.full_name);
+ALTER TABLE repositories DROP COLUMN IF EXISTS account_id;
+ALTER TABLE repositories ADD CONSTRAINT repositories_full_name_key UNIQUE (full_name);
+DROP INDEX IF EXISTS idx_notifications_account_id_github_id;
+ALTER TABLE notifications ADD CONSTRAINT notifications_github_id_key UNIQUE (github_id);
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Backfill subject_draft from already-fetched pull requests. 0014 added the column without
+-- one, so drafts synced before it only matched is:draft once their thread changed.
+UPDATE notifications
+SET subject_draft = (subject_raw->>'draft')::BOOLEAN
+WHERE subject_draft IS NULL
+  AND jsonb_typeof(subject_raw->'draft') = 'boolean';
+
+-- +goose Down
+-- Nothing to undo: the backfilled values are what a sync would have stored
@@ -81,10 +81,12 @@ const fromBackendNotification = (notification: BackendNotificationResponse): Not
 		filtered: notification.filtered ?? false,
 		snoozedUntil: notification.snoozedUntil ?? undefined,
 		snoozedAt: notification.snoozedAt ?? undefined,
+		staleAt: notification.staleAt ?? undefined,
 		updatedAt: notification.githubUpdatedAt ?? notification.importedAt,
 		labels: [],
 		viewIds: ["inbox"],
 		repositoryId: notification.repositoryId,
+		accountId: notification.accountId,
 		state: subject?.state as any, // Use actual state from parsed subject
 		authorLogin: notification.authorLogin ?? undefined,
 		githubUrl: notification.githubUrl ?? undefined,
@@ -292,17 +294,25 @@ export async function fetchNotifications(
 	};
 }
 
+// Thread IDs are only unique within a GitHub account, so single-notification
+// routes name the account when it is known
+export function notificationURL(githubId: string, path = "", accountId?: number): string {
+	const url = `/api/notifications/${encodeURIComponent(githubId)}${path}`;
+	return accountId ? `${url}?accountId=${accountId}` : url;
+}
+
 export interface FetchNotificationDetailOptions {
 	fetch?: typeof fetch;
 	fallback?: Notification;
 	query?: string;
+	accountId?: number;
 }
 
 export async function fetchNotificationDetail(
 	githubId: string,
 	options: FetchNotificationDetailOptions = {}
 ): Promise<NotificationDetail> {
-	let url = `/api/notifications/${encodeURIComponent(githubId)}`;
+	let url = notificationURL(githubId, "", options.accountId);
 	if (options.query) {
 		const separator = url.includes("?") ? "&" : "?";
 		url = `${url}${separator}query=${encodeURIComponent(options.query)}`;
@@ -337,10 +347,11 @@ export interface BulkUpdateNotificationResponse {
 // Mark notification as read
 export async function markNotificationRead(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/mark-read`,
+		notificationURL(githubId, "/mark-read", accountId),
 		{
 			method: "POST",
 		},
@@ -358,10 +369,11 @@ export async function markNotificationRead(
 // Mark notification as unread
 export async function markNotificationUnread(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/mark-unread`,
+		notificationURL(githubId, "/mark-unread", accountId),
 		{
 			method: "POST",
 		},
@@ -379,10 +391,11 @@ export async function markNotificationUnread(
 // Archive notification
 export async function archiveNotification(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/archive`,
+		notificationURL(githubId, "/archive", accountId),
 		{
 			method: "POST",
 		},
@@ -400,10 +413,11 @@ export async function archiveNotification(
 // Unarchive notification
 export async function unarchiveNotification(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/unarchive`,
+		notificationURL(githubId, "/unarchive", accountId),
 		{
 			method: "POST",
 		},
@@ -421,10 +435,11 @@ export async function unarchiveNotification(
 // Mute notification
 export async function muteNotification(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/mute`,
+		notificationURL(githubId, "/mute", accountId),
 		{
 			method: "POST",
 		},
@@ -442,10 +457,11 @@ export async function muteNotification(
 // Unmute notification
 export async function unmuteNotification(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/unmute`,
+		notificationURL(githubId, "/unmute", accountId),
 		{
 			method: "POST",
 		},
@@ -464,10 +480,11 @@ export async function unmuteNotification(
 export async function snoozeNotification(
 	githubId: string,
 	snoozedUntil: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/snooze`,
+		notificationURL(githubId, "/snooze", accountId),
 		{
 			method: "POST",
 			headers: {
@@ -489,10 +506,11 @@ export async function snoozeNotification(
 // Unsnooze notification (clear snoozed_until)
 export async function unsnoozeNotification(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/unsnooze`,
+		notificationURL(githubId, "/unsnooze", accountId),
 		{
 			method: "POST",
 		},
@@ -510,10 +528,11 @@ export async function unsnoozeNotification(
 // Star notification
 export async function starNotification(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/star`,
+		notificationURL(githubId, "/star", accountId),
 		{
 			method: "POST",
 		},
@@ -531,10 +550,11 @@ export async function starNotification(
 // Unstar notification
 export async function unstarNotification(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/unstar`,
+		notificationURL(githubId, "/unstar", accountId),
 		{
 			method: "POST",
 		},
@@ -552,10 +572,11 @@ export async function unstarNotification(
 // Unfilter notification (move to inbox)
 export async function unfilterNotification(
 	githubId: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<Notification> {
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodeURIComponent(githubId)}/unfilter`,
+		notificationURL(githubId, "/unfilter", accountId),
 		{
 			method: "POST",
 		},
@@ -575,11 +596,13 @@ export async function bulkSnoozeNotifications(
 	githubIds: string[],
 	snoozedUntil: string,
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query, snoozedUntil } : { githubIds, snoozedUntil };
+	const body =
+		query !== undefined ? { query, snoozedUntil } : { githubIds, accountId, snoozedUntil };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/snooze",
@@ -605,11 +628,12 @@ export async function bulkSnoozeNotifications(
 export async function bulkUnsnoozeNotifications(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/unsnooze",
@@ -635,11 +659,12 @@ export async function bulkUnsnoozeNotifications(
 export async function bulkMarkNotificationsUnread(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/mark-unread",
@@ -665,11 +690,12 @@ export async function bulkMarkNotificationsUnread(
 export async function bulkMarkNotificationsRead(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/mark-read",
@@ -695,11 +721,12 @@ export async function bulkMarkNotificationsRead(
 export async function bulkArchiveNotifications(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/archive",
@@ -725,11 +752,12 @@ export async function bulkArchiveNotifications(
 export async function bulkUnarchiveNotifications(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/unarchive",
@@ -755,11 +783,12 @@ export async function bulkUnarchiveNotifications(
 export async function bulkMuteNotifications(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/mute",
@@ -785,11 +814,12 @@ export async function bulkMuteNotifications(
 export async function bulkUnmuteNotifications(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/unmute",
@@ -815,11 +845,12 @@ export async function bulkUnmuteNotifications(
 export async function bulkStarNotifications(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/star",
@@ -845,11 +876,12 @@ export async function bulkStarNotifications(
 export async function bulkUnstarNotifications(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/unstar",
@@ -875,11 +907,12 @@ export async function bulkUnstarNotifications(
 export async function bulkUnfilterNotifications(
 	githubIds: string[],
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
 	// Note: query !== undefined includes empty string, which is valid for inbox semantics
-	const body = query !== undefined ? { query } : { githubIds };
+	const body = query !== undefined ? { query } : { githubIds, accountId };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/unfilter",
@@ -906,6 +939,7 @@ export async function bulkAssignTag(
 	githubIds: string[],
 	tagId: string,
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
@@ -913,7 +947,7 @@ export async function bulkAssignTag(
 	const body =
 		query !== undefined
 			? { query, tagId: parseInt(tagId, 10) }
-			: { githubIds, tagId: parseInt(tagId, 10) };
+			: { githubIds, accountId, tagId: parseInt(tagId, 10) };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/assign-tag",
@@ -940,6 +974,7 @@ export async function bulkRemoveTag(
 	githubIds: string[],
 	tagId: string,
 	query?: string,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<number> {
 	// Send either githubIds or query, but not both
@@ -947,7 +982,7 @@ export async function bulkRemoveTag(
 	const body =
 		query !== undefined
 			? { query, tagId: parseInt(tagId, 10) }
-			: { githubIds, tagId: parseInt(tagId, 10) };
+			: { githubIds, accountId, tagId: parseInt(tagId, 10) };
 
 	const response = await fetchWithAuth(
 		"/api/notifications/bulk/remove-tag",
@@ -971,9 +1006,9 @@ export async function bulkRemoveTag(
 
 export async function refreshNotificationSubject(
 	githubId: string,
-	options: { fetch?: typeof fetch; query?: string } = {}
+	options: { fetch?: typeof fetch; query?: string; accountId?: number } = {}
 ): Promise<Notification> {
-	let url = `/api/notifications/${encodeURIComponent(githubId)}/refresh-subject`;
+	let url = notificationURL(githubId, "/refresh-subject", options.accountId);
 	if (options.query) {
 		const separator = url.includes("?") ? "&" : "?";
 		url = `${url}${separator}query=${encodeURIComponent(options.query)}`;
@@ -998,10 +1033,16 @@ export async function fetchNotificationTimeline(
 	githubId: string,
 	perPage: number,
 	page: number,
+	accountId?: number,
 	fetchImpl?: typeof fetch
 ): Promise<NotificationTimelineResponse> {
-	const url = `/api/notifications/${encodeURIComponent(githubId)}/timeline?per_page=${perPage}&page=${page}`;
-	const response = await fetchWithAuth(url, {}, fetchImpl);
+	const url = notificationURL(githubId, "/timeline", accountId);
+	const separator = url.includes("?") ? "&" : "?";
+	const response = await fetchWithAuth(
+		`${url}${separator}per_page=${perPage}&page=${page}`,
+		{},
+		fetchImpl
+	);
 
 	if (!response.ok) {
 		throw new Error(`Failed to fetch timeline (${response.status})`);
@@ -37,6 +37,7 @@ interface TagEnvelope {
 }
 
 import { fetchWithAuth, buildApiUrl, ApiUnreachableError, isProxyConnectionError } from "./fetch";
+import { notificationURL } from "./notifications";
 
 export async function fetchTags(fetchImpl: typeof fetch = fetch): Promise<Tag[]> {
 	const response = await fetchWithAuth("/api/tags", {}, fetchImpl);
@@ -122,11 +123,11 @@ export async function deleteTag(id: string, fetchImpl: typeof fetch = fetch): Pr
 export async function assignTagToNotification(
 	githubId: string,
 	tagId: string,
+	accountId?: number,
 	fetchImpl: typeof fetch = fetch
 ): Promise<import("./types").Notification> {
-	const encodedGithubId = encodeURIComponent(githubId);
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodedGithubId}/tags`,
+		notificationURL(githubId, "/tags", accountId),
 		{
 			method: "POST",
 			headers: {
@@ -146,11 +147,11 @@ export async function assignTagToNotification(
 export async function assignTagToNotificationByName(
 	githubId: string,
 	tagName: string,
+	accountId?: number,
 	fetchImpl: typeof fetch = fetch
 ): Promise<import("./types").Notification> {
-	const encodedGithubId = encodeURIComponent(githubId);
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodedGithubId}/tags-by-name`,
+		notificationURL(githubId, "/tags-by-name", accountId),
 		{
 			method: "POST",
 			headers: {
@@ -170,11 +171,11 @@ export async function assignTagToNotificationByName(
 export async function removeTagFromNotification(
 	githubId: string,
 	tagId: string,
+	accountId?: number,
 	fetchImpl: typeof fetch = fetch
 ): Promise<import("./types").Notification> {
-	const encodedGithubId = encodeURIComponent(githubId);
 	const response = await fetchWithAuth(
-		`/api/notifications/${encodedGithubId}/tags/${tagId}`,
+		notificationURL(githubId, `/tags/${tagId}`, accountId),
 		{
 			method: "DELETE",
 		},
@@ -54,6 +54,7 @@ export interface BackendNotificationResponse {
 	id: number;
 	githubId: string;
 	repositoryId: number;
+	accountId: number;
 	pullRequestId?: number | null;
 	subjectType: string;
 	subjectTitle: string;
@@ -67,6 +68,7 @@ export interface BackendNotificationResponse {
 	filtered: boolean;
 	snoozedUntil?: string | null;
 	snoozedAt?: string | null;
+	staleAt?: string | null;
 	effectiveSortDate: string;
 	githubUnread?: boolean | null;
 	githubUpdatedAt?: string | null;
@@ -105,10 +107,12 @@ export interface Notification {
 	filtered?: boolean;
 	snoozedUntil?: string;
 	snoozedAt?: string;
+	staleAt?: string;
 	updatedAt: string;
 	labels: string[];
 	viewIds: string[];
 	repositoryId?: number;
+	accountId?: number;
 	state?: NotificationState;
 	authorLogin?: string;
 	githubUrl?: string;
@@ -238,6 +242,10 @@ export interface NotificationComment {
 	createdAt: string;
 	updatedAt: string;
 	htmlUrl: string;
+	// For discussion comments
+	isAnswer?: boolean;
+	upvoteCount?: number;
+	replies?: NotificationComment[];
 }
 
 export interface NotificationReview {
@@ -304,6 +312,10 @@ export interface NotificationTimelineItem {
 	// For commits
 	message?: string;
 	sha?: string;
+	// For discussion comments
+	isAnswer?: boolean;
+	upvoteCount?: number;
+	replies?: NotificationTimelineItem[];
 	// Common fields
 	htmlUrl?: string;
 	createdAt?: string;
@@ -32,11 +32,14 @@ export interface SyncSettings {
 	initialSyncMaxCount?: number | null;
 	initialSyncUnreadOnly: boolean;
 	setupCompleted: boolean;
+	writeBackToGitHub: boolean;
 }
 
 export interface UserResponse {
 	username: string;
 	syncSettings?: SyncSettings | null;
+	githubApiUrl?: string;
+	githubWebUrl?: string;
 }
 
 export interface UpdateCredentialsRequest {
@@ -243,6 +246,7 @@ export interface UpdateSyncSettingsRequest {
 	initialSyncMaxCount?: number | null;
 	initialSyncUnreadOnly: boolean;
 	setupCompleted: boolean;
+	writeBackToGitHub?: boolean;
 }
 
 export async function updateSyncSettings(
@@ -269,9 +273,28 @@ export async function updateSyncSettings(
 	return response.json();
 }
 
+export interface RateLimit {
+	limit: number;
+	remaining: number;
+	resetAt: string;
+	updatedAt: string;
+}
+
+export interface Reconciliation {
+	reconciledAt: string;
+	policy: string;
+	checked: number;
+	archived: number;
+	flagged: number;
+	deleted: number;
+	unflagged: number;
+}
+
 export interface SyncState {
 	oldestNotificationSyncedAt?: string | null;
 	initialSyncCompletedAt?: string | null;
+	rateLimit?: RateLimit | null;
+	reconciliation?: Reconciliation | null;
 }
 
 export async function getSyncState(fetchImpl?: typeof fetch): Promise<SyncState> {
@@ -291,6 +314,69 @@ export async function getSyncState(fetchImpl?: typeof fetch): Promise<SyncState>
 	return response.json();
 }
 
+export interface SyncRun {
+	id: number;
+	accountId: number;
+	kind: "sync" | "backfill";
+	startedAt: string;
+	finishedAt?: string | null; // Unset while the run is in progress
+	windowSince?: string | null;
+	windowBefore?: string | null;
+	notModified: boolean;
+	threadsFetched: number;
+	jobsQueued: number;
+	subjectFetchFailures: number;
+	error?: string | null;
+	rateLimited: boolean;
+	rateLimitRemaining?: number | null;
+	rateLimitResetAt?: string | null;
+}
+
+export interface SyncRunsPage {
+	runs: SyncRun[];
+	total: number;
+	page: number;
+	pageSize: number;
+}
+
+export interface SyncRunsParams {
+	accountId?: number;
+	page?: number;
+	pageSize?: number;
+}
+
+export async function getSyncRuns(
+	params: SyncRunsParams = {},
+	fetchImpl?: typeof fetch
+): Promise<SyncRunsPage> {
+	const searchParams = new URLSearchParams();
+	if (params.accountId) {
+		searchParams.set("accountId", String(params.accountId));
+	}
+	if (params.page) {
+		searchParams.set("page", String(params.page));
+	}
+	if (params.pageSize) {
+		searchParams.set("pageSize", String(params.pageSize));
+	}
+	const query = searchParams.toString();
+
+	const response = await fetchWithAuth(
+		query ? `/api/user/sync-runs?${query}` : "/api/user/sync-runs",
+		{
+			method: "GET",
+		},
+		fetchImpl
+	);
+
+	if (!response.ok) {
+		const error = await response.json().catch(() => ({ error: "Failed to get sync runs" }));
+		throw new Error(error.error || "Failed to get sync runs");
+	}
+
+	return response.json();
+}
+
 export interface SyncOlderRequest {
 	days: number;
 	maxCount?: number | null;
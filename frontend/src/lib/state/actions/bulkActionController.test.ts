@@ -18,6 +18,7 @@ import { writable, get } from "svelte/store";
 import { createBulkActionController } from "./bulkActionController";
 import { createSharedHelpers } from "./sharedHelpers";
 import { createDebounceManager } from "./debounceManager";
+import { bulkMarkNotificationsRead } from "$lib/api/notifications";
 import type { NotificationStore } from "../../stores/notificationStore";
 import type { PaginationStore } from "../../stores/paginationStore";
 import type { SelectionStore } from "../../stores/selectionStore";
@@ -25,6 +26,17 @@ import type { QueryStore } from "../../stores/queryStore";
 import type { UIStore } from "../../stores/uiStateStore";
 import type { ControllerOptions } from "../interfaces/common";
 
+vi.mock("$lib/api/notifications", () => ({
+	bulkMarkNotificationsRead: vi.fn(),
+}));
+
+vi.mock("$lib/stores/toastStore", () => ({
+	toastStore: {
+		success: vi.fn(),
+		error: vi.fn(),
+	},
+}));
+
 describe("BulkActionController", () => {
 	let notificationStore: NotificationStore;
 	let paginationStore: PaginationStore;
@@ -105,9 +117,7 @@ describe("BulkActionController", () => {
 
 	// Note: handleBulkAction is an internal function, not exposed.
 	// The controller exposes specific bulk action methods like bulkArchive, bulkMarkRead, etc.
-	// Testing those would require mocking the API functions, which is complex.
-	// The core logic (confirmation, selection clearing, etc.) is tested indirectly through
-	// integration tests or by testing the exposed methods.
+	// The core logic (confirmation, selection clearing, etc.) is tested through those methods.
 
 	describe("bulkArchive", () => {
 		it("is a function", () => {
@@ -119,5 +129,26 @@ describe("BulkActionController", () => {
 		it("is a function", () => {
 			expect(typeof controller.bulkMarkRead).toBe("function");
 		});
+
+		it("sends one request per account when accounts share a thread id", async () => {
+			notificationStore.pageData.set({
+				items: [
+					{ id: "1", githubId: "gh-1", accountId: 1 },
+					{ id: "2", githubId: "gh-1", accountId: 2 },
+					{ id: "3", githubId: "gh-2", accountId: 1 },
+				],
+				total: 3,
+				page: 1,
+				pageSize: 50,
+			} as any);
+			selectionStore.selectedIds.set(new Set(["1:gh-1", "2:gh-1", "1:gh-2"]));
+			vi.mocked(bulkMarkNotificationsRead).mockResolvedValue(1);
+
+			await controller.bulkMarkRead();
+
+			expect(bulkMarkNotificationsRead).toHaveBeenCalledTimes(2);
+			expect(bulkMarkNotificationsRead).toHaveBeenCalledWith(["gh-1", "gh-2"], undefined, 1);
+			expect(bulkMarkNotificationsRead).toHaveBeenCalledWith(["gh-1"], undefined, 2);
+		});
 	});
 });
@@ -39,6 +39,7 @@ import type { QueryStore } from "../../stores/queryStore";
 import type { UIStore } from "../../stores/uiStateStore";
 import type { ControllerOptions } from "../interfaces/common";
 import type { SharedHelpers } from "./sharedHelpers";
+import { notificationKey, parseNotificationKey } from "../notificationsPage";
 
 interface StoreCollection {
 	notificationStore: NotificationStore;
@@ -59,6 +60,19 @@ export function createBulkActionController(
 ): BulkActions {
 	const { notificationStore, paginationStore, selectionStore, queryStore, uiStore } = stores;
 
+	/**
+	 * Group selected notification keys by GitHub account, since thread IDs are only unique
+	 * within an account and each bulk request names one account
+	 */
+	function groupIdsByAccount(keys: string[]): Map<number | undefined, string[]> {
+		const groups = new Map<number | undefined, string[]>();
+		for (const key of keys) {
+			const { githubId, accountId } = parseNotificationKey(key);
+			groups.set(accountId, [...(groups.get(accountId) ?? []), githubId]);
+		}
+		return groups;
+	}
+
 	async function handleBulkAction(params: {
 		actionName:
 			| "markRead"
@@ -74,7 +88,7 @@ export function createBulkActionController(
 			| "unfilter"
 			| "assignTag"
 			| "removeTag";
-		performAction: (ids: string[], query?: string) => Promise<number>;
+		performAction: (ids: string[], query?: string, accountId?: number) => Promise<number>;
 		successToast: (count: number) => string;
 		errorToast: string;
 	}): Promise<void> {
@@ -114,10 +128,7 @@ export function createBulkActionController(
 			const items = pageData.items;
 			const firstItem =
 				!useQuery && ids.length > 0
-					? items.find((item) => {
-							const key = item.githubId ?? item.id;
-							return key === ids[0];
-						})
+					? items.find((item) => notificationKey(item) === ids[0])
 					: items[0];
 
 			if (firstItem) {
@@ -126,8 +137,15 @@ export function createBulkActionController(
 				willDismiss = dismissedOn.includes(actionName);
 			}
 
-			// Perform the bulk action
-			const actualCount = await performAction(ids, useQuery ? currentQuery : undefined);
+			// Perform the bulk action, once per account when acting on selected IDs
+			let actualCount = 0;
+			if (useQuery) {
+				actualCount = await performAction(ids, currentQuery);
+			} else {
+				for (const [accountId, accountIds] of groupIdsByAccount(ids)) {
+					actualCount += await performAction(accountIds, undefined, accountId);
+				}
+			}
 
 			await options.onRefreshViewCounts?.();
 
@@ -172,7 +190,7 @@ export function createBulkActionController(
 	async function bulkMarkRead(): Promise<void> {
 		await handleBulkAction({
 			actionName: "markRead",
-			performAction: (ids, query) => bulkMarkNotificationsRead(ids, query),
+			performAction: (ids, query, accountId) => bulkMarkNotificationsRead(ids, query, accountId),
 			successToast: (count) => `Marked ${count} notification${count === 1 ? "" : "s"} as read`,
 			errorToast: "Failed to mark notifications as read",
 		});
@@ -181,7 +199,7 @@ export function createBulkActionController(
 	async function bulkMarkUnread(): Promise<void> {
 		await handleBulkAction({
 			actionName: "markUnread",
-			performAction: (ids, query) => bulkMarkNotificationsUnread(ids, query),
+			performAction: (ids, query, accountId) => bulkMarkNotificationsUnread(ids, query, accountId),
 			successToast: (count) => `Marked ${count} notification${count === 1 ? "" : "s"} as unread`,
 			errorToast: "Failed to mark notifications as unread",
 		});
@@ -190,7 +208,7 @@ export function createBulkActionController(
 	async function bulkArchive(): Promise<void> {
 		await handleBulkAction({
 			actionName: "archive",
-			performAction: (ids, query) => bulkArchiveNotifications(ids, query),
+			performAction: (ids, query, accountId) => bulkArchiveNotifications(ids, query, accountId),
 			successToast: (count) => `Archived ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to archive notifications",
 		});
@@ -199,7 +217,7 @@ export function createBulkActionController(
 	async function bulkUnarchive(): Promise<void> {
 		await handleBulkAction({
 			actionName: "unarchive",
-			performAction: (ids, query) => bulkUnarchiveNotifications(ids, query),
+			performAction: (ids, query, accountId) => bulkUnarchiveNotifications(ids, query, accountId),
 			successToast: (count) => `Unarchived ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to unarchive notifications",
 		});
@@ -208,7 +226,7 @@ export function createBulkActionController(
 	async function bulkMute(): Promise<void> {
 		await handleBulkAction({
 			actionName: "mute",
-			performAction: (ids, query) => bulkMuteNotifications(ids, query),
+			performAction: (ids, query, accountId) => bulkMuteNotifications(ids, query, accountId),
 			successToast: (count) => `Muted ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to mute notifications",
 		});
@@ -217,7 +235,7 @@ export function createBulkActionController(
 	async function bulkUnmute(): Promise<void> {
 		await handleBulkAction({
 			actionName: "unmute",
-			performAction: (ids, query) => bulkUnmuteNotifications(ids, query),
+			performAction: (ids, query, accountId) => bulkUnmuteNotifications(ids, query, accountId),
 			successToast: (count) => `Unmuted ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to unmute notifications",
 		});
@@ -226,7 +244,8 @@ export function createBulkActionController(
 	async function bulkSnooze(snoozedUntil: string): Promise<void> {
 		await handleBulkAction({
 			actionName: "snooze",
-			performAction: (ids, query) => bulkSnoozeNotifications(ids, snoozedUntil, query),
+			performAction: (ids, query, accountId) =>
+				bulkSnoozeNotifications(ids, snoozedUntil, query, accountId),
 			successToast: (count) => `Snoozed ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to snooze notifications",
 		});
@@ -235,7 +254,7 @@ export function createBulkActionController(
 	async function bulkUnsnooze(): Promise<void> {
 		await handleBulkAction({
 			actionName: "unsnooze",
-			performAction: (ids, query) => bulkUnsnoozeNotifications(ids, query),
+			performAction: (ids, query, accountId) => bulkUnsnoozeNotifications(ids, query, accountId),
 			successToast: (count) => `Unsnoozed ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to unsnooze notifications",
 		});
@@ -244,7 +263,7 @@ export function createBulkActionController(
 	async function bulkStar(): Promise<void> {
 		await handleBulkAction({
 			actionName: "star",
-			performAction: (ids, query) => bulkStarNotifications(ids, query),
+			performAction: (ids, query, accountId) => bulkStarNotifications(ids, query, accountId),
 			successToast: (count) => `Starred ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to star notifications",
 		});
@@ -253,7 +272,7 @@ export function createBulkActionController(
 	async function bulkUnstar(): Promise<void> {
 		await handleBulkAction({
 			actionName: "unstar",
-			performAction: (ids, query) => bulkUnstarNotifications(ids, query),
+			performAction: (ids, query, accountId) => bulkUnstarNotifications(ids, query, accountId),
 			successToast: (count) => `Unstarred ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to unstar notifications",
 		});
@@ -262,7 +281,7 @@ export function createBulkActionController(
 	async function bulkUnfilter(): Promise<void> {
 		await handleBulkAction({
 			actionName: "unfilter",
-			performAction: (ids, query) => bulkUnfilterNotifications(ids, query),
+			performAction: (ids, query, accountId) => bulkUnfilterNotifications(ids, query, accountId),
 			successToast: (count) => `Moved ${count} notification${count === 1 ? "" : "s"} to inbox`,
 			errorToast: "Failed to move notifications to inbox",
 		});
@@ -271,7 +290,7 @@ export function createBulkActionController(
 	async function bulkAssignTag(tagId: string): Promise<void> {
 		await handleBulkAction({
 			actionName: "assignTag",
-			performAction: (ids, query) => apiBulkAssignTag(ids, tagId, query),
+			performAction: (ids, query, accountId) => apiBulkAssignTag(ids, tagId, query, accountId),
 			successToast: (count) => `Applied tag to ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to apply tag to notifications",
 		});
@@ -280,7 +299,7 @@ export function createBulkActionController(
 	async function bulkRemoveTag(tagId: string): Promise<void> {
 		await handleBulkAction({
 			actionName: "removeTag",
-			performAction: (ids, query) => apiBulkRemoveTag(ids, tagId, query),
+			performAction: (ids, query, accountId) => apiBulkRemoveTag(ids, tagId, query, accountId),
 			successToast: (count) => `Removed tag from ${count} notification${count === 1 ? "" : "s"}`,
 			errorToast: "Failed to remove tag from notifications",
 		});
@@ -24,6 +24,7 @@ import type { NotificationStore } from "../../stores/notificationStore";
 import type { PaginationStore } from "../../stores/paginationStore";
 import type { UIStore } from "../../stores/uiStateStore";
 import type { SharedHelpers } from "./sharedHelpers";
+import { notificationKey } from "../notificationsPage";
 
 /**
  * Detail Action Controller
@@ -60,13 +61,13 @@ export function createDetailActionController(
 	}
 
 	async function handleOpenInlineDetail(notification: Notification): Promise<void> {
-		const notificationId = notification.githubId ?? notification.id;
+		const notificationId = notificationKey(notification);
 		const isSplitModeMode = get(uiStore.splitModeEnabled);
 
 		// Find the index of this notification in the current page
 		const pageData = get(notificationStore.pageData);
 		const items = pageData.items;
-		const notificationIndex = items.findIndex((n) => (n.githubId ?? n.id) === notificationId);
+		const notificationIndex = items.findIndex((n) => notificationKey(n) === notificationId);
 
 		// Set keyboard focus index (but don't trigger DOM focus - the browser handles that for clicks)
 		if (notificationIndex !== -1) {
@@ -143,7 +144,7 @@ export function createDetailActionController(
 				const notifAtOriginalIndex = items[originalIndex];
 				if (
 					notifAtOriginalIndex &&
-					(notifAtOriginalIndex.githubId ?? notifAtOriginalIndex.id) === closingNotificationId
+					notificationKey(notifAtOriginalIndex) === closingNotificationId
 				) {
 					notificationIndex = originalIndex;
 				}
@@ -151,7 +152,7 @@ export function createDetailActionController(
 
 			// If not at original index, search for it (list may have been reordered)
 			if (notificationIndex === -1) {
-				notificationIndex = items.findIndex((n) => (n.githubId ?? n.id) === closingNotificationId);
+				notificationIndex = items.findIndex((n) => notificationKey(n) === closingNotificationId);
 			}
 
 			if (notificationIndex !== -1) {
@@ -202,7 +203,7 @@ export function createDetailActionController(
 
 		// If detail is open, use that to determine current position
 		if (currentDetailId && currentIndex === -1) {
-			currentIndex = items.findIndex((n) => (n.githubId ?? n.id) === currentDetailId);
+			currentIndex = items.findIndex((n) => notificationKey(n) === currentDetailId);
 		}
 
 		// If we can move within the page
@@ -248,7 +249,7 @@ export function createDetailActionController(
 
 		// If detail is open, use that to determine current position
 		if (currentDetailId && currentIndex === -1) {
-			currentIndex = items.findIndex((n) => (n.githubId ?? n.id) === currentDetailId);
+			currentIndex = items.findIndex((n) => notificationKey(n) === currentDetailId);
 		}
 
 		// If we can move within the page
@@ -18,6 +18,7 @@ import type { KeyboardShortcutActions } from "../interfaces/keyboardShortcutActi
 import type { NotificationActions } from "../interfaces/notificationActions";
 import type { KeyboardStore } from "../../stores/keyboardNavigationStore";
 import type { UIStore } from "../../stores/uiStateStore";
+import { notificationKey } from "../notificationsPage";
 
 /**
  * Keyboard Shortcut Action Controller
@@ -107,7 +108,7 @@ export function createKeyboardShortcutActionController(
 		if (!focused) {
 			return false;
 		}
-		const notificationId = focused.githubId ?? focused.id;
+		const notificationId = notificationKey(focused);
 		const currentState = get(uiStore.snoozeDropdownState);
 
 		// Toggle: if already open for this notification with keyboard-shortcut source, close it
@@ -127,7 +128,7 @@ export function createKeyboardShortcutActionController(
 		if (!focused) {
 			return false;
 		}
-		const notificationId = focused.githubId ?? focused.id;
+		const notificationId = notificationKey(focused);
 		const currentState = get(uiStore.tagDropdownState);
 
 		// Toggle: if already open for this notification with keyboard-shortcut source, close it
@@ -40,6 +40,7 @@ import type { Notification } from "$lib/api/types";
 const createMockNotification = (overrides: Partial<Notification> = {}): Notification => ({
 	id: "1",
 	githubId: "gh-1",
+	accountId: 1,
 	repoFullName: "owner/repo",
 	reason: "review_requested",
 	subjectTitle: "Test Notification",
@@ -104,7 +105,7 @@ describe("NotificationActionController", () => {
 				page: 1,
 				pageSize: 50,
 			}),
-			notificationsById: writable(new Map([["gh-1", mockNotification]])),
+			notificationsById: writable(new Map([["1:gh-1", mockNotification]])),
 			updateNotification: vi.fn(),
 			removeNotification: vi.fn(),
 			restoreNotification: vi.fn(),
@@ -190,7 +191,7 @@ describe("NotificationActionController", () => {
 
 			await controller.markRead(notification);
 
-			expect(markNotificationRead).toHaveBeenCalledWith("gh-1");
+			expect(markNotificationRead).toHaveBeenCalledWith("gh-1", 1);
 		});
 
 		it("marks read notification as unread", async () => {
@@ -200,7 +201,7 @@ describe("NotificationActionController", () => {
 
 			await controller.markRead(notification);
 
-			expect(markNotificationUnread).toHaveBeenCalledWith("gh-1");
+			expect(markNotificationUnread).toHaveBeenCalledWith("gh-1", 1);
 		});
 	});
 
@@ -212,7 +213,7 @@ describe("NotificationActionController", () => {
 
 			await controller.archive(notification);
 
-			expect(archiveNotification).toHaveBeenCalledWith("gh-1");
+			expect(archiveNotification).toHaveBeenCalledWith("gh-1", 1);
 			expect(toastStore.info).toHaveBeenCalledWith("Archived");
 		});
 
@@ -266,7 +267,7 @@ describe("NotificationActionController", () => {
 				page: 1,
 				pageSize: 50,
 			});
-			notificationStore.notificationsById.set(new Map([["gh-1", pageNotification]]));
+			notificationStore.notificationsById.set(new Map([["1:gh-1", pageNotification]]));
 			const updated = { ...pageNotification, isRead: true };
 			vi.mocked(markNotificationRead).mockResolvedValue(updated);
 
@@ -275,7 +276,7 @@ describe("NotificationActionController", () => {
 			await controller.markRead(passedNotification);
 
 			// Should use the one from store
-			expect(markNotificationRead).toHaveBeenCalledWith("gh-1");
+			expect(markNotificationRead).toHaveBeenCalledWith("gh-1", 1);
 		});
 	});
 
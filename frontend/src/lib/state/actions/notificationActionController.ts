@@ -39,6 +39,7 @@ import type { KeyboardStore } from "../../stores/keyboardNavigationStore";
 import type { ControllerOptions } from "../interfaces/common";
 import type { SharedHelpers } from "./sharedHelpers";
 import type { OptimisticUpdateHelpers } from "./optimisticUpdates";
+import { notificationKey } from "../notificationsPage";
 
 interface StoreCollection {
 	notificationStore: NotificationStore;
@@ -80,7 +81,7 @@ export function createNotificationActionController(
 			| "assignTag"
 			| "unassignTag"
 			| "unfilter";
-		performAction: (key: string) => Promise<Notification>;
+		performAction: (githubId: string, accountId?: number) => Promise<Notification>;
 		shouldRemoveFromSelection?: boolean;
 		successToast?: string | null;
 		errorToast?: string;
@@ -96,7 +97,7 @@ export function createNotificationActionController(
 			shouldCloseDetailInSingleMode = false,
 		} = params;
 
-		const key = notification.githubId ?? notification.id;
+		const key = notificationKey(notification);
 		if (!key) return;
 
 		try {
@@ -110,7 +111,7 @@ export function createNotificationActionController(
 
 			// Fallback to pageData if not in normalized store
 			if (!notificationFromStore) {
-				notificationFromStore = pageData.items.find((item) => (item.githubId ?? item.id) === key);
+				notificationFromStore = pageData.items.find((item) => notificationKey(item) === key);
 			}
 
 			// Use store notification if available, otherwise fall back to passed notification
@@ -118,7 +119,7 @@ export function createNotificationActionController(
 
 			// Check if notification is in current page
 			let currentIndex = pageData.items.findIndex(
-				(item) => (item.githubId && item.githubId === key) || item.id === currentNotification.id
+				(item) => notificationKey(item) === key || item.id === currentNotification.id
 			);
 
 			// If not found in current page, check if it's in the detail view
@@ -137,7 +138,7 @@ export function createNotificationActionController(
 
 			// Get action hints from the current notification (single source of truth)
 			// Prefer pageData item if it exists (most fresh), otherwise use store notification
-			const pageItem = pageData.items.find((item) => (item.githubId ?? item.id) === key);
+			const pageItem = pageData.items.find((item) => notificationKey(item) === key);
 
 			// Use page item if it has actionHints, otherwise use currentNotification
 			const notificationWithHints = pageItem?.actionHints ? pageItem : currentNotification;
@@ -146,8 +147,11 @@ export function createNotificationActionController(
 			// Simply check if the action is in the dismissedOn list
 			const willBeRemoved = dismissedOn.includes(actionName);
 
-			// Perform the actual API call using the key from the current notification
-			const updated = await performAction(key);
+			// Perform the actual API call using the thread and account of the current notification
+			const updated = await performAction(
+				currentNotification.githubId ?? currentNotification.id,
+				currentNotification.accountId
+			);
 
 			// Show success toast if provided
 			if (successToast) {
@@ -183,8 +187,10 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: isCurrentlyRead ? "markUnread" : "markRead",
-			performAction: (key) =>
-				isCurrentlyRead ? markNotificationUnread(key) : markNotificationRead(key),
+			performAction: (githubId, accountId) =>
+				isCurrentlyRead
+					? markNotificationUnread(githubId, accountId)
+					: markNotificationRead(githubId, accountId),
 			successToast: null,
 			errorToast: "Failed to toggle read status",
 			shouldCloseDetailInSingleMode: isCurrentlyRead,
@@ -197,8 +203,10 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: isCurrentlyArchived ? "unarchive" : "archive",
-			performAction: (key) =>
-				isCurrentlyArchived ? unarchiveNotification(key) : archiveNotification(key),
+			performAction: (githubId, accountId) =>
+				isCurrentlyArchived
+					? unarchiveNotification(githubId, accountId)
+					: archiveNotification(githubId, accountId),
 			shouldRemoveFromSelection: true,
 			successToast: isCurrentlyArchived ? "Unarchived" : "Archived",
 			errorToast: "Failed to toggle archive status",
@@ -209,7 +217,7 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: "mute",
-			performAction: (key) => muteNotification(key),
+			performAction: (githubId, accountId) => muteNotification(githubId, accountId),
 			successToast: "Muted",
 			errorToast: "Failed to mute notification",
 		});
@@ -219,7 +227,7 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: "unmute",
-			performAction: (key) => unmuteNotification(key),
+			performAction: (githubId, accountId) => unmuteNotification(githubId, accountId),
 			successToast: "Unmuted",
 			errorToast: "Failed to unmute notification",
 		});
@@ -229,8 +237,8 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: "snooze",
-			performAction: async (key) => {
-				await snoozeNotification(key, until);
+			performAction: async (githubId, accountId) => {
+				await snoozeNotification(githubId, until, accountId);
 				// Snooze API doesn't return updated notification, so return original
 				return notification;
 			},
@@ -243,7 +251,7 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: "unsnooze",
-			performAction: (key) => unsnoozeNotification(key),
+			performAction: (githubId, accountId) => unsnoozeNotification(githubId, accountId),
 			successToast: "Unsnoozed",
 			errorToast: "Failed to unsnooze notification",
 		});
@@ -253,7 +261,7 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: "star",
-			performAction: (key) => starNotification(key),
+			performAction: (githubId, accountId) => starNotification(githubId, accountId),
 			successToast: null,
 			errorToast: "Failed to star notification",
 		});
@@ -263,7 +271,7 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: "unstar",
-			performAction: (key) => unstarNotification(key),
+			performAction: (githubId, accountId) => unstarNotification(githubId, accountId),
 			successToast: null,
 			errorToast: "Failed to unstar notification",
 		});
@@ -273,17 +281,17 @@ export function createNotificationActionController(
 		await handleAction({
 			notification,
 			actionName: "unfilter",
-			performAction: (key) => unfilterNotification(key),
+			performAction: (githubId, accountId) => unfilterNotification(githubId, accountId),
 			successToast: "Moved to inbox",
 			errorToast: "Failed to move notification to inbox",
 		});
 	}
 
-	async function assignTag(githubId: string, tagIdOrName: string): Promise<void> {
+	async function assignTag(key: string, tagIdOrName: string): Promise<void> {
 		// Find the notification to operate on
 		const currentPageData = get(notificationStore.pageData);
 		const currentNotification = currentPageData.items.find(
-			(n) => (n.githubId && n.githubId === githubId) || n.id === githubId
+			(n) => notificationKey(n) === key || n.id === key
 		);
 
 		if (!currentNotification) {
@@ -297,12 +305,12 @@ export function createNotificationActionController(
 		await handleAction({
 			notification: currentNotification,
 			actionName: "assignTag",
-			performAction: async (key) => {
+			performAction: async (githubId, accountId) => {
 				if (isNumericId) {
-					return await assignTagToNotification(key, tagIdOrName);
+					return await assignTagToNotification(githubId, tagIdOrName, accountId);
 				} else {
 					return await import("$lib/api/tags").then((m) =>
-						m.assignTagToNotificationByName(key, tagIdOrName)
+						m.assignTagToNotificationByName(githubId, tagIdOrName, accountId)
 					);
 				}
 			},
@@ -311,11 +319,11 @@ export function createNotificationActionController(
 		});
 	}
 
-	async function removeTag(githubId: string, tagId: string): Promise<void> {
+	async function removeTag(key: string, tagId: string): Promise<void> {
 		// Find the notification to operate on
 		const currentPageData = get(notificationStore.pageData);
 		const currentNotification = currentPageData.items.find(
-			(n) => (n.githubId && n.githubId === githubId) || n.id === githubId
+			(n) => notificationKey(n) === key || n.id === key
 		);
 
 		if (!currentNotification) {
@@ -326,7 +334,7 @@ export function createNotificationActionController(
 		await handleAction({
 			notification: currentNotification,
 			actionName: "unassignTag",
-			performAction: (key) => removeTagFromNotification(key, tagId),
+			performAction: (githubId, accountId) => removeTagFromNotification(githubId, tagId, accountId),
 			successToast: null,
 			errorToast: "Failed to remove tag",
 		});
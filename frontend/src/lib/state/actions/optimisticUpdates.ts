@@ -24,6 +24,7 @@ import type { KeyboardStore } from "../../stores/keyboardNavigationStore";
 import type { UIStore } from "../../stores/uiStateStore";
 import type { ControllerOptions } from "../interfaces/common";
 import type { SharedHelpers } from "./sharedHelpers";
+import { notificationKey } from "../notificationsPage";
 
 /**
  * Type for optimistic update state snapshot (for rollback)
@@ -207,7 +208,7 @@ export function createOptimisticUpdateHelpers(
 		focusIndex: number | null,
 		shouldRemoveFromSelection: boolean
 	): OptimisticUpdateState {
-		const key = notification.githubId ?? notification.id;
+		const key = notificationKey(notification);
 		const wasViewingDismissedItem = detailId === key;
 		const wasSelected = get(selectionStore.selectedIds).has(key);
 
@@ -246,7 +247,7 @@ export function createOptimisticUpdateHelpers(
 			shouldRemoveFromSelection = false,
 		} = params;
 
-		const key = notification.githubId ?? notification.id;
+		const key = notificationKey(notification);
 		if (!key) return;
 
 		// Get the most up-to-date notification from store to ensure consistency
@@ -258,14 +259,14 @@ export function createOptimisticUpdateHelpers(
 
 		// Fallback to pageData if not in normalized store
 		if (!notificationFromStore) {
-			notificationFromStore = pageData.items.find((item) => (item.githubId ?? item.id) === key);
+			notificationFromStore = pageData.items.find((item) => notificationKey(item) === key);
 		}
 
 		// Use store notification if available, otherwise use passed notification
 		const currentNotification = notificationFromStore ?? notification;
 
 		// Get action hints from pageData item if available (most fresh)
-		const pageItem = pageData.items.find((item) => (item.githubId ?? item.id) === key);
+		const pageItem = pageData.items.find((item) => notificationKey(item) === key);
 		const notificationWithHints = pageItem?.actionHints ? pageItem : currentNotification;
 		const dismissedOn = notificationWithHints.actionHints?.dismissedOn ?? [];
 
@@ -347,8 +348,7 @@ export function createOptimisticUpdateHelpers(
 				if (wasViewingDismissedItem) {
 					if (isInSplitMode && actualTargetNotification) {
 						// Split mode: open the next focused item
-						const targetNotificationId =
-							actualTargetNotification.githubId ?? actualTargetNotification.id;
+						const targetNotificationId = notificationKey(actualTargetNotification);
 						if (targetNotificationId && nextItemInfo.targetIndex !== null) {
 							keyboardStore.focusAt(nextItemInfo.targetIndex);
 							detailStore.openDetail(targetNotificationId);
@@ -402,7 +402,7 @@ export function createOptimisticUpdateHelpers(
 							// If in split mode and viewing detail, open the last item
 							if (isInSplitMode && wasViewingDismissedItem) {
 								const lastItem = refreshedItems[lastIndex];
-								const lastItemId = lastItem.githubId ?? lastItem.id;
+								const lastItemId = notificationKey(lastItem);
 								if (lastItemId) {
 									detailStore.openDetail(lastItemId);
 									await sharedHelpers.updateUrlWithDetailId(lastItemId);
@@ -417,11 +417,10 @@ export function createOptimisticUpdateHelpers(
 
 						// If in split mode and we opened detail optimistically, ensure it's still open
 						if (isInSplitMode && wasViewingDismissedItem && actualTargetNotification) {
-							const targetNotificationId =
-								actualTargetNotification.githubId ?? actualTargetNotification.id;
+							const targetNotificationId = notificationKey(actualTargetNotification);
 							// Check if the target notification is still in the refreshed list
 							const stillInList = refreshedItems.some(
-								(item) => (item.githubId ?? item.id) === targetNotificationId
+								(item) => notificationKey(item) === targetNotificationId
 							);
 							if (stillInList && targetNotificationId) {
 								// Re-open detail to ensure it's synced
@@ -449,7 +448,7 @@ export function createOptimisticUpdateHelpers(
 					// Close BEFORE refresh to avoid showing "Loading..." flash
 					if (shouldCloseDetailInSingleMode && !isInSplitMode) {
 						const currentDetailId = get(detailStore.detailNotificationId);
-						const currentKey = currentNotification.githubId ?? currentNotification.id;
+						const currentKey = notificationKey(currentNotification);
 						if (currentDetailId === currentKey) {
 							// Close detail immediately (optimistic updates don't need focus restoration)
 							detailStore.closeDetail();
@@ -15,6 +15,7 @@
 
 import type { Notification } from "$lib/api/types";
 import type { SelectionStore } from "../../stores/selectionStore";
+import { notificationKey } from "../notificationsPage";
 
 /**
  * Selection Action Controller
@@ -25,7 +26,7 @@ export function createSelectionActionController(
 	selectionStore: SelectionStore
 ): (notification: Notification) => void {
 	function toggleSelection(notification: Notification): void {
-		const key = notification.githubId ?? notification.id;
+		const key = notificationKey(notification);
 		if (key) {
 			selectionStore.toggleSelection(key);
 		}
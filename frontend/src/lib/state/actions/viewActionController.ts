@@ -38,6 +38,7 @@ import type { ViewStore } from "../../stores/viewStore";
 import type { ControllerOptions } from "../interfaces/common";
 import type { SharedHelpers } from "./sharedHelpers";
 import type { DebounceManager } from "./debounceManager";
+import { notificationKey } from "../notificationsPage";
 
 interface StoreCollection {
 	notificationStore: NotificationStore;
@@ -213,20 +214,18 @@ export function createViewActionController(
 			currentFocusIndex < currentPageData.items.length
 				? currentPageData.items[currentFocusIndex]
 				: null;
-		const focusedNotificationId = focusedNotification
-			? (focusedNotification.githubId ?? focusedNotification.id)
-			: null;
+		const focusedNotificationId = focusedNotification ? notificationKey(focusedNotification) : null;
 
 		const detailNotification =
 			currentDetailId && currentPageData.items.length > 0
-				? currentPageData.items.find((n) => (n.githubId ?? n.id) === currentDetailId)
+				? currentPageData.items.find((n) => notificationKey(n) === currentDetailId)
 				: null;
 		const capturedDetailNotificationId = detailNotification
-			? (detailNotification.githubId ?? detailNotification.id)
+			? notificationKey(detailNotification)
 			: null;
 
 		// Capture current notification IDs to detect new ones
-		const currentNotificationIds = new Set(currentPageData.items.map((n) => n.githubId ?? n.id));
+		const currentNotificationIds = new Set(currentPageData.items.map((n) => notificationKey(n)));
 
 		// Refresh notifications
 		await sharedHelpers.refresh();
@@ -235,7 +234,7 @@ export function createViewActionController(
 		const refreshedItems = get(notificationStore.pageData).items;
 		const refreshedFocusIndex =
 			focusedNotificationId !== null
-				? refreshedItems.findIndex((n) => (n.githubId ?? n.id) === focusedNotificationId)
+				? refreshedItems.findIndex((n) => notificationKey(n) === focusedNotificationId)
 				: -1;
 
 		// Restore keyboard focus if the notification is still on the page
@@ -248,7 +247,7 @@ export function createViewActionController(
 		// In split mode, preserve the detail notification even if its list card is pushed off
 		if (isSplitMode && capturedDetailNotificationId !== null) {
 			const detailStillOnPage = refreshedItems.some(
-				(n) => (n.githubId ?? n.id) === capturedDetailNotificationId
+				(n) => notificationKey(n) === capturedDetailNotificationId
 			);
 			// If detail is still on page, it will remain open automatically
 			// If not, detail view will show stale data (acceptable)
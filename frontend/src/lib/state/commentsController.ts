@@ -33,8 +33,8 @@ export interface CommentsControllerStores {
 }
 
 export interface CommentsControllerActions {
-	loadComments: (githubId: string, perPage: number) => Promise<void>;
-	loadMoreComments: (githubId: string, perPage: number) => Promise<void>;
+	loadComments: (githubId: string, perPage: number, accountId?: number) => Promise<void>;
+	loadMoreComments: (githubId: string, perPage: number, accountId?: number) => Promise<void>;
 	reset: () => void;
 }
 
@@ -59,7 +59,11 @@ export function createCommentsController(): CommentsController {
 
 	let currentGithubId: string | null = null;
 
-	async function loadComments(githubId: string, perPage: number = 5): Promise<void> {
+	async function loadComments(
+		githubId: string,
+		perPage: number = 5,
+		accountId?: number
+	): Promise<void> {
 		currentGithubId = githubId;
 		isLoading.set(true);
 		error.set(null);
@@ -68,7 +72,8 @@ export function createCommentsController(): CommentsController {
 			const response: NotificationTimelineResponse = await fetchNotificationTimeline(
 				githubId,
 				perPage,
-				1
+				1,
+				accountId
 			);
 
 			// Reverse items to show oldest first (matching GitHub)
@@ -89,7 +94,11 @@ export function createCommentsController(): CommentsController {
 		}
 	}
 
-	async function loadMoreComments(githubId: string, perPage: number = 5): Promise<void> {
+	async function loadMoreComments(
+		githubId: string,
+		perPage: number = 5,
+		accountId?: number
+	): Promise<void> {
 		const currentPagination = get(pagination);
 		const nextPage = currentPagination.page + 1;
 
@@ -100,7 +109,8 @@ export function createCommentsController(): CommentsController {
 			const response: NotificationTimelineResponse = await fetchNotificationTimeline(
 				githubId,
 				perPage,
-				nextPage
+				nextPage,
+				accountId
 			);
 
 			// Reverse new items to show oldest first
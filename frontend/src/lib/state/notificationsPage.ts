@@ -63,6 +63,30 @@ export function applyNotificationUpdatesToPage(
 	return updates.reduce((next, current) => applyNotificationUpdateToPage(next, current), page);
 }
 
-export function selectKey(notification: Notification): string | null {
-	return notification.githubId ?? notification.id ?? null;
+/**
+ * Key of a notification in the stores, the selection and the ?id= URL param. Thread IDs
+ * are only unique within a GitHub account, so the key is `${accountId}:${githubId}`.
+ */
+export function notificationKey(
+	notification: Pick<Notification, "id" | "githubId" | "accountId">
+): string {
+	const githubId = notification.githubId ?? notification.id;
+	return notification.accountId !== undefined
+		? `${notification.accountId}:${githubId}`
+		: githubId;
+}
+
+/**
+ * Splits a notification key back into the thread ID the API takes and its account
+ */
+export function parseNotificationKey(key: string): { githubId: string; accountId?: number } {
+	const separator = key.indexOf(":");
+	if (separator <= 0) {
+		return { githubId: key };
+	}
+	const accountId = Number(key.slice(0, separator));
+	if (!Number.isInteger(accountId)) {
+		return { githubId: key };
+	}
+	return { githubId: key.slice(separator + 1), accountId };
 }
@@ -34,9 +34,9 @@ export interface TimelineControllerStores {
 }
 
 export interface TimelineControllerActions {
-	autoLoadTimeline: (githubId: string, perPage: number) => Promise<void>;
-	loadTimeline: (githubId: string, perPage: number) => Promise<void>;
-	loadMoreTimeline: (githubId: string, perPage: number) => Promise<void>;
+	autoLoadTimeline: (githubId: string, perPage: number, accountId?: number) => Promise<void>;
+	loadTimeline: (githubId: string, perPage: number, accountId?: number) => Promise<void>;
+	loadMoreTimeline: (githubId: string, perPage: number, accountId?: number) => Promise<void>;
 	reset: () => void;
 }
 
@@ -62,7 +62,11 @@ export function createTimelineController(): TimelineController {
 
 	let currentGithubId: string | null = null;
 
-	async function autoLoadTimeline(githubId: string, perPage: number = 10): Promise<void> {
+	async function autoLoadTimeline(
+		githubId: string,
+		perPage: number = 10,
+		accountId?: number
+	): Promise<void> {
 		// Mark that we've attempted auto-load
 		hasAttemptedAutoLoad.set(true);
 
@@ -74,7 +78,8 @@ export function createTimelineController(): TimelineController {
 			const response: NotificationTimelineResponse = await fetchNotificationTimeline(
 				githubId,
 				perPage,
-				1
+				1,
+				accountId
 			);
 
 			// Abort if githubId changed while loading (detail was closed/opened)
@@ -106,7 +111,11 @@ export function createTimelineController(): TimelineController {
 		}
 	}
 
-	async function loadTimeline(githubId: string, perPage: number = 10): Promise<void> {
+	async function loadTimeline(
+		githubId: string,
+		perPage: number = 10,
+		accountId?: number
+	): Promise<void> {
 		hasAttemptedAutoLoad.set(true);
 		currentGithubId = githubId;
 		isLoading.set(true);
@@ -116,7 +125,8 @@ export function createTimelineController(): TimelineController {
 			const response: NotificationTimelineResponse = await fetchNotificationTimeline(
 				githubId,
 				perPage,
-				1
+				1,
+				accountId
 			);
 
 			// Abort if githubId changed while loading (detail was closed/opened)
@@ -148,7 +158,11 @@ export function createTimelineController(): TimelineController {
 		}
 	}
 
-	async function loadMoreTimeline(githubId: string, perPage: number = 10): Promise<void> {
+	async function loadMoreTimeline(
+		githubId: string,
+		perPage: number = 10,
+		accountId?: number
+	): Promise<void> {
 		const currentPagination = get(pagination);
 		const nextPage = currentPagination.page + 1;
 
@@ -160,7 +174,8 @@ export function createTimelineController(): TimelineController {
 			const response: NotificationTimelineResponse = await fetchNotificationTimeline(
 				githubId,
 				perPage,
-				nextPage
+				nextPage,
+				accountId
 			);
 
 			// Abort if githubId changed while loading (detail was closed/opened)
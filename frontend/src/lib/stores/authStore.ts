@@ -25,6 +25,7 @@ import {
 	getCurrentUser,
 	type SyncSettings,
 } from "$lib/api/user";
+import { setGitHubEndpoints } from "$lib/utils/githubUrls";
 
 const AUTH_TOKEN_KEY = "auth_token";
 const AUTH_USERNAME_KEY = "auth_username";
@@ -203,6 +204,7 @@ export async function login(username: string, password: string): Promise<void> {
 // Fetch user info including sync settings
 export async function fetchUserInfo(): Promise<void> {
 	const user = await getCurrentUser();
+	setGitHubEndpoints(user.githubApiUrl, user.githubWebUrl);
 	if (user.syncSettings) {
 		syncSettingsStore.set(user.syncSettings);
 	} else {
@@ -16,6 +16,7 @@
 import { writable, derived } from "svelte/store";
 import type { Writable, Readable } from "svelte/store";
 import type { Notification, NotificationDetail } from "$lib/api/types";
+import { notificationKey } from "$lib/state/notificationsPage";
 import type { NotificationStore } from "./notificationStore";
 
 /**
@@ -53,9 +54,7 @@ export function createDetailViewStore(notificationStore: NotificationStore) {
 		[detailNotificationId, notificationStore.pageData],
 		([$detailNotificationId, $pageData]) => {
 			if (!$detailNotificationId) return null;
-			const index = $pageData.items.findIndex(
-				(n) => (n.githubId ?? n.id) === $detailNotificationId
-			);
+			const index = $pageData.items.findIndex((n) => notificationKey(n) === $detailNotificationId);
 			return index !== -1 ? index : null;
 		}
 	);
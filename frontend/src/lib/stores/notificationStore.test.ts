@@ -79,6 +79,18 @@ describe("NotificationStore", () => {
 
 			expect(get(store.notificationsById).get("1")).toBeDefined();
 		});
+
+		it("keys notifications by account so shared thread ids don't collide", () => {
+			const notifications = [
+				createMockNotification({ id: "1", githubId: "gh-1", accountId: 1 }),
+				createMockNotification({ id: "2", githubId: "gh-1", accountId: 2 }),
+			];
+			const store = createNotificationStore(createMockPageData(notifications, { total: 2 }));
+
+			expect(get(store.notificationsById).size).toBe(2);
+			expect(get(store.notificationsById).get("1:gh-1")?.id).toBe("1");
+			expect(get(store.notificationsById).get("2:gh-1")?.id).toBe("2");
+		});
 	});
 
 	describe("getNotification", () => {
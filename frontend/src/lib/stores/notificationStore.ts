@@ -16,14 +16,18 @@
 import { writable, get } from "svelte/store";
 import type { Writable } from "svelte/store";
 import type { Notification, NotificationPage } from "$lib/api/types";
-import { applyNotificationUpdateToPage, updatePageItem } from "$lib/state/notificationsPage";
+import {
+	applyNotificationUpdateToPage,
+	notificationKey,
+	updatePageItem,
+} from "$lib/state/notificationsPage";
 
 /**
  * Notification Store
  * Single source of truth for notification data with normalized storage
  */
 export function createNotificationStore(initialPage: NotificationPage) {
-	// Normalized storage - all notifications by ID
+	// Normalized storage - all notifications by notificationKey
 	const notificationsById = writable<Map<string, Notification>>(new Map());
 
 	// Current page data (references normalized store)
@@ -32,7 +36,7 @@ export function createNotificationStore(initialPage: NotificationPage) {
 	// Initialize normalized store with initial page data
 	const initialMap = new Map<string, Notification>();
 	for (const item of initialPage.items) {
-		const key = item.githubId ?? item.id;
+		const key = notificationKey(item);
 		if (key) {
 			initialMap.set(key, item);
 		}
@@ -44,7 +48,7 @@ export function createNotificationStore(initialPage: NotificationPage) {
 	 * Preserves optimistic read status to prevent refresh from overriding it
 	 */
 	function updateNotification(updated: Notification): void {
-		const key = updated.githubId ?? updated.id;
+		const key = notificationKey(updated);
 		if (!key) return;
 
 		// Check if we have an existing notification with optimistic read status
@@ -75,7 +79,7 @@ export function createNotificationStore(initialPage: NotificationPage) {
 		// Update normalized store with all items from new page
 		notificationsById.update((map) => {
 			for (const item of newPageData.items) {
-				const key = item.githubId ?? item.id;
+				const key = notificationKey(item);
 				if (key) {
 					map.set(key, item);
 				}
@@ -98,7 +102,7 @@ export function createNotificationStore(initialPage: NotificationPage) {
 	/**
 	 * Remove a notification optimistically from both normalized store and pageData
 	 * Returns the removed notification for potential rollback, or null if not found
-	 * @param id - The notification key (githubId or id)
+	 * @param id - The notificationKey of the notification
 	 */
 	function removeNotification(id: string): Notification | null {
 		const currentMap = get(notificationsById);
@@ -116,14 +120,14 @@ export function createNotificationStore(initialPage: NotificationPage) {
 		});
 
 		// Remove from pageData by filtering out the matching item
-		// Match by both githubId and id to handle all cases
+		// Match by both key and id to handle all cases
 		pageData.update((data) => {
 			const nextItems: Notification[] = [];
 			let removed = false;
 
 			for (const item of data.items) {
-				const itemKey = item.githubId ?? item.id;
-				// Match by key (githubId or id) or by explicit id match
+				const itemKey = notificationKey(item);
+				// Match by notificationKey or by explicit id match
 				if (itemKey === id || item.id === notification.id) {
 					removed = true;
 					// Skip this item (don't add to nextItems)
@@ -152,7 +156,7 @@ export function createNotificationStore(initialPage: NotificationPage) {
 	 * Used for error rollback
 	 */
 	function restoreNotification(notification: Notification): void {
-		const key = notification.githubId ?? notification.id;
+		const key = notificationKey(notification);
 		if (!key) return;
 
 		// Restore to normalized store
@@ -165,7 +169,7 @@ export function createNotificationStore(initialPage: NotificationPage) {
 		// Restore to pageData - need to restore the item and increment total
 		pageData.update((data) => {
 			// Check if notification is already in items
-			const existsInItems = data.items.some((item) => (item.githubId ?? item.id) === key);
+			const existsInItems = data.items.some((item) => notificationKey(item) === key);
 
 			if (existsInItems) {
 				// Already exists, just update it
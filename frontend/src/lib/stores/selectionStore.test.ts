@@ -137,6 +137,20 @@ describe("SelectionStore", () => {
 			expect(get(selectionStore.selectedIds).has("gh-3")).toBe(true);
 			expect(get(selectionStore.selectAllMode)).toBe("page");
 		});
+
+		it("selects notifications from different accounts that share a thread id", () => {
+			const notifications = [
+				createMockNotification({ id: "1", githubId: "gh-1", accountId: 1 }),
+				createMockNotification({ id: "2", githubId: "gh-1", accountId: 2 }),
+			];
+			const { selectionStore } = createStores(notifications);
+
+			selectionStore.selectAllPage();
+
+			expect(get(selectionStore.selectedIds).size).toBe(2);
+			expect(get(selectionStore.selectedIds).has("1:gh-1")).toBe(true);
+			expect(get(selectionStore.selectedIds).has("2:gh-1")).toBe(true);
+		});
 	});
 
 	describe("selectAllAcrossPages", () => {
@@ -16,6 +16,7 @@
 import { writable, derived, get } from "svelte/store";
 import type { Writable, Readable } from "svelte/store";
 import type { SelectAllMode } from "$lib/state/types";
+import { notificationKey } from "$lib/state/notificationsPage";
 import type { NotificationStore } from "./notificationStore";
 
 /**
@@ -36,7 +37,7 @@ export function createSelectionStore(notificationStore: NotificationStore) {
 
 			if ($selectAllMode === "all") {
 				for (const item of $pageData.items) {
-					const key = item.githubId ?? item.id;
+					const key = notificationKey(item);
 					if (key) {
 						map.set(key, true);
 					}
@@ -104,7 +105,7 @@ export function createSelectionStore(notificationStore: NotificationStore) {
 			const newIds = new Set<string>();
 
 			for (const item of pageData.items) {
-				const key = item.githubId ?? item.id;
+				const key = notificationKey(item);
 				if (key) {
 					newIds.add(key);
 				}
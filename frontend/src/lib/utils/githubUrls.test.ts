@@ -0,0 +1,57 @@
+// Copyright (C) 2025 Austin Beattie
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+import { describe, it, expect, afterEach } from "vitest";
+import {
+	constructGitHubHtmlUrl,
+	getGitHubWebUrl,
+	isGitHubApiUrl,
+	setGitHubEndpoints,
+} from "./githubUrls";
+
+describe("constructGitHubHtmlUrl", () => {
+	afterEach(() => {
+		setGitHubEndpoints(null, null);
+	});
+
+	it("builds github.com links by default", () => {
+		expect(
+			constructGitHubHtmlUrl(
+				"owner/repo",
+				"Issue",
+				"https://api.github.com/repos/owner/repo/issues/1"
+			)
+		).toBe("https://github.com/owner/repo/issues/1");
+		expect(isGitHubApiUrl("https://api.github.com/repos/owner/repo")).toBe(true);
+	});
+
+	it("builds GitHub Enterprise Server links once configured", () => {
+		setGitHubEndpoints("https://github.example.com/api/v3", "https://github.example.com");
+
+		expect(getGitHubWebUrl()).toBe("https://github.example.com");
+		expect(
+			constructGitHubHtmlUrl(
+				"corp/app",
+				"PullRequest",
+				"https://github.example.com/api/v3/repos/corp/app/pulls/7"
+			)
+		).toBe("https://github.example.com/corp/app/pull/7");
+		expect(constructGitHubHtmlUrl("corp/app", "CheckSuite")).toBe(
+			"https://github.example.com/corp/app/actions"
+		);
+		expect(isGitHubApiUrl("https://github.example.com/api/v3/repos/corp/app")).toBe(true);
+		expect(isGitHubApiUrl("https://github.example.com/corp/app")).toBe(false);
+	});
+});
@@ -18,6 +18,35 @@
  * when html_url is not available in the subject data.
  */
 
+const DEFAULT_GITHUB_API_URL = "https://api.github.com";
+const DEFAULT_GITHUB_WEB_URL = "https://github.com";
+
+let githubApiUrl = DEFAULT_GITHUB_API_URL;
+let githubWebUrl = DEFAULT_GITHUB_WEB_URL;
+
+/**
+ * Sets the GitHub instance that links point at, as reported by the backend.
+ * Needed for GitHub Enterprise Server; empty values mean github.com.
+ */
+export function setGitHubEndpoints(apiUrl?: string | null, webUrl?: string | null): void {
+	githubApiUrl = apiUrl || DEFAULT_GITHUB_API_URL;
+	githubWebUrl = webUrl || DEFAULT_GITHUB_WEB_URL;
+}
+
+/**
+ * Returns the base URL of the GitHub web interface (no trailing slash).
+ */
+export function getGitHubWebUrl(): string {
+	return githubWebUrl;
+}
+
+/**
+ * Reports whether a URL points at the GitHub API rather than the web interface.
+ */
+export function isGitHubApiUrl(url: string): boolean {
+	return url.startsWith(githubApiUrl);
+}
+
 /**
  * Constructs a GitHub HTML URL from available notification data.
  *
@@ -51,13 +80,13 @@ export function constructGitHubHtmlUrl(
 	// If we have a number, construct URL for Issue or PullRequest
 	if (number !== undefined) {
 		if (normalizedType === "pullrequest" || normalizedType === "pull_request") {
-			return `https://github.com/${repoFullName}/pull/${number}`;
+			return `${githubWebUrl}/${repoFullName}/pull/${number}`;
 		}
 		if (normalizedType === "issue") {
-			return `https://github.com/${repoFullName}/issues/${number}`;
+			return `${githubWebUrl}/${repoFullName}/issues/${number}`;
 		}
 		if (normalizedType === "discussion") {
-			return `https://github.com/${repoFullName}/discussions/${number}`;
+			return `${githubWebUrl}/${repoFullName}/discussions/${number}`;
 		}
 	}
 
@@ -74,7 +103,7 @@ export function constructGitHubHtmlUrl(
 		normalizedType === "checksuite" ||
 		normalizedType === "check_suite"
 	) {
-		return `https://github.com/${repoFullName}/actions`;
+		return `${githubWebUrl}/${repoFullName}/actions`;
 	}
 
 	// Vulnerability alerts -> security/dependabot page
@@ -82,7 +111,7 @@ export function constructGitHubHtmlUrl(
 		normalizedType === "repositoryvulnerabilityalert" ||
 		normalizedType === "repository_vulnerability_alert"
 	) {
-		return `https://github.com/${repoFullName}/security/dependabot`;
+		return `${githubWebUrl}/${repoFullName}/security/dependabot`;
 	}
 
 	return null;
@@ -96,19 +125,19 @@ function extractFromApiUrl(apiUrl: string, repoFullName: string): string | null
 		// Pattern: https://api.github.com/repos/{owner}/{repo}/issues/{number}
 		const issueMatch = apiUrl.match(/\/repos\/[^\/]+\/[^\/]+\/issues\/(\d+)/);
 		if (issueMatch) {
-			return `https://github.com/${repoFullName}/issues/${issueMatch[1]}`;
+			return `${githubWebUrl}/${repoFullName}/issues/${issueMatch[1]}`;
 		}
 
 		// Pattern: https://api.github.com/repos/{owner}/{repo}/pulls/{number}
 		const pullMatch = apiUrl.match(/\/repos\/[^\/]+\/[^\/]+\/pulls\/(\d+)/);
 		if (pullMatch) {
-			return `https://github.com/${repoFullName}/pull/${pullMatch[1]}`;
+			return `${githubWebUrl}/${repoFullName}/pull/${pullMatch[1]}`;
 		}
 
 		// Pattern: https://api.github.com/repos/{owner}/{repo}/commits/{sha}
 		const commitMatch = apiUrl.match(/\/repos\/[^\/]+\/[^\/]+\/commits\/([a-f0-9]+)/);
 		if (commitMatch) {
-			return `https://github.com/${repoFullName}/commit/${commitMatch[1]}`;
+			return `${githubWebUrl}/${repoFullName}/commit/${commitMatch[1]}`;
 		}
 
 		// Pattern: https://api.github.com/repos/{owner}/{repo}/releases/{id}
@@ -117,13 +146,13 @@ function extractFromApiUrl(apiUrl: string, repoFullName: string): string | null
 			// For releases, we need the tag name, not the ID
 			// This is a limitation - we'd need to fetch the release to get the tag
 			// For now, just link to releases page
-			return `https://github.com/${repoFullName}/releases`;
+			return `${githubWebUrl}/${repoFullName}/releases`;
 		}
 
 		// Pattern: https://api.github.com/repos/{owner}/{repo}/discussions/{number}
 		const discussionMatch = apiUrl.match(/\/repos\/[^\/]+\/[^\/]+\/discussions\/(\d+)/);
 		if (discussionMatch) {
-			return `https://github.com/${repoFullName}/discussions/${discussionMatch[1]}`;
+			return `${githubWebUrl}/${repoFullName}/discussions/${discussionMatch[1]}`;
 		}
 	} catch (error) {
 		return null;
@@ -144,7 +173,7 @@ function constructFromApiUrlPattern(
 	if (subjectType === "commit") {
 		const shaMatch = apiUrl.match(/\/commits\/([a-f0-9]{40}|[a-f0-9]{7,})/);
 		if (shaMatch) {
-			return `https://github.com/${repoFullName}/commit/${shaMatch[1]}`;
+			return `${githubWebUrl}/${repoFullName}/commit/${shaMatch[1]}`;
 		}
 	}
 
@@ -153,10 +182,10 @@ function constructFromApiUrlPattern(
 		// Try to extract tag from API URL
 		const tagMatch = apiUrl.match(/\/releases\/tags\/([^\/]+)/);
 		if (tagMatch) {
-			return `https://github.com/${repoFullName}/releases/tag/${tagMatch[1]}`;
+			return `${githubWebUrl}/${repoFullName}/releases/tag/${tagMatch[1]}`;
 		}
 		// Fallback to releases page
-		return `https://github.com/${repoFullName}/releases`;
+		return `${githubWebUrl}/${repoFullName}/releases`;
 	}
 
 	// For vulnerability alerts
@@ -164,7 +193,7 @@ function constructFromApiUrlPattern(
 		subjectType === "repositoryvulnerabilityalert" ||
 		subjectType === "repository_vulnerability_alert"
 	) {
-		return `https://github.com/${repoFullName}/security/dependabot`;
+		return `${githubWebUrl}/${repoFullName}/security/dependabot`;
 	}
 
 	// For check runs/suites
@@ -174,7 +203,7 @@ function constructFromApiUrlPattern(
 		subjectType === "checksuite" ||
 		subjectType === "check_suite"
 	) {
-		return `https://github.com/${repoFullName}/actions`;
+		return `${githubWebUrl}/${repoFullName}/actions`;
 	}
 
 	return null;